@@ -11,6 +11,7 @@ import (
 	"flag"
 	"fmt"
 	"html/template"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -23,13 +24,17 @@ import (
 	"github.com/unrolled/secure"
 	"golang.org/x/oauth2/google"
 
+	"go.skia.org/infra/bugs-central/go/config"
 	"go.skia.org/infra/bugs-central/go/db"
+	"go.skia.org/infra/bugs-central/go/notifier"
 	"go.skia.org/infra/bugs-central/go/poller"
 	"go.skia.org/infra/bugs-central/go/types"
+	"go.skia.org/infra/email/go/emailclient"
 	"go.skia.org/infra/go/alogin"
 	"go.skia.org/infra/go/alogin/proxylogin"
 	"go.skia.org/infra/go/auth"
 	"go.skia.org/infra/go/baseapp"
+	"go.skia.org/infra/go/chatbot"
 	"go.skia.org/infra/go/cleanup"
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/roles"
@@ -45,6 +50,7 @@ var (
 	fsProjectID        = flag.String("fs_project_id", "skia-firestore", "The project with the firestore instance. Datastore and Firestore can't be in the same project.")
 	serviceAccountFile = flag.String("service_account_file", "/var/secrets/google/key.json", "Service account JSON file.")
 	pollInterval       = flag.Duration("poll_interval", 2*time.Hour, "How often the server will poll the different issue frameworks for open issues.")
+	configFile         = flag.String("config_file", "prod.json", "The name of the config file to use. Must be present in bugs-central/go/config.")
 
 	// Cache of clients to charts data. Used for displaying charts in the UI.
 	clientsToChartsDataCache = map[string]map[string]*types.IssueCountsData{}
@@ -61,6 +67,17 @@ func New() (baseapp.App, error) {
 		sklog.Fatalf("Could not create %s: %s", *workdir, err)
 	}
 
+	// Parse the config file.
+	cfgContents, err := fs.ReadFile(config.Configs, *configFile)
+	if err != nil {
+		sklog.Fatalf("Could not read config file %s: %s", *configFile, err)
+	}
+	cfg, err := config.ParseCfg(cfgContents)
+	if err != nil {
+		sklog.Fatalf("Could not parse config file %s: %s", *configFile, err)
+	}
+	types.SetSLOs(cfg.GetSLOs())
+
 	ts, err := google.DefaultTokenSource(ctx, auth.ScopeUserinfoEmail, auth.ScopeFullControl, datastore.ScopeDatastore)
 	dbClient, err := db.New(ctx, ts, *fsNamespace, *fsProjectID)
 	if err != nil {
@@ -68,7 +85,7 @@ func New() (baseapp.App, error) {
 	}
 
 	// Instantiate poller and turn it on.
-	pollerClient, err := poller.New(ctx, ts, *serviceAccountFile, dbClient)
+	pollerClient, err := poller.New(ctx, ts, *serviceAccountFile, dbClient, cfg)
 	if err != nil {
 		sklog.Fatalf("Could not init poller: %s", err)
 	}
@@ -76,9 +93,16 @@ func New() (baseapp.App, error) {
 		sklog.Fatalf("Could not start poller: %s", err)
 	}
 
+	// Send the digests and nudges of the clients. Do not send them when running locally.
+	if !*baseapp.Local {
+		chatbot.Init("Bugs Central")
+		notifier.New(cfg, pollerClient.GetOpenIssues(), dbClient, emailclient.New(), chatbot.Send, *host).Start(ctx)
+	}
+
 	srv := &Server{
-		pollerClient: pollerClient,
-		dbClient:     dbClient,
+		pollerClient:  pollerClient,
+		dbClient:      dbClient,
+		statusClients: cfg.GetStatusClients(),
 	}
 	srv.loadTemplates()
 
@@ -98,6 +122,9 @@ type Server struct {
 	pollerClient *poller.IssuesPoller
 	dbClient     types.BugsDB
 	templates    *template.Template
+
+	// Clients whose counts are returned by the get_client_counts endpoint.
+	statusClients []types.RecognizedClient
 }
 
 func (srv *Server) loadTemplates() {
@@ -176,9 +203,8 @@ type GetClientCountsResponse struct {
 func (srv *Server) getClientCounts(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	clientsForStatus := []types.RecognizedClient{types.SkiaClient, types.AndroidClient, types.ChromiumClient, types.OSSFuzzClient}
 	clientsToStatusData := map[types.RecognizedClient]StatusData{}
-	for _, c := range clientsForStatus {
+	for _, c := range srv.statusClients {
 		countsData, err := srv.dbClient.GetCountsFromDB(r.Context(), c, "", "")
 		if err != nil {
 			httputils.ReportError(w, err, "Failed to query DB.", http.StatusInternalServerError)
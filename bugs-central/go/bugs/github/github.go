@@ -77,15 +77,15 @@ type githubFramework struct {
 // GithubQueryConfig is the config that will be used when querying github API.
 type GithubQueryConfig struct {
 	// Slice of labels to look for in Github issues.
-	Labels []string
+	Labels []string `json:"labels"`
 	// Slice of labels to exclude.
-	ExcludeLabels []string
+	ExcludeLabels []string `json:"exclude_labels"`
 	// Return only open issues.
-	Open bool
+	Open bool `json:"open"`
 	// If an issues has no priority label then do not include it in results.
-	PriorityRequired bool
+	PriorityRequired bool `json:"priority_required"`
 	// Which client's issues we are looking for.
-	Client types.RecognizedClient
+	Client types.RecognizedClient `json:"-"`
 }
 
 // New returns an instance of the github implementation of bugs.BugFramework.
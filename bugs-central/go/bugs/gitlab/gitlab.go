@@ -0,0 +1,292 @@
+package gitlab
+
+// Accesses the GitLab issues REST API (https://docs.gitlab.com/ee/api/issues.html).
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.skia.org/infra/bugs-central/go/bugs"
+	"go.skia.org/infra/bugs-central/go/types"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	// Maximum number of issues GitLab returns per page.
+	maxGitLabResultsPerPage = 100
+)
+
+type gitLabIssue struct {
+	IID         int       `json:"iid"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	State       string    `json:"state"`
+	Labels      []string  `json:"labels"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Assignee    *struct {
+		Username string `json:"username"`
+	} `json:"assignee"`
+}
+
+// gitLab implements bugs.BugFramework for GitLab projects.
+type gitLab struct {
+	httpClient  *http.Client
+	token       string
+	openIssues  *bugs.OpenIssues
+	queryConfig *GitLabQueryConfig
+}
+
+// GitLabQueryConfig is the config that will be used when querying GitLab API.
+type GitLabQueryConfig struct {
+	// Base URL of the GitLab instance. Eg: "https://gitlab.com".
+	Instance string `json:"instance"`
+	// Path to a file containing a GitLab access token with the read_api scope.
+	TokenFile string `json:"token_file"`
+	// Path of the project to query. Eg: "group/project".
+	Project string `json:"project"`
+	// Slice of labels to look for in GitLab issues.
+	Labels []string `json:"labels"`
+	// Slice of labels to exclude.
+	ExcludeLabels []string `json:"exclude_labels"`
+	// Which client's issues we are looking for.
+	Client types.RecognizedClient `json:"-"`
+	// Maps the GitLab priority labels (eg: "priority::1") into the standardized priorities.
+	PriorityMapping map[string]types.StandardizedPriority `json:"priority_mapping"`
+	// Issues are considered untriaged if they have any of these labels.
+	UntriagedLabels []string `json:"untriaged_labels"`
+	// Whether unassigned issues should be considered as untriaged.
+	UnassignedIsUntriaged bool `json:"unassigned_is_untriaged"`
+}
+
+// New returns an instance of the GitLab implementation of bugs.BugFramework.
+func New(openIssues *bugs.OpenIssues, queryConfig *GitLabQueryConfig) (bugs.BugFramework, error) {
+	tBody, err := os.ReadFile(queryConfig.TokenFile)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "could not find gitlab token in %s", queryConfig.TokenFile)
+	}
+	return &gitLab{
+		httpClient:  httputils.DefaultClientConfig().With2xxOnly().Client(),
+		token:       strings.TrimSpace(string(tBody)),
+		openIssues:  openIssues,
+		queryConfig: queryConfig,
+	}, nil
+}
+
+// searchIssuesWithPagination returns the open GitLab issues with the config's labels by
+// paginating till the end of results.
+func (g *gitLab) searchIssuesWithPagination(ctx context.Context) ([]gitLabIssue, error) {
+	qc := g.queryConfig
+	issues := []gitLabIssue{}
+	page := "1"
+	for page != "" {
+		params := url.Values{}
+		params.Set("state", "opened")
+		params.Set("per_page", strconv.Itoa(maxGitLabResultsPerPage))
+		params.Set("page", page)
+		if len(qc.Labels) > 0 {
+			params.Set("labels", strings.Join(qc.Labels, ","))
+		}
+		if len(qc.ExcludeLabels) > 0 {
+			params.Set("not[labels]", strings.Join(qc.ExcludeLabels, ","))
+		}
+		issuesURL := fmt.Sprintf("%s/api/v4/projects/%s/issues?%s", strings.TrimSuffix(qc.Instance, "/"), url.PathEscape(qc.Project), params.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuesURL, nil)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		req.Header.Set("PRIVATE-TOKEN", g.token)
+		resp, err := g.httpClient.Do(req)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "gitlab issues request failed")
+		}
+		var pageIssues []gitLabIssue
+		err = json.NewDecoder(resp.Body).Decode(&pageIssues)
+		util.Close(resp.Body)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "invalid JSON from gitlab issues")
+		}
+		issues = append(issues, pageIssues...)
+		// GitLab leaves X-Next-Page empty on the last page.
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return issues, nil
+}
+
+// See documentation for bugs.Search interface.
+func (g *gitLab) Search(ctx context.Context) ([]*types.Issue, *types.IssueCountsData, error) {
+	gitLabIssues, err := g.searchIssuesWithPagination(ctx)
+	if err != nil {
+		return nil, nil, skerr.Wrapf(err, "error when searching issues")
+	}
+
+	// Convert gitlab issues into bug_framework's generic issues
+	issues := []*types.Issue{}
+	countsData := &types.IssueCountsData{}
+	for _, gi := range gitLabIssues {
+		// GitLab does not return email addresses of users so use usernames instead.
+		owner := ""
+		if gi.Assignee != nil {
+			owner = gi.Assignee.Username
+		}
+		id := strconv.Itoa(gi.IID)
+
+		// Find priority. Use the highest priority if there are multiple priority labels attached.
+		priority := types.StandardizedPriority("")
+		for _, l := range gi.Labels {
+			if p, ok := g.queryConfig.PriorityMapping[l]; ok && (priority == "" || p < priority) {
+				priority = p
+			}
+		}
+
+		// Populate counts data.
+		countsData.OpenCount++
+		if owner == "" {
+			countsData.UnassignedCount++
+		}
+		countsData.IncPriority(priority)
+		sloViolation, reason, d := types.IsPrioritySLOViolation(time.Now(), gi.CreatedAt, gi.UpdatedAt, priority)
+		countsData.IncSLOViolation(sloViolation, priority)
+		hasUntriagedLabel := false
+		for _, l := range gi.Labels {
+			if util.In(l, g.queryConfig.UntriagedLabels) {
+				hasUntriagedLabel = true
+				break
+			}
+		}
+		if hasUntriagedLabel {
+			countsData.UntriagedCount++
+		} else if g.queryConfig.UnassignedIsUntriaged && owner == "" {
+			countsData.UntriagedCount++
+		}
+
+		issues = append(issues, &types.Issue{
+			Id:       id,
+			State:    gi.State,
+			Priority: priority,
+			Owner:    owner,
+			Link:     g.GetIssueLink("", id),
+
+			SLOViolation:         sloViolation,
+			SLOViolationReason:   reason,
+			SLOViolationDuration: d,
+
+			CreatedTime:  gi.CreatedAt,
+			ModifiedTime: gi.UpdatedAt,
+
+			Title:   gi.Title,
+			Summary: gi.Description,
+		})
+	}
+
+	return issues, countsData, nil
+}
+
+// projectURL returns the URL of the config's project.
+func (g *gitLab) projectURL() string {
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(g.queryConfig.Instance, "/"), g.queryConfig.Project)
+}
+
+// queryLink returns a link to the open issues of the config's query, further filtered by the
+// specified params.
+func (g *gitLab) queryLink(extraParams url.Values) string {
+	params := url.Values{}
+	params.Set("state", "opened")
+	for _, l := range g.queryConfig.Labels {
+		params.Add("label_name[]", l)
+	}
+	for _, l := range g.queryConfig.ExcludeLabels {
+		params.Add("not[label_name][]", l)
+	}
+	for k, vs := range extraParams {
+		params[k] = append(params[k], vs...)
+	}
+	return fmt.Sprintf("%s/-/issues?%s", g.projectURL(), params.Encode())
+}
+
+// priorityLink returns a link to the issues of the config's query with priority labels that map to
+// any of the specified standardized priorities. Returns "" if there are no such labels.
+func (g *gitLab) priorityLink(priorities ...types.StandardizedPriority) string {
+	wanted := map[types.StandardizedPriority]bool{}
+	for _, p := range priorities {
+		wanted[p] = true
+	}
+	labels := []string{}
+	for l, p := range g.queryConfig.PriorityMapping {
+		if wanted[p] {
+			labels = append(labels, l)
+		}
+	}
+	if len(labels) == 0 {
+		return ""
+	}
+	sort.Strings(labels)
+	return g.queryLink(url.Values{"or[label_name][]": labels})
+}
+
+// See documentation for bugs.SearchClientAndPersist interface.
+func (g *gitLab) SearchClientAndPersist(ctx context.Context, dbClient types.BugsDB, runId string) error {
+	qc := g.queryConfig
+	issues, countsData, err := g.Search(ctx)
+	if err != nil {
+		return skerr.Wrapf(err, "error when searching gitlab")
+	}
+	sklog.Infof("%s GitLab issues %+v", qc.Client, countsData)
+
+	// Construct the query description from the project, labels and exclude labels.
+	queryTokens := []string{qc.Project}
+	for _, l := range qc.Labels {
+		queryTokens = append(queryTokens, fmt.Sprintf("label:\"%s\"", l))
+	}
+	for _, l := range qc.ExcludeLabels {
+		queryTokens = append(queryTokens, fmt.Sprintf("-label:\"%s\"", l))
+	}
+	queryDesc := strings.Join(queryTokens, " ")
+
+	countsData.QueryLink = g.queryLink(nil)
+	// GitLab links cannot combine label and assignee filters with OR, so prefer the untriaged labels.
+	if len(qc.UntriagedLabels) > 0 {
+		countsData.UntriagedQueryLink = g.queryLink(url.Values{"or[label_name][]": qc.UntriagedLabels})
+	} else if qc.UnassignedIsUntriaged {
+		countsData.UntriagedQueryLink = g.queryLink(url.Values{"assignee_id": {"None"}})
+	} else {
+		countsData.UntriagedQueryLink = countsData.QueryLink
+	}
+	// Calculate priority links.
+	countsData.P0Link = g.priorityLink(types.PriorityP0)
+	countsData.P1Link = g.priorityLink(types.PriorityP1)
+	countsData.P2Link = g.priorityLink(types.PriorityP2)
+	countsData.P3AndRestLink = g.priorityLink(types.PriorityP3, types.PriorityP4, types.PriorityP5, types.PriorityP6)
+	client := qc.Client
+
+	// Put in DB.
+	if err := dbClient.PutInDB(ctx, client, types.GitLabSource, queryDesc, runId, countsData); err != nil {
+		return skerr.Wrapf(err, "error putting gitlab results in DB")
+	}
+	// Put in memory.
+	g.openIssues.PutOpenIssues(client, types.GitLabSource, queryDesc, issues)
+	return nil
+}
+
+// See documentation for bugs.GetIssueLink interface.
+func (g *gitLab) GetIssueLink(_, id string) string {
+	return fmt.Sprintf("%s/-/issues/%s", g.projectURL(), id)
+}
+
+// See documentation for bugs.SetOwnerAndAddComment interface.
+func (g *gitLab) SetOwnerAndAddComment(owner, comment, id string) error {
+	return errors.New("SetOwnerAndAddComment not implemented for gitlab")
+}
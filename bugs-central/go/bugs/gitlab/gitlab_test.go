@@ -0,0 +1,93 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/bugs-central/go/bugs"
+	"go.skia.org/infra/bugs-central/go/types"
+	"go.skia.org/infra/bugs-central/go/types/mocks"
+)
+
+func TestGitLabSearch(t *testing.T) {
+	ctx := context.Background()
+
+	// Serve two pages of results.
+	pages := map[string]string{
+		"1": `[
+			{"iid": 11, "title": "Crash", "state": "opened", "labels": ["skia", "priority::0", "priority::2"], "assignee": {"username": "superman"}, "created_at": "2021-03-01T10:00:00Z", "updated_at": "2021-03-01T10:00:00Z"},
+			{"iid": 22, "title": "Slow", "state": "opened", "labels": ["skia", "needs-triage"], "assignee": null, "created_at": "2021-03-01T10:00:00Z", "updated_at": "2021-03-02T10:00:00Z"}
+		]`,
+		"2": `[
+			{"iid": 33, "title": "Typo", "state": "opened", "labels": ["skia", "priority::3"], "assignee": null, "created_at": "2021-03-01T10:00:00Z", "updated_at": "2021-03-01T10:00:00Z"}
+		]`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v4/projects/kryptonians%2Fkrypton/issues", r.URL.EscapedPath())
+		require.Equal(t, "opened", r.URL.Query().Get("state"))
+		require.Equal(t, "skia", r.URL.Query().Get("labels"))
+		require.Equal(t, "wontfix", r.URL.Query().Get("not[labels]"))
+		require.Equal(t, "abc", r.Header.Get("PRIVATE-TOKEN"))
+		page := r.URL.Query().Get("page")
+		if page == "1" {
+			w.Header().Set("X-Next-Page", "2")
+		}
+		_, err := fmt.Fprint(w, pages[page])
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	qc := &GitLabQueryConfig{
+		Instance:      srv.URL,
+		Project:       "kryptonians/krypton",
+		Labels:        []string{"skia"},
+		ExcludeLabels: []string{"wontfix"},
+		Client:        "Krypton",
+		PriorityMapping: map[string]types.StandardizedPriority{
+			"priority::0": types.PriorityP0,
+			"priority::2": types.PriorityP2,
+			"priority::3": types.PriorityP3,
+		},
+		UntriagedLabels: []string{"needs-triage"},
+	}
+	g := &gitLab{
+		httpClient:  srv.Client(),
+		token:       "abc",
+		openIssues:  bugs.InitOpenIssues(),
+		queryConfig: qc,
+	}
+
+	issues, countsData, err := g.Search(ctx)
+	require.NoError(t, err)
+	require.Len(t, issues, 3)
+	require.Equal(t, "11", issues[0].Id)
+	// The highest priority label is used.
+	require.Equal(t, types.PriorityP0, issues[0].Priority)
+	require.Equal(t, "superman", issues[0].Owner)
+	require.Equal(t, srv.URL+"/kryptonians/krypton/-/issues/11", issues[0].Link)
+	require.Equal(t, types.StandardizedPriority(""), issues[1].Priority)
+	require.Equal(t, types.PriorityP3, issues[2].Priority)
+	require.Equal(t, 3, countsData.OpenCount)
+	require.Equal(t, 2, countsData.UnassignedCount)
+	require.Equal(t, 1, countsData.UntriagedCount)
+
+	// Set UnassignedIsUntriaged and persist the results.
+	qc.UnassignedIsUntriaged = true
+	dbClient := &mocks.BugsDB{}
+	dbClient.On("PutInDB", ctx, types.RecognizedClient("Krypton"), types.GitLabSource, `kryptonians/krypton label:"skia" -label:"wontfix"`, "run1", mock.MatchedBy(func(countsData *types.IssueCountsData) bool {
+		require.Equal(t, 2, countsData.UntriagedCount)
+		require.Equal(t, srv.URL+"/kryptonians/krypton/-/issues?label_name%5B%5D=skia&not%5Blabel_name%5D%5B%5D=wontfix&state=opened", countsData.QueryLink)
+		require.Equal(t, srv.URL+"/kryptonians/krypton/-/issues?label_name%5B%5D=skia&not%5Blabel_name%5D%5B%5D=wontfix&or%5Blabel_name%5D%5B%5D=needs-triage&state=opened", countsData.UntriagedQueryLink)
+		require.Equal(t, srv.URL+"/kryptonians/krypton/-/issues?label_name%5B%5D=skia&not%5Blabel_name%5D%5B%5D=wontfix&or%5Blabel_name%5D%5B%5D=priority%3A%3A0&state=opened", countsData.P0Link)
+		require.Equal(t, "", countsData.P1Link)
+		return true
+	})).Return(nil)
+	defer dbClient.AssertExpectations(t)
+	require.NoError(t, g.SearchClientAndPersist(ctx, dbClient, "run1"))
+}
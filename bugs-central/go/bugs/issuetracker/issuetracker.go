@@ -56,17 +56,17 @@ func New(storageClient *storage.Client, openIssues *bugs.OpenIssues, queryConfig
 // IssueTrackerQueryConfig is the config that will be used when querying issuetracker.
 type IssueTrackerQueryConfig struct {
 	// Key to find the open bugs from the storage results file.
-	Query string
+	Query string `json:"query"`
 	// Which client's issues we are looking for.
-	Client types.RecognizedClient
+	Client types.RecognizedClient `json:"-"`
 	// Issues are considered untriaged if they have any of these priorities.
-	UntriagedPriorities []string
+	UntriagedPriorities []string `json:"untriaged_priorities"`
 	// Issues are also considered untriaged if they are assigned to any of these emails.
-	UntriagedAliases []string
+	UntriagedAliases []string `json:"untriaged_aliases"`
 	// Whether unassigned issues should be considered as untriaged.
-	UnassignedIsUntriaged bool
+	UnassignedIsUntriaged bool `json:"unassigned_is_untriaged"`
 	// Which hotlists should be excluded.
-	HotlistsToExclude []int64
+	HotlistsToExclude []int64 `json:"hotlists_to_exclude"`
 }
 
 // See documentation for bugs.Search interface.
@@ -0,0 +1,301 @@
+package jira
+
+// Accesses the Jira REST API (https://docs.atlassian.com/software/jira/docs/api/REST/latest/).
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.skia.org/infra/bugs-central/go/bugs"
+	"go.skia.org/infra/bugs-central/go/types"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	// Maximum number of issues to request per page. Jira caps this at 100 for most instances.
+	maxJiraResultsPerPage = 100
+
+	// Format of the timestamps returned by the Jira REST API, eg: "2021-03-01T10:00:00.000+0000".
+	jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+)
+
+type jiraUser struct {
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string `json:"summary"`
+		Description string `json:"description"`
+		Status      struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		Priority *struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+		Assignee *jiraUser `json:"assignee"`
+		Created  string    `json:"created"`
+		Updated  string    `json:"updated"`
+	} `json:"fields"`
+}
+
+type jiraSearchResponse struct {
+	StartAt    int         `json:"startAt"`
+	MaxResults int         `json:"maxResults"`
+	Total      int         `json:"total"`
+	Issues     []jiraIssue `json:"issues"`
+}
+
+// jira implements bugs.BugFramework for Jira projects.
+type jira struct {
+	httpClient *http.Client
+	// Value of the Authorization header sent with every request.
+	authorization string
+	openIssues    *bugs.OpenIssues
+	queryConfig   *JiraQueryConfig
+}
+
+// JiraQueryConfig is the config that will be used when querying Jira API.
+type JiraQueryConfig struct {
+	// Base URL of the Jira instance. Eg: "https://jira.example.com".
+	Instance string `json:"instance"`
+	// Path to a file containing the credentials to use. Either "<email>:<API token>" for Jira
+	// Cloud, or a personal access token for Jira Server and Data Center.
+	TokenFile string `json:"token_file"`
+	// JQL query to run. Eg: "project = SKIA AND statusCategory != Done".
+	Query string `json:"query"`
+	// Which client's issues we are looking for.
+	Client types.RecognizedClient `json:"-"`
+	// Maps the Jira priority names (eg: "Highest") into the standardized priorities.
+	PriorityMapping map[string]types.StandardizedPriority `json:"priority_mapping"`
+	// Which statuses are considered as untriaged.
+	UntriagedStatuses []string `json:"untriaged_statuses"`
+	// Whether unassigned issues should be considered as untriaged.
+	UnassignedIsUntriaged bool `json:"unassigned_is_untriaged"`
+}
+
+// New returns an instance of the Jira implementation of bugs.BugFramework.
+func New(openIssues *bugs.OpenIssues, queryConfig *JiraQueryConfig) (bugs.BugFramework, error) {
+	tBody, err := os.ReadFile(queryConfig.TokenFile)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "could not find jira token in %s", queryConfig.TokenFile)
+	}
+	return &jira{
+		httpClient:    httputils.DefaultClientConfig().With2xxOnly().Client(),
+		authorization: authorizationHeader(strings.TrimSpace(string(tBody))),
+		openIssues:    openIssues,
+		queryConfig:   queryConfig,
+	}, nil
+}
+
+// authorizationHeader returns the Authorization header for the given credentials. Jira Cloud uses
+// basic auth with an email address and API token, while Jira Server and Data Center use personal
+// access tokens as bearer tokens.
+func authorizationHeader(token string) string {
+	if strings.Contains(token, ":") {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(token))
+	}
+	return "Bearer " + token
+}
+
+// searchIssuesWithPagination returns Jira issue results by paginating till the end of results.
+func (j *jira) searchIssuesWithPagination(ctx context.Context) ([]jiraIssue, error) {
+	issues := []jiraIssue{}
+	for {
+		params := url.Values{}
+		params.Set("jql", j.queryConfig.Query)
+		params.Set("startAt", strconv.Itoa(len(issues)))
+		params.Set("maxResults", strconv.Itoa(maxJiraResultsPerPage))
+		params.Set("fields", "summary,description,status,priority,assignee,created,updated")
+		searchURL := fmt.Sprintf("%s/rest/api/2/search?%s", strings.TrimSuffix(j.queryConfig.Instance, "/"), params.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchURL, nil)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", j.authorization)
+		resp, err := j.httpClient.Do(req)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "jira search request failed")
+		}
+		var searchResp jiraSearchResponse
+		err = json.NewDecoder(resp.Body).Decode(&searchResp)
+		util.Close(resp.Body)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "invalid JSON from jira search")
+		}
+
+		issues = append(issues, searchResp.Issues...)
+		if len(searchResp.Issues) == 0 || len(issues) >= searchResp.Total {
+			return issues, nil
+		}
+	}
+}
+
+// See documentation for bugs.Search interface.
+func (j *jira) Search(ctx context.Context) ([]*types.Issue, *types.IssueCountsData, error) {
+	jiraIssues, err := j.searchIssuesWithPagination(ctx)
+	if err != nil {
+		return nil, nil, skerr.Wrapf(err, "error when searching issues")
+	}
+
+	// Convert jira issues into bug_framework's generic issues
+	issues := []*types.Issue{}
+	countsData := &types.IssueCountsData{}
+	for _, ji := range jiraIssues {
+		owner := ""
+		if ji.Fields.Assignee != nil {
+			owner = ji.Fields.Assignee.EmailAddress
+			if owner == "" {
+				owner = ji.Fields.Assignee.Name
+			}
+		}
+
+		priority := types.StandardizedPriority("")
+		if ji.Fields.Priority != nil {
+			if p, ok := j.queryConfig.PriorityMapping[ji.Fields.Priority.Name]; ok {
+				priority = p
+			} else {
+				sklog.Errorf("Could not find priority value %s for jira instance %s", ji.Fields.Priority.Name, j.queryConfig.Instance)
+			}
+		}
+
+		created, err := time.Parse(jiraTimeFormat, ji.Fields.Created)
+		if err != nil {
+			return nil, nil, skerr.Wrapf(err, "invalid creation time of %s", ji.Key)
+		}
+		modified, err := time.Parse(jiraTimeFormat, ji.Fields.Updated)
+		if err != nil {
+			return nil, nil, skerr.Wrapf(err, "invalid modified time of %s", ji.Key)
+		}
+
+		// Populate counts data.
+		countsData.OpenCount++
+		if owner == "" {
+			countsData.UnassignedCount++
+		}
+		countsData.IncPriority(priority)
+		sloViolation, reason, d := types.IsPrioritySLOViolation(time.Now(), created, modified, priority)
+		countsData.IncSLOViolation(sloViolation, priority)
+		if util.In(ji.Fields.Status.Name, j.queryConfig.UntriagedStatuses) {
+			countsData.UntriagedCount++
+		} else if j.queryConfig.UnassignedIsUntriaged && owner == "" {
+			countsData.UntriagedCount++
+		}
+
+		issues = append(issues, &types.Issue{
+			Id:       ji.Key,
+			State:    ji.Fields.Status.Name,
+			Priority: priority,
+			Owner:    owner,
+			Link:     j.GetIssueLink("", ji.Key),
+
+			SLOViolation:         sloViolation,
+			SLOViolationReason:   reason,
+			SLOViolationDuration: d,
+
+			CreatedTime:  created,
+			ModifiedTime: modified,
+
+			Title:   ji.Fields.Summary,
+			Summary: ji.Fields.Description,
+		})
+	}
+
+	return issues, countsData, nil
+}
+
+// queryLink returns a link to the Jira issue navigator for the specified JQL query.
+func (j *jira) queryLink(jql string) string {
+	return fmt.Sprintf("%s/issues/?jql=%s", strings.TrimSuffix(j.queryConfig.Instance, "/"), url.QueryEscape(jql))
+}
+
+// priorityLink returns a link to the issues of the config's query with Jira priorities that map to
+// any of the specified standardized priorities. Returns "" if there are no such priorities.
+func (j *jira) priorityLink(priorities ...types.StandardizedPriority) string {
+	wanted := map[types.StandardizedPriority]bool{}
+	for _, p := range priorities {
+		wanted[p] = true
+	}
+	names := []string{}
+	for name, p := range j.queryConfig.PriorityMapping {
+		if wanted[p] {
+			names = append(names, strconv.Quote(name))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return j.queryLink(fmt.Sprintf("(%s) AND priority in (%s)", j.queryConfig.Query, strings.Join(names, ", ")))
+}
+
+// See documentation for bugs.SearchClientAndPersist interface.
+func (j *jira) SearchClientAndPersist(ctx context.Context, dbClient types.BugsDB, runId string) error {
+	qc := j.queryConfig
+	issues, countsData, err := j.Search(ctx)
+	if err != nil {
+		return skerr.Wrapf(err, "error when searching jira")
+	}
+	sklog.Infof("%s Jira issues %+v", qc.Client, countsData)
+
+	queryDesc := qc.Query
+	countsData.QueryLink = j.queryLink(qc.Query)
+	// Construct query for untriaged issues.
+	untriagedTokens := []string{}
+	if len(qc.UntriagedStatuses) > 0 {
+		statuses := []string{}
+		for _, s := range qc.UntriagedStatuses {
+			statuses = append(statuses, strconv.Quote(s))
+		}
+		untriagedTokens = append(untriagedTokens, fmt.Sprintf("status in (%s)", strings.Join(statuses, ", ")))
+	}
+	if qc.UnassignedIsUntriaged {
+		untriagedTokens = append(untriagedTokens, "assignee is EMPTY")
+	}
+	if len(untriagedTokens) > 0 {
+		countsData.UntriagedQueryLink = j.queryLink(fmt.Sprintf("(%s) AND (%s)", qc.Query, strings.Join(untriagedTokens, " OR ")))
+	} else {
+		countsData.UntriagedQueryLink = countsData.QueryLink
+	}
+	// Calculate priority links.
+	countsData.P0Link = j.priorityLink(types.PriorityP0)
+	countsData.P1Link = j.priorityLink(types.PriorityP1)
+	countsData.P2Link = j.priorityLink(types.PriorityP2)
+	countsData.P3AndRestLink = j.priorityLink(types.PriorityP3, types.PriorityP4, types.PriorityP5, types.PriorityP6)
+	client := qc.Client
+
+	// Put in DB.
+	if err := dbClient.PutInDB(ctx, client, types.JiraSource, queryDesc, runId, countsData); err != nil {
+		return skerr.Wrapf(err, "error putting jira results in DB")
+	}
+	// Put in memory.
+	j.openIssues.PutOpenIssues(client, types.JiraSource, queryDesc, issues)
+	return nil
+}
+
+// See documentation for bugs.GetIssueLink interface.
+func (j *jira) GetIssueLink(_, id string) string {
+	return fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(j.queryConfig.Instance, "/"), id)
+}
+
+// See documentation for bugs.SetOwnerAndAddComment interface.
+func (j *jira) SetOwnerAndAddComment(owner, comment, id string) error {
+	return errors.New("SetOwnerAndAddComment not implemented for jira")
+}
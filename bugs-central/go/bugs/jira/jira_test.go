@@ -0,0 +1,97 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/bugs-central/go/bugs"
+	"go.skia.org/infra/bugs-central/go/types"
+	"go.skia.org/infra/bugs-central/go/types/mocks"
+)
+
+func TestJiraSearch(t *testing.T) {
+	ctx := context.Background()
+
+	// Serve two pages of results.
+	pages := map[string]string{
+		"0": `{"startAt": 0, "maxResults": 2, "total": 3, "issues": [
+			{"key": "SKIA-1", "fields": {"summary": "Crash", "status": {"name": "Open"}, "priority": {"name": "Highest"}, "assignee": {"name": "superman", "emailAddress": "superman@krypton.com"}, "created": "2021-03-01T10:00:00.000+0000", "updated": "2021-03-01T10:00:00.000+0000"}},
+			{"key": "SKIA-2", "fields": {"summary": "Slow", "status": {"name": "New"}, "priority": {"name": "Low"}, "assignee": null, "created": "2021-03-01T10:00:00.000+0000", "updated": "2021-03-02T10:00:00.000+0000"}}
+		]}`,
+		"2": `{"startAt": 2, "maxResults": 2, "total": 3, "issues": [
+			{"key": "SKIA-3", "fields": {"summary": "Typo", "status": {"name": "In Progress"}, "priority": null, "assignee": {"name": "batman"}, "created": "2021-03-01T10:00:00.000+0000", "updated": "2021-03-01T10:00:00.000+0000"}}
+		]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rest/api/2/search", r.URL.Path)
+		require.Equal(t, "project = SKIA", r.URL.Query().Get("jql"))
+		require.Equal(t, "Bearer abc", r.Header.Get("Authorization"))
+		_, err := fmt.Fprint(w, pages[r.URL.Query().Get("startAt")])
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	qc := &JiraQueryConfig{
+		Instance: srv.URL,
+		Query:    "project = SKIA",
+		Client:   "Skia",
+		PriorityMapping: map[string]types.StandardizedPriority{
+			"Highest": types.PriorityP0,
+			"Low":     types.PriorityP3,
+			"Lowest":  types.PriorityP4,
+		},
+		UntriagedStatuses: []string{"New"},
+	}
+	j := &jira{
+		httpClient:    srv.Client(),
+		authorization: authorizationHeader("abc"),
+		openIssues:    bugs.InitOpenIssues(),
+		queryConfig:   qc,
+	}
+
+	issues, countsData, err := j.Search(ctx)
+	require.NoError(t, err)
+	require.Len(t, issues, 3)
+	require.Equal(t, "SKIA-1", issues[0].Id)
+	require.Equal(t, types.PriorityP0, issues[0].Priority)
+	require.Equal(t, "superman@krypton.com", issues[0].Owner)
+	require.Equal(t, srv.URL+"/browse/SKIA-1", issues[0].Link)
+	require.True(t, issues[0].SLOViolation)
+	require.Equal(t, types.PriorityP3, issues[1].Priority)
+	require.Equal(t, "", issues[1].Owner)
+	require.Equal(t, types.StandardizedPriority(""), issues[2].Priority)
+	require.Equal(t, "batman", issues[2].Owner)
+	require.Equal(t, 3, countsData.OpenCount)
+	require.Equal(t, 1, countsData.UnassignedCount)
+	require.Equal(t, 1, countsData.UntriagedCount)
+	require.Equal(t, 1, countsData.P0Count)
+	require.Equal(t, 1, countsData.P3Count)
+
+	// Set UnassignedIsUntriaged and persist the results.
+	qc.UnassignedIsUntriaged = true
+	qc.UntriagedStatuses = nil
+	dbClient := &mocks.BugsDB{}
+	dbClient.On("PutInDB", ctx, types.RecognizedClient("Skia"), types.JiraSource, "project = SKIA", "run1", mock.MatchedBy(func(countsData *types.IssueCountsData) bool {
+		require.Equal(t, 1, countsData.UntriagedCount)
+		require.Equal(t, srv.URL+"/issues/?jql=project+%3D+SKIA", countsData.QueryLink)
+		require.Equal(t, srv.URL+"/issues/?jql=%28project+%3D+SKIA%29+AND+%28assignee+is+EMPTY%29", countsData.UntriagedQueryLink)
+		require.Equal(t, srv.URL+"/issues/?jql=%28project+%3D+SKIA%29+AND+priority+in+%28%22Highest%22%29", countsData.P0Link)
+		require.Equal(t, "", countsData.P1Link)
+		require.Equal(t, srv.URL+"/issues/?jql=%28project+%3D+SKIA%29+AND+priority+in+%28%22Low%22%2C+%22Lowest%22%29", countsData.P3AndRestLink)
+		return true
+	})).Return(nil)
+	defer dbClient.AssertExpectations(t)
+	require.NoError(t, j.SearchClientAndPersist(ctx, dbClient, "run1"))
+	require.Len(t, j.openIssues.GetIssuesOutsideSLO("Skia", types.JiraSource, "project = SKIA")[types.PriorityP0], 1)
+}
+
+func TestAuthorizationHeader(t *testing.T) {
+	require.Equal(t, "Bearer abc", authorizationHeader("abc"))
+	require.Equal(t, "Basic c3VwZXJtYW5Aa3J5cHRvbi5jb206YWJj", authorizationHeader("superman@krypton.com:abc"))
+}
@@ -80,15 +80,15 @@ type monorail struct {
 // MonorailQueryConfig is the config that will be used when querying monorail API.
 type MonorailQueryConfig struct {
 	// Monorail instance to query.
-	Instance string
+	Instance string `json:"instance"`
 	// Monorail query to run.
-	Query string
+	Query string `json:"query"`
 	// Which client's issues we are looking for.
-	Client types.RecognizedClient
+	Client types.RecognizedClient `json:"-"`
 	// Which statuses are considered as untriaged.
-	UntriagedStatuses []string
+	UntriagedStatuses []string `json:"untriaged_statuses"`
 	// Whether unassigned issues should be considered as untriaged.
-	UnassignedIsUntriaged bool
+	UnassignedIsUntriaged bool `json:"unassigned_is_untriaged"`
 }
 
 // New returns an instance of the monorail implementation of bugs.BugFramework.
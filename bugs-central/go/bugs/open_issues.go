@@ -58,6 +58,32 @@ func (o *OpenIssues) GetIssuesOutsideSLO(client types.RecognizedClient, source t
 	return priorityToSLOIssues
 }
 
+// GetClientIssues returns all open issues of the client across all of its sources and queries.
+// Issues that are returned by multiple queries are only included once.
+func (o *OpenIssues) GetClientIssues(client types.RecognizedClient) []*types.Issue {
+	o.mtx.RLock()
+	defer o.mtx.RUnlock()
+
+	issues := []*types.Issue{}
+	seen := map[string]bool{}
+	for _, queryToIssues := range o.openIssues[client] {
+		for _, queryIssues := range queryToIssues {
+			for _, i := range queryIssues {
+				if seen[i.Link] {
+					continue
+				}
+				seen[i.Link] = true
+				issues = append(issues, i)
+			}
+		}
+	}
+	// Sort issues by link so that callers get a stable order.
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].Link < issues[j].Link
+	})
+	return issues
+}
+
 // PrettyPrintOpenIssues pretty prints the open issues in-memory object.
 func (o *OpenIssues) PrettyPrintOpenIssues() {
 	o.mtx.RLock()
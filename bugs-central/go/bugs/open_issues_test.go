@@ -55,3 +55,30 @@ func TestPutOpenIssues(t *testing.T) {
 	require.Equal(t, "id11", o.openIssues[client2][source1][query2][0].Id)
 	require.Equal(t, "id12", o.openIssues[client2][source1][query2][1].Id)
 }
+
+func TestGetClientIssues(t *testing.T) {
+
+	o := InitOpenIssues()
+	client1 := types.RecognizedClient("client1")
+	client2 := types.RecognizedClient("client2")
+	o.PutOpenIssues(client1, "source1", "query1", []*types.Issue{
+		{Id: "id2", Link: "link2"},
+		{Id: "id1", Link: "link1"},
+	})
+	// The same issue returned by another query should only be included once.
+	o.PutOpenIssues(client1, "source2", "query2", []*types.Issue{
+		{Id: "id1", Link: "link1"},
+		{Id: "id3", Link: "link3"},
+	})
+	o.PutOpenIssues(client2, "source1", "query1", []*types.Issue{
+		{Id: "id4", Link: "link4"},
+	})
+
+	issues := o.GetClientIssues(client1)
+	require.Len(t, issues, 3)
+	require.Equal(t, "id1", issues[0].Id)
+	require.Equal(t, "id2", issues[1].Id)
+	require.Equal(t, "id3", issues[2].Id)
+	require.Len(t, o.GetClientIssues(client2), 1)
+	require.Empty(t, o.GetClientIssues("unknown"))
+}
@@ -0,0 +1,236 @@
+package config
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.skia.org/infra/bugs-central/go/bugs/github"
+	"go.skia.org/infra/bugs-central/go/bugs/gitlab"
+	"go.skia.org/infra/bugs-central/go/bugs/issuetracker"
+	"go.skia.org/infra/bugs-central/go/bugs/jira"
+	"go.skia.org/infra/bugs-central/go/bugs/monorail"
+	"go.skia.org/infra/bugs-central/go/types"
+	"go.skia.org/infra/go/skerr"
+)
+
+// BugsCentralCfg is a struct that contains the clients whose issues are tracked, the queries used
+// to find their issues, and the SLOs of the different priorities.
+type BugsCentralCfg struct {
+
+	// Contains the clients whose issues are tracked.
+	Clients []*ClientCfg `json:"clients"`
+
+	// Contains a map of priority to the SLO of that priority. Priorities not in this map have no
+	// SLO. If not specified then types.DefaultSLOs is used. Note that SLO violations are only
+	// counted for P0-P3 in the charts.
+	SLOs map[types.StandardizedPriority]*SLOCfg `json:"slos"`
+}
+
+type ClientCfg struct {
+	// Name of the client. Eg: Android
+	Name types.RecognizedClient `json:"name"`
+
+	// Whether the untriaged count of this client is returned by the get_client_counts endpoint
+	// used by status.
+	ShowOnStatus bool `json:"show_on_status"`
+
+	// Queries that find the issues of this client.
+	Queries []*QueryCfg `json:"queries"`
+
+	// Digests and nudges to send for this client. Nothing is sent if not specified.
+	Notifications *NotificationsCfg `json:"notifications,omitempty"`
+}
+
+// NotificationsCfg describes the digests sent for a client and the nudges sent to the owners of
+// its issues.
+type NotificationsCfg struct {
+	// Email addresses the digest is sent to.
+	Emails []string `json:"emails"`
+	// Chat rooms the digest is sent to. Rooms must be present in the chatbot webhooks config.
+	ChatRooms []string `json:"chat_rooms"`
+	// Day of the week the digest is sent on. Eg: "Monday". The digest is sent daily if not
+	// specified.
+	Weekday string `json:"weekday"`
+	// Hour of the day in UTC that the digest and nudges are sent at.
+	HourUTC int `json:"hour_utc"`
+	// Whether the digest groups the issues outside SLO and close to SLO by owner.
+	GroupByOwner bool `json:"group_by_owner"`
+	// If set then owners of issues that will violate their SLO within this many days are sent a
+	// daily email nudge. Only owners that are email addresses are nudged.
+	NudgeDays int `json:"nudge_days"`
+}
+
+// QueryCfg describes a single query of an issue framework. Exactly one of the fields must be set.
+type QueryCfg struct {
+	Github       *GithubCfg                            `json:"github,omitempty"`
+	GitLab       *gitlab.GitLabQueryConfig             `json:"gitlab,omitempty"`
+	IssueTracker *issuetracker.IssueTrackerQueryConfig `json:"issuetracker,omitempty"`
+	Jira         *jira.JiraQueryConfig                 `json:"jira,omitempty"`
+	Monorail     *monorail.MonorailQueryConfig         `json:"monorail,omitempty"`
+}
+
+// GithubCfg is the config of a Github query along with the repo to query.
+type GithubCfg struct {
+	// Owner of the repo. Eg: flutter
+	RepoOwner string `json:"repo_owner"`
+	// Name of the repo. Eg: flutter
+	RepoName string `json:"repo_name"`
+
+	github.GithubQueryConfig
+}
+
+type SLOCfg struct {
+	// If an open issue's last modified time is beyond this many days then it is an SLO violation.
+	ModifiedDays int `json:"modified_days"`
+	// If an open issue's creation time is beyond this many days then it is an SLO violation.
+	CreatedDays int `json:"created_days"`
+}
+
+// weekdays maps the names of the days of the week to time.Weekday.
+var weekdays = map[string]time.Weekday{}
+
+func init() {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		weekdays[d.String()] = d
+	}
+}
+
+// DigestPeriod returns how often the digest is sent.
+func (n *NotificationsCfg) DigestPeriod() time.Duration {
+	if n.Weekday == "" {
+		return types.Daily
+	}
+	return types.Weekly
+}
+
+// IsDigestDue returns true if the digest is scheduled to be sent during the hour of now.
+func (n *NotificationsCfg) IsDigestDue(now time.Time) bool {
+	now = now.UTC()
+	if now.Hour() != n.HourUTC {
+		return false
+	}
+	return n.Weekday == "" || weekdays[n.Weekday] == now.Weekday()
+}
+
+// IsNudgeDue returns true if nudges are scheduled to be sent during the hour of now.
+func (n *NotificationsCfg) IsNudgeDue(now time.Time) bool {
+	return n.NudgeDays > 0 && now.UTC().Hour() == n.HourUTC
+}
+
+// Source returns the issue framework of the query.
+func (q *QueryCfg) Source() (types.IssueSource, error) {
+	sources := []types.IssueSource{}
+	if q.Github != nil {
+		sources = append(sources, types.GithubSource)
+	}
+	if q.GitLab != nil {
+		sources = append(sources, types.GitLabSource)
+	}
+	if q.IssueTracker != nil {
+		sources = append(sources, types.IssueTrackerSource)
+	}
+	if q.Jira != nil {
+		sources = append(sources, types.JiraSource)
+	}
+	if q.Monorail != nil {
+		sources = append(sources, types.MonorailSource)
+	}
+	if len(sources) != 1 {
+		return "", skerr.Fmt("Expected exactly one issue framework in query but found %d: %v", len(sources), sources)
+	}
+	return sources[0], nil
+}
+
+// GetSLOs returns the SLOs of the config in the format used by types.SetSLOs.
+func (c *BugsCentralCfg) GetSLOs() map[types.StandardizedPriority]types.SLO {
+	if c.SLOs == nil {
+		return types.DefaultSLOs
+	}
+	slos := map[types.StandardizedPriority]types.SLO{}
+	for p, slo := range c.SLOs {
+		slos[p] = types.SLO{
+			ModifiedDuration: time.Duration(slo.ModifiedDays) * types.Daily,
+			CreatedDuration:  time.Duration(slo.CreatedDays) * types.Daily,
+		}
+	}
+	return slos
+}
+
+// GetStatusClients returns the clients whose untriaged counts are returned by the
+// get_client_counts endpoint used by status.
+func (c *BugsCentralCfg) GetStatusClients() []types.RecognizedClient {
+	clients := []types.RecognizedClient{}
+	for _, client := range c.Clients {
+		if client.ShowOnStatus {
+			clients = append(clients, client.Name)
+		}
+	}
+	return clients
+}
+
+// ParseCfg is a utility function that parses and validates the given config file. The client of
+// each query is populated from the client it is listed under.
+func ParseCfg(cfgContents []byte) (*BugsCentralCfg, error) {
+	var cfg BugsCentralCfg
+	if err := json.Unmarshal([]byte(cfgContents), &cfg); err != nil {
+		return nil, skerr.Wrapf(err, "Failed to parse the config file with contents:\n%s", string(cfgContents))
+	}
+
+	clientNames := map[types.RecognizedClient]bool{}
+	for _, client := range cfg.Clients {
+		if client.Name == "" {
+			return nil, skerr.Fmt("Found a client with no name")
+		}
+		if clientNames[client.Name] {
+			return nil, skerr.Fmt("Found duplicate client %s", client.Name)
+		}
+		clientNames[client.Name] = true
+		for _, q := range client.Queries {
+			source, err := q.Source()
+			if err != nil {
+				return nil, skerr.Wrapf(err, "invalid query for client %s", client.Name)
+			}
+			switch source {
+			case types.GithubSource:
+				if q.Github.RepoOwner == "" || q.Github.RepoName == "" {
+					return nil, skerr.Fmt("Github query for client %s must specify repo_owner and repo_name", client.Name)
+				}
+				q.Github.Client = client.Name
+			case types.GitLabSource:
+				if q.GitLab.Instance == "" || q.GitLab.Project == "" {
+					return nil, skerr.Fmt("GitLab query for client %s must specify instance and project", client.Name)
+				}
+				q.GitLab.Client = client.Name
+			case types.IssueTrackerSource:
+				q.IssueTracker.Client = client.Name
+			case types.JiraSource:
+				if q.Jira.Instance == "" || q.Jira.Query == "" {
+					return nil, skerr.Fmt("Jira query for client %s must specify instance and query", client.Name)
+				}
+				q.Jira.Client = client.Name
+			case types.MonorailSource:
+				q.Monorail.Client = client.Name
+			}
+		}
+		if n := client.Notifications; n != nil {
+			if _, ok := weekdays[n.Weekday]; n.Weekday != "" && !ok {
+				return nil, skerr.Fmt("Unknown weekday %q in notifications for client %s", n.Weekday, client.Name)
+			}
+			if n.HourUTC < 0 || n.HourUTC > 23 {
+				return nil, skerr.Fmt("hour_utc must be between 0 and 23 in notifications for client %s", client.Name)
+			}
+			if n.NudgeDays < 0 {
+				return nil, skerr.Fmt("nudge_days cannot be negative in notifications for client %s", client.Name)
+			}
+		}
+	}
+
+	for p := range cfg.SLOs {
+		switch p {
+		case types.PriorityP0, types.PriorityP1, types.PriorityP2, types.PriorityP3, types.PriorityP4, types.PriorityP5, types.PriorityP6:
+		default:
+			return nil, skerr.Fmt("Unknown priority %s in slos", p)
+		}
+	}
+	return &cfg, nil
+}
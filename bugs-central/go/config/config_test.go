@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/bugs-central/go/types"
+	"go.skia.org/infra/go/testutils"
+)
+
+func TestParseCfg(t *testing.T) {
+	dir := testutils.TestDataDir(t)
+	cfgContents, err := os.ReadFile(filepath.Join(dir, "test-config.json"))
+	require.NoError(t, err)
+
+	cfg, err := ParseCfg(cfgContents)
+	require.NoError(t, err)
+	require.Len(t, cfg.Clients, 2)
+
+	krypton := cfg.Clients[0]
+	require.Equal(t, types.RecognizedClient("Krypton"), krypton.Name)
+	require.Len(t, krypton.Queries, 2)
+	gh := krypton.Queries[0].Github
+	require.Equal(t, "kryptonians", gh.RepoOwner)
+	require.Equal(t, "krypton", gh.RepoName)
+	require.Equal(t, []string{"skia"}, gh.Labels)
+	require.True(t, gh.Open)
+	require.Equal(t, types.RecognizedClient("Krypton"), gh.Client)
+	j := krypton.Queries[1].Jira
+	require.Equal(t, "https://jira.krypton.com", j.Instance)
+	require.Equal(t, map[string]types.StandardizedPriority{"Highest": types.PriorityP0, "High": types.PriorityP1}, j.PriorityMapping)
+	require.Equal(t, types.RecognizedClient("Krypton"), j.Client)
+	source, err := krypton.Queries[1].Source()
+	require.NoError(t, err)
+	require.Equal(t, types.JiraSource, source)
+
+	n := krypton.Notifications
+	require.Equal(t, []string{"kal-el@krypton.com"}, n.Emails)
+	require.Equal(t, []string{"krypton-bugs"}, n.ChatRooms)
+	require.True(t, n.GroupByOwner)
+	require.Equal(t, types.Weekly, n.DigestPeriod())
+	require.Nil(t, cfg.Clients[1].Notifications)
+
+	gl := cfg.Clients[1].Queries[0].GitLab
+	require.Equal(t, "kent/farm", gl.Project)
+	require.True(t, gl.UnassignedIsUntriaged)
+	require.Equal(t, types.RecognizedClient("Smallville"), gl.Client)
+
+	require.Equal(t, []types.RecognizedClient{"Krypton"}, cfg.GetStatusClients())
+	require.Equal(t, map[types.StandardizedPriority]types.SLO{
+		types.PriorityP0: {ModifiedDuration: types.Daily, CreatedDuration: types.Weekly},
+		types.PriorityP4: {ModifiedDuration: types.Monthly, CreatedDuration: 3 * types.Monthly},
+	}, cfg.GetSLOs())
+}
+
+func TestParseCfg_ProdConfig_MatchesDefaultSLOs(t *testing.T) {
+	cfgContents, err := Configs.ReadFile("prod.json")
+	require.NoError(t, err)
+
+	cfg, err := ParseCfg(cfgContents)
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultSLOs, cfg.GetSLOs())
+	require.Equal(t, []types.RecognizedClient{"Android", "Chromium", "Skia", "OSS-Fuzz"}, cfg.GetStatusClients())
+}
+
+func TestParseCfg_NoSLOs_UsesDefaultSLOs(t *testing.T) {
+	cfg, err := ParseCfg([]byte(`{"clients": []}`))
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultSLOs, cfg.GetSLOs())
+}
+
+func TestNotificationsCfg_Schedule(t *testing.T) {
+	// Monday 2021-03-01 09:30 UTC.
+	monday := time.Date(2021, time.March, 1, 9, 30, 0, 0, time.UTC)
+	tuesday := monday.Add(types.Daily)
+
+	weekly := &NotificationsCfg{Weekday: "Monday", HourUTC: 9, NudgeDays: 1}
+	require.True(t, weekly.IsDigestDue(monday))
+	require.False(t, weekly.IsDigestDue(monday.Add(time.Hour)))
+	require.False(t, weekly.IsDigestDue(tuesday))
+	// Nudges are sent daily.
+	require.True(t, weekly.IsNudgeDue(monday))
+	require.True(t, weekly.IsNudgeDue(tuesday))
+
+	daily := &NotificationsCfg{HourUTC: 9}
+	require.Equal(t, types.Daily, daily.DigestPeriod())
+	require.True(t, daily.IsDigestDue(monday))
+	require.True(t, daily.IsDigestDue(tuesday))
+	// Nudges are disabled.
+	require.False(t, daily.IsNudgeDue(monday))
+}
+
+func TestParseCfgInvalid(t *testing.T) {
+	for _, test := range []struct {
+		cfg           string
+		expectedError string
+	}{
+		{cfg: "Hi Mom!", expectedError: "Failed to parse the config file with contents:\nHi Mom!"},
+		{cfg: `{"clients": [{"queries": []}]}`, expectedError: "Found a client with no name"},
+		{cfg: `{"clients": [{"name": "Skia"}, {"name": "Skia"}]}`, expectedError: "Found duplicate client Skia"},
+		{cfg: `{"clients": [{"name": "Skia", "queries": [{}]}]}`, expectedError: "Expected exactly one issue framework in query but found 0"},
+		{cfg: `{"clients": [{"name": "Skia", "queries": [{"monorail": {}, "jira": {}}]}]}`, expectedError: "Expected exactly one issue framework in query but found 2"},
+		{cfg: `{"clients": [{"name": "Skia", "queries": [{"github": {"labels": ["skia"]}}]}]}`, expectedError: "must specify repo_owner and repo_name"},
+		{cfg: `{"clients": [{"name": "Skia", "queries": [{"jira": {"query": "project = SKIA"}}]}]}`, expectedError: "must specify instance and query"},
+		{cfg: `{"clients": [{"name": "Skia", "queries": [{"gitlab": {"project": "skia"}}]}]}`, expectedError: "must specify instance and project"},
+		{cfg: `{"clients": [{"name": "Skia", "notifications": {"weekday": "Caturday"}}]}`, expectedError: `Unknown weekday "Caturday" in notifications for client Skia`},
+		{cfg: `{"clients": [{"name": "Skia", "notifications": {"hour_utc": 24}}]}`, expectedError: "hour_utc must be between 0 and 23"},
+		{cfg: `{"clients": [{"name": "Skia", "notifications": {"nudge_days": -1}}]}`, expectedError: "nudge_days cannot be negative"},
+		{cfg: `{"slos": {"Critical": {"modified_days": 1}}}`, expectedError: "Unknown priority Critical in slos"},
+	} {
+		cfg, err := ParseCfg([]byte(test.cfg))
+		require.Nil(t, cfg, test.cfg)
+		require.Error(t, err, test.cfg)
+		require.Contains(t, err.Error(), test.expectedError, test.cfg)
+	}
+}
@@ -24,7 +24,8 @@ const (
 	putSingleTimeout = 10 * time.Second
 
 	// Names of Collections
-	runIdsCol = "RunIds"
+	runIdsCol        = "RunIds"
+	notificationsCol = "Notifications"
 )
 
 // FirestoreDB uses Cloud Firestore for store.
@@ -56,7 +57,7 @@ func (f *FirestoreDB) getAllLatestCounts(ctx context.Context) (*types.IssueCount
 			break
 		} else if err != nil {
 			return nil, err
-		} else if c.ID == "RunIds" {
+		} else if c.ID == runIdsCol || c.ID == notificationsCol {
 			continue
 		}
 		qcd, err := f.getLatestCountsFromClient(ctx, c)
@@ -169,7 +170,7 @@ func (f *FirestoreDB) getAllQueryData(ctx context.Context) ([]*types.QueryData,
 			break
 		} else if err != nil {
 			return nil, err
-		} else if c.ID == runIdsCol {
+		} else if c.ID == runIdsCol || c.ID == notificationsCol {
 			continue
 		}
 		qs, err := f.getAllQueryDataFromClient(ctx, c)
@@ -285,7 +286,7 @@ func (f *FirestoreDB) GetClientsFromDB(ctx context.Context) (map[types.Recognize
 			break
 		} else if err != nil {
 			return nil, err
-		} else if c.ID == runIdsCol {
+		} else if c.ID == runIdsCol || c.ID == notificationsCol {
 			continue
 		}
 		cID := types.RecognizedClient(c.ID)
@@ -386,3 +387,27 @@ func (f *FirestoreDB) StoreRunId(ctx context.Context, runId string) error {
 	}
 	return nil
 }
+
+// See GetNotificationTimes documentation in types.BugsDB interface.
+func (f *FirestoreDB) GetNotificationTimes(ctx context.Context, client types.RecognizedClient) (*types.NotificationTimes, error) {
+	times := &types.NotificationTimes{}
+	doc, err := f.client.Get(ctx, f.client.Collection(notificationsCol).Doc(string(client)), defaultAttempts, getSingleTimeout)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return times, nil
+		}
+		return nil, skerr.Wrapf(err, "could not get notification times of %s", client)
+	}
+	if err := doc.DataTo(times); err != nil {
+		return nil, skerr.Wrapf(err, "could not decode notification times of %s", client)
+	}
+	return times, nil
+}
+
+// See PutNotificationTimes documentation in types.BugsDB interface.
+func (f *FirestoreDB) PutNotificationTimes(ctx context.Context, client types.RecognizedClient, times *types.NotificationTimes) error {
+	if _, err := f.client.Set(ctx, f.client.Collection(notificationsCol).Doc(string(client)), times, defaultAttempts, putSingleTimeout); err != nil {
+		return skerr.Wrapf(err, "could not store notification times of %s", client)
+	}
+	return nil
+}
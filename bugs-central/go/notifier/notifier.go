@@ -0,0 +1,482 @@
+// Package notifier sends per-client digests of SLO violations, untriaged counts and trends over
+// email and chat, and nudges the owners of issues that are about to violate their SLOs.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	ttemplate "text/template"
+	"time"
+
+	"go.skia.org/infra/bugs-central/go/bugs"
+	"go.skia.org/infra/bugs-central/go/config"
+	"go.skia.org/infra/bugs-central/go/types"
+	"go.skia.org/infra/go/cleanup"
+	"go.skia.org/infra/go/email"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+const (
+	emailDisplayName = "Bugs Central"
+	emailFromAddress = "bugs-central@skia.org"
+
+	// How often the notifier checks whether digests or nudges are due. Must not be longer than an
+	// hour since digests and nudges are scheduled by hour.
+	tickInterval = 10 * time.Minute
+
+	// Maximum number of issues listed in chat messages.
+	maxChatIssues = 10
+
+	// Used for issues without an owner when grouping issues by owner.
+	unassignedOwner = "Unassigned"
+
+	digestEmailTemplate = `
+<h2>{{.Client}} bugs digest</h2>
+
+<table>
+  <tr><td>Open issues</td><td>{{.Counts.OpenCount}}{{.OpenTrend}}</td></tr>
+  <tr><td>Untriaged issues</td><td>{{.Counts.UntriagedCount}}{{.UntriagedTrend}}</td></tr>
+  <tr><td>Issues outside SLO</td><td>{{.OutsideSLOCount}}{{.OutsideSLOTrend}}</td></tr>
+</table>
+<br/>
+
+{{if .NewlyBreached}}
+Issues that went outside SLO in the last {{.Period}}:
+<ul>
+  {{range .NewlyBreached}}<li>{{template "issue" .}}</li>{{end}}
+</ul>
+{{else}}
+No issues went outside SLO in the last {{.Period}}.
+<br/><br/>
+{{end}}
+
+{{if .AboutToBreach}}
+Issues that will go outside SLO by {{.AboutToBreachDeadline}}:
+<ul>
+  {{range .AboutToBreach}}<li>{{template "issue" .}}</li>{{end}}
+</ul>
+{{end}}
+
+{{if .Owners}}
+Issues outside SLO or about to go outside SLO by owner:
+{{range .Owners}}
+<h4>{{.Owner}}</h4>
+<ul>
+  {{range .Issues}}<li>{{template "issue" .}}</li>{{end}}
+</ul>
+{{end}}
+{{end}}
+
+See <a href="{{.Link}}">{{.Link}}</a> for more details.
+{{define "issue"}}<a href="{{.Link}}">{{.Id}}</a> [{{.Priority}}] {{.Title}}{{if .SLOViolationReason}} - {{.SLOViolationReason}}{{end}}{{end}}
+`
+
+	digestChatTemplate = `*{{.Client}} bugs digest*
+Open issues: {{.Counts.OpenCount}}{{.OpenTrend}}
+Untriaged issues: {{.Counts.UntriagedCount}}{{.UntriagedTrend}}
+Issues outside SLO: {{.OutsideSLOCount}}{{.OutsideSLOTrend}}
+{{if .NewlyBreached}}
+Issues that went outside SLO in the last {{.Period}}:
+{{range .ChatNewlyBreached}}• <{{.Link}}|{{.Id}}> [{{.Priority}}] {{.Title}}
+{{end}}{{if .MoreNewlyBreached}}and {{.MoreNewlyBreached}} more
+{{end}}{{end}}{{if .AboutToBreach}}
+{{len .AboutToBreach}} issue(s) will go outside SLO by {{.AboutToBreachDeadline}}.
+{{end}}
+<{{.Link}}|View in Bugs Central>
+`
+
+	nudgeEmailTemplate = `
+Hi {{.Owner}},
+<br/><br/>
+
+These {{.Client}} issues assigned to you will soon be outside their SLO:
+<ul>
+  {{range .Issues}}<li><a href="{{.Issue.Link}}">{{.Issue.Id}}</a> [{{.Issue.Priority}}] {{.Issue.Title}} - goes outside SLO on {{.Deadline}}</li>{{end}}
+</ul>
+
+Updating or resolving them before then keeps them within SLO.
+<br/><br/>
+
+Thanks!
+`
+)
+
+var (
+	digestEmailTemplateParsed = template.Must(template.New("digest_email").Parse(digestEmailTemplate))
+	digestChatTemplateParsed  = ttemplate.Must(ttemplate.New("digest_chat").Parse(digestChatTemplate))
+	nudgeEmailTemplateParsed  = template.Must(template.New("nudge_email").Parse(nudgeEmailTemplate))
+)
+
+// EmailClient sends emails. It is implemented by emailclient.Client.
+type EmailClient interface {
+	SendWithMarkup(fromDisplayName string, from string, to []string, subject, body, markup, threadingReference string) (string, error)
+}
+
+// ChatSender sends the body as a message to the given chat room. Eg: chatbot.Send.
+type ChatSender func(body, room, thread string) error
+
+// Notifier sends the digests and nudges specified in the config of each client.
+type Notifier struct {
+	cfg        *config.BugsCentralCfg
+	openIssues *bugs.OpenIssues
+	dbClient   types.BugsDB
+	emailer    EmailClient
+	sendChat   ChatSender
+	// Host of the bugs central instance. Used for links in digests.
+	host string
+}
+
+// New returns a Notifier for the clients in the config.
+func New(cfg *config.BugsCentralCfg, openIssues *bugs.OpenIssues, dbClient types.BugsDB, emailer EmailClient, sendChat ChatSender, host string) *Notifier {
+	return &Notifier{
+		cfg:        cfg,
+		openIssues: openIssues,
+		dbClient:   dbClient,
+		emailer:    emailer,
+		sendChat:   sendChat,
+		host:       host,
+	}
+}
+
+// Start periodically checks whether digests or nudges are due and sends them.
+func (n *Notifier) Start(ctx context.Context) {
+	cleanup.Repeat(tickInterval, func(ctx context.Context) {
+		n.tick(ctx, time.Now())
+	}, nil)
+}
+
+// tick sends the digests and nudges that are due at the specified time and have not been sent
+// yet. When they were last sent is stored in the DB, so that they are sent only once during their
+// scheduled hour even if bugs central restarts. Errors are logged so that a failure for one client
+// does not affect the others.
+func (n *Notifier) tick(ctx context.Context, now time.Time) {
+	for _, client := range n.cfg.Clients {
+		nc := client.Notifications
+		if nc == nil {
+			continue
+		}
+		digestDue := nc.IsDigestDue(now)
+		nudgeDue := nc.IsNudgeDue(now)
+		if !digestDue && !nudgeDue {
+			continue
+		}
+		times, err := n.dbClient.GetNotificationTimes(ctx, client.Name)
+		if err != nil {
+			// Don't risk sending duplicates.
+			sklog.Errorf("Could not get notification times for %s: %s", client.Name, err)
+			continue
+		}
+		if times.LastNudges == nil {
+			times.LastNudges = map[string]time.Time{}
+		}
+		sent := false
+		if digestDue && now.Sub(times.LastDigest) > time.Hour {
+			if err := n.sendDigest(ctx, client.Name, nc, now); err != nil {
+				sklog.Errorf("Could not send digest for %s: %s", client.Name, err)
+			} else {
+				times.LastDigest = now
+				sent = true
+			}
+		}
+		if nudgeDue {
+			nudged, err := n.sendNudges(client.Name, nc, now, times.LastNudges)
+			if err != nil {
+				sklog.Errorf("Could not send nudges for %s: %s", client.Name, err)
+			}
+			sent = sent || nudged
+		}
+		if sent {
+			// Owners nudged before the previous nudge hour no longer need to be remembered.
+			for owner, ts := range times.LastNudges {
+				if now.Sub(ts) > types.Daily {
+					delete(times.LastNudges, owner)
+				}
+			}
+			if err := n.dbClient.PutNotificationTimes(ctx, client.Name, times); err != nil {
+				sklog.Errorf("Could not store notification times for %s: %s", client.Name, err)
+			}
+		}
+	}
+}
+
+// ownerIssues contains the issues of an owner.
+type ownerIssues struct {
+	Owner  string
+	Issues []*types.Issue
+}
+
+// digest contains the data used to populate the digest templates.
+type digest struct {
+	Client types.RecognizedClient
+	// Link to the client in bugs central.
+	Link string
+	// Human readable period of the digest. Eg: "week".
+	Period string
+
+	// Counts data of the latest poll.
+	Counts          *types.IssueCountsData
+	OutsideSLOCount int
+	// Changes since the previous digest. Eg: " (+3)". Empty if there is no previous data.
+	OpenTrend       string
+	UntriagedTrend  string
+	OutsideSLOTrend string
+
+	// Issues that went outside SLO since the previous digest.
+	NewlyBreached     []*types.Issue
+	ChatNewlyBreached []*types.Issue
+	MoreNewlyBreached int
+	// Issues that will go outside SLO by AboutToBreachDeadline.
+	AboutToBreach         []*types.Issue
+	AboutToBreachDeadline string
+
+	// Issues outside SLO or about to go outside SLO grouped by owner. Only populated if the config
+	// asks for grouping by owner.
+	Owners []*ownerIssues
+}
+
+// getDigest returns the digest of the client at the specified time.
+func (n *Notifier) getDigest(ctx context.Context, client types.RecognizedClient, nc *config.NotificationsCfg, now time.Time) (*digest, error) {
+	period := nc.DigestPeriod()
+	d := &digest{
+		Client: client,
+		Link:   fmt.Sprintf("https://%s/?client=%s", n.host, client),
+		Period: "day",
+	}
+	if period == types.Weekly {
+		d.Period = "week"
+	}
+
+	// Find the issues that went outside SLO during the period and the ones that will go outside
+	// SLO soon.
+	window := period
+	if nc.NudgeDays > 0 {
+		window = time.Duration(nc.NudgeDays) * types.Daily
+	}
+	aboutToBreachDeadline := now.Add(window)
+	d.AboutToBreachDeadline = formatDate(aboutToBreachDeadline)
+	ownerToIssues := map[string][]*types.Issue{}
+	for _, i := range n.openIssues.GetClientIssues(client) {
+		deadline, ok := types.GetSLODeadline(i.CreatedTime, i.ModifiedTime, i.Priority)
+		if !ok {
+			continue
+		}
+		owner := i.Owner
+		if owner == "" {
+			owner = unassignedOwner
+		}
+		if !deadline.After(now) {
+			if deadline.After(now.Add(-period)) {
+				d.NewlyBreached = append(d.NewlyBreached, i)
+			}
+			ownerToIssues[owner] = append(ownerToIssues[owner], i)
+		} else if !deadline.After(aboutToBreachDeadline) {
+			d.AboutToBreach = append(d.AboutToBreach, i)
+			ownerToIssues[owner] = append(ownerToIssues[owner], i)
+		}
+	}
+	sortByPriority(d.NewlyBreached)
+	sortByPriority(d.AboutToBreach)
+	d.ChatNewlyBreached = d.NewlyBreached
+	if len(d.ChatNewlyBreached) > maxChatIssues {
+		d.ChatNewlyBreached = d.ChatNewlyBreached[:maxChatIssues]
+		d.MoreNewlyBreached = len(d.NewlyBreached) - maxChatIssues
+	}
+	if nc.GroupByOwner {
+		for owner, issues := range ownerToIssues {
+			sortByPriority(issues)
+			d.Owners = append(d.Owners, &ownerIssues{Owner: owner, Issues: issues})
+		}
+		sort.Slice(d.Owners, func(i, j int) bool {
+			return d.Owners[i].Owner < d.Owners[j].Owner
+		})
+	}
+
+	// Find the counts of the latest poll and of the poll before the period started.
+	current, previous, err := n.getCountsTrend(ctx, client, now.Add(-period))
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	d.Counts = current
+	d.OutsideSLOCount = sloViolationCount(current)
+	if previous != nil {
+		d.OpenTrend = formatTrend(current.OpenCount - previous.OpenCount)
+		d.UntriagedTrend = formatTrend(current.UntriagedCount - previous.UntriagedCount)
+		d.OutsideSLOTrend = formatTrend(sloViolationCount(current) - sloViolationCount(previous))
+	}
+	return d, nil
+}
+
+// getCountsTrend returns the counts data of the client from the latest poll, and from the latest
+// poll at or before the specified time. The latter is nil if there is no such poll.
+func (n *Notifier) getCountsTrend(ctx context.Context, client types.RecognizedClient, before time.Time) (*types.IssueCountsData, *types.IssueCountsData, error) {
+	qds, err := n.dbClient.GetQueryDataFromDB(ctx, client, "", "")
+	if err != nil {
+		return nil, nil, skerr.Wrapf(err, "failed to get query data of %s", client)
+	}
+	validRunIds, err := n.dbClient.GetAllRecognizedRunIds(ctx)
+	if err != nil {
+		return nil, nil, skerr.Wrapf(err, "failed to get valid runIds from DB")
+	}
+
+	// Merge the counts data of all queries of each run.
+	runToCountsData := map[string]*types.IssueCountsData{}
+	for _, qd := range qds {
+		if _, ok := validRunIds[qd.RunId]; !ok {
+			// Ignore this query data since runId was not found.
+			continue
+		}
+		if _, ok := runToCountsData[qd.RunId]; !ok {
+			runToCountsData[qd.RunId] = &types.IssueCountsData{}
+		}
+		runToCountsData[qd.RunId].Merge(qd.CountsData)
+	}
+
+	var current, previous *types.IssueCountsData
+	var currentTs, previousTs time.Time
+	for runId, countsData := range runToCountsData {
+		ts, err := time.Parse(time.RFC1123, runId)
+		if err != nil {
+			return nil, nil, skerr.Wrapf(err, "could not parse runId %s", runId)
+		}
+		if current == nil || ts.After(currentTs) {
+			current, currentTs = countsData, ts
+		}
+		if !ts.After(before) && (previous == nil || ts.After(previousTs)) {
+			previous, previousTs = countsData, ts
+		}
+	}
+	if current == nil {
+		current = &types.IssueCountsData{}
+	}
+	return current, previous, nil
+}
+
+// sendDigest sends the digest of the client to the emails and chat rooms in the config.
+func (n *Notifier) sendDigest(ctx context.Context, client types.RecognizedClient, nc *config.NotificationsCfg, now time.Time) error {
+	if len(nc.Emails) == 0 && len(nc.ChatRooms) == 0 {
+		return nil
+	}
+	d, err := n.getDigest(ctx, client, nc, now)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+
+	if len(nc.Emails) > 0 {
+		var emailBytes bytes.Buffer
+		if err := digestEmailTemplateParsed.Execute(&emailBytes, d); err != nil {
+			return skerr.Wrapf(err, "failed to execute digest email template")
+		}
+		markup, err := email.GetViewActionMarkup(d.Link, "View Bugs", fmt.Sprintf("View the bugs of %s", client))
+		if err != nil {
+			return skerr.Wrapf(err, "failed to get view action markup")
+		}
+		subject := fmt.Sprintf("%s bugs digest: %d outside SLO, %d untriaged", client, d.OutsideSLOCount, d.Counts.UntriagedCount)
+		if _, err := n.emailer.SendWithMarkup(emailDisplayName, emailFromAddress, nc.Emails, subject, emailBytes.String(), markup, ""); err != nil {
+			return skerr.Wrapf(err, "failed to send digest email")
+		}
+	}
+
+	if len(nc.ChatRooms) > 0 {
+		var chatBytes bytes.Buffer
+		if err := digestChatTemplateParsed.Execute(&chatBytes, d); err != nil {
+			return skerr.Wrapf(err, "failed to execute digest chat template")
+		}
+		for _, room := range nc.ChatRooms {
+			if err := n.sendChat(chatBytes.String(), room, ""); err != nil {
+				return skerr.Wrapf(err, "failed to send digest to chat room %s", room)
+			}
+		}
+	}
+	return nil
+}
+
+// nudgeIssue is an issue that will go outside SLO on Deadline.
+type nudgeIssue struct {
+	Issue    *types.Issue
+	Deadline string
+}
+
+// sendNudges emails the owners of the client's issues that will go outside SLO within the
+// configured number of days. Owners that are not email addresses (eg: Github usernames) and owners
+// that were nudged within the last hour according to lastNudges are skipped. lastNudges is updated
+// with the owners that were successfully nudged, and true is returned if there were any. Failing to
+// nudge an owner does not prevent nudging the others.
+func (n *Notifier) sendNudges(client types.RecognizedClient, nc *config.NotificationsCfg, now time.Time, lastNudges map[string]time.Time) (bool, error) {
+	window := time.Duration(nc.NudgeDays) * types.Daily
+	ownerToIssues := map[string][]*nudgeIssue{}
+	for _, i := range n.openIssues.GetClientIssues(client) {
+		if !strings.Contains(i.Owner, "@") || now.Sub(lastNudges[i.Owner]) <= time.Hour {
+			continue
+		}
+		deadline, ok := types.GetSLODeadline(i.CreatedTime, i.ModifiedTime, i.Priority)
+		if !ok || !deadline.After(now) || deadline.After(now.Add(window)) {
+			continue
+		}
+		ownerToIssues[i.Owner] = append(ownerToIssues[i.Owner], &nudgeIssue{
+			Issue:    i,
+			Deadline: formatDate(deadline),
+		})
+	}
+
+	nudged := false
+	var errs []string
+	for owner, issues := range ownerToIssues {
+		sklog.Infof("Nudging %s about %d %s issues", owner, len(issues), client)
+		var emailBytes bytes.Buffer
+		if err := nudgeEmailTemplateParsed.Execute(&emailBytes, struct {
+			Owner  string
+			Client types.RecognizedClient
+			Issues []*nudgeIssue
+		}{
+			Owner:  owner,
+			Client: client,
+			Issues: issues,
+		}); err != nil {
+			return nudged, skerr.Wrapf(err, "failed to execute nudge email template")
+		}
+		subject := fmt.Sprintf("%d of your %s issues will soon be outside SLO", len(issues), client)
+		if _, err := n.emailer.SendWithMarkup(emailDisplayName, emailFromAddress, []string{owner}, subject, emailBytes.String(), "", ""); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to send nudge to %s: %s", owner, err))
+			continue
+		}
+		lastNudges[owner] = now
+		nudged = true
+	}
+	if len(errs) > 0 {
+		return nudged, skerr.Fmt("%s", strings.Join(errs, "; "))
+	}
+	return nudged, nil
+}
+
+// sortByPriority sorts issues by priority and then by ID.
+func sortByPriority(issues []*types.Issue) {
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Priority != issues[j].Priority {
+			return issues[i].Priority < issues[j].Priority
+		}
+		return issues[i].Id < issues[j].Id
+	})
+}
+
+// sloViolationCount returns the number of SLO violations in the counts data.
+func sloViolationCount(countsData *types.IssueCountsData) int {
+	return countsData.P0SLOViolationCount + countsData.P1SLOViolationCount + countsData.P2SLOViolationCount + countsData.P3SLOViolationCount
+}
+
+// formatTrend returns the change in a count as a suffix to display after the count.
+func formatTrend(delta int) string {
+	if delta == 0 {
+		return " (no change)"
+	}
+	return fmt.Sprintf(" (%+d)", delta)
+}
+
+// formatDate returns the date of the timestamp in a human readable format.
+func formatDate(ts time.Time) string {
+	return ts.UTC().Format("Mon Jan 2 15:04 MST")
+}
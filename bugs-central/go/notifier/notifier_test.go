@@ -0,0 +1,254 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/bugs-central/go/bugs"
+	"go.skia.org/infra/bugs-central/go/config"
+	"go.skia.org/infra/bugs-central/go/types"
+	"go.skia.org/infra/bugs-central/go/types/mocks"
+)
+
+const testClient = types.RecognizedClient("Krypton")
+
+var (
+	// Monday 2021-03-01 09:30 UTC.
+	now = time.Date(2021, time.March, 1, 9, 30, 0, 0, time.UTC)
+)
+
+type sentEmail struct {
+	to      []string
+	subject string
+	body    string
+}
+
+type fakeEmailClient struct {
+	sent []sentEmail
+	// Emails to these addresses fail.
+	failTo map[string]bool
+}
+
+func (f *fakeEmailClient) SendWithMarkup(fromDisplayName string, from string, to []string, subject, body, markup, threadingReference string) (string, error) {
+	for _, addr := range to {
+		if f.failTo[addr] {
+			return "", errors.New("failed to send")
+		}
+	}
+	f.sent = append(f.sent, sentEmail{to: to, subject: subject, body: body})
+	return "", nil
+}
+
+type sentChat struct {
+	body string
+	room string
+}
+
+// setupNotifier returns a notifier with the following open issues of testClient:
+//   - "breached": P1 issue that went outside SLO 2 days ago.
+//   - "old": P1 issue that went outside SLO 2 weeks ago.
+//   - "soon": P0 issue that goes outside SLO in 12 hours.
+//   - "later": P1 issue that goes outside SLO in 3 days.
+//   - "github": P0 issue owned by a Github user that goes outside SLO in 1 hour.
+//   - "nopri": issue without priority, which has no SLO.
+func setupNotifier(t *testing.T, nc *config.NotificationsCfg) (*Notifier, *mocks.BugsDB, *fakeEmailClient, *[]sentChat) {
+	openIssues := bugs.InitOpenIssues()
+	openIssues.PutOpenIssues(testClient, types.GithubSource, "query", []*types.Issue{
+		{Id: "breached", Link: "link/breached", Priority: types.PriorityP1, Owner: "superman@krypton.com", CreatedTime: now.Add(-types.Monthly - 2*types.Daily), ModifiedTime: now},
+		{Id: "old", Link: "link/old", Priority: types.PriorityP1, Owner: "", CreatedTime: now.Add(-types.Monthly - 2*types.Weekly), ModifiedTime: now},
+		{Id: "soon", Link: "link/soon", Priority: types.PriorityP0, Owner: "superman@krypton.com", CreatedTime: now, ModifiedTime: now.Add(-12 * time.Hour)},
+		{Id: "later", Link: "link/later", Priority: types.PriorityP1, Owner: "batman@gotham.com", CreatedTime: now.Add(-types.Monthly + 3*types.Daily), ModifiedTime: now},
+		{Id: "github", Link: "link/github", Priority: types.PriorityP0, Owner: "batman", CreatedTime: now.Add(-7*types.Daily + time.Hour), ModifiedTime: now},
+		{Id: "nopri", Link: "link/nopri", Owner: "superman@krypton.com", CreatedTime: now.Add(-types.Yearly), ModifiedTime: now.Add(-types.Yearly)},
+	})
+
+	dbClient := &mocks.BugsDB{}
+	emailer := &fakeEmailClient{}
+	chats := &[]sentChat{}
+	sendChat := func(body, room, thread string) error {
+		*chats = append(*chats, sentChat{body: body, room: room})
+		return nil
+	}
+	cfg := &config.BugsCentralCfg{
+		Clients: []*config.ClientCfg{
+			{Name: testClient, Notifications: nc},
+			{Name: "Smallville"},
+		},
+	}
+	return New(cfg, openIssues, dbClient, emailer, sendChat, "bugs-central.skia.org"), dbClient, emailer, chats
+}
+
+// mockCountsTrend mocks the DB calls made by getCountsTrend with a run from 8 days ago, a run from
+// 2 days ago and the latest run.
+func mockCountsTrend(ctx context.Context, dbClient *mocks.BugsDB) {
+	run1 := now.Add(-8 * types.Daily).Format(time.RFC1123)
+	run2 := now.Add(-2 * types.Daily).Format(time.RFC1123)
+	run3 := now.Add(-time.Hour).Format(time.RFC1123)
+	unrecognizedRun := now.Format(time.RFC1123)
+	dbClient.On("GetQueryDataFromDB", ctx, testClient, types.IssueSource(""), "").Return([]*types.QueryData{
+		{RunId: run1, CountsData: &types.IssueCountsData{OpenCount: 5, UntriagedCount: 2, P1SLOViolationCount: 1}},
+		{RunId: run1, CountsData: &types.IssueCountsData{OpenCount: 1, UntriagedCount: 1}},
+		{RunId: run2, CountsData: &types.IssueCountsData{OpenCount: 10, UntriagedCount: 10}},
+		{RunId: run3, CountsData: &types.IssueCountsData{OpenCount: 4, UntriagedCount: 3, P1SLOViolationCount: 2}},
+		{RunId: run3, CountsData: &types.IssueCountsData{OpenCount: 1}},
+		{RunId: unrecognizedRun, CountsData: &types.IssueCountsData{OpenCount: 100}},
+	}, nil)
+	dbClient.On("GetAllRecognizedRunIds", ctx).Return(map[string]bool{run1: true, run2: true, run3: true}, nil)
+}
+
+// mockNotificationTimes mocks the DB calls which get and store the notification times of
+// testClient, and returns the stored times.
+func mockNotificationTimes(ctx context.Context, dbClient *mocks.BugsDB) *types.NotificationTimes {
+	stored := &types.NotificationTimes{}
+	dbClient.On("GetNotificationTimes", ctx, testClient).Return(func(context.Context, types.RecognizedClient) *types.NotificationTimes {
+		times := &types.NotificationTimes{
+			LastDigest: stored.LastDigest,
+			LastNudges: map[string]time.Time{},
+		}
+		for owner, ts := range stored.LastNudges {
+			times.LastNudges[owner] = ts
+		}
+		return times
+	}, nil)
+	dbClient.On("PutNotificationTimes", ctx, testClient, mock.Anything).Run(func(args mock.Arguments) {
+		*stored = *args.Get(2).(*types.NotificationTimes)
+	}).Return(nil)
+	return stored
+}
+
+func TestGetDigest_Weekly_GroupByOwner(t *testing.T) {
+	ctx := context.Background()
+	nc := &config.NotificationsCfg{Weekday: "Monday", HourUTC: 9, GroupByOwner: true}
+	n, dbClient, _, _ := setupNotifier(t, nc)
+	mockCountsTrend(ctx, dbClient)
+	defer dbClient.AssertExpectations(t)
+
+	d, err := n.getDigest(ctx, testClient, nc, now)
+	require.NoError(t, err)
+	require.Equal(t, "week", d.Period)
+	require.Equal(t, "https://bugs-central.skia.org/?client=Krypton", d.Link)
+	// Counts are from the latest run and trends are against the run from 8 days ago.
+	require.Equal(t, 5, d.Counts.OpenCount)
+	require.Equal(t, 2, d.OutsideSLOCount)
+	require.Equal(t, " (-1)", d.OpenTrend)
+	require.Equal(t, " (no change)", d.UntriagedTrend)
+	require.Equal(t, " (+1)", d.OutsideSLOTrend)
+
+	require.Len(t, d.NewlyBreached, 1)
+	require.Equal(t, "breached", d.NewlyBreached[0].Id)
+	// Without nudge_days, issues going outside SLO within the next week are included.
+	require.Len(t, d.AboutToBreach, 3)
+	require.Equal(t, "github", d.AboutToBreach[0].Id)
+	require.Equal(t, "soon", d.AboutToBreach[1].Id)
+	require.Equal(t, "later", d.AboutToBreach[2].Id)
+
+	require.Len(t, d.Owners, 4)
+	require.Equal(t, "Unassigned", d.Owners[0].Owner)
+	require.Equal(t, "old", d.Owners[0].Issues[0].Id)
+	require.Equal(t, "batman", d.Owners[1].Owner)
+	require.Equal(t, "batman@gotham.com", d.Owners[2].Owner)
+	require.Equal(t, "superman@krypton.com", d.Owners[3].Owner)
+	require.Len(t, d.Owners[3].Issues, 2)
+	// Issues are sorted by priority.
+	require.Equal(t, "soon", d.Owners[3].Issues[0].Id)
+	require.Equal(t, "breached", d.Owners[3].Issues[1].Id)
+}
+
+func TestGetDigest_Daily_NoPreviousRun(t *testing.T) {
+	ctx := context.Background()
+	nc := &config.NotificationsCfg{HourUTC: 9, NudgeDays: 1}
+	n, dbClient, _, _ := setupNotifier(t, nc)
+	dbClient.On("GetQueryDataFromDB", ctx, testClient, types.IssueSource(""), "").Return([]*types.QueryData{}, nil)
+	dbClient.On("GetAllRecognizedRunIds", ctx).Return(map[string]bool{}, nil)
+	defer dbClient.AssertExpectations(t)
+
+	d, err := n.getDigest(ctx, testClient, nc, now)
+	require.NoError(t, err)
+	require.Equal(t, "day", d.Period)
+	require.Equal(t, 0, d.Counts.OpenCount)
+	require.Equal(t, "", d.OpenTrend)
+	// The issue that went outside SLO 2 days ago is not new for a daily digest.
+	require.Empty(t, d.NewlyBreached)
+	// nudge_days limits the issues that are about to go outside SLO.
+	require.Len(t, d.AboutToBreach, 2)
+	require.Equal(t, "github", d.AboutToBreach[0].Id)
+	require.Equal(t, "soon", d.AboutToBreach[1].Id)
+	require.Empty(t, d.Owners)
+}
+
+func TestTick_SendsDigestAndNudgesOncePerSchedule(t *testing.T) {
+	ctx := context.Background()
+	nc := &config.NotificationsCfg{
+		Emails:    []string{"kal-el@krypton.com"},
+		ChatRooms: []string{"krypton-bugs"},
+		Weekday:   "Monday",
+		HourUTC:   9,
+		NudgeDays: 1,
+	}
+	n, dbClient, emailer, chats := setupNotifier(t, nc)
+	mockCountsTrend(ctx, dbClient)
+	stored := mockNotificationTimes(ctx, dbClient)
+	defer dbClient.AssertExpectations(t)
+
+	n.tick(ctx, now)
+	require.Len(t, emailer.sent, 2)
+	digestEmail := emailer.sent[0]
+	require.Equal(t, []string{"kal-el@krypton.com"}, digestEmail.to)
+	require.Equal(t, "Krypton bugs digest: 2 outside SLO, 3 untriaged", digestEmail.subject)
+	require.Contains(t, digestEmail.body, `<a href="link/breached">breached</a> [P1]`)
+	// Only owners that are email addresses are nudged about issues going outside SLO within a day.
+	nudgeEmail := emailer.sent[1]
+	require.Equal(t, []string{"superman@krypton.com"}, nudgeEmail.to)
+	require.Equal(t, "1 of your Krypton issues will soon be outside SLO", nudgeEmail.subject)
+	require.Contains(t, nudgeEmail.body, `<a href="link/soon">soon</a> [P0]`)
+	require.NotContains(t, nudgeEmail.body, "breached")
+	require.Len(t, *chats, 1)
+	require.Equal(t, "krypton-bugs", (*chats)[0].room)
+	require.Contains(t, (*chats)[0].body, "*Krypton bugs digest*\nOpen issues: 5 (-1)\n")
+	require.Contains(t, (*chats)[0].body, "• <link/breached|breached> [P1]")
+
+	require.Equal(t, now, stored.LastDigest)
+	require.Equal(t, map[string]time.Time{"superman@krypton.com": now}, stored.LastNudges)
+
+	// Nothing is sent again during the same hour, even after a restart.
+	n.tick(ctx, now.Add(20*time.Minute))
+	n = New(n.cfg, n.openIssues, dbClient, emailer, n.sendChat, n.host)
+	n.tick(ctx, now.Add(40*time.Minute))
+	require.Len(t, emailer.sent, 2)
+	require.Len(t, *chats, 1)
+
+	// Two days later only nudges are sent.
+	n.tick(ctx, now.Add(2*types.Daily))
+	require.Len(t, emailer.sent, 3)
+	require.Equal(t, []string{"batman@gotham.com"}, emailer.sent[2].to)
+	require.Contains(t, emailer.sent[2].body, `<a href="link/later">later</a> [P1]`)
+	require.Len(t, *chats, 1)
+}
+
+func TestTick_NudgeFails_OnlyFailedOwnerRetried(t *testing.T) {
+	ctx := context.Background()
+	nc := &config.NotificationsCfg{HourUTC: 9, NudgeDays: 4}
+	n, dbClient, emailer, _ := setupNotifier(t, nc)
+	stored := mockNotificationTimes(ctx, dbClient)
+	defer dbClient.AssertExpectations(t)
+
+	emailer.failTo = map[string]bool{"batman@gotham.com": true}
+	n.tick(ctx, now)
+	require.Len(t, emailer.sent, 1)
+	require.Equal(t, []string{"superman@krypton.com"}, emailer.sent[0].to)
+	require.Equal(t, map[string]time.Time{"superman@krypton.com": now}, stored.LastNudges)
+
+	emailer.failTo = nil
+	n.tick(ctx, now.Add(10*time.Minute))
+	require.Len(t, emailer.sent, 2)
+	require.Equal(t, []string{"batman@gotham.com"}, emailer.sent[1].to)
+	require.Equal(t, map[string]time.Time{
+		"superman@krypton.com": now,
+		"batman@gotham.com":    now.Add(10 * time.Minute),
+	}, stored.LastNudges)
+}
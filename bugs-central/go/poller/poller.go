@@ -14,8 +14,11 @@ import (
 
 	"go.skia.org/infra/bugs-central/go/bugs"
 	"go.skia.org/infra/bugs-central/go/bugs/github"
+	"go.skia.org/infra/bugs-central/go/bugs/gitlab"
 	"go.skia.org/infra/bugs-central/go/bugs/issuetracker"
+	"go.skia.org/infra/bugs-central/go/bugs/jira"
 	"go.skia.org/infra/bugs-central/go/bugs/monorail"
+	"go.skia.org/infra/bugs-central/go/config"
 	"go.skia.org/infra/bugs-central/go/types"
 	"go.skia.org/infra/go/baseapp"
 	"go.skia.org/infra/go/cleanup"
@@ -30,13 +33,14 @@ type IssuesPoller struct {
 	storageClient            *storage.Client
 	pathToGithubToken        string
 	pathToServiceAccountFile string
+	cfg                      *config.BugsCentralCfg
 
 	dbClient   types.BugsDB
 	openIssues *bugs.OpenIssues
 }
 
 // New returns an instance of IssuesPoller.
-func New(ctx context.Context, ts oauth2.TokenSource, pathToServiceAccountFile string, dbClient types.BugsDB) (*IssuesPoller, error) {
+func New(ctx context.Context, ts oauth2.TokenSource, pathToServiceAccountFile string, dbClient types.BugsDB, cfg *config.BugsCentralCfg) (*IssuesPoller, error) {
 	httpClient := httputils.DefaultClientConfig().WithTokenSource(ts).With2xxOnly().Client()
 	storageClient, err := storage.NewClient(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
@@ -60,6 +64,7 @@ func New(ctx context.Context, ts oauth2.TokenSource, pathToServiceAccountFile st
 		storageClient:            storageClient,
 		pathToGithubToken:        pathToGithubToken,
 		pathToServiceAccountFile: pathToServiceAccountFile,
+		cfg:                      cfg,
 		dbClient:                 dbClient,
 		openIssues:               openIssues,
 	}, nil
@@ -70,121 +75,46 @@ func (p *IssuesPoller) GetOpenIssues() *bugs.OpenIssues {
 	return p.openIssues
 }
 
+// initBugFrameworks instantiates the bug frameworks of all the queries in the config.
+func (p *IssuesPoller) initBugFrameworks(ctx context.Context) ([]bugs.BugFramework, error) {
+	bugFrameworks := []bugs.BugFramework{}
+	for _, client := range p.cfg.Clients {
+		for _, q := range client.Queries {
+			source, err := q.Source()
+			if err != nil {
+				return nil, skerr.Wrapf(err, "invalid query for %s", client.Name)
+			}
+			var b bugs.BugFramework
+			switch source {
+			case types.GithubSource:
+				b, err = github.New(ctx, q.Github.RepoOwner, q.Github.RepoName, p.pathToGithubToken, p.openIssues, &q.Github.GithubQueryConfig)
+			case types.GitLabSource:
+				b, err = gitlab.New(p.openIssues, q.GitLab)
+			case types.IssueTrackerSource:
+				b, err = issuetracker.New(p.storageClient, p.openIssues, q.IssueTracker)
+			case types.JiraSource:
+				b, err = jira.New(p.openIssues, q.Jira)
+			case types.MonorailSource:
+				b, err = monorail.New(ctx, p.pathToServiceAccountFile, p.openIssues, q.Monorail)
+			}
+			if err != nil {
+				return nil, skerr.Wrapf(err, "failed to init %s for %s", source, client.Name)
+			}
+			bugFrameworks = append(bugFrameworks, b)
+		}
+	}
+	return bugFrameworks, nil
+}
+
 // Start polls the different issue frameworks and populates DB and an in-memory object with that data.
-// It hardcodes information about Skia's various clients. It may be possible to extract some/all of these into
-// flags or YAML config files in the future.
+// The clients and their queries are read from the config passed to New.
 func (p *IssuesPoller) Start(ctx context.Context, pollInterval time.Duration) error {
 
 	// Instantiate the bug frameworks with the different client configurations and then poll them.
-	bugFrameworks := []bugs.BugFramework{}
-
-	//////////////////// Android - IssueTracker ////////////////////
-	androidQueryConfig := &issuetracker.IssueTrackerQueryConfig{
-		Query:               "componentid:1346 status:open",
-		Client:              types.AndroidClient,
-		UntriagedPriorities: []string{},
-		UntriagedAliases:    []string{"skia-android-triage@google.com", "none"},
-		HotlistsToExclude:   []int64{4595112},
-	}
-	androidIssueTracker, err := issuetracker.New(p.storageClient, p.openIssues, androidQueryConfig)
-	if err != nil {
-		return skerr.Wrapf(err, "failed to init issuetracker for android")
-	}
-	bugFrameworks = append(bugFrameworks, androidIssueTracker)
-
-	//////////////////// Flutter_on_web - Github ////////////////////
-	flutterOnWebQueryConfig := &github.GithubQueryConfig{
-		Labels:           []string{"e: web_canvaskit"},
-		Open:             true,
-		PriorityRequired: true,
-		Client:           types.FlutterOnWebClient,
-	}
-	flutterOnWebGithub, err := github.New(ctx, "flutter", "flutter", p.pathToGithubToken, p.openIssues, flutterOnWebQueryConfig)
-	if err != nil {
-		return skerr.Wrapf(err, "failed to init github for flutter-on-web")
-	}
-	bugFrameworks = append(bugFrameworks, flutterOnWebGithub)
-
-	//////////////////// Flutter_native - Github ////////////////////
-	flutterNativeQueryConfig := &github.GithubQueryConfig{
-		Labels:           []string{"dependency: skia"},
-		ExcludeLabels:    []string{"e: web_canvaskit"}, // These issues are already covered by flutter-on-web
-		Open:             true,
-		PriorityRequired: false,
-		Client:           types.FlutterNativeClient,
-	}
-	flutterNativeGithub, err := github.New(ctx, "flutter", "flutter", p.pathToGithubToken, p.openIssues, flutterNativeQueryConfig)
-	if err != nil {
-		return skerr.Wrapf(err, "failed to init github for flutter-on-web")
-	}
-	bugFrameworks = append(bugFrameworks, flutterNativeGithub)
-
-	//////////////////// Chromium:Internals>Skia - Monorail ////////////////////
-	crQueryConfig1 := &monorail.MonorailQueryConfig{
-		Instance:          "chromium",
-		Query:             "is:open component=Internals>Skia",
-		Client:            types.ChromiumClient,
-		UntriagedStatuses: []string{"Untriaged", "Unconfirmed"},
-	}
-	crMonorail1, err := monorail.New(ctx, p.pathToServiceAccountFile, p.openIssues, crQueryConfig1)
-	if err != nil {
-		return skerr.Wrapf(err, "failed to init monorail for chromium")
-	}
-	bugFrameworks = append(bugFrameworks, crMonorail1)
-
-	//////////////////// Chromium:Internals>Skia>Compositing - Monorail ////////////////////
-	crQueryConfig2 := &monorail.MonorailQueryConfig{
-		Instance:          "chromium",
-		Query:             "is:open component=Internals>Skia>Compositing",
-		Client:            types.ChromiumClient,
-		UntriagedStatuses: []string{"Untriaged", "Unconfirmed"},
-	}
-	crMonorail2, err := monorail.New(ctx, p.pathToServiceAccountFile, p.openIssues, crQueryConfig2)
-	if err != nil {
-		return skerr.Wrapf(err, "failed to init monorail for chromium")
-	}
-	bugFrameworks = append(bugFrameworks, crMonorail2)
-
-	//////////////////// Chromium:Internals>Skia>PDF - Monorail ////////////////////
-	crQueryConfig3 := &monorail.MonorailQueryConfig{
-		Instance:          "chromium",
-		Query:             "is:open component=Internals>Skia>PDF",
-		Client:            types.ChromiumClient,
-		UntriagedStatuses: []string{"Untriaged", "Unconfirmed"},
-	}
-	crMonorail3, err := monorail.New(ctx, p.pathToServiceAccountFile, p.openIssues, crQueryConfig3)
-	if err != nil {
-		return skerr.Wrapf(err, "failed to init monorail for chromium")
-	}
-	bugFrameworks = append(bugFrameworks, crMonorail3)
-
-	//////////////////// Skia - Buganizer ////////////////////
-	skiaIssueTrackerQueryConfig := &issuetracker.IssueTrackerQueryConfig{
-		Query:                 "componentid:1363359+ status:open -componentid:1389238+ created>2023-10-01",
-		Client:                types.SkiaClient,
-		UntriagedPriorities:   []string{},
-		UntriagedAliases:      []string{"none"},
-		UnassignedIsUntriaged: true,
-	}
-	skiaIssueTracker, err := issuetracker.New(p.storageClient, p.openIssues, skiaIssueTrackerQueryConfig)
-	if err != nil {
-		return skerr.Wrapf(err, "failed to init issuetracker for skia")
-	}
-	bugFrameworks = append(bugFrameworks, skiaIssueTracker)
-
-	//////////////////// OSS-Fuzz - Monorail ////////////////////
-	fuzzQueryConfig := &monorail.MonorailQueryConfig{
-		Instance:              "oss-fuzz",
-		Query:                 "is:open proj=Skia",
-		Client:                types.OSSFuzzClient,
-		UntriagedStatuses:     []string{"New"},
-		UnassignedIsUntriaged: true,
-	}
-	fuzzMonorail, err := monorail.New(ctx, p.pathToServiceAccountFile, p.openIssues, fuzzQueryConfig)
+	bugFrameworks, err := p.initBugFrameworks(ctx)
 	if err != nil {
-		return skerr.Wrapf(err, "failed to init monorail for oss-fuzz")
+		return skerr.Wrap(err)
 	}
-	bugFrameworks = append(bugFrameworks, fuzzMonorail)
 
 	cleanup.Repeat(pollInterval, func(ctx context.Context) {
 		if !*baseapp.Local {
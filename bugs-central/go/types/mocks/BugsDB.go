@@ -101,6 +101,29 @@ func (_m *BugsDB) GetCountsFromDB(ctx context.Context, client types.RecognizedCl
 	return r0, r1
 }
 
+// GetNotificationTimes provides a mock function with given fields: ctx, client
+func (_m *BugsDB) GetNotificationTimes(ctx context.Context, client types.RecognizedClient) (*types.NotificationTimes, error) {
+	ret := _m.Called(ctx, client)
+
+	var r0 *types.NotificationTimes
+	if rf, ok := ret.Get(0).(func(context.Context, types.RecognizedClient) *types.NotificationTimes); ok {
+		r0 = rf(ctx, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.NotificationTimes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.RecognizedClient) error); ok {
+		r1 = rf(ctx, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetQueryDataFromDB provides a mock function with given fields: ctx, client, source, query
 func (_m *BugsDB) GetQueryDataFromDB(ctx context.Context, client types.RecognizedClient, source types.IssueSource, query string) ([]*types.QueryData, error) {
 	ret := _m.Called(ctx, client, source, query)
@@ -138,6 +161,20 @@ func (_m *BugsDB) PutInDB(ctx context.Context, client types.RecognizedClient, so
 	return r0
 }
 
+// PutNotificationTimes provides a mock function with given fields: ctx, client, times
+func (_m *BugsDB) PutNotificationTimes(ctx context.Context, client types.RecognizedClient, times *types.NotificationTimes) error {
+	ret := _m.Called(ctx, client, times)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.RecognizedClient, *types.NotificationTimes) error); ok {
+		r0 = rf(ctx, client, times)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreRunId provides a mock function with given fields: ctx, runId
 func (_m *BugsDB) StoreRunId(ctx context.Context, runId string) error {
 	ret := _m.Called(ctx, runId)
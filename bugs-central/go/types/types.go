@@ -3,24 +3,19 @@ package types
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/hako/durafmt"
 )
 
 const (
-	// All recognized clients.
-	AndroidClient       RecognizedClient = "Android"
-	ChromiumClient      RecognizedClient = "Chromium"
-	FlutterNativeClient RecognizedClient = "Flutter-native"
-	FlutterOnWebClient  RecognizedClient = "Flutter-on-web"
-	SkiaClient          RecognizedClient = "Skia"
-	OSSFuzzClient       RecognizedClient = "OSS-Fuzz"
-
 	// All recognized bug frameworks.
 	GithubSource       IssueSource = "Github"
 	MonorailSource     IssueSource = "Monorail"
 	IssueTrackerSource IssueSource = "Buganizer"
+	JiraSource         IssueSource = "Jira"
+	GitLabSource       IssueSource = "GitLab"
 
 	// All bug frameworks will be standardized to these priorities.
 	PriorityP0 StandardizedPriority = "P0"
@@ -68,6 +63,21 @@ type BugsDB interface {
 
 	// Stores the specified run ID in the DB.
 	StoreRunId(ctx context.Context, runId string) error
+
+	// GetNotificationTimes returns when notifications were last sent for the client. An empty
+	// NotificationTimes is returned if none have been sent.
+	GetNotificationTimes(ctx context.Context, client RecognizedClient) (*NotificationTimes, error)
+
+	// PutNotificationTimes stores when notifications were last sent for the client.
+	PutNotificationTimes(ctx context.Context, client RecognizedClient, times *NotificationTimes) error
+}
+
+// NotificationTimes records when notifications were last sent for a client, so that they are not
+// sent again if bugs central restarts during their scheduled hour.
+type NotificationTimes struct {
+	LastDigest time.Time `json:"last_digest"`
+	// Maps the owners of issues to when they were last nudged.
+	LastNudges map[string]time.Time `json:"last_nudges"`
 }
 
 // QueryData is the type that will be stored in BugsDB.
@@ -113,39 +123,54 @@ type GetClientsResponse struct {
 	Clients map[RecognizedClient]map[IssueSource]map[string]bool `json:"clients"`
 }
 
-type sloViolationData struct {
+// SLO is the service level objective for open issues of a priority.
+type SLO struct {
 	// If an open issue's last modified time is beyond this duration then it is an SLO violation.
-	modifiedDuration time.Duration
+	ModifiedDuration time.Duration
 	// If an open issue's creation time is beyond this duration then it is an SLO violation.
-	createdDuration time.Duration
+	CreatedDuration time.Duration
 }
 
 var (
-	// Uses data from https://docs.google.com/document/d/1OgpX1KDDq3YkHzRJjqRHSPJ9CJ8hH0RTvMAApKVxwm8/edit
-	priorityToSLOViolationData = map[StandardizedPriority]sloViolationData{
+	// DefaultSLOs uses data from https://docs.google.com/document/d/1OgpX1KDDq3YkHzRJjqRHSPJ9CJ8hH0RTvMAApKVxwm8/edit
+	DefaultSLOs = map[StandardizedPriority]SLO{
 		PriorityP0: {
-			modifiedDuration: Daily,
-			createdDuration:  Weekly,
+			ModifiedDuration: Daily,
+			CreatedDuration:  Weekly,
 		},
 		PriorityP1: {
-			modifiedDuration: Weekly,
-			createdDuration:  Monthly,
+			ModifiedDuration: Weekly,
+			CreatedDuration:  Monthly,
 		},
 		PriorityP2: {
-			modifiedDuration: Biannualy,
-			createdDuration:  Yearly,
+			ModifiedDuration: Biannualy,
+			CreatedDuration:  Yearly,
 		},
 		PriorityP3: {
-			modifiedDuration: Yearly,
-			createdDuration:  Biennialy,
+			ModifiedDuration: Yearly,
+			CreatedDuration:  Biennialy,
 		},
 	}
+
+	// The SLOs used to calculate SLO violations. Defaults to DefaultSLOs.
+	priorityToSLO = DefaultSLOs
+	// Mutex to access the above map.
+	mtxSLOs sync.RWMutex
 )
 
+// SetSLOs replaces the SLOs used to calculate SLO violations. Priorities without an SLO are never
+// in violation.
+func SetSLOs(slos map[StandardizedPriority]SLO) {
+	mtxSLOs.Lock()
+	defer mtxSLOs.Unlock()
+	priorityToSLO = slos
+}
+
 // IssueSource types will be all the recognized issue frameworks (eg: Github, IssueTracker, Monorail).
 type IssueSource string
 
-// RecognizedClient types will be all the recognized Skia clients (eg: Android, Chromium, Flutter).
+// RecognizedClient types will be all the clients whose issues are tracked (eg: Android, Chromium,
+// Flutter). Clients are defined in the bugs central config file.
 type RecognizedClient string
 
 // StandardizedPriority types will be the priorities used across issue frameworks.
@@ -276,14 +301,34 @@ func (icd *IssueCountsData) IncPriority(priority StandardizedPriority) {
 // If issue has violated SLO then returns description and a duration that shows by how much
 // it was surpassed.
 func IsPrioritySLOViolation(now, created, modified time.Time, priority StandardizedPriority) (bool, string, time.Duration) {
-	if sloViolationData, ok := priorityToSLOViolationData[priority]; ok {
-		if now.After(modified.Add(sloViolationData.modifiedDuration)) {
-			duration := now.Sub(modified.Add(sloViolationData.modifiedDuration))
+	mtxSLOs.RLock()
+	slo, ok := priorityToSLO[priority]
+	mtxSLOs.RUnlock()
+	if ok {
+		if now.After(modified.Add(slo.ModifiedDuration)) {
+			duration := now.Sub(modified.Add(slo.ModifiedDuration))
 			return true, fmt.Sprintf("exceeded modified time SLO by %s", durafmt.Parse(duration).LimitFirstN(2)), duration
-		} else if now.After(created.Add(sloViolationData.createdDuration)) {
-			duration := now.Sub(created.Add(sloViolationData.createdDuration))
+		} else if now.After(created.Add(slo.CreatedDuration)) {
+			duration := now.Sub(created.Add(slo.CreatedDuration))
 			return true, fmt.Sprintf("exceeded creation time SLO by %s", durafmt.Parse(duration).LimitFirstN(2)), duration
 		}
 	}
 	return false, "", 0
 }
+
+// GetSLODeadline returns the time at which an open issue with the specified priority will violate
+// (or has violated) the SLO. This is the earlier of its modified time and creation time SLOs.
+// Returns false if the priority has no SLO.
+func GetSLODeadline(created, modified time.Time, priority StandardizedPriority) (time.Time, bool) {
+	mtxSLOs.RLock()
+	slo, ok := priorityToSLO[priority]
+	mtxSLOs.RUnlock()
+	if !ok {
+		return time.Time{}, false
+	}
+	deadline := modified.Add(slo.ModifiedDuration)
+	if createdDeadline := created.Add(slo.CreatedDuration); createdDeadline.Before(deadline) {
+		deadline = createdDeadline
+	}
+	return deadline, true
+}
@@ -57,6 +57,50 @@ func TestIncSLOViolation(t *testing.T) {
 	}
 }
 
+func TestSetSLOs(t *testing.T) {
+	defer SetSLOs(DefaultSLOs)
+
+	now := time.Unix(1405544146, 0)
+	after2Days := now.Add(2 * Daily)
+
+	// With the default SLOs a P1 issue modified 2 days ago is within SLO and P4 has no SLO.
+	violation, _, _ := IsPrioritySLOViolation(after2Days, now, now, PriorityP1)
+	require.False(t, violation)
+
+	SetSLOs(map[StandardizedPriority]SLO{
+		PriorityP1: {ModifiedDuration: Daily, CreatedDuration: Weekly},
+		PriorityP4: {ModifiedDuration: Weekly, CreatedDuration: Daily},
+	})
+	violation, reason, d := IsPrioritySLOViolation(after2Days, now, now, PriorityP1)
+	require.True(t, violation)
+	require.Equal(t, "exceeded modified time SLO by 1 day", reason)
+	require.Equal(t, Daily, d)
+	violation, reason, d = IsPrioritySLOViolation(after2Days, now, now, PriorityP4)
+	require.True(t, violation)
+	require.Equal(t, "exceeded creation time SLO by 1 day", reason)
+	require.Equal(t, Daily, d)
+	// P0 no longer has an SLO.
+	violation, _, _ = IsPrioritySLOViolation(after2Days, now, now, PriorityP0)
+	require.False(t, violation)
+}
+
+func TestGetSLODeadline(t *testing.T) {
+	created := time.Unix(1405544146, 0)
+	modified := created.Add(Weekly)
+
+	// The P0 modified time SLO expires after the creation time SLO.
+	deadline, ok := GetSLODeadline(created, modified, PriorityP0)
+	require.True(t, ok)
+	require.Equal(t, created.Add(Weekly), deadline)
+	// The P1 modified time SLO expires first.
+	deadline, ok = GetSLODeadline(created, modified, PriorityP1)
+	require.True(t, ok)
+	require.Equal(t, modified.Add(Weekly), deadline)
+	// P4 has no SLO.
+	_, ok = GetSLODeadline(created, modified, PriorityP4)
+	require.False(t, ok)
+}
+
 func TestMergeInfo(t *testing.T) {
 
 	to := IssueCountsData{
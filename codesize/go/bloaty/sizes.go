@@ -0,0 +1,107 @@
+package bloaty
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// SizeSummary summarizes the size of a binary.
+type SizeSummary struct {
+	// FileSize is the total number of bytes the binary takes on disk.
+	FileSize int `json:"file_size"`
+
+	// VirtualMemorySize is the total number of bytes the binary takes when it is loaded into
+	// memory.
+	VirtualMemorySize int `json:"vm_size"`
+
+	// SymbolGroups maps symbol groups (see SymbolGroup) to their total file size.
+	SymbolGroups map[string]int `json:"symbol_groups"`
+}
+
+// SymbolGroup returns the group that the symbols in the given compile unit are tracked under over
+// time. Groups are coarse enough to be stable across commits, e.g. "src/core" for
+// "src/core/SkCanvas.cpp", "third_party/freetype" for "third_party/freetype/src/base/ftobjs.c" and
+// "[section .rodata]" for "[section .rodata]".
+func SymbolGroup(compileUnit string) string {
+	if strings.HasPrefix(compileUnit, "[") {
+		return compileUnit
+	}
+	dir := path.Dir(compileUnit)
+	if dir == "." {
+		return compileUnit
+	}
+	parts := strings.SplitN(dir, "/", 3)
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, "/")
+}
+
+// Summarize returns the SizeSummary of the given parsed Bloaty output.
+func Summarize(items []OutputItem) SizeSummary {
+	summary := SizeSummary{
+		SymbolGroups: map[string]int{},
+	}
+	for _, item := range items {
+		summary.FileSize += item.FileSize
+		summary.VirtualMemorySize += item.VirtualMemorySize
+		summary.SymbolGroups[SymbolGroup(item.CompileUnit)] += item.FileSize
+	}
+	return summary
+}
+
+// ParseSizeDiffTotal parses a Bloaty size diff output in plain-text format, i.e. the output of
+// an invocation such as the following:
+//
+//	$ bloaty <path/to/binary> -- <path/to/base/binary>
+//
+// and returns the change in file size and VM size reported on its TOTAL line, in bytes.
+func ParseSizeDiffTotal(diff string) (int, int, error) {
+	for _, line := range strings.Split(diff, "\n") {
+		// Bloaty prints unchanged sizes as "[ = ]" instead of a percentage.
+		fields := strings.Fields(strings.ReplaceAll(line, "[ = ]", "="))
+		if len(fields) != 5 || fields[4] != "TOTAL" {
+			continue
+		}
+		fileSizeDelta, err := parseSizeDelta(fields[1])
+		if err != nil {
+			return 0, 0, skerr.Wrapf(err, "invalid file size on TOTAL line %q", line)
+		}
+		vmSizeDelta, err := parseSizeDelta(fields[3])
+		if err != nil {
+			return 0, 0, skerr.Wrapf(err, "invalid VM size on TOTAL line %q", line)
+		}
+		return fileSizeDelta, vmSizeDelta, nil
+	}
+	return 0, 0, skerr.Fmt("no TOTAL line found in Bloaty size diff output")
+}
+
+// sizeSuffixes are the suffixes Bloaty uses for human-readable sizes.
+var sizeSuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Gi", 1 << 30},
+	{"Mi", 1 << 20},
+	{"Ki", 1 << 10},
+}
+
+// parseSizeDelta parses a size change as printed by Bloaty, e.g. "+328", "-1.50Ki" or "0".
+func parseSizeDelta(s string) (int, error) {
+	multiplier := 1.0
+	for _, suffix := range sizeSuffixes {
+		if strings.HasSuffix(s, suffix.suffix) {
+			s = strings.TrimSuffix(s, suffix.suffix)
+			multiplier = suffix.multiplier
+			break
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, skerr.Wrap(err)
+	}
+	return int(f * multiplier), nil
+}
@@ -0,0 +1,60 @@
+package bloaty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymbolGroup_VariousCompileUnits_GroupedByTopLevelDirs(t *testing.T) {
+	assert.Equal(t, "src/core", SymbolGroup("src/core/SkCanvas.cpp"))
+	assert.Equal(t, "src/core", SymbolGroup("src/core/deep/dir/SkFoo.cpp"))
+	assert.Equal(t, "third_party/freetype", SymbolGroup("third_party/freetype/src/base/ftobjs.c"))
+	assert.Equal(t, "dm", SymbolGroup("dm/DM.cpp"))
+	assert.Equal(t, "foo.cpp", SymbolGroup("foo.cpp"))
+	assert.Equal(t, "[section .rodata]", SymbolGroup("[section .rodata]"))
+}
+
+func TestSummarize_Success(t *testing.T) {
+	summary := Summarize([]OutputItem{
+		{CompileUnit: "src/core/SkCanvas.cpp", Symbol: "a", VirtualMemorySize: 10, FileSize: 12},
+		{CompileUnit: "src/core/SkPaint.cpp", Symbol: "b", VirtualMemorySize: 20, FileSize: 22},
+		{CompileUnit: "dm/DM.cpp", Symbol: "c", VirtualMemorySize: 30, FileSize: 32},
+	})
+	assert.Equal(t, SizeSummary{
+		FileSize:          66,
+		VirtualMemorySize: 60,
+		SymbolGroups: map[string]int{
+			"src/core": 34,
+			"dm":       32,
+		},
+	}, summary)
+}
+
+func TestParseSizeDiffTotal_Success(t *testing.T) {
+	diff := `    FILE SIZE        VM SIZE
+ --------------  --------------
+  +1.2%    +584  +1.2%    +584    src/core/SkCanvas.cpp
+  [NEW] +1.50Ki  [NEW] +1.50Ki    src/core/SkNew.cpp
+  -0.5%    -128  [ = ]       0    src/core/SkPaint.cpp
+  +0.1% +1.95Ki  +0.1% +1.95Ki    TOTAL
+`
+	fileSizeDelta, vmSizeDelta, err := ParseSizeDiffTotal(diff)
+	require.NoError(t, err)
+	assert.Equal(t, 1996, fileSizeDelta)
+	assert.Equal(t, 1996, vmSizeDelta)
+}
+
+func TestParseSizeDiffTotal_UnchangedAndShrunk_Success(t *testing.T) {
+	fileSizeDelta, vmSizeDelta, err := ParseSizeDiffTotal("  -0.1%    -328  [ = ]       0    TOTAL\n")
+	require.NoError(t, err)
+	assert.Equal(t, -328, fileSizeDelta)
+	assert.Equal(t, 0, vmSizeDelta)
+}
+
+func TestParseSizeDiffTotal_NoTotalLine_Error(t *testing.T) {
+	_, _, err := ParseSizeDiffTotal("  +1.2%    +584  +1.2%    +584    src/core/SkCanvas.cpp\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no TOTAL line")
+}
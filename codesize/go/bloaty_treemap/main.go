@@ -7,27 +7,37 @@ package main
 //
 //     $ bloaty <path/to/binary> -d compileunits,symbols -n 0 --tsv | bloaty_treemap > index.html
 //
+// Other size reports supported by the sizereport package can be read via the --format flag, e.g.:
+//
+//     $ bloaty_treemap --format=linker_map < <path/to/binary.map> > index.html
+//
 // [1] https://skia.googlesource.com/skia/+/5a7d91c35beb48afce9362852f1f5e26f7550ba8/tools/bloaty_treemap.py
 // [2] https://github.com/google/bloaty
 
 import (
 	_ "embed"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
 	"text/template"
 
 	"go.skia.org/infra/codesize/go/bloaty"
+	"go.skia.org/infra/codesize/go/sizereport"
 )
 
 //go:embed template.html
 var templateHTML string
 
+var format = flag.String("format", string(sizereport.BloatyTSV), fmt.Sprintf("Format of the size report read from stdin. One of %v.", sizereport.AllFormats))
+
 func main() {
+	flag.Parse()
+
 	stdin, err := os.ReadFile(os.Stdin.Name())
 	ifErrThenDie(err)
 
-	bloatyOutputItems, err := bloaty.ParseTSVOutput(string(stdin))
+	bloatyOutputItems, err := sizereport.Parse(sizereport.Format(*format), stdin)
 	ifErrThenDie(err)
 
 	tmpl, err := template.New("template").Parse(templateHTML) // The template name does not matter.
@@ -0,0 +1,98 @@
+// Package budgets defines per-binary code size budgets, and checks binaries against them.
+package budgets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// Budget is the code size budget of a binary.
+type Budget struct {
+	// BinaryName is the name of the binary, e.g. "dm".
+	BinaryName string `json:"binary_name"`
+
+	// CompileTaskName restricts the budget to the binary built by the given compile task. If empty,
+	// the budget applies to binaries with the given name built by any compile task which does not
+	// have a budget of its own.
+	CompileTaskName string `json:"compile_task_name,omitempty"`
+
+	// MaxFileSize is the maximum file size of the binary, in bytes. Zero means no limit.
+	MaxFileSize int `json:"max_file_size,omitempty"`
+
+	// MaxGrowth is the maximum number of bytes by which a single CL may grow the binary. Zero means
+	// no limit.
+	MaxGrowth int `json:"max_growth,omitempty"`
+}
+
+// Check returns a human-readable description of each way in which a binary with the given file
+// size, which a CL grew by the given number of bytes, exceeds the budget. Pass zero as the growth
+// of binaries not built for a CL.
+func (b Budget) Check(fileSize, growth int) []string {
+	var violations []string
+	if b.MaxFileSize > 0 && fileSize > b.MaxFileSize {
+		violations = append(violations, fmt.Sprintf("%s is %d bytes, which exceeds its budget of %d bytes by %d bytes.", b.BinaryName, fileSize, b.MaxFileSize, fileSize-b.MaxFileSize))
+	}
+	if b.MaxGrowth > 0 && growth > b.MaxGrowth {
+		violations = append(violations, fmt.Sprintf("%s grew by %d bytes, which exceeds the %d bytes allowed per CL.", b.BinaryName, growth, b.MaxGrowth))
+	}
+	return violations
+}
+
+// Config is the set of all budgets.
+type Config struct {
+	Budgets []Budget `json:"budgets"`
+}
+
+// Parse parses and validates a JSON-encoded Config.
+func Parse(b []byte) (*Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, skerr.Wrapf(err, "failed to parse budgets")
+	}
+	seen := map[[2]string]bool{}
+	for _, budget := range cfg.Budgets {
+		if budget.BinaryName == "" {
+			return nil, skerr.Fmt("budgets must have a binary_name")
+		}
+		if budget.MaxFileSize < 0 || budget.MaxGrowth < 0 {
+			return nil, skerr.Fmt("budget for %s must not be negative", budget.BinaryName)
+		}
+		key := [2]string{budget.BinaryName, budget.CompileTaskName}
+		if seen[key] {
+			return nil, skerr.Fmt("duplicate budget for binary %q and compile task %q", budget.BinaryName, budget.CompileTaskName)
+		}
+		seen[key] = true
+	}
+	return &cfg, nil
+}
+
+// Load reads and validates the Config in the given JSON file.
+func Load(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to read budgets from %s", path)
+	}
+	return Parse(b)
+}
+
+// Find returns the budget for the given binary built by the given compile task, if any. A budget
+// for the specific compile task takes precedence over one for all compile tasks.
+func (c *Config) Find(binaryName, compileTaskName string) (Budget, bool) {
+	var rv Budget
+	found := false
+	for _, budget := range c.Budgets {
+		if budget.BinaryName != binaryName {
+			continue
+		}
+		if budget.CompileTaskName == compileTaskName {
+			return budget, true
+		}
+		if budget.CompileTaskName == "" {
+			rv, found = budget, true
+		}
+	}
+	return rv, found
+}
@@ -0,0 +1,54 @@
+package budgets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `{
+	"budgets": [
+		{"binary_name": "dm", "max_file_size": 1000, "max_growth": 100},
+		{"binary_name": "dm", "compile_task_name": "Build-Debian10-Clang-arm", "max_file_size": 500}
+	]
+}`
+
+func TestParse_DuplicateBudget_Error(t *testing.T) {
+	_, err := Parse([]byte(`{"budgets": [{"binary_name": "dm"}, {"binary_name": "dm"}]}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate budget")
+}
+
+func TestParse_MissingBinaryName_Error(t *testing.T) {
+	_, err := Parse([]byte(`{"budgets": [{"max_file_size": 10}]}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "binary_name")
+}
+
+func TestFind_CompileTaskSpecificBudget_TakesPrecedence(t *testing.T) {
+	cfg, err := Parse([]byte(testConfig))
+	require.NoError(t, err)
+
+	budget, ok := cfg.Find("dm", "Build-Debian10-Clang-arm")
+	require.True(t, ok)
+	assert.Equal(t, 500, budget.MaxFileSize)
+
+	budget, ok = cfg.Find("dm", "Build-Debian10-Clang-x86_64")
+	require.True(t, ok)
+	assert.Equal(t, 1000, budget.MaxFileSize)
+
+	_, ok = cfg.Find("fm", "Build-Debian10-Clang-arm")
+	assert.False(t, ok)
+}
+
+func TestCheck_WithinAndOverBudget_ReportsViolations(t *testing.T) {
+	budget := Budget{BinaryName: "dm", MaxFileSize: 1000, MaxGrowth: 100}
+	assert.Empty(t, budget.Check(1000, 100))
+	assert.Equal(t, []string{
+		"dm is 1200 bytes, which exceeds its budget of 1000 bytes by 200 bytes.",
+		"dm grew by 150 bytes, which exceeds the 100 bytes allowed per CL.",
+	}, budget.Check(1200, 150))
+	// Zero means no limit.
+	assert.Empty(t, Budget{BinaryName: "dm"}.Check(1<<30, 1<<20))
+}
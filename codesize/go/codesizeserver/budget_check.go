@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/codesize/go/bloaty"
+	"go.skia.org/infra/codesize/go/budgets"
+	"go.skia.org/infra/codesize/go/codesizeserver/rpc"
+	"go.skia.org/infra/codesize/go/store"
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// sizeTracker records the size of the most recent binaries built at a commit as metrics, which
+// are used to alert on size regressions.
+type sizeTracker struct {
+	mtx sync.Mutex
+	// latest maps binary names and compile task names to the timestamp of the most recent binary
+	// whose size has been recorded.
+	latest map[[2]string]time.Time
+}
+
+// record records the size of the given binary, unless a more recent binary with the same name and
+// compile task has already been recorded.
+func (t *sizeTracker) record(binary store.Binary, summary bloaty.SizeSummary, budget *budgets.Budget) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	key := [2]string{binary.Metadata.BinaryName, binary.Metadata.CompileTaskName}
+	if latest, ok := t.latest[key]; ok && !binary.Timestamp.After(latest) {
+		return
+	}
+	t.latest[key] = binary.Timestamp
+
+	tags := map[string]string{
+		"binary":       binary.Metadata.BinaryName,
+		"compile_task": binary.Metadata.CompileTaskName,
+	}
+	metrics2.GetInt64Metric("codesize_file_size_bytes", tags).Update(int64(summary.FileSize))
+	metrics2.GetInt64Metric("codesize_vm_size_bytes", tags).Update(int64(summary.VirtualMemorySize))
+	if budget != nil {
+		overBudget := int64(0)
+		if len(budget.Check(summary.FileSize, 0)) > 0 {
+			overBudget = 1
+		}
+		metrics2.GetInt64Metric("codesize_over_budget", tags).Update(overBudget)
+	}
+}
+
+// findBudget returns the budget for the given binary, or nil if it has none.
+func (s *server) findBudget(binaryName, compileTaskName string) *budgets.Budget {
+	if budget, ok := s.budgets.Find(binaryName, compileTaskName); ok {
+		return &budget
+	}
+	return nil
+}
+
+// checkBudget checks the given binary against its budget.
+func (s *server) checkBudget(ctx context.Context, binary store.Binary) (rpc.BudgetCheckRPCResponse, error) {
+	summary, err := s.store.GetSizeSummary(ctx, binary)
+	if err != nil {
+		return rpc.BudgetCheckRPCResponse{}, skerr.Wrap(err)
+	}
+	growth := 0
+	if binary.BloatySizeDiffOutputFileGCSPath != "" {
+		diff, err := s.store.GetBloatySizeDiffOutputFileContents(ctx, binary)
+		if err != nil {
+			return rpc.BudgetCheckRPCResponse{}, skerr.Wrap(err)
+		}
+		growth, _, err = bloaty.ParseSizeDiffTotal(string(diff))
+		if err != nil {
+			return rpc.BudgetCheckRPCResponse{}, skerr.Wrap(err)
+		}
+	}
+	res := rpc.BudgetCheckRPCResponse{
+		Metadata: binary.Metadata,
+		FileSize: summary.FileSize,
+		Growth:   growth,
+		Budget:   s.findBudget(binary.Metadata.BinaryName, binary.Metadata.CompileTaskName),
+	}
+	if res.Budget != nil {
+		res.Violations = res.Budget.Check(res.FileSize, res.Growth)
+	}
+	return res, nil
+}
+
+// recordSize records the size of the given binary built at a commit.
+func (s *server) recordSize(ctx context.Context, binary store.Binary) error {
+	summary, err := s.store.GetSizeSummary(ctx, binary)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	s.sizeTracker.record(binary, summary, s.findBudget(binary.Metadata.BinaryName, binary.Metadata.CompileTaskName))
+	return nil
+}
+
+// recordLatestSizes records the size of the most recent indexed binary built at a commit for each
+// binary name and compile task. Errors are logged.
+func (s *server) recordLatestSizes(ctx context.Context) {
+	recorded := map[[2]string]bool{}
+	// GetMostRecentBinaries returns the most recent commits and patchsets first.
+	for _, group := range s.store.GetMostRecentBinaries(math.MaxInt32) {
+		if group.CommitOrPatchset.IsPatchset() {
+			continue
+		}
+		for _, binary := range group.Binaries {
+			key := [2]string{binary.Metadata.BinaryName, binary.Metadata.CompileTaskName}
+			if recorded[key] {
+				continue
+			}
+			recorded[key] = true
+			if err := s.recordSize(ctx, binary); err != nil {
+				sklog.Errorf("Failed to record the size of %s: %s", binary.BloatyOutputFileGCSPath, err)
+			}
+		}
+	}
+}
+
+// checkPatchsetBudget checks a binary built for a patchset against its budget and comments on the
+// CL if it is exceeded.
+func (s *server) checkPatchsetBudget(ctx context.Context, binary store.Binary) error {
+	if s.findBudget(binary.Metadata.BinaryName, binary.Metadata.CompileTaskName) == nil {
+		return nil
+	}
+	res, err := s.checkBudget(ctx, binary)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if len(res.Violations) == 0 {
+		return nil
+	}
+	return skerr.Wrap(s.commentOnPatchset(ctx, binary, res.Violations))
+}
+
+// commentOnPatchset leaves a comment on the CL for which the given binary was built describing how
+// it exceeds its budget, unless the CL already has that comment, e.g. because the binary was
+// uploaded again.
+func (s *server) commentOnPatchset(ctx context.Context, binary store.Binary, violations []string) error {
+	issue, err := strconv.ParseInt(binary.Metadata.PatchIssue, 10, 64)
+	if err != nil {
+		return skerr.Wrapf(err, "invalid issue %q", binary.Metadata.PatchIssue)
+	}
+	g, err := gerrit.NewGerrit(binary.Metadata.PatchServer, s.gerritHTTPClient)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	change, err := g.GetIssueProperties(ctx, issue)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	msg := fmt.Sprintf("Patchset %s exceeds the code size budget of %s built by %s:\n\n%s\n\nSee https://codesize.skia.org/binary_diff?patch_issue=%s&patch_set=%s&binary_name=%s&compile_task_name=%s for details.",
+		binary.Metadata.PatchSet,
+		binary.Metadata.BinaryName,
+		binary.Metadata.CompileTaskName,
+		strings.Join(violations, "\n"),
+		binary.Metadata.PatchIssue,
+		binary.Metadata.PatchSet,
+		binary.Metadata.BinaryName,
+		binary.Metadata.CompileTaskName)
+	for _, m := range change.Messages {
+		if strings.Contains(m.Message, msg) {
+			sklog.Infof("Not commenting on %s/%d again: %s", binary.Metadata.PatchServer, issue, msg)
+			return nil
+		}
+	}
+	sklog.Infof("Commenting on %s/%d: %s", binary.Metadata.PatchServer, issue, msg)
+	return skerr.Wrap(g.AddComment(ctx, change, msg))
+}
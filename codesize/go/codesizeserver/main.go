@@ -3,10 +3,12 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"net/http"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
@@ -19,11 +21,14 @@ import (
 	"google.golang.org/api/option"
 
 	"go.skia.org/infra/codesize/go/bloaty"
+	"go.skia.org/infra/codesize/go/budgets"
 	"go.skia.org/infra/codesize/go/codesizeserver/rpc"
+	"go.skia.org/infra/codesize/go/sizereport"
 	"go.skia.org/infra/codesize/go/store"
 	"go.skia.org/infra/go/baseapp"
 	"go.skia.org/infra/go/fileutil"
 	"go.skia.org/infra/go/gcs/gcsclient"
+	"go.skia.org/infra/go/gerrit"
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/pubsub/sub"
@@ -50,6 +55,11 @@ const (
 	maxChildrenPerParent = 200
 )
 
+var (
+	budgetsFile    = flag.String("budgets", "", "Path to a JSON file with the code size budgets of each binary. If unset, no budgets are enforced.")
+	gerritComments = flag.Bool("gerrit_comments", false, "If set, comment on CLs whose patchsets exceed the code size budget of a binary.")
+)
+
 var exponentialBackoffSettings = &backoff.ExponentialBackOff{
 	InitialInterval:     5 * time.Second,
 	RandomizationFactor: 0.5,
@@ -69,22 +79,47 @@ type gcsPubSubEvent struct {
 }
 
 type server struct {
-	templates *template.Template
-	gcsClient *gcsclient.StorageClient
-	store     store.Store
+	templates        *template.Template
+	gcsClient        *gcsclient.StorageClient
+	gerritHTTPClient *http.Client
+	store            store.Store
+	budgets          *budgets.Config
+	sizeTracker      *sizeTracker
+
+	// indexMtx serializes indexing, which happens on the goroutines of both PubSub subscriptions.
+	indexMtx sync.Mutex
 }
 
 // See baseapp.Constructor.
 func new() (baseapp.App, error) {
 	ctx := context.Background()
-	srv := &server{}
+	srv := &server{
+		budgets: &budgets.Config{},
+		sizeTracker: &sizeTracker{
+			latest: map[[2]string]time.Time{},
+		},
+	}
+
+	// Load budgets.
+	if *budgetsFile != "" {
+		cfg, err := budgets.Load(*budgetsFile)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		srv.budgets = cfg
+	}
 
 	// Set up GCS client.
-	ts, err := google.DefaultTokenSource(ctx, storage.ScopeReadWrite)
+	scopes := []string{storage.ScopeReadWrite}
+	if *gerritComments {
+		scopes = append(scopes, gerrit.AuthScope)
+	}
+	ts, err := google.DefaultTokenSource(ctx, scopes...)
 	if err != nil {
 		return nil, skerr.Wrapf(err, "failed to get token source")
 	}
 	client := httputils.DefaultClientConfig().WithTokenSource(ts).With2xxOnly().Client()
+	srv.gerritHTTPClient = client
 	storageClient, err := storage.NewClient(ctx, option.WithHTTPClient(client))
 	if err != nil {
 		return nil, skerr.Wrapf(err, "failed to create storage client")
@@ -113,6 +148,10 @@ func new() (baseapp.App, error) {
 		return nil, skerr.Wrapf(err, "failed to preload Bloaty outputs from GCS")
 	}
 
+	// Record the sizes of the latest preloaded binaries, as no upload notifications will be
+	// received for them.
+	srv.recordLatestSizes(ctx)
+
 	// Subscribe to the PubSub topic via which GCS will notify us of file uploads. We use a broadcast
 	// name provider to ensure that all replicas are notified of each file upload. We specify an
 	// expiration policy to shorten the time until unused subscriptions are garbage-collected after
@@ -122,70 +161,82 @@ func new() (baseapp.App, error) {
 	if err != nil {
 		return nil, skerr.Wrapf(err, "failed to subscribe to PubSub topic")
 	}
+	go srv.receiveFileUploadNotifications(ctx, subscription, srv.handleFileUploadNotification)
 
-	// Launch a goroutine to listen for PubSub messages.
-	go func() {
-		sklog.Infof("Listening for PubSub messages.")
-		for {
-			if err := subscription.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
-				evt := gcsPubSubEvent{}
-				if err := json.Unmarshal(msg.Data, &evt); err != nil {
-					// This should never happen. But if it does, then GCS is sending spurious messages, or
-					// something else is publishing on the topic.
-					msg.Ack() // No point in retrying a spurious message.
-					// TODO(lovisolo): Add a metrics counter.
-					sklog.Errorf("Received malformed PubSub message. JSON Unmarshal error: %s\n", err)
-					return
-				}
-
-				sklog.Infof("Received PubSub message: [bucket: %s, name: %s].\n", evt.Bucket, evt.Name)
-
-				// We should only receive events for files in our GCS bucket.
-				if evt.Bucket != gcsBucket {
-					msg.Ack() // No point in retrying a spurious message.
-					// TODO(lovisolo): Add a metrics counter.
-					sklog.Errorf("Received a PubSub message from an unknown GCS bucket %q, but %q was expected. Potential configuration error.", evt.Bucket, gcsBucket)
-					return
-				}
-
-				// Process message.
-				if err := srv.handleFileUploadNotification(ctx, evt.Name); err != nil {
-					// TODO(lovisolo): Add a metrics counter.
-					sklog.Warningf("Failed to process PubSub message: [bucket: %s, name: %s]. Error: %s.\n", evt.Bucket, evt.Name, err)
-
-					// We Ack messages we failed to process to prevent them from being continuously retried.
-					// Some of these errors might be retriable, such as transient network errors while
-					// downloading from GCS, but I don't anticipate this to happen often. As is, such an error
-					// would prevent the replica from picking up the latest Bloaty output, but this would
-					// resolve itself as soon as the next Skia commit lands.
-					//
-					// If our metrics indicate that we're failing to process lots of messages, an alternative
-					// is to Nack failed messages so as to retry them, and set up a dead-letter topic
-					// (https://cloud.google.com/pubsub/docs/handling-failures) with a small
-					// MaxDeliveryAttempts value in order to limit the number of retries.
-					msg.Ack()
-				} else {
-					// TODO(lovisolo): Add a metrics counter.
-					sklog.Infof("Done processing PubSub message: [bucket: %s, name: %s].\n", evt.Bucket, evt.Name)
-					msg.Ack()
-				}
-			}); err != nil {
-				// Receive returns a non-retryable error, so we log with Fatal to exit the program.
-				sklog.Fatal(err)
-			}
+	// Budget checks comment on CLs, so each upload must be handled by a single replica. We use a
+	// round robin name provider so that all replicas share the same subscription.
+	if *gerritComments {
+		budgetCheckSubscription, err := sub.NewWithSubNameProvider(ctx, *baseapp.Local, gcpProjectName, pubSubTopic, sub.NewRoundRobinNameProvider(*baseapp.Local, pubSubTopic), 1)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "failed to subscribe to PubSub topic for budget checks")
 		}
-	}()
+		go srv.receiveFileUploadNotifications(ctx, budgetCheckSubscription, srv.handleBudgetCheckNotification)
+	}
 
 	srv.loadTemplates()
 
 	return srv, nil
 }
 
+// receiveFileUploadNotifications listens for PubSub messages on the given subscription and passes
+// the path of each uploaded file to the given handler.
+func (s *server) receiveFileUploadNotifications(ctx context.Context, subscription *pubsub.Subscription, handler func(context.Context, string) error) {
+	sklog.Infof("Listening for PubSub messages.")
+	for {
+		if err := subscription.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+			evt := gcsPubSubEvent{}
+			if err := json.Unmarshal(msg.Data, &evt); err != nil {
+				// This should never happen. But if it does, then GCS is sending spurious messages, or
+				// something else is publishing on the topic.
+				msg.Ack() // No point in retrying a spurious message.
+				// TODO(lovisolo): Add a metrics counter.
+				sklog.Errorf("Received malformed PubSub message. JSON Unmarshal error: %s\n", err)
+				return
+			}
+
+			sklog.Infof("Received PubSub message: [bucket: %s, name: %s].\n", evt.Bucket, evt.Name)
+
+			// We should only receive events for files in our GCS bucket.
+			if evt.Bucket != gcsBucket {
+				msg.Ack() // No point in retrying a spurious message.
+				// TODO(lovisolo): Add a metrics counter.
+				sklog.Errorf("Received a PubSub message from an unknown GCS bucket %q, but %q was expected. Potential configuration error.", evt.Bucket, gcsBucket)
+				return
+			}
+
+			// Process message.
+			if err := handler(ctx, evt.Name); err != nil {
+				// TODO(lovisolo): Add a metrics counter.
+				sklog.Warningf("Failed to process PubSub message: [bucket: %s, name: %s]. Error: %s.\n", evt.Bucket, evt.Name, err)
+
+				// We Ack messages we failed to process to prevent them from being continuously retried.
+				// Some of these errors might be retriable, such as transient network errors while
+				// downloading from GCS, but I don't anticipate this to happen often. As is, such an error
+				// would prevent the replica from picking up the latest Bloaty output, but this would
+				// resolve itself as soon as the next Skia commit lands.
+				//
+				// If our metrics indicate that we're failing to process lots of messages, an alternative
+				// is to Nack failed messages so as to retry them, and set up a dead-letter topic
+				// (https://cloud.google.com/pubsub/docs/handling-failures) with a small
+				// MaxDeliveryAttempts value in order to limit the number of retries.
+				msg.Ack()
+			} else {
+				// TODO(lovisolo): Add a metrics counter.
+				sklog.Infof("Done processing PubSub message: [bucket: %s, name: %s].\n", evt.Bucket, evt.Name)
+				msg.Ack()
+			}
+		}); err != nil {
+			// Receive returns a non-retryable error, so we log with Fatal to exit the program.
+			sklog.Fatal(err)
+		}
+	}
+}
+
 // preloadBloatyFiles preloads the latest Bloaty outputs for each supported build artifact.
 func (s *server) preloadBloatyFiles(ctx context.Context) error {
-	// We'll filter out anything that isn't under a YYYY/MM/DD directory and doesn't end in ".tsv".
-	// This excludes debug files that we occasionally upload to GCS.
-	bloatyOutputFilePattern := regexp.MustCompile(`^[0-9]{4}/[0-9]{2}/[0-9]{2}/.*\.tsv$`)
+	// We'll filter out anything that isn't under a YYYY/MM/DD directory. This excludes debug files
+	// that we occasionally upload to GCS.
+	bloatyOutputFilePattern := regexp.MustCompile(`^[0-9]{4}/[0-9]{2}/[0-9]{2}/`)
 
 	n := now.Now(ctx).UTC()
 	dirs := fileutil.GetHourlyDirs("", n.Add(-daysToPreload*24*time.Hour), n)
@@ -198,6 +249,11 @@ func (s *server) preloadBloatyFiles(ctx context.Context) error {
 			if !bloatyOutputFilePattern.MatchString(item.Name) {
 				return nil
 			}
+			// Skip JSON metadata files, Bloaty size diff outputs, and any other files that aren't size
+			// reports in a supported format.
+			if _, _, ok := sizereport.FormatFromPath(item.Name); !ok {
+				return nil
+			}
 			if err := s.store.Index(ctx, item.Name); err != nil {
 				// If this happens often (e.g. because we're hitting a GCS QPS limit) we can do a combination
 				// of limiting our QPS rate and only fetching the most recent files.
@@ -217,17 +273,54 @@ func (s *server) preloadBloatyFiles(ctx context.Context) error {
 	return nil
 }
 
-// handleFileUploadNotification is called when a new file is uploaded to the GCS bucket.
+// indexFile indexes the given file if it is a size report which has not been indexed yet, and
+// returns the corresponding binary. It returns false if the file is not a size report.
+func (s *server) indexFile(ctx context.Context, path string) (store.Binary, bool, error) {
+	if _, _, ok := sizereport.FormatFromPath(path); !ok {
+		if strings.HasSuffix(path, ".json") || strings.HasSuffix(path, ".diff.txt") {
+			sklog.Infof("Ignoring %s because we index .json and .diff.txt files when we see a corresponding .tsv file or other size report", path)
+		} else {
+			sklog.Infof("Ignoring %s because it is not a size report in a supported format", path)
+		}
+		return store.Binary{}, false, nil
+	}
+	s.indexMtx.Lock()
+	defer s.indexMtx.Unlock()
+	if _, ok := s.store.GetIndexedBinary(path); !ok {
+		if err := s.store.Index(ctx, path); err != nil {
+			return store.Binary{}, false, skerr.Wrap(err)
+		}
+	}
+	binary, ok := s.store.GetIndexedBinary(path)
+	return binary, ok, nil
+}
+
+// handleFileUploadNotification is called on every replica when a new file is uploaded to the GCS
+// bucket.
 func (s *server) handleFileUploadNotification(ctx context.Context, path string) error {
 	sklog.Infof("Received file upload PubSub message: %s", path)
-	if strings.HasSuffix(path, ".json") || strings.HasSuffix(path, ".diff.txt") {
-		sklog.Infof("Ignoring %s because we index .json and .diff.txt files when we see a corresponding .tsv file", path)
+	binary, ok, err := s.indexFile(ctx, path)
+	if err != nil || !ok {
+		return skerr.Wrap(err)
+	}
+	if binary.Metadata.PatchIssue != "" {
 		return nil
 	}
-	if err := s.store.Index(ctx, path); err != nil {
+	return skerr.Wrap(s.recordSize(ctx, binary))
+}
+
+// handleBudgetCheckNotification is called on a single replica when a new file is uploaded to the
+// GCS bucket.
+func (s *server) handleBudgetCheckNotification(ctx context.Context, path string) error {
+	sklog.Infof("Received file upload PubSub message for budget check: %s", path)
+	binary, ok, err := s.indexFile(ctx, path)
+	if err != nil || !ok {
 		return skerr.Wrap(err)
 	}
-	return nil
+	if binary.Metadata.PatchIssue == "" {
+		return nil
+	}
+	return skerr.Wrap(s.checkPatchsetBudget(ctx, binary))
 }
 
 // loadTemplates loads the HTML templates to serve to the UI.
@@ -282,15 +375,9 @@ func (s *server) binaryRPCHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bytes, err := s.store.GetBloatyOutputFileContents(r.Context(), binary)
-	if err != nil {
-		httputils.ReportError(w, err, "Failed to retrieve Bloaty output file", http.StatusInternalServerError)
-		return
-	}
-
-	outputItems, err := bloaty.ParseTSVOutput(string(bytes))
+	outputItems, err := s.store.GetOutputItems(r.Context(), binary)
 	if err != nil {
-		httputils.ReportError(w, err, "Failed to parse Bloaty output file.", http.StatusInternalServerError)
+		httputils.ReportError(w, err, "Failed to retrieve or parse Bloaty output file", http.StatusInternalServerError)
 		return
 	}
 
@@ -335,6 +422,52 @@ func (s *server) mostRecentBinariesRPCHandler(w http.ResponseWriter, r *http.Req
 	sendJSONResponse(res, w)
 }
 
+func (s *server) binaryHistoryRPCHandler(w http.ResponseWriter, r *http.Request) {
+	req := rpc.BinaryHistoryRPCRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to parse request", http.StatusBadRequest)
+		return
+	}
+
+	res := rpc.BinaryHistoryRPCResponse{
+		Points: []rpc.BinaryHistoryPoint{},
+		Budget: s.findBudget(req.BinaryName, req.CompileTaskName),
+	}
+	for _, binary := range s.store.GetBinaryHistory(req.BinaryName, req.CompileTaskName) {
+		summary, err := s.store.GetSizeSummary(r.Context(), binary)
+		if err != nil {
+			httputils.ReportError(w, err, "Failed to compute binary size", http.StatusInternalServerError)
+			return
+		}
+		res.Points = append(res.Points, rpc.BinaryHistoryPoint{
+			Metadata: binary.Metadata,
+			Size:     summary,
+		})
+	}
+	sendJSONResponse(res, w)
+}
+
+func (s *server) budgetCheckRPCHandler(w http.ResponseWriter, r *http.Request) {
+	req := rpc.BudgetCheckRPCRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to parse request", http.StatusBadRequest)
+		return
+	}
+
+	binary, ok := s.store.GetBinary(req.CommitOrPatchset, req.BinaryName, req.CompileTaskName)
+	if !ok {
+		httputils.ReportError(w, nil, "Binary not found in Store", http.StatusNotFound)
+		return
+	}
+
+	res, err := s.checkBudget(r.Context(), binary)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to check binary against its budget", http.StatusInternalServerError)
+		return
+	}
+	sendJSONResponse(res, w)
+}
+
 // See baseapp.App.
 func (s *server) AddHandlers(r chi.Router) {
 	r.Get("/", s.indexPageHandler)
@@ -343,6 +476,8 @@ func (s *server) AddHandlers(r chi.Router) {
 	r.Post("/rpc/binary/v1", s.binaryRPCHandler)
 	r.Post("/rpc/binary_size_diff/v1", s.binarySizeDiffRPCHandler)
 	r.Get("/rpc/most_recent_binaries/v1", s.mostRecentBinariesRPCHandler)
+	r.Post("/rpc/binary_history/v1", s.binaryHistoryRPCHandler)
+	r.Post("/rpc/budget_check/v1", s.budgetCheckRPCHandler)
 }
 
 // See baseapp.App.
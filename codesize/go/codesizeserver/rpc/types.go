@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"go.skia.org/infra/codesize/go/bloaty"
+	"go.skia.org/infra/codesize/go/budgets"
 	"go.skia.org/infra/codesize/go/common"
 	"go.skia.org/infra/codesize/go/store"
 )
@@ -36,3 +37,45 @@ type BinarySizeDiffRPCResponse struct {
 type MostRecentBinariesRPCResponse struct {
 	Binaries []store.BinariesFromCommitOrPatchset `json:"binaries"`
 }
+
+type BinaryHistoryRPCRequest struct {
+	BinaryName      string `json:"binary_name"`
+	CompileTaskName string `json:"compile_task_name"`
+}
+
+// BinaryHistoryPoint is the size of a binary at a single commit.
+type BinaryHistoryPoint struct {
+	Metadata common.BloatyOutputMetadata `json:"metadata"`
+	Size     bloaty.SizeSummary          `json:"size"`
+}
+
+type BinaryHistoryRPCResponse struct {
+	// Points are in chronological order.
+	Points []BinaryHistoryPoint `json:"points" go2ts:"ignorenil"`
+
+	// Budget is the size budget of the binary, or nil if it has none.
+	Budget *budgets.Budget `json:"budget"`
+}
+
+type BudgetCheckRPCRequest struct {
+	store.CommitOrPatchset
+	BinaryName      string `json:"binary_name"`
+	CompileTaskName string `json:"compile_task_name"`
+}
+
+type BudgetCheckRPCResponse struct {
+	Metadata common.BloatyOutputMetadata `json:"metadata"`
+
+	// FileSize is the file size of the binary, in bytes.
+	FileSize int `json:"file_size"`
+
+	// Growth is the number of bytes by which the patchset grew the binary, according to the Bloaty
+	// size diff output. It is zero for binaries built at a commit.
+	Growth int `json:"growth"`
+
+	// Budget is the size budget of the binary, or nil if it has none.
+	Budget *budgets.Budget `json:"budget"`
+
+	// Violations describe how the binary exceeds its budget. Empty if it is within budget.
+	Violations []string `json:"violations" go2ts:"ignorenil"`
+}
@@ -0,0 +1,47 @@
+package sizereport
+
+import (
+	"archive/zip"
+	"bytes"
+	"path"
+	"strings"
+
+	"go.skia.org/infra/codesize/go/bloaty"
+	"go.skia.org/infra/go/skerr"
+)
+
+// ParseAndroidArchive breaks down the size of an Android APK or AAB file (both of which are ZIP
+// archives) by the files it contains. Each file is reported as a symbol in the directory that
+// contains it, e.g. "libskia.so" in "lib/arm64-v8a", or in "[root]" for top-level files such as
+// "classes.dex".
+//
+// The file size of each item is its compressed size, i.e. the number of bytes it contributes to the
+// download size, and the VM size is its uncompressed size, i.e. the number of bytes it takes once
+// installed.
+func ParseAndroidArchive(contents []byte) ([]bloaty.OutputItem, error) {
+	r, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to read Android archive")
+	}
+	var items []bloaty.OutputItem
+	for _, f := range r.File {
+		if strings.HasSuffix(f.Name, "/") {
+			continue // Skip directories.
+		}
+		dir, name := path.Split(f.Name)
+		dir = strings.TrimSuffix(dir, "/")
+		if dir == "" {
+			dir = "[root]"
+		}
+		items = append(items, bloaty.OutputItem{
+			CompileUnit:       dir,
+			Symbol:            name,
+			VirtualMemorySize: int(f.UncompressedSize64),
+			FileSize:          int(f.CompressedSize64),
+		})
+	}
+	if len(items) == 0 {
+		return nil, skerr.Fmt("empty Android archive")
+	}
+	return items, nil
+}
@@ -0,0 +1,52 @@
+package sizereport
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAndroidArchive_Success(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, contents := range map[string]string{
+		"AndroidManifest.xml":          "manifest",
+		"classes.dex":                  strings.Repeat("a", 1000),
+		"lib/arm64-v8a/libskia.so":     strings.Repeat("b", 2000),
+		"res/drawable/icon.png":        "png",
+		"assets/fonts/":                "",
+		"assets/fonts/Roboto-Bold.ttf": "ttf",
+	} {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	items, err := ParseAndroidArchive(buf.Bytes())
+	require.NoError(t, err)
+	require.Len(t, items, 5)
+	byName := map[string]int{}
+	for _, item := range items {
+		byName[item.CompileUnit+"/"+item.Symbol] = item.VirtualMemorySize
+		assert.Greater(t, item.FileSize, 0)
+	}
+	assert.Equal(t, map[string]int{
+		"[root]/AndroidManifest.xml":   8,
+		"[root]/classes.dex":           1000,
+		"lib/arm64-v8a/libskia.so":     2000,
+		"res/drawable/icon.png":        3,
+		"assets/fonts/Roboto-Bold.ttf": 3,
+	}, byName)
+}
+
+func TestParseAndroidArchive_NotAZipFile_Error(t *testing.T) {
+	_, err := ParseAndroidArchive([]byte("not a zip file"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read Android archive")
+}
@@ -0,0 +1,323 @@
+package sizereport
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.skia.org/infra/codesize/go/bloaty"
+	"go.skia.org/infra/go/skerr"
+)
+
+// inputSection is a section of an object file placed in the output binary by the linker.
+type inputSection struct {
+	outputSection string
+	name          string
+	file          string
+	address       uint64
+	size          uint64
+	symbols       []symbol
+}
+
+// symbol is a symbol defined in an input section. Linker maps do not include the sizes of symbols,
+// so we assume each symbol spans until the next one or the end of its input section.
+type symbol struct {
+	address uint64
+	name    string
+}
+
+// ParseLinkerMap parses a linker map file produced by GNU ld or LLVM lld, e.g. via
+// "-Wl,-Map=<path/to/binary.map>".
+//
+// Input sections are attributed to the object files they come from, which take the place of the
+// compile units in Bloaty outputs, and are broken down by the symbols they define. Sections
+// without an object file (e.g. those synthesized by the linker) are attributed to
+// "[section <output section>]". Debug information is skipped, and .bss and .tbss only contribute
+// to the VM size.
+func ParseLinkerMap(linkerMap string) ([]bloaty.OutputItem, error) {
+	if strings.TrimSpace(linkerMap) == "" {
+		return nil, skerr.Fmt("empty input")
+	}
+	lines := strings.Split(strings.ReplaceAll(linkerMap, "\r\n", "\n"), "\n")
+
+	var sections []*inputSection
+	var err error
+	if header := strings.Fields(lines[0]); len(header) > 0 && (header[0] == "VMA" || header[0] == "Address") {
+		sections, err = parseLLDMap(lines)
+	} else {
+		sections, err = parseGNUMap(lines)
+	}
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	var items []bloaty.OutputItem
+	for _, section := range sections {
+		items = append(items, section.outputItems()...)
+	}
+	if len(items) == 0 {
+		return nil, skerr.Fmt("no sections found in linker map")
+	}
+	return items, nil
+}
+
+// parseGNUMap parses a GNU ld map file, in which the memory map looks like the following:
+//
+//	.text           0x0000000000401000      0x180
+//	 *(.text .text.*)
+//	 .text          0x0000000000401000      0x100 obj/src/core/SkCanvas.o
+//	                0x0000000000401000                SkCanvas::save()
+//	 .text._ZN7SkPaintC2Ev
+//	                0x0000000000401100       0x80 obj/src/core/SkPaint.o
+//	                0x0000000000401100                SkPaint::SkPaint()
+func parseGNUMap(lines []string) ([]*inputSection, error) {
+	var sections []*inputSection
+	var current *inputSection
+	outputSection := ""
+	pendingName := ""
+	inMemoryMap := false
+	for i, line := range lines {
+		if !inMemoryMap {
+			inMemoryMap = strings.HasPrefix(line, "Linker script and memory map")
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// Output sections and linker script commands start at the beginning of the line.
+		if !strings.HasPrefix(line, " ") {
+			current, pendingName = nil, ""
+			if fields[0] == "LOAD" || fields[0] == "OUTPUT" || strings.HasPrefix(fields[0], "OUTPUT(") {
+				continue
+			}
+			outputSection = fields[0]
+			continue
+		}
+
+		// Input sections start after a single space. Long section names are followed by their address,
+		// size and file on the next line.
+		if !strings.HasPrefix(line, "  ") {
+			current, pendingName = nil, ""
+			if strings.HasPrefix(fields[0], "*") && fields[0] != "*fill*" {
+				continue // Input section description, e.g. "*(.text .text.*)".
+			}
+			if len(fields) == 1 {
+				pendingName = fields[0]
+				continue
+			}
+		} else if pendingName != "" {
+			fields = append([]string{pendingName}, fields...)
+			pendingName = ""
+		} else {
+			// Symbol, e.g. "0x0000000000401000                SkCanvas::save()".
+			if current == nil || len(fields) < 2 {
+				continue
+			}
+			address, ok := parseHex(fields[0])
+			if !ok {
+				continue
+			}
+			name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), fields[0]))
+			if strings.HasPrefix(name, "PROVIDE") || strings.Contains(name, " = ") {
+				continue // Linker script assignment.
+			}
+			current.symbols = append(current.symbols, symbol{address: address, name: name})
+			continue
+		}
+
+		// Input section, e.g. ".text 0x0000000000401000 0x100 obj/src/core/SkCanvas.o".
+		if len(fields) < 3 {
+			return nil, skerr.Fmt("on line %d: expected an input section, got %q", i+1, line)
+		}
+		address, ok := parseHex(fields[1])
+		if !ok {
+			continue // Linker script assignment within an output section.
+		}
+		size, ok := parseHex(fields[2])
+		if !ok {
+			return nil, skerr.Fmt("on line %d: invalid size %q", i+1, fields[2])
+		}
+		current = &inputSection{
+			outputSection: outputSection,
+			name:          fields[0],
+			file:          strings.Join(fields[3:], " "),
+			address:       address,
+			size:          size,
+		}
+		sections = append(sections, current)
+	}
+	if !inMemoryMap {
+		return nil, skerr.Fmt("unrecognized linker map format: neither an lld map nor a GNU ld map with a memory map")
+	}
+	return sections, nil
+}
+
+// parseLLDMap parses an LLVM lld map file, which looks like the following:
+//
+//	   VMA              LMA     Size Align Out     In      Symbol
+//	201000           201000      180    16 .text
+//	201000           201000      100    16         obj/src/core/SkCanvas.o:(.text)
+//	201000           201000        0     1                 SkCanvas::save()
+//
+// Older versions of lld print a single "Address" column instead of the VMA and LMA columns.
+func parseLLDMap(lines []string) ([]*inputSection, error) {
+	header := lines[0]
+	outCol := strings.Index(header, "Out")
+	inCol := strings.Index(header, "In ")
+	symbolCol := strings.Index(header, "Symbol")
+	if outCol == -1 || inCol == -1 || symbolCol == -1 {
+		return nil, skerr.Fmt("unrecognized lld map header %q", header)
+	}
+	sizeIdx := -1
+	for i, column := range strings.Fields(header[:outCol]) {
+		if column == "Size" {
+			sizeIdx = i
+		}
+	}
+	if sizeIdx == -1 {
+		return nil, skerr.Fmt("no Size column in lld map header %q", header)
+	}
+
+	var sections []*inputSection
+	var current *inputSection
+	outputSection := ""
+	for i, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if len(line) <= outCol {
+			return nil, skerr.Fmt("on line %d: line too short: %q", i+2, line)
+		}
+		numbers := strings.Fields(line[:outCol])
+		if len(numbers) <= sizeIdx {
+			return nil, skerr.Fmt("on line %d: expected at least %d columns, got %q", i+2, sizeIdx+1, line)
+		}
+		address, ok := parseHex(numbers[0])
+		if !ok {
+			return nil, skerr.Fmt("on line %d: invalid address %q", i+2, numbers[0])
+		}
+		size, ok := parseHex(numbers[sizeIdx])
+		if !ok {
+			return nil, skerr.Fmt("on line %d: invalid size %q", i+2, numbers[sizeIdx])
+		}
+		nameCol := outCol + len(line[outCol:]) - len(strings.TrimLeft(line[outCol:], " "))
+		name := strings.TrimSpace(line[nameCol:])
+
+		switch {
+		case nameCol >= symbolCol:
+			if current == nil || strings.HasPrefix(name, ".") || strings.Contains(name, " = ") {
+				continue // Linker script assignment.
+			}
+			current.symbols = append(current.symbols, symbol{address: address, name: name})
+		case nameCol >= inCol:
+			// E.g. "obj/src/core/SkCanvas.o:(.text)" or "libfoo.a(bar.o):(.text)".
+			file, sectionName := "", name
+			if idx := strings.LastIndex(name, ":("); idx != -1 && strings.HasSuffix(name, ")") {
+				file, sectionName = name[:idx], name[idx+2:len(name)-1]
+			}
+			if file == "<internal>" {
+				file = ""
+			}
+			current = &inputSection{
+				outputSection: outputSection,
+				name:          sectionName,
+				file:          file,
+				address:       address,
+				size:          size,
+			}
+			sections = append(sections, current)
+		default:
+			outputSection, current = name, nil
+		}
+	}
+	return sections, nil
+}
+
+// outputItems breaks the input section down into Bloaty output items.
+func (s *inputSection) outputItems() []bloaty.OutputItem {
+	if s.size == 0 || s.outputSection == "/DISCARD/" || s.outputSection == ".comment" || strings.HasPrefix(s.outputSection, ".debug") {
+		return nil
+	}
+	vmOnly := s.outputSection == ".bss" || s.outputSection == ".tbss"
+	compileUnit := "[section " + s.outputSection + "]"
+	if s.file != "" {
+		compileUnit = objectFileCompileUnit(s.file)
+	}
+	newItem := func(name string, size uint64) bloaty.OutputItem {
+		item := bloaty.OutputItem{
+			CompileUnit:       compileUnit,
+			Symbol:            name,
+			VirtualMemorySize: int(size),
+			FileSize:          int(size),
+		}
+		if vmOnly {
+			item.FileSize = 0
+		}
+		return item
+	}
+
+	end := s.address + s.size
+	var symbols []symbol
+	for _, sym := range s.symbols {
+		if sym.address >= s.address && sym.address < end {
+			symbols = append(symbols, sym)
+		}
+	}
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return symbols[i].address < symbols[j].address
+	})
+
+	var items []bloaty.OutputItem
+	// Any bytes before the first symbol are attributed to the section itself.
+	unattributed := s.size
+	if len(symbols) > 0 {
+		unattributed = symbols[0].address - s.address
+	}
+	if unattributed > 0 {
+		items = append(items, newItem(s.name, unattributed))
+	}
+	for i, sym := range symbols {
+		// Skip aliases, i.e. symbols at the same address as the previous one.
+		if i > 0 && sym.address == symbols[i-1].address {
+			continue
+		}
+		symbolEnd := end
+		for _, next := range symbols[i+1:] {
+			if next.address > sym.address {
+				symbolEnd = next.address
+				break
+			}
+		}
+		items = append(items, newItem(sym.name, symbolEnd-sym.address))
+	}
+	return items
+}
+
+// objectFileCompileUnit returns the compile unit for an object file in a linker map, e.g.
+// "src/core/SkCanvas.o" for "obj/src/core/SkCanvas.o", or "libskia.a/SkCanvas.o" for
+// "libskia.a(SkCanvas.o)".
+func objectFileCompileUnit(file string) string {
+	if idx := strings.Index(file, "("); idx != -1 && strings.HasSuffix(file, ")") {
+		file = file[:idx] + "/" + file[idx+1:len(file)-1]
+	}
+	if path.IsAbs(file) {
+		file = path.Clean(file)
+		if idx := strings.Index(file, "third_party"); idx != -1 {
+			return file[idx:]
+		}
+		return strings.TrimPrefix(file, "/")
+	}
+	for strings.HasPrefix(file, "../") {
+		file = strings.TrimPrefix(file, "../")
+	}
+	return strings.TrimPrefix(file, "obj/")
+}
+
+// parseHex parses a hexadecimal number with an optional "0x" prefix.
+func parseHex(s string) (uint64, bool) {
+	n, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	return n, err == nil
+}
@@ -0,0 +1,83 @@
+package sizereport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/codesize/go/bloaty"
+)
+
+const gnuLinkerMap = `Archive member included to satisfy reference by file (symbol)
+
+Memory Configuration
+
+Name             Origin             Length             Attributes
+*default*        0x0000000000000000 0xffffffffffffffff
+
+Linker script and memory map
+
+LOAD obj/src/core/SkCanvas.o
+                0x0000000000400000                PROVIDE (__executable_start = SEGMENT_START ("text-segment", 0x400000))
+
+.text           0x0000000000401000      0x200
+ *(.text .stub .text.*)
+ .text          0x0000000000401000      0x100 obj/src/core/SkCanvas.o
+                0x0000000000401000                SkCanvas::save()
+                0x0000000000401000                SkCanvas::save_alias()
+                0x0000000000401080                SkCanvas::operator=(SkCanvas const&)
+ .text._ZN7SkPaintC2Ev
+                0x0000000000401100       0x80 ../../third_party/libpng/libpng.a(png.o)
+                0x0000000000401110                png_init()
+ *fill*         0x0000000000401180       0x80 
+
+.bss            0x0000000000602000       0x40
+ .bss           0x0000000000602000       0x40 obj/src/core/SkCanvas.o
+
+.debug_info     0x0000000000000000     0x1000
+ .debug_info    0x0000000000000000     0x1000 obj/src/core/SkCanvas.o
+OUTPUT(out/dm elf64-x86-64)
+`
+
+func TestParseLinkerMap_GNU_Success(t *testing.T) {
+	items, err := ParseLinkerMap(gnuLinkerMap)
+	require.NoError(t, err)
+	assert.Equal(t, []bloaty.OutputItem{
+		{CompileUnit: "src/core/SkCanvas.o", Symbol: "SkCanvas::save()", VirtualMemorySize: 0x80, FileSize: 0x80},
+		{CompileUnit: "src/core/SkCanvas.o", Symbol: "SkCanvas::operator=(SkCanvas const&)", VirtualMemorySize: 0x80, FileSize: 0x80},
+		{CompileUnit: "third_party/libpng/libpng.a/png.o", Symbol: ".text._ZN7SkPaintC2Ev", VirtualMemorySize: 0x10, FileSize: 0x10},
+		{CompileUnit: "third_party/libpng/libpng.a/png.o", Symbol: "png_init()", VirtualMemorySize: 0x70, FileSize: 0x70},
+		{CompileUnit: "[section .text]", Symbol: "*fill*", VirtualMemorySize: 0x80, FileSize: 0x80},
+		{CompileUnit: "src/core/SkCanvas.o", Symbol: ".bss", VirtualMemorySize: 0x40, FileSize: 0},
+	}, items)
+}
+
+const lldLinkerMap = `             VMA              LMA     Size Align Out     In      Symbol
+          2001c8           2001c8       1c     1 .interp
+          2001c8           2001c8       1c     1         <internal>:(.interp)
+          201000           201000      180    16 .text
+          201000           201000      100    16         obj/src/core/SkCanvas.o:(.text)
+          201000           201000        0     1                 SkCanvas::save()
+          201040           201040        0     1                 SkCanvas::restore()
+          201100           201100       80    16         libskia.a(SkPaint.o):(.text._ZN7SkPaintC2Ev)
+          201100           201100        0     1                 SkPaint::SkPaint()
+          201180           201180        0     1                 . = ALIGN(16)
+`
+
+func TestParseLinkerMap_LLD_Success(t *testing.T) {
+	items, err := ParseLinkerMap(lldLinkerMap)
+	require.NoError(t, err)
+	assert.Equal(t, []bloaty.OutputItem{
+		{CompileUnit: "[section .interp]", Symbol: ".interp", VirtualMemorySize: 0x1c, FileSize: 0x1c},
+		{CompileUnit: "src/core/SkCanvas.o", Symbol: "SkCanvas::save()", VirtualMemorySize: 0x40, FileSize: 0x40},
+		{CompileUnit: "src/core/SkCanvas.o", Symbol: "SkCanvas::restore()", VirtualMemorySize: 0xc0, FileSize: 0xc0},
+		{CompileUnit: "libskia.a/SkPaint.o", Symbol: "SkPaint::SkPaint()", VirtualMemorySize: 0x80, FileSize: 0x80},
+	}, items)
+}
+
+func TestParseLinkerMap_UnrecognizedFormat_Error(t *testing.T) {
+	_, err := ParseLinkerMap("compileunits\tsymbols\tvmsize\tfilesize\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unrecognized linker map format")
+}
@@ -0,0 +1,77 @@
+// Package sizereport parses the size reports supported by CodeSize into Bloaty output items, so
+// that they can be indexed, summarized and displayed as a treemap just like Bloaty outputs.
+//
+// Supported formats are Bloaty TSV outputs, GNU ld and LLVM lld linker map files, twiggy[1]
+// WebAssembly size profiles, and Android APK and AAB archives.
+//
+// [1] https://github.com/rustwasm/twiggy
+package sizereport
+
+import (
+	"strings"
+
+	"go.skia.org/infra/codesize/go/bloaty"
+	"go.skia.org/infra/go/skerr"
+)
+
+// Format is the format of a size report.
+type Format string
+
+const (
+	// BloatyTSV is the TSV output of Bloaty. See bloaty.ParseTSVOutput.
+	BloatyTSV Format = "bloaty_tsv"
+
+	// LinkerMap is a map file produced by GNU ld or LLVM lld via the -Map flag.
+	LinkerMap Format = "linker_map"
+
+	// Twiggy is the JSON output of "twiggy top -f json <path/to/binary.wasm>".
+	Twiggy Format = "twiggy"
+
+	// AndroidArchive is an Android APK or AAB file.
+	AndroidArchive Format = "android_archive"
+)
+
+// AllFormats lists all supported formats.
+var AllFormats = []Format{BloatyTSV, LinkerMap, Twiggy, AndroidArchive}
+
+// extensions maps file extensions to the format of the size reports with that extension. Longer
+// extensions must come first, as ".twiggy.json" would otherwise be mistaken for a JSON metadata
+// file.
+var extensions = []struct {
+	extension string
+	format    Format
+}{
+	{".twiggy.json", Twiggy},
+	{".tsv", BloatyTSV},
+	{".map", LinkerMap},
+	{".apk", AndroidArchive},
+	{".aab", AndroidArchive},
+}
+
+// FormatFromPath returns the format of the size report at the given path, and the path without the
+// extension, to which CodeSize tasks append ".json" and ".diff.txt" to name the JSON metadata file
+// and the Bloaty size diff output file. It returns false if the path has no supported extension.
+func FormatFromPath(path string) (Format, string, bool) {
+	for _, ext := range extensions {
+		if strings.HasSuffix(path, ext.extension) {
+			return ext.format, strings.TrimSuffix(path, ext.extension), true
+		}
+	}
+	return "", "", false
+}
+
+// Parse parses a size report in the given format.
+func Parse(format Format, contents []byte) ([]bloaty.OutputItem, error) {
+	switch format {
+	case BloatyTSV:
+		return bloaty.ParseTSVOutput(string(contents))
+	case LinkerMap:
+		return ParseLinkerMap(string(contents))
+	case Twiggy:
+		return ParseTwiggy(contents)
+	case AndroidArchive:
+		return ParseAndroidArchive(contents)
+	default:
+		return nil, skerr.Fmt("unknown size report format %q", format)
+	}
+}
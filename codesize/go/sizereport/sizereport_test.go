@@ -0,0 +1,35 @@
+package sizereport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatFromPath_SupportedExtensions_Success(t *testing.T) {
+	test := func(path string, expectedFormat Format, expectedBasePath string) {
+		format, basePath, ok := FormatFromPath(path)
+		require.True(t, ok, path)
+		assert.Equal(t, expectedFormat, format)
+		assert.Equal(t, expectedBasePath, basePath)
+	}
+	test("2022/01/31/01/dm.tsv", BloatyTSV, "2022/01/31/01/dm")
+	test("2022/01/31/01/dm.map", LinkerMap, "2022/01/31/01/dm")
+	test("2022/01/31/01/canvaskit.twiggy.json", Twiggy, "2022/01/31/01/canvaskit")
+	test("2022/01/31/01/skqp.apk", AndroidArchive, "2022/01/31/01/skqp")
+	test("2022/01/31/01/skqp.aab", AndroidArchive, "2022/01/31/01/skqp")
+}
+
+func TestFormatFromPath_UnsupportedExtension_ReturnsFalse(t *testing.T) {
+	for _, path := range []string{"2022/01/31/01/dm.json", "2022/01/31/01/dm.diff.txt", "dm"} {
+		_, _, ok := FormatFromPath(path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestParse_UnknownFormat_Error(t *testing.T) {
+	_, err := Parse("elf", []byte("foo"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown size report format")
+}
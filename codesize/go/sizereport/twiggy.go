@@ -0,0 +1,59 @@
+package sizereport
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"go.skia.org/infra/codesize/go/bloaty"
+	"go.skia.org/infra/go/skerr"
+)
+
+// twiggyItem is an item in the JSON output of "twiggy top".
+type twiggyItem struct {
+	Name        string `json:"name"`
+	ShallowSize int    `json:"shallow_size"`
+}
+
+// twiggyIndexedItemRegexp matches items without a debug name, e.g. "code[12]" or "data[3]".
+var twiggyIndexedItemRegexp = regexp.MustCompile(`^([a-z_]+)\[\d+\]$`)
+
+// ParseTwiggy parses the JSON output of twiggy, i.e. the output of an invocation such as the
+// following:
+//
+//	$ twiggy top -n 1000000 -f json <path/to/binary.wasm>
+//
+// WebAssembly modules have no compile units, so items are grouped by the kind of item: functions
+// are grouped under "[wasm functions]", items without a debug name (e.g. "data[3]") under
+// "[wasm <kind>]", and custom sections (e.g. the "name" section) under "[wasm custom sections]".
+// WebAssembly modules are loaded in their entirety, so the VM size of each item is its file size.
+func ParseTwiggy(contents []byte) ([]bloaty.OutputItem, error) {
+	var twiggyItems []twiggyItem
+	if err := json.Unmarshal(contents, &twiggyItems); err != nil {
+		return nil, skerr.Wrapf(err, "failed to parse twiggy output")
+	}
+	if len(twiggyItems) == 0 {
+		return nil, skerr.Fmt("empty input")
+	}
+
+	var items []bloaty.OutputItem
+	for _, twiggyItem := range twiggyItems {
+		// Skip summary rows, e.g. "... and 12 more." and "Σ [1234 Total Rows]".
+		if strings.HasPrefix(twiggyItem.Name, "... and ") || strings.HasPrefix(twiggyItem.Name, "Σ") {
+			continue
+		}
+		compileUnit := "[wasm functions]"
+		if m := twiggyIndexedItemRegexp.FindStringSubmatch(twiggyItem.Name); m != nil {
+			compileUnit = "[wasm " + m[1] + "]"
+		} else if strings.HasPrefix(twiggyItem.Name, "custom section ") || strings.HasSuffix(twiggyItem.Name, " subsection") {
+			compileUnit = "[wasm custom sections]"
+		}
+		items = append(items, bloaty.OutputItem{
+			CompileUnit:       compileUnit,
+			Symbol:            twiggyItem.Name,
+			VirtualMemorySize: twiggyItem.ShallowSize,
+			FileSize:          twiggyItem.ShallowSize,
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,33 @@
+package sizereport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/codesize/go/bloaty"
+)
+
+func TestParseTwiggy_Success(t *testing.T) {
+	items, err := ParseTwiggy([]byte(`[
+		{"name": "data[0]", "shallow_size": 1000, "shallow_size_percent": 50.0},
+		{"name": "SkCanvas::drawRect(SkRect const&, SkPaint const&)", "shallow_size": 600, "shallow_size_percent": 30.0},
+		{"name": "\"function names\" subsection", "shallow_size": 300, "shallow_size_percent": 15.0},
+		{"name": "code[12]", "shallow_size": 100, "shallow_size_percent": 5.0},
+		{"name": "Σ [4 Total Rows]", "shallow_size": 2000, "shallow_size_percent": 100.0}
+	]`))
+	require.NoError(t, err)
+	assert.Equal(t, []bloaty.OutputItem{
+		{CompileUnit: "[wasm data]", Symbol: "data[0]", VirtualMemorySize: 1000, FileSize: 1000},
+		{CompileUnit: "[wasm functions]", Symbol: "SkCanvas::drawRect(SkRect const&, SkPaint const&)", VirtualMemorySize: 600, FileSize: 600},
+		{CompileUnit: "[wasm custom sections]", Symbol: "\"function names\" subsection", VirtualMemorySize: 300, FileSize: 300},
+		{CompileUnit: "[wasm code]", Symbol: "code[12]", VirtualMemorySize: 100, FileSize: 100},
+	}, items)
+}
+
+func TestParseTwiggy_InvalidJSON_Error(t *testing.T) {
+	_, err := ParseTwiggy([]byte(" Shallow Bytes │ Shallow % │ Item"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse twiggy output")
+}
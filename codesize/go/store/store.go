@@ -4,11 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"sort"
-	"strings"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
+	"go.skia.org/infra/codesize/go/bloaty"
 	"go.skia.org/infra/codesize/go/common"
+	"go.skia.org/infra/codesize/go/sizereport"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 )
@@ -16,6 +17,15 @@ import (
 // Size of the in-memory cache with the contents of Bloaty output files.
 const gcsLRUCacheSize = 100 // Arbitrarily chosen.
 
+// Size of the in-memory cache with the size summaries of binaries. Summaries are much smaller than
+// Bloaty output files, so we can afford to keep enough of them to cover the preloaded history.
+const sizeSummaryLRUCacheSize = 10000 // Arbitrarily chosen.
+
+// Size of the in-memory cache with the parsed contents of Android archives. Archives can be hundreds
+// of megabytes, so they are never kept in the cache of Bloaty output files; only the much smaller
+// breakdown of their contents is cached.
+const androidArchiveItemsLRUCacheSize = 20 // Arbitrarily chosen.
+
 // CommitOrPatchset is used to group Bloaty outputs by commit, i.e. those generated by a post-submit
 // task, or by patchset, i.e. those generated by a tryjob.
 type CommitOrPatchset struct {
@@ -28,11 +38,17 @@ func (c CommitOrPatchset) IsPatchset() bool {
 	return c.PatchIssue != "" || c.PatchSet != ""
 }
 
-// Binary represents a single binary that has been analyzed with Bloaty.
+// Binary represents a single binary that has been analyzed with Bloaty or another size tool.
 type Binary struct {
-	Metadata                        common.BloatyOutputMetadata `json:"metadata"`
-	BloatyOutputFileGCSPath         string                      `json:"-"`
-	BloatySizeDiffOutputFileGCSPath string                      `json:"-"`
+	Metadata common.BloatyOutputMetadata `json:"metadata"`
+
+	// Format is the format of the size report found at BloatyOutputFileGCSPath.
+	Format sizereport.Format `json:"format"`
+
+	// BloatyOutputFileGCSPath is the path to the Bloaty output file, or to the size report in one of
+	// the other supported formats.
+	BloatyOutputFileGCSPath         string `json:"-"`
+	BloatySizeDiffOutputFileGCSPath string `json:"-"`
 
 	// Timestamp should reflect the "timestamp" field in the JSON metadata.
 	Timestamp time.Time `json:"-"`
@@ -51,8 +67,10 @@ type DownloadFn func(ctx context.Context, path string) ([]byte, error)
 
 // Store keeps track of the Bloaty output files found in GCS, and provides methods to access them.
 type Store struct {
-	downloadFn DownloadFn
-	gcsCache   *lru.Cache
+	downloadFn               DownloadFn
+	gcsCache                 *lru.Cache
+	sizeSummaryCache         *lru.Cache
+	androidArchiveItemsCache *lru.Cache
 
 	binariesByCommitOrPatchset map[CommitOrPatchset][]Binary
 	indexedFiles               map[string]bool
@@ -65,20 +83,32 @@ func New(downloadFn DownloadFn) Store {
 		// This only happens if the provided cache size value is negative, so not a recoverable error.
 		panic(err)
 	}
+	sizeSummaryCache, err := lru.New(sizeSummaryLRUCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	androidArchiveItemsCache, err := lru.New(androidArchiveItemsLRUCacheSize)
+	if err != nil {
+		panic(err)
+	}
 
 	return Store{
 		downloadFn:                 downloadFn,
 		gcsCache:                   cache,
+		sizeSummaryCache:           sizeSummaryCache,
+		androidArchiveItemsCache:   androidArchiveItemsCache,
 		binariesByCommitOrPatchset: map[CommitOrPatchset][]Binary{},
 		indexedFiles:               map[string]bool{},
 	}
 }
 
-// Index indexes the given Bloaty output file (*.tsv). It downloads the corresponding JSON metadata
-// file, which is kept in memory, but it does not download the Bloaty output file itself.
+// Index indexes the given Bloaty output file (*.tsv), or size report in one of the other formats
+// supported by the sizereport package. It downloads the corresponding JSON metadata file, which is
+// kept in memory, but it does not download the Bloaty output file or size report itself.
 func (s *Store) Index(ctx context.Context, bloatyOutputFileGCSPath string) error {
-	if !strings.HasSuffix(bloatyOutputFileGCSPath, ".tsv") {
-		return skerr.Fmt(`file must end with ".tsv", got: %s`, bloatyOutputFileGCSPath)
+	format, basePath, ok := sizereport.FormatFromPath(bloatyOutputFileGCSPath)
+	if !ok {
+		return skerr.Fmt(`file must end with ".tsv" or the extension of another supported size report format, got: %s`, bloatyOutputFileGCSPath)
 	}
 
 	// Prevent indexing the same file twice, which can cause duplicate entries on the web UI. This
@@ -93,7 +123,6 @@ func (s *Store) Index(ctx context.Context, bloatyOutputFileGCSPath string) error
 	sklog.Infof("Indexing file: %s", bloatyOutputFileGCSPath)
 
 	// Download and parse JSON metadata file.
-	basePath := bloatyOutputFileGCSPath[:len(bloatyOutputFileGCSPath)-4] // Drop the ".tsv" extension.
 	jsonMetadataFilePath := basePath + ".json"
 	bytes, err := s.downloadFn(ctx, jsonMetadataFilePath) // We skip the GCS cache as JSON files are only downloaded once.
 	if err != nil {
@@ -117,6 +146,7 @@ func (s *Store) Index(ctx context.Context, bloatyOutputFileGCSPath string) error
 	// Add a new entry to the index.
 	binary := Binary{
 		Metadata:                        metadata,
+		Format:                          format,
 		BloatyOutputFileGCSPath:         bloatyOutputFileGCSPath,
 		BloatySizeDiffOutputFileGCSPath: bloatySizeDiffOutputFileGCSPath,
 		Timestamp:                       timestamp,
@@ -172,8 +202,87 @@ func (s *Store) GetBinary(commitOrPatchset CommitOrPatchset, binaryName, compile
 	return Binary{}, false
 }
 
+// GetIndexedBinary returns the binary with the given Bloaty output file, if it has been indexed.
+func (s *Store) GetIndexedBinary(bloatyOutputFileGCSPath string) (Binary, bool) {
+	if !s.indexedFiles[bloatyOutputFileGCSPath] {
+		return Binary{}, false
+	}
+	for _, binaries := range s.binariesByCommitOrPatchset {
+		for _, binary := range binaries {
+			if binary.BloatyOutputFileGCSPath == bloatyOutputFileGCSPath {
+				return binary, true
+			}
+		}
+	}
+	return Binary{}, false
+}
+
+// GetBinaryHistory returns the binaries with the given name built by the given compile task at
+// each indexed commit (i.e. excluding tryjobs), in chronological order.
+func (s *Store) GetBinaryHistory(binaryName, compileTaskName string) []Binary {
+	var history []Binary
+	for commitOrPatchset := range s.binariesByCommitOrPatchset {
+		if commitOrPatchset.IsPatchset() {
+			continue
+		}
+		if binary, ok := s.GetBinary(commitOrPatchset, binaryName, compileTaskName); ok {
+			history = append(history, binary)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Timestamp.Before(history[j].Timestamp)
+	})
+	return history
+}
+
+// GetSizeSummary returns the size summary of the given binary, which is computed from its Bloaty
+// output file.
+func (s *Store) GetSizeSummary(ctx context.Context, binary Binary) (bloaty.SizeSummary, error) {
+	if summary, ok := s.sizeSummaryCache.Get(binary.BloatyOutputFileGCSPath); ok {
+		return summary.(bloaty.SizeSummary), nil
+	}
+	items, err := s.GetOutputItems(ctx, binary)
+	if err != nil {
+		return bloaty.SizeSummary{}, skerr.Wrap(err)
+	}
+	summary := bloaty.Summarize(items)
+	s.sizeSummaryCache.Add(binary.BloatyOutputFileGCSPath, summary)
+	return summary, nil
+}
+
+// GetOutputItems downloads and parses the Bloaty output file or size report of the given binary.
+func (s *Store) GetOutputItems(ctx context.Context, binary Binary) ([]bloaty.OutputItem, error) {
+	isAndroidArchive := binary.Format == sizereport.AndroidArchive
+	if isAndroidArchive {
+		if items, ok := s.androidArchiveItemsCache.Get(binary.BloatyOutputFileGCSPath); ok {
+			return items.([]bloaty.OutputItem), nil
+		}
+	}
+	bytes, err := s.GetBloatyOutputFileContents(ctx, binary)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	format := binary.Format
+	if format == "" {
+		format = sizereport.BloatyTSV
+	}
+	items, err := sizereport.Parse(format, bytes)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to parse %s", binary.BloatyOutputFileGCSPath)
+	}
+	if isAndroidArchive {
+		s.androidArchiveItemsCache.Add(binary.BloatyOutputFileGCSPath, items)
+	}
+	return items, nil
+}
+
 // GetBloatyOutputFileContents downloads and returns the raw contents of a Bloaty output file.
 func (s *Store) GetBloatyOutputFileContents(ctx context.Context, binary Binary) ([]byte, error) {
+	if binary.Format == sizereport.AndroidArchive {
+		// Android archives are too large to keep in the cache.
+		bytes, err := s.downloadFn(ctx, binary.BloatyOutputFileGCSPath)
+		return bytes, skerr.Wrap(err)
+	}
 	return s.downloadAndCache(ctx, binary.BloatyOutputFileGCSPath)
 }
 
@@ -1,14 +1,19 @@
 package store
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/codesize/go/bloaty"
 	"go.skia.org/infra/codesize/go/common"
+	"go.skia.org/infra/codesize/go/sizereport"
 )
 
 func TestStore_Index_InvalidJSONMetadataFile_Error(t *testing.T) {
@@ -75,6 +80,7 @@ func TestStore_IndexThenGetMostRecentBinaries_Success(t *testing.T) {
 						BinaryName:      "dm",
 						Revision:        "commit2",
 					},
+					Format:                  sizereport.BloatyTSV,
 					BloatyOutputFileGCSPath: "commit2/Build-Foo/dm.tsv",
 					Timestamp:               time.Date(2022, time.February, 16, 16, 34, 48, 0, time.UTC),
 				},
@@ -93,6 +99,7 @@ func TestStore_IndexThenGetMostRecentBinaries_Success(t *testing.T) {
 						BinaryName:      "dm",
 						Revision:        "commit1",
 					},
+					Format:                  sizereport.BloatyTSV,
 					BloatyOutputFileGCSPath: "commit1/Build-Foo/dm.tsv",
 					Timestamp:               time.Date(2022, time.February, 16, 15, 23, 19, 0, time.UTC),
 				},
@@ -104,6 +111,7 @@ func TestStore_IndexThenGetMostRecentBinaries_Success(t *testing.T) {
 						BinaryName:      "fm",
 						Revision:        "commit1",
 					},
+					Format:                  sizereport.BloatyTSV,
 					BloatyOutputFileGCSPath: "commit1/Build-Foo/fm.tsv",
 					Timestamp:               time.Date(2022, time.February, 16, 15, 22, 50, 0, time.UTC),
 				},
@@ -115,6 +123,7 @@ func TestStore_IndexThenGetMostRecentBinaries_Success(t *testing.T) {
 						BinaryName:      "dm",
 						Revision:        "commit1",
 					},
+					Format:                  sizereport.BloatyTSV,
 					BloatyOutputFileGCSPath: "commit1/Build-Bar/dm.tsv",
 					Timestamp:               time.Date(2022, time.February, 16, 15, 21, 53, 0, time.UTC),
 				},
@@ -137,6 +146,7 @@ func TestStore_IndexThenGetMostRecentBinaries_Success(t *testing.T) {
 						PatchSet:        "11",
 						Revision:        "commit3",
 					},
+					Format:                          sizereport.BloatyTSV,
 					BloatyOutputFileGCSPath:         "issue9876/patchset3/job1/Build-Foo/dm.tsv",
 					BloatySizeDiffOutputFileGCSPath: "issue9876/patchset3/job1/Build-Foo/dm.diff.txt",
 					Timestamp:                       time.Date(2022, time.February, 16, 14, 34, 25, 0, time.UTC),
@@ -151,6 +161,7 @@ func TestStore_IndexThenGetMostRecentBinaries_Success(t *testing.T) {
 						PatchSet:        "11",
 						Revision:        "commit3",
 					},
+					Format:                  sizereport.BloatyTSV,
 					BloatyOutputFileGCSPath: "issue9876/patchset3/job2/Build-Bar/fm.tsv",
 					Timestamp:               time.Date(2022, time.February, 16, 14, 26, 52, 0, time.UTC),
 				},
@@ -174,6 +185,7 @@ func TestStore_IndexThenGetMostRecentBinaries_Success(t *testing.T) {
 						BinaryName:      "dm",
 						Revision:        "commit2",
 					},
+					Format:                  sizereport.BloatyTSV,
 					BloatyOutputFileGCSPath: "commit2/Build-Foo/dm.tsv",
 					Timestamp:               time.Date(2022, time.February, 16, 16, 34, 48, 0, time.UTC),
 				},
@@ -192,6 +204,7 @@ func TestStore_IndexThenGetMostRecentBinaries_Success(t *testing.T) {
 						BinaryName:      "dm",
 						Revision:        "commit1",
 					},
+					Format:                  sizereport.BloatyTSV,
 					BloatyOutputFileGCSPath: "commit1/Build-Foo/dm.tsv",
 					Timestamp:               time.Date(2022, time.February, 16, 15, 23, 19, 0, time.UTC),
 				},
@@ -203,6 +216,7 @@ func TestStore_IndexThenGetMostRecentBinaries_Success(t *testing.T) {
 						BinaryName:      "fm",
 						Revision:        "commit1",
 					},
+					Format:                  sizereport.BloatyTSV,
 					BloatyOutputFileGCSPath: "commit1/Build-Foo/fm.tsv",
 					Timestamp:               time.Date(2022, time.February, 16, 15, 22, 50, 0, time.UTC),
 				},
@@ -214,6 +228,7 @@ func TestStore_IndexThenGetMostRecentBinaries_Success(t *testing.T) {
 						BinaryName:      "dm",
 						Revision:        "commit1",
 					},
+					Format:                  sizereport.BloatyTSV,
 					BloatyOutputFileGCSPath: "commit1/Build-Bar/dm.tsv",
 					Timestamp:               time.Date(2022, time.February, 16, 15, 21, 53, 0, time.UTC),
 				},
@@ -259,6 +274,7 @@ func TestStore_IndexThenGetBinary_Success(t *testing.T) {
 			BinaryName:      "dm",
 			Revision:        "commit1",
 		},
+		Format:                  sizereport.BloatyTSV,
 		BloatyOutputFileGCSPath: "commit1/Build-Foo/dm.tsv",
 		Timestamp:               time.Date(2022, time.February, 16, 15, 23, 19, 0, time.UTC),
 	},
@@ -280,6 +296,7 @@ func TestStore_IndexThenGetBinary_Success(t *testing.T) {
 			PatchSet:        "11",
 			Revision:        "commit3",
 		},
+		Format:                  sizereport.BloatyTSV,
 		BloatyOutputFileGCSPath: "issue9876/patchset3/job2/Build-Bar/fm.tsv",
 		Timestamp:               time.Date(2022, time.February, 16, 14, 26, 52, 0, time.UTC),
 	},
@@ -290,6 +307,7 @@ func TestStore_GetBloatyOutputFileContents_NonExistentBinary_Error(t *testing.T)
 	store := newStoreForTesting()
 
 	_, err := store.GetBloatyOutputFileContents(context.Background(), Binary{
+		Format:                  sizereport.BloatyTSV,
 		BloatyOutputFileGCSPath: "no-such-file.tsv",
 	})
 	require.Error(t, err)
@@ -319,6 +337,7 @@ func TestStore_IndexThenGetBloatyOutputFileContents_Success(t *testing.T) {
 			BinaryName:      "dm",
 			Revision:        "commit1",
 		},
+		Format:                  sizereport.BloatyTSV,
 		BloatyOutputFileGCSPath: "commit1/Build-Foo/dm.tsv",
 		Timestamp:               time.Date(2022, time.February, 16, 15, 23, 19, 0, time.UTC),
 	})
@@ -336,6 +355,7 @@ func TestStore_IndexThenGetBloatyOutputFileContents_Success(t *testing.T) {
 			PatchSet:        "11",
 			Revision:        "commit3",
 		},
+		Format:                  sizereport.BloatyTSV,
 		BloatyOutputFileGCSPath: "issue9876/patchset3/job2/Build-Bar/fm.tsv",
 		Timestamp:               time.Date(2022, time.February, 16, 14, 26, 52, 0, time.UTC),
 	})
@@ -379,6 +399,7 @@ func TestStore_GetBloatySizeDiffOutputFileContents_Success(t *testing.T) {
 			PatchSet:        "11",
 			Revision:        "commit3",
 		},
+		Format:                          sizereport.BloatyTSV,
 		BloatyOutputFileGCSPath:         "issue9876/patchset3/job1/Build-Foo/dm.tsv",
 		BloatySizeDiffOutputFileGCSPath: "issue9876/patchset3/job1/Build-Foo/dm.diff.txt",
 		Timestamp:                       time.Date(2022, time.February, 16, 14, 34, 25, 0, time.UTC),
@@ -387,6 +408,106 @@ func TestStore_GetBloatySizeDiffOutputFileContents_Success(t *testing.T) {
 	assert.Equal(t, "Fake Bloaty diff", string(bytes))
 }
 
+func TestStore_IndexThenGetBinaryHistory_ExcludesTryjobsInChronologicalOrder(t *testing.T) {
+	store := newStoreForTesting()
+	require.NoError(t, store.Index(context.Background(), "commit2/Build-Foo/dm.tsv"))
+	require.NoError(t, store.Index(context.Background(), "commit1/Build-Foo/dm.tsv"))
+	require.NoError(t, store.Index(context.Background(), "commit1/Build-Foo/fm.tsv"))
+	require.NoError(t, store.Index(context.Background(), "commit1/Build-Bar/dm.tsv"))
+	require.NoError(t, store.Index(context.Background(), "issue9876/patchset3/job1/Build-Foo/dm.tsv"))
+
+	history := store.GetBinaryHistory("dm", "Build-Foo")
+	require.Len(t, history, 2)
+	assert.Equal(t, "commit1/Build-Foo/dm.tsv", history[0].BloatyOutputFileGCSPath)
+	assert.Equal(t, "commit2/Build-Foo/dm.tsv", history[1].BloatyOutputFileGCSPath)
+
+	assert.Empty(t, store.GetBinaryHistory("dm", "Build-Baz"))
+}
+
+func TestStore_GetSizeSummary_DownloadsOnceAndSummarizes(t *testing.T) {
+	downloads := 0
+	store := New(func(ctx context.Context, path string) ([]byte, error) {
+		downloads++
+		return []byte("compileunits\tsymbols\tvmsize\tfilesize\n" +
+			"src/core/SkCanvas.cpp\tSkCanvas::draw()\t10\t12\n" +
+			"dm/DM.cpp\tmain\t20\t22\n"), nil
+	})
+	binary := Binary{BloatyOutputFileGCSPath: "commit1/Build-Foo/dm.tsv"}
+
+	summary, err := store.GetSizeSummary(context.Background(), binary)
+	require.NoError(t, err)
+	assert.Equal(t, bloaty.SizeSummary{
+		FileSize:          34,
+		VirtualMemorySize: 30,
+		SymbolGroups: map[string]int{
+			"src/core": 12,
+			"dm":       22,
+		},
+	}, summary)
+
+	_, err = store.GetSizeSummary(context.Background(), binary)
+	require.NoError(t, err)
+	assert.Equal(t, 1, downloads)
+}
+
+func TestStore_IndexTwiggyOutputThenGetOutputItems_Success(t *testing.T) {
+	store := newStoreForTesting()
+	require.NoError(t, store.Index(context.Background(), "commit1/Build-Wasm/canvaskit.twiggy.json"))
+
+	binary, ok := store.GetIndexedBinary("commit1/Build-Wasm/canvaskit.twiggy.json")
+	require.True(t, ok)
+	assert.Equal(t, sizereport.Twiggy, binary.Format)
+	assert.Equal(t, "canvaskit", binary.Metadata.BinaryName)
+
+	items, err := store.GetOutputItems(context.Background(), binary)
+	require.NoError(t, err)
+	assert.Equal(t, []bloaty.OutputItem{
+		{CompileUnit: "[wasm code]", Symbol: "code[0]", VirtualMemorySize: 100, FileSize: 100},
+	}, items)
+}
+
+func TestStore_GetOutputItems_AndroidArchive_CachesOnlyParsedItems(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create("classes.dex")
+	require.NoError(t, err)
+	_, err = f.Write([]byte(strings.Repeat("a", 1000)))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	downloads := 0
+	store := New(func(ctx context.Context, path string) ([]byte, error) {
+		downloads++
+		return buf.Bytes(), nil
+	})
+	binary := Binary{
+		Format:                  sizereport.AndroidArchive,
+		BloatyOutputFileGCSPath: "commit1/Build-Android/skqp.apk",
+	}
+
+	items, err := store.GetOutputItems(context.Background(), binary)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 1000, items[0].VirtualMemorySize)
+	_, err = store.GetOutputItems(context.Background(), binary)
+	require.NoError(t, err)
+	assert.Equal(t, 1, downloads)
+
+	// The archive itself is not cached.
+	assert.False(t, store.gcsCache.Contains(binary.BloatyOutputFileGCSPath))
+	_, err = store.GetBloatyOutputFileContents(context.Background(), binary)
+	require.NoError(t, err)
+	assert.Equal(t, 2, downloads)
+	assert.False(t, store.gcsCache.Contains(binary.BloatyOutputFileGCSPath))
+}
+
+func TestStore_Index_UnsupportedExtension_Error(t *testing.T) {
+	store := newStoreForTesting()
+	err := store.Index(context.Background(), "commit1/Build-Foo/dm.txt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file must end with")
+}
+
 // newStoreForTesting returns a Store that "downloads" files from the fake GCS bucket defined by
 // the fakeGCSBucket map below.
 func newStoreForTesting() Store {
@@ -492,6 +613,16 @@ var fakeGCSBucket = map[string]string{
 	`,
 	"issue9876/patchset3/job2/Build-Bar/fm.tsv": "Fake Bloaty output 6",
 
+	"commit1/Build-Wasm/canvaskit.json": `
+{
+  "version": 1,
+  "timestamp": "2022-01-31T00:00:00Z",
+  "compile_task_name": "Build-Wasm",
+  "binary_name": "canvaskit",
+  "revision": "commit1"
+}`,
+	"commit1/Build-Wasm/canvaskit.twiggy.json": `[{"name": "code[0]", "shallow_size": 100}]`,
+
 	"kaboom.json": `{{{{{"malformed JSON file": "kaboom"}`,
 	"kaboom.tsv":  "Invalid file.",
 }
@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"go.skia.org/infra/codesize/go/codesizeserver/rpc"
+	"go.skia.org/infra/codesize/go/sizereport"
 	"go.skia.org/infra/go/go2ts"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
@@ -17,11 +18,16 @@ func main() {
 	flag.Parse()
 
 	generator := go2ts.New()
+	generator.AddUnionWithName(sizereport.AllFormats, "Format")
 	generator.Add(rpc.BinaryRPCRequest{})
 	generator.Add(rpc.BinaryRPCResponse{})
 	generator.Add(rpc.BinarySizeDiffRPCRequest{})
 	generator.Add(rpc.BinarySizeDiffRPCResponse{})
 	generator.Add(rpc.MostRecentBinariesRPCResponse{})
+	generator.Add(rpc.BinaryHistoryRPCRequest{})
+	generator.Add(rpc.BinaryHistoryRPCResponse{})
+	generator.Add(rpc.BudgetCheckRPCRequest{})
+	generator.Add(rpc.BudgetCheckRPCResponse{})
 
 	err := util.WithWriteFile(*outputPath, func(w io.Writer) error {
 		return generator.Render(w)
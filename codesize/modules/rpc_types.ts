@@ -57,6 +57,7 @@ export interface BinarySizeDiffRPCResponse {
 
 export interface Binary {
 	metadata: BloatyOutputMetadata;
+	format: Format;
 }
 
 export interface BinariesFromCommitOrPatchset {
@@ -69,3 +70,49 @@ export interface BinariesFromCommitOrPatchset {
 export interface MostRecentBinariesRPCResponse {
 	binaries: BinariesFromCommitOrPatchset[] | null;
 }
+
+export interface BinaryHistoryRPCRequest {
+	binary_name: string;
+	compile_task_name: string;
+}
+
+export interface SizeSummary {
+	file_size: number;
+	vm_size: number;
+	symbol_groups: { [key: string]: number };
+}
+
+export interface BinaryHistoryPoint {
+	metadata: BloatyOutputMetadata;
+	size: SizeSummary;
+}
+
+export interface Budget {
+	binary_name: string;
+	compile_task_name?: string;
+	max_file_size?: number;
+	max_growth?: number;
+}
+
+export interface BinaryHistoryRPCResponse {
+	points: BinaryHistoryPoint[];
+	budget: Budget | null;
+}
+
+export interface BudgetCheckRPCRequest {
+	binary_name: string;
+	compile_task_name: string;
+	commit: string;
+	patch_issue: string;
+	patch_set: string;
+}
+
+export interface BudgetCheckRPCResponse {
+	metadata: BloatyOutputMetadata;
+	file_size: number;
+	growth: number;
+	budget: Budget | null;
+	violations: string[];
+}
+
+export type Format = 'bloaty_tsv' | 'linker_map' | 'twiggy' | 'android_archive';
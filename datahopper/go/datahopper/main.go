@@ -16,11 +16,13 @@ import (
 	"cloud.google.com/go/storage"
 	"go.skia.org/infra/datahopper/go/bot_metrics"
 	"go.skia.org/infra/datahopper/go/cd_metrics"
+	"go.skia.org/infra/datahopper/go/export"
 	"go.skia.org/infra/datahopper/go/gcloud_metrics"
 	"go.skia.org/infra/datahopper/go/supported_branches"
 	"go.skia.org/infra/datahopper/go/swarming_metrics"
 	"go.skia.org/infra/go/auth"
 	"go.skia.org/infra/go/common"
+	"go.skia.org/infra/go/gcs"
 	"go.skia.org/infra/go/gcs/gcsclient"
 	"go.skia.org/infra/go/git"
 	"go.skia.org/infra/go/gitstore/bt_gitstore"
@@ -47,6 +49,9 @@ var (
 	btInstance         = flag.String("bigtable_instance", "", "BigTable instance to use.")
 	btProject          = flag.String("bigtable_project", "", "GCE project to use for BigTable.")
 	dockerImageNames   = common.NewMultiStringFlag("docker_image", nil, "Docker images to watch for Continuous Deployment metrics.")
+	exportDays         = flag.Int("export_days", 3, "Number of most recent complete days of jobs and tasks to (re-)write on every export.")
+	exportDest         = flag.String("export_dest", "", "Local directory or GCS location (gs://bucket/prefix) to which jobs, tasks and bot usage are exported as day-partitioned Parquet files. Exports are disabled if not set.")
+	exportFreq         = flag.Duration("export_freq", time.Hour, "How often to export jobs, tasks and bot usage.")
 	firestoreInstance  = flag.String("firestore_instance", "", "Firestore instance to use, eg. \"production\"")
 	gcloudProjects     = common.NewMultiStringFlag("gcloud_project", nil, "GCloud projects from which to ingest data")
 	gitstoreTable      = flag.String("gitstore_bt_table", "git-repos2", "BigTable table used for GitStore.")
@@ -188,6 +193,21 @@ func main() {
 		sklog.Fatal(err)
 	}
 
+	// Export jobs, tasks and bot usage for long-term analysis.
+	if *exportDest != "" {
+		dest, err := export.NewDestination(*exportDest, func(bucket string) gcs.GCSClient {
+			return gcsclient.New(gsClient, bucket)
+		})
+		if err != nil {
+			sklog.Fatal(err)
+		}
+		exporter, err := export.New(d, dest, *exportDays)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+		exporter.Start(ctx, *exportFreq)
+	}
+
 	// Generate "time to X% bot coverage" metrics.
 	if err := bot_metrics.Start(ctx, tCache, repos, tcc, *btProject, *btInstance, ts); err != nil {
 		sklog.Fatal(err)
@@ -0,0 +1,218 @@
+// Package export periodically writes Task Scheduler jobs and tasks, and the
+// bot usage derived from them, as day-partitioned files with stable schemas to
+// a local directory or GCS. Unlike the metrics which datahopper exposes to
+// Prometheus, these files are kept indefinitely and may be loaded into
+// BigQuery for long-horizon analysis of CI cost and latency.
+//
+// Each table is written as:
+//
+//	<dest>/<table>/schema.json                  BigQuery JSON schema
+//	<dest>/<table>/dt=YYYY-MM-DD/data.parquet   rows created on the given day (UTC)
+//
+// The partitions for the most recent days are rewritten on every export so
+// that they pick up jobs and tasks which finished after the previous export.
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/db"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	day = 24 * time.Hour
+
+	schemaFile = "schema.json"
+	dataFile   = "data.parquet"
+
+	gcsPrefix = "gs://"
+)
+
+// Destination is where exported files are written.
+type Destination interface {
+	// Write writes the given file, replacing it if it already exists. The
+	// path is relative to the root of the Destination.
+	Write(ctx context.Context, path string, contents []byte) error
+}
+
+// localDestination is a Destination which writes to a local directory.
+type localDestination struct {
+	dir string
+}
+
+// Write implements Destination.
+func (d *localDestination) Write(_ context.Context, path string, contents []byte) error {
+	return util.WithWriteFile(filepath.Join(d.dir, filepath.FromSlash(path)), func(w io.Writer) error {
+		_, err := w.Write(contents)
+		return err
+	})
+}
+
+// gcsDestination is a Destination which writes to GCS.
+type gcsDestination struct {
+	client gcs.GCSClient
+	prefix string
+}
+
+// contentTypes maps the extensions of exported files to their content types.
+var contentTypes = map[string]string{
+	".json":    "application/json",
+	".parquet": "application/vnd.apache.parquet",
+}
+
+// Write implements Destination.
+func (d *gcsDestination) Write(ctx context.Context, p string, contents []byte) error {
+	opts := gcs.FileWriteOptions{ContentType: contentTypes[path.Ext(p)]}
+	return skerr.Wrap(d.client.SetFileContents(ctx, path.Join(d.prefix, p), opts, contents))
+}
+
+// NewDestination returns a Destination for the given path, which is either a
+// local directory or a GCS location of the form "gs://bucket/prefix". The
+// newGCSClient function is called to create a client for the bucket of a GCS
+// location.
+func NewDestination(dest string, newGCSClient func(bucket string) gcs.GCSClient) (Destination, error) {
+	if dest == "" {
+		return nil, skerr.Fmt("destination is required")
+	}
+	if !strings.HasPrefix(dest, gcsPrefix) {
+		return &localDestination{dir: dest}, nil
+	}
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(dest, gcsPrefix), "/")
+	if bucket == "" {
+		return nil, skerr.Fmt("invalid GCS destination %q", dest)
+	}
+	return &gcsDestination{
+		client: newGCSClient(bucket),
+		prefix: strings.Trim(prefix, "/"),
+	}, nil
+}
+
+// Reader is the subset of db.RemoteDB used by Exporter.
+type Reader interface {
+	db.TaskReader
+	db.JobReader
+}
+
+// Exporter exports jobs, tasks and bot usage.
+type Exporter struct {
+	db   Reader
+	dest Destination
+	days int
+}
+
+// New returns an Exporter which reads from the given DB and writes the given
+// number of most recent complete days to dest on every export.
+func New(d Reader, dest Destination, days int) (*Exporter, error) {
+	if days < 1 {
+		return nil, skerr.Fmt("at least one day must be exported; got %d", days)
+	}
+	return &Exporter{
+		db:   d,
+		dest: dest,
+		days: days,
+	}, nil
+}
+
+// partition returns the path of the data file for the given table and day.
+func partition(table string, d time.Time) string {
+	return path.Join(table, fmt.Sprintf("dt=%s", d.Format("2006-01-02")), dataFile)
+}
+
+// writeTable writes the schema and the given rows of one partition of a table.
+func (e *Exporter) writeTable(ctx context.Context, table string, schema Schema, d time.Time, rows []Row) error {
+	b, err := schema.JSON()
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if err := e.dest.Write(ctx, path.Join(table, schemaFile), b); err != nil {
+		return skerr.Wrapf(err, "failed to write schema for %s", table)
+	}
+	var buf bytes.Buffer
+	if err := schema.WriteParquet(&buf, rows); err != nil {
+		return skerr.Wrapf(err, "failed to encode %s", table)
+	}
+	if err := e.dest.Write(ctx, partition(table, d), buf.Bytes()); err != nil {
+		return skerr.Wrapf(err, "failed to write %s", partition(table, d))
+	}
+	return nil
+}
+
+// exportDay exports all tables for the day starting at the given time.
+func (e *Exporter) exportDay(ctx context.Context, d time.Time) error {
+	jobs, err := e.db.GetJobsFromDateRange(ctx, d, d.Add(day), "")
+	if err != nil {
+		return skerr.Wrapf(err, "failed to retrieve jobs")
+	}
+	sortJobs(jobs)
+	if err := e.writeTable(ctx, TableJobs, JobsSchema, d, JobRows(jobs)); err != nil {
+		return skerr.Wrap(err)
+	}
+	tasks, err := e.db.GetTasksFromDateRange(ctx, d, d.Add(day), "")
+	if err != nil {
+		return skerr.Wrapf(err, "failed to retrieve tasks")
+	}
+	sortTasks(tasks)
+	if err := e.writeTable(ctx, TableTasks, TasksSchema, d, TaskRows(tasks)); err != nil {
+		return skerr.Wrap(err)
+	}
+	return skerr.Wrap(e.writeTable(ctx, TableBots, BotsSchema, d, BotRows(d, tasks)))
+}
+
+// Export writes the partitions for the most recent complete days before the
+// given time.
+func (e *Exporter) Export(ctx context.Context, now time.Time) error {
+	today := now.UTC().Truncate(day)
+	for i := e.days; i > 0; i-- {
+		d := today.Add(-time.Duration(i) * day)
+		if err := e.exportDay(ctx, d); err != nil {
+			return skerr.Wrapf(err, "failed to export %s", d.Format("2006-01-02"))
+		}
+	}
+	return nil
+}
+
+// Start exports at the given frequency until the context is canceled.
+func (e *Exporter) Start(ctx context.Context, freq time.Duration) {
+	lv := metrics2.NewLiveness("datahopper_export")
+	go util.RepeatCtx(ctx, freq, func(ctx context.Context) {
+		if err := e.Export(ctx, time.Now()); err != nil {
+			sklog.Errorf("Failed to export jobs and tasks: %s", err)
+		} else {
+			lv.Reset()
+		}
+	})
+}
+
+// sortJobs sorts jobs by creation time and ID so that exports are stable.
+func sortJobs(jobs []*types.Job) {
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].Created.Equal(jobs[j].Created) {
+			return jobs[i].Created.Before(jobs[j].Created)
+		}
+		return jobs[i].Id < jobs[j].Id
+	})
+}
+
+// sortTasks sorts tasks by creation time and ID so that exports are stable.
+func sortTasks(tasks []*types.Task) {
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].Created.Equal(tasks[j].Created) {
+			return tasks[i].Created.Before(tasks[j].Created)
+		}
+		return tasks[i].Id < tasks[j].Id
+	})
+}
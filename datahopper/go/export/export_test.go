@@ -0,0 +1,99 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/task_scheduler/go/db/memory"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+var ts = time.Date(2021, time.September, 1, 10, 0, 0, 0, time.UTC)
+
+func readFile(t *testing.T, dir, path string) string {
+	b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestExport_JobsAndTasks_WritesPartitionsAndSchemas(t *testing.T) {
+	ctx := context.Background()
+	d := memory.NewInMemoryDB()
+
+	job := types.MakeTestJob(ts)
+	job.Requested = ts.Add(-time.Minute)
+	job.Finished = ts.Add(time.Hour)
+	job.Status = types.JOB_STATUS_SUCCESS
+	require.NoError(t, d.PutJob(ctx, job))
+	// This job was created on the current day, which is not exported.
+	require.NoError(t, d.PutJob(ctx, types.MakeTestJob(ts.Add(day))))
+	d.Wait()
+
+	task1 := types.MakeTestTask(ts, []string{"abc123"})
+	task1.SwarmingBotId = "skia-e-001"
+	task1.Started = ts.Add(time.Minute)
+	task1.Finished = ts.Add(11 * time.Minute)
+	task1.Status = types.TASK_STATUS_FAILURE
+	task2 := types.MakeTestTask(ts.Add(time.Hour), []string{"def456"})
+	task2.SwarmingBotId = "skia-e-001"
+	task2.Started = ts.Add(time.Hour + time.Minute)
+	task2.Finished = ts.Add(time.Hour + 6*time.Minute)
+	task2.Status = types.TASK_STATUS_SUCCESS
+	// Still running, so it does not count towards the bot's usage.
+	task3 := types.MakeTestTask(ts.Add(time.Hour), []string{"def456"})
+	task3.SwarmingBotId = "skia-e-002"
+	task3.Started = ts.Add(time.Hour + time.Minute)
+	task3.Status = types.TASK_STATUS_RUNNING
+	require.NoError(t, d.PutTasks(ctx, []*types.Task{task1, task2, task3}))
+	d.Wait()
+
+	dir := t.TempDir()
+	dest, err := NewDestination(dir, nil)
+	require.NoError(t, err)
+	e, err := New(d, dest, 1)
+	require.NoError(t, err)
+	require.NoError(t, e.Export(ctx, ts.Add(day)))
+
+	names, jobs := readParquet(t, []byte(readFile(t, dir, "jobs/dt=2021-09-01/data.parquet")))
+	require.Equal(t, []string{"id", "name", "repo", "revision", "issue", "patchset", "is_try_job", "is_force", "is_cq", "status", "requested", "created", "finished", "duration_s", "creation_lag_s", "num_tasks", "priority"}, names)
+	require.Equal(t, []Row{{job.Id, "Test-Job", "go-on-now.git", "", "", "", false, false, false, "SUCCESS", ts.Add(-time.Minute), ts, ts.Add(time.Hour), 3600.0, 60.0, int64(0), 0.0}}, jobs)
+
+	names, tasks := readParquet(t, []byte(readFile(t, dir, "tasks/dt=2021-09-01/data.parquet")))
+	require.Equal(t, []string{"id", "name", "repo", "revision", "is_try_job", "forced_job_id", "status", "attempt", "retry_of", "bot_id", "swarming_task_id", "task_executor", "created", "started", "finished", "pending_s", "running_s", "num_commits"}, names)
+	require.Len(t, tasks, 3)
+	require.Equal(t, Row{task1.Id, "Test-Task", "go-on-now.git", "abc123", false, "", "FAILURE", int64(0), "", "skia-e-001", "swarmid", "", ts, ts.Add(time.Minute), ts.Add(11 * time.Minute), 60.0, 600.0, int64(1)}, tasks[0])
+	// Unfinished tasks have no finish time.
+	for _, row := range tasks {
+		if row[0] == task3.Id {
+			require.Nil(t, row[14])
+		} else {
+			require.NotNil(t, row[14])
+		}
+	}
+
+	names, bots := readParquet(t, []byte(readFile(t, dir, "bots/dt=2021-09-01/data.parquet")))
+	require.Equal(t, []string{"bot_id", "date", "num_tasks", "num_failed", "num_mishaps", "busy_s"}, names)
+	require.Equal(t, []Row{{"skia-e-001", ts.Truncate(day), int64(2), int64(1), int64(0), 900.0}}, bots)
+
+	for _, table := range []string{TableJobs, TableTasks, TableBots} {
+		require.Contains(t, readFile(t, dir, table+"/schema.json"), `"mode": "NULLABLE"`)
+	}
+	_, err = os.Stat(filepath.Join(dir, "jobs", "dt=2021-09-02"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestNewDestination_GCSPath_UsesBucketAndPrefix(t *testing.T) {
+	var bucket string
+	dest, err := NewDestination("gs://my-bucket/some/prefix/", func(b string) gcs.GCSClient {
+		bucket = b
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "my-bucket", bucket)
+	require.Equal(t, "some/prefix", dest.(*gcsDestination).prefix)
+}
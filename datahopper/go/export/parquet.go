@@ -0,0 +1,384 @@
+package export
+
+// This file writes Tables as Parquet files. Only the subset of the format
+// needed for flat tables of nullable columns is supported: each file has a
+// single row group, each column chunk has a single uncompressed, PLAIN-encoded
+// data page, and the metadata is encoded with the Thrift compact protocol. See
+// https://github.com/apache/parquet-format for the specification.
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+const (
+	parquetMagic     = "PAR1"
+	parquetCreatedBy = "go.skia.org/infra/datahopper/go/export"
+
+	// Physical types.
+	parquetTypeBoolean   = 0
+	parquetTypeInt64     = 2
+	parquetTypeDouble    = 5
+	parquetTypeByteArray = 6
+
+	// Converted types, for readers which don't support logical types.
+	parquetConvertedUTF8            = 0
+	parquetConvertedTimestampMicros = 10
+
+	parquetRepetitionOptional = 1
+	parquetEncodingPlain      = 0
+	parquetEncodingRLE        = 3
+	parquetCodecUncompressed  = 0
+	parquetPageTypeData       = 0
+
+	// Thrift compact protocol types.
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes Thrift structs using the compact protocol.
+type thriftWriter struct {
+	buf bytes.Buffer
+	// lastIDs holds the ID of the last field written in each struct being
+	// written, innermost last, since field IDs are delta-encoded.
+	lastIDs []int16
+}
+
+func (w *thriftWriter) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+// varint writes a zigzag-encoded varint, as used for all integer types.
+func (w *thriftWriter) varint(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf.Write(b[:binary.PutVarint(b[:], v)])
+}
+
+func (w *thriftWriter) str(v string) {
+	w.uvarint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+func (w *thriftWriter) beginStruct() {
+	w.lastIDs = append(w.lastIDs, 0)
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf.WriteByte(0)
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &w.lastIDs[len(w.lastIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) boolField(id int16, v bool) {
+	if v {
+		w.fieldHeader(id, thriftTrue)
+	} else {
+		w.fieldHeader(id, thriftFalse)
+	}
+}
+
+func (w *thriftWriter) i32Field(id int16, v int32) {
+	w.fieldHeader(id, thriftI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64Field(id int16, v int64) {
+	w.fieldHeader(id, thriftI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) stringField(id int16, v string) {
+	w.fieldHeader(id, thriftBinary)
+	w.str(v)
+}
+
+// structField writes a struct field whose fields are written by fn.
+func (w *thriftWriter) structField(id int16, fn func()) {
+	w.fieldHeader(id, thriftStruct)
+	w.beginStruct()
+	fn()
+	w.endStruct()
+}
+
+// listField writes the header of a list field with n elements of the given
+// type, which the caller must then write.
+func (w *thriftWriter) listField(id int16, elemType byte, n int) {
+	w.fieldHeader(id, thriftList)
+	if n < 15 {
+		w.buf.WriteByte(byte(n)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xf0 | elemType)
+		w.uvarint(uint64(n))
+	}
+}
+
+// parquetType returns the physical type and converted type of a Column.
+func parquetType(c Column) (int32, int32, bool) {
+	switch c.Type {
+	case ColumnTypeString:
+		return parquetTypeByteArray, parquetConvertedUTF8, true
+	case ColumnTypeInteger:
+		return parquetTypeInt64, 0, false
+	case ColumnTypeFloat:
+		return parquetTypeDouble, 0, false
+	case ColumnTypeBoolean:
+		return parquetTypeBoolean, 0, false
+	case ColumnTypeTimestamp:
+		return parquetTypeInt64, parquetConvertedTimestampMicros, true
+	}
+	return 0, 0, false
+}
+
+// encodeColumnPage returns the contents of a data page holding the given
+// column of the rows: the definition levels followed by the PLAIN-encoded
+// non-NULL values.
+func encodeColumnPage(c Column, idx int, rows []Row) ([]byte, error) {
+	defined := make([]bool, 0, len(rows))
+	var bits []bool
+	var values bytes.Buffer
+	var b [8]byte
+	for _, row := range rows {
+		v := row[idx]
+		ok := false
+		switch c.Type {
+		case ColumnTypeString:
+			var s string
+			if s, ok = v.(string); ok {
+				binary.LittleEndian.PutUint32(b[:4], uint32(len(s)))
+				values.Write(b[:4])
+				values.WriteString(s)
+			}
+		case ColumnTypeInteger:
+			var i int64
+			if i, ok = v.(int64); ok {
+				binary.LittleEndian.PutUint64(b[:], uint64(i))
+				values.Write(b[:])
+			}
+		case ColumnTypeFloat:
+			var f float64
+			if f, ok = v.(float64); ok {
+				binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+				values.Write(b[:])
+			}
+		case ColumnTypeBoolean:
+			var bit bool
+			if bit, ok = v.(bool); ok {
+				bits = append(bits, bit)
+			}
+		case ColumnTypeTimestamp:
+			var ts time.Time
+			if ts, ok = v.(time.Time); ok {
+				if ts.IsZero() {
+					defined = append(defined, false)
+					continue
+				}
+				binary.LittleEndian.PutUint64(b[:], uint64(ts.UnixMicro()))
+				values.Write(b[:])
+			}
+		}
+		if !ok {
+			return nil, skerr.Fmt("invalid value %v (%T) for %s column %q", v, v, c.Type, c.Name)
+		}
+		defined = append(defined, true)
+	}
+	// Booleans are bit-packed, least significant bit first.
+	if len(bits) > 0 {
+		packed := make([]byte, (len(bits)+7)/8)
+		for i, bit := range bits {
+			if bit {
+				packed[i/8] |= 1 << (i % 8)
+			}
+		}
+		values.Write(packed)
+	}
+	levels := encodeDefinitionLevels(defined)
+	rv := make([]byte, 4, 4+len(levels)+values.Len())
+	binary.LittleEndian.PutUint32(rv, uint32(len(levels)))
+	rv = append(rv, levels...)
+	return append(rv, values.Bytes()...), nil
+}
+
+// encodeDefinitionLevels encodes the definition levels of a nullable column,
+// which are 1 for defined values and 0 for NULLs, with the RLE/bit-packing
+// hybrid encoding. Only RLE runs are used.
+func encodeDefinitionLevels(defined []bool) []byte {
+	var buf bytes.Buffer
+	var b [binary.MaxVarintLen64]byte
+	for i := 0; i < len(defined); {
+		j := i
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		// The header of an RLE run is its length shifted left by one,
+		// followed by the repeated value in one byte, since the bit width
+		// of the levels is one.
+		buf.Write(b[:binary.PutUvarint(b[:], uint64(j-i)<<1)])
+		if defined[i] {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+		i = j
+	}
+	return buf.Bytes()
+}
+
+// encodePageHeader returns the PageHeader of a data page with the given size
+// holding the given number of values, including NULLs.
+func encodePageHeader(size, numValues int) []byte {
+	t := &thriftWriter{}
+	t.beginStruct()
+	t.i32Field(1, parquetPageTypeData)
+	t.i32Field(2, int32(size)) // Uncompressed size.
+	t.i32Field(3, int32(size)) // Compressed size.
+	t.structField(5, func() {
+		t.i32Field(1, int32(numValues))
+		t.i32Field(2, parquetEncodingPlain)
+		t.i32Field(3, parquetEncodingRLE) // Definition levels.
+		t.i32Field(4, parquetEncodingRLE) // Repetition levels.
+	})
+	t.endStruct()
+	return t.buf.Bytes()
+}
+
+// columnChunk describes the location of a column chunk within a file.
+type columnChunk struct {
+	offset int64
+	size   int64
+}
+
+// encodeFileMetaData returns the FileMetaData of a file containing the given
+// number of rows of the Schema, in a single row group made up of the given
+// column chunks, if there are any rows.
+func (s Schema) encodeFileMetaData(numRows int, chunks []columnChunk) []byte {
+	t := &thriftWriter{}
+	t.beginStruct()
+	t.i32Field(1, 1) // Version.
+	t.listField(2, thriftStruct, len(s)+1)
+	// The root of the schema is a group containing all of the columns.
+	t.beginStruct()
+	t.stringField(4, "schema")
+	t.i32Field(5, int32(len(s)))
+	t.endStruct()
+	for _, c := range s {
+		typ, converted, hasConverted := parquetType(c)
+		t.beginStruct()
+		t.i32Field(1, typ)
+		t.i32Field(3, parquetRepetitionOptional)
+		t.stringField(4, c.Name)
+		if hasConverted {
+			t.i32Field(6, converted)
+		}
+		// Logical type.
+		switch c.Type {
+		case ColumnTypeString:
+			t.structField(10, func() {
+				t.structField(1, func() {}) // STRING.
+			})
+		case ColumnTypeTimestamp:
+			t.structField(10, func() {
+				t.structField(8, func() { // TIMESTAMP.
+					t.boolField(1, true) // isAdjustedToUTC.
+					t.structField(2, func() {
+						t.structField(2, func() {}) // MICROS.
+					})
+				})
+			})
+		}
+		t.endStruct()
+	}
+	t.i64Field(3, int64(numRows))
+	if len(chunks) == 0 {
+		t.listField(4, thriftStruct, 0)
+	} else {
+		t.listField(4, thriftStruct, 1)
+		t.beginStruct()
+		t.listField(1, thriftStruct, len(chunks))
+		var totalSize int64
+		for idx, chunk := range chunks {
+			typ, _, _ := parquetType(s[idx])
+			t.beginStruct()
+			t.i64Field(2, chunk.offset)
+			t.structField(3, func() {
+				t.i32Field(1, typ)
+				t.listField(2, thriftI32, 2)
+				t.varint(parquetEncodingPlain)
+				t.varint(parquetEncodingRLE)
+				t.listField(3, thriftBinary, 1)
+				t.str(s[idx].Name)
+				t.i32Field(4, parquetCodecUncompressed)
+				t.i64Field(5, int64(numRows))
+				t.i64Field(6, chunk.size) // Uncompressed size.
+				t.i64Field(7, chunk.size) // Compressed size.
+				t.i64Field(9, chunk.offset)
+			})
+			t.endStruct()
+			totalSize += chunk.size
+		}
+		t.i64Field(2, totalSize)
+		t.i64Field(3, int64(numRows))
+		t.endStruct()
+	}
+	t.stringField(6, parquetCreatedBy)
+	t.endStruct()
+	return t.buf.Bytes()
+}
+
+// WriteParquet writes the rows as a Parquet file. Every column is OPTIONAL;
+// zero time.Time values are written as NULL. TIMESTAMP columns are stored as
+// microseconds since the Unix epoch, in UTC.
+func (s Schema) WriteParquet(w io.Writer, rows []Row) error {
+	for _, row := range rows {
+		if len(row) != len(s) {
+			return skerr.Fmt("row has %d values but the schema has %d columns", len(row), len(s))
+		}
+	}
+	var buf bytes.Buffer
+	buf.WriteString(parquetMagic)
+	var chunks []columnChunk
+	if len(rows) > 0 {
+		chunks = make([]columnChunk, 0, len(s))
+		for idx, c := range s {
+			page, err := encodeColumnPage(c, idx, rows)
+			if err != nil {
+				return skerr.Wrap(err)
+			}
+			header := encodePageHeader(len(page), len(rows))
+			chunks = append(chunks, columnChunk{
+				offset: int64(buf.Len()),
+				size:   int64(len(header) + len(page)),
+			})
+			buf.Write(header)
+			buf.Write(page)
+		}
+	}
+	meta := s.encodeFileMetaData(len(rows), chunks)
+	buf.Write(meta)
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(len(meta)))
+	buf.Write(b[:])
+	buf.WriteString(parquetMagic)
+	_, err := w.Write(buf.Bytes())
+	return skerr.Wrap(err)
+}
@@ -0,0 +1,259 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/testutils"
+)
+
+// thriftReader decodes Thrift structs encoded with the compact protocol into
+// maps of field ID to value, independently of thriftWriter.
+type thriftReader struct {
+	t   *testing.T
+	b   []byte
+	pos int
+}
+
+func (r *thriftReader) byte() byte {
+	require.Less(r.t, r.pos, len(r.b), "unexpected end of data")
+	r.pos++
+	return r.b[r.pos-1]
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b[r.pos:])
+	require.Greater(r.t, n, 0, "invalid varint")
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) varint() int64 {
+	v, n := binary.Varint(r.b[r.pos:])
+	require.Greater(r.t, n, 0, "invalid varint")
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case thriftTrue:
+		return true
+	case thriftFalse:
+		return false
+	case thriftI32, thriftI64:
+		return r.varint()
+	case thriftBinary:
+		n := int(r.uvarint())
+		r.pos += n
+		return string(r.b[r.pos-n : r.pos])
+	case thriftList:
+		h := r.byte()
+		n := int(h >> 4)
+		if n == 15 {
+			n = int(r.uvarint())
+		}
+		rv := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			rv = append(rv, r.value(h&0x0f))
+		}
+		return rv
+	case thriftStruct:
+		return r.readStruct()
+	}
+	require.FailNow(r.t, "unsupported thrift type", "%d", typ)
+	return nil
+}
+
+func (r *thriftReader) readStruct() map[int16]interface{} {
+	rv := map[int16]interface{}{}
+	var last int16
+	for {
+		h := r.byte()
+		if h == 0 {
+			return rv
+		}
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			id = int16(r.varint())
+		}
+		rv[id] = r.value(h & 0x0f)
+		last = id
+	}
+}
+
+// readParquet decodes a file written by WriteParquet, returning the column
+// names and the rows. NULLs are returned as nil.
+func readParquet(t *testing.T, b []byte) ([]string, []Row) {
+	require.Equal(t, parquetMagic, string(b[:4]))
+	require.Equal(t, parquetMagic, string(b[len(b)-4:]))
+	metaLen := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	r := &thriftReader{t: t, b: b[:len(b)-8], pos: len(b) - 8 - metaLen}
+	meta := r.readStruct()
+	require.Equal(t, len(b)-8, r.pos)
+
+	schema := meta[2].([]interface{})
+	root := schema[0].(map[int16]interface{})
+	require.Equal(t, int64(len(schema)-1), root[5])
+	var names []string
+	for _, elem := range schema[1:] {
+		names = append(names, elem.(map[int16]interface{})[4].(string))
+	}
+	numRows := int(meta[3].(int64))
+	rows := make([]Row, numRows)
+	for i := range rows {
+		rows[i] = make(Row, len(names))
+	}
+	rowGroups := meta[4].([]interface{})
+	if numRows == 0 {
+		require.Empty(t, rowGroups)
+		return names, rows
+	}
+	require.Len(t, rowGroups, 1)
+	chunks := rowGroups[0].(map[int16]interface{})[1].([]interface{})
+	require.Len(t, chunks, len(names))
+	for idx, chunk := range chunks {
+		colMeta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+		require.Equal(t, []interface{}{names[idx]}, colMeta[3])
+		require.Equal(t, int64(numRows), colMeta[5])
+		elem := schema[idx+1].(map[int16]interface{})
+
+		// Read the page header.
+		r := &thriftReader{t: t, b: b, pos: int(colMeta[9].(int64))}
+		header := r.readStruct()
+		pageSize := int(header[3].(int64))
+		require.Equal(t, colMeta[7], int64(r.pos-int(colMeta[9].(int64))+pageSize))
+		require.Equal(t, int64(numRows), header[5].(map[int16]interface{})[1])
+		page := b[r.pos : r.pos+pageSize]
+
+		// Decode the definition levels, which should only use RLE runs.
+		levelsLen := int(binary.LittleEndian.Uint32(page))
+		lr := &thriftReader{t: t, b: page[4 : 4+levelsLen]}
+		var defined []bool
+		for lr.pos < len(lr.b) {
+			h := lr.uvarint()
+			require.Zero(t, h&1, "expected an RLE run")
+			v := lr.byte()
+			for i := uint64(0); i < h>>1; i++ {
+				defined = append(defined, v == 1)
+			}
+		}
+		require.Len(t, defined, numRows)
+
+		// Decode the values.
+		values := page[4+levelsLen:]
+		bit := 0
+		for i := range rows {
+			if !defined[i] {
+				continue
+			}
+			switch elem[1].(int64) {
+			case parquetTypeBoolean:
+				rows[i][idx] = values[bit/8]&(1<<(bit%8)) != 0
+				bit++
+			case parquetTypeInt64:
+				v := int64(binary.LittleEndian.Uint64(values))
+				values = values[8:]
+				if elem[6] == int64(parquetConvertedTimestampMicros) {
+					rows[i][idx] = time.UnixMicro(v).UTC()
+				} else {
+					rows[i][idx] = v
+				}
+			case parquetTypeDouble:
+				rows[i][idx] = math.Float64frombits(binary.LittleEndian.Uint64(values))
+				values = values[8:]
+			case parquetTypeByteArray:
+				n := int(binary.LittleEndian.Uint32(values))
+				rows[i][idx] = string(values[4 : 4+n])
+				values = values[4+n:]
+			}
+		}
+	}
+	return names, rows
+}
+
+func TestWriteParquet_AllColumnTypes_RoundTrips(t *testing.T) {
+	schema := Schema{
+		col("s", ColumnTypeString, ""),
+		col("i", ColumnTypeInteger, ""),
+		col("f", ColumnTypeFloat, ""),
+		col("b", ColumnTypeBoolean, ""),
+		col("ts", ColumnTypeTimestamp, ""),
+	}
+	var rows []Row
+	for i := 0; i < 20; i++ {
+		created := ts.Add(time.Duration(i) * time.Millisecond)
+		if i%3 == 0 {
+			created = time.Time{}
+		}
+		rows = append(rows, Row{string(rune('a' + i)), int64(i - 10), float64(i) / 4, i%2 == 0, created})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, schema.WriteParquet(&buf, rows))
+
+	names, actual := readParquet(t, buf.Bytes())
+	require.Equal(t, []string{"s", "i", "f", "b", "ts"}, names)
+	for _, row := range rows {
+		if row[4].(time.Time).IsZero() {
+			// Zero timestamps are written as NULL.
+			row[4] = nil
+		}
+	}
+	require.Equal(t, rows, actual)
+}
+
+func TestWriteParquet_NoRows_WritesEmptyFile(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, BotsSchema.WriteParquet(&buf, nil))
+	names, rows := readParquet(t, buf.Bytes())
+	require.Len(t, names, len(BotsSchema))
+	require.Empty(t, rows)
+}
+
+func TestWriteParquet_WrongValueType_ReturnsError(t *testing.T) {
+	err := BotsSchema.WriteParquet(&bytes.Buffer{}, []Row{{"bot", ts, 1, int64(0), int64(0), 0.0}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `column "num_tasks"`)
+}
+
+// goldenSchema and goldenRows are written to testdata/golden.parquet, which
+// testdata/check_golden.py verifies with pyarrow. If the output of
+// WriteParquet changes, regenerate the file by running the tests with
+// PARQUET_UPDATE_GOLDEN=1 and then run the script.
+var (
+	goldenSchema = Schema{
+		col("name", ColumnTypeString, ""),
+		col("count", ColumnTypeInteger, ""),
+		col("ratio", ColumnTypeFloat, ""),
+		col("ok", ColumnTypeBoolean, ""),
+		col("created", ColumnTypeTimestamp, ""),
+	}
+	goldenRows = []Row{
+		{"linux", int64(42), 1.5, true, ts},
+		{"ünïcode", int64(-1), -0.25, false, time.Time{}},
+		{"", int64(0), 0.0, true, ts.Add(1500 * time.Microsecond)},
+	}
+)
+
+func TestWriteParquet_MatchesGoldenFile(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, goldenSchema.WriteParquet(&buf, goldenRows))
+	if os.Getenv("PARQUET_UPDATE_GOLDEN") != "" {
+		require.NoError(t, os.WriteFile(testutils.TestDataFilename(t, "golden.parquet"), buf.Bytes(), 0644))
+	}
+	golden := testutils.ReadFileBytes(t, "golden.parquet")
+	require.Equal(t, golden, buf.Bytes())
+
+	names, rows := readParquet(t, golden)
+	require.Equal(t, []string{"name", "count", "ratio", "ok", "created"}, names)
+	require.Equal(t, []Row{
+		{"linux", int64(42), 1.5, true, ts},
+		{"ünïcode", int64(-1), -0.25, false, nil},
+		{"", int64(0), 0.0, true, ts.Add(1500 * time.Microsecond)},
+	}, rows)
+}
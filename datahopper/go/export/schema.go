@@ -0,0 +1,54 @@
+package export
+
+import (
+	"encoding/json"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// ColumnType is the type of a Column, named as in BigQuery schemas.
+type ColumnType string
+
+const (
+	ColumnTypeString    ColumnType = "STRING"
+	ColumnTypeInteger   ColumnType = "INTEGER"
+	ColumnTypeFloat     ColumnType = "FLOAT"
+	ColumnTypeBoolean   ColumnType = "BOOLEAN"
+	ColumnTypeTimestamp ColumnType = "TIMESTAMP"
+)
+
+// Column describes one column of a Table.
+type Column struct {
+	Name string     `json:"name"`
+	Type ColumnType `json:"type"`
+	// Mode is always NULLABLE; it is included so that the schema file can be
+	// passed to "bq load --schema" as-is.
+	Mode        string `json:"mode"`
+	Description string `json:"description"`
+}
+
+// Schema is the ordered list of columns of a Table. Columns may be added to
+// the end of a Schema, but existing columns must never be removed, renamed or
+// reordered, so that files written at different times can be loaded into the
+// same table.
+type Schema []Column
+
+func col(name string, typ ColumnType, desc string) Column {
+	return Column{
+		Name:        name,
+		Type:        typ,
+		Mode:        "NULLABLE",
+		Description: desc,
+	}
+}
+
+// JSON returns the Schema in the BigQuery JSON schema format.
+func (s Schema) JSON() ([]byte, error) {
+	b, err := json.MarshalIndent(s, "", "  ")
+	return b, skerr.Wrap(err)
+}
+
+// Row is a single row of a Table. Values must be string, int64, float64, bool
+// or time.Time, matching the types of the columns. The zero time.Time is
+// written as NULL.
+type Row []interface{}
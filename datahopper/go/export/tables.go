@@ -0,0 +1,179 @@
+package export
+
+import (
+	"sort"
+	"time"
+
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// TableJobs contains one row per Job.
+	TableJobs = "jobs"
+	// TableTasks contains one row per Task.
+	TableTasks = "tasks"
+	// TableBots contains one row per bot per day, aggregated from the Tasks
+	// which ran on that bot.
+	TableBots = "bots"
+)
+
+// JobsSchema is the Schema of TableJobs.
+var JobsSchema = Schema{
+	col("id", ColumnTypeString, "ID of the job."),
+	col("name", ColumnTypeString, "Name of the job."),
+	col("repo", ColumnTypeString, "Repository URL."),
+	col("revision", ColumnTypeString, "Commit hash at which the job ran."),
+	col("issue", ColumnTypeString, "Gerrit issue, for try jobs."),
+	col("patchset", ColumnTypeString, "Gerrit patchset, for try jobs."),
+	col("is_try_job", ColumnTypeBoolean, "Whether this is a try job."),
+	col("is_force", ColumnTypeBoolean, "Whether the job was forced."),
+	col("is_cq", ColumnTypeBoolean, "Whether the job was triggered by the commit queue."),
+	col("status", ColumnTypeString, "Status of the job; empty if in progress."),
+	col("requested", ColumnTypeTimestamp, "When the job was requested, eg. the commit time."),
+	col("created", ColumnTypeTimestamp, "When the job was created."),
+	col("finished", ColumnTypeTimestamp, "When the job finished."),
+	col("duration_s", ColumnTypeFloat, "Seconds from creation to completion."),
+	col("creation_lag_s", ColumnTypeFloat, "Seconds from request to creation."),
+	col("num_tasks", ColumnTypeInteger, "Number of task attempts run for the job."),
+	col("priority", ColumnTypeFloat, "Priority of the job."),
+}
+
+// TasksSchema is the Schema of TableTasks.
+var TasksSchema = Schema{
+	col("id", ColumnTypeString, "ID of the task."),
+	col("name", ColumnTypeString, "Name of the task."),
+	col("repo", ColumnTypeString, "Repository URL."),
+	col("revision", ColumnTypeString, "Commit hash at which the task ran."),
+	col("is_try_job", ColumnTypeBoolean, "Whether the task is part of a try job."),
+	col("forced_job_id", ColumnTypeString, "ID of the forced job which triggered the task, if any."),
+	col("status", ColumnTypeString, "Status of the task."),
+	col("attempt", ColumnTypeInteger, "Attempt number, starting at 0."),
+	col("retry_of", ColumnTypeString, "ID of the task this task retries, if any."),
+	col("bot_id", ColumnTypeString, "ID of the bot which ran the task."),
+	col("swarming_task_id", ColumnTypeString, "ID of the Swarming task."),
+	col("task_executor", ColumnTypeString, "Task executor which ran the task."),
+	col("created", ColumnTypeTimestamp, "When the task was created."),
+	col("started", ColumnTypeTimestamp, "When the task started running."),
+	col("finished", ColumnTypeTimestamp, "When the task finished."),
+	col("pending_s", ColumnTypeFloat, "Seconds from creation to start."),
+	col("running_s", ColumnTypeFloat, "Seconds from start to completion."),
+	col("num_commits", ColumnTypeInteger, "Number of commits in the task's blamelist."),
+}
+
+// BotsSchema is the Schema of TableBots.
+var BotsSchema = Schema{
+	col("bot_id", ColumnTypeString, "ID of the bot."),
+	col("date", ColumnTypeTimestamp, "Start of the day, in UTC."),
+	col("num_tasks", ColumnTypeInteger, "Number of finished tasks created on this day which ran on the bot."),
+	col("num_failed", ColumnTypeInteger, "Number of those tasks which failed."),
+	col("num_mishaps", ColumnTypeInteger, "Number of those tasks which had a mishap."),
+	col("busy_s", ColumnTypeFloat, "Total seconds spent running those tasks."),
+}
+
+// seconds returns the number of seconds from start to end, or 0 if either is
+// unset.
+func seconds(start, end time.Time) float64 {
+	if start.IsZero() || end.IsZero() {
+		return 0
+	}
+	return end.Sub(start).Seconds()
+}
+
+// JobRows returns the rows of TableJobs for the given Jobs.
+func JobRows(jobs []*types.Job) []Row {
+	rv := make([]Row, 0, len(jobs))
+	for _, j := range jobs {
+		numTasks := 0
+		for _, summaries := range j.Tasks {
+			numTasks += len(summaries)
+		}
+		rv = append(rv, Row{
+			j.Id,
+			j.Name,
+			j.Repo,
+			j.Revision,
+			j.Issue,
+			j.Patchset,
+			j.IsTryJob(),
+			j.IsForce,
+			j.IsCQ,
+			string(j.Status),
+			j.Requested,
+			j.Created,
+			j.Finished,
+			seconds(j.Created, j.Finished),
+			seconds(j.Requested, j.Created),
+			int64(numTasks),
+			j.Priority,
+		})
+	}
+	return rv
+}
+
+// TaskRows returns the rows of TableTasks for the given Tasks.
+func TaskRows(tasks []*types.Task) []Row {
+	rv := make([]Row, 0, len(tasks))
+	for _, t := range tasks {
+		rv = append(rv, Row{
+			t.Id,
+			t.Name,
+			t.Repo,
+			t.Revision,
+			t.IsTryJob(),
+			t.ForcedJobId,
+			string(t.Status),
+			int64(t.Attempt),
+			t.RetryOf,
+			t.SwarmingBotId,
+			t.SwarmingTaskId,
+			t.TaskExecutor,
+			t.Created,
+			t.Started,
+			t.Finished,
+			seconds(t.Created, t.Started),
+			seconds(t.Started, t.Finished),
+			int64(len(t.Commits)),
+		})
+	}
+	return rv
+}
+
+// BotRows returns the rows of TableBots for the given Tasks, all of which are
+// assumed to have been created on the day starting at the given time.
+// Unfinished tasks and tasks with no bot are ignored.
+func BotRows(day time.Time, tasks []*types.Task) []Row {
+	type botStats struct {
+		numTasks, numFailed, numMishaps int64
+		busy                            float64
+	}
+	byBot := map[string]*botStats{}
+	for _, t := range tasks {
+		if t.SwarmingBotId == "" || !t.Done() {
+			continue
+		}
+		s, ok := byBot[t.SwarmingBotId]
+		if !ok {
+			s = &botStats{}
+			byBot[t.SwarmingBotId] = s
+		}
+		s.numTasks++
+		switch t.Status {
+		case types.TASK_STATUS_FAILURE:
+			s.numFailed++
+		case types.TASK_STATUS_MISHAP:
+			s.numMishaps++
+		}
+		s.busy += seconds(t.Started, t.Finished)
+	}
+	bots := make([]string, 0, len(byBot))
+	for bot := range byBot {
+		bots = append(bots, bot)
+	}
+	sort.Strings(bots)
+	rv := make([]Row, 0, len(bots))
+	for _, bot := range bots {
+		s := byBot[bot]
+		rv = append(rv, Row{bot, day, s.numTasks, s.numFailed, s.numMishaps, s.busy})
+	}
+	return rv
+}
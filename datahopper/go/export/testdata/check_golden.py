@@ -0,0 +1,45 @@
+#!/usr/bin/env python3
+
+# Usage: check_golden.py [golden.parquet]
+#
+# Verifies that golden.parquet, which is written by WriteParquet in
+# parquet_test.go, can be read by pyarrow and holds the expected schema and
+# rows. Run this whenever golden.parquet is regenerated.
+#
+# Note: This requires pyarrow to be installed.
+
+
+import datetime
+import os
+import sys
+
+import pyarrow as pa
+import pyarrow.parquet as pq
+
+path = sys.argv[1] if len(sys.argv) > 1 else os.path.join(
+    os.path.dirname(os.path.abspath(__file__)), 'golden.parquet')
+
+created = datetime.datetime(2021, 9, 1, 10, 0, 0, tzinfo=datetime.timezone.utc)
+expected_schema = pa.schema([
+    ('name', pa.string()),
+    ('count', pa.int64()),
+    ('ratio', pa.float64()),
+    ('ok', pa.bool_()),
+    ('created', pa.timestamp('us', tz='UTC')),
+])
+expected_rows = [
+    {'name': 'linux', 'count': 42, 'ratio': 1.5, 'ok': True,
+     'created': created},
+    {'name': u'ünïcode', 'count': -1, 'ratio': -0.25, 'ok': False,
+     'created': None},
+    {'name': '', 'count': 0, 'ratio': 0.0, 'ok': True,
+     'created': created + datetime.timedelta(microseconds=1500)},
+]
+
+table = pq.read_table(path)
+if not table.schema.equals(expected_schema):
+  sys.exit('Unexpected schema:\n%s\nwant:\n%s' % (table.schema, expected_schema))
+rows = table.to_pylist()
+if rows != expected_rows:
+  sys.exit('Unexpected rows:\n%s\nwant:\n%s' % (rows, expected_rows))
+print('%s is valid' % path)
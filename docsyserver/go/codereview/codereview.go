@@ -36,3 +36,19 @@ type CodeReview interface {
 	// "refs/head/master".
 	GetPatchsetInfo(ctx context.Context, issue Issue) (string, bool, error)
 }
+
+// Commenter is a CodeReview that can also list its open issues and comment on
+// them, which allows announcing the preview URL on issues that change the
+// documentation.
+type Commenter interface {
+	CodeReview
+
+	// ListOpenIssues returns all the open issues.
+	ListOpenIssues(ctx context.Context) ([]Issue, error)
+
+	// ListComments returns the bodies of all the comments on the issue.
+	ListComments(ctx context.Context, issue Issue) ([]string, error)
+
+	// AddComment adds a comment with the given body to the issue.
+	AddComment(ctx context.Context, issue Issue, body string) error
+}
@@ -0,0 +1,191 @@
+// Package github implements CodeReview for GitHub pull requests.
+package github
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	github_api "github.com/google/go-github/v29/github"
+	"go.skia.org/infra/docsyserver/go/codereview"
+	"go.skia.org/infra/go/github"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
+	"golang.org/x/oauth2"
+)
+
+// File statuses returned by the GitHub API for the files of a pull request.
+const (
+	fileStatusRemoved = "removed"
+	fileStatusRenamed = "renamed"
+)
+
+// pullRequests is the subset of *github.GitHub used by gitHubCodeReview.
+type pullRequests interface {
+	GetPullRequest(pullRequestNum int) (*github_api.PullRequest, error)
+	ListOpenPullRequests() ([]*github_api.PullRequest, error)
+	ListPullRequestFiles(pullRequestNum int) ([]*github_api.CommitFile, error)
+	ReadFileAtRef(filePath, ref string) ([]byte, error)
+	ListComments(issueNum int) ([]*github_api.IssueComment, error)
+	AddComment(pullRequestNum int, msg string) error
+}
+
+// gitHubCodeReview implements codereview.Commenter.
+//
+// Issues are pull request numbers and the patchset refs are the SHAs of the
+// head commits of the pull requests.
+type gitHubCodeReview struct {
+	gh pullRequests
+}
+
+// ParseRepoURL returns the owner and name of a GitHub repo from the URL passed
+// to 'git clone', e.g. "https://github.com/google/skia-buildbot.git". The
+// returned bool is false if the URL is not a GitHub repo URL.
+func ParseRepoURL(repoURL string) (string, string, bool) {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Host != "github.com" {
+		return "", "", false
+	}
+	parts := strings.Split(strings.Trim(strings.TrimSuffix(u.Path, ".git"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// New returns a new instance of gitHubCodeReview for the GitHub repo at
+// repoURL.
+//
+// The GitHub token is read from the home directory if local is true.
+func New(ctx context.Context, local bool, repoURL string) (*gitHubCodeReview, error) {
+	owner, name, ok := ParseRepoURL(repoURL)
+	if !ok {
+		return nil, skerr.Fmt("Not a GitHub repo URL: %q", repoURL)
+	}
+	pathToGithubToken := filepath.Join(github.GITHUB_TOKEN_SERVER_PATH, github.GITHUB_TOKEN_FILENAME)
+	if local {
+		usr, err := user.Current()
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		pathToGithubToken = filepath.Join(usr.HomeDir, github.GITHUB_TOKEN_FILENAME)
+	}
+	b, err := os.ReadFile(pathToGithubToken)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not find GitHub token in %s", pathToGithubToken)
+	}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: strings.TrimSpace(string(b))})
+	client := httputils.DefaultClientConfig().WithTokenSource(ts).With2xxOnly().Client()
+	gh, err := github.NewGitHub(ctx, owner, name, client)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return &gitHubCodeReview{
+		gh: gh,
+	}, nil
+}
+
+// pullRequestNum converts an issue into a pull request number.
+func pullRequestNum(issue codereview.Issue) (int, error) {
+	num, err := strconv.Atoi(string(issue))
+	if err != nil {
+		return 0, skerr.Wrapf(err, "Invalid pull request number: %q", issue)
+	}
+	return num, nil
+}
+
+// ListModifiedFiles implements CodeReview.
+//
+// GitHub lists the files modified by the whole pull request relative to its
+// base branch, i.e. across all of its commits, so the ref is not used. This
+// matches the latest patchset, which is what the ref refers to after a call to
+// GetPatchsetInfo.
+func (cr *gitHubCodeReview) ListModifiedFiles(ctx context.Context, issue codereview.Issue, ref string) ([]codereview.ListModifiedFilesResult, error) {
+	num, err := pullRequestNum(issue)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	files, err := cr.gh.ListPullRequestFiles(num)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed retrieving list of files for %d", num)
+	}
+	ret := []codereview.ListModifiedFilesResult{}
+	for _, f := range files {
+		ret = append(ret, codereview.ListModifiedFilesResult{
+			Filename: f.GetFilename(),
+			Deleted:  f.GetStatus() == fileStatusRemoved,
+		})
+		// A renamed file is also removed from its previous location.
+		if f.GetStatus() == fileStatusRenamed && f.GetPreviousFilename() != "" {
+			ret = append(ret, codereview.ListModifiedFilesResult{
+				Filename: f.GetPreviousFilename(),
+				Deleted:  true,
+			})
+		}
+	}
+	return ret, nil
+}
+
+// GetFile implements CodeReview.
+func (cr *gitHubCodeReview) GetFile(ctx context.Context, filename, ref string) ([]byte, error) {
+	return cr.gh.ReadFileAtRef(filename, ref)
+}
+
+// GetPatchsetInfo implements CodeReview.
+func (cr *gitHubCodeReview) GetPatchsetInfo(ctx context.Context, issue codereview.Issue) (string, bool, error) {
+	num, err := pullRequestNum(issue)
+	if err != nil {
+		return "", false, skerr.Wrap(err)
+	}
+	pr, err := cr.gh.GetPullRequest(num)
+	if err != nil {
+		return "", false, skerr.Wrap(err)
+	}
+	return pr.GetHead().GetSHA(), pr.GetState() == github.CLOSED_STATE, nil
+}
+
+// ListOpenIssues implements Commenter.
+func (cr *gitHubCodeReview) ListOpenIssues(ctx context.Context) ([]codereview.Issue, error) {
+	prs, err := cr.gh.ListOpenPullRequests()
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	ret := make([]codereview.Issue, 0, len(prs))
+	for _, pr := range prs {
+		ret = append(ret, codereview.Issue(strconv.Itoa(pr.GetNumber())))
+	}
+	return ret, nil
+}
+
+// ListComments implements Commenter.
+func (cr *gitHubCodeReview) ListComments(ctx context.Context, issue codereview.Issue) ([]string, error) {
+	num, err := pullRequestNum(issue)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	comments, err := cr.gh.ListComments(num)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	ret := make([]string, 0, len(comments))
+	for _, c := range comments {
+		ret = append(ret, c.GetBody())
+	}
+	return ret, nil
+}
+
+// AddComment implements Commenter.
+func (cr *gitHubCodeReview) AddComment(ctx context.Context, issue codereview.Issue, body string) error {
+	num, err := pullRequestNum(issue)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	return skerr.Wrap(cr.gh.AddComment(num, body))
+}
+
+// Assert that gitHubCodeReview implements the Commenter interface.
+var _ codereview.Commenter = (*gitHubCodeReview)(nil)
@@ -0,0 +1,152 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	github_api "github.com/google/go-github/v29/github"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/docsyserver/go/codereview"
+)
+
+var myFakeError = fmt.Errorf("My fake error")
+
+const issue codereview.Issue = "123"
+
+// fakePullRequests implements pullRequests for pull request 123.
+type fakePullRequests struct {
+	pr       *github_api.PullRequest
+	files    []*github_api.CommitFile
+	comments []string
+	err      error
+}
+
+func (f *fakePullRequests) GetPullRequest(pullRequestNum int) (*github_api.PullRequest, error) {
+	return f.pr, f.err
+}
+
+func (f *fakePullRequests) ListOpenPullRequests() ([]*github_api.PullRequest, error) {
+	return []*github_api.PullRequest{f.pr}, f.err
+}
+
+func (f *fakePullRequests) ListPullRequestFiles(pullRequestNum int) ([]*github_api.CommitFile, error) {
+	return f.files, f.err
+}
+
+func (f *fakePullRequests) ReadFileAtRef(filePath, ref string) ([]byte, error) {
+	return []byte(filePath + "@" + ref), f.err
+}
+
+func (f *fakePullRequests) ListComments(issueNum int) ([]*github_api.IssueComment, error) {
+	ret := []*github_api.IssueComment{}
+	for i := range f.comments {
+		ret = append(ret, &github_api.IssueComment{Body: &f.comments[i]})
+	}
+	return ret, f.err
+}
+
+func (f *fakePullRequests) AddComment(pullRequestNum int, msg string) error {
+	f.comments = append(f.comments, msg)
+	return f.err
+}
+
+func newPullRequest(state string) *github_api.PullRequest {
+	num := 123
+	sha := "abc123"
+	return &github_api.PullRequest{
+		Number: &num,
+		State:  &state,
+		Head:   &github_api.PullRequestBranch{SHA: &sha},
+	}
+}
+
+func TestParseRepoURL_GitHubURLs_ReturnsOwnerAndName(t *testing.T) {
+	for _, repoURL := range []string{
+		"https://github.com/google/skia-buildbot",
+		"https://github.com/google/skia-buildbot.git",
+		"https://github.com/google/skia-buildbot/",
+	} {
+		owner, name, ok := ParseRepoURL(repoURL)
+		require.True(t, ok, repoURL)
+		require.Equal(t, "google", owner)
+		require.Equal(t, "skia-buildbot", name)
+	}
+}
+
+func TestParseRepoURL_NotGitHubURLs_ReturnsFalse(t *testing.T) {
+	for _, repoURL := range []string{
+		"https://skia.googlesource.com/skia",
+		"https://github.com/google",
+		"https://github.com/google/skia/tree/main",
+		"/tmp/some/checkout",
+	} {
+		_, _, ok := ParseRepoURL(repoURL)
+		require.False(t, ok, repoURL)
+	}
+}
+
+func TestGetPatchsetInfo_OpenPullRequest_ReturnsHeadSHA(t *testing.T) {
+	cr := gitHubCodeReview{gh: &fakePullRequests{pr: newPullRequest("open")}}
+	ref, isClosed, err := cr.GetPatchsetInfo(context.Background(), issue)
+	require.NoError(t, err)
+	require.False(t, isClosed)
+	require.Equal(t, "abc123", ref)
+}
+
+func TestGetPatchsetInfo_ClosedPullRequest_ReturnsClosed(t *testing.T) {
+	cr := gitHubCodeReview{gh: &fakePullRequests{pr: newPullRequest("closed")}}
+	_, isClosed, err := cr.GetPatchsetInfo(context.Background(), issue)
+	require.NoError(t, err)
+	require.True(t, isClosed)
+}
+
+func TestGetPatchsetInfo_InvalidIssue_ReturnsError(t *testing.T) {
+	cr := gitHubCodeReview{gh: &fakePullRequests{}}
+	_, _, err := cr.GetPatchsetInfo(context.Background(), codereview.MainIssue)
+	require.Contains(t, err.Error(), "Invalid pull request number")
+}
+
+func TestListModifiedFiles_RemovedAndRenamedFiles_ReportedAsDeleted(t *testing.T) {
+	cr := gitHubCodeReview{gh: &fakePullRequests{files: []*github_api.CommitFile{
+		{Filename: github_api.String("site/modified.md"), Status: github_api.String("modified")},
+		{Filename: github_api.String("site/removed.md"), Status: github_api.String("removed")},
+		{Filename: github_api.String("site/new.md"), Status: github_api.String("renamed"), PreviousFilename: github_api.String("site/old.md")},
+	}}}
+	files, err := cr.ListModifiedFiles(context.Background(), issue, "abc123")
+	require.NoError(t, err)
+	require.Equal(t, []codereview.ListModifiedFilesResult{
+		{Filename: "site/modified.md", Deleted: false},
+		{Filename: "site/removed.md", Deleted: true},
+		{Filename: "site/new.md", Deleted: false},
+		{Filename: "site/old.md", Deleted: true},
+	}, files)
+}
+
+func TestListModifiedFiles_ListFails_ReturnsError(t *testing.T) {
+	cr := gitHubCodeReview{gh: &fakePullRequests{err: myFakeError}}
+	_, err := cr.ListModifiedFiles(context.Background(), issue, "abc123")
+	require.Contains(t, err.Error(), myFakeError.Error())
+}
+
+func TestGetFile_ReadsFileAtRef(t *testing.T) {
+	cr := gitHubCodeReview{gh: &fakePullRequests{}}
+	b, err := cr.GetFile(context.Background(), "site/index.md", "abc123")
+	require.NoError(t, err)
+	require.Equal(t, "site/index.md@abc123", string(b))
+}
+
+func TestListOpenIssues_ReturnsPullRequestNumbers(t *testing.T) {
+	cr := gitHubCodeReview{gh: &fakePullRequests{pr: newPullRequest("open")}}
+	issues, err := cr.ListOpenIssues(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []codereview.Issue{issue}, issues)
+}
+
+func TestAddComment_CommentIsListed(t *testing.T) {
+	cr := gitHubCodeReview{gh: &fakePullRequests{comments: []string{"LGTM"}}}
+	require.NoError(t, cr.AddComment(context.Background(), issue, "Preview"))
+	comments, err := cr.ListComments(context.Background(), issue)
+	require.NoError(t, err)
+	require.Equal(t, []string{"LGTM", "Preview"}, comments)
+}
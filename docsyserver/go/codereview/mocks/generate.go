@@ -1,3 +1,4 @@
 package mocks
 
 //go:generate bazelisk run --config=mayberemote //:mockery -- --name CodeReview  --srcpkg=go.skia.org/infra/docsyserver/go/codereview --output ${PWD}
+//go:generate bazelisk run --config=mayberemote //:mockery -- --name Commenter  --srcpkg=go.skia.org/infra/docsyserver/go/codereview --output ${PWD}
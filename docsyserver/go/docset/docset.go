@@ -1,5 +1,6 @@
-// Package docset keeps track of checkouts of a repository of Markdown documents
-// and their rendered counterparts.
+// Package docset keeps track of checkouts of a repository of Markdown documents,
+// optionally aggregated with the documents of satellite repositories, and their
+// rendered counterparts.
 package docset
 
 import (
@@ -8,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -56,8 +58,73 @@ const (
 	// See the description of docSet for how these are used.
 	contentSubDirectory     = "content"
 	destinationSubDirectory = "destination"
+	satellitesSubDirectory  = "satellites"
+
+	// satelliteIssueSeparator separates the satellite name from the issue in
+	// the satellite's code review system, e.g. "infra:123".
+	satelliteIssueSeparator = ":"
 )
 
+// Satellite is a repository whose documentation is mounted into the
+// documentation of the main repository, so that both are served as a single
+// site with a single navigation.
+type Satellite struct {
+	// Name of the satellite, which prefixes the issues of the satellite, see
+	// SatelliteIssue.
+	Name string
+
+	// The URL of the repo passed to 'git clone'.
+	RepoURL string
+
+	// The relative path in the git repo where the docs are stored, e.g. "site".
+	DocPath string
+
+	// The relative path in the rendered site where the docs are mounted, e.g.
+	// "docs/infra". It must not exist in the main repository.
+	MountPath string
+
+	// CodeReview allows querying info from the satellite's code review system.
+	// Issues of the satellite can't be previewed if nil.
+	CodeReview codereview.CodeReview
+}
+
+// ParseSatellite parses a Satellite from a string of the form:
+//
+//	name,repoURL,docPath,mountPath
+//
+// The returned Satellite has no CodeReview.
+func ParseSatellite(s string) (Satellite, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return Satellite{}, skerr.Fmt("Satellite must be of the form name,repoURL,docPath,mountPath: %q", s)
+	}
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+		if parts[i] == "" {
+			return Satellite{}, skerr.Fmt("Satellite has an empty field: %q", s)
+		}
+	}
+	ret := Satellite{
+		Name:      parts[0],
+		RepoURL:   parts[1],
+		DocPath:   parts[2],
+		MountPath: path.Clean(parts[3]),
+	}
+	if strings.ContainsAny(ret.Name, satelliteIssueSeparator+"/") {
+		return Satellite{}, skerr.Fmt("Satellite name must not contain %q or '/': %q", satelliteIssueSeparator, ret.Name)
+	}
+	if path.IsAbs(ret.MountPath) || ret.MountPath == "." || strings.HasPrefix(ret.MountPath, "..") {
+		return Satellite{}, skerr.Fmt("Satellite mount path must be a relative sub-directory: %q", parts[3])
+	}
+	return ret, nil
+}
+
+// SatelliteIssue returns the issue used by the DocSet for the given issue in
+// the code review system of the named satellite.
+func SatelliteIssue(name string, issue codereview.Issue) codereview.Issue {
+	return codereview.Issue(name + satelliteIssueSeparator + string(issue))
+}
+
 // entry in the docSet FileSystem cache.
 type entry struct {
 	mutex sync.Mutex
@@ -83,6 +150,9 @@ type entry struct {
 //	  /content/{issue}/ - A patched checkout of the documentation in the
 //	    docPath of the respository, with /main/ representing the main branch at HEAD.
 //	  /destination/{issue}/ - The docsy rendered version of /content/{issue}.
+//	  /satellites/{name}/ - A checkout of each satellite repository at HEAD,
+//	    whose docPath is linked into /content/main/ at the satellite's
+//	    MountPath.
 //
 // The background processes will monitor all current issues and update them to
 // more recent patchsets periodically and also remove both /content/{issue}/ and
@@ -111,6 +181,10 @@ type docSet struct {
 	// codeReview allows querying info from the code review system.
 	codeReview codereview.CodeReview
 
+	// satellites are the repositories whose documentation is mounted into the
+	// documentation of the main repository.
+	satellites []Satellite
+
 	// cache of rendered sets of documentation including the main set of docs
 	// from HEAD at [InvalidIssue].
 	cache map[codereview.Issue]*entry
@@ -133,6 +207,13 @@ type docSet struct {
 //
 // repoURL is the URL of the repo passed to 'git clone'.
 func New(workDir string, docPath string, docsyDir string, repoURL string, codeReview codereview.CodeReview, docsy docsy.Docsy) *docSet {
+	return NewWithSatellites(workDir, docPath, docsyDir, repoURL, codeReview, docsy, nil)
+}
+
+// NewWithSatellites returns a new *docSet instance that also mounts the
+// documentation of the satellites into the documentation of the main
+// repository. See New for the other arguments.
+func NewWithSatellites(workDir string, docPath string, docsyDir string, repoURL string, codeReview codereview.CodeReview, docsy docsy.Docsy, satellites []Satellite) *docSet {
 	ret := &docSet{
 		codeReview: codeReview,
 		docPath:    docPath,
@@ -140,6 +221,7 @@ func New(workDir string, docPath string, docsyDir string, repoURL string, codeRe
 		workDir:    workDir,
 		repoURL:    repoURL,
 		docsy:      docsy,
+		satellites: satellites,
 		cache:      map[codereview.Issue]*entry{},
 		cacheSize:  metrics2.GetInt64Metric("docsy_docset_cache_size"),
 		liveness:   metrics2.NewLiveness("docsy_docset_refresh"),
@@ -162,8 +244,10 @@ func (d *docSet) FileSystem(ctx context.Context, issue codereview.Issue) (http.F
 
 		// Update patchsetRef and isClosed if we aren't on MainIsse.
 		if issue != codereview.MainIssue {
-			var err error
-			patchsetRef, isClosed, err = d.codeReview.GetPatchsetInfo(ctx, issue)
+			cr, crIssue, _, err := d.codeReviewForIssue(issue)
+			if err == nil {
+				patchsetRef, isClosed, err = cr.GetPatchsetInfo(ctx, crIssue)
+			}
 			if err != nil {
 				if e == nil {
 					return nil, skerr.Wrap(err)
@@ -191,6 +275,26 @@ func (d *docSet) FileSystem(ctx context.Context, issue codereview.Issue) (http.F
 	return e.fs, nil
 }
 
+// codeReviewForIssue returns the code review system of the given issue, the
+// issue in that code review system, and the satellite the issue belongs to,
+// which is nil for issues of the main repository.
+func (d *docSet) codeReviewForIssue(issue codereview.Issue) (codereview.CodeReview, codereview.Issue, *Satellite, error) {
+	name, crIssue, found := strings.Cut(string(issue), satelliteIssueSeparator)
+	if !found {
+		return d.codeReview, issue, nil, nil
+	}
+	for i, satellite := range d.satellites {
+		if satellite.Name != name {
+			continue
+		}
+		if satellite.CodeReview == nil {
+			return nil, "", nil, skerr.Fmt("Satellite %q does not support previews.", name)
+		}
+		return satellite.CodeReview, codereview.Issue(crIssue), &d.satellites[i], nil
+	}
+	return nil, "", nil, skerr.Fmt("Unknown satellite %q for issue %q.", name, issue)
+}
+
 // refresh updates the files for the given issue at the given patchset.
 func (d *docSet) refresh(ctx context.Context, issue codereview.Issue, patchsetRef string) (http.FileSystem, error) {
 	sklog.Infof("Refreshing isue: %q patchset: %q", issue, patchsetRef)
@@ -201,9 +305,11 @@ func (d *docSet) refresh(ctx context.Context, issue codereview.Issue, patchsetRe
 		if _, err := gitinfo.CloneOrUpdate(ctx, d.repoURL, filepath.Join(d.workDir, contentSubDirectory, string(issue)), false); err != nil {
 			return nil, skerr.Wrap(err)
 		}
+		if err := d.mountSatellites(ctx); err != nil {
+			return nil, skerr.Wrap(err)
+		}
 	} else {
-		srcDirWithoutDocPath := filepath.Join(d.workDir, contentSubDirectory, string(issue))
-		if err := d.copyAndPatch(ctx, issue, patchsetRef, srcDirWithoutDocPath, d.docPath); err != nil {
+		if err := d.copyAndPatch(ctx, issue, patchsetRef); err != nil {
 			return nil, skerr.Wrap(err)
 		}
 	}
@@ -227,39 +333,78 @@ func (d *docSet) refresh(ctx context.Context, issue codereview.Issue, patchsetRe
 	return ret, nil
 }
 
+// mountSatellites updates the checkout of each satellite and links its
+// documentation into the documentation of the main branch.
+func (d *docSet) mountSatellites(ctx context.Context) error {
+	mainSrcDir := filepath.Join(d.workDir, contentSubDirectory, string(codereview.MainIssue), d.docPath)
+	for _, satellite := range d.satellites {
+		checkoutDir := filepath.Join(d.workDir, satellitesSubDirectory, satellite.Name)
+		if _, err := gitinfo.CloneOrUpdate(ctx, satellite.RepoURL, checkoutDir, false); err != nil {
+			return skerr.Wrapf(err, "Failed to update satellite %q", satellite.Name)
+		}
+		// Start from an empty mount so that files deleted in the satellite
+		// don't linger.
+		mountDir := filepath.Join(mainSrcDir, satellite.MountPath)
+		if err := os.RemoveAll(mountDir); err != nil {
+			return skerr.Wrapf(err, "Failed to clear mount of satellite %q", satellite.Name)
+		}
+		if err := copyFilesAsLinks(filepath.Join(checkoutDir, satellite.DocPath), mountDir); err != nil {
+			return skerr.Wrapf(err, "Failed to mount satellite %q", satellite.Name)
+		}
+	}
+	return nil
+}
+
 // copyAndPatch copies over the documentation source from the main branch into a
 // new directory as symlinks, and then overwrites any files changed in the issue
 // with updated values fetched from the code review system.
-func (d *docSet) copyAndPatch(ctx context.Context, issue codereview.Issue, patchsetRef, srcDirWithoutDocPath, docPath string) error {
+//
+// For an issue of a satellite only the files under the satellite's DocPath are
+// patched, into the satellite's MountPath.
+func (d *docSet) copyAndPatch(ctx context.Context, issue codereview.Issue, patchsetRef string) error {
+	cr, crIssue, satellite, err := d.codeReviewForIssue(issue)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+
 	// Copy files over from the main branch.
 	mainSrcDir := filepath.Join(d.workDir, contentSubDirectory, string(codereview.MainIssue), d.docPath)
-	srcDir := filepath.Join(srcDirWithoutDocPath, docPath)
+	srcDir := filepath.Join(d.workDir, contentSubDirectory, string(issue), d.docPath)
 	if err := copyFilesAsLinks(mainSrcDir, srcDir); err != nil {
 		return skerr.Wrap(err)
 	}
 
 	// Then download the patched files from the code review system.
-	files, err := d.codeReview.ListModifiedFiles(ctx, issue, patchsetRef)
+	docPath := d.docPath
+	patchDir := srcDir
+	if satellite != nil {
+		docPath = satellite.DocPath
+		patchDir = filepath.Join(srcDir, satellite.MountPath)
+	}
+	files, err := cr.ListModifiedFiles(ctx, crIssue, patchsetRef)
 	if err != nil {
 		return skerr.Wrap(err)
 	}
 	for _, fileinfo := range files {
-		if fileinfo.Deleted {
-			_ = os.Remove(filepath.Join(srcDirWithoutDocPath, fileinfo.Filename))
+		// Check if file is a subdir of docPath.
+		relPath, ok := pathInDocPath(fileinfo.Filename, docPath)
+		if !ok {
 			continue
 		}
-
-		// Check if file is a subdir of docPath.
-		if !strings.HasPrefix(fileinfo.Filename, docPath) {
+		dstFile := filepath.Join(patchDir, relPath)
+		if fileinfo.Deleted {
+			_ = os.Remove(dstFile)
 			continue
 		}
 
-		b, err := d.codeReview.GetFile(ctx, fileinfo.Filename, patchsetRef)
+		b, err := cr.GetFile(ctx, fileinfo.Filename, patchsetRef)
 		if err != nil {
 			return skerr.Wrap(err)
 		}
-		// srcDir contains the docPath, but fileinfo also contains the docPath.
-		err = util.WithWriteFile(filepath.Join(srcDirWithoutDocPath, fileinfo.Filename), func(w io.Writer) error {
+		if err := os.MkdirAll(filepath.Dir(dstFile), 0755); err != nil {
+			return skerr.Wrapf(err, "Failed to create directory for: %q", fileinfo.Filename)
+		}
+		err = util.WithWriteFile(dstFile, func(w io.Writer) error {
 			_, err := w.Write(b)
 			if err != nil {
 				return skerr.Wrapf(err, "Failed to write: %q", fileinfo.Filename)
@@ -273,6 +418,21 @@ func (d *docSet) copyAndPatch(ctx context.Context, issue codereview.Issue, patch
 	return nil
 }
 
+// pathInDocPath returns the path of filename, which is relative to the root of
+// a git repo, relative to docPath. The returned bool is false if filename is
+// not under docPath.
+func pathInDocPath(filename, docPath string) (string, bool) {
+	prefix := path.Clean(docPath)
+	if prefix == "." {
+		return filename, true
+	}
+	prefix += "/"
+	if !strings.HasPrefix(filename, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(filename, prefix), true
+}
+
 // copyFilesAsLinks creates a copy of the directory 'src' in 'dst' using
 // symlinks.
 func copyFilesAsLinks(src, dst string) error {
@@ -390,3 +390,146 @@ func TestRefresh_ListModifiedFilesFails_ReturnsError(t *testing.T) {
 	require.Contains(t, err.Error(), myFakeError.Error())
 	cr.AssertExpectations(t)
 }
+
+// Returns a context, the working directory, the full path to the source, the
+// full path to the destination, a mock for Docsy, a mock for the CoreReview of
+// the "infra" satellite, and a constructed docSet that has already loaded and
+// rendered the main repo with the "infra" satellite mounted at
+// "docs/infra".
+func setupForTestWithSatelliteLoaded(t *testing.T) (context.Context, string, string, string, *mocks.Docsy, *crmocks.CodeReview, *docSet) {
+	ctx := cipd_git.UseGitFinder(context.Background())
+	ctx = context.WithValue(ctx, now.ContextKey, mockTime)
+
+	gb := gittestutils.GitInit(t, ctx)
+	gb.Add(ctx, "site/_index.md", "This is an index file.")
+	gb.Commit(ctx)
+
+	satelliteGB := gittestutils.GitInit(t, ctx)
+	satelliteGB.Add(ctx, "docs/_index.md", "This is the infra index file.")
+	satelliteGB.Add(ctx, "docs/bots.md", "All about bots.")
+	satelliteGB.Add(ctx, "README.md", "Not documentation.")
+	satelliteGB.Commit(ctx)
+
+	workDir := t.TempDir()
+	src := filepath.Join(workDir, contentSubDirectory, string(codereview.MainIssue), docPath)
+	dst := filepath.Join(workDir, destinationSubDirectory, string(codereview.MainIssue), docPath)
+	docsy := &mocks.Docsy{}
+	cr := &crmocks.CodeReview{}
+	satelliteCR := &crmocks.CodeReview{}
+	docset := NewWithSatellites(workDir, docPath, docsyDir, gb.Dir(), cr, docsy, []Satellite{
+		{
+			Name:       "infra",
+			RepoURL:    satelliteGB.Dir(),
+			DocPath:    "docs",
+			MountPath:  "docs/infra",
+			CodeReview: satelliteCR,
+		},
+	})
+
+	docsy.On("Render", testutils.AnyContext, src, dst).Return(nil)
+	require.NoError(t, docset.singleStep(ctx))
+	docsy.AssertExpectations(t)
+
+	return ctx, workDir, src, dst, docsy, satelliteCR, docset
+}
+
+func TestSingleStep_WithSatellite_SatelliteDocsAreMounted(t *testing.T) {
+	_, _, src, _, _, _, _ := setupForTestWithSatelliteLoaded(t)
+
+	require.FileExists(t, filepath.Join(src, "_index.md"))
+	require.FileExists(t, filepath.Join(src, "docs", "infra", "_index.md"))
+	require.FileExists(t, filepath.Join(src, "docs", "infra", "bots.md"))
+	require.NoFileExists(t, filepath.Join(src, "docs", "infra", "README.md"))
+}
+
+func TestSingleStep_SatelliteFileWasRemovedFromMount_FileIsMountedAgain(t *testing.T) {
+	ctx, _, src, _, _, _, docset := setupForTestWithSatelliteLoaded(t)
+
+	require.NoError(t, os.Remove(filepath.Join(src, "docs", "infra", "bots.md")))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "docs", "infra", "stale.md"), []byte("stale"), 0644))
+
+	require.NoError(t, docset.singleStep(ctx))
+	require.FileExists(t, filepath.Join(src, "docs", "infra", "bots.md"))
+	require.NoFileExists(t, filepath.Join(src, "docs", "infra", "stale.md"))
+}
+
+func TestFileSystem_SatelliteIssue_PatchesFilesIntoMountPath(t *testing.T) {
+	ctx, workDir, _, _, docsy, satelliteCR, docset := setupForTestWithSatelliteLoaded(t)
+
+	const satelliteIssue = codereview.Issue("45")
+	satelliteCR.On("GetPatchsetInfo", testutils.AnyContext, satelliteIssue).Return("abc123", false, nil)
+	satelliteCR.On("ListModifiedFiles", testutils.AnyContext, satelliteIssue, "abc123").Return([]codereview.ListModifiedFilesResult{
+		{Filename: "docs/new/page.md"},
+		{Filename: "docs/bots.md", Deleted: true},
+		{Filename: "site/not-the-satellite-doc-path.md"},
+	}, nil)
+	satelliteCR.On("GetFile", testutils.AnyContext, "docs/new/page.md", "abc123").Return([]byte("A new page."), nil)
+
+	issue := SatelliteIssue("infra", satelliteIssue)
+	src := filepath.Join(workDir, contentSubDirectory, string(issue), docPath)
+	dst := filepath.Join(workDir, destinationSubDirectory, string(issue), docPath)
+	docsy.On("Render", testutils.AnyContext, src, dst).Return(nil)
+
+	_, err := docset.FileSystem(ctx, issue)
+	require.NoError(t, err)
+	b, err := os.ReadFile(filepath.Join(src, "docs", "infra", "new", "page.md"))
+	require.NoError(t, err)
+	require.Equal(t, "A new page.", string(b))
+	require.NoFileExists(t, filepath.Join(src, "docs", "infra", "bots.md"))
+	require.FileExists(t, filepath.Join(src, "docs", "infra", "_index.md"))
+	require.NoFileExists(t, filepath.Join(src, "not-the-satellite-doc-path.md"))
+	// The main branch is untouched.
+	require.FileExists(t, filepath.Join(workDir, contentSubDirectory, string(codereview.MainIssue), docPath, "docs", "infra", "bots.md"))
+	satelliteCR.AssertExpectations(t)
+	docsy.AssertExpectations(t)
+}
+
+func TestFileSystem_UnknownSatelliteIssue_ReturnsError(t *testing.T) {
+	ctx, _, _, _, _, _, docset := setupForTestWithSatelliteLoaded(t)
+
+	_, err := docset.FileSystem(ctx, SatelliteIssue("unknown", "45"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Unknown satellite "unknown"`)
+}
+
+func TestParseSatellite_ValidSatellite_Success(t *testing.T) {
+	satellite, err := ParseSatellite("infra, https://github.com/google/skia-buildbot ,site,docs/infra/")
+	require.NoError(t, err)
+	require.Equal(t, Satellite{
+		Name:      "infra",
+		RepoURL:   "https://github.com/google/skia-buildbot",
+		DocPath:   "site",
+		MountPath: "docs/infra",
+	}, satellite)
+}
+
+func TestParseSatellite_InvalidSatellites_ReturnError(t *testing.T) {
+	for s, expectedError := range map[string]string{
+		"infra,https://github.com/google/skia-buildbot,site":            "must be of the form",
+		"infra,https://github.com/google/skia-buildbot,,docs/infra":     "empty field",
+		"in:fra,https://github.com/google/skia-buildbot,site,docs":      "name must not contain",
+		"infra,https://github.com/google/skia-buildbot,site,/docs":      "relative sub-directory",
+		"infra,https://github.com/google/skia-buildbot,site,../docs":    "relative sub-directory",
+		"infra,https://github.com/google/skia-buildbot,site,docs/../.":  "relative sub-directory",
+		"infra,https://github.com/google/skia-buildbot,site,docs,extra": "must be of the form",
+	} {
+		_, err := ParseSatellite(s)
+		require.Error(t, err, s)
+		require.Contains(t, err.Error(), expectedError, s)
+	}
+}
+
+func TestPathInDocPath(t *testing.T) {
+	test := func(name, filename, docPath, expected string, expectedOK bool) {
+		t.Run(name, func(t *testing.T) {
+			actual, ok := pathInDocPath(filename, docPath)
+			require.Equal(t, expectedOK, ok)
+			require.Equal(t, expected, actual)
+		})
+	}
+	test("InDocPath", "site/dir/index.md", "site", "dir/index.md", true)
+	test("InDocPathWithTrailingSlash", "site/index.md", "site/", "index.md", true)
+	test("DocPathIsAPrefixOfTheDirectory", "sitemap/index.md", "site", "", false)
+	test("OutsideDocPath", "README.md", "site", "", false)
+	test("DocPathIsTheRoot", "README.md", ".", "README.md", true)
+}
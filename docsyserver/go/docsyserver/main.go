@@ -5,13 +5,16 @@ import (
 	"flag"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/fiorix/go-web/autogzip"
 	"github.com/go-chi/chi/v5"
 	"go.skia.org/infra/docsyserver/go/codereview"
 	"go.skia.org/infra/docsyserver/go/codereview/gerrit"
+	"go.skia.org/infra/docsyserver/go/codereview/github"
 	"go.skia.org/infra/docsyserver/go/docset"
 	"go.skia.org/infra/docsyserver/go/docsy"
+	"go.skia.org/infra/docsyserver/go/previews"
 	"go.skia.org/infra/go/common"
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/skerr"
@@ -20,33 +23,98 @@ import (
 
 // flags
 var (
-	docPath   = flag.String("doc_path", "site", "The relative directory, from the top of the repo, where the documents are located.")
-	docRepo   = flag.String("doc_repo", "https://skia.googlesource.com/skia", "The repo to check out.")
-	docsyDir  = flag.String("docsy_dir", "../../docsy-example", "The directory where docsy is found.")
-	gerritURL = flag.String("gerrit_url", "https://skia-review.googlesource.com", "The gerrit URL.")
-	hugoExe   = flag.String("hugo", "hugo", "The absolute path to the hugo executable.")
-	local     = flag.Bool("local", false, "Running locally if true. As opposed to in production.")
-	port      = flag.String("port", ":8000", "HTTP service address (e.g., ':8000')")
-	promPort  = flag.String("prom_port", ":20000", "Metrics service address (e.g., ':10110')")
-	workDir   = flag.String("work_dir", "/tmp", "The directory to check out the doc repo into.")
+	docPath        = flag.String("doc_path", "site", "The relative directory, from the top of the repo, where the documents are located.")
+	docRepo        = flag.String("doc_repo", "https://skia.googlesource.com/skia", "The repo to check out. Either a Gerrit or a GitHub repo.")
+	docsyDir       = flag.String("docsy_dir", "../../docsy-example", "The directory where docsy is found.")
+	gerritURL      = flag.String("gerrit_url", "https://skia-review.googlesource.com", "The gerrit URL.")
+	hugoExe        = flag.String("hugo", "hugo", "The absolute path to the hugo executable.")
+	local          = flag.Bool("local", false, "Running locally if true. As opposed to in production.")
+	port           = flag.String("port", ":8000", "HTTP service address (e.g., ':8000')")
+	previewBaseURL = flag.String("preview_base_url", "", "The URL docsyserver is served at, e.g. 'https://skia.org'. If set, a comment with the preview URL is added to the GitHub pull requests that change the documentation.")
+	promPort       = flag.String("prom_port", ":20000", "Metrics service address (e.g., ':10110')")
+	workDir        = flag.String("work_dir", "/tmp", "The directory to check out the doc repo into.")
 )
 
+var satelliteFlags repeatedFlag
+
+func init() {
+	flag.Var(&satelliteFlags, "satellite", "A GitHub repo whose documentation is mounted into the documentation of --doc_repo, of the form 'name,repoURL,docPath,mountPath', e.g. 'infra,https://github.com/google/skia-buildbot,site,docs/infra'. May be repeated.")
+}
+
+// repeatedFlag is a flag.Value for a flag that may be repeated. Unlike
+// common.MultiStringFlagVar the values are not split on commas.
+type repeatedFlag []string
+
+// String implements flag.Value.
+func (r *repeatedFlag) String() string {
+	return strings.Join(*r, " ")
+}
+
+// Set implements flag.Value.
+func (r *repeatedFlag) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
+
 type server struct {
 	docset docset.DocSet
 }
 
 func new() (*server, error) {
-	codeReview, err := gerrit.New(*local, *gerritURL, *docRepo)
-	if err != nil {
-		return nil, skerr.Wrap(err)
+	ctx := context.Background()
+
+	// The sources of the issues that get a comment with the preview URL.
+	var sources []previews.Source
+
+	var codeReview codereview.CodeReview
+	if _, _, ok := github.ParseRepoURL(*docRepo); ok {
+		gitHubCodeReview, err := github.New(ctx, *local, *docRepo)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		codeReview = gitHubCodeReview
+		sources = append(sources, previews.Source{
+			CodeReview: gitHubCodeReview,
+			DocPath:    *docPath,
+		})
+	} else {
+		gerritCodeReview, err := gerrit.New(*local, *gerritURL, *docRepo)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		codeReview = gerritCodeReview
+	}
+
+	satellites := make([]docset.Satellite, 0, len(satelliteFlags))
+	for _, s := range satelliteFlags {
+		satellite, err := docset.ParseSatellite(s)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		gitHubCodeReview, err := github.New(ctx, *local, satellite.RepoURL)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Satellites must be GitHub repos")
+		}
+		satellite.CodeReview = gitHubCodeReview
+		satellites = append(satellites, satellite)
+		sources = append(sources, previews.Source{
+			Name:       satellite.Name,
+			CodeReview: gitHubCodeReview,
+			DocPath:    satellite.DocPath,
+		})
 	}
+
 	docsy := docsy.New(*hugoExe, *docsyDir, *docPath)
 
-	docset := docset.New(*workDir, *docPath, *docsyDir, *docRepo, codeReview, docsy)
-	if err := docset.Start(context.Background()); err != nil {
+	docset := docset.NewWithSatellites(*workDir, *docPath, *docsyDir, *docRepo, codeReview, docsy, satellites)
+	if err := docset.Start(ctx); err != nil {
 		return nil, skerr.Wrap(err)
 	}
 
+	if !*local && *previewBaseURL != "" && len(sources) > 0 {
+		previews.New(docset, *previewBaseURL, sources).Start(ctx)
+	}
+
 	return &server{
 		docset: docset,
 	}, nil
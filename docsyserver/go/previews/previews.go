@@ -0,0 +1,202 @@
+// Package previews announces the preview URL of documentation changes on the
+// code review issues that make them.
+package previews
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"go.skia.org/infra/docsyserver/go/codereview"
+	"go.skia.org/infra/docsyserver/go/docset"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// refreshDuration is how often the open issues are checked for documentation
+// changes.
+const refreshDuration = 2 * time.Minute
+
+// Source is a repository whose open issues are checked for documentation
+// changes.
+type Source struct {
+	// Name of the satellite, see docset.Satellite, or the empty string for the
+	// main repository.
+	Name string
+
+	// CodeReview of the repository.
+	CodeReview codereview.Commenter
+
+	// DocPath is the directory, relative to the root of the repository, where
+	// the documents are located.
+	DocPath string
+}
+
+// Previews renders the documentation of every open issue that changes it and
+// adds a comment with the preview URL to the issue.
+type Previews struct {
+	docset  docset.DocSet
+	baseURL string
+	sources []Source
+
+	// checked maps the docset issues to the ref of the last patchset that was
+	// checked for documentation changes, so that each patchset is only checked
+	// once.
+	checked map[codereview.Issue]string
+
+	// announced are the docset issues that have a comment with the preview
+	// URL.
+	announced map[codereview.Issue]bool
+
+	// Liveness for the Go routine started by Start.
+	liveness metrics2.Liveness
+}
+
+// New returns a new *Previews instance. The preview URLs are relative to
+// baseURL, e.g. "https://skia.org".
+func New(ds docset.DocSet, baseURL string, sources []Source) *Previews {
+	return &Previews{
+		docset:    ds,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		sources:   sources,
+		checked:   map[codereview.Issue]string{},
+		announced: map[codereview.Issue]bool{},
+		liveness:  metrics2.NewLiveness("docsyserver_previews"),
+	}
+}
+
+// URL returns the preview URL of the given docset issue.
+func (p *Previews) URL(issue codereview.Issue) string {
+	return p.baseURL + "/?cl=" + url.QueryEscape(string(issue))
+}
+
+// changesDocs returns true if any of the files is under docPath.
+func changesDocs(files []codereview.ListModifiedFilesResult, docPath string) bool {
+	docPath = path.Clean(docPath)
+	for _, f := range files {
+		if docPath == "." || strings.HasPrefix(f.Filename, docPath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// announce renders the preview of the issue and adds a comment with the
+// preview URL to the issue, unless it already has one.
+func (p *Previews) announce(ctx context.Context, source Source, issue, docsetIssue codereview.Issue) error {
+	if _, err := p.docset.FileSystem(ctx, docsetIssue); err != nil {
+		return skerr.Wrapf(err, "Failed to render preview of %q", docsetIssue)
+	}
+	previewURL := p.URL(docsetIssue)
+	comments, err := source.CodeReview.ListComments(ctx, issue)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	for _, comment := range comments {
+		// The comment was added before a restart.
+		if strings.Contains(comment, previewURL) {
+			return nil
+		}
+	}
+	msg := fmt.Sprintf("The documentation changes of this issue can be previewed at %s", previewURL)
+	if err := source.CodeReview.AddComment(ctx, issue, msg); err != nil {
+		return skerr.Wrapf(err, "Failed to comment on %q", docsetIssue)
+	}
+	sklog.Infof("Announced preview of %q at %s", docsetIssue, previewURL)
+	return nil
+}
+
+// singleStepSource checks the open issues of a single source and returns the
+// docset issues of all of them.
+func (p *Previews) singleStepSource(ctx context.Context, source Source) ([]codereview.Issue, error) {
+	issues, err := source.CodeReview.ListOpenIssues(ctx)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	ret := make([]codereview.Issue, 0, len(issues))
+	for _, issue := range issues {
+		docsetIssue := issue
+		if source.Name != "" {
+			docsetIssue = docset.SatelliteIssue(source.Name, issue)
+		}
+		ret = append(ret, docsetIssue)
+		if p.announced[docsetIssue] {
+			continue
+		}
+		ref, closed, err := source.CodeReview.GetPatchsetInfo(ctx, issue)
+		if err != nil {
+			sklog.Errorf("Failed to get patchset info of %q: %s", docsetIssue, err)
+			continue
+		}
+		if closed || p.checked[docsetIssue] == ref {
+			continue
+		}
+		files, err := source.CodeReview.ListModifiedFiles(ctx, issue, ref)
+		if err != nil {
+			sklog.Errorf("Failed to list modified files of %q: %s", docsetIssue, err)
+			continue
+		}
+		if changesDocs(files, source.DocPath) {
+			if err := p.announce(ctx, source, issue, docsetIssue); err != nil {
+				// Leave the issue unchecked so that it is retried on the next
+				// step.
+				sklog.Errorf("Failed to announce preview: %s", err)
+				continue
+			}
+			p.announced[docsetIssue] = true
+		}
+		p.checked[docsetIssue] = ref
+	}
+	return ret, nil
+}
+
+// singleStep checks the open issues of all the sources, and forgets about
+// the issues that have been closed.
+func (p *Previews) singleStep(ctx context.Context) error {
+	open := map[codereview.Issue]bool{}
+	for _, source := range p.sources {
+		issues, err := p.singleStepSource(ctx, source)
+		if err != nil {
+			return skerr.Wrapf(err, "Failed to list open issues of %q", source.Name)
+		}
+		for _, issue := range issues {
+			open[issue] = true
+		}
+	}
+	for issue := range p.checked {
+		if !open[issue] {
+			delete(p.checked, issue)
+		}
+	}
+	for issue := range p.announced {
+		if !open[issue] {
+			delete(p.announced, issue)
+		}
+	}
+	p.liveness.Reset()
+	return nil
+}
+
+// Start the long running process that announces previews.
+func (p *Previews) Start(ctx context.Context) {
+	ticker := time.NewTicker(refreshDuration)
+	done := ctx.Done()
+	go func() {
+		for {
+			if err := p.singleStep(ctx); err != nil {
+				sklog.Errorf("Failed single step in previews background process: %s", err)
+			}
+			select {
+			case <-done:
+				sklog.Warning("Context cancelled")
+				ticker.Stop()
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,156 @@
+package previews
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/docsyserver/go/codereview"
+	crmocks "go.skia.org/infra/docsyserver/go/codereview/mocks"
+	"go.skia.org/infra/go/testutils"
+)
+
+const (
+	baseURL = "https://skia.org/"
+	issue   = codereview.Issue("12")
+	ref     = "abc123"
+)
+
+var errMyMockError = errors.New("my mock error")
+
+// fakeDocSet implements docset.DocSet and records the rendered issues.
+type fakeDocSet struct {
+	rendered []codereview.Issue
+	err      error
+}
+
+func (f *fakeDocSet) FileSystem(ctx context.Context, issue codereview.Issue) (http.FileSystem, error) {
+	f.rendered = append(f.rendered, issue)
+	return nil, f.err
+}
+
+func (f *fakeDocSet) Start(ctx context.Context) error {
+	return nil
+}
+
+func setupForTest(t *testing.T, name string) (*fakeDocSet, *crmocks.Commenter, *Previews) {
+	ds := &fakeDocSet{}
+	cr := crmocks.NewCommenter(t)
+	return ds, cr, New(ds, baseURL, []Source{
+		{
+			Name:       name,
+			CodeReview: cr,
+			DocPath:    "site",
+		},
+	})
+}
+
+func TestURL_SatelliteIssue_IssueIsEscaped(t *testing.T) {
+	_, _, p := setupForTest(t, "")
+	require.Equal(t, "https://skia.org/?cl=12", p.URL(issue))
+	require.Equal(t, "https://skia.org/?cl=infra%3A12", p.URL("infra:12"))
+}
+
+func TestSingleStep_IssueChangesDocs_PreviewIsRenderedAndAnnounced(t *testing.T) {
+	ds, cr, p := setupForTest(t, "")
+	cr.On("ListOpenIssues", testutils.AnyContext).Return([]codereview.Issue{issue}, nil)
+	cr.On("GetPatchsetInfo", testutils.AnyContext, issue).Return(ref, false, nil).Once()
+	cr.On("ListModifiedFiles", testutils.AnyContext, issue, ref).Return([]codereview.ListModifiedFilesResult{
+		{Filename: "README.md"},
+		{Filename: "site/index.md"},
+	}, nil).Once()
+	cr.On("ListComments", testutils.AnyContext, issue).Return([]string{"LGTM"}, nil).Once()
+	cr.On("AddComment", testutils.AnyContext, issue, "The documentation changes of this issue can be previewed at https://skia.org/?cl=12").Return(nil).Once()
+
+	require.NoError(t, p.singleStep(context.Background()))
+	require.Equal(t, []codereview.Issue{issue}, ds.rendered)
+
+	// The issue is only announced once.
+	require.NoError(t, p.singleStep(context.Background()))
+	require.Len(t, ds.rendered, 1)
+}
+
+func TestSingleStep_SatelliteIssueChangesDocs_SatelliteIssueIsRenderedAndAnnounced(t *testing.T) {
+	ds, cr, p := setupForTest(t, "infra")
+	cr.On("ListOpenIssues", testutils.AnyContext).Return([]codereview.Issue{issue}, nil)
+	cr.On("GetPatchsetInfo", testutils.AnyContext, issue).Return(ref, false, nil)
+	cr.On("ListModifiedFiles", testutils.AnyContext, issue, ref).Return([]codereview.ListModifiedFilesResult{
+		{Filename: "site/index.md", Deleted: true},
+	}, nil)
+	cr.On("ListComments", testutils.AnyContext, issue).Return([]string{}, nil)
+	cr.On("AddComment", testutils.AnyContext, issue, "The documentation changes of this issue can be previewed at https://skia.org/?cl=infra%3A12").Return(nil)
+
+	require.NoError(t, p.singleStep(context.Background()))
+	require.Equal(t, []codereview.Issue{"infra:12"}, ds.rendered)
+}
+
+func TestSingleStep_IssueAlreadyHasPreviewComment_NoCommentIsAdded(t *testing.T) {
+	ds, cr, p := setupForTest(t, "")
+	cr.On("ListOpenIssues", testutils.AnyContext).Return([]codereview.Issue{issue}, nil)
+	cr.On("GetPatchsetInfo", testutils.AnyContext, issue).Return(ref, false, nil)
+	cr.On("ListModifiedFiles", testutils.AnyContext, issue, ref).Return([]codereview.ListModifiedFilesResult{
+		{Filename: "site/index.md"},
+	}, nil)
+	cr.On("ListComments", testutils.AnyContext, issue).Return([]string{"Preview at https://skia.org/?cl=12"}, nil)
+
+	require.NoError(t, p.singleStep(context.Background()))
+	require.Len(t, ds.rendered, 1)
+	require.True(t, p.announced[issue])
+}
+
+func TestSingleStep_IssueDoesNotChangeDocs_PatchsetIsOnlyCheckedOnce(t *testing.T) {
+	ds, cr, p := setupForTest(t, "")
+	cr.On("ListOpenIssues", testutils.AnyContext).Return([]codereview.Issue{issue}, nil)
+	cr.On("GetPatchsetInfo", testutils.AnyContext, issue).Return(ref, false, nil).Twice()
+	cr.On("ListModifiedFiles", testutils.AnyContext, issue, ref).Return([]codereview.ListModifiedFilesResult{
+		{Filename: "sitemap/index.md"},
+	}, nil).Once()
+
+	require.NoError(t, p.singleStep(context.Background()))
+	require.NoError(t, p.singleStep(context.Background()))
+	require.Empty(t, ds.rendered)
+}
+
+func TestSingleStep_IssueIsClosed_IsIgnored(t *testing.T) {
+	ds, cr, p := setupForTest(t, "")
+	cr.On("ListOpenIssues", testutils.AnyContext).Return([]codereview.Issue{issue}, nil)
+	cr.On("GetPatchsetInfo", testutils.AnyContext, issue).Return(ref, true, nil)
+
+	require.NoError(t, p.singleStep(context.Background()))
+	require.Empty(t, ds.rendered)
+}
+
+func TestSingleStep_RenderFails_IsRetriedOnNextStep(t *testing.T) {
+	ds, cr, p := setupForTest(t, "")
+	ds.err = errMyMockError
+	cr.On("ListOpenIssues", testutils.AnyContext).Return([]codereview.Issue{issue}, nil)
+	cr.On("GetPatchsetInfo", testutils.AnyContext, issue).Return(ref, false, nil)
+	cr.On("ListModifiedFiles", testutils.AnyContext, issue, ref).Return([]codereview.ListModifiedFilesResult{
+		{Filename: "site/index.md"},
+	}, nil)
+
+	require.NoError(t, p.singleStep(context.Background()))
+	require.NoError(t, p.singleStep(context.Background()))
+	require.Len(t, ds.rendered, 2)
+	require.False(t, p.announced[issue])
+}
+
+func TestSingleStep_IssueIsNoLongerOpen_IssueIsForgotten(t *testing.T) {
+	_, cr, p := setupForTest(t, "")
+	p.announced[issue] = true
+	p.checked[issue] = ref
+	cr.On("ListOpenIssues", testutils.AnyContext).Return([]codereview.Issue{}, nil)
+
+	require.NoError(t, p.singleStep(context.Background()))
+	require.Empty(t, p.announced)
+	require.Empty(t, p.checked)
+}
+
+func TestSingleStep_ListOpenIssuesFails_ReturnsError(t *testing.T) {
+	_, cr, p := setupForTest(t, "")
+	cr.On("ListOpenIssues", testutils.AnyContext).Return(nil, errMyMockError)
+
+	require.Error(t, p.singleStep(context.Background()))
+}
@@ -20,6 +20,7 @@ import (
 
 	"contrib.go.opencensus.io/exporter/stackdriver"
 	"github.com/go-chi/chi/v5"
+	"github.com/pmezard/go-difflib/difflib"
 	"go.opencensus.io/trace"
 	"go.skia.org/infra/fiddlek/go/named"
 	"go.skia.org/infra/fiddlek/go/runner"
@@ -46,6 +47,8 @@ var (
 	port           = flag.String("port", ":8000", "HTTP service address (e.g., ':8000')")
 	scrapExchange  = flag.String("scrapexchange", "http://scrapexchange:9000", "Scrap exchange service HTTP address.")
 	sourceImageDir = flag.String("source_image_dir", "./source", "The directory to load the source images from.")
+	storeDir       = flag.String("store_dir", "", "If set, fiddles are stored in this local directory instead of Google Storage, and traces aren't exported to Stackdriver. Used for self-contained deployments.")
+	fiddlers       = common.NewMultiStringFlag("fiddler", nil, "The host:port address of a fiddler to run fiddles on, e.g. 'localhost:8001'. May be repeated. If set, fiddles are run on these fiddlers instead of fiddler pods discovered via Kubernetes.")
 )
 
 var (
@@ -127,6 +130,7 @@ func loadTemplates() {
 	templates = template.Must(template.New("").Delims("{%", "%}").Funcs(funcMap).ParseFiles(
 		filepath.Join(*distDir, "newindex.html"),
 		filepath.Join(*distDir, "named.html"),
+		filepath.Join(*distDir, "history.html"),
 	))
 }
 
@@ -187,6 +191,149 @@ func namedHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// namedJSONHandler returns all the named fiddles as JSON.
+func namedJSONHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	named, err := fiddleStore.ListAllNames()
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to retrieve list of named fiddles.", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(named); err != nil {
+		httputils.ReportError(w, err, "Failed to JSON Encode response.", http.StatusInternalServerError)
+	}
+}
+
+// historyRevision is a single revision of a named fiddle, as displayed in history.html.
+type historyRevision struct {
+	store.NamedRevision
+
+	// Previous is the fiddle hash of the revision before this one, or the empty string if this is
+	// the first revision.
+	Previous string
+}
+
+type historyContext struct {
+	Name      string
+	Revisions []historyRevision // Newest first.
+}
+
+// historyHandler displays every revision of a named fiddle, with links to the diffs between them.
+func historyHandler(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	history, err := fiddleStore.GetNameHistory(name)
+	if err != nil {
+		http.NotFound(w, r)
+		sklog.Errorf("Failed to load history of %q: %s", name, err)
+		return
+	}
+	if *local {
+		loadTemplates()
+	}
+	templateContext := historyContext{
+		Name: name,
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		rev := historyRevision{NamedRevision: history[i]}
+		if i > 0 {
+			rev.Previous = history[i-1].Hash
+		}
+		templateContext.Revisions = append(templateContext.Revisions, rev)
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if err := templates.ExecuteTemplate(w, "history.html", templateContext); err != nil {
+		sklog.Errorf("Failed to expand template: %s", err)
+	}
+}
+
+// historyJSONHandler returns every revision of a named fiddle as JSON, oldest first.
+func historyJSONHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	name := chi.URLParam(r, "name")
+	history, err := fiddleStore.GetNameHistory(name)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load named fiddle history.", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		httputils.ReportError(w, err, "Failed to JSON Encode response.", http.StatusInternalServerError)
+	}
+}
+
+// splitLines splits the given text into lines for difflib, each of which ends in a newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	lines := strings.SplitAfter(text, "\n")
+	return lines[:len(lines)-1]
+}
+
+// diffFiddles returns a unified diff of the code and options of two fiddles.
+func diffFiddles(fromHash, toHash string) (string, error) {
+	var files [2]struct {
+		code    string
+		options string
+	}
+	for i, fiddleHash := range []string{fromHash, toHash} {
+		code, options, err := fiddleStore.GetCode(fiddleHash)
+		if err != nil {
+			return "", skerr.Wrapf(err, "loading fiddle %q", fiddleHash)
+		}
+		b, err := json.MarshalIndent(options, "", "  ")
+		if err != nil {
+			return "", skerr.Wrapf(err, "encoding options of fiddle %q", fiddleHash)
+		}
+		files[i].code = code
+		files[i].options = string(b)
+	}
+	var ret strings.Builder
+	for _, diff := range []difflib.UnifiedDiff{
+		{
+			A:        splitLines(files[0].code),
+			B:        splitLines(files[1].code),
+			FromFile: fromHash + "/draw.cpp",
+			ToFile:   toHash + "/draw.cpp",
+			Context:  3,
+		},
+		{
+			A:        splitLines(files[0].options),
+			B:        splitLines(files[1].options),
+			FromFile: fromHash + "/options.json",
+			ToFile:   toHash + "/options.json",
+			Context:  3,
+		},
+	} {
+		if err := difflib.WriteUnifiedDiff(&ret, diff); err != nil {
+			return "", skerr.Wrap(err)
+		}
+	}
+	return ret.String(), nil
+}
+
+// diffHandler displays a unified diff between two fiddles.
+//
+// The URLs look like:
+//
+//	/diff/<fiddleHash>/<fiddleHash>
+func diffHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	diff, err := diffFiddles(chi.URLParam(r, "from"), chi.URLParam(r, "to"))
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to diff fiddles.", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if _, err := w.Write([]byte(diff)); err != nil {
+		sklog.Errorf("Failed to write diff: %s", err)
+	}
+}
+
 // iframeHandle handles permalinks to individual fiddles.
 func iframeHandle(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -562,6 +709,10 @@ func addHandlers(r chi.Router) {
 	r.Get("/scrap/{type:[a-z]+}/{hashOrName:[@0-9a-zA-Z-_]+}", scrapHandler)
 	r.Get("/f/", failedHandler)
 	r.Get("/named/", namedHandler)
+	r.Get("/named/{name:[0-9a-zA-Z_]+}/history", historyHandler)
+	r.Get("/_/named", namedJSONHandler)
+	r.Get("/_/named/{name:[0-9a-zA-Z_]+}/history", historyJSONHandler)
+	r.Get("/diff/{from:[0-9a-zA-Z]+}/{to:[0-9a-zA-Z]+}", diffHandler)
 	r.Get("/new", basicModeHandler)
 	r.Get("/", mainHandler)
 	r.Post("/_/run", runHandler)
@@ -575,7 +726,7 @@ func main() {
 	)
 
 	var err error
-	if !*local {
+	if !*local && *storeDir == "" {
 		exporter, err := stackdriver.NewExporter(stackdriver.Options{
 			BundleDelayThreshold: time.Second / 10,
 			BundleCountThreshold: 10})
@@ -590,7 +741,11 @@ func main() {
 
 	loadTemplates()
 	ctx := context.Background()
-	fiddleStore, err = store.New(ctx, *local)
+	if *storeDir != "" {
+		fiddleStore, err = store.NewLocal(*storeDir)
+	} else {
+		fiddleStore, err = store.New(ctx, *local)
+	}
 	if err != nil {
 		sklog.Fatalf("Failed to connect to store: %s", err)
 	}
@@ -598,7 +753,11 @@ func main() {
 	if err != nil {
 		sklog.Fatalf("Failed to create scrap exchange client: %s", err)
 	}
-	run, err = runner.New(*local, *sourceImageDir)
+	if len(*fiddlers) > 0 {
+		run, err = runner.NewWithPool(*sourceImageDir, *fiddlers)
+	} else {
+		run, err = runner.New(*local, *sourceImageDir)
+	}
 	if err != nil {
 		sklog.Fatalf("Failed to initialize runner: %s", err)
 	}
@@ -2,14 +2,17 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/fiddlek/go/store"
 	"go.skia.org/infra/fiddlek/go/store/mocks"
 	"go.skia.org/infra/fiddlek/go/types"
 	"go.skia.org/infra/go/testutils"
@@ -133,3 +136,73 @@ func TestScrapHandler_WithInvalidType_ReturnsBadRequest(t *testing.T) {
 
 	require.Equal(t, 404, w.Code)
 }
+
+func TestHistoryJSONHandler_HappyPath(t *testing.T) {
+
+	r := httptest.NewRequest("GET", "/_/named/star/history", nil)
+	w := httptest.NewRecorder()
+
+	history := []store.NamedRevision{
+		{Hash: "abc123", User: "alice@example.com", Timestamp: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{Hash: "def456", User: "bob@example.com", Timestamp: time.Date(2021, time.March, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	storeMock := &mocks.Store{}
+	storeMock.On("GetNameHistory", "star").Return(history, nil)
+	defer storeMock.AssertExpectations(t)
+	fiddleStore = storeMock
+
+	router := chi.NewRouter()
+	addHandlers(router)
+
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, 200, w.Code)
+	var actual []store.NamedRevision
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&actual))
+	require.Equal(t, history, actual)
+}
+
+func TestDiffHandler_HappyPath(t *testing.T) {
+
+	r := httptest.NewRequest("GET", "/diff/abc123/def456", nil)
+	w := httptest.NewRecorder()
+
+	storeMock := &mocks.Store{}
+	storeMock.On("GetCode", "abc123").Return("void draw(SkCanvas* canvas) {\n}\n", &types.Options{Width: 256, Height: 256}, nil)
+	storeMock.On("GetCode", "def456").Return("void draw(SkCanvas* canvas) {\n    canvas->clear(SK_ColorRED);\n}\n", &types.Options{Width: 256, Height: 256}, nil)
+	defer storeMock.AssertExpectations(t)
+	fiddleStore = storeMock
+
+	router := chi.NewRouter()
+	addHandlers(router)
+
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, 200, w.Code)
+	// The options are the same, so only the code shows up in the diff.
+	require.Equal(t, `--- abc123/draw.cpp
++++ def456/draw.cpp
+@@ -1,2 +1,3 @@
+ void draw(SkCanvas* canvas) {
++    canvas->clear(SK_ColorRED);
+ }
+`, w.Body.String())
+}
+
+func TestDiffHandler_UnknownFiddle_ReturnsNotFound(t *testing.T) {
+
+	r := httptest.NewRequest("GET", "/diff/abc123/def456", nil)
+	w := httptest.NewRecorder()
+
+	storeMock := &mocks.Store{}
+	storeMock.On("GetCode", "abc123").Return("", nil, errMyMockError)
+	defer storeMock.AssertExpectations(t)
+	fiddleStore = storeMock
+
+	router := chi.NewRouter()
+	addHandlers(router)
+
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, 404, w.Code)
+}
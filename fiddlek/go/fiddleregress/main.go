@@ -0,0 +1,78 @@
+// Command line app to re-run every named fiddle against a fiddle server running a different
+// version of Skia, and report the fiddles that no longer compile, fail at runtime, or draw a
+// different image.
+//
+// Example:
+//
+//	fiddleregress --baseline https://fiddle.skia.org --candidate http://localhost:8000 --output /tmp/report.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.skia.org/infra/fiddlek/go/regression"
+	"go.skia.org/infra/go/common"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/sklog"
+)
+
+// flags
+var (
+	baseline  = flag.String("baseline", "https://fiddle.skia.org", "The fiddle server to read the named fiddles and their expected images from.")
+	candidate = flag.String("candidate", "", "The fiddle server, running the version of Skia under test, to re-run the named fiddles on.")
+	output    = flag.String("output", "", "If set, the name of the file to write the JSON report to.")
+	procs     = flag.Int("procs", 4, "The number of parallel requests to make to the candidate fiddle server.")
+)
+
+func main() {
+	common.Init()
+	if *candidate == "" {
+		flag.Usage()
+		sklog.Fatal("--candidate is a required flag.")
+	}
+
+	runner := regression.New(httputils.NewTimeoutClient(), *baseline, *candidate, *procs)
+	report, err := runner.Run(context.Background())
+	if err != nil {
+		sklog.Fatalf("Failed to run named fiddles: %s", err)
+	}
+	if *output != "" {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			sklog.Fatalf("Failed to encode report: %s", err)
+		}
+		if err := os.WriteFile(*output, b, 0644); err != nil {
+			sklog.Fatalf("Failed to write report: %s", err)
+		}
+	}
+
+	counts := map[regression.Status]int{}
+	for _, result := range report.Results {
+		counts[result.Status]++
+		if result.Status == regression.Error {
+			fmt.Printf("%s: error: %s\n", result.Name, result.Message)
+		}
+	}
+	regressions := report.Regressions()
+	for _, result := range regressions {
+		switch result.Status {
+		case regression.CompileFailed:
+			fmt.Printf("%s: no longer compiles:\n", result.Name)
+			for _, compileErr := range result.CompileErrors {
+				fmt.Printf("    %s\n", compileErr.Text)
+			}
+		case regression.RunFailed:
+			fmt.Printf("%s: failed at runtime: %s\n", result.Name, result.RunTimeError)
+		case regression.ImageChanged:
+			fmt.Printf("%s: %d pixels changed: %s/c/@%s vs %s/c/%s\n", result.Name, result.DifferentPixels, *baseline, result.Name, *candidate, result.FiddleHash)
+		}
+	}
+	fmt.Printf("%d unchanged, %d regressed, %d skipped, %d errors.\n", counts[regression.Unchanged], len(regressions), counts[regression.Skipped], counts[regression.Error])
+	if len(regressions) > 0 || counts[regression.Error] > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,330 @@
+// Package regression re-runs every named fiddle from one fiddle server against another, e.g. a
+// fiddle server running a new version of Skia, and reports the fiddles that no longer compile,
+// fail at runtime, or draw something different. Since the named fiddles are Skia's public
+// examples this gives early warning of breaking changes to Skia's API.
+package regression
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"go.skia.org/infra/fiddlek/go/store"
+	"go.skia.org/infra/fiddlek/go/types"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+// Status is the outcome of re-running a single named fiddle.
+type Status string
+
+const (
+	// Unchanged means the fiddle ran successfully and drew the same image.
+	Unchanged Status = "unchanged"
+
+	// ImageChanged means the fiddle ran successfully but drew a different image.
+	ImageChanged Status = "image_changed"
+
+	// CompileFailed means the fiddle no longer compiles.
+	CompileFailed Status = "compile_failed"
+
+	// RunFailed means the fiddle compiled but failed at runtime.
+	RunFailed Status = "run_failed"
+
+	// Skipped means the fiddle was already failing on the baseline server, so it wasn't run.
+	Skipped Status = "skipped"
+
+	// Error means the fiddle couldn't be run, e.g. because a server was unreachable.
+	Error Status = "error"
+)
+
+// IsRegression returns true if the status indicates a change in behavior between the baseline and
+// candidate servers.
+func (s Status) IsRegression() bool {
+	return s == ImageChanged || s == CompileFailed || s == RunFailed
+}
+
+// Result is the result of re-running a single named fiddle.
+type Result struct {
+	Name          string               `json:"name"`
+	Status        Status               `json:"status"`
+	FiddleHash    string               `json:"fiddleHash,omitempty"`
+	CompileErrors []types.CompileError `json:"compile_errors,omitempty"`
+	RunTimeError  string               `json:"runtime_error,omitempty"`
+
+	// DifferentPixels is the number of pixels that differ between the baseline and candidate
+	// images. It is only set if Status is ImageChanged.
+	DifferentPixels int `json:"different_pixels,omitempty"`
+
+	// Message explains a Skipped or Error status.
+	Message string `json:"message,omitempty"`
+}
+
+// Report is the result of a regression run.
+type Report struct {
+	Baseline  string   `json:"baseline"`
+	Candidate string   `json:"candidate"`
+	Results   []Result `json:"results"` // Sorted by name.
+}
+
+// Regressions returns the results of the fiddles whose behavior changed.
+func (r *Report) Regressions() []Result {
+	ret := []Result{}
+	for _, result := range r.Results {
+		if result.Status.IsRegression() {
+			ret = append(ret, result)
+		}
+	}
+	return ret
+}
+
+// Runner re-runs named fiddles from a baseline fiddle server against a candidate fiddle server.
+type Runner struct {
+	client *http.Client
+
+	// baseline and candidate are the scheme and domain names of the fiddle servers, e.g.
+	// "https://fiddle.skia.org".
+	baseline  string
+	candidate string
+
+	// procs is the number of fiddles to run in parallel.
+	procs int
+}
+
+// New returns a new *Runner.
+func New(client *http.Client, baseline, candidate string, procs int) *Runner {
+	if procs < 1 {
+		procs = 1
+	}
+	return &Runner{
+		client:    client,
+		baseline:  baseline,
+		candidate: candidate,
+		procs:     procs,
+	}
+}
+
+// get makes a GET request to the given URL and returns the body of the response.
+func (r *Runner) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "requesting %s", url)
+	}
+	defer util.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, skerr.Fmt("requesting %s: %s", url, resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading %s", url)
+	}
+	return b, nil
+}
+
+// getJSON makes a GET request to the given URL and decodes the JSON response into dst.
+func (r *Runner) getJSON(ctx context.Context, url string, dst interface{}) error {
+	b, err := r.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	return skerr.Wrapf(json.Unmarshal(b, dst), "decoding %s", url)
+}
+
+// Requests returns a types.BulkRequest which re-runs every named fiddle on the baseline server,
+// keyed by name, along with the results of the named fiddles which are skipped because they are
+// already failing on the baseline server.
+func (r *Runner) Requests(ctx context.Context) (types.BulkRequest, []Result, error) {
+	var named []store.Named
+	if err := r.getJSON(ctx, r.baseline+"/_/named", &named); err != nil {
+		return nil, nil, skerr.Wrapf(err, "listing named fiddles")
+	}
+	requests := types.BulkRequest{}
+	var skipped []Result
+	for _, n := range named {
+		if n.Status != "" {
+			skipped = append(skipped, Result{
+				Name:       n.Name,
+				Status:     Skipped,
+				FiddleHash: n.Hash,
+				Message:    n.Status,
+			})
+			continue
+		}
+		var fiddle types.FiddleContext
+		if err := r.getJSON(ctx, r.baseline+"/e/@"+n.Name, &fiddle); err != nil {
+			return nil, nil, skerr.Wrapf(err, "retrieving named fiddle %q", n.Name)
+		}
+		// Only send the code and options, and force the candidate to compile and run the fiddle,
+		// since it may already have results for the same fiddle hash.
+		requests[n.Name] = &types.FiddleContext{
+			Code:    fiddle.Code,
+			Options: fiddle.Options,
+			Fast:    false,
+		}
+	}
+	return requests, skipped, nil
+}
+
+// Run re-runs every named fiddle on the baseline server against the candidate server.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	report := &Report{
+		Baseline:  r.baseline,
+		Candidate: r.candidate,
+	}
+	requests, skipped, err := r.Requests(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Results = append(report.Results, skipped...)
+	sklog.Infof("Re-running %d named fiddles from %s on %s.", len(requests), r.baseline, r.candidate)
+
+	names := make(chan string, len(requests))
+	for name := range requests {
+		names <- name
+	}
+	close(names)
+
+	// mutex protects report.Results.
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < r.procs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				result := r.runOne(ctx, name, requests[name])
+				mutex.Lock()
+				report.Results = append(report.Results, result)
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(report.Results, func(i, j int) bool {
+		return report.Results[i].Name < report.Results[j].Name
+	})
+	return report, nil
+}
+
+// runOne runs a single named fiddle on the candidate server and compares its output to that of the
+// baseline server.
+func (r *Runner) runOne(ctx context.Context, name string, req *types.FiddleContext) Result {
+	result := Result{
+		Name: name,
+	}
+	runResults, err := r.run(ctx, req)
+	if err != nil {
+		sklog.Errorf("Failed to run %q: %s", name, err)
+		result.Status = Error
+		result.Message = err.Error()
+		return result
+	}
+	result.FiddleHash = runResults.FiddleHash
+	if len(runResults.CompileErrors) > 0 {
+		result.Status = CompileFailed
+		result.CompileErrors = runResults.CompileErrors
+		return result
+	}
+	if runResults.RunTimeError != "" {
+		result.Status = RunFailed
+		result.RunTimeError = runResults.RunTimeError
+		return result
+	}
+
+	result.Status = Unchanged
+	// Text only fiddles have no image, and animated fiddles only have videos, so there's nothing to
+	// compare.
+	if req.Options.TextOnly || req.Options.Animated {
+		return result
+	}
+	baseline, err := r.getImage(ctx, r.baseline, "@"+name)
+	if err != nil {
+		result.Status = Error
+		result.Message = fmt.Sprintf("Failed to retrieve baseline image: %s", err)
+		return result
+	}
+	candidate, err := r.getImage(ctx, r.candidate, runResults.FiddleHash)
+	if err != nil {
+		result.Status = Error
+		result.Message = fmt.Sprintf("Failed to retrieve candidate image: %s", err)
+		return result
+	}
+	if diff := DifferentPixels(baseline, candidate); diff > 0 {
+		result.Status = ImageChanged
+		result.DifferentPixels = diff
+	}
+	return result
+}
+
+// run runs a single fiddle on the candidate server.
+func (r *Runner) run(ctx context.Context, fiddle *types.FiddleContext) (*types.RunResults, error) {
+	b, err := json.Marshal(fiddle)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.candidate+"/_/run", bytes.NewReader(b))
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "running fiddle")
+	}
+	defer util.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, skerr.Fmt("running fiddle: %s", resp.Status)
+	}
+	var runResults types.RunResults
+	if err := json.NewDecoder(resp.Body).Decode(&runResults); err != nil {
+		return nil, skerr.Wrapf(err, "decoding run results")
+	}
+	return &runResults, nil
+}
+
+// getImage retrieves the CPU image of the given fiddle, which may be a fiddle hash or "@name".
+func (r *Runner) getImage(ctx context.Context, domain, id string) (image.Image, error) {
+	b, err := r.get(ctx, domain+"/i/"+id+"_raster.png")
+	if err != nil {
+		return nil, err
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "decoding image of %s", id)
+	}
+	return img, nil
+}
+
+// DifferentPixels returns the number of pixels that differ between the two images. If the images
+// are different sizes then every pixel of the larger image is counted as different.
+func DifferentPixels(a, b image.Image) int {
+	sizeA, sizeB := a.Bounds().Size(), b.Bounds().Size()
+	if sizeA != sizeB {
+		return util.MaxInt(sizeA.X*sizeA.Y, sizeB.X*sizeB.Y)
+	}
+	diff := 0
+	for y := 0; y < sizeA.Y; y++ {
+		for x := 0; x < sizeA.X; x++ {
+			ca := color.NRGBAModel.Convert(a.At(a.Bounds().Min.X+x, a.Bounds().Min.Y+y))
+			cb := color.NRGBAModel.Convert(b.At(b.Bounds().Min.X+x, b.Bounds().Min.Y+y))
+			if ca != cb {
+				diff++
+			}
+		}
+	}
+	return diff
+}
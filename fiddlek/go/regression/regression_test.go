@@ -0,0 +1,158 @@
+package regression
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/fiddlek/go/store"
+	"go.skia.org/infra/fiddlek/go/types"
+)
+
+// encodePNG returns a PNG of the given size filled with the given color.
+func encodePNG(t *testing.T, size int, c color.Color) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// fakeServer is a fiddle server which serves the given JSON responses and images by path, and runs
+// fiddles by looking up their code in results.
+func fakeServer(t *testing.T, responses map[string]interface{}, images map[string][]byte, results map[string]*types.RunResults) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/_/run" {
+			var req types.FiddleContext
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.False(t, req.Fast)
+			require.NoError(t, json.NewEncoder(w).Encode(results[req.Code]))
+			return
+		}
+		if b, ok := images[r.URL.Path]; ok {
+			_, err := w.Write(b)
+			require.NoError(t, err)
+			return
+		}
+		if resp, ok := responses[r.URL.Path]; ok {
+			require.NoError(t, json.NewEncoder(w).Encode(resp))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+}
+
+func TestRun_MixedResults_ReportsRegressions(t *testing.T) {
+	red := encodePNG(t, 4, color.NRGBA{R: 0xff, A: 0xff})
+	blue := encodePNG(t, 4, color.NRGBA{B: 0xff, A: 0xff})
+	fiddle := func(code string, options types.Options) *types.FiddleContext {
+		return &types.FiddleContext{Code: code, Options: options, Version: "baseline"}
+	}
+	baseline := fakeServer(t, map[string]interface{}{
+		"/_/named": []store.Named{
+			{Name: "broken", Hash: "0000"},
+			{Name: "changed", Hash: "1111"},
+			{Name: "crashes", Hash: "2222"},
+			{Name: "same", Hash: "3333"},
+			{Name: "text", Hash: "4444"},
+			{Name: "already_failing", Hash: "5555", Status: "Failed to compile."},
+		},
+		"/e/@broken":  fiddle("broken code", types.Options{Width: 4, Height: 4}),
+		"/e/@changed": fiddle("changed code", types.Options{Width: 4, Height: 4}),
+		"/e/@crashes": fiddle("crashes code", types.Options{Width: 4, Height: 4}),
+		"/e/@same":    fiddle("same code", types.Options{Width: 4, Height: 4}),
+		"/e/@text":    fiddle("text code", types.Options{TextOnly: true}),
+	}, map[string][]byte{
+		"/i/@changed_raster.png": red,
+		"/i/@same_raster.png":    red,
+	}, nil)
+	defer baseline.Close()
+
+	candidate := fakeServer(t, nil, map[string][]byte{
+		"/i/1111_raster.png": blue,
+		"/i/3333_raster.png": red,
+	}, map[string]*types.RunResults{
+		"broken code":  {FiddleHash: "0000", CompileErrors: []types.CompileError{{Text: "error: no member named 'foo'", Line: 2, Col: 5}}},
+		"changed code": {FiddleHash: "1111"},
+		"crashes code": {FiddleHash: "2222", RunTimeError: "Failed to run, possibly violated security container."},
+		"same code":    {FiddleHash: "3333"},
+		"text code":    {FiddleHash: "4444", Text: "Hello"},
+	})
+	defer candidate.Close()
+
+	report, err := New(http.DefaultClient, baseline.URL, candidate.URL, 2).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Result{
+		{Name: "already_failing", Status: Skipped, FiddleHash: "5555", Message: "Failed to compile."},
+		{Name: "broken", Status: CompileFailed, FiddleHash: "0000", CompileErrors: []types.CompileError{{Text: "error: no member named 'foo'", Line: 2, Col: 5}}},
+		{Name: "changed", Status: ImageChanged, FiddleHash: "1111", DifferentPixels: 16},
+		{Name: "crashes", Status: RunFailed, FiddleHash: "2222", RunTimeError: "Failed to run, possibly violated security container."},
+		{Name: "same", Status: Unchanged, FiddleHash: "3333"},
+		{Name: "text", Status: Unchanged, FiddleHash: "4444"},
+	}, report.Results)
+
+	var regressions []string
+	for _, result := range report.Regressions() {
+		regressions = append(regressions, result.Name)
+	}
+	assert.Equal(t, []string{"broken", "changed", "crashes"}, regressions)
+}
+
+func TestRun_CandidateImageMissing_ReportsError(t *testing.T) {
+	baseline := fakeServer(t, map[string]interface{}{
+		"/_/named": []store.Named{{Name: "same", Hash: "3333"}},
+		"/e/@same": &types.FiddleContext{Code: "same code", Options: types.Options{Width: 4, Height: 4}},
+	}, map[string][]byte{
+		"/i/@same_raster.png": encodePNG(t, 4, color.White),
+	}, nil)
+	defer baseline.Close()
+	candidate := fakeServer(t, nil, nil, map[string]*types.RunResults{
+		"same code": {FiddleHash: "3333"},
+	})
+	defer candidate.Close()
+
+	report, err := New(http.DefaultClient, baseline.URL, candidate.URL, 1).Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, Error, report.Results[0].Status)
+	assert.True(t, strings.HasPrefix(report.Results[0].Message, "Failed to retrieve candidate image"))
+	assert.Empty(t, report.Regressions())
+}
+
+func TestRun_BaselineUnreachable_ReturnsError(t *testing.T) {
+	baseline := fakeServer(t, nil, nil, nil)
+	defer baseline.Close()
+
+	_, err := New(http.DefaultClient, baseline.URL, baseline.URL, 1).Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "listing named fiddles")
+}
+
+func TestDifferentPixels_DifferentSizes_CountsLargerImage(t *testing.T) {
+	a := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	b := image.NewNRGBA(image.Rect(0, 0, 3, 3))
+	assert.Equal(t, 9, DifferentPixels(a, b))
+}
+
+func TestDifferentPixels_DifferentPixelFormats_ComparesColors(t *testing.T) {
+	a := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	a.Set(0, 0, color.NRGBA{R: 0xff, A: 0xff})
+	a.Set(1, 0, color.NRGBA{G: 0xff, A: 0xff})
+	b := image.NewRGBA(image.Rect(10, 10, 12, 11))
+	b.Set(10, 10, color.RGBA{R: 0xff, A: 0xff})
+	b.Set(11, 10, color.RGBA{B: 0xff, A: 0xff})
+	assert.Equal(t, 1, DifferentPixels(a, b))
+}
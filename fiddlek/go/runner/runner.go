@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"net"
 	"net/http"
 	"path/filepath"
 	"strings"
@@ -43,6 +44,9 @@ DrawOptions GetDrawOptions() {
 	// NUM_RETRIES is the number of time to try to find a pod to run the fiddle
 	// on before giving up.
 	NUM_RETRIES = 8
+
+	// FIDDLER_PORT is the port fiddler pods listen on.
+	FIDDLER_PORT = "8000"
 )
 
 var (
@@ -75,19 +79,43 @@ type Runner struct {
 	clientset *kubernetes.Clientset
 	rand      *rand.Rand
 
+	// pool is a fixed list of fiddler addresses to use instead of discovering
+	// fiddler pods via Kubernetes. See NewWithPool.
+	pool []string
+
 	mutex       sync.Mutex // mutex protects the members below.
 	skiaGitHash string
-	fiddlerIPs  []string
+	// fiddlerAddresses are the host:port addresses of all known fiddlers.
+	fiddlerAddresses []string
 }
 
 func New(local bool, sourceDir string) (*Runner, error) {
+	return newRunner(local, sourceDir, nil)
+}
+
+// NewWithPool returns a Runner that dispatches fiddles to a fixed pool of
+// fiddlers, e.g. fiddler processes or containers running on the same machine,
+// instead of fiddler pods discovered via Kubernetes. This allows hosting a
+// self-contained fiddle server.
+//
+//	sourceDir - The directory to load the source images from.
+//	pool - The host:port addresses of the fiddlers, e.g. "localhost:8001".
+func NewWithPool(sourceDir string, pool []string) (*Runner, error) {
+	if len(pool) == 0 {
+		return nil, fmt.Errorf("At least one fiddler address is required.")
+	}
+	return newRunner(false, sourceDir, pool)
+}
+
+func newRunner(local bool, sourceDir string, pool []string) (*Runner, error) {
 	ret := &Runner{
-		sourceDir:  sourceDir,
-		local:      local,
-		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
-		fiddlerIPs: []string{},
+		sourceDir:        sourceDir,
+		local:            local,
+		rand:             rand.New(rand.NewSource(time.Now().UnixNano())),
+		pool:             pool,
+		fiddlerAddresses: []string{},
 	}
-	if !local {
+	if !local && pool == nil {
 		config, err := rest.InClusterConfig()
 		if err != nil {
 			return nil, fmt.Errorf("Failed to get in-cluster config: %s", err)
@@ -101,17 +129,19 @@ func New(local bool, sourceDir string) (*Runner, error) {
 	}
 	// Start the IP refresher.
 	if err := ret.fiddlerIPsOneStep(); err != nil {
-		return nil, fmt.Errorf("Failed initial population of fiddlerIPs: %s", err)
+		return nil, fmt.Errorf("Failed initial population of fiddlerAddresses: %s", err)
 	}
 	go ret.fiddlerIPsRefresher()
 	return ret, nil
 }
 
-// fiddlerIPsOneStep refreshes a list of fiddler pod IP addresses just once.
+// fiddlerIPsOneStep refreshes a list of fiddler addresses just once.
 func (r *Runner) fiddlerIPsOneStep() error {
-	ips := []string{}
+	addresses := []string{}
 	if r.local {
-		ips = []string{"127.0.0.1"}
+		addresses = []string{net.JoinHostPort("127.0.0.1", FIDDLER_PORT)}
+	} else if r.pool != nil {
+		addresses = append(addresses, r.pool...)
 	} else {
 		pods, err := r.clientset.CoreV1().Pods("default").List(context.TODO(), metav1.ListOptions{
 			LabelSelector: "app=fiddler",
@@ -119,21 +149,21 @@ func (r *Runner) fiddlerIPsOneStep() error {
 		if err != nil {
 			return fmt.Errorf("Could not list fiddler pods: %s", err)
 		}
-		ips = make([]string, 0, len(pods.Items))
+		addresses = make([]string, 0, len(pods.Items))
 		for _, p := range pods.Items {
 			// Note that the PodIP can be the empty string. Who knew?
 			if p.Status.PodIP != "" {
-				ips = append(ips, p.Status.PodIP)
+				addresses = append(addresses, net.JoinHostPort(p.Status.PodIP, FIDDLER_PORT))
 			}
 		}
 	}
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
-	r.fiddlerIPs = ips
+	r.fiddlerAddresses = addresses
 	return nil
 }
 
-// fiddlerIPsRefresher refreshes a list of fiddler pod IP addresses.
+// fiddlerIPsRefresher refreshes a list of fiddler addresses.
 func (r *Runner) fiddlerIPsRefresher() {
 	fiddlerIPLiveness := metrics2.NewLiveness("fiddler_ips")
 	for range time.Tick(5 * time.Second) {
@@ -285,9 +315,9 @@ func (r *Runner) Run(ctx context.Context, local bool, req *types.FiddleContext)
 		// Try to run the fiddle on an open pod. If all pods are busy then
 		// wait a bit and try again.
 		for tries := 0; tries < NUM_RETRIES; tries++ {
-			ips := r.randPodIPs()
-			for _, p := range ips {
-				rootURL := fmt.Sprintf("http://%s:8000", p)
+			addresses := r.randPodAddresses()
+			for _, p := range addresses {
+				rootURL := fmt.Sprintf("http://%s", p)
 				sklog.Infof("%q Trying: %q", req.Hash, rootURL)
 				// Run the fiddle in the open pod.
 				ret, err := r.singleRun(ctx, rootURL+"/run", bytes.NewReader(b))
@@ -306,30 +336,33 @@ func (r *Runner) Run(ctx context.Context, local bool, req *types.FiddleContext)
 	}
 }
 
-func (r *Runner) podIPs() []string {
+func (r *Runner) podAddresses() []string {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
-	return append([]string(nil), r.fiddlerIPs...)
+	return append([]string(nil), r.fiddlerAddresses...)
 }
 
-// randPodIPs returns 10 random pods IPs.
+// randPodAddresses returns 10 random pod addresses.
 //
 // Only return a subset, not the full list, as this may lead to a thundering
 // herd problem. I.e. A large number of incoming requests will hit the backends
 // and cause requests to fail, so requests have to look further down the list
 // of pods, increasing traffic further.
-func (r *Runner) randPodIPs() []string {
+func (r *Runner) randPodAddresses() []string {
 	ret := []string{}
-	ips := r.podIPs()
-	n := len(ips)
+	addresses := r.podAddresses()
+	n := len(addresses)
+	if n == 0 {
+		return ret
+	}
 	for i := 0; i < 10; i++ {
-		ret = append(ret, ips[rand.Intn(n)])
+		ret = append(ret, addresses[rand.Intn(n)])
 	}
 	return ret
 }
 
 func singlePodVersion(client *http.Client, address string) (string, types.State, bool) {
-	rootURL := fmt.Sprintf("http://%s:8000", address)
+	rootURL := fmt.Sprintf("http://%s", address)
 	req, err := http.NewRequest("GET", rootURL, nil)
 	if err != nil {
 		sklog.Infof("Failed to create request for fiddler status: %s", err)
@@ -358,12 +391,12 @@ func (r *Runner) metricsSingleStep() {
 	idleCount := 0
 	// What versions of skia are all the fiddlers running.
 	versions := map[string]int{}
-	ips := r.podIPs()
+	addresses := r.podAddresses()
 	fastClient := httputils.NewFastTimeoutClient()
 	for _, st := range types.AllStates {
 		metrics2.GetCounter("fiddler_pod_state", map[string]string{"state": string(st)}).Reset()
 	}
-	for _, address := range ips {
+	for _, address := range addresses {
 		if ver, st, ok := singlePodVersion(fastClient, address); ok {
 			idleCount += 1
 			versions[ver] += 1
@@ -384,7 +417,7 @@ func (r *Runner) metricsSingleStep() {
 		}
 	}
 	podsIdle.Update(int64(idleCount))
-	podsTotal.Update(int64(len(ips)))
+	podsTotal.Update(int64(len(addresses)))
 }
 
 // Metrics captures metrics on the state of all the fiddler pods.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -133,6 +134,33 @@ func TestRun(t *testing.T) {
 	assert.Equal(t, "Compile Failed.", res.Errors)
 }
 
+func TestRunWithPool_BusyFiddlerSkipped_RunsOnIdleFiddler(t *testing.T) {
+	busy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer busy.Close()
+	idle := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/run", r.URL.Path)
+		_, err := fmt.Fprintln(w, `{"Errors": "Compile Failed."}`)
+		assert.NoError(t, err)
+	}))
+	defer idle.Close()
+
+	r, err := NewWithPool("/etc/fiddle/source", []string{
+		strings.TrimPrefix(busy.URL, "http://"),
+		strings.TrimPrefix(idle.URL, "http://"),
+	})
+	assert.NoError(t, err)
+	res, err := r.Run(context.Background(), false, &types.FiddleContext{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Compile Failed.", res.Errors)
+}
+
+func TestNewWithPool_EmptyPool_Error(t *testing.T) {
+	_, err := NewWithPool("/etc/fiddle/source", nil)
+	assert.Error(t, err)
+}
+
 func TestValidateOptions(t *testing.T) {
 	testCases := []struct {
 		value         *types.Options
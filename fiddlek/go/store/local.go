@@ -0,0 +1,362 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	"go.skia.org/infra/fiddlek/go/types"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	// namedFilename is the name of the file in the root of a localStore which records all the named
+	// fiddles and their revision history.
+	namedFilename = "named_fiddles.json"
+
+	// lockFilename is the name of the file in the root of a localStore which is locked while the
+	// named fiddles are read or written, so that multiple processes may share a store.
+	lockFilename = "named.lock"
+
+	// optionsFilename is the name of the file next to draw.cpp which records the options a fiddle
+	// was run under.
+	optionsFilename = "options.json"
+)
+
+// localStore implements Store by storing fiddles and media in a local directory, so that a fiddle
+// server can be hosted on a single machine without access to Google Storage.
+//
+// Files are laid out the same way as in Google Storage:
+//
+//	<dir>/fiddle/<fiddleHash>/draw.cpp
+//	<dir>/fiddle/<fiddleHash>/options.json
+//	<dir>/fiddle/<fiddleHash>/cpu.png
+//	...
+//
+// Named fiddles and their revision history are all recorded in <dir>/named_fiddles.json, which is
+// read on every access and rewritten atomically on each change while holding a lock on
+// <dir>/named.lock.
+type localStore struct {
+	dir string
+	now func() time.Time
+}
+
+// namedFiddles is the contents of namedFilename.
+type namedFiddles struct {
+	Names   map[string]Named           `json:"names"`
+	History map[string][]NamedRevision `json:"history"`
+}
+
+// NewLocal creates a new *localStore, which implements Store, that stores fiddles in the given
+// directory. The directory is created if it doesn't exist.
+func NewLocal(dir string) (*localStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, skerr.Wrapf(err, "creating store directory %q", dir)
+	}
+	s := &localStore{
+		dir: dir,
+		now: time.Now,
+	}
+	// Fail early if the named fiddles can't be read.
+	if err := s.withNames(false, func(*namedFiddles) error { return nil }); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// fiddleDir returns the directory where the code and media of the given fiddle are stored.
+func (s *localStore) fiddleDir(fiddleHash string) string {
+	return filepath.Join(s.dir, "fiddle", fiddleHash)
+}
+
+// checkHash returns an error if the given string is not a valid fiddle hash, which prevents user
+// supplied hashes from escaping the store directory.
+func checkHash(fiddleHash string) error {
+	if !validName.MatchString(fiddleHash) {
+		return fmt.Errorf("Invalid fiddle hash %q", fiddleHash)
+	}
+	return nil
+}
+
+// writeFile atomically writes the given contents to the file at the given path.
+func writeFile(path string, contents []byte) error {
+	return util.WithWriteFile(path, func(w io.Writer) error {
+		_, err := w.Write(contents)
+		return err
+	})
+}
+
+// Put implements Store.
+func (s *localStore) Put(code string, options types.Options, results *types.Result) (string, error) {
+	fiddleHash, err := options.ComputeHash(code)
+	if err != nil {
+		return "", fmt.Errorf("Could not compute hash for the code: %s", err)
+	}
+	b, err := json.Marshal(options)
+	if err != nil {
+		return "", skerr.Wrapf(err, "encoding options")
+	}
+	dir := s.fiddleDir(fiddleHash)
+	if err := writeFile(filepath.Join(dir, "draw.cpp"), []byte(code)); err != nil {
+		return "", skerr.Wrapf(err, "writing code")
+	}
+	if err := writeFile(filepath.Join(dir, optionsFilename), b); err != nil {
+		return "", skerr.Wrapf(err, "writing options")
+	}
+	if results == nil {
+		return fiddleHash, nil
+	}
+	if err := s.PutMedia(options, fiddleHash, results); err != nil {
+		return fiddleHash, err
+	}
+	return fiddleHash, nil
+}
+
+// writeMediaFile writes a media file to the directory of the given fiddle.
+func (s *localStore) writeMediaFile(media Media, fiddleHash, b64 string) error {
+	p, body, err := decodeMediaFile(media, b64)
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(s.fiddleDir(fiddleHash), p.filename), body)
+}
+
+// PutMedia implements Store.
+func (s *localStore) PutMedia(options types.Options, fiddleHash string, results *types.Result) error {
+	for _, f := range mediaFiles(options, results) {
+		if err := s.writeMediaFile(f.media, fiddleHash, f.b64); err != nil {
+			return err
+		}
+	}
+	if results.Execute.Output.GLInfo != "" {
+		if err := s.writeMediaFile(GLINFO, fiddleHash, results.Execute.Output.GLInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCode implements Store.
+func (s *localStore) GetCode(fiddleHash string) (string, *types.Options, error) {
+	if err := checkHash(fiddleHash); err != nil {
+		return "", nil, err
+	}
+	dir := s.fiddleDir(fiddleHash)
+	code, err := os.ReadFile(filepath.Join(dir, "draw.cpp"))
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to read source file for %s: %s", fiddleHash, err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, optionsFilename))
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to read options for %s: %s", fiddleHash, err)
+	}
+	options := &types.Options{}
+	if err := json.Unmarshal(b, options); err != nil {
+		return "", nil, fmt.Errorf("Failed to parse options for %s: %s", fiddleHash, err)
+	}
+	return string(code), options, nil
+}
+
+// GetMedia implements Store.
+func (s *localStore) GetMedia(fiddleHash string, media Media) ([]byte, string, string, error) {
+	if err := checkHash(fiddleHash); err != nil {
+		return nil, "", "", err
+	}
+	p, ok := mediaProps[media]
+	if !ok {
+		return nil, "", "", fmt.Errorf("Unknown media type.")
+	}
+	b, err := os.ReadFile(filepath.Join(s.fiddleDir(fiddleHash), p.filename))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("Unable to read the media file (%s, %s): %s", fiddleHash, string(media), err)
+	}
+	return b, p.contentType, p.filename, nil
+}
+
+// readNames reads the named fiddles from disk. The caller must hold the lock on the store.
+func (s *localStore) readNames() (*namedFiddles, error) {
+	n := &namedFiddles{}
+	b, err := os.ReadFile(filepath.Join(s.dir, namedFilename))
+	if err == nil {
+		if err := json.Unmarshal(b, n); err != nil {
+			return nil, skerr.Wrapf(err, "reading named fiddles")
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, skerr.Wrapf(err, "reading named fiddles")
+	}
+	if n.Names == nil {
+		n.Names = map[string]Named{}
+	}
+	if n.History == nil {
+		n.History = map[string][]NamedRevision{}
+	}
+	return n, nil
+}
+
+// withNames reads the named fiddles and calls fn with them while holding a lock on the store, which
+// is exclusive if write is true. If write is true and fn succeeds, the named fiddles and their
+// history are then written back to disk atomically, in a single file.
+func (s *localStore) withNames(write bool, fn func(n *namedFiddles) error) error {
+	f, err := os.OpenFile(filepath.Join(s.dir, lockFilename), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return skerr.Wrapf(err, "opening lock file")
+	}
+	// Closing the file releases the lock.
+	defer util.Close(f)
+	how := syscall.LOCK_SH
+	if write {
+		how = syscall.LOCK_EX
+	}
+	if err := syscall.Flock(int(f.Fd()), how); err != nil {
+		return skerr.Wrapf(err, "locking named fiddles")
+	}
+	n, err := s.readNames()
+	if err != nil {
+		return err
+	}
+	if err := fn(n); err != nil || !write {
+		return err
+	}
+	b, err := json.MarshalIndent(n, "", "  ")
+	if err != nil {
+		return skerr.Wrapf(err, "encoding named fiddles")
+	}
+	return skerr.Wrapf(writeFile(filepath.Join(s.dir, namedFilename), b), "writing named fiddles")
+}
+
+// ListAllNames implements Store.
+func (s *localStore) ListAllNames() ([]Named, error) {
+	var ret []Named
+	err := s.withNames(false, func(n *namedFiddles) error {
+		ret = make([]Named, 0, len(n.Names))
+		for _, named := range n.Names {
+			ret = append(ret, named)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret, nil
+}
+
+// GetHashFromName implements Store.
+func (s *localStore) GetHashFromName(name string) (string, error) {
+	var hash string
+	err := s.withNames(false, func(n *namedFiddles) error {
+		named, ok := n.Names[name]
+		if !ok {
+			return fmt.Errorf("Failed to find named fiddle %q", name)
+		}
+		hash = named.Hash
+		return nil
+	})
+	return hash, err
+}
+
+// currentRevision returns the revision of the named fiddle, whose timestamp is unknown, or nil if
+// the name doesn't exist.
+func (n *namedFiddles) currentRevision(name string) *NamedRevision {
+	named, ok := n.Names[name]
+	if !ok {
+		return nil
+	}
+	return &NamedRevision{
+		Hash: named.Hash,
+		User: named.User,
+	}
+}
+
+// GetNameHistory implements Store.
+//
+// Names written before history was recorded have a single revision, taken from the current name,
+// until they are next written.
+func (s *localStore) GetNameHistory(name string) ([]NamedRevision, error) {
+	var history []NamedRevision
+	err := s.withNames(false, func(n *namedFiddles) error {
+		history = n.History[name]
+		if len(history) == 0 {
+			current := n.currentRevision(name)
+			if current == nil {
+				return fmt.Errorf("Failed to find named fiddle %q", name)
+			}
+			history = []NamedRevision{*current}
+		}
+		return nil
+	})
+	return history, err
+}
+
+// ValidName implements Store.
+func (s *localStore) ValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// WriteName implements Store.
+func (s *localStore) WriteName(name, hash, user, status string) error {
+	if !s.ValidName(name) {
+		return fmt.Errorf("Invalid character found in name.")
+	}
+	return s.withNames(true, func(n *namedFiddles) error {
+		if current := n.currentRevision(name); current == nil || current.Hash != hash {
+			next := NamedRevision{
+				Hash:      hash,
+				User:      user,
+				Timestamp: s.now(),
+			}
+			n.History[name] = append(n.History[name], revisionsToWrite(current, len(n.History[name]) > 0, next)...)
+		}
+		n.Names[name] = Named{
+			Name:   name,
+			User:   user,
+			Hash:   hash,
+			Status: status,
+		}
+		return nil
+	})
+}
+
+// SetStatus implements Store.
+func (s *localStore) SetStatus(name, status string) error {
+	if !s.ValidName(name) {
+		return fmt.Errorf("Invalid character found in name.")
+	}
+	return s.withNames(true, func(n *namedFiddles) error {
+		named, ok := n.Names[name]
+		if !ok {
+			return fmt.Errorf("Failed to find named fiddle %q", name)
+		}
+		named.Status = status
+		n.Names[name] = named
+		return nil
+	})
+}
+
+// DeleteName implements Store.
+func (s *localStore) DeleteName(name string) error {
+	return s.withNames(true, func(n *namedFiddles) error {
+		delete(n.Names, name)
+		return nil
+	})
+}
+
+// Exists implements Store.
+func (s *localStore) Exists(hash string) error {
+	if err := checkHash(hash); err != nil {
+		return err
+	}
+	_, err := os.Stat(filepath.Join(s.fiddleDir(hash), "draw.cpp"))
+	return err
+}
+
+// Confirm the *localStore implements Store.
+var _ Store = (*localStore)(nil)
@@ -0,0 +1,166 @@
+package store
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/fiddlek/go/types"
+)
+
+func b64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func TestLocalStore_PutThenGet_Success(t *testing.T) {
+	s, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	options := types.Options{Width: 256, Height: 128, Source: 2, SRGB: true}
+	results := &types.Result{
+		Execute: types.Execute{
+			Output: types.Output{
+				Raster: b64("cpu"),
+				Gpu:    b64("gpu"),
+				Pdf:    b64("pdf"),
+				Skp:    b64("skp"),
+			},
+		},
+	}
+	hash, err := s.Put("void draw(SkCanvas* canvas) {}", options, results)
+	require.NoError(t, err)
+	require.NoError(t, s.Exists(hash))
+
+	code, gotOptions, err := s.GetCode(hash)
+	require.NoError(t, err)
+	assert.Equal(t, "void draw(SkCanvas* canvas) {}", code)
+	assert.Equal(t, options, *gotOptions)
+
+	body, contentType, filename, err := s.GetMedia(hash, GPU)
+	require.NoError(t, err)
+	assert.Equal(t, "gpu", string(body))
+	assert.Equal(t, "image/png", contentType)
+	assert.Equal(t, "gpu.png", filename)
+
+	_, _, _, err = s.GetMedia(hash, TXT)
+	assert.Error(t, err)
+}
+
+func TestLocalStore_InvalidHash_Error(t *testing.T) {
+	s, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	assert.Error(t, s.Exists("../named.json"))
+	_, _, err = s.GetCode("../../etc")
+	assert.Error(t, err)
+	_, _, _, err = s.GetMedia("..", CPU)
+	assert.Error(t, err)
+}
+
+func TestLocalStore_Names_PersistedAcrossInstances(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocal(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, s.WriteName("star", "abc123", "alice@example.com", ""))
+	require.NoError(t, s.WriteName("circle", "def456", "bob@example.com", ""))
+	require.NoError(t, s.SetStatus("star", "Failed to compile."))
+	require.Error(t, s.SetStatus("unknown", "Failed to compile."))
+	require.Error(t, s.WriteName("not valid", "abc123", "alice@example.com", ""))
+
+	// Reload from disk.
+	s, err = NewLocal(dir)
+	require.NoError(t, err)
+	names, err := s.ListAllNames()
+	require.NoError(t, err)
+	assert.Equal(t, []Named{
+		{Name: "circle", User: "bob@example.com", Hash: "def456"},
+		{Name: "star", User: "alice@example.com", Hash: "abc123", Status: "Failed to compile."},
+	}, names)
+
+	hash, err := s.GetHashFromName("circle")
+	require.NoError(t, err)
+	assert.Equal(t, "def456", hash)
+
+	require.NoError(t, s.DeleteName("circle"))
+	_, err = s.GetHashFromName("circle")
+	assert.Error(t, err)
+}
+
+func TestLocalStore_WriteName_RecordsHistoryWhenHashChanges(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocal(dir)
+	require.NoError(t, err)
+	ts := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time {
+		ts = ts.Add(time.Hour)
+		return ts
+	}
+
+	require.NoError(t, s.WriteName("star", "abc123", "alice@example.com", ""))
+	// Only changing the status doesn't create a new revision.
+	require.NoError(t, s.WriteName("star", "abc123", "alice@example.com", "Failed to compile."))
+	require.NoError(t, s.WriteName("star", "def456", "bob@example.com", ""))
+	require.NoError(t, s.DeleteName("star"))
+
+	// History is kept across instances and after the name is deleted.
+	s, err = NewLocal(dir)
+	require.NoError(t, err)
+	history, err := s.GetNameHistory("star")
+	require.NoError(t, err)
+	assert.Equal(t, []NamedRevision{
+		{Hash: "abc123", User: "alice@example.com", Timestamp: time.Date(2021, time.March, 1, 1, 0, 0, 0, time.UTC)},
+		{Hash: "def456", User: "bob@example.com", Timestamp: time.Date(2021, time.March, 1, 2, 0, 0, 0, time.UTC)},
+	}, history)
+
+	_, err = s.GetNameHistory("unknown")
+	assert.Error(t, err)
+}
+
+func TestLocalStore_WriteName_NameWithoutHistory_RecordsCurrentRevisionFirst(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, namedFilename), []byte(`{"names": {"star": {"Name": "star", "User": "alice@example.com", "Hash": "abc123"}}}`), 0644))
+	s, err := NewLocal(dir)
+	require.NoError(t, err)
+	ts := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time {
+		return ts
+	}
+
+	history, err := s.GetNameHistory("star")
+	require.NoError(t, err)
+	assert.Equal(t, []NamedRevision{{Hash: "abc123", User: "alice@example.com"}}, history)
+
+	require.NoError(t, s.WriteName("star", "def456", "bob@example.com", ""))
+	history, err = s.GetNameHistory("star")
+	require.NoError(t, err)
+	assert.Equal(t, []NamedRevision{
+		{Hash: "abc123", User: "alice@example.com"},
+		{Hash: "def456", User: "bob@example.com", Timestamp: ts},
+	}, history)
+}
+
+func TestLocalStore_SharedDirectory_SeesChangesFromOtherInstances(t *testing.T) {
+	dir := t.TempDir()
+	s1, err := NewLocal(dir)
+	require.NoError(t, err)
+	s2, err := NewLocal(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, s1.WriteName("star", "abc123", "alice@example.com", ""))
+	require.NoError(t, s2.WriteName("circle", "def456", "bob@example.com", ""))
+
+	// Neither instance overwrites the changes of the other.
+	for _, s := range []*localStore{s1, s2} {
+		names, err := s.ListAllNames()
+		require.NoError(t, err)
+		require.Len(t, names, 2)
+		history, err := s.GetNameHistory("circle")
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+	}
+}
@@ -132,6 +132,29 @@ func (_m *Store) GetMedia(fiddleHash string, media store.Media) ([]byte, string,
 	return r0, r1, r2, r3
 }
 
+// GetNameHistory provides a mock function with given fields: name
+func (_m *Store) GetNameHistory(name string) ([]store.NamedRevision, error) {
+	ret := _m.Called(name)
+
+	var r0 []store.NamedRevision
+	if rf, ok := ret.Get(0).(func(string) []store.NamedRevision); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]store.NamedRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListAllNames provides a mock function with given fields:
 func (_m *Store) ListAllNames() ([]store.Named, error) {
 	ret := _m.Called()
@@ -1,4 +1,11 @@
-// Package store stores and retrieves fiddles and associated assets in Google Storage.
+// Package store stores and retrieves fiddles and associated assets in Google Storage, or in a local
+// directory for self-contained deployments.
+//
+// The local store records named fiddles and their history in a single JSON file which is locked
+// with flock(2) while it is read or rewritten, rather than in an embedded database such as SQLite,
+// as no SQLite driver is a dependency of this module and a cgo-based one would complicate the
+// build. The file is small, and names change rarely, so rewriting it on each change is cheap, and
+// the lock lets several fiddle processes share a store directory.
 package store
 
 import (
@@ -6,12 +13,14 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"path"
 	"reflect"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"cloud.google.com/go/storage"
 	lru "github.com/hashicorp/golang-lru"
@@ -50,6 +59,10 @@ const (
 	OFFSCREEN_SAMPLE_COUNT_METADATA = "offscreen_sample_count"
 	OFFSCREEN_TEXTURABLE_METADATA   = "offscreen_texturable"
 	OFFSCREEN_MIPMAP_METADATA       = "offscreen_mipmap"
+
+	// historyTimestampFormat is the format of the timestamps which name the
+	// revisions in gs://skia-fiddle/named_history/<name>/.
+	historyTimestampFormat = "20060102T150405.000000000Z"
 )
 
 // Media is the type of outputs we can get from running a fiddle.
@@ -155,6 +168,12 @@ type Store interface {
 	// ListAllNames returns the list of all named fiddles.
 	ListAllNames() ([]Named, error)
 
+	// GetNameHistory returns every revision of the named fiddle, i.e. each
+	// fiddle hash the name has pointed at, in chronological order.
+	//
+	//   name - The name of the fidde.
+	GetNameHistory(name string) ([]NamedRevision, error)
+
 	// GetHashFromName loads the fiddle hash for the given name.
 	GetHashFromName(name string) (string, error)
 
@@ -163,7 +182,9 @@ type Store interface {
 	//   name - The name of the fidde.
 	ValidName(name string) bool
 
-	// WriteName writes the name file for a named fiddle.
+	// WriteName writes the name file for a named fiddle. If the name is new or
+	// now points at a different hash then a new revision is recorded in the
+	// name's history.
 	//
 	//   name - The name of the fidde.
 	//   hash - The fiddle hash.
@@ -229,22 +250,32 @@ func New(ctx context.Context, local bool) (*store, error) {
 	}, nil
 }
 
-// writeMediaFile writes a file to Google Storage. It also adds it to the cache.
-//
-//	media - The type of the file to write.
-//	fiddleHash - The hash of the fiddle.
-//	b64 - The contents of the media file base64 encoded.
-func (s *store) writeMediaFile(media Media, fiddleHash, b64 string, wg *sync.WaitGroup) error {
+// decodeMediaFile validates and decodes the base64 encoded contents of a media file, and returns
+// them along with the props of the media.
+func decodeMediaFile(media Media, b64 string) (props, []byte, error) {
 	if b64 == "" && media != TXT {
-		return fmt.Errorf("An empty file is not a valid %s file.", string(media))
+		return props{}, nil, fmt.Errorf("An empty file is not a valid %s file.", string(media))
 	}
 	p := mediaProps[media]
 	if p.filename == "" {
-		return fmt.Errorf("Unknown media type.")
+		return props{}, nil, fmt.Errorf("Unknown media type.")
 	}
 	body, err := base64.StdEncoding.DecodeString(b64)
 	if err != nil {
-		return fmt.Errorf("Media wasn't properly encoded base64: %s", err)
+		return props{}, nil, fmt.Errorf("Media wasn't properly encoded base64: %s", err)
+	}
+	return p, body, nil
+}
+
+// writeMediaFile writes a file to Google Storage. It also adds it to the cache.
+//
+//	media - The type of the file to write.
+//	fiddleHash - The hash of the fiddle.
+//	b64 - The contents of the media file base64 encoded.
+func (s *store) writeMediaFile(media Media, fiddleHash, b64 string, wg *sync.WaitGroup) error {
+	p, body, err := decodeMediaFile(media, b64)
+	if err != nil {
+		return err
 	}
 
 	// Only PNGs get stored in the cache.
@@ -327,43 +358,33 @@ func (s *store) Put(code string, options types.Options, results *types.Result) (
 	return fiddleHash, nil
 }
 
+// mediaFile is a media file to be written, with its contents base64 encoded.
+type mediaFile struct {
+	media Media
+	b64   string
+}
+
+// mediaFiles returns the media files that should be written for the given results, in the order in
+// which they should be written. GLInfo is not included, as failing to write it is not an error.
+func mediaFiles(options types.Options, results *types.Result) []mediaFile {
+	output := results.Execute.Output
+	if options.TextOnly {
+		return []mediaFile{{TXT, output.Text}}
+	}
+	if options.Animated {
+		return []mediaFile{{ANIM_CPU, output.AnimatedRaster}, {ANIM_GPU, output.AnimatedGpu}}
+	}
+	return []mediaFile{{CPU, output.Raster}, {GPU, output.Gpu}, {PDF, output.Pdf}, {SKP, output.Skp}}
+}
+
 // PutMedia implements Store.
 func (s *store) PutMedia(options types.Options, fiddleHash string, results *types.Result) error {
 	// Write each of the media files.
 	var wg sync.WaitGroup
-	if options.TextOnly {
-		err := s.writeMediaFile(TXT, fiddleHash, results.Execute.Output.Text, &wg)
-		if err != nil {
+	for _, f := range mediaFiles(options, results) {
+		if err := s.writeMediaFile(f.media, fiddleHash, f.b64, &wg); err != nil {
 			return err
 		}
-	} else {
-		if options.Animated {
-			err := s.writeMediaFile(ANIM_CPU, fiddleHash, results.Execute.Output.AnimatedRaster, &wg)
-			if err != nil {
-				return err
-			}
-			err = s.writeMediaFile(ANIM_GPU, fiddleHash, results.Execute.Output.AnimatedGpu, &wg)
-			if err != nil {
-				return err
-			}
-		} else {
-			err := s.writeMediaFile(CPU, fiddleHash, results.Execute.Output.Raster, &wg)
-			if err != nil {
-				return err
-			}
-			err = s.writeMediaFile(GPU, fiddleHash, results.Execute.Output.Gpu, &wg)
-			if err != nil {
-				return err
-			}
-			err = s.writeMediaFile(PDF, fiddleHash, results.Execute.Output.Pdf, &wg)
-			if err != nil {
-				return err
-			}
-			err = s.writeMediaFile(SKP, fiddleHash, results.Execute.Output.Skp, &wg)
-			if err != nil {
-				return err
-			}
-		}
 	}
 	if results.Execute.Output.GLInfo != "" {
 		err := s.writeMediaFile(GLINFO, fiddleHash, results.Execute.Output.GLInfo, &wg)
@@ -506,6 +527,13 @@ type Named struct {
 	Status string // If a non-empty string then this named fiddle is broken and the string contains some information about the breakage.
 }
 
+// NamedRevision is a single revision of a named fiddle.
+type NamedRevision struct {
+	Hash      string    `json:"hash"`
+	User      string    `json:"user"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 // ListAllNames implements Store.
 func (s *store) ListAllNames() ([]Named, error) {
 	ret := []Named{}
@@ -557,6 +585,105 @@ func (s *store) GetHashFromName(name string) (string, error) {
 	return string(b), nil
 }
 
+// GetNameHistory implements Store.
+//
+// Revisions are stored as gs://skia-fiddle/named_history/<name>/<timestamp>,
+// which contain the fiddle hash. Names written before history was recorded
+// have a single revision, taken from the current name file, until they are
+// next written.
+func (s *store) GetNameHistory(name string) ([]NamedRevision, error) {
+	if !s.ValidName(name) {
+		return nil, fmt.Errorf("Invalid character found in name.")
+	}
+	ctx := context.Background()
+	ret := []NamedRevision{}
+	q := &storage.Query{
+		Prefix: fmt.Sprintf("named_history/%s/", name),
+	}
+	it := s.bucket.Objects(ctx, q)
+	for obj, err := it.Next(); err != iterator.Done; obj, err = it.Next() {
+		if err != nil {
+			return nil, fmt.Errorf("Failed to retrieve history of %q: %s", name, err)
+		}
+		ts, err := time.Parse(historyTimestampFormat, path.Base(obj.Name))
+		if err != nil {
+			ts = obj.Created
+		}
+		ret = append(ret, NamedRevision{
+			Hash:      obj.Metadata[HASH_METADATA],
+			User:      obj.Metadata[USER_METADATA],
+			Timestamp: ts,
+		})
+	}
+	if len(ret) == 0 {
+		current, err := s.currentNameRevision(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, fmt.Errorf("Failed to find named fiddle %q", name)
+		}
+		ret = append(ret, *current)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Timestamp.Before(ret[j].Timestamp)
+	})
+	return ret, nil
+}
+
+// currentNameRevision returns the revision held by the name file, or nil if
+// the name doesn't exist.
+func (s *store) currentNameRevision(ctx context.Context, name string) (*NamedRevision, error) {
+	attrs, err := s.bucket.Object(fmt.Sprintf("named/%s", name)).Attrs(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Failed to read attributes of named fiddle %q: %s", name, err)
+	}
+	hash := attrs.Metadata[HASH_METADATA]
+	if hash == "" {
+		if hash, err = s.GetHashFromName(name); err != nil {
+			return nil, err
+		}
+	}
+	return &NamedRevision{
+		Hash:      hash,
+		User:      attrs.Metadata[USER_METADATA],
+		Timestamp: attrs.Updated,
+	}, nil
+}
+
+// hasNameHistory returns true if any revisions of the name have been recorded.
+func (s *store) hasNameHistory(ctx context.Context, name string) (bool, error) {
+	it := s.bucket.Objects(ctx, &storage.Query{
+		Prefix: fmt.Sprintf("named_history/%s/", name),
+	})
+	_, err := it.Next()
+	if err == iterator.Done {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("Failed to retrieve history of %q: %s", name, err)
+	}
+	return true, nil
+}
+
+// revisionsToWrite returns the revisions to add to the history of a name when
+// it is changed to next. If the name already exists but has no history,
+// because it was written before history was recorded, its current revision is
+// recorded first so that it isn't lost.
+func revisionsToWrite(current *NamedRevision, hasHistory bool, next NamedRevision) []NamedRevision {
+	if current == nil {
+		return []NamedRevision{next}
+	}
+	if current.Hash == next.Hash {
+		return nil
+	}
+	if !hasHistory {
+		return []NamedRevision{*current, next}
+	}
+	return []NamedRevision{next}
+}
+
 // ValidName implements Store.
 func (s *store) ValidName(name string) bool {
 	return validName.MatchString(name)
@@ -568,6 +695,26 @@ func (s *store) WriteName(name, hash, user, status string) error {
 		return fmt.Errorf("Invalid character found in name.")
 	}
 	ctx := context.Background()
+	current, err := s.currentNameRevision(ctx, name)
+	if err != nil {
+		return err
+	}
+	hasHistory := false
+	if current != nil && current.Hash != hash {
+		if hasHistory, err = s.hasNameHistory(ctx, name); err != nil {
+			return err
+		}
+	}
+	next := NamedRevision{
+		Hash:      hash,
+		User:      user,
+		Timestamp: time.Now(),
+	}
+	for _, rev := range revisionsToWrite(current, hasHistory, next) {
+		if err := s.writeNameRevision(ctx, name, rev); err != nil {
+			return err
+		}
+	}
 	w := s.bucket.Object(fmt.Sprintf("named/%s", name)).NewWriter(ctx)
 	w.ObjectAttrs.Metadata = map[string]string{
 		USER_METADATA:   user,
@@ -583,6 +730,24 @@ func (s *store) WriteName(name, hash, user, status string) error {
 	return nil
 }
 
+// writeNameRevision records a revision in the history of a named fiddle. The
+// revision is named after its timestamp.
+func (s *store) writeNameRevision(ctx context.Context, name string, rev NamedRevision) error {
+	w := s.bucket.Object(fmt.Sprintf("named_history/%s/%s", name, rev.Timestamp.UTC().Format(historyTimestampFormat))).NewWriter(ctx)
+	w.ObjectAttrs.Metadata = map[string]string{
+		USER_METADATA: rev.User,
+		HASH_METADATA: rev.Hash,
+	}
+	if _, err := w.Write([]byte(rev.Hash)); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("Failed to write history of named file %q: %s", name, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("Failed to close after writing history of named file %q: %s", name, err)
+	}
+	return nil
+}
+
 // SetStatus implements Store.
 func (s *store) SetStatus(name, status string) error {
 	if !s.ValidName(name) {
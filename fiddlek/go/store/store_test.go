@@ -2,6 +2,7 @@ package store
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -11,3 +12,26 @@ func TestMedia(t *testing.T) {
 	assert.Equal(t, "pdf.pdf", mediaProps[PDF].filename)
 	assert.Equal(t, "abcd-GPU", cacheKey("abcd", GPU))
 }
+
+func TestRevisionsToWrite_NewName_WritesNextRevision(t *testing.T) {
+	next := NamedRevision{Hash: "def456", User: "bob@example.com", Timestamp: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, []NamedRevision{next}, revisionsToWrite(nil, false, next))
+}
+
+func TestRevisionsToWrite_SameHash_WritesNothing(t *testing.T) {
+	current := &NamedRevision{Hash: "abc123", User: "alice@example.com", Timestamp: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)}
+	next := NamedRevision{Hash: "abc123", User: "bob@example.com", Timestamp: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	assert.Empty(t, revisionsToWrite(current, false, next))
+}
+
+func TestRevisionsToWrite_NameWithoutHistoryUpdated_WritesCurrentRevisionFirst(t *testing.T) {
+	current := &NamedRevision{Hash: "abc123", User: "alice@example.com", Timestamp: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)}
+	next := NamedRevision{Hash: "def456", User: "bob@example.com", Timestamp: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, []NamedRevision{*current, next}, revisionsToWrite(current, false, next))
+}
+
+func TestRevisionsToWrite_NameWithHistoryUpdated_WritesNextRevision(t *testing.T) {
+	current := &NamedRevision{Hash: "abc123", User: "alice@example.com", Timestamp: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)}
+	next := NamedRevision{Hash: "def456", User: "bob@example.com", Timestamp: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, []NamedRevision{next}, revisionsToWrite(current, true, next))
+}
@@ -0,0 +1,2 @@
+import '../../infra-sk/modules/theme-chooser-sk';
+import '../../infra-sk/modules/app-sk';
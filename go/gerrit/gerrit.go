@@ -242,6 +242,7 @@ const RecipientBCC = "BCC"
 type cherryPickPostData struct {
 	Message     string `json:"message"`
 	Destination string `json:"destination"`
+	Base        string `json:"base,omitempty"`
 }
 
 // ChangeInfoMessage contains information about Gerrit messages.
@@ -443,6 +444,7 @@ type GerritInterface interface {
 	Config() *Config
 	CreateChange(context.Context, string, string, string, string, string) (*ChangeInfo, error)
 	CreateCherryPickChange(context.Context, string, string, string, string) (*ChangeInfo, error)
+	CreateCherryPickChangeOnBase(context.Context, string, string, string, string, string) (*ChangeInfo, error)
 	DeleteChangeEdit(context.Context, *ChangeInfo) error
 	DeleteFile(context.Context, *ChangeInfo, string) error
 	DeleteVote(context.Context, int64, string, int, NotifyOption, bool) error
@@ -1525,6 +1527,15 @@ func (g *Gerrit) CreateChange(ctx context.Context, project, branch, subject, bas
 //   - msg: Text to be added as a commit message.
 //   - destBranch: The cherry-pick destination branch.
 func (g *Gerrit) CreateCherryPickChange(ctx context.Context, changeID, revisionID, msg, destBranch string) (*ChangeInfo, error) {
+	return g.CreateCherryPickChangeOnBase(ctx, changeID, revisionID, msg, destBranch, "")
+}
+
+// CreateCherryPickChangeOnBase is like CreateCherryPickChange, but the
+// cherry-picked change is created on top of the given base commit instead of
+// the tip of the destination branch. The base commit must be reachable from
+// the destination branch or be the current patchset of an open change on it.
+// If base is empty then the tip of the destination branch is used.
+func (g *Gerrit) CreateCherryPickChangeOnBase(ctx context.Context, changeID, revisionID, msg, destBranch, base string) (*ChangeInfo, error) {
 	// Respect the rate limit.
 	if err := g.rl.Wait(ctx); err != nil {
 		return nil, skerr.Wrap(err)
@@ -1532,6 +1543,7 @@ func (g *Gerrit) CreateCherryPickChange(ctx context.Context, changeID, revisionI
 	c := cherryPickPostData{
 		Message:     msg,
 		Destination: destBranch,
+		Base:        base,
 	}
 	b, err := json.Marshal(c)
 	if err != nil {
@@ -829,3 +829,39 @@ func TestCreateCherryPickChange(t *testing.T) {
 	require.Equal(t, "myProject~release~I8473b95934b5732ac55d26311a706c9c2bde9941", ci.Id)
 	require.Equal(t, "I8473b95934b5732ac55d26311a706c9c2bde9941", ci.ChangeId)
 }
+
+func TestCreateCherryPickChangeOnBase(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/a/changes/myProject~123/revisions/current/cherrypick", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var data cherryPickPostData
+		require.NoError(t, json.Unmarshal(body, &data))
+
+		require.Equal(t, "main", data.Destination)
+		require.Equal(t, "Speculative pick", data.Message)
+		require.Equal(t, "674ac754f91e64a0efb8087e59a176484bd534d1", data.Base)
+		_, err = fmt.Fprint(w, `)]}'
+{
+  "id": "myProject~main~I8473b95934b5732ac55d26311a706c9c2bde9941",
+  "project": "myProject",
+  "branch": "main",
+  "change_id": "I8473b95934b5732ac55d26311a706c9c2bde9941",
+  "subject": "Speculative pick",
+  "status": "NEW",
+  "_number": 3966
+}`+"\n")
+		require.NoError(t, err)
+	}))
+
+	defer ts.Close()
+
+	api, err := NewGerritWithConfig(ConfigChromium, ts.URL, c)
+	require.NoError(t, err)
+	ci, err := api.CreateCherryPickChangeOnBase(context.Background(), "myProject~123", "current", "Speculative pick", "main", "674ac754f91e64a0efb8087e59a176484bd534d1")
+	require.NoError(t, err)
+	require.Equal(t, int64(3966), ci.Issue)
+	require.Equal(t, "main", ci.Branch)
+}
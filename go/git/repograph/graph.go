@@ -14,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/willf/bitset"
 
 	"go.skia.org/infra/go/git"
@@ -27,6 +28,10 @@ import (
 
 var (
 	ErrStopRecursing = errors.New("Stop recursing")
+
+	// ErrChangedFilesNotSupported is returned by Graph.ChangedFiles when the
+	// Graph has no way to determine which files were changed by a commit.
+	ErrChangedFilesNotSupported = errors.New("Changed files are not supported for this repo")
 )
 
 // Commit represents a commit in a Git repo.
@@ -205,6 +210,50 @@ type RepoImpl interface {
 	UpdateCallback(context.Context, []*vcsinfo.LongCommit, []*vcsinfo.LongCommit, *Graph) error
 }
 
+// ChangedFilesGetter provides the paths of the files which were changed by a
+// given commit, relative to its first parent. A RepoImpl may optionally
+// implement ChangedFilesGetter, or a ChangedFilesGetter may be provided via
+// Graph.SetChangedFilesGetter.
+type ChangedFilesGetter interface {
+	ChangedFiles(ctx context.Context, hash string) ([]string, error)
+}
+
+// changedFilesCacheSize is the maximum number of commits whose changed files
+// are cached. Callers are generally only interested in recent commits, eg. the
+// ones in the Task Scheduler's scheduling window, so older commits are evicted
+// first.
+const changedFilesCacheSize = 10000
+
+// changedFilesCache caches the results of a ChangedFilesGetter. Commits are
+// immutable, so cached results never need to be invalidated.
+type changedFilesCache struct {
+	getter ChangedFilesGetter
+	files  *lru.Cache // Thread-safe.
+	mtx    sync.Mutex // Protects getter and files.
+}
+
+// newLRU returns an empty cache for changedFilesCache.
+func newLRU() *lru.Cache {
+	rv, err := lru.New(changedFilesCacheSize)
+	if err != nil {
+		// This only happens if the size is not positive.
+		panic(err)
+	}
+	return rv
+}
+
+// newChangedFilesCache returns a changedFilesCache which uses the given
+// RepoImpl, if it implements ChangedFilesGetter.
+func newChangedFilesCache(ri RepoImpl) *changedFilesCache {
+	rv := &changedFilesCache{
+		files: newLRU(),
+	}
+	if getter, ok := ri.(ChangedFilesGetter); ok {
+		rv.getter = getter
+	}
+	return rv
+}
+
 // Graph represents an entire Git repo.
 type Graph struct {
 	branches []*git.Branch
@@ -213,6 +262,8 @@ type Graph struct {
 
 	updateMtx sync.Mutex
 	repoImpl  RepoImpl
+
+	changedFiles *changedFilesCache
 }
 
 // NewWithRepoImpl returns a Graph instance which uses the given RepoImpl. The
@@ -220,8 +271,9 @@ type Graph struct {
 // passed to NewWithRepoImpl.
 func NewWithRepoImpl(ctx context.Context, ri RepoImpl) (*Graph, error) {
 	rv := &Graph{
-		commits:  map[string]*Commit{},
-		repoImpl: ri,
+		commits:      map[string]*Commit{},
+		repoImpl:     ri,
+		changedFiles: newChangedFilesCache(ri),
 	}
 	if _, _, err := rv.updateFrom(ctx, rv.repoImpl); err != nil {
 		return nil, err
@@ -252,6 +304,7 @@ func NewFromGob(ctx context.Context, r io.Reader, ri RepoImpl) (*Graph, error) {
 	}
 	// Swap to the passed-in RepoImpl.
 	rv.repoImpl = ri
+	rv.changedFiles = newChangedFilesCache(ri)
 	return rv, nil
 }
 
@@ -603,9 +656,46 @@ func (r *Graph) shallowCopy() *Graph {
 		})
 	}
 	return &Graph{
-		branches: newBranches,
-		commits:  newCommits,
+		branches:     newBranches,
+		commits:      newCommits,
+		changedFiles: r.changedFiles,
+	}
+}
+
+// SetChangedFilesGetter sets the ChangedFilesGetter used by ChangedFiles,
+// overriding the RepoImpl if it implements ChangedFilesGetter.
+func (r *Graph) SetChangedFilesGetter(getter ChangedFilesGetter) {
+	r.changedFiles.mtx.Lock()
+	defer r.changedFiles.mtx.Unlock()
+	r.changedFiles.getter = getter
+	r.changedFiles.files = newLRU()
+}
+
+// ChangedFiles returns the paths of the files which were changed by the given
+// commit, relative to its first parent. Returns ErrChangedFilesNotSupported if
+// there is no ChangedFilesGetter for this Graph.
+func (r *Graph) ChangedFiles(ctx context.Context, hash string) ([]string, error) {
+	if r.changedFiles == nil {
+		return nil, ErrChangedFilesNotSupported
+	}
+	r.changedFiles.mtx.Lock()
+	getter := r.changedFiles.getter
+	cache := r.changedFiles.files
+	r.changedFiles.mtx.Unlock()
+	if getter == nil {
+		return nil, ErrChangedFilesNotSupported
+	}
+	if files, ok := cache.Get(hash); ok {
+		return files.([]string), nil
+	}
+	// Don't hold the lock while retrieving the files, since this may involve
+	// a slow network request.
+	files, err := getter.ChangedFiles(ctx, hash)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to retrieve changed files for %s", hash)
 	}
+	cache.Add(hash, files)
+	return files, nil
 }
 
 // Get returns a Commit object for the given ref, if such a commit exists. This
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 
 	"go.skia.org/infra/go/git"
 	"go.skia.org/infra/go/skerr"
@@ -118,6 +119,30 @@ func (r *localRepoImpl) Branches(ctx context.Context) ([]*git.Branch, error) {
 	return r.branches, nil
 }
 
+// See documentation for ChangedFilesGetter interface.
+func (r *localRepoImpl) ChangedFiles(ctx context.Context, hash string) ([]string, error) {
+	details, err := r.Details(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	var output string
+	if len(details.Parents) == 0 {
+		output, err = r.Repo.Git(ctx, "diff-tree", "--no-commit-id", "--name-only", "-r", "--root", hash)
+	} else {
+		output, err = r.Repo.Git(ctx, "diff", "--name-only", details.Parents[0], hash)
+	}
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	rv := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			rv = append(rv, line)
+		}
+	}
+	return rv, nil
+}
+
 // See documentation for RepoImpl interface.
 func (r *localRepoImpl) UpdateCallback(ctx context.Context, _, _ []*vcsinfo.LongCommit, g *Graph) error {
 	sklog.Infof("  Writing cache file...")
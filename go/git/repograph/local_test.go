@@ -82,3 +82,27 @@ func TestBranchMembershipRepo(t *testing.T) {
 	defer cleanup()
 	shared_tests.TestBranchMembership(t, ctx, g, repo, ud)
 }
+
+func TestChangedFiles_LocalRepo_ReturnsFilesChangedSinceFirstParent(t *testing.T) {
+	ctx := context.Background()
+	g := git_testutils.GitInit(t, ctx)
+	defer g.Cleanup()
+	c1 := g.CommitGen(ctx, "a.txt")
+	g.Add(ctx, "dir/b.txt", "b")
+	g.Add(ctx, "c.txt", "c")
+	c2 := g.Commit(ctx)
+
+	tmp, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer testutils.RemoveAll(t, tmp)
+	repo, err := repograph.NewLocalGraph(ctx, g.Dir(), tmp)
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(ctx))
+
+	files, err := repo.ChangedFiles(ctx, c1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.txt"}, files)
+	files, err = repo.ChangedFiles(ctx, c2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"c.txt", "dir/b.txt"}, files)
+}
@@ -89,3 +89,91 @@ func TestBranchMembershipMem(t *testing.T) {
 	defer cleanup()
 	shared_tests.TestBranchMembership(t, ctx, g, repo, ud)
 }
+
+// fakeChangedFilesGetter is a ChangedFilesGetter backed by a map.
+type fakeChangedFilesGetter struct {
+	files map[string][]string
+	calls int
+}
+
+func (f *fakeChangedFilesGetter) ChangedFiles(_ context.Context, hash string) ([]string, error) {
+	f.calls++
+	return f.files[hash], nil
+}
+
+func TestChangedFiles_Mem_NoGetter_ReturnsNotSupported(t *testing.T) {
+	ctx, _, repo, _, cleanup := setupMem(t)
+	defer cleanup()
+
+	_, err := repo.ChangedFiles(ctx, "abc123")
+	require.Equal(t, repograph.ErrChangedFilesNotSupported, err)
+}
+
+func TestChangedFiles_Mem_SetChangedFilesGetter_ResultsCached(t *testing.T) {
+	ctx, _, repo, _, cleanup := setupMem(t)
+	defer cleanup()
+
+	getter := &fakeChangedFilesGetter{
+		files: map[string][]string{
+			"abc123": {"README.md"},
+		},
+	}
+	repo.SetChangedFilesGetter(getter)
+	for i := 0; i < 2; i++ {
+		files, err := repo.ChangedFiles(ctx, "abc123")
+		require.NoError(t, err)
+		require.Equal(t, []string{"README.md"}, files)
+	}
+	require.Equal(t, 1, getter.calls)
+
+	// Updates swap in a new copy of the Graph internally; the getter and its
+	// cached results should be retained.
+	require.NoError(t, repo.Update(ctx))
+	_, err := repo.ChangedFiles(ctx, "abc123")
+	require.NoError(t, err)
+	require.Equal(t, 1, getter.calls)
+}
+
+// blockingChangedFilesGetter is a ChangedFilesGetter which blocks retrieving
+// the files changed by blockHash until unblock is closed.
+type blockingChangedFilesGetter struct {
+	blockHash string
+	started   chan struct{}
+	unblock   chan struct{}
+}
+
+func (f *blockingChangedFilesGetter) ChangedFiles(_ context.Context, hash string) ([]string, error) {
+	if hash == f.blockHash {
+		close(f.started)
+		<-f.unblock
+	}
+	return []string{hash + ".txt"}, nil
+}
+
+func TestChangedFiles_Mem_SlowGetter_DoesNotBlockOtherCommits(t *testing.T) {
+	ctx, _, repo, _, cleanup := setupMem(t)
+	defer cleanup()
+
+	getter := &blockingChangedFilesGetter{
+		blockHash: "abc123",
+		started:   make(chan struct{}),
+		unblock:   make(chan struct{}),
+	}
+	repo.SetChangedFilesGetter(getter)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		files, err := repo.ChangedFiles(ctx, "abc123")
+		require.NoError(t, err)
+		require.Equal(t, []string{"abc123.txt"}, files)
+	}()
+	<-getter.started
+
+	// The first call is still in progress, but this one should not wait for it.
+	files, err := repo.ChangedFiles(ctx, "def456")
+	require.NoError(t, err)
+	require.Equal(t, []string{"def456.txt"}, files)
+
+	close(getter.unblock)
+	<-done
+}
@@ -17,6 +17,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -45,6 +46,9 @@ const (
 
 	AUTOSUBMIT_LABEL = "autosubmit"
 
+	// The max number of results to request per page from list APIs.
+	LIST_PER_PAGE = 100
+
 	CHECK_STATE_SUCCESS         = "success"
 	CHECK_STATE_CANCELLED       = "cancelled"
 	CHECK_STATE_FAILURE         = "failure"
@@ -62,6 +66,9 @@ const (
 var (
 	OPEN_STATE   = "open"
 	CLOSED_STATE = "closed"
+
+	// ErrNotFound is returned by ReadFileAtRef when the file does not exist.
+	ErrNotFound = errors.New("NOT_FOUND")
 )
 
 // Check encapsulates the different Github checks (Cirrus/Travis/etc).
@@ -243,8 +250,17 @@ func (g *GitHub) CreatePullRequest(title, baseBranch, headBranch, body string) (
 // See https://developer.github.com/v3/pulls/#merge-a-pull-request-merge-button
 // for the API documentation.
 func (g *GitHub) MergePullRequest(pullRequestNum int, msg, mergeMethod string) error {
+	return g.MergePullRequestAtSHA(pullRequestNum, msg, mergeMethod, "")
+}
+
+// MergePullRequestAtSHA is like MergePullRequest, but the merge fails unless
+// the head of the pull request is the specified SHA. This ensures that commits
+// pushed after the pull request was verified are not merged. If sha is empty
+// then the head of the pull request is not checked.
+func (g *GitHub) MergePullRequestAtSHA(pullRequestNum int, msg, mergeMethod, sha string) error {
 	options := &github.PullRequestOptions{
 		MergeMethod: mergeMethod,
+		SHA:         sha,
 	}
 	_, resp, err := g.client.PullRequests.Merge(g.ctx, g.RepoOwner, g.RepoName, pullRequestNum, msg, options)
 	if err != nil {
@@ -350,10 +366,10 @@ func (g *GitHub) RemoveLabel(pullRequestNum int, oldLabel string) error {
 	if err != nil {
 		return fmt.Errorf("Error when getting labels for %d: %s", pullRequestNum, err)
 	}
-	// Remove the specified label.
+	// Remove the specified label. GitHub label names are case-insensitive.
 	newLabels := []string{}
 	for _, l := range existingLabels {
-		if l != oldLabel {
+		if !strings.EqualFold(l, oldLabel) {
 			newLabels = append(newLabels, l)
 		}
 	}
@@ -379,10 +395,10 @@ func (g *GitHub) ReplaceLabel(pullRequestNum int, oldLabel, newLabel string) err
 	if err != nil {
 		return fmt.Errorf("Error when getting labels for %d: %s", pullRequestNum, err)
 	}
-	// Remove the specified label.
+	// Remove the specified label. GitHub label names are case-insensitive.
 	newLabels := []string{}
 	for _, l := range existingLabels {
-		if l != oldLabel {
+		if !strings.EqualFold(l, oldLabel) {
 			newLabels = append(newLabels, l)
 		}
 	}
@@ -490,6 +506,153 @@ func (g *GitHub) GetChecks(ref string) ([]*Check, error) {
 	return totalChecks, nil
 }
 
+// See https://developer.github.com/v3/pulls/#list-commits-on-a-pull-request
+// for the API documentation.
+// Note: This returns at most 250 commits which is a limitation of the API.
+func (g *GitHub) ListCommits(pullRequestNum int) ([]*github.RepositoryCommit, error) {
+	opts := &github.ListOptions{PerPage: LIST_PER_PAGE}
+	allCommits := []*github.RepositoryCommit{}
+	for {
+		commits, resp, err := g.client.PullRequests.ListCommits(g.ctx, g.RepoOwner, g.RepoName, pullRequestNum, opts)
+		if err != nil {
+			return nil, fmt.Errorf("Failed doing pullrequests.listcommits: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Unexpected status code %d from pullrequests.listcommits.", resp.StatusCode)
+		}
+		allCommits = append(allCommits, commits...)
+		if resp.NextPage == 0 {
+			return allCommits, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// See https://developer.github.com/v3/pulls/#list-pull-requests-files
+// for the API documentation.
+func (g *GitHub) ListFiles(pullRequestNum int) ([]string, error) {
+	files, err := g.ListPullRequestFiles(pullRequestNum)
+	if err != nil {
+		return nil, err
+	}
+	fileNames := make([]string, 0, len(files))
+	for _, f := range files {
+		fileNames = append(fileNames, f.GetFilename())
+	}
+	return fileNames, nil
+}
+
+// See https://developer.github.com/v3/pulls/#list-pull-requests-files
+// for the API documentation.
+// Unlike ListFiles this returns the status of each file, eg: "removed".
+func (g *GitHub) ListPullRequestFiles(pullRequestNum int) ([]*github.CommitFile, error) {
+	opts := &github.ListOptions{PerPage: LIST_PER_PAGE}
+	allFiles := []*github.CommitFile{}
+	for {
+		files, resp, err := g.client.PullRequests.ListFiles(g.ctx, g.RepoOwner, g.RepoName, pullRequestNum, opts)
+		if err != nil {
+			return nil, fmt.Errorf("Failed doing pullrequests.listfiles: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Unexpected status code %d from pullrequests.listfiles.", resp.StatusCode)
+		}
+		allFiles = append(allFiles, files...)
+		if resp.NextPage == 0 {
+			return allFiles, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// See https://developer.github.com/v3/pulls/reviews/#list-reviews-on-a-pull-request
+// for the API documentation.
+func (g *GitHub) ListReviews(pullRequestNum int) ([]*github.PullRequestReview, error) {
+	opts := &github.ListOptions{PerPage: LIST_PER_PAGE}
+	allReviews := []*github.PullRequestReview{}
+	for {
+		reviews, resp, err := g.client.PullRequests.ListReviews(g.ctx, g.RepoOwner, g.RepoName, pullRequestNum, opts)
+		if err != nil {
+			return nil, fmt.Errorf("Failed doing pullrequests.listreviews: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Unexpected status code %d from pullrequests.listreviews.", resp.StatusCode)
+		}
+		allReviews = append(allReviews, reviews...)
+		if resp.NextPage == 0 {
+			return allReviews, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// See https://developer.github.com/v3/issues/comments/#list-comments-on-an-issue
+// for the API documentation.
+func (g *GitHub) ListComments(issueNum int) ([]*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: LIST_PER_PAGE}}
+	allComments := []*github.IssueComment{}
+	for {
+		comments, resp, err := g.client.Issues.ListComments(g.ctx, g.RepoOwner, g.RepoName, issueNum, opts)
+		if err != nil {
+			return nil, fmt.Errorf("Failed doing issues.listcomments: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Unexpected status code %d from issues.listcomments.", resp.StatusCode)
+		}
+		allComments = append(allComments, comments...)
+		if resp.NextPage == 0 {
+			return allComments, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// See https://developer.github.com/v3/issues/events/#list-events-for-an-issue
+// for the API documentation.
+func (g *GitHub) ListIssueEvents(issueNum int) ([]*github.IssueEvent, error) {
+	opts := &github.ListOptions{PerPage: LIST_PER_PAGE}
+	allEvents := []*github.IssueEvent{}
+	for {
+		events, resp, err := g.client.Issues.ListIssueEvents(g.ctx, g.RepoOwner, g.RepoName, issueNum, opts)
+		if err != nil {
+			return nil, fmt.Errorf("Failed doing issues.listissueevents: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Unexpected status code %d from issues.listissueevents.", resp.StatusCode)
+		}
+		allEvents = append(allEvents, events...)
+		if resp.NextPage == 0 {
+			return allEvents, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// See https://developer.github.com/v3/repos/contents/#get-contents
+// for the API documentation.
+// Unlike ReadRawFile this supports any ref (eg: "refs/pull/123/head") and
+// returns ErrNotFound if the file does not exist at the ref.
+func (g *GitHub) ReadFileAtRef(filePath, ref string) ([]byte, error) {
+	opts := &github.RepositoryContentGetOptions{Ref: ref}
+	fileContent, _, resp, err := g.client.Repositories.GetContents(g.ctx, g.RepoOwner, g.RepoName, filePath, opts)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed doing repos.getcontents: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected status code %d from repos.getcontents.", resp.StatusCode)
+	}
+	if fileContent == nil {
+		return nil, fmt.Errorf("%s at %s is not a file", filePath, ref)
+	}
+	contents, err := fileContent.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("Could not decode %s at %s: %s", filePath, ref, err)
+	}
+	return []byte(contents), nil
+}
+
 // See https://developer.github.com/v3/issues/#get-a-single-issue
 // for the API documentation.
 func (g *GitHub) GetDescription(pullRequestNum int) (string, error) {
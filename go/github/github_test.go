@@ -216,6 +216,24 @@ func TestMergePullRequest(t *testing.T) {
 	require.NoError(t, mergePullErr)
 }
 
+func TestMergePullRequestAtSHA(t *testing.T) {
+	reqType := "application/json"
+	reqBody := []byte(`{"commit_message":"test comment","merge_method":"squash","sha":"abc123"}
+`)
+	r := chi.NewRouter()
+	md := mockhttpclient.MockPutDialogue(reqType, reqBody, nil)
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Put("/repos/kryptonians/krypton/pulls/1234/merge", md.ServeHTTP)
+	httpClient := mockhttpclient.NewMuxClient(r)
+
+	githubClient, err := NewGitHub(context.Background(), "kryptonians", "krypton", httpClient)
+	require.NoError(t, err)
+	mergePullErr := githubClient.MergePullRequestAtSHA(1234, "test comment", "squash", "abc123")
+	require.NoError(t, mergePullErr)
+}
+
 func TestClosePullRequest(t *testing.T) {
 	respBody := []byte(testutils.MarshalJSON(t, &github.PullRequest{State: &CLOSED_STATE}))
 	reqType := "application/json"
@@ -343,6 +361,37 @@ func TestRemoveLabelRequest(t *testing.T) {
 	require.NoError(t, removeLabelErr1)
 }
 
+func TestRemoveLabelRequest_DifferentCase_LabelRemoved(t *testing.T) {
+	label1Name := "test1"
+	label2Name := "Test2"
+	label1 := github.Label{Name: &label1Name}
+	label2 := github.Label{Name: &label2Name}
+	respBody := []byte(testutils.MarshalJSON(t, &github.PullRequest{Labels: []*github.Label{&label1, &label2}}))
+	r := chi.NewRouter()
+	md := mockhttpclient.MockGetDialogue(respBody)
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Get("/repos/kryptonians/krypton/issues/1234", md.ServeHTTP)
+
+	patchRespBody := []byte(testutils.MarshalJSON(t, &github.PullRequest{}))
+	patchReqType := "application/json"
+	patchReqBody := []byte(`{"labels":["test1"]}
+`)
+	patchMd := mockhttpclient.MockPatchDialogue(patchReqType, patchReqBody, patchRespBody)
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Patch("/repos/kryptonians/krypton/issues/1234", patchMd.ServeHTTP)
+
+	httpClient := mockhttpclient.NewMuxClient(r)
+
+	githubClient, err := NewGitHub(context.Background(), "kryptonians", "krypton", httpClient)
+	require.NoError(t, err)
+	removeLabelErr := githubClient.RemoveLabel(1234, "test2")
+	require.NoError(t, removeLabelErr)
+}
+
 func TestReplaceLabelRequest(t *testing.T) {
 	label1Name := "test1"
 	label2Name := "test2"
@@ -461,6 +510,136 @@ func TestGetDescription(t *testing.T) {
 	require.Equal(t, body, desc)
 }
 
+func TestListFiles(t *testing.T) {
+	f1 := "dir/file1.go"
+	f2 := "file2.md"
+	respBody := []byte(testutils.MarshalJSON(t, []*github.CommitFile{
+		{Filename: &f1},
+		{Filename: &f2},
+	}))
+	r := chi.NewRouter()
+	md := mockhttpclient.MockGetDialogue(respBody)
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Get("/repos/kryptonians/krypton/pulls/1234/files", md.ServeHTTP)
+	httpClient := mockhttpclient.NewMuxClient(r)
+
+	githubClient, err := NewGitHub(context.Background(), "kryptonians", "krypton", httpClient)
+	require.NoError(t, err)
+	files, err := githubClient.ListFiles(1234)
+	require.NoError(t, err)
+	require.Equal(t, []string{f1, f2}, files)
+}
+
+func TestListPullRequestFiles(t *testing.T) {
+	f1 := "dir/file1.go"
+	f2 := "file2.md"
+	removed := "removed"
+	respBody := []byte(testutils.MarshalJSON(t, []*github.CommitFile{
+		{Filename: &f1},
+		{Filename: &f2, Status: &removed},
+	}))
+	r := chi.NewRouter()
+	md := mockhttpclient.MockGetDialogue(respBody)
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Get("/repos/kryptonians/krypton/pulls/1234/files", md.ServeHTTP)
+	httpClient := mockhttpclient.NewMuxClient(r)
+
+	githubClient, err := NewGitHub(context.Background(), "kryptonians", "krypton", httpClient)
+	require.NoError(t, err)
+	files, err := githubClient.ListPullRequestFiles(1234)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, f1, files[0].GetFilename())
+	require.Equal(t, removed, files[1].GetStatus())
+}
+
+func TestListComments(t *testing.T) {
+	body := "Looks good"
+	respBody := []byte(testutils.MarshalJSON(t, []*github.IssueComment{
+		{Body: &body},
+	}))
+	r := chi.NewRouter()
+	md := mockhttpclient.MockGetDialogue(respBody)
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Get("/repos/kryptonians/krypton/issues/1234/comments", md.ServeHTTP)
+	httpClient := mockhttpclient.NewMuxClient(r)
+
+	githubClient, err := NewGitHub(context.Background(), "kryptonians", "krypton", httpClient)
+	require.NoError(t, err)
+	comments, err := githubClient.ListComments(1234)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.Equal(t, body, comments[0].GetBody())
+}
+
+func TestListReviews(t *testing.T) {
+	approved := "APPROVED"
+	login := "superman"
+	respBody := []byte(testutils.MarshalJSON(t, []*github.PullRequestReview{
+		{State: &approved, User: &github.User{Login: &login}},
+	}))
+	r := chi.NewRouter()
+	md := mockhttpclient.MockGetDialogue(respBody)
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Get("/repos/kryptonians/krypton/pulls/1234/reviews", md.ServeHTTP)
+	httpClient := mockhttpclient.NewMuxClient(r)
+
+	githubClient, err := NewGitHub(context.Background(), "kryptonians", "krypton", httpClient)
+	require.NoError(t, err)
+	reviews, err := githubClient.ListReviews(1234)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	require.Equal(t, approved, reviews[0].GetState())
+	require.Equal(t, login, reviews[0].GetUser().GetLogin())
+}
+
+func TestReadFileAtRef(t *testing.T) {
+	fileType := "file"
+	encoding := "base64"
+	content := "YWJjZA==" // "abcd"
+	respBody := []byte(testutils.MarshalJSON(t, &github.RepositoryContent{
+		Type:     &fileType,
+		Encoding: &encoding,
+		Content:  &content,
+	}))
+	r := chi.NewRouter()
+	md := mockhttpclient.MockGetDialogue(respBody)
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Get("/repos/kryptonians/krypton/contents/path/to/this.txt", md.ServeHTTP)
+	httpClient := mockhttpclient.NewMuxClient(r)
+
+	githubClient, err := NewGitHub(context.Background(), "kryptonians", "krypton", httpClient)
+	require.NoError(t, err)
+	contents, err := githubClient.ReadFileAtRef("path/to/this.txt", "refs/pull/1234/head")
+	require.NoError(t, err)
+	require.Equal(t, "abcd", string(contents))
+}
+
+func TestReadFileAtRef_NotFound_ReturnsErrNotFound(t *testing.T) {
+	r := chi.NewRouter()
+	md := mockhttpclient.MockGetError("Not Found", http.StatusNotFound)
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Get("/repos/kryptonians/krypton/contents/path/to/this.txt", md.ServeHTTP)
+	httpClient := mockhttpclient.NewMuxClient(r)
+
+	githubClient, err := NewGitHub(context.Background(), "kryptonians", "krypton", httpClient)
+	require.NoError(t, err)
+	_, err = githubClient.ReadFileAtRef("path/to/this.txt", "main")
+	require.Equal(t, ErrNotFound, err)
+}
+
 func TestReadRawFileRequest(t *testing.T) {
 	respBody := []byte(`abcd`)
 	r := chi.NewRouter()
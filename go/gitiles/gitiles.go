@@ -430,6 +430,27 @@ func (r *Repo) GetTreeDiffs(ctx context.Context, ref string) ([]*TreeDiff, error
 	return c.TreeDiffs, nil
 }
 
+// ChangedFiles returns the sorted paths of the files which were changed by the
+// given commit, relative to its first parent. For renames and copies, both the
+// old and new paths are included. Implements repograph.ChangedFilesGetter.
+func (r *Repo) ChangedFiles(ctx context.Context, ref string) ([]string, error) {
+	treeDiffs, err := r.GetTreeDiffs(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	files := util.StringSet{}
+	for _, td := range treeDiffs {
+		for _, path := range []string{td.OldPath, td.NewPath} {
+			if path != "" && path != "dev/null" && path != "/dev/null" {
+				files[path] = true
+			}
+		}
+	}
+	rv := files.Keys()
+	sort.Strings(rv)
+	return rv, nil
+}
+
 // LogOption represents an optional parameter to a Log function. Either Key()
 // AND Value() OR Path() must return non-empty strings. Only one LogOption in
 // a given set may return a non-empty value for Path().
@@ -491,6 +491,11 @@ func TestGetTreeDiffs(t *testing.T) {
 	require.Equal(t, "delete", treeDiffs[1].Type)
 	require.Equal(t, "test/go/test2.go", treeDiffs[1].OldPath)
 	require.Equal(t, "dev/null", treeDiffs[1].NewPath)
+
+	urlMock.MockOnce(fmt.Sprintf(CommitURLJSON, repoURL, "my/other/ref"), mockhttpclient.MockGetDialogue([]byte(resp)))
+	files, err := repo.ChangedFiles(ctx, "my/other/ref")
+	require.NoError(t, err)
+	require.Equal(t, []string{"test/go/test.go", "test/go/test2.go"}, files)
 }
 
 func TestListDir(t *testing.T) {
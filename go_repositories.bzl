@@ -3257,8 +3257,8 @@ def go_repositories():
     go_repository(
         name = "org_golang_x_image",
         importpath = "golang.org/x/image",
-        sum = "h1:hVwzHzIUGRjiF7EcUjqNxk3NCfkPxbDKRdnNE1Rpg0U=",
-        version = "v0.0.0-20191009234506-e7c1f5e7dbb8",
+        sum = "h1:5JMiNunQeQw++mMOz48/ISeNu3Iweh/JaZU8ZLqHRrI=",
+        version = "v0.5.0",
     )
 
     go_repository(
@@ -90,7 +90,7 @@ Add images generated by the tests to the test results. This requires two argumen
 	env.addCommonFlags(imgTestAddCmd, true)
 	env.addKeysFlags(imgTestAddCmd, "add-test-" /* =flagsPrefix */)
 	imgTestAddCmd.Flags().StringVar(&env.testName, "test-name", "", "Unique name of the test, must not contain spaces.")
-	imgTestAddCmd.Flags().StringVar(&env.pngFile, "png-file", "", "Path to the PNG file that contains the test results. png-file or png-digest must be provided")
+	imgTestAddCmd.Flags().StringVar(&env.pngFile, "png-file", "", "Path to the image file that contains the test results. PNG (8 or 16 bits per channel), WebP and RawF16 images are supported. png-file or png-digest must be provided")
 	imgTestAddCmd.Flags().StringVar(&env.pngDigest, "png-digest", "", "If provided, will be used as the digest for the given image. If omitted, an md5 hash of the pixel content will be done and used.")
 
 	must(imgTestAddCmd.MarkFlagRequired("test-name"))
@@ -117,7 +117,7 @@ Does not upload anything nor queue anything for upload.`,
 	env.addKeysFlags(imgTestCheckCmd, "" /* =flagsPrefix */)
 	imgTestCheckCmd.Flags().StringVar(&env.workDir, fstrWorkDir, "", "Work directory for intermediate results")
 	imgTestCheckCmd.Flags().StringVar(&env.testName, "test-name", "", "Unique name of the test, must not contain spaces.")
-	imgTestCheckCmd.Flags().StringVar(&env.pngFile, "png-file", "", "Path to the PNG file that contains the test results.")
+	imgTestCheckCmd.Flags().StringVar(&env.pngFile, "png-file", "", "Path to the image file that contains the test results. PNG (8 or 16 bits per channel), WebP and RawF16 images are supported.")
 	imgTestCheckCmd.Flags().StringVar(&env.instanceID, "instance", "", "ID of the Gold instance.")
 
 	imgTestCheckCmd.Flags().StringVar(&env.bucketOverride, "bucket", "", "GCS Bucket to use. If empty the URL will be derived from the value of 'instance'")
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"image"
 	"image/png"
@@ -19,6 +18,7 @@ import (
 	"go.skia.org/infra/gold-client/go/imgmatching/positive_if_only_image"
 	"go.skia.org/infra/gold-client/go/imgmatching/sample_area"
 	"go.skia.org/infra/gold-client/go/imgmatching/sobel"
+	"go.skia.org/infra/golden/go/image/imgformat"
 )
 
 // matchEnv provides the environment for the match command.
@@ -61,9 +61,9 @@ func (m *matchEnv) runMatchCmd(cmd *cobra.Command, args []string) {
 // Match instantiates the specified image matching algorithm and runs it against two images.
 func (m *matchEnv) Match(ctx context.Context, leftFile, rightFile string) {
 	// Load input images.
-	leftImage, err := loadPng(leftFile)
+	leftImage, err := loadImage(leftFile)
 	ifErrLogExit(ctx, err)
-	rightImage, err := loadPng(rightFile)
+	rightImage, err := loadImage(rightFile)
 	ifErrLogExit(ctx, err)
 
 	// Instantiate the specified algorithm.
@@ -100,16 +100,15 @@ func (m *matchEnv) Match(ctx context.Context, leftFile, rightFile string) {
 	exitProcess(ctx, 0)
 }
 
-// loadPng loads a PNG image from disk.
-func loadPng(fileName string) (image.Image, error) {
-	// Load the image and save the bytes because we need to return them.
+// loadImage loads an image in any of the formats supported by imgformat from disk.
+func loadImage(fileName string) (image.Image, error) {
 	imgBytes, err := os.ReadFile(fileName)
 	if err != nil {
 		return nil, skerr.Wrapf(err, "loading file %s", fileName)
 	}
-	img, err := png.Decode(bytes.NewReader(imgBytes))
+	img, _, err := imgformat.Decode(imgBytes)
 	if err != nil {
-		return nil, skerr.Wrapf(err, "decoding PNG file %s", fileName)
+		return nil, skerr.Wrapf(err, "decoding image file %s", fileName)
 	}
 	return img, nil
 }
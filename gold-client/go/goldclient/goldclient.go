@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"image"
+	"image/color"
+	"image/draw"
 	"image/png"
 	"math"
 	"net/url"
@@ -26,6 +29,7 @@ import (
 	"go.skia.org/infra/gold-client/go/imgmatching"
 	"go.skia.org/infra/golden/go/diff"
 	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/jsonio"
 	"go.skia.org/infra/golden/go/tiling"
 	"go.skia.org/infra/golden/go/types"
@@ -222,22 +226,61 @@ func LoadCloudClient(workDir string) (*CloudClient, error) {
 }
 
 // loadAndHashImage loads an image from disk and hashes the internal Pixel buffer. It returns
-// the bytes of the encoded image and the MD5 hash of the pixels as hex encoded string.
+// the bytes of the encoded image and the MD5 hash of the pixels as hex encoded string. The image
+// may be in any of the formats supported by imgformat.
 func loadAndHashImage(fileName string) ([]byte, types.Digest, error) {
 	// Load the image and save the bytes because we need to return them.
 	imgBytes, err := os.ReadFile(fileName)
 	if err != nil {
 		return nil, "", skerr.Wrapf(err, "loading file %s", fileName)
 	}
-	img, err := png.Decode(bytes.NewReader(imgBytes))
+	img, _, err := imgformat.Decode(imgBytes)
 	if err != nil {
-		return nil, "", skerr.Wrapf(err, "decoding PNG in file %s", fileName)
+		return nil, "", skerr.Wrapf(err, "decoding image in file %s", fileName)
+	}
+	return imgBytes, hashPixels(img), nil
+}
+
+// hashPixels returns the MD5 hash of the pixels of the given image. 8-bit images are hashed as
+// NRGBA, which is how Gold has always computed digests. Images with more precision than that,
+// i.e. 16-bit PNGs and half-float images, are hashed at full precision so that differences below
+// 8 bits per channel result in different digests.
+func hashPixels(img image.Image) types.Digest {
+	var s [md5.Size]byte
+	if f, ok := img.(*imgformat.FloatImage); ok {
+		c := imgformat.NewChannels(f)
+		buf := make([]byte, 4*len(c.Pix))
+		for i, v := range c.Pix {
+			binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(v))
+		}
+		s = md5.Sum(buf)
+	} else if is16Bit(img) {
+		s = md5.Sum(getNRGBA64(img).Pix)
+	} else {
+		s = md5.Sum(diff.GetNRGBA(img).Pix)
+	}
+	return types.Digest(hex.EncodeToString(s[:]))
+}
+
+// is16Bit returns true if the given image has 16 bits per channel.
+func is16Bit(img image.Image) bool {
+	switch img.ColorModel() {
+	case color.RGBA64Model, color.NRGBA64Model, color.Gray16Model:
+		return true
+	}
+	return false
+}
+
+// getNRGBA64 converts the given 16-bit image to NRGBA64, whose Pix holds each channel as two
+// big-endian bytes.
+func getNRGBA64(img image.Image) *image.NRGBA64 {
+	if n, ok := img.(*image.NRGBA64); ok {
+		return n
 	}
-	nrgbaImg := diff.GetNRGBA(img)
-	// hash it
-	s := md5.Sum(nrgbaImg.Pix)
-	md5Hash := hex.EncodeToString(s[:])
-	return imgBytes, types.Digest(md5Hash), nil
+	b := img.Bounds()
+	n := image.NewNRGBA64(b)
+	draw.Draw(n, b, img, b.Min, draw.Src)
+	return n
 }
 
 // SetSharedConfig implements the GoldClient interface.
@@ -309,8 +352,12 @@ func (c *CloudClient) addTest(ctx context.Context, name types.TestName, imgFileN
 		}
 	}
 
-	// Add the result of this test.
-	traceParams, traceID := c.addResult(name, imgDigest, additionalKeys, optionalKeys)
+	// Add the result of this test. If only a digest was supplied, we assume it is a PNG.
+	ext := imgformat.PNG
+	if f := imgformat.Sniff(imgBytes); f != imgformat.Unknown {
+		ext = f
+	}
+	traceParams, traceID := c.addResult(name, imgDigest, ext, additionalKeys, optionalKeys)
 
 	// Check that the trace params include the keys needed by the corpus' grouping, and fail early
 	// if they do not.
@@ -335,7 +382,7 @@ func (c *CloudClient) addTest(ctx context.Context, name types.TestName, imgFileN
 	// Check against known hashes and upload if needed.
 	if !c.resultState.KnownHashes[imgDigest] && imgBytes != nil {
 		egroup.Go(func() error {
-			gcsImagePath := c.resultState.getGCSImagePath(imgDigest)
+			gcsImagePath := c.resultState.getGCSImagePath(imgDigest, ext)
 			if err := uploader.UploadBytes(ctx, imgBytes, imgFileName, gcsImagePath); err != nil {
 				return skerr.Fmt("Error uploading image %s to %s. Got: %s", imgFileName, gcsImagePath, err)
 			}
@@ -520,10 +567,10 @@ func (c *CloudClient) matchImageAgainstBaseline(ctx context.Context, testName ty
 		return false, "", skerr.Fmt("Must supply the image if using a non-exact matching algorithm")
 	}
 
-	// Decode test output PNG image.
-	img, err := png.Decode(bytes.NewReader(imageBytes))
+	// Decode test output image.
+	img, _, err := imgformat.Decode(imageBytes)
 	if err != nil {
-		return false, "", skerr.Wrapf(err, "decoding PNG image")
+		return false, "", skerr.Wrapf(err, "decoding image")
 	}
 
 	// Fetch the most recent positive digest.
@@ -572,17 +619,17 @@ func (c *CloudClient) getResultStatePath() string {
 }
 
 // addResult adds the given test to the overall results and returns the params and ID of the
-// affected trace.
-func (c *CloudClient) addResult(name types.TestName, imgHash types.Digest, additionalKeys, optionalKeys map[string]string) (paramtools.Params, tiling.TraceIDV2) {
+// affected trace. ext is the format of the image that produced imgHash.
+func (c *CloudClient) addResult(name types.TestName, imgHash types.Digest, ext imgformat.Format, additionalKeys, optionalKeys map[string]string) (paramtools.Params, tiling.TraceIDV2) {
 	resultKey, traceParams, traceID := c.makeResultKeyAndTraceParamsAndID(name, additionalKeys)
 
 	newResult := jsonio.Result{
 		Digest: imgHash,
 		Key:    resultKey,
 
-		// We need to specify this is a png, otherwise the backend will refuse
+		// We need to specify the image format, otherwise the backend will refuse
 		// to ingest it.
-		Options: map[string]string{"ext": "png"},
+		Options: map[string]string{"ext": string(ext)},
 	}
 	for k, v := range optionalKeys {
 		newResult.Options[k] = v
@@ -663,14 +710,14 @@ func (c *CloudClient) Diff(ctx context.Context, grouping paramtools.Params, imgF
 		return skerr.Wrapf(err, "reading input %s", imgFileName)
 	}
 
-	origFilePath := filepath.Join(outDir, fmt.Sprintf("input-%s.png", inputDigest))
-	if err := os.WriteFile(origFilePath, b, 0644); err != nil {
-		return skerr.Wrapf(err, "writing to %s", origFilePath)
+	leftImg, inputFormat, err := imgformat.Decode(b)
+	if err != nil {
+		return skerr.Wrapf(err, "decoding %s", imgFileName)
 	}
 
-	leftImg, err := png.Decode(bytes.NewReader(b))
-	if err != nil {
-		return skerr.Wrapf(err, "reading %s as png", origFilePath)
+	origFilePath := filepath.Join(outDir, fmt.Sprintf("input-%s.%s", inputDigest, inputFormat))
+	if err := os.WriteFile(origFilePath, b, 0644); err != nil {
+		return skerr.Wrapf(err, "writing to %s", origFilePath)
 	}
 
 	// 2) Check JSON endpoint digests to download
@@ -690,7 +737,7 @@ func (c *CloudClient) Diff(ctx context.Context, grouping paramtools.Params, imgF
 		return nil
 	}
 
-	infof(ctx, "Going to compare %s.png against %d other images\n", inputDigest, len(dlr.Digests))
+	infof(ctx, "Going to compare %s.%s against %d other images\n", inputDigest, inputFormat, len(dlr.Digests))
 
 	// 3a) Download those from bucket (or use from working directory cache). We download them with
 	//    the same credentials that let us upload them.
@@ -722,7 +769,7 @@ func (c *CloudClient) Diff(ctx context.Context, grouping paramtools.Params, imgF
 	infof(ctx, "Digest %s was closest (combined metric of %f)\n", closestRightDigest, smallestCombined)
 
 	// 4) Write closest image and the diff to that image to the output directory.
-	o := filepath.Join(outDir, fmt.Sprintf("closest-%s.png", closestRightDigest))
+	o := filepath.Join(outDir, fmt.Sprintf("closest-%s.%s", closestRightDigest, imgformat.Sniff(closestRightImg)))
 	if err := os.WriteFile(o, closestRightImg, 0644); err != nil {
 		return skerr.Wrapf(err, "writing closest image to %s", o)
 	}
@@ -748,8 +795,8 @@ func urlEncode(p map[string]string) string {
 	return values.Encode()
 }
 
-// getDigestFromCacheOrGCS downloads from GCS the PNG file corresponding to the given digest, and
-// returns the decoded image.Image and raw PNG file as a byte slice.
+// getDigestFromCacheOrGCS downloads from GCS the image file corresponding to the given digest, and
+// returns the decoded image.Image and raw image file as a byte slice.
 //
 // The downloaded image is cached on disk. Subsequent calls for the same digest will load the
 // cached image from disk.
@@ -774,10 +821,9 @@ func (c *CloudClient) getDigestFromCacheOrGCS(ctx context.Context, digest types.
 		downloader := extractImageDownloader(ctx)
 
 		// Download digest.
-		digestGcsPath := c.resultState.getGCSImagePath(digest)
 		digestBytes, err = downloader.DownloadImage(ctx, c.resultState.GoldURL, digest)
 		if err != nil {
-			return nil, nil, skerr.Wrapf(err, "downloading %s", digestGcsPath)
+			return nil, nil, skerr.Wrapf(err, "downloading digest %s", digest)
 		}
 
 		// Cache digest.
@@ -786,10 +832,10 @@ func (c *CloudClient) getDigestFromCacheOrGCS(ctx context.Context, digest types.
 		}
 	}
 
-	// Decode PNG file.
-	img, err := png.Decode(bytes.NewReader(digestBytes))
+	// Decode image file.
+	img, _, err := imgformat.Decode(digestBytes)
 	if err != nil {
-		return nil, nil, skerr.Wrapf(err, "decoding PNG file at %s", digestPath)
+		return nil, nil, skerr.Wrapf(err, "decoding image file at %s", digestPath)
 	}
 
 	return img, digestBytes, nil
@@ -3,10 +3,12 @@ package goldclient
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"image"
+	"image/color"
 	"image/png"
 	"io"
 	"net/http"
@@ -30,6 +32,7 @@ import (
 	"go.skia.org/infra/gold-client/go/mocks"
 	"go.skia.org/infra/golden/go/diff"
 	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/image/text"
 	"go.skia.org/infra/golden/go/jsonio"
 	"go.skia.org/infra/golden/go/sql"
@@ -259,7 +262,7 @@ func TestAddResult_Success(t *testing.T) {
 		},
 	}
 
-	traceParams, traceID := goldClient.addResult("my_test", "9d0568469d206c1aedf1b71f12f474bc", map[string]string{"gamma": "delta"}, map[string]string{"epsilon": "zeta"})
+	traceParams, traceID := goldClient.addResult("my_test", "9d0568469d206c1aedf1b71f12f474bc", imgformat.PNG, map[string]string{"gamma": "delta"}, map[string]string{"epsilon": "zeta"})
 	assert.Equal(t, paramtools.Params{
 		types.CorpusField: "my_corpus",
 		"name":            "my_test",
@@ -300,7 +303,7 @@ func TestAddResult_NoCorpusSpecified_UsesInstanceIdAsCorpus_Success(t *testing.T
 		},
 	}
 
-	traceParams, traceID := goldClient.addResult("my_test", "9d0568469d206c1aedf1b71f12f474bc", map[string]string{"gamma": "delta"}, map[string]string{"epsilon": "zeta"})
+	traceParams, traceID := goldClient.addResult("my_test", "9d0568469d206c1aedf1b71f12f474bc", imgformat.PNG, map[string]string{"gamma": "delta"}, map[string]string{"epsilon": "zeta"})
 	assert.Equal(t, paramtools.Params{
 		types.CorpusField: "my_instance",
 		"name":            "my_test",
@@ -2253,7 +2256,7 @@ func TestCloudClient_GetDigestFromCacheOrGCS_NotInCache_DownloadsCorruptedImageF
 
 	_, _, err = goldClient.getDigestFromCacheOrGCS(ctx, digest)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "decoding PNG file at "+filepath.Join(wd, digestsDirectory, string(digest))+".png")
+	assert.Contains(t, err.Error(), "decoding image file at "+filepath.Join(wd, digestsDirectory, string(digest))+".png")
 }
 
 func TestCloudClient_GetDigestFromCacheOrGCS_InCache_ReadsImageFromDisk_Success(t *testing.T) {
@@ -2309,7 +2312,7 @@ func TestCloudClient_GetDigestFromCacheOrGCS_InCache_ReadsCorruptedImageFromDisk
 
 	_, _, err = goldClient.getDigestFromCacheOrGCS(ctx, digest)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "decoding PNG file at "+filepath.Join(wd, digestsDirectory, string(digest))+".png")
+	assert.Contains(t, err.Error(), "decoding image file at "+filepath.Join(wd, digestsDirectory, string(digest))+".png")
 }
 
 func TestCloudClient_Whoami_Success(t *testing.T) {
@@ -2783,3 +2786,54 @@ func openNRGBAFromFile(fileName string) (*image.NRGBA, error) {
 	}
 	return img, nil
 }
+
+func TestHashPixels_EightBitImage_HashesNRGBAPixels(t *testing.T) {
+	img := text.MustToNRGBA(one_by_five.ImageOne)
+	s := md5.Sum(img.Pix)
+	assert.Equal(t, types.Digest(hex.EncodeToString(s[:])), hashPixels(img))
+}
+
+func TestHashPixels_SixteenBitImage_HashesNRGBA64Pixels(t *testing.T) {
+	img := image.NewNRGBA64(image.Rect(0, 0, 1, 1))
+	img.SetNRGBA64(0, 0, color.NRGBA64{R: 0x1234, A: 0xffff})
+	s := md5.Sum([]byte{0x12, 0x34, 0, 0, 0, 0, 0xff, 0xff})
+	assert.Equal(t, types.Digest(hex.EncodeToString(s[:])), hashPixels(img))
+}
+
+func TestHashPixels_SixteenBitImages_DifferentBelowEightBits_DifferentDigests(t *testing.T) {
+	img1 := image.NewRGBA64(image.Rect(0, 0, 1, 1))
+	img1.SetRGBA64(0, 0, color.RGBA64{R: 0x1200, A: 0xffff})
+	img2 := image.NewRGBA64(image.Rect(0, 0, 1, 1))
+	img2.SetRGBA64(0, 0, color.RGBA64{R: 0x1201, A: 0xffff})
+	assert.NotEqual(t, hashPixels(img1), hashPixels(img2))
+}
+
+func TestHashPixels_SixteenBitGrayImage_SameDigestAsNRGBA64(t *testing.T) {
+	gray := image.NewGray16(image.Rect(0, 0, 1, 1))
+	gray.SetGray16(0, 0, color.Gray16{Y: 0x1234})
+	nrgba := image.NewNRGBA64(image.Rect(0, 0, 1, 1))
+	nrgba.SetNRGBA64(0, 0, color.NRGBA64{R: 0x1234, G: 0x1234, B: 0x1234, A: 0xffff})
+	assert.Equal(t, hashPixels(nrgba), hashPixels(gray))
+}
+
+func TestHashPixels_HalfFloatImages_DifferentBelowEightBits_DifferentDigests(t *testing.T) {
+	img1 := imgformat.NewFloatImage(image.Rect(0, 0, 1, 1))
+	img1.SetFloat(0, 0, [4]float32{0.5, 0, 0, 1})
+	img2 := imgformat.NewFloatImage(image.Rect(0, 0, 1, 1))
+	img2.SetFloat(0, 0, [4]float32{0.5001, 0, 0, 1})
+	assert.NotEqual(t, hashPixels(img1), hashPixels(img2))
+}
+
+func TestLoadAndHashImage_HalfFloatImage_Success(t *testing.T) {
+	img := imgformat.NewFloatImage(image.Rect(0, 0, 1, 1))
+	img.SetFloat(0, 0, [4]float32{2, 1, 0.5, 1})
+	var buf bytes.Buffer
+	require.NoError(t, imgformat.EncodeRawF16(&buf, img))
+	p := filepath.Join(t.TempDir(), "image.f16")
+	require.NoError(t, os.WriteFile(p, buf.Bytes(), 0644))
+
+	b, digest, err := loadAndHashImage(p)
+	require.NoError(t, err)
+	assert.Equal(t, buf.Bytes(), b)
+	assert.Equal(t, hashPixels(img), digest)
+}
@@ -12,6 +12,7 @@ import (
 	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/jsonio"
 	"go.skia.org/infra/golden/go/types"
 	"go.skia.org/infra/golden/go/web/frontend"
@@ -197,9 +198,10 @@ func (r *resultState) getResultFilePath(ctx context.Context) string {
 	return fmt.Sprintf("%s/%s", r.Bucket, path)
 }
 
-// getGCSImagePath returns the path in GCS where the image with the given hash should be stored.
-func (r *resultState) getGCSImagePath(imgHash types.Digest) string {
-	return fmt.Sprintf("gs://%s/%s/%s.png", r.Bucket, imagePrefix, imgHash)
+// getGCSImagePath returns the path in GCS where the image with the given hash and format should
+// be stored. The file extension is the format, e.g. "<hash>.webp".
+func (r *resultState) getGCSImagePath(imgHash types.Digest, format imgformat.Format) string {
+	return fmt.Sprintf("gs://%s/%s/%s.%s", r.Bucket, imagePrefix, imgHash, format)
 }
 
 // loadStateFromJSON loads a serialization of a resultState instance that was previously written
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/jsonio"
 
 	"github.com/stretchr/testify/assert"
@@ -49,3 +50,10 @@ func TestGetResultFilePath_Success(t *testing.T) {
 	}, "/trybot/dm-json-v1/2022/01/02/03/680_00112233445566778899aabbccddeeff_0/765432/dm-1641092645000000067.json")
 
 }
+
+func TestGetGCSImagePath_UsesFormatAsExtension(t *testing.T) {
+	rs := resultState{Bucket: "my-bucket"}
+	assert.Equal(t, "gs://my-bucket/dm-images-v1/00000000000000000000000000000001.png", rs.getGCSImagePath("00000000000000000000000000000001", imgformat.PNG))
+	assert.Equal(t, "gs://my-bucket/dm-images-v1/00000000000000000000000000000001.webp", rs.getGCSImagePath("00000000000000000000000000000001", imgformat.WebP))
+	assert.Equal(t, "gs://my-bucket/dm-images-v1/00000000000000000000000000000001.f16", rs.getGCSImagePath("00000000000000000000000000000001", imgformat.RawF16))
+}
@@ -2,13 +2,15 @@ package exact
 
 import (
 	"image"
-	"image/draw"
+
+	"go.skia.org/infra/golden/go/image/imgformat"
 )
 
 // Matcher is an image matching algorithm.
 //
 // It implements exact matching. That is, two images match if they are are the same size, and if
-// the pixel found at each (x, y) coordinate is identical on both images.
+// the pixel found at each (x, y) coordinate is identical on both images. Images with more than 8
+// bits per channel are compared at full precision.
 type Matcher struct {
 	// Debug information about the last pair of matched images.
 	lastDifferentPixelFound *image.Point
@@ -21,19 +23,17 @@ func (m *Matcher) Match(expected, actual image.Image) bool {
 		return false
 	}
 
-	// Convert both images to NRGBA.
+	// Read the channel values of both images without quantizing them to 8 bits.
 	bounds := expected.Bounds()
-	expectedNRGBA := image.NewNRGBA(bounds)
-	actualNRGBA := image.NewNRGBA(bounds)
-	draw.Draw(expectedNRGBA, bounds, expected, bounds.Min, draw.Src)
-	draw.Draw(actualNRGBA, bounds, actual, bounds.Min, draw.Src)
+	expectedChannels := imgformat.NewChannels(expected)
+	actualChannels := imgformat.NewChannels(actual)
 
 	// Iterate over and compare all pixels.
 	m.lastDifferentPixelFound = nil
 	for x := bounds.Min.X; x < bounds.Max.X; x++ {
 		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-			p1 := expectedNRGBA.NRGBAAt(x, y)
-			p2 := actualNRGBA.NRGBAAt(x, y)
+			p1 := expectedChannels.At(x, y)
+			p2 := actualChannels.At(x, y)
 			if p1 != p2 {
 				m.lastDifferentPixelFound = &image.Point{X: x, Y: y}
 				return false
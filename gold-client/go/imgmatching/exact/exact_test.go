@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/image/text"
 )
 
@@ -31,6 +32,18 @@ func TestMatcher_DifferentImages_ReturnsFalse(t *testing.T) {
 	testDifferentImages(t, "one pixel different", image3x3White, image3x3WhiteWithOnePixelBlack, &image.Point{X: 1, Y: 1})
 }
 
+func TestMatcher_FloatImagesDifferingBelowEightBits_ReturnsFalse(t *testing.T) {
+	img1 := imgformat.NewFloatImage(image.Rect(0, 0, 1, 1))
+	img2 := imgformat.NewFloatImage(image.Rect(0, 0, 1, 1))
+	img1.SetFloat(0, 0, [4]float32{2, 0.5, 0, 1})
+	img2.SetFloat(0, 0, [4]float32{2, 0.5001, 0, 1})
+
+	matcher := Matcher{}
+	assert.False(t, matcher.Match(img1, img2))
+	assert.Equal(t, &image.Point{X: 0, Y: 0}, matcher.LastDifferentPixelFound())
+	assert.True(t, matcher.Match(img1, img1))
+}
+
 func testDifferentImages(t *testing.T, name, inputImage1, inputImage2 string, lastDifferentPixelFound *image.Point) {
 	img1 := text.MustToNRGBA(inputImage1)
 	img2 := text.MustToNRGBA(inputImage2)
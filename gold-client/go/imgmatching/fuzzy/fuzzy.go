@@ -2,8 +2,9 @@ package fuzzy
 
 import (
 	"image"
-	"image/draw"
 	"math"
+
+	"go.skia.org/infra/golden/go/image/imgformat"
 )
 
 // Matcher is an image matching algorithm.
@@ -23,7 +24,9 @@ import (
 // that is intentional, consider using exact matching instead (e.g. by not specifying the
 // image_matching_algorithm optional key).
 //
-// This algorithm assumes 8-bit channels.
+// Deltas are measured in 8-bit steps. Images with more than 8 bits per channel are compared at
+// full precision, with deltas rounded up to the next 8-bit step (see imgformat.Delta). Thus, a
+// difference too small to survive quantization to 8 bits still counts as a delta of 1.
 //
 // Valid PixelDeltaThreshold values are 0 to 1020 inclusive (0 <= d{R,G,B,A} <= 255, thus
 // 0 <= dR + dG + dB + dA <= 255*4 = 1020).
@@ -59,12 +62,10 @@ func (m *Matcher) Match(expected, actual image.Image) bool {
 		usePerChannelThreshold = true
 	}
 
-	// Convert both images to NRGBA.
+	// Read the channel values of both images without quantizing them to 8 bits.
 	bounds := expected.Bounds()
-	expectedNRGBA := image.NewNRGBA(bounds)
-	actualNRGBA := image.NewNRGBA(bounds)
-	draw.Draw(expectedNRGBA, bounds, expected, bounds.Min, draw.Src)
-	draw.Draw(actualNRGBA, bounds, actual, bounds.Min, draw.Src)
+	expectedChannels := imgformat.NewChannels(expected)
+	actualChannels := imgformat.NewChannels(actual)
 
 	// Reset counters.
 	m.actualNumDifferentPixels = 0
@@ -74,8 +75,8 @@ func (m *Matcher) Match(expected, actual image.Image) bool {
 	b := m.IgnoredBorderThickness
 	for x := (bounds.Min.X + b); x < (bounds.Max.X - b); x++ {
 		for y := (bounds.Min.Y + b); y < (bounds.Max.Y - b); y++ {
-			p1 := expectedNRGBA.NRGBAAt(x, y)
-			p2 := actualNRGBA.NRGBAAt(x, y)
+			p1 := expectedChannels.At(x, y)
+			p2 := actualChannels.At(x, y)
 
 			// Track number of different pixels.
 			if p1 != p2 {
@@ -83,14 +84,18 @@ func (m *Matcher) Match(expected, actual image.Image) bool {
 			}
 
 			// Track maximum pixel-wise difference.
+			dR := imgformat.Delta(p1[0], p2[0])
+			dG := imgformat.Delta(p1[1], p2[1])
+			dB := imgformat.Delta(p1[2], p2[2])
+			dA := imgformat.Delta(p1[3], p2[3])
 			var pixelDelta int
 			if usePerChannelThreshold {
-				pixelDelta = absDiff(p1.R, p2.R)
-				pixelDelta = int(math.Max(float64(pixelDelta), float64(absDiff(p1.G, p2.G))))
-				pixelDelta = int(math.Max(float64(pixelDelta), float64(absDiff(p1.B, p2.B))))
-				pixelDelta = int(math.Max(float64(pixelDelta), float64(absDiff(p1.A, p2.A))))
+				pixelDelta = dR
+				pixelDelta = int(math.Max(float64(pixelDelta), float64(dG)))
+				pixelDelta = int(math.Max(float64(pixelDelta), float64(dB)))
+				pixelDelta = int(math.Max(float64(pixelDelta), float64(dA)))
 			} else {
-				pixelDelta = absDiff(p1.R, p2.R) + absDiff(p1.G, p2.G) + absDiff(p1.B, p2.B) + absDiff(p1.A, p2.A)
+				pixelDelta = dR + dG + dB + dA
 			}
 			if pixelDelta > m.actualMaxPixelDelta {
 				m.actualMaxPixelDelta = pixelDelta
@@ -131,12 +136,3 @@ func (m *Matcher) PixelComparisonMethod() string {
 	}
 	return "pixel per-channel delta threshold"
 }
-
-// absDiff takes two uint8 values m and n, computes |m - n|, and converts the result into an int
-// suitable for addition without the risk of overflowing.
-func absDiff(m, n uint8) int {
-	if m > n {
-		return int(m - n)
-	}
-	return int(n - m)
-}
@@ -2,6 +2,7 @@ package fuzzy
 
 import (
 	"image"
+	"image/color"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1173,3 +1174,44 @@ func TestMatcher_NonZeroIgnoredBorderThickness_Success(t *testing.T) {
 		}
 	})
 }
+
+func TestMatcher_SixteenBitImages_DifferencesBelowEightBitsCounted(t *testing.T) {
+	image1 := image.NewNRGBA64(image.Rect(0, 0, 2, 1))
+	image2 := image.NewNRGBA64(image.Rect(0, 0, 2, 1))
+	image1.SetNRGBA64(0, 0, color.NRGBA64{R: 0x1234, A: 0xffff})
+	image2.SetNRGBA64(0, 0, color.NRGBA64{R: 0x1235, A: 0xffff})
+	image1.SetNRGBA64(1, 0, color.NRGBA64{G: 0x1000, A: 0xffff})
+	image2.SetNRGBA64(1, 0, color.NRGBA64{G: 0x1000, A: 0xffff})
+
+	runTestCases(t, []testCase{
+		{
+			name:                       "differences too small for 8 bits, counted as a delta of 1",
+			image1:                     image1,
+			image2:                     image2,
+			expectedToMatch:            true,
+			expectedNumDifferentPixels: 1,
+			expectedMaxPixelDelta:      1,
+		},
+	}, func() Matcher {
+		return Matcher{
+			MaxDifferentPixels:  1,
+			PixelDeltaThreshold: 1,
+		}
+	})
+
+	runTestCases(t, []testCase{
+		{
+			name:                       "exceeds max different pixels, returns false",
+			image1:                     image1,
+			image2:                     image2,
+			expectedToMatch:            false,
+			expectedNumDifferentPixels: 1,
+			expectedMaxPixelDelta:      1,
+		},
+	}, func() Matcher {
+		return Matcher{
+			MaxDifferentPixels:  0,
+			PixelDeltaThreshold: 1,
+		}
+	})
+}
@@ -3,7 +3,8 @@ package sample_area
 import (
 	"fmt"
 	"image"
-	"image/draw"
+
+	"go.skia.org/infra/golden/go/image/imgformat"
 )
 
 // Matcher is a non-exact image matching algorithm.
@@ -76,11 +77,10 @@ func (m *Matcher) Match(expected, actual image.Image) bool {
 		return false
 	}
 
-	// Convert the images to NRGBA.
-	expectedNRGBA := image.NewNRGBA(bounds)
-	actualNRGBA := image.NewNRGBA(bounds)
-	draw.Draw(expectedNRGBA, bounds, expected, bounds.Min, draw.Src)
-	draw.Draw(actualNRGBA, bounds, actual, bounds.Min, draw.Src)
+	// Read the channel values of both images without quantizing them to 8 bits. Deltas are
+	// measured in 8-bit steps, rounded up (see imgformat.Delta).
+	expectedChannels := imgformat.NewChannels(expected)
+	actualChannels := imgformat.NewChannels(actual)
 
 	// Iterate over each sample area and compare.
 	for x := bounds.Min.X; x <= bounds.Max.X-m.SampleAreaWidth; x++ {
@@ -88,13 +88,13 @@ func (m *Matcher) Match(expected, actual image.Image) bool {
 			numDifferentPixels := 0
 			for xOffset := 0; xOffset < m.SampleAreaWidth; xOffset++ {
 				for yOffset := 0; yOffset < m.SampleAreaWidth; yOffset++ {
-					expectedPixel := expectedNRGBA.NRGBAAt(x+xOffset, y+yOffset)
-					actualPixel := actualNRGBA.NRGBAAt(x+xOffset, y+yOffset)
+					expectedPixel := expectedChannels.At(x+xOffset, y+yOffset)
+					actualPixel := actualChannels.At(x+xOffset, y+yOffset)
 					if expectedPixel != actualPixel {
-						rDiff := absDiff(expectedPixel.R, actualPixel.R)
-						gDiff := absDiff(expectedPixel.G, actualPixel.G)
-						bDiff := absDiff(expectedPixel.B, actualPixel.B)
-						aDiff := absDiff(expectedPixel.A, actualPixel.A)
+						rDiff := imgformat.Delta(expectedPixel[0], actualPixel[0])
+						gDiff := imgformat.Delta(expectedPixel[1], actualPixel[1])
+						bDiff := imgformat.Delta(expectedPixel[2], actualPixel[2])
+						aDiff := imgformat.Delta(expectedPixel[3], actualPixel[3])
 						if rDiff > m.SampleAreaChannelDeltaThreshold ||
 							gDiff > m.SampleAreaChannelDeltaThreshold ||
 							bDiff > m.SampleAreaChannelDeltaThreshold ||
@@ -144,12 +144,3 @@ func (m *Matcher) MaxDifferentPixelsPerAreaOutOfRange() bool {
 func (m *Matcher) SampleAreaChannelDeltaThresholdOutOfRange() bool {
 	return m.sampleAreaChannelDeltaThresholdOutOfRange
 }
-
-// absDiff takes two uint8 values m and n, computes |m - n|, and converts the result into an int
-// suitable for addition without the risk of overflowing.
-func absDiff(m, n uint8) int {
-	if m > n {
-		return int(m - n)
-	}
-	return int(n - m)
-}
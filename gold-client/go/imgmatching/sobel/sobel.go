@@ -7,6 +7,7 @@ import (
 	"math"
 
 	"go.skia.org/infra/gold-client/go/imgmatching/fuzzy"
+	"go.skia.org/infra/golden/go/image/imgformat"
 )
 
 // testMatcher is an exact copy of the imgmatching.Matcher interface for the sole purpose of
@@ -159,7 +160,25 @@ func zeroOutEdges(img image.Image, edges *image.Gray, edgeThreshold uint8) image
 		panic("input and edges images must have the same bounds")
 	}
 
-	outputImg := image.NewNRGBA(img.Bounds())
+	// Keep the precision of the input image, so that the fuzzy matcher can compare the images at
+	// full precision.
+	if floatImg, ok := img.(*imgformat.FloatImage); ok {
+		outputImg := imgformat.NewFloatImage(floatImg.Rect)
+		outputImg.White = floatImg.White
+		copy(outputImg.Pix, floatImg.Pix)
+		for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+			for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+				if edges.GrayAt(x, y).Y > edgeThreshold {
+					outputImg.SetFloat(x, y, [4]float32{0, 0, 0, 1})
+				}
+			}
+		}
+		return outputImg
+	}
+	var outputImg draw.Image = image.NewNRGBA(img.Bounds())
+	if imgformat.IsHighPrecision(img) {
+		outputImg = image.NewNRGBA64(img.Bounds())
+	}
 
 	// Iterate over all pixels.
 	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
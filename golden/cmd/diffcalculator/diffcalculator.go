@@ -5,10 +5,8 @@ package main
 import (
 	"context"
 	"flag"
-	"io"
 	"math/rand"
 	"net/http"
-	"path"
 	"sync/atomic"
 	"time"
 
@@ -26,12 +24,12 @@ import (
 	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
-	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/golden/go/config"
 	"go.skia.org/infra/golden/go/diff"
 	"go.skia.org/infra/golden/go/diff/worker"
 	"go.skia.org/infra/golden/go/sql"
 	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/storage"
 	"go.skia.org/infra/golden/go/tracing"
 	"go.skia.org/infra/golden/go/types"
 )
@@ -40,9 +38,6 @@ const (
 	// An arbitrary amount.
 	maxSQLConnections = 20
 
-	// The GCS folder that contains the images, named by their digests.
-	imgFolder = "dm-images-v1"
-
 	calculateCLDataProportion = 0.8
 
 	primaryBranchStalenessThreshold = time.Minute
@@ -228,16 +223,7 @@ type gcsImageDownloader struct {
 
 // GetImage downloads the image with the corresponding digest (name) from GCS.
 func (g *gcsImageDownloader) GetImage(ctx context.Context, digest types.Digest) ([]byte, error) {
-	// intentionally using path because gcs is forward slashes
-	imgPath := path.Join(imgFolder, string(digest)+".png")
-	r, err := g.client.Bucket(g.bucket).Object(imgPath).NewReader(ctx)
-	if err != nil {
-		// If not image not found, this error path will be taken.
-		return nil, skerr.Wrap(err)
-	}
-	defer util.Close(r)
-	b, err := io.ReadAll(r)
-	return b, skerr.Wrap(err)
+	return storage.ReadImage(ctx, g.client.Bucket(g.bucket), digest)
 }
 
 type processor struct {
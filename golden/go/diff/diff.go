@@ -12,6 +12,7 @@ import (
 	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/types"
 )
 
@@ -79,7 +80,7 @@ type DiffMetrics struct {
 }
 
 // ComputeDiffMetrics computes and returns the diff metrics between two given images.
-func ComputeDiffMetrics(leftImg *image.NRGBA, rightImg *image.NRGBA) *DiffMetrics {
+func ComputeDiffMetrics(leftImg, rightImg image.Image) *DiffMetrics {
 	defer metrics2.FuncTimer().Stop()
 	ret, _ := PixelDiff(leftImg, rightImg)
 	ret.CombinedMetric = CombinedDiffMetric(ret.MaxRGBADiffs, ret.PixelDiffPercent)
@@ -187,9 +188,13 @@ func GetNRGBA(img image.Image) *image.NRGBA {
 }
 
 // PixelDiff is a utility function that calculates the DiffMetrics and the image of the
-// difference for the provided images.
+// difference for the provided images. If either image has more than 8 bits per channel, the
+// images are compared at full precision (see highPrecisionPixelDiff).
 func PixelDiff(img1, img2 image.Image) (*DiffMetrics, *image.NRGBA) {
 	defer metrics2.FuncTimer().Stop()
+	if imgformat.IsHighPrecision(img1) || imgformat.IsHighPrecision(img2) {
+		return highPrecisionPixelDiff(img1, img2)
+	}
 	img1Bounds := img1.Bounds()
 	img2Bounds := img2.Bounds()
 
@@ -279,6 +284,134 @@ func PixelDiff(img1, img2 image.Image) (*DiffMetrics, *image.NRGBA) {
 		DimDiffer:        (cmpWidth != resultWidth) || (cmpHeight != resultHeight)}, resultImg
 }
 
+// highPrecisionPixelDiff is like PixelDiff, but does not quantize the images to 8 bits per
+// channel before comparing them. Channel differences are still reported in 8-bit steps so that
+// the resulting metrics are comparable with those of 8-bit images, but they are rounded up, so
+// that pixels that differ by less than one 8-bit step are counted as different and show up in
+// the diff image. Differences between HDR values are capped at 255.
+func highPrecisionPixelDiff(img1, img2 image.Image) (*DiffMetrics, *image.NRGBA) {
+	c1 := imgformat.NewChannels(img1)
+	c2 := imgformat.NewChannels(img2)
+	b1 := img1.Bounds()
+	b2 := img2.Bounds()
+	cmpWidth := util.MinInt(b1.Dx(), b2.Dx())
+	cmpHeight := util.MinInt(b1.Dy(), b2.Dy())
+	resultWidth := util.MaxInt(b1.Dx(), b2.Dx())
+	resultHeight := util.MaxInt(b1.Dy(), b2.Dy())
+	resultImg := image.NewNRGBA(image.Rect(0, 0, resultWidth, resultHeight))
+	totalPixels := resultWidth * resultHeight
+
+	numDiffPixels := totalPixels
+	maxRGBADiffs := [4]int{0, 0, 0, 0}
+	maxDiffColor := pixelDiffColor[deltaOffset(1024)]
+	for y := 0; y < resultHeight; y++ {
+		for x := 0; x < resultWidth; x++ {
+			offset := resultImg.PixOffset(x, y)
+			if x >= cmpWidth || y >= cmpHeight {
+				copy(resultImg.Pix[offset:], maxDiffColor)
+				continue
+			}
+			p1 := c1.At(b1.Min.X+x, b1.Min.Y+y)
+			p2 := c2.At(b2.Min.X+x, b2.Min.Y+y)
+			if p1 == p2 {
+				numDiffPixels--
+				continue
+			}
+			var deltas [4]int
+			for i := range deltas {
+				deltas[i] = imgformat.Delta(p1[i], p2[i])
+				maxRGBADiffs[i] = util.MaxInt(maxRGBADiffs[i], deltas[i])
+			}
+			dr, dg, db, da := deltas[0], deltas[1], deltas[2], deltas[3]
+			if dr+dg+db > 0 {
+				copy(resultImg.Pix[offset:], pixelDiffColor[deltaOffset(dr+dg+db+da)])
+			} else if da > 0 {
+				copy(resultImg.Pix[offset:], pixelAlphaDiffColor[deltaOffset(da)])
+			} else {
+				// The pixels differ, but only in ways that are not representable as a delta (e.g.
+				// both channels are NaN). Such pixels still count as different.
+				copy(resultImg.Pix[offset:], pixelDiffColor[0])
+			}
+		}
+	}
+
+	return &DiffMetrics{
+		NumDiffPixels:    numDiffPixels,
+		PixelDiffPercent: getPixelDiffPercent(numDiffPixels, totalPixels),
+		MaxRGBADiffs:     maxRGBADiffs,
+		DimDiffer:        (cmpWidth != resultWidth) || (cmpHeight != resultHeight)}, resultImg
+}
+
+// ToneMappedDiff returns an image that shows how much each color channel differs between the
+// two images, rather than just which pixels differ. This is meant for high precision images,
+// whose differences are often too small (or, for HDR content, too large) to read from the diff
+// image returned by PixelDiff. The differences are normalized so that the largest one is shown
+// at full intensity. If that is greater than 1.0 (i.e. an HDR difference), they are tone-mapped
+// the same way FloatImage tone-maps its pixels. Pixels whose colors are the same but whose alpha
+// differs are shown in the alpha diff color used by PixelDiff, and pixels outside of the area
+// common to both images in the max diff color.
+func ToneMappedDiff(img1, img2 image.Image) *image.NRGBA {
+	c1 := imgformat.NewChannels(img1)
+	c2 := imgformat.NewChannels(img2)
+	b1 := img1.Bounds()
+	b2 := img2.Bounds()
+	cmpWidth := util.MinInt(b1.Dx(), b2.Dx())
+	cmpHeight := util.MinInt(b1.Dy(), b2.Dy())
+	resultWidth := util.MaxInt(b1.Dx(), b2.Dx())
+	resultHeight := util.MaxInt(b1.Dy(), b2.Dy())
+
+	// Channels scales values so that 255 is full intensity.
+	diffs := imgformat.NewFloatImage(image.Rect(0, 0, cmpWidth, cmpHeight))
+	var maxDiff float32
+	for y := 0; y < cmpHeight; y++ {
+		for x := 0; x < cmpWidth; x++ {
+			p1 := c1.At(b1.Min.X+x, b1.Min.Y+y)
+			p2 := c2.At(b2.Min.X+x, b2.Min.Y+y)
+			var d [4]float32
+			for i := 0; i < 3; i++ {
+				d[i] = float32(math.Abs(float64(p1[i]-p2[i]))) / 255
+				if d[i] > maxDiff {
+					maxDiff = d[i]
+				}
+			}
+			d[3] = 1
+			diffs.SetFloat(x, y, d)
+		}
+	}
+	if maxDiff > 1 {
+		diffs.White = maxDiff
+	} else if maxDiff > 0 {
+		for i := 0; i < len(diffs.Pix); i += 4 {
+			diffs.Pix[i] /= maxDiff
+			diffs.Pix[i+1] /= maxDiff
+			diffs.Pix[i+2] /= maxDiff
+		}
+	}
+	toneMapped := GetNRGBA(diffs)
+
+	resultImg := image.NewNRGBA(image.Rect(0, 0, resultWidth, resultHeight))
+	maxDiffColor := pixelDiffColor[deltaOffset(1024)]
+	for y := 0; y < resultHeight; y++ {
+		for x := 0; x < resultWidth; x++ {
+			offset := resultImg.PixOffset(x, y)
+			if x >= cmpWidth || y >= cmpHeight {
+				copy(resultImg.Pix[offset:], maxDiffColor)
+				continue
+			}
+			p1 := c1.At(b1.Min.X+x, b1.Min.Y+y)
+			p2 := c2.At(b2.Min.X+x, b2.Min.Y+y)
+			if p1[0] == p2[0] && p1[1] == p2[1] && p1[2] == p2[2] {
+				if da := imgformat.Delta(p1[3], p2[3]); da > 0 {
+					copy(resultImg.Pix[offset:], pixelAlphaDiffColor[deltaOffset(da)])
+					continue
+				}
+			}
+			copy(resultImg.Pix[offset:offset+4], toneMapped.Pix[toneMapped.PixOffset(x, y):])
+		}
+	}
+	return resultImg
+}
+
 type Calculator interface {
 	// CalculateDiffs recomputes all diffs for the current grouping, including any digests provided.
 	CalculateDiffs(ctx context.Context, grouping paramtools.Params, additional []types.Digest) error
@@ -3,6 +3,7 @@ package diff
 import (
 	"bytes"
 	"image"
+	"image/color"
 	"image/png"
 	"math"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/image/text"
 	one_by_five "go.skia.org/infra/golden/go/testutils/data_one_by_five"
 )
@@ -222,6 +224,94 @@ func TestCombinedDiffMetric(t *testing.T) {
 	assert.InDelta(t, math.Sqrt(0.5), CombinedDiffMetric([4]int{255, 255, 255, 255}, 0.5), 0.000001)
 }
 
+func TestPixelDiff_SixteenBitImages_DifferencesBelowEightBitsDetected(t *testing.T) {
+	left := image.NewNRGBA64(image.Rect(0, 0, 2, 2))
+	right := image.NewNRGBA64(image.Rect(0, 0, 2, 2))
+	for _, img := range []*image.NRGBA64{left, right} {
+		for i := range img.Pix {
+			img.Pix[i] = 0xff
+		}
+	}
+	// These two pixels would be identical if quantized to 8 bits.
+	left.SetNRGBA64(1, 1, color.NRGBA64{R: 0x1234, G: 0xffff, B: 0xffff, A: 0xffff})
+	right.SetNRGBA64(1, 1, color.NRGBA64{R: 0x1235, G: 0xffff, B: 0xffff, A: 0xffff})
+
+	dm, diffImg := PixelDiff(left, right)
+	assert.Equal(t, &DiffMetrics{
+		NumDiffPixels:    1,
+		PixelDiffPercent: 25,
+		MaxRGBADiffs:     [4]int{1, 0, 0, 0},
+	}, dm)
+	assert.Equal(t, uint8ToColor(pixelDiffColor[0]), diffImg.At(1, 1))
+	assert.Equal(t, color.NRGBA{}, diffImg.NRGBAAt(0, 0))
+}
+
+func TestPixelDiff_FloatImages_HDRDifferencesCapped(t *testing.T) {
+	left := imgformat.NewFloatImage(image.Rect(0, 0, 1, 1))
+	right := imgformat.NewFloatImage(image.Rect(0, 0, 1, 1))
+	left.SetFloat(0, 0, [4]float32{0, 0.5, 8, 1})
+	right.SetFloat(0, 0, [4]float32{0, 0.5, 2, 1})
+
+	dm, _ := PixelDiff(left, right)
+	assert.Equal(t, &DiffMetrics{
+		NumDiffPixels:    1,
+		PixelDiffPercent: 100,
+		MaxRGBADiffs:     [4]int{0, 0, 255, 0},
+	}, dm)
+}
+
+func TestPixelDiff_HighPrecisionDifferentSizes_DimDiffer(t *testing.T) {
+	left := imgformat.NewFloatImage(image.Rect(0, 0, 1, 1))
+	right := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+
+	dm, diffImg := PixelDiff(left, right)
+	// (0, 0) is transparent black in both images.
+	assert.Equal(t, 1, dm.NumDiffPixels)
+	assert.True(t, dm.DimDiffer)
+	assert.Equal(t, uint8ToColor(pixelDiffColor[deltaOffset(1024)]), diffImg.At(1, 0))
+}
+
+func TestToneMappedDiff_SmallDifferences_LargestShownAtFullIntensity(t *testing.T) {
+	left := imgformat.NewFloatImage(image.Rect(0, 0, 3, 1))
+	right := imgformat.NewFloatImage(image.Rect(0, 0, 3, 1))
+	left.SetFloat(0, 0, [4]float32{0.5, 0.5, 0.5, 1})
+	right.SetFloat(0, 0, [4]float32{0.5, 0.5, 0.5, 1})
+	left.SetFloat(1, 0, [4]float32{0.5, 0.5, 0.5, 1})
+	right.SetFloat(1, 0, [4]float32{0.501, 0.5, 0.5, 1})
+	left.SetFloat(2, 0, [4]float32{0.5, 0.5, 0.5, 1})
+	right.SetFloat(2, 0, [4]float32{0.5, 0.5, 0.5, 0.5})
+
+	diffImg := ToneMappedDiff(left, right)
+	assert.Equal(t, color.NRGBA{R: 0, G: 0, B: 0, A: 0xff}, diffImg.NRGBAAt(0, 0))
+	assert.Equal(t, color.NRGBA{R: 0xff, G: 0, B: 0, A: 0xff}, diffImg.NRGBAAt(1, 0))
+	assert.Equal(t, uint8ToColor(pixelAlphaDiffColor[deltaOffset(128)]), diffImg.At(2, 0))
+}
+
+func TestToneMappedDiff_HDRDifferences_ToneMapped(t *testing.T) {
+	left := imgformat.NewFloatImage(image.Rect(0, 0, 2, 1))
+	right := imgformat.NewFloatImage(image.Rect(0, 0, 2, 1))
+	left.SetFloat(0, 0, [4]float32{4, 0, 0, 1})
+	right.SetFloat(0, 0, [4]float32{0, 0, 0, 1})
+	left.SetFloat(1, 0, [4]float32{1, 0, 0, 1})
+	right.SetFloat(1, 0, [4]float32{0, 0, 0, 1})
+
+	diffImg := ToneMappedDiff(left, right)
+	assert.Equal(t, uint8(0xff), diffImg.NRGBAAt(0, 0).R)
+	// A difference of 1.0 is no longer shown at full intensity, but is still visible.
+	r := diffImg.NRGBAAt(1, 0).R
+	assert.Less(t, r, uint8(0xff))
+	assert.Greater(t, r, uint8(0))
+}
+
+func TestToneMappedDiff_DifferentSizes_MaxDiffColorOutsideCommonArea(t *testing.T) {
+	left := imgformat.NewFloatImage(image.Rect(0, 0, 1, 1))
+	right := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+
+	diffImg := ToneMappedDiff(left, right)
+	assert.Equal(t, image.Rect(0, 0, 2, 1), diffImg.Bounds())
+	assert.Equal(t, uint8ToColor(pixelDiffColor[deltaOffset(1024)]), diffImg.At(1, 0))
+}
+
 func benchmarkDiff(b *testing.B, img1, img2 image.Image) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
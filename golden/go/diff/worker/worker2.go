@@ -1,13 +1,11 @@
 package worker
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"image"
-	"image/png"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -25,6 +23,7 @@ import (
 	"go.skia.org/infra/go/sql/sqlutil"
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/golden/go/diff"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/sql"
 	"go.skia.org/infra/golden/go/sql/schema"
 	"go.skia.org/infra/golden/go/types"
@@ -453,13 +452,13 @@ func max(diffs [4]int) int {
 // getImage retrieves and decodes the given image. If the image is cached, this function will
 // return the cached version. We choose to cache the decoded image (and not just the downloaded
 // image) because the decoding tends to take 3-5x longer than downloading.
-func (w *WorkerImpl) getDecodedImage(ctx context.Context, digest types.Digest) (*image.NRGBA, error) {
+func (w *WorkerImpl) getDecodedImage(ctx context.Context, digest types.Digest) (image.Image, error) {
 	ctx, span := trace.StartSpan(ctx, "getDecodedImage")
 	defer span.End()
 	cache := getImgCache(ctx)
 	if cache != nil {
 		if cachedImg, ok := cache.Get(string(digest)); ok {
-			return cachedImg.(*image.NRGBA), nil
+			return cachedImg.(image.Image), nil
 		}
 	}
 	b, err := w.imageSource.GetImage(ctx, digest)
@@ -470,9 +469,14 @@ func (w *WorkerImpl) getDecodedImage(ctx context.Context, digest types.Digest) (
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
-	// In memory, the image takes up 4 bytes per pixel.
+	// In memory, the image takes up 4 bytes per pixel, unless it has more than 8 bits per
+	// channel, in which case we use an approximation.
 	s := img.Bounds().Size()
-	sizeInBytes := int64(s.X * s.Y * 4)
+	bytesPerPixel := int64(4)
+	if imgformat.IsHighPrecision(img) {
+		bytesPerPixel = 16
+	}
+	sizeInBytes := int64(s.X*s.Y) * bytesPerPixel
 	span.AddAttributes(trace.Int64Attribute("size_in_bytes", sizeInBytes))
 	if cache != nil {
 		cache.Add(string(digest), img)
@@ -710,14 +714,20 @@ func getImgCache(ctx context.Context) *lru.Cache {
 	return c
 }
 
-// decode decodes the provided bytes as a PNG and returns them.
-func decode(ctx context.Context, b []byte) (*image.NRGBA, error) {
+// decode decodes the provided bytes as any of the formats supported by imgformat and returns
+// them. 8-bit images are returned as *image.NRGBA, which diff.PixelDiff handles fastest; images
+// with more precision than that are returned as is, so the precision is not lost when diffing.
+func decode(ctx context.Context, b []byte) (image.Image, error) {
 	ctx, span := trace.StartSpan(ctx, "decode")
 	defer span.End()
-	im, err := png.Decode(bytes.NewReader(b))
+	im, f, err := imgformat.Decode(b)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
+	span.AddAttributes(trace.StringAttribute("format", string(f)))
+	if imgformat.IsHighPrecision(im) {
+		return im, nil
+	}
 	return diff.GetNRGBA(im), nil
 }
 
@@ -292,7 +292,7 @@ func TestWorkerImpl_CalculateDiffs_CorruptedImage_PartialData(t *testing.T) {
 	assert.Equal(t, string(dks.DigestA04Unt), problem.Digest)
 	// The sentinel value is 100. This should be greater than that because of the new error.
 	assert.True(t, problem.NumErrors >= 101)
-	assert.Contains(t, problem.LatestError, "png: invalid format: not a PNG file")
+	assert.Contains(t, problem.LatestError, "unrecognized image format")
 	assert.Equal(t, fakeNow, problem.ErrorTS)
 }
 
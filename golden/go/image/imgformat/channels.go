@@ -0,0 +1,102 @@
+package imgformat
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Channels holds the non-premultiplied R, G, B, A values of an image as float32s, scaled so
+// that 255 is full intensity. Unlike *image.NRGBA, it keeps the precision of 16-bit and float
+// images. The values of 8-bit images are whole numbers, so comparing two 8-bit images through
+// Channels gives the same results as comparing their *image.NRGBA representations.
+type Channels struct {
+	Pix    []float32
+	Stride int
+	Rect   image.Rectangle
+}
+
+// NewChannels returns the Channels of the given image. Float images keep their linear values,
+// meaning that HDR content may have values greater than 255.
+func NewChannels(img image.Image) *Channels {
+	b := img.Bounds()
+	ret := &Channels{
+		Pix:    make([]float32, 4*b.Dx()*b.Dy()),
+		Stride: 4 * b.Dx(),
+		Rect:   b,
+	}
+	i := 0
+	switch t := img.(type) {
+	case *FloatImage:
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				for _, v := range t.FloatAt(x, y) {
+					ret.Pix[i] = v * 255
+					i++
+				}
+			}
+		}
+	case *image.NRGBA:
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				c := t.NRGBAAt(x, y)
+				ret.Pix[i], ret.Pix[i+1], ret.Pix[i+2], ret.Pix[i+3] = float32(c.R), float32(c.G), float32(c.B), float32(c.A)
+				i += 4
+			}
+		}
+	default:
+		highPrecision := IsHighPrecision(img)
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if highPrecision {
+					c := color.NRGBA64Model.Convert(img.At(x, y)).(color.NRGBA64)
+					// 0xffff / 0xff == 257, so this maps 16-bit values onto the 8-bit scale.
+					ret.Pix[i], ret.Pix[i+1], ret.Pix[i+2], ret.Pix[i+3] = float32(c.R)/257, float32(c.G)/257, float32(c.B)/257, float32(c.A)/257
+				} else {
+					c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+					ret.Pix[i], ret.Pix[i+1], ret.Pix[i+2], ret.Pix[i+3] = float32(c.R), float32(c.G), float32(c.B), float32(c.A)
+				}
+				i += 4
+			}
+		}
+	}
+	return ret
+}
+
+// Bounds returns the bounds of the image the Channels were created from.
+func (c *Channels) Bounds() image.Rectangle { return c.Rect }
+
+// At returns the R, G, B, A values of the pixel at (x, y).
+func (c *Channels) At(x, y int) [4]float32 {
+	if !(image.Point{X: x, Y: y}.In(c.Rect)) {
+		return [4]float32{}
+	}
+	i := (y-c.Rect.Min.Y)*c.Stride + (x-c.Rect.Min.X)*4
+	return [4]float32{c.Pix[i], c.Pix[i+1], c.Pix[i+2], c.Pix[i+3]}
+}
+
+// deltaEpsilon absorbs float error when converting a difference to 8-bit steps. It is smaller
+// than the smallest difference between two 16-bit values (1/257).
+const deltaEpsilon = 1e-3
+
+// Delta returns the absolute difference between two channel values, as returned by
+// Channels.At, in 8-bit steps. It is rounded up, so that any difference, however small, counts
+// as at least 1, and it is capped at 255 so that differences between HDR values stay in the
+// same range as those between 8-bit ones.
+func Delta(a, b float32) int {
+	d := float64(a) - float64(b)
+	if d < 0 {
+		d = -d
+	}
+	if d == 0 || math.IsNaN(d) {
+		return 0
+	}
+	if d >= 255 {
+		return 255
+	}
+	ret := int(math.Ceil(d - deltaEpsilon))
+	if ret < 1 {
+		return 1
+	}
+	return ret
+}
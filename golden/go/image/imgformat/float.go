@@ -0,0 +1,264 @@
+package imgformat
+
+import (
+	"bufio"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"io"
+	"math"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// The RawF16 format is a minimal container for half-float images, meant for tests that render
+// to floating point surfaces (e.g. HDR or extended range content) and that would otherwise need
+// to quantize their output before handing it to Gold. Its layout is:
+//
+//	"SKRAWF16"            8 byte magic
+//	width                 uint32, little endian
+//	height                uint32, little endian
+//	pixels                width*height*4 IEEE 754 half-floats, little endian
+//
+// Pixels are stored in row-major order as non-premultiplied, linear R, G, B, A values. Color
+// values are not clamped and may exceed 1.0.
+var rawF16Magic = []byte("SKRAWF16")
+
+// maxRawF16Pixels limits how much memory a (possibly corrupt) RawF16 header can make us
+// allocate. It is far larger than any image Gold would reasonably be asked to handle.
+const maxRawF16Pixels = 1 << 28
+
+// FloatImage is an in-memory image whose pixels are non-premultiplied, linear RGBA float32
+// values. Color values above 1.0 are allowed.
+//
+// At returns the tone-mapped, sRGB encoded representation of each pixel, which is what
+// generic image code (e.g. draw.Draw or a PNG encoder) will see. Use FloatAt to get at the
+// actual values.
+type FloatImage struct {
+	// Pix holds the image's pixels, in R, G, B, A order.
+	Pix []float32
+	// Stride is the Pix stride (in float32s, not bytes) between vertically adjacent pixels.
+	Stride int
+	// Rect is the image's bounds.
+	Rect image.Rectangle
+	// White is the smallest value that is tone-mapped to full intensity. If it is less than
+	// 1.0, color values are simply clamped to [0, 1].
+	White float32
+}
+
+// NewFloatImage returns a new, transparent FloatImage with the given bounds.
+func NewFloatImage(r image.Rectangle) *FloatImage {
+	return &FloatImage{
+		Pix:    make([]float32, 4*r.Dx()*r.Dy()),
+		Stride: 4 * r.Dx(),
+		Rect:   r,
+		White:  1,
+	}
+}
+
+// ColorModel implements the image.Image interface.
+func (p *FloatImage) ColorModel() color.Model { return color.NRGBA64Model }
+
+// Bounds implements the image.Image interface.
+func (p *FloatImage) Bounds() image.Rectangle { return p.Rect }
+
+// At implements the image.Image interface. It returns the tone-mapped pixel at (x, y).
+func (p *FloatImage) At(x, y int) color.Color {
+	if !(image.Point{X: x, Y: y}.In(p.Rect)) {
+		return color.NRGBA64{}
+	}
+	c := p.FloatAt(x, y)
+	return color.NRGBA64{
+		R: toUint16(linearToSRGB(toneMap(c[0], p.White))),
+		G: toUint16(linearToSRGB(toneMap(c[1], p.White))),
+		B: toUint16(linearToSRGB(toneMap(c[2], p.White))),
+		A: toUint16(c[3]),
+	}
+}
+
+// PixOffset returns the index of the first element of Pix that corresponds to the pixel at
+// (x, y).
+func (p *FloatImage) PixOffset(x, y int) int {
+	return (y-p.Rect.Min.Y)*p.Stride + (x-p.Rect.Min.X)*4
+}
+
+// FloatAt returns the R, G, B, A values of the pixel at (x, y).
+func (p *FloatImage) FloatAt(x, y int) [4]float32 {
+	if !(image.Point{X: x, Y: y}.In(p.Rect)) {
+		return [4]float32{}
+	}
+	i := p.PixOffset(x, y)
+	return [4]float32{p.Pix[i], p.Pix[i+1], p.Pix[i+2], p.Pix[i+3]}
+}
+
+// SetFloat sets the R, G, B, A values of the pixel at (x, y).
+func (p *FloatImage) SetFloat(x, y int, c [4]float32) {
+	if !(image.Point{X: x, Y: y}.In(p.Rect)) {
+		return
+	}
+	i := p.PixOffset(x, y)
+	copy(p.Pix[i:i+4], c[:])
+}
+
+// toneMap maps a linear value in [0, +inf) to [0, 1] using the extended Reinhard operator,
+// with white being the smallest value mapped to 1. Images whose values are all in [0, 1]
+// have a white point of 1, for which the operator is the identity.
+func toneMap(v, white float32) float32 {
+	if v <= 0 || math.IsNaN(float64(v)) {
+		return 0
+	}
+	if white <= 1 {
+		return clamp01(v)
+	}
+	return clamp01(v * (1 + v/(white*white)) / (1 + v))
+}
+
+// linearToSRGB applies the sRGB transfer function to a linear value in [0, 1].
+func linearToSRGB(v float32) float32 {
+	if v <= 0.0031308 {
+		return 12.92 * v
+	}
+	return float32(1.055*math.Pow(float64(v), 1/2.4) - 0.055)
+}
+
+func clamp01(v float32) float32 {
+	if v < 0 || math.IsNaN(float64(v)) {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+func toUint16(v float32) uint16 {
+	return uint16(clamp01(v)*0xffff + 0.5)
+}
+
+// DecodeRawF16 reads a RawF16 image from r. The returned image's White point is set to its
+// largest color value, so that At maps the full range of the image to [0, 1].
+func DecodeRawF16(r io.Reader) (*FloatImage, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(rawF16Magic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, skerr.Wrapf(err, "reading header")
+	}
+	if string(magic) != string(rawF16Magic) {
+		return nil, skerr.Fmt("not a RawF16 image")
+	}
+	var dims [2]uint32
+	if err := binary.Read(br, binary.LittleEndian, &dims); err != nil {
+		return nil, skerr.Wrapf(err, "reading dimensions")
+	}
+	w, h := int(dims[0]), int(dims[1])
+	if w <= 0 || h <= 0 || uint64(w)*uint64(h) > maxRawF16Pixels {
+		return nil, skerr.Fmt("invalid RawF16 dimensions %dx%d", w, h)
+	}
+	img := NewFloatImage(image.Rect(0, 0, w, h))
+	halves := make([]uint16, img.Stride)
+	white := float32(1)
+	for y := 0; y < h; y++ {
+		if err := binary.Read(br, binary.LittleEndian, halves); err != nil {
+			return nil, skerr.Wrapf(err, "reading row %d", y)
+		}
+		row := img.Pix[y*img.Stride : (y+1)*img.Stride]
+		for i, hv := range halves {
+			v := halfToFloat32(hv)
+			row[i] = v
+			// Every fourth value is alpha, which does not participate in tone mapping.
+			if i%4 != 3 && v > white && !math.IsInf(float64(v), 1) {
+				white = v
+			}
+		}
+	}
+	img.White = white
+	return img, nil
+}
+
+// EncodeRawF16 writes img to w in the RawF16 format. Values that cannot be represented as
+// half-floats are rounded to the nearest one.
+func EncodeRawF16(w io.Writer, img *FloatImage) error {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(rawF16Magic); err != nil {
+		return skerr.Wrap(err)
+	}
+	b := img.Bounds()
+	if err := binary.Write(bw, binary.LittleEndian, [2]uint32{uint32(b.Dx()), uint32(b.Dy())}); err != nil {
+		return skerr.Wrap(err)
+	}
+	halves := make([]uint16, 4*b.Dx())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := img.FloatAt(x, y)
+			for i, v := range c {
+				halves[(x-b.Min.X)*4+i] = float32ToHalf(v)
+			}
+		}
+		if err := binary.Write(bw, binary.LittleEndian, halves); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
+	return skerr.Wrap(bw.Flush())
+}
+
+// halfToFloat32 converts an IEEE 754 binary16 value to a float32.
+func halfToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+	switch exp {
+	case 0:
+		// Zero or subnormal; subnormals are mant * 2^-24.
+		f := float32(mant) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	case 0x1f:
+		// Infinity or NaN.
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
+
+// float32ToHalf converts a float32 to the nearest IEEE 754 binary16 value, rounding ties to
+// even. Values too large to be represented become infinities.
+func float32ToHalf(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int((bits >> 23) & 0xff)
+	mant := bits & 0x7fffff
+	if exp == 0xff {
+		if mant != 0 {
+			return sign | 0x7e00
+		}
+		return sign | 0x7c00
+	}
+	e := exp - 127 + 15
+	if e >= 0x1f {
+		return sign | 0x7c00
+	}
+	if e <= 0 {
+		// The result is a half-float subnormal (or zero).
+		if e < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint32(14 - e)
+		h := mant >> shift
+		rem := mant & (1<<shift - 1)
+		half := uint32(1) << (shift - 1)
+		if rem > half || (rem == half && h&1 == 1) {
+			h++
+		}
+		return sign | uint16(h)
+	}
+	h := uint32(e)<<10 | mant>>13
+	rem := mant & 0x1fff
+	// Rounding up may carry into the exponent, which correctly yields the next power of two
+	// (or infinity).
+	if rem > 0x1000 || (rem == 0x1000 && h&1 == 1) {
+		h++
+	}
+	return sign | uint16(h)
+}
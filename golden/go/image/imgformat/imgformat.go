@@ -0,0 +1,101 @@
+// Package imgformat knows how to detect and decode the image formats Gold accepts as test
+// output, and provides helpers to compare images without first quantizing them to 8 bits per
+// channel.
+//
+// The supported formats are:
+//   - PNG, with 8 or 16 bits per channel.
+//   - WebP. Only lossless WebP images make sense for Gold, but lossy ones will decode as well.
+//   - RawF16, a minimal container for half-float images (see float.go).
+//
+// Images that carry more than 8 bits of precision per channel (16-bit PNGs and half-float
+// images) are said to be "high precision". Code that diffs or matches images should use
+// NewChannels to read them, which preserves that precision, rather than converting them to
+// *image.NRGBA, which does not.
+package imgformat
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+
+	"golang.org/x/image/webp"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// Format identifies the encoding of an image.
+type Format string
+
+const (
+	// Unknown is returned by Sniff for bytes that are not in a supported format.
+	Unknown Format = ""
+	PNG     Format = "png"
+	WebP    Format = "webp"
+	RawF16  Format = "f16"
+)
+
+// AllFormats contains all the supported formats. The values double as the file extensions
+// (without the leading dot) and as the "ext" option of a Gold result.
+var AllFormats = []Format{PNG, WebP, RawF16}
+
+var (
+	pngMagic = []byte("\x89PNG\r\n\x1a\n")
+	// WebP files are RIFF containers of the form "RIFF" <4 byte length> "WEBP" ...
+	riffMagic = []byte("RIFF")
+	webpMagic = []byte("WEBP")
+)
+
+// Sniff returns the format of the encoded image in b, or Unknown if it is not one we support.
+func Sniff(b []byte) Format {
+	switch {
+	case bytes.HasPrefix(b, pngMagic):
+		return PNG
+	case len(b) >= 12 && bytes.Equal(b[0:4], riffMagic) && bytes.Equal(b[8:12], webpMagic):
+		return WebP
+	case bytes.HasPrefix(b, rawF16Magic):
+		return RawF16
+	}
+	return Unknown
+}
+
+// IsValid returns true if the given string is the name of a supported format.
+func IsValid(ext string) bool {
+	for _, f := range AllFormats {
+		if string(f) == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode detects the format of the provided bytes and decodes them. 16-bit PNGs are returned
+// with their full precision (e.g. as *image.NRGBA64) and half-float images as *FloatImage.
+func Decode(b []byte) (image.Image, Format, error) {
+	var img image.Image
+	var err error
+	f := Sniff(b)
+	switch f {
+	case PNG:
+		img, err = png.Decode(bytes.NewReader(b))
+	case WebP:
+		img, err = webp.Decode(bytes.NewReader(b))
+	case RawF16:
+		img, err = DecodeRawF16(bytes.NewReader(b))
+	default:
+		return nil, Unknown, skerr.Fmt("unrecognized image format")
+	}
+	if err != nil {
+		return nil, f, skerr.Wrapf(err, "decoding %s image", f)
+	}
+	return img, f, nil
+}
+
+// IsHighPrecision returns true if the image stores more than 8 bits per channel, meaning that
+// converting it to *image.NRGBA would lose information.
+func IsHighPrecision(img image.Image) bool {
+	switch img.(type) {
+	case *FloatImage, *image.NRGBA64, *image.RGBA64, *image.Gray16:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package imgformat
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// losslessWebP is a 1x1, fully transparent lossless WebP image.
+var losslessWebP = []byte{
+	0x52, 0x49, 0x46, 0x46, 0x1a, 0x00, 0x00, 0x00, 0x57, 0x45, 0x42, 0x50, 0x56, 0x50, 0x38, 0x4c,
+	0x0d, 0x00, 0x00, 0x00, 0x2f, 0x00, 0x00, 0x00, 0x10, 0x07, 0x10, 0x11, 0x11, 0x88, 0x88, 0xfe,
+	0x07, 0x00,
+}
+
+func TestSniff_SupportedFormats_Detected(t *testing.T) {
+	assert.Equal(t, PNG, Sniff(encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 1, 1)))))
+	assert.Equal(t, WebP, Sniff(losslessWebP))
+	assert.Equal(t, RawF16, Sniff(encodeRawF16(t, NewFloatImage(image.Rect(0, 0, 1, 1)))))
+}
+
+func TestSniff_UnsupportedFormats_ReturnsUnknown(t *testing.T) {
+	assert.Equal(t, Unknown, Sniff(nil))
+	assert.Equal(t, Unknown, Sniff([]byte("GIF89a")))
+	assert.Equal(t, Unknown, Sniff([]byte("RIFF\x00\x00\x00\x00WAVE")))
+}
+
+func TestDecode_SixteenBitPNG_KeepsFullPrecision(t *testing.T) {
+	src := image.NewNRGBA64(image.Rect(0, 0, 2, 1))
+	src.SetNRGBA64(0, 0, color.NRGBA64{R: 0x1234, G: 0x5678, B: 0x9abc, A: 0xffff})
+	src.SetNRGBA64(1, 0, color.NRGBA64{R: 0x1235, G: 0x5678, B: 0x9abc, A: 0xffff})
+
+	img, f, err := Decode(encodePNG(t, src))
+	require.NoError(t, err)
+	assert.Equal(t, PNG, f)
+	assert.True(t, IsHighPrecision(img))
+	// These two pixels would be identical if quantized to 8 bits.
+	assert.NotEqual(t, img.At(0, 0), img.At(1, 0))
+}
+
+func TestDecode_EightBitPNG_NotHighPrecision(t *testing.T) {
+	img, f, err := Decode(encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 1, 1))))
+	require.NoError(t, err)
+	assert.Equal(t, PNG, f)
+	assert.False(t, IsHighPrecision(img))
+}
+
+func TestDecode_LosslessWebP_Success(t *testing.T) {
+	img, f, err := Decode(losslessWebP)
+	require.NoError(t, err)
+	assert.Equal(t, WebP, f)
+	assert.Equal(t, image.Rect(0, 0, 1, 1), img.Bounds())
+	assert.Equal(t, color.NRGBA{}, color.NRGBAModel.Convert(img.At(0, 0)))
+}
+
+func TestDecode_UnknownFormat_ReturnsError(t *testing.T) {
+	_, f, err := Decode([]byte("not an image"))
+	require.Error(t, err)
+	assert.Equal(t, Unknown, f)
+}
+
+func TestDecode_TruncatedRawF16_ReturnsError(t *testing.T) {
+	b := encodeRawF16(t, NewFloatImage(image.Rect(0, 0, 4, 4)))
+	_, f, err := Decode(b[:len(b)-1])
+	require.Error(t, err)
+	assert.Equal(t, RawF16, f)
+}
+
+func TestRawF16_RoundTrip_ValuesPreserved(t *testing.T) {
+	src := NewFloatImage(image.Rect(0, 0, 2, 2))
+	src.SetFloat(0, 0, [4]float32{0, 0.5, 1, 1})
+	src.SetFloat(1, 0, [4]float32{2, 4.5, 0.25, 1})
+	src.SetFloat(0, 1, [4]float32{-1, 0.125, 1024, 0.5})
+	src.SetFloat(1, 1, [4]float32{0, 0, 0, 0})
+
+	img, f, err := Decode(encodeRawF16(t, src))
+	require.NoError(t, err)
+	assert.Equal(t, RawF16, f)
+	fi := img.(*FloatImage)
+	assert.Equal(t, src.Pix, fi.Pix)
+	// The white point is the brightest color value, ignoring alpha.
+	assert.Equal(t, float32(1024), fi.White)
+}
+
+func TestFloatImage_At_ToneMapsToWhitePoint(t *testing.T) {
+	img := NewFloatImage(image.Rect(0, 0, 3, 1))
+	img.SetFloat(0, 0, [4]float32{0, 0, 0, 1})
+	img.SetFloat(1, 0, [4]float32{4, 4, 4, 1})
+	img.SetFloat(2, 0, [4]float32{1, 1, 1, 1})
+	img.White = 4
+
+	assert.Equal(t, color.NRGBA64{A: 0xffff}, img.At(0, 0))
+	assert.Equal(t, color.NRGBA64{R: 0xffff, G: 0xffff, B: 0xffff, A: 0xffff}, img.At(1, 0))
+	// 1.0 is no longer full intensity once there are brighter values in the image.
+	c := img.At(2, 0).(color.NRGBA64)
+	assert.Less(t, c.R, uint16(0xffff))
+	assert.Greater(t, c.R, uint16(0))
+}
+
+func TestFloatImage_At_StandardRangeIsNotToneMapped(t *testing.T) {
+	img := NewFloatImage(image.Rect(0, 0, 1, 1))
+	img.SetFloat(0, 0, [4]float32{1, 0, 0.5, 1})
+	// With a white point of 1, only the sRGB transfer function is applied.
+	assert.Equal(t, color.NRGBA64{R: 0xffff, G: 0, B: 0xbc40, A: 0xffff}, img.At(0, 0))
+}
+
+func TestHalfFloatConversion_RoundTrips(t *testing.T) {
+	for _, h := range []uint16{
+		0x0000, // 0
+		0x8000, // -0
+		0x0001, // smallest subnormal
+		0x03ff, // largest subnormal
+		0x0400, // smallest normal
+		0x3c00, // 1
+		0xc000, // -2
+		0x7bff, // 65504, the largest finite value
+		0x7c00, // +Inf
+		0xfc00, // -Inf
+	} {
+		assert.Equal(t, h, float32ToHalf(halfToFloat32(h)), "0x%04x", h)
+	}
+	assert.Equal(t, uint16(0x7e00), float32ToHalf(float32(math.NaN())))
+	// Values too large for a half-float become infinity.
+	assert.Equal(t, uint16(0x7c00), float32ToHalf(1e6))
+	// 1 + 2^-11 is halfway between 1 and the next half-float; ties round to even.
+	assert.Equal(t, uint16(0x3c00), float32ToHalf(1+1.0/2048))
+	assert.Equal(t, uint16(0x3c01), float32ToHalf(1+3.0/4096))
+}
+
+func TestDelta_EightBitValues_Exact(t *testing.T) {
+	assert.Equal(t, 0, Delta(17, 17))
+	assert.Equal(t, 1, Delta(17, 18))
+	assert.Equal(t, 255, Delta(0, 255))
+}
+
+func TestDelta_SubEightBitDifference_CountsAsOne(t *testing.T) {
+	assert.Equal(t, 1, Delta(float32(0x1234)/257, float32(0x1235)/257))
+	assert.Equal(t, 1, Delta(0.5, 0.5000001))
+}
+
+func TestDelta_HDRDifference_Capped(t *testing.T) {
+	assert.Equal(t, 255, Delta(0, 10*255))
+}
+
+func TestNewChannels_EightBitImage_WholeNumbers(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 1, G: 2, B: 3, A: 4})
+	assert.Equal(t, [4]float32{1, 2, 3, 4}, NewChannels(img).At(0, 0))
+}
+
+func TestNewChannels_SixteenBitImage_KeepsPrecision(t *testing.T) {
+	img := image.NewNRGBA64(image.Rect(0, 0, 1, 1))
+	img.SetNRGBA64(0, 0, color.NRGBA64{R: 0x0101, G: 0x0102, B: 0xffff, A: 0xffff})
+	assert.Equal(t, [4]float32{1, float32(0x0102) / 257, 255, 255}, NewChannels(img).At(0, 0))
+}
+
+func TestNewChannels_FloatImage_NotClamped(t *testing.T) {
+	img := NewFloatImage(image.Rect(0, 0, 1, 1))
+	img.SetFloat(0, 0, [4]float32{2, 0.5, 0, 1})
+	assert.Equal(t, [4]float32{510, 127.5, 0, 255}, NewChannels(img).At(0, 0))
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodeRawF16(t *testing.T, img *FloatImage) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, EncodeRawF16(&buf, img))
+	return buf.Bytes()
+}
@@ -21,6 +21,7 @@ import (
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/sql/sqlutil"
 	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/ingestion"
 	"go.skia.org/infra/golden/go/jsonio"
 	"go.skia.org/infra/golden/go/sql"
@@ -874,11 +875,11 @@ func paramsAndOptions(gr *jsonio.GoldResults, r jsonio.Result) (map[string]strin
 // shouldIngest returns a descriptive error if we should ignore an entry
 // with these params/options.
 func shouldIngest(params, options map[string]string) error {
-	// Ignore anything that is not a png. In the early days (pre-2015), ext was omitted
-	// but implied to be "png". Thus if ext is not provided, it will be ingested.
+	// Ignore anything that is not in a supported image format. In the early days (pre-2015), ext
+	// was omitted but implied to be "png". Thus if ext is not provided, it will be ingested.
 	// New entries (created by goldctl) will always have ext set.
-	if ext, ok := options["ext"]; ok && (ext != "png") {
-		return errors.New("ignoring non-png entry")
+	if ext, ok := options["ext"]; ok && !imgformat.IsValid(ext) {
+		return skerr.Fmt("ignoring entry with unsupported image format %q", ext)
 	}
 
 	// Make sure the test name meets basic requirements.
@@ -1551,3 +1551,19 @@ func (f *fakeGCSSource) GetReader(_ context.Context, _ string) (io.ReadCloser, e
 func (f *fakeGCSSource) HandlesFile(_ string) bool {
 	return true
 }
+
+func TestShouldIngest_SupportedImageFormats_NoError(t *testing.T) {
+	params := map[string]string{types.PrimaryKeyField: "my_test"}
+	for _, ext := range []string{"png", "webp", "f16"} {
+		assert.NoError(t, shouldIngest(params, map[string]string{"ext": ext}), ext)
+	}
+	// Entries without an ext are implied to be PNGs.
+	assert.NoError(t, shouldIngest(params, map[string]string{}))
+}
+
+func TestShouldIngest_UnsupportedImageFormat_ReturnsError(t *testing.T) {
+	params := map[string]string{types.PrimaryKeyField: "my_test"}
+	err := shouldIngest(params, map[string]string{"ext": "pdf"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported image format")
+}
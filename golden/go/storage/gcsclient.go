@@ -14,6 +14,7 @@ import (
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/types"
 	"google.golang.org/api/option"
 )
@@ -162,16 +163,33 @@ func (g *ClientImpl) writeToPath(ctx context.Context, targetPath, contentType st
 func (g *ClientImpl) GetImage(ctx context.Context, digest types.Digest) ([]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "gcsclient_GetImage")
 	defer span.End()
+	return ReadImage(ctx, g.storageClient.Bucket(g.options.Bucket), digest)
+}
+
+// ImagePath returns the path in the GCS bucket of the image with the given digest and format.
+// Images are stored with the file extension of their format, e.g. "<digest>.webp".
+func ImagePath(digest types.Digest, format imgformat.Format) string {
 	// intentionally using path because gcs is forward slashes
-	imgPath := path.Join(imgFolder, string(digest)+".png")
-	r, err := g.storageClient.Bucket(g.options.Bucket).Object(imgPath).NewReader(ctx)
-	if err != nil {
-		// If not image not found, this error path will be taken.
-		return nil, skerr.Wrap(err)
+	return path.Join(imgFolder, string(digest)+"."+string(format))
+}
+
+// ReadImage downloads the bytes of the image with the given digest from the bucket. As the
+// format of the image is not known up front, the path of each supported format is tried in
+// turn, starting with PNG, which is by far the most common one. It returns an error if the
+// image is not found.
+func ReadImage(ctx context.Context, bucket *gstorage.BucketHandle, digest types.Digest) ([]byte, error) {
+	for _, format := range imgformat.AllFormats {
+		r, err := bucket.Object(ImagePath(digest, format)).NewReader(ctx)
+		if err == gstorage.ErrObjectNotExist {
+			continue
+		} else if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		defer util.Close(r)
+		b, err := io.ReadAll(r)
+		return b, skerr.Wrap(err)
 	}
-	defer util.Close(r)
-	b, err := io.ReadAll(r)
-	return b, skerr.Wrap(err)
+	return nil, skerr.Wrapf(gstorage.ErrObjectNotExist, "image %s", digest)
 }
 
 // Ensure ClientImpl fulfills the GCSClient interface.
@@ -8,7 +8,6 @@ import (
 	"errors"
 	"fmt"
 	"image"
-	"image/png"
 	"net/http"
 	"net/url"
 	"path"
@@ -43,6 +42,7 @@ import (
 	"go.skia.org/infra/golden/go/diff"
 	"go.skia.org/infra/golden/go/expectations"
 	"go.skia.org/infra/golden/go/ignore"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/search"
 	search_query "go.skia.org/infra/golden/go/search/query"
 	"go.skia.org/infra/golden/go/sql"
@@ -2392,7 +2392,9 @@ func (wh *Handlers) ImageHandler(w http.ResponseWriter, r *http.Request) {
 		left := types.Digest(imgID[:validDigestLength])
 		// + 1 for the dash
 		right := types.Digest(imgID[validDigestLength+1:])
-		wh.serveImageDiff(ctx, w, left, right)
+		// Appending ?tonemap=true returns a tone-mapped diff, which shows how much the images
+		// differ rather than just where. This is mostly useful for high precision images.
+		wh.serveImageDiff(ctx, w, left, right, r.FormValue("tonemap") == "true")
 	} else {
 		noCacheNotFound(w)
 		return
@@ -2404,15 +2406,28 @@ func (wh *Handlers) ImageHandler(w http.ResponseWriter, r *http.Request) {
 func (wh *Handlers) serveImageWithDigest(ctx context.Context, w http.ResponseWriter, digest types.Digest) {
 	ctx, span := trace.StartSpan(ctx, "serveImageWithDigest")
 	defer span.End()
-	// Go's image package has no color profile support and we convert to 8-bit NRGBA to diff,
-	// but our source images may have embedded color profiles and be up to 16-bit. So we must
-	// at least take care to serve the original .pngs unaltered.
+	// Go's image package has no color profile support, but our source images may have embedded
+	// color profiles. So we must at least take care to serve the original images unaltered
+	// whenever browsers are able to display them.
 	b, err := wh.GCSClient.GetImage(ctx, digest)
 	if err != nil {
 		sklog.Warningf("Could not get image with digest %s: %s", digest, err)
 		noCacheNotFound(w)
 		return
 	}
+	// Browsers cannot display half-float images, so we serve a tone-mapped 8-bit rendition of
+	// them instead. PNGs (including 16-bit ones) and WebP images are served unaltered.
+	if imgformat.Sniff(b) == imgformat.RawF16 {
+		img, _, err := imgformat.Decode(b)
+		if err != nil {
+			httputils.ReportError(w, err, "Could not decode image.", http.StatusInternalServerError)
+			return
+		}
+		if err := encodeImg(w, diff.GetNRGBA(img)); err != nil {
+			httputils.ReportError(w, err, "Could not load image. Try again later.", http.StatusInternalServerError)
+		}
+		return
+	}
 	if _, err := w.Write(b); err != nil {
 		httputils.ReportError(w, err, "Could not load image. Try again later.", http.StatusInternalServerError)
 		return
@@ -2420,17 +2435,17 @@ func (wh *Handlers) serveImageWithDigest(ctx context.Context, w http.ResponseWri
 }
 
 // serveImageDiff downloads the left and right images, computes the diff between them, encodes
-// the diff as a PNG image and writes it to the provided ResponseWriter. If there is an error, it
-// returns a 404 or 500 error as appropriate.
-func (wh *Handlers) serveImageDiff(ctx context.Context, w http.ResponseWriter, left types.Digest, right types.Digest) {
+// the diff as a PNG image and writes it to the provided ResponseWriter. If toneMapped is true,
+// the diff is computed with diff.ToneMappedDiff instead of diff.PixelDiff. If there is an error,
+// it returns a 404 or 500 error as appropriate.
+func (wh *Handlers) serveImageDiff(ctx context.Context, w http.ResponseWriter, left types.Digest, right types.Digest, toneMapped bool) {
 	ctx, span := trace.StartSpan(ctx, "serveImageDiff")
 	defer span.End()
-	// TODO(lovisolo): Diff in NRGBA64?
 	// TODO(lovisolo): Make sure each pair of images is in the same color space before diffing?
 	//                 (They probably are today but it'd be a good correctness check to make sure.)
 	eg, eCtx := errgroup.WithContext(ctx)
-	var leftImg *image.NRGBA
-	var rightImg *image.NRGBA
+	var leftImg image.Image
+	var rightImg image.Image
 	eg.Go(func() error {
 		b, err := wh.GCSClient.GetImage(eCtx, left)
 		if err != nil {
@@ -2453,7 +2468,12 @@ func (wh *Handlers) serveImageDiff(ctx context.Context, w http.ResponseWriter, l
 		return
 	}
 	// Compute the diff image.
-	_, diffImg := diff.PixelDiff(leftImg, rightImg)
+	var diffImg *image.NRGBA
+	if toneMapped {
+		diffImg = diff.ToneMappedDiff(leftImg, rightImg)
+	} else {
+		_, diffImg = diff.PixelDiff(leftImg, rightImg)
+	}
 
 	// Write output image to the http.ResponseWriter. Content-Type is set automatically
 	// based on the first 512 bytes of written data. See docs for ResponseWriter.Write()
@@ -2469,12 +2489,17 @@ func (wh *Handlers) serveImageDiff(ctx context.Context, w http.ResponseWriter, l
 	}
 }
 
-// decode decodes the provided bytes as a PNG and returns them as an *image.NRGBA.
-func decode(b []byte) (*image.NRGBA, error) {
-	im, err := png.Decode(bytes.NewReader(b))
+// decode decodes the provided bytes as any of the formats supported by imgformat. Images with
+// more than 8 bits per channel keep their precision, so that diff.PixelDiff can take it into
+// account.
+func decode(b []byte) (image.Image, error) {
+	im, _, err := imgformat.Decode(b)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
+	if imgformat.IsHighPrecision(im) {
+		return im, nil
+	}
 	return diff.GetNRGBA(im), nil
 }
 
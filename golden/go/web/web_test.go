@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -40,6 +41,7 @@ import (
 	"go.skia.org/infra/golden/go/ignore"
 	mock_ignore "go.skia.org/infra/golden/go/ignore/mocks"
 	"go.skia.org/infra/golden/go/ignore/sqlignorestore"
+	"go.skia.org/infra/golden/go/image/imgformat"
 	"go.skia.org/infra/golden/go/image/text"
 	"go.skia.org/infra/golden/go/mocks"
 	"go.skia.org/infra/golden/go/search"
@@ -834,6 +836,31 @@ func TestImageHandler_SingleKnownImage_CorrectBytesReturned(t *testing.T) {
 	assertImageResponseWas(t, []byte("some png bytes"), w)
 }
 
+func TestImageHandler_SingleHalfFloatImage_ToneMappedPNGReturned(t *testing.T) {
+	src := imgformat.NewFloatImage(image.Rect(0, 0, 1, 2))
+	src.SetFloat(0, 0, [4]float32{4, 4, 4, 1})
+	src.SetFloat(0, 1, [4]float32{0, 0, 0, 1})
+	var b bytes.Buffer
+	require.NoError(t, imgformat.EncodeRawF16(&b, src))
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("0123456789abcdef0123456789abcdef")).Return(b.Bytes(), nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GCSClient: mgc,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/images/0123456789abcdef0123456789abcdef.png", nil)
+	wh.ImageHandler(w, r)
+	// The brightest pixel in the image is mapped to white.
+	assertDiffImageWas(t, w, `! SKTEXTSIMPLE
+1 2
+0xffffffff
+0x000000ff`)
+}
+
 func TestImageHandler_SingleUnknownImage_404Returned(t *testing.T) {
 	mgc := &mocks.GCSClient{}
 	mgc.On("GetImage", testutils.AnyContext, mock.Anything).Return(nil, errors.New("unknown"))
@@ -878,6 +905,37 @@ func TestImageHandler_TwoKnownImages_DiffReturned(t *testing.T) {
 0xc6dbefff`)
 }
 
+func TestImageHandler_TwoKnownImages_ToneMapped_ToneMappedDiffReturned(t *testing.T) {
+	left := imgformat.NewFloatImage(image.Rect(0, 0, 1, 2))
+	left.SetFloat(0, 0, [4]float32{0.5, 0.5, 0.5, 1})
+	left.SetFloat(0, 1, [4]float32{0.5, 0.5, 0.5, 1})
+	right := imgformat.NewFloatImage(image.Rect(0, 0, 1, 2))
+	right.SetFloat(0, 0, [4]float32{0.5, 0.5, 0.5, 1})
+	right.SetFloat(0, 1, [4]float32{0.5, 0.5, 0.501, 1})
+	var b1, b2 bytes.Buffer
+	require.NoError(t, imgformat.EncodeRawF16(&b1, left))
+	require.NoError(t, imgformat.EncodeRawF16(&b2, right))
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("11111111111111111111111111111111")).Return(b1.Bytes(), nil)
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("22222222222222222222222222222222")).Return(b2.Bytes(), nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GCSClient: mgc,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/diffs/11111111111111111111111111111111-22222222222222222222222222222222.png?tonemap=true", nil)
+	wh.ImageHandler(w, r)
+	// The only difference is in the blue channel of the second pixel, which is shown at full
+	// intensity even though it is far smaller than one 8-bit step.
+	assertDiffImageWas(t, w, `! SKTEXTSIMPLE
+1 2
+0x000000ff
+0x0000ffff`)
+}
+
 func TestImageHandler_OneUnknownImage_404Returned(t *testing.T) {
 	image1 := loadAsPNGBytes(t, one_by_five.ImageOne)
 	mgc := &mocks.GCSClient{}
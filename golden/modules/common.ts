@@ -46,6 +46,15 @@ export function digestDiffImagePath(d1: string, d2: string): string {
   return `${diffPrefix}/${order}.png`;
 }
 
+/**
+ * Returns a link to the tone-mapped diff between the given digests, which shows how much the
+ * images differ rather than just where. This is most useful for high precision images.
+ */
+export function digestToneMappedDiffImagePath(d1: string, d2: string): string {
+  const path = digestDiffImagePath(d1, d2);
+  return path ? `${path}?tonemap=true` : '';
+}
+
 /**
  * Returns a link to the details page for a given test-digest pair.
  * @param grouping Grouping.
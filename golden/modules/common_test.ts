@@ -4,6 +4,7 @@ import {
   humanReadableQuery,
   digestImagePath,
   digestDiffImagePath,
+  digestToneMappedDiffImagePath,
   detailHref,
   diffPageHref,
   sendBeginTask,
@@ -53,6 +54,18 @@ describe('digestDiffImagePath', () => {
   });
 });
 
+describe('digestToneMappedDiffImagePath', () => {
+  it('returns the diff path with the tonemap parameter', () => {
+    expect(digestToneMappedDiffImagePath(bDigest, aDigest)).to.equal(
+      '/img/diffs/aaab78c9711cb79197d47f448ba51338-bbb8b07beb4e1247c2cbafdb92b93e55.png?tonemap=true'
+    );
+  });
+
+  it('returns an empty string if a digest is missing', () => {
+    expect(digestToneMappedDiffImagePath(aDigest, '')).to.equal('');
+  });
+});
+
 describe('detailHref', () => {
   it('returns a path with and without an changelist id', () => {
     expect(
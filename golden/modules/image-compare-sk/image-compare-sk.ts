@@ -12,7 +12,12 @@ import { ElementSk } from '../../../infra-sk/modules/ElementSk';
 import { MultiZoomSk } from '../multi-zoom-sk/multi-zoom-sk';
 
 import '../../../elements-sk/modules/icons/open-in-new-icon-sk';
-import { digestDiffImagePath, digestImagePath } from '../common';
+import '../../../elements-sk/modules/icons/tonality-icon-sk';
+import {
+  digestDiffImagePath,
+  digestImagePath,
+  digestToneMappedDiffImagePath,
+} from '../common';
 
 import '../multi-zoom-sk';
 
@@ -76,6 +81,13 @@ export class ImageCompareSk extends ElementSk {
       <a target="_blank" rel="noopener" href=${diffSrc}>
         <open-in-new-icon-sk></open-in-new-icon-sk>
       </a>
+      <a
+        target="_blank"
+        rel="noopener"
+        title="Tone-mapped diff"
+        href=${digestToneMappedDiffImagePath(ele.left.digest, ele.right.digest)}>
+        <tonality-icon-sk></tonality-icon-sk>
+      </a>
 
       <figure>
         <img
@@ -59,3 +59,8 @@ func PutDSTask(k *datastore.Key, t *types.Task) (*datastore.Key, error) {
 func UpdateDSTask(k *datastore.Key, t *types.Task) (*datastore.Key, error) {
 	return ds.DS.Put(context.Background(), k, t)
 }
+
+func GetHealthCheckPendingDSTasks() *datastore.Iterator {
+	q := ds.NewQuery(ds.TASK).EventualConsistency().Filter("HealthCheckPending =", true)
+	return ds.DS.Run(context.TODO(), q)
+}
@@ -0,0 +1,218 @@
+/*
+	Used by the Leasing Server to lease machines through the machine server.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"google.golang.org/api/iterator"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/swarming"
+	"go.skia.org/infra/leasing/go/machinelease"
+	"go.skia.org/infra/leasing/go/types"
+	"go.skia.org/infra/machine/go/configs"
+	"go.skia.org/infra/machine/go/machine/pools"
+	"go.skia.org/infra/machine/go/machine/store/cdb"
+	"go.skia.org/infra/machine/go/machineserver/config"
+)
+
+// leaser leases machines through the machine server. It is nil if leasing
+// machines is not enabled.
+var leaser *machinelease.Leaser
+
+// MachineLeasingInit enables leasing machines from the machine store described
+// by the named config file, as found in machine/go/configs.
+func MachineLeasingInit(ctx context.Context, configName string) error {
+	var instanceConfig config.InstanceConfig
+	b, err := fs.ReadFile(configs.Configs, configName)
+	if err != nil {
+		return skerr.Wrapf(err, "read config file %q", configName)
+	}
+	if err := json.Unmarshal(b, &instanceConfig); err != nil {
+		return skerr.Wrap(err)
+	}
+	if instanceConfig.ConnectionString == "" {
+		return skerr.Fmt("ConnectionString must be supplied in the instance config")
+	}
+	p, err := pools.New(instanceConfig)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	db, err := pgxpool.Connect(ctx, instanceConfig.ConnectionString)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	s, err := cdb.New(db, p)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	leaser = machinelease.New(s)
+	return nil
+}
+
+// validateMachine returns an error if the machine does not exist or can not
+// currently be leased, so that requests can fail before a task is stored.
+func validateMachine(ctx context.Context, machineID string) error {
+	if leaser == nil {
+		return skerr.Fmt("Leasing machines through the machine server is not enabled")
+	}
+	return skerr.Wrap(leaser.CheckAvailable(ctx, machineID))
+}
+
+// leaseMachine leases the machine for the provided Task, populates its
+// connection info and lease start/end times, stores it in the datastore, and
+// sends a start email. The lease is released if it cannot be stored. Failing to
+// send the start email does not fail the lease.
+func leaseMachine(ctx context.Context, k *datastore.Key, t *types.Task) error {
+	if leaser == nil {
+		return skerr.Fmt("Leasing machines through the machine server is not enabled")
+	}
+	durationHrs, err := strconv.Atoi(t.InitialDurationHrs)
+	if err != nil {
+		return skerr.Fmt("Failed to parse %s", t.InitialDurationHrs)
+	}
+	t.LeaseStartTime = time.Now()
+	t.LeaseEndTime = t.LeaseStartTime.Add(time.Hour * time.Duration(durationHrs))
+	info, err := leaser.Acquire(ctx, t.MachineID, t.Requester, t.LeaseEndTime)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	t.SSH = info.SSH
+	t.ConnectionInstructions = info.Instructions
+	// Machine leases have no Swarming task, but the task state is what the
+	// UI displays.
+	t.SwarmingTaskState = swarming.TASK_STATE_RUNNING
+	if _, err := UpdateDSTask(k, t); err != nil {
+		// Nothing would ever release the machine, so release it now.
+		if releaseErr := leaser.Release(ctx, t.MachineID, t.Requester); releaseErr != nil {
+			sklog.Errorf("Failed to release machine %s after failing to store its lease: %s", t.MachineID, releaseErr)
+		}
+		return skerr.Wrapf(err, "Error updating task with machine lease in datastore")
+	}
+
+	threadingReference, err := SendMachineStartEmail(t)
+	if err != nil {
+		sklog.Errorf("Error sending start email for task %d: %s", k.ID, err)
+		return nil
+	}
+	t.EmailThreadingReference = threadingReference
+	if _, err := UpdateDSTask(k, t); err != nil {
+		sklog.Errorf("Error storing email threading reference for task %d: %s", k.ID, err)
+	}
+	return nil
+}
+
+// releaseMachine ends the lease of the machine for the provided Task, starts
+// its health check and sends a completion email.
+func releaseMachine(ctx context.Context, k *datastore.Key, t *types.Task) error {
+	if leaser == nil {
+		return skerr.Fmt("Leasing machines through the machine server is not enabled")
+	}
+	if err := leaser.Release(ctx, t.MachineID, t.Requester); err != nil {
+		return skerr.Wrap(err)
+	}
+	t.Done = true
+	t.ReleasedTime = time.Now()
+	if t.LeaseEndTime.After(t.ReleasedTime) {
+		t.LeaseEndTime = t.ReleasedTime
+	}
+	t.HealthCheckPending = true
+	t.SwarmingTaskState = getCompletedStateStr(false)
+	if _, err := UpdateDSTask(k, t); err != nil {
+		return skerr.Wrapf(err, "Error updating task in datastore")
+	}
+	sklog.Infof("Released machine %s for task %d", t.MachineID, k.ID)
+	if err := SendMachineCompletionEmail(t); err != nil {
+		return skerr.Wrapf(err, "Error sending completion email")
+	}
+	return nil
+}
+
+// checkMachineHealth checks the health of the released machine for the
+// provided Task and records the result once it is known.
+func checkMachineHealth(ctx context.Context, k *datastore.Key, t *types.Task) error {
+	status, reason, err := leaser.CheckHealth(ctx, t.MachineID, t.ReleasedTime)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if status == machinelease.HealthPending {
+		return nil
+	}
+	t.HealthCheckPending = false
+	t.HealthCheckResult = string(status)
+	if reason != "" {
+		t.HealthCheckResult = fmt.Sprintf("%s: %s", status, reason)
+	}
+	if _, err := UpdateDSTask(k, t); err != nil {
+		return skerr.Wrapf(err, "Error updating task in datastore")
+	}
+	if status == machinelease.HealthFailed {
+		if err := SendMachineHealthCheckFailureEmail(t, reason); err != nil {
+			return skerr.Wrapf(err, "Error sending health check failure email")
+		}
+	}
+	return nil
+}
+
+// pollMachineLeases expires machine leases which have ended, warns the
+// requesters of leases which are about to end, and checks the health of
+// released machines.
+func pollMachineLeases(ctx context.Context) error {
+	if leaser == nil {
+		return nil
+	}
+	it := GetRunningDSTasks()
+	for {
+		t := &types.Task{}
+		k, err := it.Next(t)
+		if err == iterator.Done {
+			break
+		} else if err != nil {
+			return skerr.Wrapf(err, "Failed to retrieve list of tasks")
+		}
+		if t.MachineID == "" {
+			continue
+		}
+		t.DatastoreId = k.ID
+		if t.LeaseEndTime.Before(time.Now()) {
+			if err := releaseMachine(ctx, k, t); err != nil {
+				return skerr.Wrapf(err, "Error when expiring machine lease")
+			}
+		} else if !t.WarningSent && t.LeaseEndTime.Before(time.Now().Add(time.Minute*15)) {
+			if err := SendMachineWarningEmail(t); err != nil {
+				return skerr.Wrapf(err, "Error sending 15m warning email")
+			}
+			t.WarningSent = true
+			if _, err := UpdateDSTask(k, t); err != nil {
+				return skerr.Wrapf(err, "Error updating task in datastore")
+			}
+		}
+	}
+
+	it = GetHealthCheckPendingDSTasks()
+	for {
+		t := &types.Task{}
+		k, err := it.Next(t)
+		if err == iterator.Done {
+			break
+		} else if err != nil {
+			return skerr.Wrapf(err, "Failed to retrieve list of tasks")
+		}
+		t.DatastoreId = k.ID
+		if err := checkMachineHealth(ctx, k, t); err != nil {
+			return skerr.Wrapf(err, "Error checking health of %s", t.MachineID)
+		}
+	}
+	return nil
+}
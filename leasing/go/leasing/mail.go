@@ -6,13 +6,16 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"go.skia.org/infra/email/go/emailclient"
 	"go.skia.org/infra/go/email"
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/rotations"
 	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/leasing/go/types"
 )
 
 const (
@@ -183,3 +186,106 @@ func getSwarmingLinkMarkup(taskLink string) (string, error) {
 func getUsernameFromEmail(e string) string {
 	return strings.Split(e, "@")[0]
 }
+
+// SendMachineStartEmail sends an email notifying the user that their machine
+// lease has started. It returns the email's threadingReference to use for
+// threading followup emails.
+func SendMachineStartEmail(t *types.Task) (string, error) {
+	bodyTemplate := `
+		You have leased the machine %s until %s.
+		<br/><br/>
+		Connect to it with: <code>ssh %s</code>
+		<br/>
+		%s
+		<br/><br/>
+		Contact the CC'ed Infra Gardener if you have any questions.
+		<br/><br/>
+		You can expire or extend the lease time <a href="%s">here</a>.
+		<br/>
+		Another email will be sent 15 mins before the lease end time.
+		<br/><br/>
+		Thanks!
+	`
+	body := fmt.Sprintf(bodyTemplate, t.MachineID, t.LeaseEndTime.UTC().Format(time.RFC1123), t.SSH, t.ConnectionInstructions, getMyLeasesLink())
+	return sendMachineEmail(t, body, "")
+}
+
+// SendMachineWarningEmail sends an email notifying the user that their machine
+// lease ends in less than 15 mins.
+func SendMachineWarningEmail(t *types.Task) error {
+	bodyTemplate := `
+		Your lease of the machine %s has less than 15 mins remaining.
+		<br/><br/>
+		You can expire or extend the lease time <a href="%s">here</a>.
+		<br/><br/>
+		Thanks!
+	`
+	body := fmt.Sprintf(bodyTemplate, t.MachineID, getMyLeasesLink())
+	if _, err := sendMachineEmail(t, body, t.EmailThreadingReference); err != nil {
+		return fmt.Errorf("Could not send warning email: %s", err)
+	}
+	return nil
+}
+
+// SendMachineExtensionEmail sends an email notifying the user that their
+// machine lease has been extended.
+func SendMachineExtensionEmail(t *types.Task, durationHrs int) error {
+	bodyTemplate := `
+		Your lease of the machine %s has been extended by %dhr.
+		<br/><br/>
+		Thanks!
+	`
+	body := fmt.Sprintf(bodyTemplate, t.MachineID, durationHrs)
+	if _, err := sendMachineEmail(t, body, t.EmailThreadingReference); err != nil {
+		return fmt.Errorf("Could not send extension email: %s", err)
+	}
+	return nil
+}
+
+// SendMachineCompletionEmail sends an email notifying the user that their
+// machine lease has ended.
+func SendMachineCompletionEmail(t *types.Task) error {
+	bodyTemplate := `
+		Your lease of the machine %s has completed. The machine will be returned
+		to its pool once it passes a health check.
+		<br/><br/>
+		If needed, you can lease more machines <a href="https://%s">here</a>.
+		<br/><br/>
+		Thanks!
+	`
+	body := fmt.Sprintf(bodyTemplate, t.MachineID, *host)
+	if _, err := sendMachineEmail(t, body, t.EmailThreadingReference); err != nil {
+		return fmt.Errorf("Could not send completion email: %s", err)
+	}
+	return nil
+}
+
+// SendMachineHealthCheckFailureEmail sends an email notifying the user and the
+// Infra Gardener that a machine failed its health check after being released.
+func SendMachineHealthCheckFailureEmail(t *types.Task, reason string) error {
+	bodyTemplate := `
+		The machine %s failed its health check after its lease ended: %s.
+		<br/><br/>
+		It remains in maintenance mode. The CC'ed Infra Gardener will take a look.
+		<br/><br/>
+		Thanks!
+	`
+	body := fmt.Sprintf(bodyTemplate, t.MachineID, reason)
+	if _, err := sendMachineEmail(t, body, t.EmailThreadingReference); err != nil {
+		return fmt.Errorf("Could not send health check failure email: %s", err)
+	}
+	return nil
+}
+
+func sendMachineEmail(t *types.Task, body, threadingReference string) (string, error) {
+	subject := getSubject(t.Requester, t.MachineID, strconv.FormatInt(t.DatastoreId, 10))
+	markup, err := email.GetViewActionMarkup(getMyLeasesLink(), "View Leases", "Direct link to your leases")
+	if err != nil {
+		return "", fmt.Errorf("Failed to get view action markup: %s", err)
+	}
+	return mail.SendWithMarkup(leasingEmailDisplayName, leasingEmailAddress, getRecipients(t.Requester), subject, body, markup, threadingReference)
+}
+
+func getMyLeasesLink() string {
+	return fmt.Sprintf("https://%s%s", *host, myLeasesURI)
+}
@@ -19,6 +19,7 @@ import (
 	"sync"
 	"time"
 
+	"cloud.google.com/go/datastore"
 	"github.com/go-chi/chi/v5"
 	"github.com/unrolled/secure"
 	swarming_api "go.chromium.org/luci/common/api/swarming/swarming/v1"
@@ -61,6 +62,7 @@ var (
 	artifactsDir               = flag.String("artifacts_dir", "", "The directory to find leasing server's artifacts.")
 	pollInterval               = flag.Duration("poll_interval", 1*time.Minute, "How often the leasing server will check if tasks have expired.")
 	poolDetailsUpdateFrequency = flag.Duration("pool_details_update_freq", 5*time.Minute, "How often to call swarming API to refresh the details of supported pools.")
+	machineConfig              = flag.String("machine_config", "", "The name of the machine server's configuration file, such as prod.json, as found in machine/go/configs. If set, machines can be leased through the machine server.")
 
 	// Datastore params
 	namespace   = flag.String("namespace", "leasing-server", "The Cloud Datastore namespace, such as 'leasing-server'.")
@@ -96,6 +98,13 @@ func New() (baseapp.App, error) {
 		sklog.Fatalf("Failed to init cloud datastore: %s", err)
 	}
 
+	// Initialize leasing through the machine server.
+	if *machineConfig != "" {
+		if err := MachineLeasingInit(ctx, *machineConfig); err != nil {
+			sklog.Fatalf("Failed to init machine leasing: %s", err)
+		}
+	}
+
 	var err error
 	poolToDetails, err = GetDetailsOfAllPools(ctx)
 	if err != nil {
@@ -119,6 +128,9 @@ func New() (baseapp.App, error) {
 			if err := pollSwarmingTasks(ctx); err != nil {
 				sklog.Errorf("Error when checking for expired tasks: %v", err)
 			}
+			if err := pollMachineLeases(ctx); err != nil {
+				sklog.Errorf("Error when checking for expired machine leases: %v", err)
+			}
 		}
 	}()
 
@@ -361,7 +373,13 @@ func (srv *Server) extendTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Inform the requester that the task has been extended by durationHrs.
-	if err := SendExtensionEmail(t.Requester, t.SwarmingServer, t.SwarmingTaskId, t.SwarmingBotId, t.EmailThreadingReference, extendRequest.DurationHrs); err != nil {
+	if t.MachineID != "" {
+		t.DatastoreId = k.ID
+		if err := SendMachineExtensionEmail(t, extendRequest.DurationHrs); err != nil {
+			httputils.ReportError(w, err, "Error sending extension email", http.StatusInternalServerError)
+			return
+		}
+	} else if err := SendExtensionEmail(t.Requester, t.SwarmingServer, t.SwarmingTaskId, t.SwarmingBotId, t.EmailThreadingReference, extendRequest.DurationHrs); err != nil {
 		httputils.ReportError(w, err, "Error sending extension email", http.StatusInternalServerError)
 		return
 	}
@@ -386,6 +404,19 @@ func (srv *Server) expireTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if t.MachineID != "" {
+		// Release the machine, which also sends the completion email.
+		t.DatastoreId = k.ID
+		if err := releaseMachine(r.Context(), k, t); err != nil {
+			httputils.ReportError(w, err, "Error releasing machine", http.StatusInternalServerError)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(t); err != nil {
+			sklog.Errorf("Failed to send response: %s", err)
+		}
+		return
+	}
+
 	// Change the task to Done, change the lease end time to now, and mark the
 	// state as successfully completed.
 	t.Done = true
@@ -418,6 +449,10 @@ func (srv *Server) addTaskHandler(w http.ResponseWriter, r *http.Request) {
 	defer util.Close(r.Body)
 
 	key := GetNewDSKey()
+	if task.MachineID != "" {
+		srv.addMachineTask(w, r, key, task)
+		return
+	}
 	if task.SwarmingBotId != "" {
 		// If BotId is specified then validate it so that we can fail fast if
 		// necessary.
@@ -488,6 +523,42 @@ func (srv *Server) addTaskHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// addMachineTask leases a machine through the machine server instead of
+// triggering a Swarming task.
+func (srv *Server) addMachineTask(w http.ResponseWriter, r *http.Request, key *datastore.Key, task *types.Task) {
+	// Validate the machine first so that we can fail fast if necessary.
+	if err := validateMachine(r.Context(), task.MachineID); err != nil {
+		httputils.ReportError(w, err, fmt.Sprintf("Could not lease machine %q: %s", task.MachineID, err), http.StatusBadRequest)
+		return
+	}
+	task.Requester = string(plogin.LoggedInAs(r))
+	task.Created = time.Now()
+	task.SwarmingTaskState = swarming.TASK_STATE_PENDING
+	// Store the task first so that its ID can be used in emails.
+	datastoreKey, err := PutDSTask(key, task)
+	if err != nil {
+		httputils.ReportError(w, err, fmt.Sprintf("Error putting task in datastore: %v", err), http.StatusInternalServerError)
+		return
+	}
+	task.DatastoreId = datastoreKey.ID
+	if err := leaseMachine(r.Context(), datastoreKey, task); err != nil {
+		// Record the failure on the task so that it does not appear to be
+		// pending forever.
+		task.Done = true
+		task.SwarmingTaskState = getCompletedStateStr(true)
+		if _, err := UpdateDSTask(datastoreKey, task); err != nil {
+			sklog.Errorf("Error updating task in datastore: %s", err)
+		}
+		httputils.ReportError(w, err, fmt.Sprintf("Error leasing machine %s: %s", task.MachineID, err), http.StatusInternalServerError)
+		return
+	}
+
+	sklog.Infof("Added %v machine lease into the datastore with key %s", task, datastoreKey)
+	if err := json.NewEncoder(w).Encode(task); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
 // AddMiddleware implements baseapp.App.
 func (srv *Server) AddMiddleware() []func(http.Handler) http.Handler {
 	return []func(http.Handler) http.Handler{}
@@ -143,6 +143,10 @@ func pollSwarmingTasks(ctx context.Context) error {
 			return fmt.Errorf("Failed to retrieve list of tasks: %s", err)
 		}
 
+		if t.MachineID != "" {
+			// Machine leases are polled by pollMachineLeases.
+			continue
+		}
 		if t.SwarmingTaskId == "" {
 			// This task is not ready to be looked at yet.
 			continue
@@ -0,0 +1,291 @@
+// Package machinelease leases test machines and their attached devices through
+// the machine server's store, rather than by triggering Swarming tasks.
+//
+// A leased machine is put into maintenance mode, which stops it from running
+// tasks, with a MaintenanceMode message that identifies the lease. On release
+// the machine is power cycled if possible and stays in maintenance mode until
+// it reports back healthy.
+package machinelease
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machine/store"
+)
+
+const (
+	// LeasedPrefix starts the MaintenanceMode message of leased machines.
+	LeasedPrefix = "leased: "
+
+	// HealthCheckPrefix starts the MaintenanceMode message of machines
+	// which have been released and are waiting for a health check.
+	HealthCheckPrefix = "lease health check: "
+
+	// HealthCheckTimeout is how long a released machine has to report
+	// back before it fails its health check.
+	HealthCheckTimeout = 30 * time.Minute
+
+	// sshUser is the user used to connect to test machines.
+	sshUser = "chrome-bot"
+
+	// annotationUser is the user recorded in annotations which are not
+	// made on behalf of a requester.
+	annotationUser = "leasing"
+)
+
+// ConnectionInfo describes how to connect to a leased machine.
+type ConnectionInfo struct {
+	// SSH is the destination to pass to ssh, eg. "chrome-bot@skia-rpi2-01".
+	SSH string `json:"ssh"`
+	// Instructions are the commands to run once connected to reach the
+	// attached device, if any.
+	Instructions string `json:"instructions"`
+}
+
+// GetConnectionInfo returns the ConnectionInfo for the given machine.
+func GetConnectionInfo(d machine.Description) ConnectionInfo {
+	machineID := d.Dimensions.GetDimensionValueOrEmptyString(machine.DimID)
+	rv := ConnectionInfo{
+		SSH: fmt.Sprintf("%s@%s", sshUser, machineID),
+	}
+	switch d.AttachedDevice {
+	case machine.AttachedDeviceAdb:
+		rv.Instructions = "Run 'adb devices' to find the attached device, then 'adb shell' to connect to it."
+	case machine.AttachedDeviceIOS:
+		rv.Instructions = "Run 'idevice_id -l' to find the attached device; use the idevice* tools to interact with it."
+	case machine.AttachedDeviceSSH:
+		rv.Instructions = fmt.Sprintf("Run 'ssh %s' to connect to the attached device.", d.SSHUserIP)
+	}
+	return rv
+}
+
+// IsLeased returns true iff the machine is leased.
+func IsLeased(d machine.Description) bool {
+	return strings.HasPrefix(d.MaintenanceMode, LeasedPrefix)
+}
+
+// isOwnMaintenanceMode returns true iff the machine is in maintenance mode due
+// to a lease or a lease health check.
+func isOwnMaintenanceMode(d machine.Description) bool {
+	return IsLeased(d) || strings.HasPrefix(d.MaintenanceMode, HealthCheckPrefix)
+}
+
+// unavailableReason returns a reason why the machine can not be leased, or the
+// empty string if it can.
+func unavailableReason(d machine.Description) string {
+	if d.InMaintenanceMode() {
+		return fmt.Sprintf("it is in maintenance mode: %s", d.MaintenanceMode)
+	}
+	if d.IsQuarantined {
+		return "it is quarantined"
+	}
+	if d.IsRecovering() {
+		return fmt.Sprintf("it is recovering: %s", d.Recovering)
+	}
+	return ""
+}
+
+// Leaser leases machines.
+type Leaser struct {
+	store store.Store
+}
+
+// New returns a Leaser which uses the given store.
+func New(s store.Store) *Leaser {
+	return &Leaser{
+		store: s,
+	}
+}
+
+// get returns the Description of the machine, or an error if the machine is
+// not in the store.
+//
+// The store's Update creates machines which do not exist, so this must be
+// called before every Update to avoid adding machines to, or restoring deleted
+// machines into, the store.
+func (l *Leaser) get(ctx context.Context, machineID string) (machine.Description, error) {
+	if machineID == "" {
+		return machine.Description{}, skerr.Fmt("a machine ID is required")
+	}
+	d, err := l.store.Get(ctx, machineID)
+	if err != nil {
+		return machine.Description{}, skerr.Wrapf(err, "failed to find machine %s", machineID)
+	}
+	return d, nil
+}
+
+// CheckAvailable returns an error if the machine does not exist or can not
+// currently be leased.
+func (l *Leaser) CheckAvailable(ctx context.Context, machineID string) error {
+	d, err := l.get(ctx, machineID)
+	if err != nil {
+		return err
+	}
+	if reason := unavailableReason(d); reason != "" {
+		return skerr.Fmt("%s can not be leased because %s", machineID, reason)
+	}
+	return nil
+}
+
+// Acquire puts the machine into the leased state until the given time and
+// returns the information needed to connect to it. Machines which are in
+// maintenance mode, quarantined or recovering can not be leased. A task
+// already running on the machine is allowed to finish, but no new tasks are
+// started.
+func (l *Leaser) Acquire(ctx context.Context, machineID, requester string, end time.Time) (ConnectionInfo, error) {
+	if err := l.CheckAvailable(ctx, machineID); err != nil {
+		return ConnectionInfo{}, err
+	}
+	ts := now.Now(ctx)
+	var reason string
+	var info ConnectionInfo
+	err := l.store.Update(ctx, machineID, func(d machine.Description) machine.Description {
+		// The callback may be called more than once.
+		reason = unavailableReason(d)
+		if reason != "" {
+			return d
+		}
+		ret := d.Copy()
+		ret.MaintenanceMode = fmt.Sprintf("%s%s until %s", LeasedPrefix, requester, end.UTC().Format(time.RFC3339))
+		ret.Annotation = machine.Annotation{
+			Message:   "Leased",
+			User:      requester,
+			Timestamp: ts,
+		}
+		info = GetConnectionInfo(ret)
+		return ret
+	})
+	if err != nil {
+		return ConnectionInfo{}, skerr.Wrapf(err, "failed to lease %s", machineID)
+	}
+	if reason != "" {
+		return ConnectionInfo{}, skerr.Fmt("%s can not be leased because %s", machineID, reason)
+	}
+	return info, nil
+}
+
+// Release ends the lease of the machine and starts its health check. The
+// machine is power cycled if possible and remains in maintenance mode until
+// CheckHealth finds it healthy.
+func (l *Leaser) Release(ctx context.Context, machineID, requester string) error {
+	d, err := l.get(ctx, machineID)
+	if err != nil {
+		return err
+	}
+	if !IsLeased(d) {
+		// Someone else has since taken over the machine, so leave it be.
+		return nil
+	}
+	ts := now.Now(ctx)
+	err = l.store.Update(ctx, machineID, func(d machine.Description) machine.Description {
+		if !IsLeased(d) {
+			// Someone else has since taken over the machine.
+			return d
+		}
+		ret := d.Copy()
+		ret.MaintenanceMode = fmt.Sprintf("%sreleased by %s at %s", HealthCheckPrefix, requester, ts.UTC().Format(time.RFC3339))
+		ret.Annotation = machine.Annotation{
+			Message:   "Lease released",
+			User:      requester,
+			Timestamp: ts,
+		}
+		if ret.PowerCycleState == machine.Available {
+			ret.PowerCycle = true
+		}
+		return ret
+	})
+	return skerr.Wrapf(err, "failed to release %s", machineID)
+}
+
+// HealthStatus is the result of a health check.
+type HealthStatus string
+
+const (
+	// HealthPending indicates that the machine has not yet reported its
+	// state since it was released.
+	HealthPending HealthStatus = "pending"
+	// HealthPassed indicates that the machine has been returned to its
+	// pool.
+	HealthPassed HealthStatus = "passed"
+	// HealthFailed indicates that the machine failed its health check and
+	// remains in maintenance mode.
+	HealthFailed HealthStatus = "failed"
+)
+
+// healthStatus returns the result of the health check of the given machine,
+// which was released at the given time, as of ts.
+func healthStatus(d machine.Description, released, ts time.Time) (HealthStatus, string) {
+	if !strings.HasPrefix(d.MaintenanceMode, HealthCheckPrefix) {
+		// The health check has already completed, or someone has since
+		// taken over the machine.
+		if d.InMaintenanceMode() && !isOwnMaintenanceMode(d) {
+			return HealthFailed, fmt.Sprintf("machine was put into maintenance mode: %s", d.MaintenanceMode)
+		}
+		return HealthPassed, ""
+	}
+	if d.LastUpdated.After(released) && !d.PowerCycle {
+		if d.IsQuarantined {
+			return HealthFailed, "machine is quarantined"
+		}
+		if d.IsRecovering() {
+			return HealthFailed, fmt.Sprintf("machine is recovering: %s", d.Recovering)
+		}
+		return HealthPassed, ""
+	}
+	if ts.Sub(released) > HealthCheckTimeout {
+		return HealthFailed, fmt.Sprintf("machine did not report back within %s of release", HealthCheckTimeout)
+	}
+	return HealthPending, ""
+}
+
+// CheckHealth checks the health of a machine which was released at the given
+// time. A healthy machine which has reported its state since it was released
+// is taken out of maintenance mode. If the machine is unhealthy, or it does
+// not report back within HealthCheckTimeout, it remains in maintenance mode
+// with a message describing the failure, which is also returned.
+func (l *Leaser) CheckHealth(ctx context.Context, machineID string, released time.Time) (HealthStatus, string, error) {
+	d, err := l.get(ctx, machineID)
+	if err != nil {
+		return "", "", skerr.Wrapf(err, "failed to check health of %s", machineID)
+	}
+	ts := now.Now(ctx)
+	status, reason := healthStatus(d, released, ts)
+	if status == HealthPending || !strings.HasPrefix(d.MaintenanceMode, HealthCheckPrefix) {
+		// Nothing to write.
+		return status, reason, nil
+	}
+	err = l.store.Update(ctx, machineID, func(d machine.Description) machine.Description {
+		// The callback may be called more than once.
+		status, reason = healthStatus(d, released, ts)
+		if status == HealthPending || !strings.HasPrefix(d.MaintenanceMode, HealthCheckPrefix) {
+			return d
+		}
+		ret := d.Copy()
+		if status == HealthPassed {
+			ret.MaintenanceMode = ""
+			ret.Annotation = machine.Annotation{
+				Message:   "Lease health check passed",
+				User:      annotationUser,
+				Timestamp: ts,
+			}
+		} else {
+			ret.MaintenanceMode = fmt.Sprintf("lease health check failed: %s", reason)
+			ret.Annotation = machine.Annotation{
+				Message:   ret.MaintenanceMode,
+				User:      annotationUser,
+				Timestamp: ts,
+			}
+		}
+		return ret
+	})
+	if err != nil {
+		return "", "", skerr.Wrapf(err, "failed to check health of %s", machineID)
+	}
+	return status, reason, nil
+}
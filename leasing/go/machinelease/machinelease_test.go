@@ -0,0 +1,237 @@
+package machinelease
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machine/pools"
+	"go.skia.org/infra/machine/go/machine/pools/poolstest"
+	"go.skia.org/infra/machine/go/machine/store"
+	"go.skia.org/infra/machine/go/machine/store/cdb"
+	"go.skia.org/infra/machine/go/machine/store/cdb/cdbtest"
+	"go.skia.org/infra/machine/go/machine/store/mocks"
+)
+
+const (
+	machineID = "skia-rpi2-rack1-shelf1-001"
+	requester = "somebody@example.org"
+)
+
+var (
+	fakeNow  = time.Date(2021, time.September, 1, 10, 0, 0, 0, time.UTC)
+	leaseEnd = fakeNow.Add(2 * time.Hour)
+)
+
+// setup returns a Leaser whose store holds a single machine with the given
+// Description, and a pointer to that Description which reflects updates.
+func setup(t *testing.T, d machine.Description) (context.Context, *Leaser, *machine.Description) {
+	ctx := context.WithValue(context.Background(), now.ContextKey, fakeNow)
+	d.Dimensions = machine.SwarmingDimensions{
+		machine.DimID: []string{machineID},
+	}
+	s := mocks.NewStore(t)
+	s.On("Get", testutils.AnyContext, machineID).Return(func(context.Context, string) machine.Description {
+		return d
+	}, nil).Maybe()
+	s.On("Update", testutils.AnyContext, machineID, mock.Anything).Run(func(args mock.Arguments) {
+		cb := args.Get(2).(store.UpdateCallback)
+		d = cb(d)
+	}).Return(nil).Maybe()
+	return ctx, New(s), &d
+}
+
+// setupCockroachDB returns a Leaser which uses a CockroachDB store holding a
+// single machine with the given Description.
+func setupCockroachDB(t *testing.T, d machine.Description) (context.Context, *Leaser, store.Store) {
+	ctx := context.WithValue(context.Background(), now.ContextKey, fakeNow)
+	db := cdbtest.NewCockroachDBForTests(t, "machinelease")
+	p, err := pools.New(poolstest.PoolConfigForTesting)
+	require.NoError(t, err)
+	s, err := cdb.New(db, p)
+	require.NoError(t, err)
+	require.NoError(t, s.Update(ctx, machineID, func(machine.Description) machine.Description {
+		ret := d.Copy()
+		ret.Dimensions = machine.SwarmingDimensions{
+			machine.DimID: []string{machineID},
+		}
+		return ret
+	}))
+	return ctx, New(s), s
+}
+
+func assertNotInStore(ctx context.Context, t *testing.T, s store.Store, machineID string) {
+	all, err := s.List(ctx)
+	require.NoError(t, err)
+	for _, d := range all {
+		require.NotEqual(t, machineID, d.Dimensions.GetDimensionValueOrEmptyString(machine.DimID))
+	}
+}
+
+func TestAcquire_MachineAvailable_MachineLeasedAndConnectionInfoReturned(t *testing.T) {
+	ctx, l, d := setup(t, machine.Description{
+		AttachedDevice: machine.AttachedDeviceAdb,
+	})
+	info, err := l.Acquire(ctx, machineID, requester, leaseEnd)
+	require.NoError(t, err)
+	require.Equal(t, "chrome-bot@"+machineID, info.SSH)
+	require.Contains(t, info.Instructions, "adb shell")
+	require.True(t, IsLeased(*d))
+	require.Equal(t, "leased: somebody@example.org until 2021-09-01T12:00:00Z", d.MaintenanceMode)
+	require.Equal(t, requester, d.Annotation.User)
+}
+
+func TestAcquire_UnknownMachine_ReturnsErrorWithoutUpdatingStore(t *testing.T) {
+	ctx := context.WithValue(context.Background(), now.ContextKey, fakeNow)
+	s := mocks.NewStore(t)
+	s.On("Get", testutils.AnyContext, "unknown").Return(machine.Description{}, errors.New("no rows in result set"))
+	_, err := New(s).Acquire(ctx, "unknown", requester, leaseEnd)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to find machine unknown")
+}
+
+func TestAcquire_EmptyMachineID_ReturnsError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), now.ContextKey, fakeNow)
+	_, err := New(mocks.NewStore(t)).Acquire(ctx, "", requester, leaseEnd)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "machine ID is required")
+}
+
+func TestAcquire_MachineQuarantined_ReturnsErrorAndMachineUnchanged(t *testing.T) {
+	ctx, l, d := setup(t, machine.Description{
+		IsQuarantined: true,
+	})
+	_, err := l.Acquire(ctx, machineID, requester, leaseEnd)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "quarantined")
+	require.False(t, d.InMaintenanceMode())
+}
+
+func TestAcquire_MachineInMaintenanceMode_ReturnsError(t *testing.T) {
+	ctx, l, _ := setup(t, machine.Description{
+		MaintenanceMode: "someone-else 2021-08-31T00:00:00Z",
+	})
+	_, err := l.Acquire(ctx, machineID, requester, leaseEnd)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "maintenance mode")
+}
+
+func TestRelease_MachineLeased_StartsHealthCheckAndPowerCycles(t *testing.T) {
+	ctx, l, d := setup(t, machine.Description{
+		PowerCycleState: machine.Available,
+	})
+	_, err := l.Acquire(ctx, machineID, requester, leaseEnd)
+	require.NoError(t, err)
+
+	require.NoError(t, l.Release(ctx, machineID, requester))
+	require.False(t, IsLeased(*d))
+	require.Contains(t, d.MaintenanceMode, HealthCheckPrefix)
+	require.True(t, d.PowerCycle)
+}
+
+func TestRelease_MachineNotLeased_DoesNotUpdateStore(t *testing.T) {
+	ctx := context.WithValue(context.Background(), now.ContextKey, fakeNow)
+	s := mocks.NewStore(t)
+	s.On("Get", testutils.AnyContext, machineID).Return(machine.Description{
+		MaintenanceMode: "someone-else 2021-08-31T00:00:00Z",
+	}, nil)
+	require.NoError(t, New(s).Release(ctx, machineID, requester))
+}
+
+func TestCheckHealth_MachineHasNotReportedBack_Pending(t *testing.T) {
+	ctx, l, d := setup(t, machine.Description{
+		MaintenanceMode: HealthCheckPrefix + "released",
+		LastUpdated:     fakeNow.Add(-10 * time.Minute),
+	})
+	status, _, err := l.CheckHealth(ctx, machineID, fakeNow.Add(-5*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, HealthPending, status)
+	require.Equal(t, HealthCheckPrefix+"released", d.MaintenanceMode)
+}
+
+func TestCheckHealth_MachineReportedBackHealthy_ReturnedToPool(t *testing.T) {
+	ctx, l, d := setup(t, machine.Description{
+		MaintenanceMode: HealthCheckPrefix + "released",
+		LastUpdated:     fakeNow.Add(-time.Minute),
+	})
+	status, _, err := l.CheckHealth(ctx, machineID, fakeNow.Add(-10*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, HealthPassed, status)
+	require.False(t, d.InMaintenanceMode())
+}
+
+func TestCheckHealth_MachineReportedBackRecovering_FailsAndStaysInMaintenanceMode(t *testing.T) {
+	ctx, l, d := setup(t, machine.Description{
+		MaintenanceMode: HealthCheckPrefix + "released",
+		LastUpdated:     fakeNow.Add(-time.Minute),
+		Recovering:      "too hot",
+	})
+	status, reason, err := l.CheckHealth(ctx, machineID, fakeNow.Add(-10*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, HealthFailed, status)
+	require.Contains(t, reason, "too hot")
+	require.Contains(t, d.MaintenanceMode, "lease health check failed")
+}
+
+func TestCheckHealth_MachineNeverReportedBack_FailsAfterTimeout(t *testing.T) {
+	released := fakeNow.Add(-HealthCheckTimeout - time.Minute)
+	ctx, l, d := setup(t, machine.Description{
+		MaintenanceMode: HealthCheckPrefix + "released",
+		LastUpdated:     released.Add(-time.Minute),
+	})
+	status, _, err := l.CheckHealth(ctx, machineID, released)
+	require.NoError(t, err)
+	require.Equal(t, HealthFailed, status)
+	require.Contains(t, d.MaintenanceMode, "did not report back")
+}
+
+func TestCheckHealth_HealthCheckAlreadyCompleted_DoesNotUpdateStore(t *testing.T) {
+	ctx := context.WithValue(context.Background(), now.ContextKey, fakeNow)
+	s := mocks.NewStore(t)
+	s.On("Get", testutils.AnyContext, machineID).Return(machine.Description{}, nil)
+	status, _, err := New(s).CheckHealth(ctx, machineID, fakeNow.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, HealthPassed, status)
+}
+
+func TestAcquire_CockroachDBStore_UnknownMachine_NotAddedToStore(t *testing.T) {
+	ctx, l, s := setupCockroachDB(t, machine.Description{})
+	_, err := l.Acquire(ctx, "unknown", requester, leaseEnd)
+	require.Error(t, err)
+	assertNotInStore(ctx, t, s, "unknown")
+}
+
+func TestReleaseAndCheckHealth_CockroachDBStore_DeletedMachine_NotRestored(t *testing.T) {
+	ctx, l, s := setupCockroachDB(t, machine.Description{})
+	_, err := l.Acquire(ctx, machineID, requester, leaseEnd)
+	require.NoError(t, err)
+	require.NoError(t, s.Delete(ctx, machineID))
+
+	require.Error(t, l.Release(ctx, machineID, requester))
+	_, _, err = l.CheckHealth(ctx, machineID, fakeNow)
+	require.Error(t, err)
+	assertNotInStore(ctx, t, s, machineID)
+}
+
+func TestAcquireAndRelease_CockroachDBStore_Success(t *testing.T) {
+	ctx, l, s := setupCockroachDB(t, machine.Description{
+		PowerCycleState: machine.Available,
+	})
+	_, err := l.Acquire(ctx, machineID, requester, leaseEnd)
+	require.NoError(t, err)
+	d, err := s.Get(ctx, machineID)
+	require.NoError(t, err)
+	require.True(t, IsLeased(d))
+
+	require.NoError(t, l.Release(ctx, machineID, requester))
+	d, err = s.Get(ctx, machineID)
+	require.NoError(t, err)
+	require.Contains(t, d.MaintenanceMode, HealthCheckPrefix)
+	require.True(t, d.PowerCycle)
+}
@@ -23,6 +23,16 @@ type Task struct {
 	SwarmingTaskId    string `json:"swarmingTaskId"`
 	SwarmingTaskState string `json:"swarmingTaskState"`
 
+	// MachineID is set for leases of machines managed by the machine
+	// server, which are leased through the machine store instead of by
+	// triggering a Swarming task.
+	MachineID              string    `json:"machineId"`
+	SSH                    string    `json:"ssh"`
+	ConnectionInstructions string    `json:"connectionInstructions"`
+	ReleasedTime           time.Time `json:"releasedTime"`
+	HealthCheckPending     bool      `json:"healthCheckPending"`
+	HealthCheckResult      string    `json:"healthCheckResult"`
+
 	DatastoreId int64 `json:"datastoreId"`
 
 	// Left for backwards compatibility but no longer used.
@@ -18,6 +18,12 @@ export interface Task {
 	swarmingServer: string;
 	swarmingTaskId: string;
 	swarmingTaskState: string;
+	machineId: string;
+	ssh: string;
+	connectionInstructions: string;
+	releasedTime: string;
+	healthCheckPending: boolean;
+	healthCheckResult: string;
 	datastoreId: number;
 	architecture: string;
 	setupDebugger: boolean;
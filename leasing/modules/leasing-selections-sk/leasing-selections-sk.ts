@@ -99,6 +99,13 @@ export class LeasingSelectionsSk extends ElementSk {
         </td>
       </tr>
 
+      <tr>
+        <td class="step-title">Or lease a machine from the<br/>machine server directly by its Id<br/>(optional)</td>
+        <td>
+          <input id="machine_id" ?disabled=${ele.loadingDetails}></input>
+        </td>
+      </tr>
+
       <tr>
         <td class="step-title">Specify Swarming Task Id<br/>to keep artifacts ready on bot<br/>(optional)</td>
         <td>
@@ -215,6 +222,7 @@ export class LeasingSelectionsSk extends ElementSk {
     const deviceType = ($$('#device_type', this) as HTMLInputElement).value;
     const botId = ($$('#bot_id', this) as HTMLInputElement).value;
     const taskId = ($$('#task_id', this) as HTMLInputElement).value;
+    const machineId = ($$('#machine_id', this) as HTMLInputElement).value;
     const duration = ($$('#duration', this) as HTMLInputElement).value;
     const desc = ($$('#desc', this) as HTMLInputElement).value;
 
@@ -241,6 +249,7 @@ export class LeasingSelectionsSk extends ElementSk {
       }
     }
     detail.taskIdForIsolates = taskId;
+    detail.machineId = machineId;
     detail.duration = duration;
     detail.description = desc;
 
@@ -83,7 +83,30 @@ function displaySwarmingTaskForIsolates(task: Task): TemplateResult {
   `;
 }
 
+function displayMachine(task: Task): TemplateResult {
+  if (!task.machineId) {
+    return html``;
+  }
+  let healthCheck = html``;
+  if (task.healthCheckPending) {
+    healthCheck = html`<br />Health Check: Pending`;
+  } else if (task.healthCheckResult) {
+    healthCheck = html`<br />Health Check: ${task.healthCheckResult}`;
+  }
+  return html`
+    <br />
+    Machine: ${task.machineId}
+    <br />
+    Connect: <code>ssh ${task.ssh}</code>
+    <br />
+    ${task.connectionInstructions} ${healthCheck}
+  `;
+}
+
 function displaySwarmingTask(task: Task): TemplateResult {
+  if (task.machineId) {
+    return html`N/A`;
+  }
   if (!task.swarmingTaskId) {
     return html`Processing`;
   }
@@ -125,6 +148,7 @@ export class LeasingTaskSk extends ElementSk {
           Pool: ${ele.leasingTask.pool} ${displayDimensions(ele.leasingTask)}
           ${displayBotId(ele.leasingTask)}
           ${displaySwarmingTaskForIsolates(ele.leasingTask)}
+          ${displayMachine(ele.leasingTask)}
         </td>
         <td>
           Task Log:${displaySwarmingTask(ele.leasingTask)}
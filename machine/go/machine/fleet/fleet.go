@@ -0,0 +1,110 @@
+// Package fleet computes fleet wide health reports from the history of
+// machine.Descriptions.
+package fleet
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultMinDays is the minimum number of days of battery samples a
+	// machine needs before a trend is computed for it.
+	DefaultMinDays = 3
+
+	// DefaultSlopeThreshold is the change in daily maximum battery charge, in
+	// percent per day, at or below which a battery is considered degrading.
+	DefaultSlopeThreshold = -1.0
+)
+
+// QuarantineCount is the number of times a machine entered quarantine.
+type QuarantineCount struct {
+	MachineID string
+	Count     int
+}
+
+// DailyBattery is the maximum battery charge seen for a machine on a single
+// day. A healthy battery reaches the same maximum charge day after day, while
+// a degrading one reaches a lower maximum every day.
+type DailyBattery struct {
+	MachineID  string
+	Day        time.Time
+	MaxBattery int
+}
+
+// BatteryTrend describes how the daily maximum battery charge of a machine
+// changed over a time range.
+type BatteryTrend struct {
+	MachineID string
+
+	// Days is the number of days that had battery samples.
+	Days int
+
+	// FirstMax and LastMax are the maximum charges on the first and last day.
+	FirstMax int
+	LastMax  int
+
+	// SlopePerDay is the least squares fit of the daily maximum charge, in
+	// percent per day.
+	SlopePerDay float64
+}
+
+// DegradingBatteries returns the trends of all machines whose daily maximum
+// battery charge is falling by slopeThreshold percent a day or faster, sorted
+// with the fastest degrading batteries first. Machines with fewer than minDays
+// of samples are ignored.
+func DegradingBatteries(samples []DailyBattery, minDays int, slopeThreshold float64) []BatteryTrend {
+	byMachine := map[string][]DailyBattery{}
+	for _, s := range samples {
+		byMachine[s.MachineID] = append(byMachine[s.MachineID], s)
+	}
+
+	ret := []BatteryTrend{}
+	for machineID, days := range byMachine {
+		if len(days) < minDays || len(days) < 2 {
+			continue
+		}
+		sort.Slice(days, func(i, j int) bool {
+			return days[i].Day.Before(days[j].Day)
+		})
+		slope := slopePerDay(days)
+		if slope > slopeThreshold {
+			continue
+		}
+		ret = append(ret, BatteryTrend{
+			MachineID:   machineID,
+			Days:        len(days),
+			FirstMax:    days[0].MaxBattery,
+			LastMax:     days[len(days)-1].MaxBattery,
+			SlopePerDay: slope,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].SlopePerDay != ret[j].SlopePerDay {
+			return ret[i].SlopePerDay < ret[j].SlopePerDay
+		}
+		return ret[i].MachineID < ret[j].MachineID
+	})
+	return ret
+}
+
+// slopePerDay returns the slope of the least squares fit of MaxBattery over
+// time, measured in days since the first sample. days must be sorted and have
+// at least two entries.
+func slopePerDay(days []DailyBattery) float64 {
+	n := float64(len(days))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, d := range days {
+		x := d.Day.Sub(days[0].Day).Hours() / 24
+		y := float64(d.MaxBattery)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
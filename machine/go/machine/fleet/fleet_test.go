@@ -0,0 +1,61 @@
+package fleet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var day0 = time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+func dailyBatteries(machineID string, maxBatteries ...int) []DailyBattery {
+	ret := []DailyBattery{}
+	for i, b := range maxBatteries {
+		ret = append(ret, DailyBattery{
+			MachineID:  machineID,
+			Day:        day0.Add(time.Duration(i) * 24 * time.Hour),
+			MaxBattery: b,
+		})
+	}
+	return ret
+}
+
+func TestDegradingBatteries_MixOfHealthyAndDegradingBatteries_ReturnsDegradingSortedBySlope(t *testing.T) {
+	samples := dailyBatteries("skia-rpi2-0001", 100, 100, 99, 100)
+	samples = append(samples, dailyBatteries("skia-rpi2-0002", 100, 98, 96, 94)...)
+	samples = append(samples, dailyBatteries("skia-rpi2-0003", 90, 80, 70, 60)...)
+
+	require.Equal(t, []BatteryTrend{
+		{
+			MachineID:   "skia-rpi2-0003",
+			Days:        4,
+			FirstMax:    90,
+			LastMax:     60,
+			SlopePerDay: -10,
+		},
+		{
+			MachineID:   "skia-rpi2-0002",
+			Days:        4,
+			FirstMax:    100,
+			LastMax:     94,
+			SlopePerDay: -2,
+		},
+	}, DegradingBatteries(samples, DefaultMinDays, DefaultSlopeThreshold))
+}
+
+func TestDegradingBatteries_TooFewDays_MachineIsIgnored(t *testing.T) {
+	samples := dailyBatteries("skia-rpi2-0001", 90, 50)
+	require.Empty(t, DegradingBatteries(samples, DefaultMinDays, DefaultSlopeThreshold))
+}
+
+func TestDegradingBatteries_SamplesOutOfOrder_SortedBeforeComputingTrend(t *testing.T) {
+	samples := dailyBatteries("skia-rpi2-0001", 90, 80, 70)
+	samples[0], samples[2] = samples[2], samples[0]
+
+	trends := DegradingBatteries(samples, DefaultMinDays, DefaultSlopeThreshold)
+	require.Len(t, trends, 1)
+	require.Equal(t, 90, trends[0].FirstMax)
+	require.Equal(t, 70, trends[0].LastMax)
+	require.Equal(t, -10.0, trends[0].SlopePerDay)
+}
@@ -132,9 +132,14 @@ const (
 	// AttachedDeviceSSH means a ChromeOS device, or any other device we
 	// interact with via SSH.
 	AttachedDeviceSSH AttachedDevice = "ssh"
+
+	// AttachedDeviceProbe means a device that test_machine_monitor has no
+	// built-in support for, which is interrogated by running an external probe
+	// plugin executable. See Probe.
+	AttachedDeviceProbe AttachedDevice = "probe"
 )
 
-var AllAttachedDevices = []AttachedDevice{AttachedDeviceNone, AttachedDeviceAdb, AttachedDeviceIOS, AttachedDeviceSSH}
+var AllAttachedDevices = []AttachedDevice{AttachedDeviceNone, AttachedDeviceAdb, AttachedDeviceIOS, AttachedDeviceSSH, AttachedDeviceProbe}
 
 // Annotation represents a timestamped message.
 type Annotation struct {
@@ -422,12 +427,44 @@ func (s *Standalone) IsPopulated() bool {
 	return s.Cores > 0
 }
 
+// Probe is the device report emitted as JSON by a probe plugin executable. It
+// allows new kinds of devices to be added to the fleet without changes to
+// test_machine_monitor or machineserver.
+type Probe struct {
+	// Dimensions of the attached device, e.g. "device_type" and "os". A probe
+	// must report at least one dimension when a device is attached. The "id"
+	// dimension is always set to the host name and can't be overridden.
+	Dimensions SwarmingDimensions `json:"dimensions"`
+
+	// Battery charge as an integer percent, or nil if the device doesn't have
+	// a battery.
+	Battery *int `json:"battery,omitempty"`
+
+	// Temperature of each of the device's sensors, in Celsius.
+	Temperature map[string]float64 `json:"temperature,omitempty"`
+
+	// Health is a list of problems the probe found with the device. An empty
+	// list means the device is healthy, otherwise the machine is put into
+	// recovery mode.
+	Health []string `json:"health,omitempty"`
+
+	// UptimeSeconds is how long the device has been running.
+	UptimeSeconds int64 `json:"uptime_s"`
+}
+
+// IsPopulated returns whether the Probe subevent record has been filled out,
+// implying a device reported by a probe plugin is attached.
+func (p *Probe) IsPopulated() bool {
+	return len(p.Dimensions) > 0
+}
+
 // Event is the information a machine should send via Source when its local state has changed.
 type Event struct {
 	EventType           EventType  `json:"type"`
 	Android             Android    `json:"android"`
 	ChromeOS            ChromeOS   `json:"chromeos"`
 	IOS                 IOS        `json:"ios"`
+	Probe               Probe      `json:"probe"`
 	Standalone          Standalone `json:"standalone"`
 	Host                Host       `json:"host"`
 	RunningSwarmingTask bool       `json:"running_swarming_task"`
@@ -459,3 +496,72 @@ type TaskResult struct {
 	machineIndex struct{}         `sql:"INDEX by_machine_id (machine_id)"`
 	statusIndex  struct{}         `sql:"INDEX by_status (status)"`
 }
+
+// HistorySampleInterval is the granularity of DescriptionHistory. At most one
+// sample is kept per machine for each interval, the last one recorded wins.
+const HistorySampleInterval = 5 * time.Minute
+
+// DescriptionHistory is a point in time sample of the parts of a Description
+// that are useful to track over time, such as battery charge and quarantine
+// state.
+type DescriptionHistory struct {
+	MachineID string `sql:"machine_id STRING NOT NULL"`
+
+	// Timestamp is the start of the HistorySampleInterval this sample was
+	// recorded in.
+	Timestamp time.Time `sql:"ts TIMESTAMPTZ NOT NULL"`
+
+	Battery      int                `sql:"battery INT NOT NULL DEFAULT 0"` // Charge as an integer percent, e.g. 50% = 50.
+	Temperature  map[string]float64 `sql:"temperatures JSONB NOT NULL"`    // In Celsius.
+	DeviceUptime int32              `sql:"device_uptime INT4 NOT NULL DEFAULT 0"`
+
+	IsQuarantined bool `sql:"is_quarantined BOOL NOT NULL DEFAULT FALSE"`
+	Maintenance   bool `sql:"maintenance BOOL NOT NULL DEFAULT FALSE"`
+	Recovering    bool `sql:"recovering BOOL NOT NULL DEFAULT FALSE"`
+
+	// RunningTask is true if the machine was running either a Swarming task
+	// or a Task Scheduler task.
+	RunningTask bool `sql:"running_task BOOL NOT NULL DEFAULT FALSE"`
+
+	primaryKey struct{} `sql:"PRIMARY KEY (machine_id, ts)"`
+
+	// Fleet wide reports query by time range across all machines.
+	timestampIndex struct{} `sql:"INDEX by_ts (ts)"`
+}
+
+// NewDescriptionHistory returns a DescriptionHistory sample of the given
+// Description recorded at time ts.
+func NewDescriptionHistory(d Description, ts time.Time) DescriptionHistory {
+	temperature := make(map[string]float64, len(d.Temperature))
+	for k, v := range d.Temperature {
+		temperature[k] = v
+	}
+	return DescriptionHistory{
+		MachineID:     d.Dimensions.GetDimensionValueOrEmptyString(DimID),
+		Timestamp:     ts.UTC().Truncate(HistorySampleInterval),
+		Battery:       d.Battery,
+		Temperature:   temperature,
+		DeviceUptime:  d.DeviceUptime,
+		IsQuarantined: d.IsQuarantined,
+		Maintenance:   d.InMaintenanceMode(),
+		Recovering:    d.IsRecovering(),
+		RunningTask:   d.RunningSwarmingTask || d.TaskRequest != nil,
+	}
+}
+
+// DestFromDescriptionHistory returns a slice of interface containing pointers
+// to every public member of DescriptionHistory, in the same order as the
+// columns in the SQL table.
+func DestFromDescriptionHistory(h *DescriptionHistory) []interface{} {
+	return []interface{}{
+		&h.MachineID,
+		&h.Timestamp,
+		&h.Battery,
+		&h.Temperature,
+		&h.DeviceUptime,
+		&h.IsQuarantined,
+		&h.Maintenance,
+		&h.Recovering,
+		&h.RunningTask,
+	}
+}
@@ -137,3 +137,26 @@ func TestDescription_InMaintenanceMode_ReturnsTrueIfHasMaintenanceModeMessage(t
 func TestDescription_InMaintenanceMode_ReturnsFalseIfMaintenanceModeMessageIsEmpty(t *testing.T) {
 	require.False(t, machine.Description{}.InMaintenanceMode())
 }
+
+func TestNewDescriptionHistory_FullyFilledInDescription_TimestampIsTruncatedToSampleInterval(t *testing.T) {
+	d := machinetest.FullyFilledInDescription.Copy()
+	d.MaintenanceMode = "barney@example.com"
+	ts := time.Date(2021, time.September, 1, 10, 7, 5, 0, time.UTC)
+
+	h := machine.NewDescriptionHistory(d, ts)
+	require.Equal(t, machine.DescriptionHistory{
+		MachineID:     d.Dimensions[machine.DimID][0],
+		Timestamp:     time.Date(2021, time.September, 1, 10, 5, 0, 0, time.UTC),
+		Battery:       d.Battery,
+		Temperature:   d.Temperature,
+		DeviceUptime:  d.DeviceUptime,
+		IsQuarantined: d.IsQuarantined,
+		Maintenance:   true,
+		Recovering:    d.IsRecovering(),
+		RunningTask:   true,
+	}, h)
+
+	// Confirm the temperatures are a copy.
+	h.Temperature["cpu"] = 0
+	require.NotEqual(t, d.Temperature, h.Temperature)
+}
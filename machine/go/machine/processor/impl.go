@@ -125,6 +125,8 @@ func (p *ProcessorImpl) processEvent(ctx context.Context, previous machine.Descr
 		return processChromeOSEvent(ctx, previous, event)
 	} else if event.IOS.IsPopulated() {
 		return processIOSEvent(ctx, previous, event)
+	} else if event.Probe.IsPopulated() {
+		return processProbeEvent(ctx, previous, event)
 	} else if event.Standalone.IsPopulated() {
 		return processStandaloneEvent(ctx, previous, event)
 	}
@@ -286,6 +288,45 @@ func processIOSEvent(ctx context.Context, previous machine.Description, event ma
 	return ret
 }
 
+// processProbeEvent processes an event from a machine whose attached device is
+// interrogated by a probe plugin executable.
+func processProbeEvent(ctx context.Context, previous machine.Description, event machine.Event) machine.Description {
+	machineID := event.Host.Name
+	ret := previous.Copy()
+	for k, values := range event.Probe.Dimensions {
+		ret.Dimensions[k] = values
+	}
+	ret.Dimensions[machine.DimID] = []string{machineID}
+
+	maintenanceMessages := []string{}
+	ret.Battery = 0
+	if event.Probe.Battery != nil {
+		ret.Battery = *event.Probe.Battery
+		if ret.Battery < minBatteryLevel {
+			maintenanceMessages = append(maintenanceMessages, "Battery low.")
+		}
+		metrics2.GetInt64Metric("machine_processor_device_battery_level", map[string]string{"machine": machineID}).Update(int64(ret.Battery))
+	}
+
+	ret.Temperature = nil
+	if len(event.Probe.Temperature) > 0 {
+		ret.Temperature = event.Probe.Temperature
+		if findMaxTemperature(ret.Temperature) > maxTemperatureC {
+			maintenanceMessages = append(maintenanceMessages, "Too hot.")
+		}
+		for sensor, temp := range ret.Temperature {
+			metrics2.GetFloat64Metric("machine_processor_device_temperature_c", map[string]string{"machine": machineID, "sensor": sensor}).Update(temp)
+		}
+	}
+
+	maintenanceMessages = append(maintenanceMessages, event.Probe.Health...)
+	ret.DeviceUptime = int32(event.Probe.UptimeSeconds)
+
+	ret = handleGeneralFields(ctx, ret, event)
+	ret = handleRecoveryMode(ctx, previous, ret, strings.Join(maintenanceMessages, " "))
+	return ret
+}
+
 // processMissingDeviceEvent processes an event from a machine that expects to have an attached
 // device but cannot communicate with it.
 func processMissingDeviceEvent(ctx context.Context, previous machine.Description, event machine.Event) machine.Description {
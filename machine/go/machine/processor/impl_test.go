@@ -1400,3 +1400,90 @@ func TestProcess_Standalone_DockerInstalled_Success(t *testing.T) {
 		Version: "2021-07-22-jcgregorio-78bcc725fef1e29b518291469b8ad8f0cc3b21e4",
 	}, next)
 }
+
+func probeEventForTest(eventTime time.Time, probe machine.Probe) machine.Event {
+	return machine.Event{
+		EventType: machine.EventTypeRawState,
+		Host: machine.Host{
+			Name:      "skia-rpi2-0001",
+			Version:   "some-version",
+			StartTime: eventTime,
+		},
+		Probe: probe,
+	}
+}
+
+func TestProcess_ProbeDeviceAttached_DimensionsBatteryAndTemperatureAreSet(t *testing.T) {
+	eventTime := time.Date(2021, time.September, 1, 10, 1, 0, 0, time.UTC)
+	serverTime := time.Date(2021, time.September, 1, 10, 1, 5, 0, time.UTC)
+
+	previous := machine.NewDescription(context.Background())
+	previous.Dimensions[machine.DimID] = []string{"skia-rpi2-0001"}
+	battery := 80
+	event := probeEventForTest(eventTime, machine.Probe{
+		Dimensions: machine.SwarmingDimensions{
+			machine.DimID:         []string{"the-probe-can-not-change-this"},
+			machine.DimDeviceType: []string{"devboard-9000"},
+			machine.DimOS:         []string{"Zephyr"},
+		},
+		Battery:       &battery,
+		Temperature:   map[string]float64{"soc": 30.5},
+		UptimeSeconds: 123,
+	})
+
+	ctx := now.TimeTravelingContext(serverTime)
+	next := newProcessorForTest().Process(ctx, previous, event)
+	assert.Equal(t, machine.Description{
+		AttachedDevice:     machine.AttachedDeviceNone,
+		LastUpdated:        serverTime,
+		Battery:            80,
+		Temperature:        map[string]float64{"soc": 30.5},
+		DeviceUptime:       123,
+		SuppliedDimensions: machine.SwarmingDimensions{},
+		Dimensions: machine.SwarmingDimensions{
+			machine.DimID:                        []string{"skia-rpi2-0001"},
+			machine.DimDeviceType:                []string{"devboard-9000"},
+			machine.DimOS:                        []string{"Zephyr"},
+			machine.DimTestMachineMonitorVersion: []string{"some-version"},
+		},
+		Version: "some-version",
+	}, next)
+}
+
+func TestProcess_ProbeReportsHealthProblems_RecoveryModeIsSet(t *testing.T) {
+	serverTime := time.Date(2021, time.September, 1, 10, 1, 5, 0, time.UTC)
+
+	previous := machine.NewDescription(context.Background())
+	battery := 10
+	event := probeEventForTest(serverTime, machine.Probe{
+		Dimensions: machine.SwarmingDimensions{
+			machine.DimDeviceType: []string{"devboard-9000"},
+		},
+		Battery:     &battery,
+		Temperature: map[string]float64{"soc": 50},
+		Health:      []string{"Fan stopped."},
+	})
+
+	ctx := now.TimeTravelingContext(serverTime)
+	next := newProcessorForTest().Process(ctx, previous, event)
+	assert.Equal(t, "Battery low. Too hot. Fan stopped.", next.Recovering)
+	assert.Equal(t, serverTime, next.RecoveryStart)
+}
+
+func TestProcess_ProbeReportsNoBattery_BatteryIsZeroAndNotRecovering(t *testing.T) {
+	serverTime := time.Date(2021, time.September, 1, 10, 1, 5, 0, time.UTC)
+
+	previous := machine.NewDescription(context.Background())
+	previous.Battery = 50
+	event := probeEventForTest(serverTime, machine.Probe{
+		Dimensions: machine.SwarmingDimensions{
+			machine.DimDeviceType: []string{"console"},
+		},
+	})
+
+	ctx := now.TimeTravelingContext(serverTime)
+	next := newProcessorForTest().Process(ctx, previous, event)
+	assert.Equal(t, 0, next.Battery)
+	assert.Nil(t, next.Temperature)
+	assert.False(t, next.IsRecovering())
+}
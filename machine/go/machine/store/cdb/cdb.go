@@ -20,6 +20,7 @@ import (
 	"go.skia.org/infra/go/sql/schema"
 	"go.skia.org/infra/go/sql/sqlutil"
 	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machine/fleet"
 	"go.skia.org/infra/machine/go/machine/pools"
 	"go.skia.org/infra/machine/go/machine/store"
 	"go.skia.org/infra/machine/go/machine/store/cdb/expectedschema"
@@ -42,10 +43,16 @@ const (
 	List
 	Delete
 	GetFreeMachines
+	AddHistory
+	GetHistory
+	DeleteHistory
+	ListQuarantineCounts
+	ListDailyMaxBattery
 )
 
 var (
 	descriptionAllNonComputedColumns = strings.Join(Description, ",")
+	descriptionHistoryAllColumns     = strings.Join(DescriptionHistory, ",")
 )
 
 // Statements are all the SQL statements used in Store.
@@ -113,12 +120,85 @@ WHERE
 AND
 	dimensions @> CONCAT('{"task_type": ["sktask"], "pool":["', $1, '"]}')::JSONB
 `, descriptionAllNonComputedColumns),
+	AddHistory: fmt.Sprintf(`
+UPSERT INTO
+	DescriptionHistory (%s)
+VALUES
+	%s
+`, descriptionHistoryAllColumns, sqlutil.ValuesPlaceholders(len(DescriptionHistory), 1),
+	),
+	GetHistory: fmt.Sprintf(`
+SELECT
+	%s
+FROM
+	DescriptionHistory
+WHERE
+	machine_id = $1
+	AND ts >= $2
+	AND ts < $3
+ORDER BY
+	ts
+`, descriptionHistoryAllColumns),
+	DeleteHistory: `
+DELETE FROM
+	DescriptionHistory
+WHERE
+	ts < $1
+`,
+	// Counts the transitions into quarantine, i.e. samples that are
+	// quarantined where the previous sample for the same machine wasn't. A
+	// machine that is quarantined at the start of the time range counts as
+	// one transition.
+	ListQuarantineCounts: `
+SELECT
+	machine_id,
+	COUNT(*) AS quarantined
+FROM (
+	SELECT
+		machine_id,
+		is_quarantined,
+		LAG(is_quarantined) OVER (PARTITION BY machine_id ORDER BY ts) AS previous
+	FROM
+		DescriptionHistory@by_ts
+	WHERE
+		ts >= $1
+		AND ts < $2
+)
+WHERE
+	is_quarantined
+	AND (previous IS NULL OR NOT previous)
+GROUP BY
+	machine_id
+ORDER BY
+	quarantined DESC, machine_id
+LIMIT
+	$3
+`,
+	// Machines without a battery report a charge of 0 or BadBatteryLevel, so
+	// they are excluded.
+	ListDailyMaxBattery: `
+SELECT
+	machine_id,
+	date_trunc('day', ts) AS day,
+	MAX(battery)
+FROM
+	DescriptionHistory@by_ts
+WHERE
+	ts >= $1
+	AND ts < $2
+	AND battery > 0
+GROUP BY
+	machine_id, day
+ORDER BY
+	machine_id, day
+`,
 }
 
 // Tables represents all SQL tables used by machineserver.
 type Tables struct {
-	Description []machine.Description
-	TaskResult  []machine.TaskResult
+	Description        []machine.Description
+	TaskResult         []machine.TaskResult
+	DescriptionHistory []machine.DescriptionHistory
 }
 
 // Store implements ../store.Store.
@@ -325,3 +405,88 @@ func (s *Store) GetFreeMachines(ctx context.Context, pool string) ([]machine.Des
 
 	return ret, nil
 }
+
+// AddHistory implements ../store.Store.
+func (s *Store) AddHistory(ctx context.Context, history machine.DescriptionHistory) error {
+	history.Timestamp = history.Timestamp.UTC().Truncate(time.Millisecond)
+	if history.Temperature == nil {
+		history.Temperature = map[string]float64{}
+	}
+	if _, err := s.db.Exec(ctx, Statements[AddHistory], machine.DestFromDescriptionHistory(&history)...); err != nil {
+		return wrappedErrorForID(err, history.MachineID)
+	}
+	return nil
+}
+
+// GetHistory implements ../store.Store.
+func (s *Store) GetHistory(ctx context.Context, machineID string, begin, end time.Time) ([]machine.DescriptionHistory, error) {
+	ret := []machine.DescriptionHistory{}
+
+	rows, err := s.db.Query(ctx, Statements[GetHistory], machineID, begin, end)
+	if err != nil {
+		return nil, wrappedErrorForID(err, machineID)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h machine.DescriptionHistory
+		if err := rows.Scan(machine.DestFromDescriptionHistory(&h)...); err != nil {
+			return nil, wrappedErrorForID(err, machineID)
+		}
+		h.Timestamp = h.Timestamp.UTC()
+		ret = append(ret, h)
+	}
+
+	return ret, nil
+}
+
+// DeleteHistory implements ../store.Store.
+func (s *Store) DeleteHistory(ctx context.Context, before time.Time) error {
+	if _, err := s.db.Exec(ctx, Statements[DeleteHistory], before); err != nil {
+		return wrappedError(err)
+	}
+	return nil
+}
+
+// ListQuarantineCounts implements ../store.Store.
+func (s *Store) ListQuarantineCounts(ctx context.Context, begin, end time.Time, limit int) ([]fleet.QuarantineCount, error) {
+	ret := []fleet.QuarantineCount{}
+
+	rows, err := s.db.Query(ctx, Statements[ListQuarantineCounts], begin, end, limit)
+	if err != nil {
+		return nil, wrappedError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var qc fleet.QuarantineCount
+		if err := rows.Scan(&qc.MachineID, &qc.Count); err != nil {
+			return nil, wrappedError(err)
+		}
+		ret = append(ret, qc)
+	}
+
+	return ret, nil
+}
+
+// ListDailyMaxBattery implements ../store.Store.
+func (s *Store) ListDailyMaxBattery(ctx context.Context, begin, end time.Time) ([]fleet.DailyBattery, error) {
+	ret := []fleet.DailyBattery{}
+
+	rows, err := s.db.Query(ctx, Statements[ListDailyMaxBattery], begin, end)
+	if err != nil {
+		return nil, wrappedError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var db fleet.DailyBattery
+		if err := rows.Scan(&db.MachineID, &db.Day, &db.MaxBattery); err != nil {
+			return nil, wrappedError(err)
+		}
+		db.Day = db.Day.UTC()
+		ret = append(ret, db)
+	}
+
+	return ret, nil
+}
@@ -13,6 +13,7 @@ import (
 	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/sql/schema"
 	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machine/fleet"
 	"go.skia.org/infra/machine/go/machine/machinetest"
 	"go.skia.org/infra/machine/go/machine/pools"
 	"go.skia.org/infra/machine/go/machine/pools/poolstest"
@@ -42,6 +43,9 @@ VALUES
 func Test_LengthsOfColumnHeadersAndCaolumnValuesAreTheSame(t *testing.T) {
 	d := machine.NewDescription(context.Background())
 	require.Equal(t, len(machine.DestFromDescription(&d)), len(cdb.Description))
+
+	var h machine.DescriptionHistory
+	require.Equal(t, len(machine.DestFromDescriptionHistory(&h)), len(cdb.DescriptionHistory))
 }
 
 const (
@@ -311,6 +315,106 @@ func TestGetFreeMachines_TwoMachinesNotRunningTasksOnlyOneWithTheRightTaskType_R
 	deepequal.DeepEqual(expected, descriptions[0])
 }
 
+var historyStart = time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+// addHistory adds a sample for the given machine n days after historyStart.
+func addHistory(t *testing.T, ctx context.Context, s *cdb.Store, machineID string, n int, battery int, isQuarantined bool) machine.DescriptionHistory {
+	h := machine.DescriptionHistory{
+		MachineID:     machineID,
+		Timestamp:     historyStart.Add(time.Duration(n) * 24 * time.Hour),
+		Battery:       battery,
+		Temperature:   map[string]float64{"cpu": 26.4},
+		IsQuarantined: isQuarantined,
+	}
+	require.NoError(t, s.AddHistory(ctx, h))
+	return h
+}
+
+func TestStore_AddHistoryAndGetHistory_ReturnsSamplesInRangeSortedByTime(t *testing.T) {
+	ctx, s, _ := setupForTestWithEmptyStore(t)
+
+	day2 := addHistory(t, ctx, s, machineID1, 2, 80, false)
+	day1 := addHistory(t, ctx, s, machineID1, 1, 90, true)
+	_ = addHistory(t, ctx, s, machineID1, 0, 100, false)
+	_ = addHistory(t, ctx, s, machineID1, 3, 70, false)
+	_ = addHistory(t, ctx, s, machineID2, 1, 50, false)
+
+	history, err := s.GetHistory(ctx, machineID1, historyStart.Add(24*time.Hour), historyStart.Add(3*24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []machine.DescriptionHistory{day1, day2}, history)
+}
+
+func TestStore_AddHistory_SameTimestamp_LastSampleWins(t *testing.T) {
+	ctx, s, _ := setupForTestWithEmptyStore(t)
+
+	_ = addHistory(t, ctx, s, machineID1, 0, 100, false)
+	last := addHistory(t, ctx, s, machineID1, 0, 90, true)
+
+	history, err := s.GetHistory(ctx, machineID1, historyStart, historyStart.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []machine.DescriptionHistory{last}, history)
+}
+
+func TestStore_DeleteHistory_RemovesOnlyOlderSamples(t *testing.T) {
+	ctx, s, _ := setupForTestWithEmptyStore(t)
+
+	_ = addHistory(t, ctx, s, machineID1, 0, 100, false)
+	kept := addHistory(t, ctx, s, machineID1, 1, 90, false)
+
+	require.NoError(t, s.DeleteHistory(ctx, historyStart.Add(24*time.Hour)))
+	history, err := s.GetHistory(ctx, machineID1, historyStart, historyStart.Add(7*24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []machine.DescriptionHistory{kept}, history)
+}
+
+func TestStore_ListQuarantineCounts_CountsTransitionsIntoQuarantine(t *testing.T) {
+	ctx, s, _ := setupForTestWithEmptyStore(t)
+
+	// machineID1 enters quarantine twice.
+	for i, quarantined := range []bool{false, true, true, false, true} {
+		_ = addHistory(t, ctx, s, machineID1, i, 100, quarantined)
+	}
+	// machineID2 enters quarantine once.
+	for i, quarantined := range []bool{true, true, false} {
+		_ = addHistory(t, ctx, s, machineID2, i, 100, quarantined)
+	}
+	// machineID3 is never quarantined.
+	_ = addHistory(t, ctx, s, machineID3, 0, 100, false)
+
+	counts, err := s.ListQuarantineCounts(ctx, historyStart, historyStart.Add(7*24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Equal(t, []fleet.QuarantineCount{
+		{MachineID: machineID1, Count: 2},
+		{MachineID: machineID2, Count: 1},
+	}, counts)
+
+	counts, err = s.ListQuarantineCounts(ctx, historyStart, historyStart.Add(7*24*time.Hour), 1)
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+}
+
+func TestStore_ListDailyMaxBattery_ReturnsMaxPerDayAndSkipsMachinesWithoutBattery(t *testing.T) {
+	ctx, s, _ := setupForTestWithEmptyStore(t)
+
+	_ = addHistory(t, ctx, s, machineID1, 0, 90, false)
+	h := machine.DescriptionHistory{
+		MachineID:   machineID1,
+		Timestamp:   historyStart.Add(time.Hour),
+		Battery:     95,
+		Temperature: map[string]float64{},
+	}
+	require.NoError(t, s.AddHistory(ctx, h))
+	_ = addHistory(t, ctx, s, machineID1, 1, 85, false)
+	_ = addHistory(t, ctx, s, machineID2, 0, machine.BadBatteryLevel, false)
+
+	batteries, err := s.ListDailyMaxBattery(ctx, historyStart, historyStart.Add(7*24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []fleet.DailyBattery{
+		{MachineID: machineID1, Day: historyStart, MaxBattery: 95},
+		{MachineID: machineID1, Day: historyStart.Add(24 * time.Hour), MaxBattery: 85},
+	}, batteries)
+}
+
 const LiveSchema = `CREATE TABLE IF NOT EXISTS Description (
 	maintenance_mode STRING NOT NULL DEFAULT '',
 	is_quarantined BOOL NOT NULL DEFAULT FALSE,
@@ -353,6 +457,20 @@ CREATE TABLE IF NOT EXISTS TaskResult (
 	INDEX by_machine_id (machine_id),
 	INDEX by_status (status)
   );
+
+CREATE TABLE IF NOT EXISTS DescriptionHistory (
+	machine_id STRING NOT NULL,
+	ts TIMESTAMPTZ NOT NULL,
+	battery INT NOT NULL DEFAULT 0,
+	temperatures JSONB NOT NULL,
+	device_uptime INT4 NOT NULL DEFAULT 0,
+	is_quarantined BOOL NOT NULL DEFAULT FALSE,
+	maintenance BOOL NOT NULL DEFAULT FALSE,
+	recovering BOOL NOT NULL DEFAULT FALSE,
+	running_task BOOL NOT NULL DEFAULT FALSE,
+	PRIMARY KEY (machine_id, ts),
+	INDEX by_ts (ts)
+  );
 `
 
 func GetSchema(t *testing.T, db *pgxpool.Pool) *schema.Description {
@@ -372,6 +490,8 @@ func Test_LiveToNextSchemaMigration(t *testing.T) {
 	require.NoError(t, err)
 	_, err = db.Exec(ctx, "DROP TABLE IF EXISTS TaskResult")
 	require.NoError(t, err)
+	_, err = db.Exec(ctx, "DROP TABLE IF EXISTS DescriptionHistory")
+	require.NoError(t, err)
 
 	_, err = db.Exec(ctx, LiveSchema)
 	require.NoError(t, err)
@@ -40,6 +40,19 @@ CREATE TABLE IF NOT EXISTS TaskResult (
   INDEX by_machine_id (machine_id),
   INDEX by_status (status)
 );
+CREATE TABLE IF NOT EXISTS DescriptionHistory (
+  machine_id STRING NOT NULL,
+  ts TIMESTAMPTZ NOT NULL,
+  battery INT NOT NULL DEFAULT 0,
+  temperatures JSONB NOT NULL,
+  device_uptime INT4 NOT NULL DEFAULT 0,
+  is_quarantined BOOL NOT NULL DEFAULT FALSE,
+  maintenance BOOL NOT NULL DEFAULT FALSE,
+  recovering BOOL NOT NULL DEFAULT FALSE,
+  running_task BOOL NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (machine_id, ts),
+  INDEX by_ts (ts)
+);
 `
 
 var Description = []string{
@@ -73,3 +86,15 @@ var TaskResult = []string{
 	"finished",
 	"status",
 }
+
+var DescriptionHistory = []string{
+	"machine_id",
+	"ts",
+	"battery",
+	"temperatures",
+	"device_uptime",
+	"is_quarantined",
+	"maintenance",
+	"recovering",
+	"running_task",
+}
@@ -6,11 +6,15 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	fleet "go.skia.org/infra/machine/go/machine/fleet"
+
 	machine "go.skia.org/infra/machine/go/machine"
 
 	store "go.skia.org/infra/machine/go/machine/store"
 
 	testing "testing"
+
+	time "time"
 )
 
 // Store is an autogenerated mock type for the Store type
@@ -18,6 +22,20 @@ type Store struct {
 	mock.Mock
 }
 
+// AddHistory provides a mock function with given fields: ctx, history
+func (_m *Store) AddHistory(ctx context.Context, history machine.DescriptionHistory) error {
+	ret := _m.Called(ctx, history)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, machine.DescriptionHistory) error); ok {
+		r0 = rf(ctx, history)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, machineID
 func (_m *Store) Delete(ctx context.Context, machineID string) error {
 	ret := _m.Called(ctx, machineID)
@@ -32,6 +50,20 @@ func (_m *Store) Delete(ctx context.Context, machineID string) error {
 	return r0
 }
 
+// DeleteHistory provides a mock function with given fields: ctx, before
+func (_m *Store) DeleteHistory(ctx context.Context, before time.Time) error {
+	ret := _m.Called(ctx, before)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, machineID
 func (_m *Store) Get(ctx context.Context, machineID string) (machine.Description, error) {
 	ret := _m.Called(ctx, machineID)
@@ -76,6 +108,29 @@ func (_m *Store) GetFreeMachines(ctx context.Context, pool string) ([]machine.De
 	return r0, r1
 }
 
+// GetHistory provides a mock function with given fields: ctx, machineID, begin, end
+func (_m *Store) GetHistory(ctx context.Context, machineID string, begin time.Time, end time.Time) ([]machine.DescriptionHistory, error) {
+	ret := _m.Called(ctx, machineID, begin, end)
+
+	var r0 []machine.DescriptionHistory
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []machine.DescriptionHistory); ok {
+		r0 = rf(ctx, machineID, begin, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]machine.DescriptionHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, machineID, begin, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx
 func (_m *Store) List(ctx context.Context) ([]machine.Description, error) {
 	ret := _m.Called(ctx)
@@ -99,6 +154,29 @@ func (_m *Store) List(ctx context.Context) ([]machine.Description, error) {
 	return r0, r1
 }
 
+// ListDailyMaxBattery provides a mock function with given fields: ctx, begin, end
+func (_m *Store) ListDailyMaxBattery(ctx context.Context, begin time.Time, end time.Time) ([]fleet.DailyBattery, error) {
+	ret := _m.Called(ctx, begin, end)
+
+	var r0 []fleet.DailyBattery
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []fleet.DailyBattery); ok {
+		r0 = rf(ctx, begin, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]fleet.DailyBattery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, begin, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListPowerCycle provides a mock function with given fields: ctx
 func (_m *Store) ListPowerCycle(ctx context.Context) ([]string, error) {
 	ret := _m.Called(ctx)
@@ -122,6 +200,29 @@ func (_m *Store) ListPowerCycle(ctx context.Context) ([]string, error) {
 	return r0, r1
 }
 
+// ListQuarantineCounts provides a mock function with given fields: ctx, begin, end, limit
+func (_m *Store) ListQuarantineCounts(ctx context.Context, begin time.Time, end time.Time, limit int) ([]fleet.QuarantineCount, error) {
+	ret := _m.Called(ctx, begin, end, limit)
+
+	var r0 []fleet.QuarantineCount
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []fleet.QuarantineCount); ok {
+		r0 = rf(ctx, begin, end, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]fleet.QuarantineCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, begin, end, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, machineID, updateCallback
 func (_m *Store) Update(ctx context.Context, machineID string, updateCallback store.UpdateCallback) error {
 	ret := _m.Called(ctx, machineID, updateCallback)
@@ -3,8 +3,10 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machine/fleet"
 )
 
 // UpdateCallback is the callback that Store.Update() takes to update a single
@@ -35,4 +37,24 @@ type Store interface {
 
 	// Get a list of Kingsford machines that aren't running tasks.
 	GetFreeMachines(ctx context.Context, pool string) ([]machine.Description, error)
+
+	// AddHistory records a sample of a machine's state. A later sample for
+	// the same machine and timestamp replaces an earlier one.
+	AddHistory(ctx context.Context, history machine.DescriptionHistory) error
+
+	// GetHistory returns the samples recorded for the given machine in the
+	// time range [begin, end), sorted by timestamp.
+	GetHistory(ctx context.Context, machineID string, begin, end time.Time) ([]machine.DescriptionHistory, error)
+
+	// DeleteHistory removes all samples recorded before the given time.
+	DeleteHistory(ctx context.Context, before time.Time) error
+
+	// ListQuarantineCounts returns how many times each machine entered
+	// quarantine in the time range [begin, end), with the most often
+	// quarantined machines first. At most limit machines are returned.
+	ListQuarantineCounts(ctx context.Context, begin, end time.Time, limit int) ([]fleet.QuarantineCount, error)
+
+	// ListDailyMaxBattery returns the maximum battery charge of every machine
+	// with a battery for each day in the time range [begin, end).
+	ListDailyMaxBattery(ctx context.Context, begin, end time.Time) ([]fleet.DailyBattery, error)
 }
@@ -24,8 +24,10 @@ func main() {
 		rpc.SetNoteRequest{},
 		rpc.SupplyChromeOSRequest{},
 		rpc.SetAttachedDevice{},
+		rpc.FleetReportResponse{},
 	)
 	generator.AddIgnoreNil(rpc.ListMachinesResponse{})
+	generator.AddIgnoreNil(rpc.MachineHistoryResponse{})
 	generator.AddUnion(machine.AllAttachedDevices)
 	generator.AddUnion(machine.AllPowerCycleStates)
 	generator.AddUnion(machine.AllTaskRequestorStates)
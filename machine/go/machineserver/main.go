@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
@@ -32,11 +33,13 @@ import (
 	"go.skia.org/infra/go/roles"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/machine/go/configs"
 	"go.skia.org/infra/machine/go/machine"
 	changeSink "go.skia.org/infra/machine/go/machine/change/sink"
 	sseChangeSink "go.skia.org/infra/machine/go/machine/change/sink/sse"
 	httpEventSource "go.skia.org/infra/machine/go/machine/event/source/httpsource"
+	"go.skia.org/infra/machine/go/machine/fleet"
 	"go.skia.org/infra/machine/go/machine/pools"
 	machineProcessor "go.skia.org/infra/machine/go/machine/processor"
 	machineStore "go.skia.org/infra/machine/go/machine/store"
@@ -47,9 +50,24 @@ import (
 
 var errFailedToGetID = errors.New("failed to get id from URL")
 
+const (
+	// defaultHistoryDuration is how far back the machine history and fleet
+	// report endpoints look if the request doesn't specify a time range.
+	defaultHistoryDuration = 7 * 24 * time.Hour
+
+	// defaultFleetReportLimit is the default number of machines in each list
+	// of the fleet report.
+	defaultFleetReportLimit = 20
+
+	// historyTrimPeriod is how often history older than the retention period
+	// is deleted.
+	historyTrimPeriod = 24 * time.Hour
+)
+
 type flags struct {
 	configFlag              string
 	changeEventSSERPeerPort int
+	historyRetention        time.Duration
 	namespace               string
 	labelSelector           string
 	local                   bool
@@ -61,6 +79,7 @@ type flags struct {
 func (f *flags) Register(fs *flag.FlagSet) {
 	fs.StringVar(&f.configFlag, "config", "test.json", "The name to the configuration file, such as prod.json or test.json, as found in machine/go/configs.")
 	fs.IntVar(&f.changeEventSSERPeerPort, "change_event_sser_peer_port", 4000, "The port used to communicate among peers messages that need to be sent over SSE.")
+	fs.DurationVar(&f.historyRetention, "history_retention", 90*24*time.Hour, "How long to keep the history of machine states.")
 	fs.StringVar(&f.namespace, "namespace", "default", "The namespace this application is running under in k8s.")
 	fs.StringVar(&f.labelSelector, "label_selector", "app=machineserver", "A label selector that finds all peer pods of this application in k8s.")
 	fs.BoolVar(&f.local, "local", false, "Running locally if true. As opposed to in production.")
@@ -160,9 +179,22 @@ func new(args []string) (*server, error) {
 	}
 	s.loadTemplates()
 	go s.listenMachineEvents(ctx)
+	go s.trimHistory(ctx)
 	return s, nil
 }
 
+// trimHistory periodically deletes machine history older than the retention
+// period. This function doesn't return unless the context is cancelled.
+func (s *server) trimHistory(ctx context.Context) {
+	trimFail := metrics2.GetCounter("machineserver_history_trim_fail")
+	util.RepeatCtx(ctx, historyTrimPeriod, func(ctx context.Context) {
+		if err := s.store.DeleteHistory(ctx, now.Now(ctx).Add(-s.flags.historyRetention)); err != nil {
+			trimFail.Inc(1)
+			sklog.Errorf("Failed to trim history: %s", err)
+		}
+	})
+}
+
 // Starts listening for the arrival of machine.Events. This function doesn't
 // return unless the context is cancelled.
 func (s *server) listenMachineEvents(ctx context.Context) {
@@ -179,13 +211,22 @@ func (s *server) listenMachineEvents(ctx context.Context) {
 	}
 }
 
+// processEventArrival applies the event to the machine's Description and
+// records a sample of the resulting state in the machine's history.
 func processEventArrival(ctx context.Context, store machineStore.Store, storeUpdateFail metrics2.Counter, processor machineProcessor.Processor, event machine.Event) {
+	var updated machine.Description
 	err := store.Update(ctx, event.Host.Name, func(previous machine.Description) machine.Description {
-		return processor.Process(ctx, previous, event)
+		updated = processor.Process(ctx, previous, event)
+		return updated
 	})
 	if err != nil {
 		storeUpdateFail.Inc(1)
 		sklog.Errorf("Failed to update: %s", err)
+		return
+	}
+	if err := store.AddHistory(ctx, machine.NewDescriptionHistory(updated, now.Now(ctx))); err != nil {
+		storeUpdateFail.Inc(1)
+		sklog.Errorf("Failed to add history: %s", err)
 	}
 }
 
@@ -538,6 +579,87 @@ func (s *server) apiMachineDescriptionHandler(w http.ResponseWriter, r *http.Req
 	sendJSONResponse(desc, w)
 }
 
+// getTimeRange returns the time range given by the optional 'begin' and 'end'
+// RFC3339 query parameters. It reports an error on the ResponseWriter if
+// either fails to parse.
+func getTimeRange(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, error) {
+	end := now.Now(r.Context())
+	if value := r.FormValue("end"); value != "" {
+		var err error
+		end, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			httputils.ReportError(w, err, "Invalid 'end' time.", http.StatusBadRequest)
+			return time.Time{}, time.Time{}, skerr.Wrap(err)
+		}
+	}
+	begin := end.Add(-defaultHistoryDuration)
+	if value := r.FormValue("begin"); value != "" {
+		var err error
+		begin, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			httputils.ReportError(w, err, "Invalid 'begin' time.", http.StatusBadRequest)
+			return time.Time{}, time.Time{}, skerr.Wrap(err)
+		}
+	}
+	return begin, end, nil
+}
+
+func (s *server) apiMachineHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := getID(w, r)
+	if err != nil {
+		return
+	}
+	begin, end, err := getTimeRange(w, r)
+	if err != nil {
+		return
+	}
+
+	history, err := s.store.GetHistory(r.Context(), id, begin, end)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to read from datastore", http.StatusInternalServerError)
+		return
+	}
+	sendJSONResponse(rpc.MachineHistoryResponse(history), w)
+}
+
+func (s *server) apiFleetReportHandler(w http.ResponseWriter, r *http.Request) {
+	begin, end, err := getTimeRange(w, r)
+	if err != nil {
+		return
+	}
+	limit := defaultFleetReportLimit
+	if value := r.FormValue("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			http.Error(w, "Invalid 'limit'.", http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx := r.Context()
+	mostQuarantined, err := s.store.ListQuarantineCounts(ctx, begin, end, limit)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to read from datastore", http.StatusInternalServerError)
+		return
+	}
+	dailyBatteries, err := s.store.ListDailyMaxBattery(ctx, begin, end)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to read from datastore", http.StatusInternalServerError)
+		return
+	}
+	degradingBatteries := fleet.DegradingBatteries(dailyBatteries, fleet.DefaultMinDays, fleet.DefaultSlopeThreshold)
+	if len(degradingBatteries) > limit {
+		degradingBatteries = degradingBatteries[:limit]
+	}
+
+	sendJSONResponse(rpc.FleetReportResponse{
+		Begin:              begin,
+		End:                end,
+		DegradingBatteries: degradingBatteries,
+		MostQuarantined:    mostQuarantined,
+	}, w)
+}
+
 func (s *server) apiPowerCycleListHandler(w http.ResponseWriter, r *http.Request) {
 	toPowerCycle, err := s.store.ListPowerCycle(r.Context())
 	if err != nil {
@@ -671,6 +793,8 @@ func (s *server) AddHandlers(r chi.Router) {
 	r.Get("/_/machines", gzip(http.HandlerFunc(s.machinesHandler)).ServeHTTP)
 	r.Get(rpc.MachineDescriptionURL, gzip(http.HandlerFunc(s.apiMachineDescriptionHandler)).ServeHTTP)
 	r.Get(rpc.PowerCycleListURL, gzip(http.HandlerFunc(s.apiPowerCycleListHandler)).ServeHTTP)
+	r.Get(rpc.MachineHistoryURL, gzip(http.HandlerFunc(s.apiMachineHistoryHandler)).ServeHTTP)
+	r.Get(rpc.FleetReportURL, gzip(http.HandlerFunc(s.apiFleetReportHandler)).ServeHTTP)
 	r.Get("/loginstatus/", gzip(http.HandlerFunc(s.loginStatus)).ServeHTTP)
 }
 
@@ -17,12 +17,16 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.skia.org/infra/go/alogin/proxylogin"
+	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/roles"
 	"go.skia.org/infra/go/testutils"
 	"go.skia.org/infra/kube/go/authproxy"
 	"go.skia.org/infra/machine/go/machine"
 	changeSinkMocks "go.skia.org/infra/machine/go/machine/change/sink/mocks"
+	"go.skia.org/infra/machine/go/machine/fleet"
+	machineProcessor "go.skia.org/infra/machine/go/machine/processor"
+	machineStore "go.skia.org/infra/machine/go/machine/store"
 	"go.skia.org/infra/machine/go/machine/store/mocks"
 	"go.skia.org/infra/machine/go/machineserver/rpc"
 )
@@ -618,3 +622,109 @@ func TestMachineClearQuarantineHandler_MachineIDNotSupplied_ReturnsNotFound(t *t
 func TestClearQuarantined(t *testing.T) {
 	require.False(t, clearQuarantined(machine.Description{IsQuarantined: true}).IsQuarantined)
 }
+
+func TestProcessEventArrival_UpdateSucceeds_HistoryOfUpdatedDescriptionIsAdded(t *testing.T) {
+	ctx, desc, s, _, _ := setupForTest(t)
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("Update", testutils.AnyContext, machineID, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).(machineStore.UpdateCallback)(desc)
+	}).Return(nil)
+	processor := machineProcessor.ProcessorFunc(func(ctx context.Context, current machine.Description, event machine.Event) machine.Description {
+		ret := current.Copy()
+		ret.Battery = 87
+		ret.IsQuarantined = true
+		return ret
+	})
+	storeMock.On("AddHistory", testutils.AnyContext, machine.DescriptionHistory{
+		MachineID:     machineID,
+		Timestamp:     time.Date(2021, time.September, 1, 2, 0, 0, 0, time.UTC),
+		Battery:       87,
+		Temperature:   desc.Temperature,
+		IsQuarantined: true,
+	}).Return(nil)
+
+	event := machine.NewEvent()
+	event.Host.Name = machineID
+	processEventArrival(ctx, storeMock, metrics2.GetCounter("test_store_update_fail"), processor, event)
+}
+
+func TestProcessEventArrival_UpdateFails_HistoryIsNotAdded(t *testing.T) {
+	ctx, _, s, _, _ := setupForTest(t)
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("Update", testutils.AnyContext, machineID, mock.Anything).Return(errFake)
+
+	event := machine.NewEvent()
+	event.Host.Name = machineID
+	storeUpdateFail := metrics2.GetCounter("test_store_update_fail_no_history")
+	processEventArrival(ctx, storeMock, storeUpdateFail, machineProcessor.New(ctx), event)
+	require.Equal(t, int64(1), storeUpdateFail.Get())
+}
+
+func TestApiMachineHistoryHandler_NoTimeRangeSupplied_ReturnsLastWeekOfHistory(t *testing.T) {
+	ctx, desc, s, router, w := setupForTest(t)
+	history := []machine.DescriptionHistory{machine.NewDescriptionHistory(desc, fakeTime)}
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("GetHistory", testutils.AnyContext, machineID, fakeTime.Add(-7*24*time.Hour), fakeTime).Return(history, nil)
+
+	r := newAuthorizedRequest("GET", fmt.Sprintf("/json/v1/machine/history/%s", machineID), nil)
+	r = r.WithContext(ctx)
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var actual rpc.MachineHistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+	assert.Equal(t, rpc.MachineHistoryResponse(history), actual)
+}
+
+func TestApiMachineHistoryHandler_InvalidBegin_ReturnsBadRequest(t *testing.T) {
+	_, _, _, router, w := setupForTest(t)
+
+	r := newAuthorizedRequest("GET", fmt.Sprintf("/json/v1/machine/history/%s?begin=yesterday", machineID), nil)
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestApiFleetReportHandler_TimeRangeAndLimitSupplied_ReturnsReport(t *testing.T) {
+	_, _, s, router, w := setupForTest(t)
+	begin := time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, time.August, 8, 0, 0, 0, 0, time.UTC)
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("ListQuarantineCounts", testutils.AnyContext, begin, end, 1).Return([]fleet.QuarantineCount{
+		{MachineID: machineID, Count: 3},
+	}, nil)
+	dailyBatteries := []fleet.DailyBattery{}
+	for i, battery := range []int{100, 90, 80} {
+		dailyBatteries = append(dailyBatteries,
+			fleet.DailyBattery{MachineID: machineID, Day: begin.Add(time.Duration(i) * 24 * time.Hour), MaxBattery: battery},
+			fleet.DailyBattery{MachineID: "skia-rpi2-rack4-shelf1-002", Day: begin.Add(time.Duration(i) * 24 * time.Hour), MaxBattery: battery - 2*i},
+		)
+	}
+	storeMock.On("ListDailyMaxBattery", testutils.AnyContext, begin, end).Return(dailyBatteries, nil)
+
+	r := newAuthorizedRequest("GET", "/json/v1/fleet/report?begin=2021-08-01T00:00:00Z&end=2021-08-08T00:00:00Z&limit=1", nil)
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var actual rpc.FleetReportResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+	assert.Equal(t, rpc.FleetReportResponse{
+		Begin: begin,
+		End:   end,
+		DegradingBatteries: []fleet.BatteryTrend{
+			{MachineID: "skia-rpi2-rack4-shelf1-002", Days: 3, FirstMax: 100, LastMax: 76, SlopePerDay: -12},
+		},
+		MostQuarantined: []fleet.QuarantineCount{
+			{MachineID: machineID, Count: 3},
+		},
+	}, actual)
+}
+
+func TestApiFleetReportHandler_InvalidLimit_ReturnsBadRequest(t *testing.T) {
+	_, _, _, router, w := setupForTest(t)
+
+	r := newAuthorizedRequest("GET", "/json/v1/fleet/report?limit=-1", nil)
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -1,22 +1,29 @@
 package rpc
 
 import (
+	"time"
+
 	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machine/fleet"
 )
 
 // URL paths.
 const (
 	APIPrefix = "/json/v1"
 
+	FleetReportRelativeURL                  = "/fleet/report"
 	MachineDescriptionRelativeURL           = "/machine/description/{id:.+}"
 	MachineEventRelativeURL                 = "/machine/event/"
+	MachineHistoryRelativeURL               = "/machine/history/{id:.+}"
 	PowerCycleCompleteRelativeURL           = "/powercycle/complete/{id:.+}"
 	PowerCycleListRelativeURL               = "/powercycle/list"
 	PowerCycleStateUpdateRelativeURL        = "/powercycle/state/update"
 	SSEMachineDescriptionUpdatedRelativeURL = "/machine/sse/description/updated"
 
+	FleetReportURL                  = APIPrefix + FleetReportRelativeURL
 	MachineDescriptionURL           = APIPrefix + MachineDescriptionRelativeURL
 	MachineEventURL                 = APIPrefix + MachineEventRelativeURL
+	MachineHistoryURL               = APIPrefix + MachineHistoryRelativeURL
 	PowerCycleCompleteURL           = APIPrefix + PowerCycleCompleteRelativeURL
 	PowerCycleListURL               = APIPrefix + PowerCycleListRelativeURL
 	PowerCycleStateUpdateURL        = APIPrefix + PowerCycleStateUpdateRelativeURL
@@ -57,3 +64,21 @@ type ListPowerCycleResponse []string
 func ToListPowerCycleResponse(machineIDs []string) ListPowerCycleResponse {
 	return machineIDs
 }
+
+// MachineHistoryResponse is the timeline of a single machine, oldest sample
+// first.
+type MachineHistoryResponse []machine.DescriptionHistory
+
+// FleetReportResponse summarizes the health of the whole fleet over the time
+// range [Begin, End).
+type FleetReportResponse struct {
+	Begin time.Time
+	End   time.Time
+
+	// DegradingBatteries are the machines whose batteries reach a lower
+	// maximum charge every day, fastest degrading first.
+	DegradingBatteries []fleet.BatteryTrend
+
+	// MostQuarantined are the machines that entered quarantine most often.
+	MostQuarantined []fleet.QuarantineCount
+}
@@ -33,6 +33,7 @@ import (
 	"go.skia.org/infra/machine/go/machineserver/rpc"
 	"go.skia.org/infra/machine/go/test_machine_monitor/adb"
 	"go.skia.org/infra/machine/go/test_machine_monitor/ios"
+	"go.skia.org/infra/machine/go/test_machine_monitor/probe"
 	"go.skia.org/infra/machine/go/test_machine_monitor/ssh"
 	"go.skia.org/infra/machine/go/test_machine_monitor/standalone"
 	"go.skia.org/infra/machine/go/test_machine_monitor/swarming"
@@ -82,6 +83,9 @@ type Machine struct {
 	// ssh is an abstraction around an ssh executor
 	ssh ssh.SSH
 
+	// probe talks to devices via a probe plugin executable.
+	probe probe.Probe
+
 	// MachineID is the swarming id of the machine.
 	MachineID string
 
@@ -122,7 +126,7 @@ type Machine struct {
 }
 
 // New return an instance of *Machine.
-func New(ctx context.Context, local bool, instanceConfig config.InstanceConfig, version string, startSwarming bool, machineServerHost string, triggerInterrogationCh <-chan bool) (*Machine, error) {
+func New(ctx context.Context, local bool, instanceConfig config.InstanceConfig, version string, startSwarming bool, machineServerHost string, probeExe string, triggerInterrogationCh <-chan bool) (*Machine, error) {
 
 	hostname, err := os.Hostname()
 	if err != nil {
@@ -172,6 +176,7 @@ func New(ctx context.Context, local bool, instanceConfig config.InstanceConfig,
 		adb:                            adb.New(),
 		ios:                            ios.New(),
 		ssh:                            ssh.ExeImpl{},
+		probe:                          probe.New(probeExe),
 		sshMachineLocation:             defaultSSHMachineFileLocation,
 		MachineID:                      machineID,
 		Version:                        version,
@@ -227,6 +232,12 @@ func (m *Machine) interrogate(ctx context.Context) (machine.Event, error) {
 			sklog.Infof("Successful communication with iOS device: %#v", ie)
 			ret.IOS = ie
 		}
+	case machine.AttachedDeviceProbe:
+		var pe machine.Probe
+		if pe, err = m.tryInterrogatingProbeDevice(ctx); err == nil {
+			sklog.Infof("Successful communication with probe device: %#v", pe)
+			ret.Probe = pe
+		}
 	case machine.AttachedDeviceNone:
 		var standaloneEvent machine.Standalone
 		sklog.Infof("No attached device set. Getting dimensions of host...")
@@ -398,12 +409,15 @@ func (m *Machine) IsRunningSwarmingTask() bool {
 // RebootDevice reboots the attached device.
 func (m *Machine) RebootDevice(ctx context.Context) error {
 	m.mutex.Lock()
+	shouldRebootProbe := m.description.AttachedDevice == machine.AttachedDeviceProbe
 	shouldRebootAndroid := len(m.description.Dimensions[machine.DimAndroidDevices]) > 0
 	shouldRebootIOS := util.In("iOS", m.description.Dimensions[machine.DimOS])
 	sshUserIP := m.description.SSHUserIP
 	m.mutex.Unlock()
 
-	if shouldRebootAndroid {
+	if shouldRebootProbe {
+		return m.probe.Reboot(ctx)
+	} else if shouldRebootAndroid {
 		return m.adb.Reboot(ctx)
 	} else if shouldRebootIOS {
 		return m.ios.Reboot(ctx)
@@ -492,6 +506,22 @@ func (m *Machine) tryInterrogatingIOSDevice(ctx context.Context) (machine.IOS, e
 	return ret, nil
 }
 
+// tryInterrogatingProbeDevice runs the probe plugin to get a report on the attached device. If
+// there is no device attached, or the plugin fails, it returns an error.
+func (m *Machine) tryInterrogatingProbeDevice(ctx context.Context) (machine.Probe, error) {
+	metrics2.GetCounter("test_machine_monitor_interrogate_device_type", map[string]string{
+		"machine": m.MachineID,
+		"type":    "probe",
+	}).Inc(1)
+	sklog.Info("tryInterrogatingProbeDevice")
+
+	ret, err := m.probe.Interrogate(ctx)
+	if err != nil {
+		return ret, skerr.Wrapf(err, "Failed to interrogate probe device - assuming there is no device attached")
+	}
+	return ret, nil
+}
+
 // tryInterrogatingStandaloneHost gathers information about the test machine itself (rather than an
 // attached device). It returns a Standlone struct which can be partially filled out; anything we
 // didn't manage to fill out will be warned about.
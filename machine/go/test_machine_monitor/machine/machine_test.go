@@ -26,6 +26,7 @@ import (
 	"go.skia.org/infra/machine/go/machineserver/rpc"
 	"go.skia.org/infra/machine/go/test_machine_monitor/adb"
 	"go.skia.org/infra/machine/go/test_machine_monitor/ios"
+	"go.skia.org/infra/machine/go/test_machine_monitor/probe"
 	"go.skia.org/infra/machine/go/test_machine_monitor/ssh"
 )
 
@@ -1054,3 +1055,76 @@ func TestIsAvailable_AvailableMachine_ReturnsTrue(t *testing.T) {
 	}
 	require.True(t, m.IsAvailable())
 }
+
+const probeExePlaceholder = "/usr/local/bin/devboard_probe"
+
+func TestInterrogate_ProbeDeviceAttached_Success(t *testing.T) {
+	ctx := executil.FakeTestsContext("Test_FakeExe_Probe_ReturnsReport")
+
+	timePlaceholder := time.Date(2021, time.September, 2, 2, 2, 2, 2, time.UTC)
+	m := &Machine{
+		probe:            probe.New(probeExePlaceholder),
+		MachineID:        "some-machine",
+		Version:          "some-version",
+		startTime:        timePlaceholder,
+		interrogateTimer: noop.Float64SummaryMetric{},
+		description: machine.Description{
+			AttachedDevice: machine.AttachedDeviceProbe,
+		},
+	}
+	actual, err := m.interrogate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, machine.Event{
+		EventType: machine.EventTypeRawState,
+		Host: machine.Host{
+			Name:      "some-machine",
+			Version:   "some-version",
+			StartTime: timePlaceholder,
+		},
+		Probe: machine.Probe{
+			Dimensions: machine.SwarmingDimensions{
+				machine.DimDeviceType: []string{"devboard-9000"},
+			},
+			UptimeSeconds: 60,
+		},
+	}, actual)
+}
+
+func TestTryInterrogatingProbeDevice_ProbeFails_DeviceConsideredUnattached(t *testing.T) {
+	ctx := executil.FakeTestsContext("Test_FakeExe_ExitCodeOne")
+
+	m := &Machine{probe: probe.New(probeExePlaceholder)}
+	_, err := m.tryInterrogatingProbeDevice(ctx)
+	require.Error(t, err)
+}
+
+func TestRebootDevice_ProbeDeviceAttached_Success(t *testing.T) {
+	ctx := executil.FakeTestsContext("Test_FakeExe_ProbeReboot_Success")
+
+	m := &Machine{
+		probe: probe.New(probeExePlaceholder),
+		description: machine.Description{
+			AttachedDevice: machine.AttachedDeviceProbe,
+		},
+	}
+
+	require.NoError(t, m.RebootDevice(ctx))
+	assert.Equal(t, 1, executil.FakeCommandsReturned(ctx))
+}
+
+func Test_FakeExe_Probe_ReturnsReport(t *testing.T) {
+	if !executil.IsCallingFakeCommand() {
+		return
+	}
+	require.Equal(t, []string{probeExePlaceholder, probe.InterrogateAction}, executil.OriginalArgs())
+	fmt.Print(`{"dimensions": {"device_type": ["devboard-9000"]}, "uptime_s": 60}`)
+	os.Exit(0)
+}
+
+func Test_FakeExe_ProbeReboot_Success(t *testing.T) {
+	if !executil.IsCallingFakeCommand() {
+		return
+	}
+	require.Equal(t, []string{probeExePlaceholder, probe.RebootAction}, executil.OriginalArgs())
+	os.Exit(0)
+}
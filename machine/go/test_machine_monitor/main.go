@@ -28,6 +28,7 @@ var (
 	local             = flag.Bool("local", false, "Running locally if true. As opposed to in production.")
 	machineServerHost = flag.String("machine_server", "https://machines.skia.org", "A URL with the scheme and domain name of the machine hosting the machine server API.")
 	metadataURL       = flag.String("metadata_url", "http://metadata:8000/computeMetadata/v1/instance/service-accounts/default/token", "The URL of the metadata server that provides service account tokens.")
+	probeExe          = flag.String("probe", "", "Absolute path to a probe plugin executable, used to interrogate the attached device if the machine's attached device is set to 'probe'.")
 	port              = flag.String("port", ":11000", "HTTP service address (e.g., 'localhost:8000' or ':8000')")
 	promPort          = flag.String("prom_port", ":20000", "Metrics service address (e.g., 'localhost:10110' or ':10110')")
 	pythonExe         = flag.String("python_exe", "", "Absolute path to Python.")
@@ -63,7 +64,7 @@ func main() {
 
 	ctx := context.Background()
 	triggerInterrogationCh := make(chan bool, interrogationChannelSize)
-	machineState, err := machine.New(ctx, *local, instanceConfig, Version, *startSwarming, *machineServerHost, triggerInterrogationCh)
+	machineState, err := machine.New(ctx, *local, instanceConfig, Version, *startSwarming, *machineServerHost, *probeExe, triggerInterrogationCh)
 	if err != nil {
 		sklog.Fatal("Failed to create machine: %s", err)
 	}
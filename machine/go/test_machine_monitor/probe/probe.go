@@ -0,0 +1,99 @@
+// Package probe runs probe plugins, external executables that report on
+// devices test_machine_monitor has no built-in support for.
+//
+// A probe plugin is run with a single argument, the action to perform:
+//
+//	interrogate - Write a JSON encoded machine.Probe describing the attached
+//	              device to stdout and exit with a status code of 0. Exit with
+//	              a non-zero status code if no device is attached.
+//	reboot      - Reboot the attached device.
+//
+// Anything the plugin writes to stderr is only used in error messages.
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"time"
+
+	"go.skia.org/infra/go/executil"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/machine/go/machine"
+)
+
+const (
+	// commandTimeout is how long a probe plugin may run. It is kept shorter
+	// than the interrogation interval of test_machine_monitor.
+	commandTimeout = 20 * time.Second
+
+	// InterrogateAction is the argument passed to the plugin to interrogate
+	// the device.
+	InterrogateAction = "interrogate"
+
+	// RebootAction is the argument passed to the plugin to reboot the device.
+	RebootAction = "reboot"
+)
+
+// Probe talks to a device via a probe plugin.
+type Probe interface {
+	// Interrogate returns the report of the attached device, or an error if
+	// no device is attached.
+	Interrogate(ctx context.Context) (machine.Probe, error)
+
+	// Reboot the attached device.
+	Reboot(ctx context.Context) error
+}
+
+// ExeImpl implements Probe by running a probe plugin executable.
+type ExeImpl struct {
+	exe string
+}
+
+// New returns a new ExeImpl that runs the probe plugin at the given path.
+func New(exe string) ExeImpl {
+	return ExeImpl{
+		exe: exe,
+	}
+}
+
+// run the probe plugin with the given action and return its stdout.
+func (e ExeImpl) run(ctx context.Context, action string) ([]byte, error) {
+	if e.exe == "" {
+		return nil, skerr.Fmt("No probe plugin was configured, see the --probe flag.")
+	}
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+	b, err := executil.CommandContext(ctx, e.exe, action).Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, skerr.Wrapf(err, "probe plugin %q failed to %s with stderr: %q", e.exe, action, ee.Stderr)
+		}
+		return nil, skerr.Wrapf(err, "probe plugin %q failed to %s", e.exe, action)
+	}
+	return b, nil
+}
+
+// Interrogate implements Probe.
+func (e ExeImpl) Interrogate(ctx context.Context) (machine.Probe, error) {
+	var ret machine.Probe
+	b, err := e.run(ctx, InterrogateAction)
+	if err != nil {
+		return ret, skerr.Wrap(err)
+	}
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return ret, skerr.Wrapf(err, "invalid report from probe plugin %q: %q", e.exe, string(b))
+	}
+	if !ret.IsPopulated() {
+		return ret, skerr.Fmt("probe plugin %q reported no dimensions - assuming there is no device attached", e.exe)
+	}
+	return ret, nil
+}
+
+// Reboot implements Probe.
+func (e ExeImpl) Reboot(ctx context.Context) error {
+	_, err := e.run(ctx, RebootAction)
+	return skerr.Wrap(err)
+}
+
+var _ Probe = ExeImpl{}
@@ -0,0 +1,107 @@
+package probe
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/executil"
+	"go.skia.org/infra/machine/go/machine"
+)
+
+const probeExe = "/usr/local/bin/devboard_probe"
+
+func TestInterrogate_PluginEmitsReport_Success(t *testing.T) {
+	ctx := executil.FakeTestsContext("Test_FakeExe_Probe_EmitsReport")
+
+	actual, err := New(probeExe).Interrogate(ctx)
+	require.NoError(t, err)
+	battery := 55
+	assert.Equal(t, machine.Probe{
+		Dimensions: machine.SwarmingDimensions{
+			machine.DimDeviceType: []string{"devboard-9000"},
+		},
+		Battery:       &battery,
+		Temperature:   map[string]float64{"soc": 31.5},
+		Health:        []string{"Fan stopped."},
+		UptimeSeconds: 3600,
+	}, actual)
+}
+
+func TestInterrogate_PluginEmitsInvalidJSON_ReturnsError(t *testing.T) {
+	ctx := executil.FakeTestsContext("Test_FakeExe_Probe_EmitsInvalidJSON")
+
+	_, err := New(probeExe).Interrogate(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid report")
+}
+
+func TestInterrogate_PluginReportsNoDimensions_ReturnsError(t *testing.T) {
+	ctx := executil.FakeTestsContext("Test_FakeExe_Probe_EmitsEmptyReport")
+
+	_, err := New(probeExe).Interrogate(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no device attached")
+}
+
+func TestInterrogate_PluginFails_ReturnsErrorWithStderr(t *testing.T) {
+	ctx := executil.FakeTestsContext("Test_FakeExe_Probe_Fails")
+
+	_, err := New(probeExe).Interrogate(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no board found")
+}
+
+func TestInterrogate_NoPluginConfigured_ReturnsError(t *testing.T) {
+	ctx := executil.FakeTestsContext() // Any exe call will panic
+
+	_, err := New("").Interrogate(ctx)
+	require.Error(t, err)
+}
+
+func TestReboot_PluginSucceeds_Success(t *testing.T) {
+	ctx := executil.FakeTestsContext("Test_FakeExe_Probe_Reboot")
+
+	require.NoError(t, New(probeExe).Reboot(ctx))
+	assert.Equal(t, 1, executil.FakeCommandsReturned(ctx))
+}
+
+// fakeProbeCommand pretends to be a probe plugin run with the given action,
+// printing the given stdout and stderr and returning the given status code.
+func fakeProbeCommand(t *testing.T, action, stdout, stderr string, statusCode int) {
+	if !executil.IsCallingFakeCommand() {
+		return
+	}
+	require.Equal(t, []string{probeExe, action}, executil.OriginalArgs())
+	fmt.Print(stdout)
+	fmt.Fprint(os.Stderr, stderr)
+	os.Exit(statusCode)
+}
+
+func Test_FakeExe_Probe_EmitsReport(t *testing.T) {
+	fakeProbeCommand(t, InterrogateAction, `{
+  "dimensions": {"device_type": ["devboard-9000"]},
+  "battery": 55,
+  "temperature": {"soc": 31.5},
+  "health": ["Fan stopped."],
+  "uptime_s": 3600
+}`, "", 0)
+}
+
+func Test_FakeExe_Probe_EmitsInvalidJSON(t *testing.T) {
+	fakeProbeCommand(t, InterrogateAction, "not json", "", 0)
+}
+
+func Test_FakeExe_Probe_EmitsEmptyReport(t *testing.T) {
+	fakeProbeCommand(t, InterrogateAction, "{}", "", 0)
+}
+
+func Test_FakeExe_Probe_Fails(t *testing.T) {
+	fakeProbeCommand(t, InterrogateAction, "", "no board found", 1)
+}
+
+func Test_FakeExe_Probe_Reboot(t *testing.T) {
+	fakeProbeCommand(t, RebootAction, "", "", 0)
+}
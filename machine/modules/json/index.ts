@@ -13,6 +13,26 @@ export interface SetAttachedDevice {
 	AttachedDevice: AttachedDevice;
 }
 
+export interface BatteryTrend {
+	MachineID: string;
+	Days: number;
+	FirstMax: number;
+	LastMax: number;
+	SlopePerDay: number;
+}
+
+export interface QuarantineCount {
+	MachineID: string;
+	Count: number;
+}
+
+export interface FleetReportResponse {
+	Begin: string;
+	End: string;
+	DegradingBatteries: BatteryTrend[] | null;
+	MostQuarantined: QuarantineCount[] | null;
+}
+
 export interface Annotation {
 	Message: string;
 	User: string;
@@ -74,9 +94,21 @@ export interface Description {
 	TaskStarted: string;
 }
 
+export interface DescriptionHistory {
+	MachineID: string;
+	Timestamp: string;
+	Battery: number;
+	Temperature: { [key: string]: number };
+	DeviceUptime: number;
+	IsQuarantined: boolean;
+	Maintenance: boolean;
+	Recovering: boolean;
+	RunningTask: boolean;
+}
+
 export type SwarmingDimensions = { [key: string]: string[] | null } | null;
 
-export type AttachedDevice = 'nodevice' | 'adb' | 'ios' | 'ssh';
+export type AttachedDevice = 'nodevice' | 'adb' | 'ios' | 'ssh' | 'probe';
 
 export type PowerCycleState = 'not_available' | 'available' | 'in_error';
 
@@ -84,4 +116,6 @@ export type Duration = number;
 
 export type ListMachinesResponse = Description[];
 
+export type MachineHistoryResponse = DescriptionHistory[];
+
 export type TaskRequestor = 'swarming' | 'sktask';
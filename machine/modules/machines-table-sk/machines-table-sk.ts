@@ -85,6 +85,7 @@ const attachedDeviceDisplayName: Record<string, AttachedDevice> = {
   Android: 'adb',
   iOS: 'ios',
   SSH: 'ssh',
+  Probe: 'probe',
 };
 
 /** attachedDeviceDisplayName keys sorted by display name. */
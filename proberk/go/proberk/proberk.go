@@ -1,9 +1,11 @@
-// Proberk is an HTTP prober that periodically sends out HTTP requests to specified
-// endpoints and reports if the returned results match the expectations. The results
-// of the probe, including latency, are recorded in metrics2.
+// Proberk is a prober that periodically sends out HTTP requests to specified
+// endpoints and reports if the returned results match the expectations. It can also
+// probe gRPC health checks, TCP ports, DNS names and TLS certs, and run multi-step
+// HTTP probes. The results of the probe, including latency, are recorded in metrics2.
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5"
 	"crypto/tls"
@@ -11,10 +13,12 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -27,6 +31,7 @@ import (
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/proberk/go/probes"
 	"go.skia.org/infra/proberk/go/types"
 	"golang.org/x/oauth2/google"
 )
@@ -72,6 +77,7 @@ func readConfigFile(ctx context.Context, filename string) (types.Probes, error)
 	for k, v := range p {
 		v.Failure = map[string]metrics2.Int64Metric{}
 		v.Latency = map[string]metrics2.Int64Metric{}
+		v.CertExpiry = map[string]metrics2.Int64Metric{}
 		if v.ResponseTestName != "" {
 			if f, ok := responseTesters[v.ResponseTestName]; ok {
 				v.ResponseTest = f
@@ -79,6 +85,9 @@ func readConfigFile(ctx context.Context, filename string) (types.Probes, error)
 				errs = append(errs, fmt.Sprintf("ResponseTestName Not Found %q", k))
 			}
 		}
+		if err := validateProbe(v); err != nil {
+			errs = append(errs, fmt.Sprintf("Invalid probe %q: %s", k, err))
+		}
 		allProbes[k] = v
 	}
 	if len(errs) != 0 {
@@ -87,6 +96,37 @@ func readConfigFile(ctx context.Context, filename string) (types.Probes, error)
 	return allProbes, nil
 }
 
+// validateProbe checks the parts of the probe config that the JSON schema cannot.
+func validateProbe(probe *types.Probe) error {
+	assertions := probe.Assertions
+	switch probe.Method {
+	case types.MethodSteps:
+		if len(probe.Steps) == 0 {
+			return skerr.Fmt("%s probes must have steps", types.MethodSteps)
+		}
+		for _, step := range probe.Steps {
+			if step.URL == "" {
+				return skerr.Fmt("step %q has no url", step.Name)
+			}
+			assertions = append(assertions, step.Assertions...)
+		}
+	case types.MethodTCP, types.MethodGRPC:
+		for _, u := range probe.URLs {
+			if _, _, err := net.SplitHostPort(u); err != nil {
+				return skerr.Wrapf(err, "%s probes must use host:port urls", probe.Method)
+			}
+		}
+	}
+	for _, a := range assertions {
+		if a.Matches != "" {
+			if _, err := regexp.Compile(a.Matches); err != nil {
+				return skerr.Wrapf(err, "invalid regexp %q", a.Matches)
+			}
+		}
+	}
+	return nil
+}
+
 // in returns true if n is found in the slice of integers.
 func in(n int, list []int) bool {
 	for _, x := range list {
@@ -162,7 +202,7 @@ func gobPublicReposGood(r io.Reader, _ http.Header) bool {
 	return ret
 }
 
-func probeOneRound(cfg types.Probes, anonymousClient, authClient *http.Client) {
+func probeOneRound(ctx context.Context, cfg types.Probes, anonymousClient, authClient *http.Client) {
 	var resp *http.Response
 	var begin time.Time
 	for name, probe := range cfg {
@@ -180,11 +220,8 @@ func probeOneRound(cfg types.Probes, anonymousClient, authClient *http.Client) {
 				resp, err = c.Head(u)
 			} else if probe.Method == "POST" {
 				resp, err = c.Post(u, probe.MimeType, strings.NewReader(probe.Body))
-			} else if probe.Method == "SSL" {
+			} else if probe.Method == types.MethodSSL {
 				// SSL is a fictitious method that tests the SSL cert.
-				// TODO(stephana): We should consider refactoring the prober into a
-				// more generic prober beyond just HTTP related probes. SSL probing
-				// would fit cleaner into such a framework.
 				if err := probeSSL(probe, u); err != nil {
 					sklog.Errorf("While testing %s we got SSL error: %s", u, err)
 					probe.Failure[u].Update(1)
@@ -192,6 +229,16 @@ func probeOneRound(cfg types.Probes, anonymousClient, authClient *http.Client) {
 					probe.Failure[u].Update(0)
 				}
 				continue
+			} else if isNonHTTPMethod(probe.Method) {
+				err := probeNonHTTP(ctx, probe, u, c)
+				probe.Latency[u].Update(time.Since(begin).Milliseconds())
+				if err != nil {
+					sklog.Warningf("Probe failed: Name: %s URL: %s Error: %s", name, u, err)
+					probe.Failure[u].Update(1)
+				} else {
+					probe.Failure[u].Update(0)
+				}
+				continue
 			} else {
 				sklog.Errorf("Error: unknown method: %s", probe.Method)
 				continue
@@ -205,8 +252,16 @@ func probeOneRound(cfg types.Probes, anonymousClient, authClient *http.Client) {
 			}
 			if resp != nil {
 				responseTestResults := true
-				if probe.ResponseTest != nil && resp.Body != nil {
-					responseTestResults = probe.ResponseTest(resp.Body, resp.Header)
+				var assertionErr error
+				if resp.Body != nil && (probe.ResponseTest != nil || len(probe.Assertions) > 0) {
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						sklog.Warningf("Failed to read response: Name: %s URL: %s Error: %s", name, u, err)
+					}
+					if probe.ResponseTest != nil {
+						responseTestResults = probe.ResponseTest(bytes.NewReader(body), resp.Header)
+					}
+					assertionErr = probes.CheckAssertions(probe.Assertions, body, resp.Header)
 				}
 				if resp.Body != nil {
 					util.Close(resp.Body)
@@ -223,6 +278,11 @@ func probeOneRound(cfg types.Probes, anonymousClient, authClient *http.Client) {
 					probe.Failure[u].Update(1)
 					continue
 				}
+				if assertionErr != nil {
+					sklog.Warningf("Response assertion failed: Name: %s URL: %s Error: %s", name, u, assertionErr)
+					probe.Failure[u].Update(1)
+					continue
+				}
 			}
 
 			probe.Failure[u].Update(0)
@@ -230,6 +290,70 @@ func probeOneRound(cfg types.Probes, anonymousClient, authClient *http.Client) {
 	}
 }
 
+// isNonHTTPMethod returns true if the method is one of the probe types implemented in the probes
+// package.
+func isNonHTTPMethod(method string) bool {
+	switch method {
+	case types.MethodTLS, types.MethodTCP, types.MethodDNS, types.MethodGRPC, types.MethodSteps:
+		return true
+	}
+	return false
+}
+
+// probeNonHTTP runs the probe for a method from isNonHTTPMethod against the URL.
+func probeNonHTTP(ctx context.Context, probe *types.Probe, u string, c *http.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	switch probe.Method {
+	case types.MethodTLS:
+		return probeTLS(ctx, probe, u)
+	case types.MethodTCP:
+		return probes.TCP(ctx, u)
+	case types.MethodDNS:
+		addrs, err := probes.DNS(ctx, net.DefaultResolver, u)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		return probes.CheckAssertions(probe.Assertions, []byte(strings.Join(addrs, "\n")), nil)
+	case types.MethodGRPC:
+		return probes.GRPCHealth(ctx, u, probe.GRPCService, probe.Plaintext)
+	case types.MethodSteps:
+		return probes.RunSteps(ctx, c, u, probe.Steps)
+	}
+	return skerr.Fmt("unknown method: %s", probe.Method)
+}
+
+// probeTLS records the number of days until the certs of the URL expire, and returns an error if
+// the certs do not verify or will expire within the number of days in Expected[0], which
+// defaults to the same duration as SSL probes.
+func probeTLS(ctx context.Context, probe *types.Probe, URL string) error {
+	addr := URL
+	if parsedURL, err := url.Parse(URL); err == nil && parsedURL.Host != "" {
+		addr = parsedURL.Host
+		if parsedURL.Port() == "" {
+			addr += ":443"
+		}
+	}
+	expiry, err := probes.CertExpiry(ctx, addr, nil)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	delta := time.Until(expiry)
+	if m, ok := probe.CertExpiry[URL]; ok {
+		m.Update(int64(delta / (24 * time.Hour)))
+	}
+
+	minExpirationDelta := defaultSSLValidDuration
+	if len(probe.Expected) > 0 && probe.Expected[0] > 0 {
+		minExpirationDelta = time.Duration(probe.Expected[0]) * 24 * time.Hour
+	}
+	if delta < minExpirationDelta {
+		return skerr.Fmt("Certificate for %s is expired or will expire in %s.", addr, human.Duration(delta))
+	}
+	return nil
+}
+
 // probeSSL inspects the SSL cert for the given URL and checks whether
 // the time to expiration is below a certain number of days.
 func probeSSL(probe *types.Probe, URL string) error {
@@ -335,6 +459,9 @@ func main() {
 		for _, u := range probe.URLs {
 			probe.Failure[u] = metrics2.GetInt64Metric("prober", map[string]string{"type": "failure", "probename": name, "url": u})
 			probe.Latency[u] = metrics2.GetInt64Metric("prober", map[string]string{"type": "latency", "probename": name, "url": u})
+			if probe.Method == types.MethodTLS {
+				probe.CertExpiry[u] = metrics2.GetInt64Metric("prober", map[string]string{"type": "cert_expiry_days", "probename": name, "url": u})
+			}
 		}
 	}
 
@@ -352,9 +479,9 @@ func main() {
 		return http.ErrUseLastResponse
 	}
 
-	probeOneRound(cfg, anonymousClient, authClient)
+	probeOneRound(ctx, cfg, anonymousClient, authClient)
 	for range time.Tick(*runEvery) {
-		probeOneRound(cfg, anonymousClient, authClient)
+		probeOneRound(ctx, cfg, anonymousClient, authClient)
 		liveness.Reset()
 
 		currentHash, err := getHashOfConfigFile()
@@ -0,0 +1,158 @@
+package probes
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/proberk/go/types"
+)
+
+// CheckAssertions returns an error describing the first assertion that the response with the
+// specified body and header fails.
+func CheckAssertions(assertions []*types.Assertion, body []byte, header http.Header) error {
+	var doc interface{}
+	docParsed := false
+	for _, a := range assertions {
+		var value string
+		switch {
+		case a.JSONPath != "":
+			if !docParsed {
+				var err error
+				if doc, err = decodeJSON(body); err != nil {
+					return skerr.Wrapf(err, "assertion on %s requires a JSON response", a.JSONPath)
+				}
+				docParsed = true
+			}
+			v, err := EvalJSONPath(doc, a.JSONPath)
+			if err != nil {
+				return skerr.Wrap(err)
+			}
+			if value, err = jsonValueToString(v); err != nil {
+				return skerr.Wrap(err)
+			}
+		case a.Header != "":
+			values := header.Values(a.Header)
+			if len(values) == 0 {
+				return skerr.Fmt("header %s not found", a.Header)
+			}
+			value = strings.Join(values, ",")
+		default:
+			value = string(body)
+		}
+		if err := checkValue(a, value); err != nil {
+			return skerr.Wrapf(err, "assertion failed on %s", describe(a))
+		}
+	}
+	return nil
+}
+
+// checkValue checks the value selected by the assertion.
+func checkValue(a *types.Assertion, value string) error {
+	if a.Equals != "" && value != a.Equals {
+		return skerr.Fmt("got %q, want %q", value, a.Equals)
+	}
+	if a.Contains != "" && !strings.Contains(value, a.Contains) {
+		return skerr.Fmt("%q does not contain %q", value, a.Contains)
+	}
+	if a.Matches != "" {
+		re, err := regexp.Compile(a.Matches)
+		if err != nil {
+			return skerr.Wrapf(err, "invalid regexp %q", a.Matches)
+		}
+		if !re.MatchString(value) {
+			return skerr.Fmt("%q does not match %q", value, a.Matches)
+		}
+	}
+	return nil
+}
+
+// describe returns a description of what the assertion selects, for error messages.
+func describe(a *types.Assertion) string {
+	if a.JSONPath != "" {
+		return a.JSONPath
+	}
+	if a.Header != "" {
+		return "header " + a.Header
+	}
+	return "body"
+}
+
+// decodeJSON decodes the JSON document, keeping numbers in their original format.
+func decodeJSON(b []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, skerr.Wrapf(err, "invalid JSON")
+	}
+	return doc, nil
+}
+
+// jsonValueToString returns strings as is and all other JSON values in their JSON encoding.
+func jsonValueToString(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case json.Number:
+		return t.String(), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	return string(b), nil
+}
+
+// jsonPathTokenRegex matches a single token of a JSONPath after the root: ".name", "['name']",
+// "["name"]" or "[index]".
+var jsonPathTokenRegex = regexp.MustCompile(`^(?:\.([^.\[\]]+)|\['([^']*)'\]|\["([^"]*)"\]|\[(-?[0-9]+)\])`)
+
+// EvalJSONPath returns the value at the JSONPath in the decoded JSON document. Only a subset of
+// JSONPath is supported: the root "$" followed by child names (".name", "['name']") and array
+// indices ("[0]", with negative indices counting from the end).
+func EvalJSONPath(doc interface{}, path string) (interface{}, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, skerr.Fmt("JSONPath %q must start with $", path)
+	}
+	current := doc
+	rest := path[1:]
+	for rest != "" {
+		m := jsonPathTokenRegex.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, skerr.Fmt("unsupported JSONPath %q at %q", path, rest)
+		}
+		rest = rest[len(m[0]):]
+		if m[4] != "" {
+			arr, ok := current.([]interface{})
+			if !ok {
+				return nil, skerr.Fmt("%s: %s is not an array", path, m[0])
+			}
+			idx, err := strconv.Atoi(m[4])
+			if err != nil {
+				return nil, skerr.Wrap(err)
+			}
+			if idx < 0 {
+				idx += len(arr)
+			}
+			if idx < 0 || idx >= len(arr) {
+				return nil, skerr.Fmt("%s: index %s out of range for array of length %d", path, m[4], len(arr))
+			}
+			current = arr[idx]
+			continue
+		}
+		name := m[1] + m[2] + m[3]
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, skerr.Fmt("%s: cannot select %q from a non-object", path, name)
+		}
+		if current, ok = obj[name]; !ok {
+			return nil, skerr.Fmt("%s: %q not found", path, name)
+		}
+	}
+	return current, nil
+}
@@ -0,0 +1,94 @@
+package probes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/proberk/go/types"
+)
+
+const testJSON = `{
+  "status": "ok",
+  "count": 3,
+  "ratio": 0.50,
+  "enabled": true,
+  "items": [{"name": "first"}, {"name": "last", "tags": ["a", "b"]}],
+  "odd key": {"x.y": null}
+}`
+
+func TestEvalJSONPath_SupportedPaths_ReturnValues(t *testing.T) {
+	doc, err := decodeJSON([]byte(testJSON))
+	require.NoError(t, err)
+
+	for path, expected := range map[string]string{
+		"$.status":               "ok",
+		"$.count":                "3",
+		"$.ratio":                "0.50",
+		"$.enabled":              "true",
+		"$.items[0].name":        "first",
+		"$.items[-1].name":       "last",
+		"$['items'][1].tags":     `["a","b"]`,
+		`$["odd key"]['x.y']`:    "null",
+		"$.items[1]['tags'][-2]": "a",
+	} {
+		v, err := EvalJSONPath(doc, path)
+		require.NoError(t, err, path)
+		s, err := jsonValueToString(v)
+		require.NoError(t, err, path)
+		require.Equal(t, expected, s, path)
+	}
+}
+
+func TestEvalJSONPath_InvalidPaths_ReturnError(t *testing.T) {
+	doc, err := decodeJSON([]byte(testJSON))
+	require.NoError(t, err)
+
+	for path, expectedError := range map[string]string{
+		"status":          "must start with $",
+		"$.missing":       `"missing" not found`,
+		"$.items[2]":      "index 2 out of range for array of length 2",
+		"$.status[0]":     "is not an array",
+		"$.count.value":   `cannot select "value" from a non-object`,
+		"$.items[*].name": "unsupported JSONPath",
+	} {
+		_, err := EvalJSONPath(doc, path)
+		require.Error(t, err, path)
+		require.Contains(t, err.Error(), expectedError, path)
+	}
+}
+
+func TestCheckAssertions_AllPass_ReturnsNil(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	require.NoError(t, CheckAssertions([]*types.Assertion{
+		{JSONPath: "$.status", Equals: "ok"},
+		{JSONPath: "$.items[1].name", Contains: "as"},
+		{JSONPath: "$.count", Matches: "^[0-9]+$"},
+		// Only has to exist.
+		{JSONPath: "$.odd key"},
+		{Header: "Content-Type", Contains: "application/json"},
+		{Contains: `"enabled": true`},
+	}, []byte(testJSON), header))
+}
+
+func TestCheckAssertions_Failures_ReturnError(t *testing.T) {
+	for _, test := range []struct {
+		assertion     *types.Assertion
+		body          string
+		expectedError string
+	}{
+		{assertion: &types.Assertion{JSONPath: "$.status", Equals: "bad"}, body: testJSON, expectedError: `assertion failed on $.status: got "ok", want "bad"`},
+		{assertion: &types.Assertion{JSONPath: "$.missing"}, body: testJSON, expectedError: `"missing" not found`},
+		{assertion: &types.Assertion{JSONPath: "$.status"}, body: "<html>", expectedError: "requires a JSON response"},
+		{assertion: &types.Assertion{Header: "X-Missing"}, body: testJSON, expectedError: "header X-Missing not found"},
+		{assertion: &types.Assertion{Contains: "nope"}, body: testJSON, expectedError: "assertion failed on body"},
+		{assertion: &types.Assertion{JSONPath: "$.count", Matches: "^[a-z]+$"}, body: testJSON, expectedError: `"3" does not match`},
+		{assertion: &types.Assertion{Matches: "("}, body: testJSON, expectedError: "invalid regexp"},
+	} {
+		err := CheckAssertions([]*types.Assertion{test.assertion}, []byte(test.body), http.Header{})
+		require.Error(t, err, test.expectedError)
+		require.Contains(t, err.Error(), test.expectedError)
+	}
+}
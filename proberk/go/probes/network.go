@@ -0,0 +1,104 @@
+// Package probes implements the probe types of proberk that go beyond a single HTTP request.
+package probes
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+)
+
+// TCP checks that a TCP connection can be established to the "host:port" address.
+func TCP(ctx context.Context, addr string) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return skerr.Wrapf(err, "failed to connect to %s", addr)
+	}
+	util.Close(conn)
+	return nil
+}
+
+// Resolver resolves host names. It is implemented by net.Resolver.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// DNS returns the addresses that the host name resolves to. It is an error for the host name to
+// resolve to no addresses.
+func DNS(ctx context.Context, resolver Resolver, host string) ([]string, error) {
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to resolve %s", host)
+	}
+	if len(addrs) == 0 {
+		return nil, skerr.Fmt("%s resolved to no addresses", host)
+	}
+	return addrs, nil
+}
+
+// GRPCHealth calls the standard gRPC health check service at the "host:port" address and returns
+// an error if the service is not SERVING. An empty service checks the overall health of the
+// server. TLS is used unless plaintext is true.
+func GRPCHealth(ctx context.Context, addr, service string, plaintext bool) error {
+	creds := credentials.NewTLS(&tls.Config{})
+	if plaintext {
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return skerr.Wrapf(err, "failed to dial %s", addr)
+	}
+	defer util.Close(conn)
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+	if err != nil {
+		return skerr.Wrapf(err, "health check of %s failed", addr)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return skerr.Fmt("%s is %s", addr, resp.Status)
+	}
+	return nil
+}
+
+// CertExpiry connects to the "host:port" address over TLS, verifies its cert chain and host name,
+// and returns the time at which the first cert of the chain expires. A nil conf verifies against
+// the system roots.
+func CertExpiry(ctx context.Context, addr string, conf *tls.Config) (time.Time, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return time.Time{}, skerr.Wrapf(err, "invalid address %s", addr)
+	}
+	if conf == nil {
+		conf = &tls.Config{}
+	}
+	conf = conf.Clone()
+	conf.ServerName = host
+
+	d := tls.Dialer{Config: conf}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return time.Time{}, skerr.Wrapf(err, "TLS handshake with %s failed", addr)
+	}
+	defer util.Close(conn)
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return time.Time{}, skerr.Fmt("unable to retrieve peer certificates for %s", addr)
+	}
+	expiry := certs[0].NotAfter
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.Before(expiry) {
+			expiry = cert.NotAfter
+		}
+	}
+	return expiry, nil
+}
@@ -0,0 +1,93 @@
+package probes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestTCP_ListeningAndClosedPorts(t *testing.T) {
+	ctx := context.Background()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+
+	require.NoError(t, TCP(ctx, addr))
+	require.NoError(t, l.Close())
+	require.Error(t, TCP(ctx, addr))
+}
+
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	addrs, ok := f[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func TestDNS_ResolvesHosts(t *testing.T) {
+	ctx := context.Background()
+	r := fakeResolver{
+		"skia.org":  {"1.2.3.4", "::1"},
+		"empty.org": {},
+	}
+
+	addrs, err := DNS(ctx, r, "skia.org")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1.2.3.4", "::1"}, addrs)
+
+	_, err = DNS(ctx, r, "empty.org")
+	require.Contains(t, err.Error(), "empty.org resolved to no addresses")
+	_, err = DNS(ctx, r, "missing.org")
+	require.Contains(t, err.Error(), "failed to resolve missing.org")
+}
+
+func TestGRPCHealth_ServingAndNotServing(t *testing.T) {
+	ctx := context.Background()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("skia.Fiddle", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	grpc_health_v1.RegisterHealthServer(srv, healthServer)
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	defer srv.Stop()
+	addr := l.Addr().String()
+
+	require.NoError(t, GRPCHealth(ctx, addr, "", true))
+	err = GRPCHealth(ctx, addr, "skia.Fiddle", true)
+	require.Contains(t, err.Error(), "is NOT_SERVING")
+	err = GRPCHealth(ctx, addr, "skia.Unknown", true)
+	require.Contains(t, err.Error(), "health check of "+addr+" failed")
+}
+
+func TestCertExpiry_ValidAndUntrustedCerts(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	addr := srv.Listener.Addr().String()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	expiry, err := CertExpiry(ctx, addr, &tls.Config{RootCAs: roots})
+	require.NoError(t, err)
+	require.Equal(t, srv.Certificate().NotAfter, expiry)
+
+	// The test server's cert is not trusted by the system roots.
+	_, err = CertExpiry(ctx, addr, nil)
+	require.Contains(t, err.Error(), "TLS handshake with "+addr+" failed")
+}
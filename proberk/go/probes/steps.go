@@ -0,0 +1,148 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/proberk/go/types"
+)
+
+// Maximum size of a response body read by a step.
+const maxStepResponseSize = 10 * 1024 * 1024
+
+// varRegex matches references to captured values, eg: "${token}".
+var varRegex = regexp.MustCompile(`\$\{([a-zA-Z0-9_]+)\}`)
+
+// RunSteps runs the steps in order, resolving relative step URLs against baseURL. Each run uses a
+// fresh cookie jar so that steps can log in with cookies without leaking sessions between runs.
+func RunSteps(ctx context.Context, client *http.Client, baseURL string, steps []*types.Step) error {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return skerr.Wrapf(err, "invalid base URL %s", baseURL)
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	c := *client
+	c.Jar = jar
+
+	vars := map[string]string{}
+	for i, step := range steps {
+		name := step.Name
+		if name == "" {
+			name = fmt.Sprintf("step %d", i+1)
+		}
+		if err := runStep(ctx, &c, base, step, vars); err != nil {
+			return skerr.Wrapf(err, "%s failed", name)
+		}
+	}
+	return nil
+}
+
+// runStep runs a single step and adds the values it captures to vars.
+func runStep(ctx context.Context, client *http.Client, base *url.URL, step *types.Step, vars map[string]string) error {
+	rawURL, err := expandVars(step.URL, vars)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	ref, err := url.Parse(rawURL)
+	if err != nil {
+		return skerr.Wrapf(err, "invalid URL %s", rawURL)
+	}
+	body, err := expandVars(step.Body, vars)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	method := step.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, base.ResolveReference(ref).String(), strings.NewReader(body))
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if step.MimeType != "" {
+		req.Header.Set("Content-Type", step.MimeType)
+	}
+	for k, v := range step.Headers {
+		if v, err = expandVars(v, vars); err != nil {
+			return skerr.Wrap(err)
+		}
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return skerr.Wrapf(err, "request failed")
+	}
+	defer util.Close(resp.Body)
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxStepResponseSize))
+	if err != nil {
+		return skerr.Wrapf(err, "failed to read response")
+	}
+
+	expected := step.Expected
+	if len(expected) == 0 {
+		expected = []int{http.StatusOK}
+	}
+	if !statusIn(resp.StatusCode, expected) {
+		return skerr.Fmt("got status code %d, want %v", resp.StatusCode, expected)
+	}
+	if err := CheckAssertions(step.Assertions, respBody, resp.Header); err != nil {
+		return skerr.Wrap(err)
+	}
+
+	if len(step.Capture) > 0 {
+		doc, err := decodeJSON(respBody)
+		if err != nil {
+			return skerr.Wrapf(err, "capturing values requires a JSON response")
+		}
+		for name, path := range step.Capture {
+			v, err := EvalJSONPath(doc, path)
+			if err != nil {
+				return skerr.Wrapf(err, "failed to capture %s", name)
+			}
+			if vars[name], err = jsonValueToString(v); err != nil {
+				return skerr.Wrapf(err, "failed to capture %s", name)
+			}
+		}
+	}
+	return nil
+}
+
+// statusIn returns true if the status code is one of the expected codes.
+func statusIn(code int, expected []int) bool {
+	for _, e := range expected {
+		if e == code {
+			return true
+		}
+	}
+	return false
+}
+
+// expandVars replaces references to captured values in s. It is an error to refer to a value
+// that has not been captured.
+func expandVars(s string, vars map[string]string) (string, error) {
+	var missing []string
+	expanded := varRegex.ReplaceAllStringFunc(s, func(ref string) string {
+		name := varRegex.FindStringSubmatch(ref)[1]
+		v, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", skerr.Fmt("no captured value for %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
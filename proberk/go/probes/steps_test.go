@@ -0,0 +1,105 @@
+package probes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/proberk/go/types"
+)
+
+// newAPIServer returns a server with a login endpoint that returns a token and sets a session
+// cookie, and an API endpoint that requires both.
+func newAPIServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var creds map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&creds))
+		if creds["user"] != "superman" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1"})
+		_, err := fmt.Fprint(w, `{"auth": {"token": "abc", "user_id": 7}}`)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/api/users/7", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "s1" || r.Header.Get("Authorization") != "Bearer abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err = fmt.Fprint(w, `{"name": "Clark", "roles": ["admin"]}`)
+		require.NoError(t, err)
+	})
+	return httptest.NewServer(mux)
+}
+
+func loginAndGetUserSteps(user string) []*types.Step {
+	return []*types.Step{
+		{
+			Name:     "login",
+			URL:      "/login",
+			Method:   http.MethodPost,
+			Body:     fmt.Sprintf(`{"user": %q}`, user),
+			MimeType: "application/json",
+			Capture:  map[string]string{"token": "$.auth.token", "id": "$.auth.user_id"},
+		},
+		{
+			Name:    "get user",
+			URL:     "/api/users/${id}",
+			Headers: map[string]string{"Authorization": "Bearer ${token}"},
+			Assertions: []*types.Assertion{
+				{JSONPath: "$.name", Equals: "Clark"},
+				{JSONPath: "$.roles[0]", Equals: "admin"},
+				{Header: "Content-Type", Equals: "application/json"},
+			},
+		},
+	}
+}
+
+func TestRunSteps_LoginCaptureAndCallAPI_Success(t *testing.T) {
+	srv := newAPIServer(t)
+	defer srv.Close()
+
+	require.NoError(t, RunSteps(context.Background(), srv.Client(), srv.URL, loginAndGetUserSteps("superman")))
+}
+
+func TestRunSteps_UnexpectedStatusCode_ReturnsError(t *testing.T) {
+	srv := newAPIServer(t)
+	defer srv.Close()
+
+	err := RunSteps(context.Background(), srv.Client(), srv.URL, loginAndGetUserSteps("batman"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "login failed: got status code 403, want [200]")
+}
+
+func TestRunSteps_FailedAssertion_ReturnsError(t *testing.T) {
+	srv := newAPIServer(t)
+	defer srv.Close()
+
+	steps := loginAndGetUserSteps("superman")
+	steps[1].Assertions = []*types.Assertion{{JSONPath: "$.name", Equals: "Bruce"}}
+	err := RunSteps(context.Background(), srv.Client(), srv.URL, steps)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `get user failed: assertion failed on $.name: got "Clark", want "Bruce"`)
+}
+
+func TestRunSteps_UncapturedValue_ReturnsError(t *testing.T) {
+	srv := newAPIServer(t)
+	defer srv.Close()
+
+	err := RunSteps(context.Background(), srv.Client(), srv.URL, []*types.Step{
+		{URL: "/api/users/${id}"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "step 1 failed: no captured value for id")
+}
@@ -22,15 +22,95 @@ import (
 //go:embed probesSchema.json
 var schema []byte
 
+// The methods of probes that are not HTTP methods.
+const (
+	// MethodSSL checks that the SSL certs of the URLs are valid for at least the number of days in
+	// Expected[0], defaulting to 10 days. The cert chain is not verified.
+	MethodSSL = "SSL"
+
+	// MethodTLS is like MethodSSL but also verifies the cert chain and host name, and records the
+	// number of days until the certs expire so that alerts can warn ahead of expiry.
+	MethodTLS = "TLS"
+
+	// MethodTCP checks that a TCP connection can be established to the "host:port" URLs.
+	MethodTCP = "TCP"
+
+	// MethodDNS checks that the host name URLs resolve. Assertions without a JSONPath or Header
+	// apply to the resolved addresses, one per line.
+	MethodDNS = "DNS"
+
+	// MethodGRPC calls the standard gRPC health check service at the "host:port" URLs and checks
+	// that the service is SERVING.
+	MethodGRPC = "GRPC"
+
+	// MethodSteps runs the Steps of the probe in order against each of the URLs.
+	MethodSteps = "STEPS"
+)
+
 // ResponseTester tests the response from a probe and returns true if it passes all tests.
 type ResponseTester func(io.Reader, http.Header) bool
 
+// Assertion is a check on a response. It selects a value from the response using JSONPath or
+// Header, or uses the whole body if neither is set. If none of Equals, Contains or Matches are set
+// then the selected value only has to exist.
+type Assertion struct {
+	// JSONPath selects a value from a JSON response body, eg: "$.items[0].name". Supports child
+	// names (".name" or "['name']") and array indices ("[0]", "[-1]" for the last element).
+	JSONPath string `json:"jsonpath,omitempty"`
+
+	// Header selects the value of a response header.
+	Header string `json:"header,omitempty"`
+
+	// Equals is the expected value. Strings are compared without quotes and other JSON values
+	// are compared in their JSON encoding, eg: "true" or "[1,2]".
+	Equals string `json:"equals,omitempty"`
+
+	// Contains is a substring that the value must contain.
+	Contains string `json:"contains,omitempty"`
+
+	// Matches is a regular expression that the value must match.
+	Matches string `json:"matches,omitempty"`
+}
+
+// Step is a single HTTP request of a MethodSteps probe. The URL, Body and Headers may refer to
+// values captured by previous steps as ${name}.
+type Step struct {
+	// Name of the step, used in error messages. Defaults to "step N".
+	Name string `json:"name,omitempty"`
+
+	// URL to request. Relative URLs are resolved against the URL of the probe.
+	URL string `json:"url"`
+
+	// Method is the HTTP method of the request. Defaults to GET.
+	Method string `json:"method,omitempty"`
+
+	// Body is the body of the request.
+	Body string `json:"body,omitempty"`
+
+	// The mimetype of the Body.
+	MimeType string `json:"mimetype,omitempty"`
+
+	// Headers to add to the request. Eg: {"Authorization": "Bearer ${token}"}.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Expected is the list of expected HTTP status codes. Defaults to [200].
+	Expected []int `json:"expected,omitempty"`
+
+	// Assertions that the response must pass.
+	Assertions []*Assertion `json:"assertions,omitempty"`
+
+	// Capture maps names to JSONPaths of values in the JSON response body. The captured values
+	// can be used by later steps as ${name}.
+	Capture map[string]string `json:"capture,omitempty"`
+}
+
 // Probe is a single endpoint we are probing.
 type Probe struct {
-	// URL is the HTTP URL to probe.
+	// URL is the HTTP URL to probe. For the TCP and GRPC methods it is the "host:port" to connect
+	// to, and for the DNS method it is the host name to resolve.
 	URLs []string `json:"urls"`
 
-	// Method is the HTTP method to use when probing.
+	// Method is the HTTP method to use when probing, or one of the non-HTTP methods above.
 	Method string `json:"method"`
 
 	// Expected is the list of expected HTTP status code, i.e. [200, 201]
@@ -50,9 +130,24 @@ type Probe struct {
 
 	ResponseTest ResponseTester `json:"-"`
 
+	// Assertions that the response must pass. Prefer these over ResponseTestName for new probes.
+	Assertions []*Assertion `json:"assertions,omitempty"`
+
+	// Steps of a MethodSteps probe.
+	Steps []*Step `json:"steps,omitempty"`
+
+	// GRPCService is the name of the service to health check for the GRPC method. Defaults to
+	// the overall health of the server.
+	GRPCService string `json:"grpc_service,omitempty"`
+
+	// Plaintext disables TLS for the GRPC method.
+	Plaintext bool `json:"plaintext,omitempty"`
+
 	//      map[url]metric.
 	Failure map[string]metrics2.Int64Metric `json:"-"`
 	Latency map[string]metrics2.Int64Metric `json:"-"` // Latency in ms.
+	// Days until the certs expire. Only populated for the TLS method.
+	CertExpiry map[string]metrics2.Int64Metric `json:"-"`
 
 	// Note is some comment about this prober.
 	Note string `json:"note,omitempty"`
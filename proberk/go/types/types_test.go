@@ -3,9 +3,12 @@ package types
 import (
 	"context"
 	_ "embed"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/jsonschema"
 )
 
 func TestLoadFromJSONFile_FileViolatesSchema_ReturnsError(t *testing.T) {
@@ -18,3 +21,25 @@ func TestLoadFromJSONFile_ValidFile_Success(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, probers, 1)
 }
+
+func TestLoadFromJSONFile_ProbeTypes_Success(t *testing.T) {
+	document, err := os.ReadFile("./testdata/probe_types.json")
+	require.NoError(t, err)
+	validationErrors, err := jsonschema.Validate(context.Background(), document, schema)
+	require.NoError(t, err, validationErrors)
+
+	probers, err := LoadFromJSONFile(context.Background(), "./testdata/probe_types.json")
+	require.NoError(t, err)
+	require.Len(t, probers, 5)
+	require.Equal(t, MethodGRPC, probers["grpc"].Method)
+	require.Equal(t, "cabe.proto.Analysis", probers["grpc"].GRPCService)
+	require.Equal(t, []*Assertion{{Matches: "^[0-9.:a-f\\n]+$"}}, probers["dns"].Assertions)
+	steps := probers["api"].Steps
+	require.Len(t, steps, 2)
+	require.Equal(t, map[string]string{"token": "$.token"}, steps[0].Capture)
+	// The name and method of a step are optional.
+	require.Empty(t, steps[1].Name)
+	require.Empty(t, steps[1].Method)
+	require.Equal(t, "Bearer ${token}", steps[1].Headers["Authorization"])
+	require.Equal(t, []*Assertion{{JSONPath: "$.items[0].name", Equals: "first"}}, steps[1].Assertions)
+}
@@ -23,11 +23,13 @@ import (
 const maxScrapSize = 128 * 1024
 
 var (
-	ErrInvalidScrapType = errors.New("Invalid scrap type.")
-	ErrInvalidScrapName = errors.New("Invalid scrap name.")
-	ErrInvalidLanguage  = errors.New("Invalid language.")
-	ErrInvalidHash      = errors.New("Invalid SHA256 hash.")
-	ErrInvalidScrapSize = errors.New("Scrap is too large.")
+	ErrInvalidScrapType    = errors.New("Invalid scrap type.")
+	ErrInvalidScrapName    = errors.New("Invalid scrap name.")
+	ErrInvalidLanguage     = errors.New("Invalid language.")
+	ErrInvalidHash         = errors.New("Invalid SHA256 hash.")
+	ErrInvalidScrapSize    = errors.New("Scrap is too large.")
+	ErrInvalidMeshVertices = errors.New("Mesh vertices must be x, y pairs of whole triangles.")
+	ErrNoTemplate          = errors.New("No template exists for this scrap type and language.")
 )
 
 // SHA256 is a SHA 256 hash encoded in hex.
@@ -46,12 +48,18 @@ const (
 	// Particle scrap.
 	Particle Type = "particle"
 
+	// Lottie scrap, a Lottie animation as played by Skottie.
+	Lottie Type = "lottie"
+
+	// SKSLMesh scrap, the SkSL vertex and fragment programs of an SkMesh.
+	SKSLMesh Type = "skslmesh"
+
 	// UnknownType type of scrap.
 	UnknownType Type = ""
 )
 
 // AllTypes is a slice of supported Types.
-var AllTypes = []Type{SVG, SKSL, Particle}
+var AllTypes = []Type{SVG, SKSL, Particle, Lottie, SKSLMesh}
 
 // ToType converts a string to a Type, returning UnknownType if it is not a
 // valid Type.
@@ -82,12 +90,15 @@ const (
 	// JS is the Javascript language.
 	JS Lang = "js"
 
+	// Python is the Python language, using the skia-python bindings.
+	Python Lang = "py"
+
 	// UnknownLang is an unknown language.
 	UnknownLang Lang = ""
 )
 
 // AllLangs is the list of all supported Langs.
-var AllLangs = []Lang{CPP, JS}
+var AllLangs = []Lang{CPP, JS, Python}
 
 // ToLang converts a string to a Lang, returning UnknownLang if it not a valid
 // Lang.
@@ -113,6 +124,8 @@ var MimeTypes = map[Type]string{
 	SVG:      "image/svg+xml",
 	SKSL:     "text/plain",
 	Particle: "application/json",
+	Lottie:   "application/json",
+	SKSLMesh: "text/plain",
 }
 
 // SVGMetaData is metadata for SVG scraps.
@@ -149,6 +162,22 @@ type SKSLMetaData struct {
 type ParticlesMetaData struct {
 }
 
+// LottieMetaData is metadata for Lottie scraps.
+type LottieMetaData struct {
+}
+
+// SKSLMeshMetaData is metadata for SKSLMesh scraps. The scrap body is the
+// vertex program of the mesh.
+type SKSLMeshMetaData struct {
+	// FragmentBody is the fragment program of the mesh.
+	FragmentBody string
+
+	// Vertices are the x, y positions of the triangles of the mesh, so the
+	// length must be a multiple of 6. A quad that covers the canvas is drawn
+	// if empty.
+	Vertices []float32
+}
+
 // ScrapBody is the body of scrap stored in GCS and transported by the API.
 type ScrapBody struct {
 	Type Type
@@ -158,6 +187,8 @@ type ScrapBody struct {
 	SVGMetaData       *SVGMetaData       `json:",omitempty"`
 	SKSLMetaData      *SKSLMetaData      `json:",omitempty"`
 	ParticlesMetaData *ParticlesMetaData `json:",omitempty"`
+	LottieMetaData    *LottieMetaData    `json:",omitempty"`
+	SKSLMeshMetaData  *SKSLMeshMetaData  `json:",omitempty"`
 }
 
 // ScrapID contains the identity of a newly created scrap.
@@ -245,6 +276,9 @@ func (s *scrapExchange) validate(scrap *ScrapBody) error {
 	if err := validateType(scrap.Type); err != nil {
 		return err
 	}
+	if scrap.Type == SKSLMesh && scrap.SKSLMeshMetaData != nil && len(scrap.SKSLMeshMetaData.Vertices)%6 != 0 {
+		return skerr.Wrapf(ErrInvalidMeshVertices, "got %d values", len(scrap.SKSLMeshMetaData.Vertices))
+	}
 	return nil
 }
 
@@ -254,6 +288,10 @@ func (s *scrapExchange) Expand(ctx context.Context, t Type, hashOrName string, l
 	if err := validateLang(lang); err != nil {
 		return err
 	}
+	tmpl, ok := s.templates[lang][t]
+	if !ok {
+		return skerr.Wrapf(ErrNoTemplate, "%s", templateName(t, lang))
+	}
 	// loadedScraps maps hashOrName to scrapNode to avoid duplicate loads.
 	loadedScraps := make(map[string]scrapNode)
 	root, err := s.loadScrapTree(ctx, t, hashOrName, loadedScraps)
@@ -262,7 +300,7 @@ func (s *scrapExchange) Expand(ctx context.Context, t Type, hashOrName string, l
 	}
 	nextNodeID := 1
 	createScrapNodeNames(&root, &nextNodeID)
-	err = s.templates[lang][t].Execute(w, root)
+	err = tmpl.Execute(w, root)
 	if err != nil {
 		return skerr.Wrapf(err, "Failed to expand template.")
 	}
@@ -139,6 +139,19 @@ func TestExpand_InvalidLang_ReturnsError(t *testing.T) {
 	require.Contains(t, err.Error(), ErrInvalidLanguage.Error())
 }
 
+func TestExpand_NoTemplateForTypeAndLang_ReturnsError(t *testing.T) {
+	se, err := New(&test_gcsclient.GCSClient{})
+	require.NoError(t, err)
+
+	for _, lang := range []Lang{JS, Python} {
+		var w bytes.Buffer
+		err = se.Expand(context.Background(), SKSLMesh, svgHash, lang, &w)
+		require.ErrorIs(t, err, ErrNoTemplate)
+		require.Contains(t, err.Error(), templateName(SKSLMesh, lang))
+		require.Empty(t, w.String())
+	}
+}
+
 func TestLoadScrap_HappyPathWithHash_Success(t *testing.T) {
 	s := &test_gcsclient.GCSClient{}
 
@@ -272,6 +285,19 @@ func TestCreateScrap_InvalidScrapType_ReturnsError(t *testing.T) {
 	require.Contains(t, err.Error(), ErrInvalidScrapType.Error())
 }
 
+func TestCreateScrap_MeshWithPartialTriangle_ReturnsError(t *testing.T) {
+	s := &test_gcsclient.GCSClient{}
+	se, err := New(s)
+	require.NoError(t, err)
+	sentBody := ScrapBody{
+		Type:             SKSLMesh,
+		Body:             "Varyings main(const Attributes a) { Varyings v; return v; }",
+		SKSLMeshMetaData: &SKSLMeshMetaData{Vertices: []float32{0, 0, 1, 0, 1}},
+	}
+	_, err = se.CreateScrap(context.Background(), sentBody)
+	require.Contains(t, err.Error(), ErrInvalidMeshVertices.Error())
+}
+
 func TestCreateScrap_TooLargeScrap_ReturnsError(t *testing.T) {
 	s := &test_gcsclient.GCSClient{}
 	se, err := New(s)
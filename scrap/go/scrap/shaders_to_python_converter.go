@@ -0,0 +1,164 @@
+package scrap
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// loadImagesPython is a template.Template callback to write Python code to
+// load all images used by the |root| scrap, and all child scraps, and create
+// an image shader for each.
+func loadImagesPython(root scrapNode) (string, error) {
+	urls, err := root.getImageURLs()
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	info, err := newWriteInfo(root)
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	var b bytes.Buffer
+	for _, url := range urls {
+		imgID, ok := info.imageIDs[url]
+		if !ok {
+			return "", skerr.Fmt("Cannot find id for img url %q", url)
+		}
+		mustWriteStringf(&b, "img%d = load_image(\"%s\")\n", imgID, url)
+		mustWriteStringf(&b, "img_shader%d = img%d.makeShader(skia.TileMode.kClamp, skia.TileMode.kClamp)\n", imgID, imgID)
+	}
+	return b.String(), nil
+}
+
+// writeCreateEffectsPython writes Python code to create the runtime effect for
+// the scrap |node| and all child nodes to |w|.
+func writeCreateEffectsPython(w io.StringWriter, node scrapNode) {
+	for _, child := range node.Children {
+		writeCreateEffectsPython(w, child)
+	}
+	mustWriteStringf(w, "\n")
+	if node.Name != "" {
+		mustWriteStringf(w, "# Shader %q\n", node.Name)
+	}
+	mustWriteStringf(w, "prog%s = \"\"\"\n", node.Name)
+	mustWriteStringf(w, indentMultilineString(skslDefaultInputs, 4))
+	mustWriteStringf(w, "\n")
+	writeShaderInputDefinitions(w, node, 4)
+	mustWriteStringf(w, "\n")
+	mustWriteStringf(w, indentMultilineString(node.Scrap.Body, 4))
+	mustWriteStringf(w, "\n\"\"\"\n")
+	mustWriteStringf(w, "effect%s = skia.RuntimeEffect.MakeForShader(prog%s)\n", node.Name, node.Name)
+}
+
+// createEffectsPython is the template.Template callback to write Python code
+// to create all effects for the given |root| node and all child nodes.
+func createEffectsPython(root scrapNode) string {
+	var b bytes.Buffer
+	writeCreateEffectsPython(&b, root)
+	return b.String()
+}
+
+// getSkSLCustomUniformsPython returns the SkSL scrap custom uniform values as
+// the elements of a Python list, or an empty string if the scrap contains no
+// custom uniforms.
+func getSkSLCustomUniformsPython(body ScrapBody) string {
+	if body.Type != SKSL || body.SKSLMetaData == nil || len(body.SKSLMetaData.Uniforms) == 0 {
+		return ""
+	}
+	vals := make([]string, 0, len(body.SKSLMetaData.Uniforms))
+	for _, u := range body.SKSLMetaData.Uniforms {
+		vals = append(vals, fmt.Sprintf("%g", u))
+	}
+	return fmt.Sprintf("    # User supplied uniform values:\n    %s,", strings.Join(vals, ", "))
+}
+
+// writeCreateShaderPython writes Python code to create the shader for the
+// given |node| and all child nodes, with all uniform values (stock and custom)
+// and all children.
+func writeCreateShaderPython(w io.StringWriter, node scrapNode, info writeInfo) error {
+	imgID, err := getNodeImageID(node.Scrap, info)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	for _, child := range node.Children {
+		if err := writeCreateShaderPython(w, child, info); err != nil {
+			return skerr.Wrap(err)
+		}
+		mustWriteStringf(w, "\n")
+	}
+	mustWriteStringf(w, "uniforms%s = [\n", node.Name)
+	mustWriteStringf(w, "    %-57s # iResolution\n", "shader_width, shader_height, 1,")
+	mustWriteStringf(w, "    %-57s # iTime\n", "i_time,")
+	mustWriteStringf(w, "    %-57s # iMouse\n", "mouse_drag_x, mouse_drag_y, mouse_click_x, mouse_click_y,")
+	mustWriteStringf(w, "    %-57s # iImageResolution\n", fmt.Sprintf("img%d.width(), img%d.height(), 1,", imgID, imgID))
+	if u := getSkSLCustomUniformsPython(node.Scrap); u != "" {
+		mustWriteStringf(w, "%s\n", u)
+	}
+	mustWriteStringf(w, "]\n")
+
+	mustWriteStringf(w, "children%s = [\n", node.Name)
+	mustWriteStringf(w, "    img_shader%d,  # iImage1\n", imgID)
+	for _, child := range node.Children {
+		mustWriteStringf(w, "    shader%s,\n", child.Name)
+	}
+	mustWriteStringf(w, "]\n")
+	mustWriteStringf(w, "shader%s = make_shader(effect%s, uniforms%s, children%s)\n",
+		node.Name, node.Name, node.Name, node.Name)
+	return nil
+}
+
+// createShadersPython is the template.Template callback to write Python code
+// to create all shaders for the given |root| node and all child nodes.
+func createShadersPython(root scrapNode) (string, error) {
+	info, err := newWriteInfo(root)
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	var b bytes.Buffer
+	if err := writeCreateShaderPython(&b, root, info); err != nil {
+		return "", skerr.Wrap(err)
+	}
+	return b.String(), nil
+}
+
+// The template used to convert a SkSL shader scrap (from shaders.skia.org)
+// to a Python program that uses skia-python to draw the shader to a PNG.
+const skslPython = `import struct
+import urllib.request
+
+import skia
+
+shader_width = 512
+shader_height = 512
+i_time = 0.0
+mouse_drag_x, mouse_drag_y = 0.0, 0.0
+mouse_click_x, mouse_click_y = 0.0, 0.0
+
+
+def load_image(url):
+    with urllib.request.urlopen(url) as response:
+        return skia.Image.MakeFromEncoded(skia.Data.MakeWithCopy(response.read()))
+
+
+def make_shader(effect, uniforms, children):
+    if effect is None:
+        raise RuntimeError("Could not make effect")
+    data = skia.Data.MakeWithCopy(struct.pack("%df" % len(uniforms), *uniforms))
+    shader = effect.makeShader(data, children)
+    if shader is None:
+        raise RuntimeError("Could not make shader")
+    return shader
+
+
+{{ loadImagesPython . }}{{ createEffectsPython . }}
+{{ createShadersPython . }}
+surface = skia.Surface(shader_width, shader_height)
+with surface as canvas:
+    paint = skia.Paint()
+    paint.setShader(shader{{ .Name }})
+    canvas.drawPaint(paint)
+surface.makeImageSnapshot().save("output.png", skia.kPNG)
+`
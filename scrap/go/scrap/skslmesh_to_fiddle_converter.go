@@ -0,0 +1,84 @@
+package scrap
+
+import (
+	"bytes"
+)
+
+// defaultMeshVertices are two triangles that cover the 256x256 fiddle canvas.
+var defaultMeshVertices = []float32{
+	0, 0, 256, 0, 0, 256,
+	0, 256, 256, 0, 256, 256,
+}
+
+// getSkSLMeshFragment is a template helper function that returns the fragment
+// program of an SKSLMesh scrap.
+func getSkSLMeshFragment(body ScrapBody) string {
+	if body.SKSLMeshMetaData == nil {
+		return ""
+	}
+	return body.SKSLMeshMetaData.FragmentBody
+}
+
+// getSkSLMeshVerticesCPP is a template helper function that returns the
+// vertices of an SKSLMesh scrap as the elements of a C++ SkPoint array, one
+// triangle per line. For example:
+//
+//	{0.0f, 0.0f}, {256.0f, 0.0f}, {0.0f, 256.0f},
+func getSkSLMeshVerticesCPP(body ScrapBody) string {
+	vertices := defaultMeshVertices
+	if body.SKSLMeshMetaData != nil && len(body.SKSLMeshMetaData.Vertices) > 0 {
+		vertices = body.SKSLMeshMetaData.Vertices
+	}
+	var b bytes.Buffer
+	for i := 0; i+6 <= len(vertices); i += 6 {
+		if i > 0 {
+			mustWriteStringf(&b, "\n")
+		}
+		mustWriteStringf(&b, "        {%s}, {%s}, {%s},",
+			floatSliceToString(vertices[i:i+2]),
+			floatSliceToString(vertices[i+2:i+4]),
+			floatSliceToString(vertices[i+4:i+6]),
+		)
+	}
+	return b.String()
+}
+
+// The template used to convert an SkSL mesh scrap to C++ suitable for use in
+// fiddle.skia.org. The scrap body is the vertex program.
+const skslMeshCpp = `void draw(SkCanvas* canvas) {
+    const SkMeshSpecification::Attribute attributes[] = {
+        {SkMeshSpecification::Attribute::Type::kFloat2, 0, SkString("position")},
+    };
+    const SkMeshSpecification::Varying varyings[] = {
+        {SkMeshSpecification::Varying::Type::kFloat2, SkString("position")},
+    };
+
+    constexpr char vs[] = R"(
+{{ indentMultilineString .Scrap.Body 8 }}
+    )";
+    constexpr char fs[] = R"(
+{{ indentMultilineString (getSkSLMeshFragment .Scrap) 8 }}
+    )";
+    auto [spec, err] = SkMeshSpecification::Make(attributes, sizeof(SkPoint), varyings,
+                                                 SkString(vs), SkString(fs));
+    if (!spec) {
+        SkDebugf("Cannot create mesh specification: %s", err.c_str());
+        return;
+    }
+
+    const SkPoint vertices[] = {
+{{ getSkSLMeshVerticesCPP .Scrap }}
+    };
+    sk_sp<SkMesh::VertexBuffer> vertexBuffer =
+            SkMeshes::MakeVertexBuffer(vertices, sizeof(vertices));
+    auto [mesh, meshErr] = SkMesh::Make(spec, SkMesh::Mode::kTriangles, vertexBuffer,
+                                        std::size(vertices), 0, nullptr, {},
+                                        SkRect::BoundsOrEmpty(vertices));
+    if (!mesh.isValid()) {
+        SkDebugf("Cannot create mesh: %s", meshErr.c_str());
+        return;
+    }
+
+    canvas->clear(SK_ColorBLACK);
+    canvas->drawMesh(mesh, nullptr, SkPaint());
+}`
@@ -3,6 +3,7 @@ package scrap
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"regexp"
@@ -92,6 +93,18 @@ func bodyAsQuotedStringSlice(body string) []string {
 	return ret
 }
 
+// bodyAsStringLiteral is a template helper function that quotes a ScrapBody
+// as a single string literal that is valid in both JavaScript and Python.
+func bodyAsStringLiteral(body string) (string, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(body); err != nil {
+		return "", skerr.Wrap(err)
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
 // indentMultilineString will indent each line of a multiline string,
 // if that line is not empty, by the number of spaces specified by |indent|.
 // Each line will also be trimmed of all trailing whitespace characters.
@@ -156,6 +169,8 @@ func getSkSLCustomUniforms(body ScrapBody) string {
 // funcMap are the template helper functions available in each template.
 var funcMap = template.FuncMap{
 	"bodyAsQuotedStringSlice": bodyAsQuotedStringSlice,
+	"bodyAsStringLiteral":     bodyAsStringLiteral,
+	"indentMultilineString":   indentMultilineString,
 	"getSkSLImageURL":         getSkSLImageURL,
 	"getSkSLCustomUniforms":   getSkSLCustomUniforms,
 	"createShadersCPP":        createShadersCPP,
@@ -164,6 +179,11 @@ var funcMap = template.FuncMap{
 	"createFragmentShadersJS": createFragmentShadersJS,
 	"putShaderOnPaintJS":      putShaderOnPaintJS,
 	"deleteShadersJS":         deleteShadersJS,
+	"loadImagesPython":        loadImagesPython,
+	"createEffectsPython":     createEffectsPython,
+	"createShadersPython":     createShadersPython,
+	"getSkSLMeshFragment":     getSkSLMeshFragment,
+	"getSkSLMeshVerticesCPP":  getSkSLMeshVerticesCPP,
 }
 
 func loadTemplates() (templateMap, error) {
@@ -171,7 +191,12 @@ func loadTemplates() (templateMap, error) {
 	for _, lang := range AllLangs {
 		ret[lang] = map[Type]*template.Template{}
 		for _, t := range AllTypes {
-			tmpl, err := template.New("").Funcs(funcMap).Parse(templates[templateName(t, lang)])
+			text, ok := templates[templateName(t, lang)]
+			if !ok {
+				// Expand reports ErrNoTemplate for this combination.
+				continue
+			}
+			tmpl, err := template.New("").Funcs(funcMap).Parse(text)
 			if err != nil {
 				return nil, skerr.Wrapf(err, "Failed to parse template %v %v", lang, t)
 			}
@@ -184,10 +209,15 @@ func loadTemplates() (templateMap, error) {
 
 // TODO(cmumford) Fill in the rest of the templates.
 var templates = map[string]string{
-	"svg-cpp":  svgCpp,
-	"svg-js":   "",
-	"sksl-cpp": skslCpp,
-	"sksl-js":  skslJavaScript,
+	"svg-cpp":      svgCpp,
+	"svg-py":       svgPython,
+	"sksl-cpp":     skslCpp,
+	"sksl-js":      skslJavaScript,
+	"sksl-py":      skslPython,
+	"lottie-cpp":   lottieCpp,
+	"lottie-js":    lottieJavaScript,
+	"lottie-py":    lottiePython,
+	"skslmesh-cpp": skslMeshCpp,
 }
 
 const svgCpp = `void draw(SkCanvas* canvas) {
@@ -215,3 +245,79 @@ const svgCpp = `void draw(SkCanvas* canvas) {
 
     svgDom->render(canvas);
 }`
+
+const svgPython = `import skia
+
+svg = {{ bodyAsStringLiteral .Scrap.Body }}
+
+stream = skia.MemoryStream(skia.Data.MakeWithCopy(svg.encode()))
+svg_dom = skia.SVGDOM.MakeFromStream(stream)
+if svg_dom is None:
+    raise RuntimeError("Failed to parse SVG.")
+
+# Use the intrinsic SVG size if available, otherwise fall back to a default value.
+if svg_dom.containerSize().isEmpty():
+    svg_dom.setContainerSize(skia.Size(128, 128))
+size = svg_dom.containerSize()
+
+surface = skia.Surface(int(size.width()), int(size.height()))
+with surface as canvas:
+    svg_dom.render(canvas)
+surface.makeImageSnapshot().save("output.png", skia.kPNG)
+`
+
+// The Lottie JSON is embedded as a raw string literal since it commonly
+// contains escaped characters of its own.
+const lottieCpp = `void draw(SkCanvas* canvas) {
+    constexpr char json[] = R"lottie({{ .Scrap.Body }})lottie";
+
+    sk_sp<skottie::Animation> animation =
+            skottie::Animation::Builder().make(json, sizeof(json) - 1);
+    if (!animation) {
+        SkDebugf("Failed to parse Lottie animation.");
+        return;
+    }
+
+    // Play the whole animation over the duration of the fiddle.
+    animation->seek(frame);
+    animation->render(canvas);
+}`
+
+const lottieJavaScript = `const animation = CanvasKit.MakeAnimation({{ bodyAsStringLiteral .Scrap.Body }});
+if (!animation) {
+  throw "Could not parse Lottie animation";
+}
+const surface = CanvasKit.MakeCanvasSurface(canvas.id);
+if (!surface) {
+  throw "Could not make surface";
+}
+const bounds = CanvasKit.LTRBRect(0, 0, canvas.width, canvas.height);
+const durationMs = animation.duration() * 1000;
+const startTimeMs = Date.now();
+
+function drawFrame(skcanvas) {
+  const elapsedMs = Date.now() - startTimeMs;
+  animation.seek(durationMs ? (elapsedMs % durationMs) / durationMs : 0);
+  skcanvas.clear(CanvasKit.WHITE);
+  animation.render(skcanvas, bounds);
+  surface.requestAnimationFrame(drawFrame);
+}
+surface.requestAnimationFrame(drawFrame);
+`
+
+const lottiePython = `import skia
+
+lottie = {{ bodyAsStringLiteral .Scrap.Body }}
+
+animation = skia.Animation.Make(lottie)
+if animation is None:
+    raise RuntimeError("Failed to parse Lottie animation.")
+size = animation.size()
+
+surface = skia.Surface(int(size.width()), int(size.height()))
+with surface as canvas:
+    # Render the first frame, seek() takes a time in [0, 1].
+    animation.seek(0)
+    animation.render(canvas)
+surface.makeImageSnapshot().save("output.png", skia.kPNG)
+`
@@ -2,6 +2,7 @@ package scrap
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -603,3 +604,278 @@ half4 main(float2 fragCoord) {
 				SKSLMetaData: &SKSLMetaData{Uniforms: []float32{0.5}},
 			}})
 }
+
+func TestTemplateExpand_SkSLToPython_ResponseMatchesExpected(t *testing.T) {
+	tmplMap, err := loadTemplates()
+	require.NoError(t, err)
+	var b bytes.Buffer
+	body := ScrapBody{
+		Type:         SKSL,
+		Body:         "uniform float  iValue;\n\nhalf4 main(in vec2 fragCoord ) {\n    return vec4( result, iValue );\n}",
+		SKSLMetaData: &SKSLMetaData{Uniforms: []float32{0.25}, ImageURL: "/img/soccer.png"},
+	}
+	err = tmplMap[Python][SKSL].Execute(&b, scrapNode{Scrap: body})
+	require.NoError(t, err)
+	expected := `import struct
+import urllib.request
+
+import skia
+
+shader_width = 512
+shader_height = 512
+i_time = 0.0
+mouse_drag_x, mouse_drag_y = 0.0, 0.0
+mouse_click_x, mouse_click_y = 0.0, 0.0
+
+
+def load_image(url):
+    with urllib.request.urlopen(url) as response:
+        return skia.Image.MakeFromEncoded(skia.Data.MakeWithCopy(response.read()))
+
+
+def make_shader(effect, uniforms, children):
+    if effect is None:
+        raise RuntimeError("Could not make effect")
+    data = skia.Data.MakeWithCopy(struct.pack("%df" % len(uniforms), *uniforms))
+    shader = effect.makeShader(data, children)
+    if shader is None:
+        raise RuntimeError("Could not make shader")
+    return shader
+
+
+img1 = load_image("https://shaders.skia.org/img/soccer.png")
+img_shader1 = img1.makeShader(skia.TileMode.kClamp, skia.TileMode.kClamp)
+
+prog = """
+    // Inputs supplied by shaders.skia.org:
+    uniform float3 iResolution;      // Viewport resolution (pixels)
+    uniform float  iTime;            // Shader playback time (s)
+    uniform float4 iMouse;           // Mouse drag pos=.xy Click pos=.zw (pixels)
+    uniform float3 iImageResolution; // iImage1 resolution (pixels)
+    uniform shader iImage1;          // An input image.
+
+    uniform float  iValue;
+
+    half4 main(in vec2 fragCoord ) {
+        return vec4( result, iValue );
+    }
+"""
+effect = skia.RuntimeEffect.MakeForShader(prog)
+
+uniforms = [
+    shader_width, shader_height, 1,                           # iResolution
+    i_time,                                                   # iTime
+    mouse_drag_x, mouse_drag_y, mouse_click_x, mouse_click_y, # iMouse
+    img1.width(), img1.height(), 1,                           # iImageResolution
+    # User supplied uniform values:
+    0.25,
+]
+children = [
+    img_shader1,  # iImage1
+]
+shader = make_shader(effect, uniforms, children)
+
+surface = skia.Surface(shader_width, shader_height)
+with surface as canvas:
+    paint = skia.Paint()
+    paint.setShader(shader)
+    canvas.drawPaint(paint)
+surface.makeImageSnapshot().save("output.png", skia.kPNG)
+`
+	require.Equal(t, expected, b.String())
+}
+
+func TestTemplateExpand_SkSLWithChildNodesToPython_ChildShadersCreatedFirst(t *testing.T) {
+	tmplMap, err := loadTemplates()
+	require.NoError(t, err)
+	var b bytes.Buffer
+	rootNode := scrapNode{
+		Name: "Root",
+		Scrap: ScrapBody{
+			Type: SKSL,
+			Body: "half4 main(in vec2 fragCoord ) {\n    return childA.eval(fragCoord);\n}",
+			SKSLMetaData: &SKSLMetaData{
+				Children: []ChildShader{{UniformName: "childA", ScrapHashOrName: "unused"}},
+			},
+		},
+		Children: []scrapNode{{
+			Name: "A",
+			Scrap: ScrapBody{
+				Type:         SKSL,
+				Body:         "half4 main(in vec2 fragCoord ) {\n    return half4(1);\n}",
+				SKSLMetaData: &SKSLMetaData{ImageURL: "https://example.com/A.png"},
+			},
+		}},
+	}
+	err = tmplMap[Python][SKSL].Execute(&b, rootNode)
+	require.NoError(t, err)
+	code := b.String()
+	require.Contains(t, code, `img1 = load_image("https://example.com/A.png")`)
+	require.Contains(t, code, `img2 = load_image("https://shaders.skia.org/img/mandrill.png")`)
+	require.Contains(t, code, "    uniform shader childA;\n")
+	require.Contains(t, code, `childrenRoot = [
+    img_shader2,  # iImage1
+    shaderA,
+]
+shaderRoot = make_shader(effectRoot, uniformsRoot, childrenRoot)`)
+	require.Less(t, strings.Index(code, "shaderA = "), strings.Index(code, "shaderRoot = "))
+	require.Contains(t, code, "paint.setShader(shaderRoot)")
+}
+
+func TestTemplateExpand_SVGToPython_BodyIsQuoted(t *testing.T) {
+	tmplMap, err := loadTemplates()
+	require.NoError(t, err)
+	var b bytes.Buffer
+	body := ScrapBody{
+		Type: SVG,
+		Body: "<svg width=\"10\"> \n</svg>",
+	}
+	err = tmplMap[Python][SVG].Execute(&b, scrapNode{Scrap: body})
+	require.NoError(t, err)
+	require.Contains(t, b.String(), `svg = "<svg width=\"10\"> \n</svg>"`)
+	require.Contains(t, b.String(), "svg_dom = skia.SVGDOM.MakeFromStream(stream)")
+}
+
+func TestTemplateExpand_LottieToCPP_Success(t *testing.T) {
+	tmplMap, err := loadTemplates()
+	require.NoError(t, err)
+	var b bytes.Buffer
+	body := ScrapBody{
+		Type: Lottie,
+		Body: `{"v":"5.7.4","nm":"A \"quoted\" name"}`,
+	}
+	err = tmplMap[CPP][Lottie].Execute(&b, scrapNode{Scrap: body})
+	require.NoError(t, err)
+	expected := `void draw(SkCanvas* canvas) {
+    constexpr char json[] = R"lottie({"v":"5.7.4","nm":"A \"quoted\" name"})lottie";
+
+    sk_sp<skottie::Animation> animation =
+            skottie::Animation::Builder().make(json, sizeof(json) - 1);
+    if (!animation) {
+        SkDebugf("Failed to parse Lottie animation.");
+        return;
+    }
+
+    // Play the whole animation over the duration of the fiddle.
+    animation->seek(frame);
+    animation->render(canvas);
+}`
+	require.Equal(t, expected, b.String())
+}
+
+func TestTemplateExpand_LottieToJavaScriptAndPython_BodyIsQuoted(t *testing.T) {
+	tmplMap, err := loadTemplates()
+	require.NoError(t, err)
+	body := ScrapBody{
+		Type: Lottie,
+		Body: "{\"v\":\"5.7.4\",\n\"nm\":\"A \\\"quoted\\\" name\"}",
+	}
+
+	var b bytes.Buffer
+	err = tmplMap[JS][Lottie].Execute(&b, scrapNode{Scrap: body})
+	require.NoError(t, err)
+	require.Contains(t, b.String(), `const animation = CanvasKit.MakeAnimation("{\"v\":\"5.7.4\",\n\"nm\":\"A \\\"quoted\\\" name\"}");`)
+
+	b.Reset()
+	err = tmplMap[Python][Lottie].Execute(&b, scrapNode{Scrap: body})
+	require.NoError(t, err)
+	require.Contains(t, b.String(), `lottie = "{\"v\":\"5.7.4\",\n\"nm\":\"A \\\"quoted\\\" name\"}"`)
+	require.Contains(t, b.String(), "animation = skia.Animation.Make(lottie)")
+}
+
+func TestTemplateExpand_SkSLMeshToCPP_ResponseMatchesExpected(t *testing.T) {
+	tmplMap, err := loadTemplates()
+	require.NoError(t, err)
+	var b bytes.Buffer
+	body := ScrapBody{
+		Type: SKSLMesh,
+		Body: "Varyings main(const Attributes a) {\n    Varyings v;\n    v.position = a.position;\n    return v;\n}",
+		SKSLMeshMetaData: &SKSLMeshMetaData{
+			FragmentBody: "float2 main(const Varyings v, out half4 color) {\n    color = half4(1);\n    return v.position;\n}",
+			Vertices:     []float32{0, 0, 128, 0, 64, 100.5},
+		},
+	}
+	err = tmplMap[CPP][SKSLMesh].Execute(&b, scrapNode{Scrap: body})
+	require.NoError(t, err)
+	expected := `void draw(SkCanvas* canvas) {
+    const SkMeshSpecification::Attribute attributes[] = {
+        {SkMeshSpecification::Attribute::Type::kFloat2, 0, SkString("position")},
+    };
+    const SkMeshSpecification::Varying varyings[] = {
+        {SkMeshSpecification::Varying::Type::kFloat2, SkString("position")},
+    };
+
+    constexpr char vs[] = R"(
+        Varyings main(const Attributes a) {
+            Varyings v;
+            v.position = a.position;
+            return v;
+        }
+    )";
+    constexpr char fs[] = R"(
+        float2 main(const Varyings v, out half4 color) {
+            color = half4(1);
+            return v.position;
+        }
+    )";
+    auto [spec, err] = SkMeshSpecification::Make(attributes, sizeof(SkPoint), varyings,
+                                                 SkString(vs), SkString(fs));
+    if (!spec) {
+        SkDebugf("Cannot create mesh specification: %s", err.c_str());
+        return;
+    }
+
+    const SkPoint vertices[] = {
+        {0.0f, 0.0f}, {128.0f, 0.0f}, {64.0f, 100.5f},
+    };
+    sk_sp<SkMesh::VertexBuffer> vertexBuffer =
+            SkMeshes::MakeVertexBuffer(vertices, sizeof(vertices));
+    auto [mesh, meshErr] = SkMesh::Make(spec, SkMesh::Mode::kTriangles, vertexBuffer,
+                                        std::size(vertices), 0, nullptr, {},
+                                        SkRect::BoundsOrEmpty(vertices));
+    if (!mesh.isValid()) {
+        SkDebugf("Cannot create mesh: %s", meshErr.c_str());
+        return;
+    }
+
+    canvas->clear(SK_ColorBLACK);
+    canvas->drawMesh(mesh, nullptr, SkPaint());
+}`
+	require.Equal(t, expected, b.String())
+}
+
+func TestGetSkSLMeshVerticesCPP_NoVertices_ReturnsCanvasQuad(t *testing.T) {
+	expected := `        {0.0f, 0.0f}, {256.0f, 0.0f}, {0.0f, 256.0f},
+        {0.0f, 256.0f}, {256.0f, 0.0f}, {256.0f, 256.0f},`
+	require.Equal(t, expected, getSkSLMeshVerticesCPP(ScrapBody{Type: SKSLMesh}))
+	require.Equal(t, expected, getSkSLMeshVerticesCPP(ScrapBody{Type: SKSLMesh, SKSLMeshMetaData: &SKSLMeshMetaData{}}))
+}
+
+func TestTemplateHelper_bodyAsStringLiteral_ReturnsExpectedLiteral(t *testing.T) {
+	test := func(name string, expected string, input string) {
+		t.Run(name, func(t *testing.T) {
+			actual, err := bodyAsStringLiteral(input)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		})
+	}
+	test("Empty", `""`, "")
+	test("Multiline", `"<svg>\n</svg>"`, "<svg>\n</svg>")
+	test("QuotesAndBackslashes", `"{\"a\":\"b\\\"c\"}"`, `{"a":"b\"c"}`)
+	test("HTMLIsNotEscaped", `"<a href=\"x&y\">"`, `<a href="x&y">`)
+}
+
+func TestLoadTemplates_OnlyCombinationsWithTemplates_AreLoaded(t *testing.T) {
+	tmplMap, err := loadTemplates()
+	require.NoError(t, err)
+	for _, lang := range AllLangs {
+		for _, typ := range AllTypes {
+			_, hasTemplate := templates[templateName(typ, lang)]
+			_, loaded := tmplMap[lang][typ]
+			assert.Equal(t, hasTemplate, loaded, "%s %s", lang, typ)
+		}
+	}
+	assert.Contains(t, tmplMap[CPP], SKSLMesh)
+	assert.NotContains(t, tmplMap[JS], SKSLMesh)
+	assert.NotContains(t, tmplMap[Python], SKSLMesh)
+}
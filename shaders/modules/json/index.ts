@@ -17,12 +17,22 @@ export interface SKSLMetaData {
 export interface ParticlesMetaData {
 }
 
+export interface LottieMetaData {
+}
+
+export interface SKSLMeshMetaData {
+	FragmentBody: string;
+	Vertices: number[] | null;
+}
+
 export interface ScrapBody {
 	Type: Type;
 	Body: string;
 	SVGMetaData?: SVGMetaData | null;
 	SKSLMetaData?: SKSLMetaData | null;
 	ParticlesMetaData?: ParticlesMetaData | null;
+	LottieMetaData?: LottieMetaData | null;
+	SKSLMeshMetaData?: SKSLMeshMetaData | null;
 }
 
 export interface ScrapID {
@@ -34,6 +44,6 @@ export interface SkShadersConfig {
 	jsfiddle_origin: string;
 }
 
-export type Type = 'svg' | 'sksl' | 'particle';
+export type Type = 'svg' | 'sksl' | 'particle' | 'lottie' | 'skslmesh';
 
 export type SHA256 = string;
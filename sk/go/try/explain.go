@@ -0,0 +1,369 @@
+package try
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.skia.org/infra/go/exec"
+	"go.skia.org/infra/go/git"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/repo_root"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/skcq/go/footers"
+	"go.skia.org/infra/task_scheduler/go/rpc"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultUpstream is the branch the local change is compared to if the
+	// current branch has no upstream.
+	defaultUpstream = "origin/main"
+
+	taskSchedulerURL = "https://task-scheduler.skia.org"
+
+	// durationEstimatePeriod is how far back to look for successful jobs when
+	// estimating job durations.
+	durationEstimatePeriod = 7 * 24 * time.Hour
+)
+
+var (
+	// stdout is an abstraction of os.Stdout which is convenient for testing.
+	stdout io.Writer = os.Stdout
+
+	// durations is an instance of durationEstimator which may be replaced for
+	// testing.
+	durations durationEstimator = &durationEstimatorImpl{}
+)
+
+// cqJob describes whether a job in the commit_queue section of tasks.json would
+// be triggered on the local change.
+type cqJob struct {
+	Name string
+
+	// Experimental jobs are triggered but do not block the change from
+	// landing.
+	Experimental bool
+
+	// CQ is true if the LUCI CQ would trigger the job.
+	CQ bool
+
+	// SkCQ is true if SkCQ would trigger the job.
+	SkCQ bool
+
+	// Reason describes why the job is triggered or skipped.
+	Reason string
+}
+
+// explain reads tasks.json and the local change, and prints the jobs which the
+// CQ and SkCQ would trigger on the change, along with their estimated
+// durations.
+func explain(ctx context.Context) error {
+	repoRoot, err := repo_root.GetLocal()
+	if err != nil {
+		return err
+	}
+	tasksCfg, err := specs.ReadTasksCfg(repoRoot)
+	if err != nil {
+		return err
+	}
+	upstream, files, err := changedFiles(ctx)
+	if err != nil {
+		return err
+	}
+	commitMsg, err := exec.RunCwd(ctx, ".", "git", "log", "-n1", "--format=%B")
+	if err != nil {
+		return err
+	}
+	jobs, err := evaluateCQ(tasksCfg.CommitQueue, files, git.GetFootersMap(commitMsg))
+	if err != nil {
+		return err
+	}
+
+	triggered := []string{}
+	for _, job := range jobs {
+		if job.CQ || job.SkCQ {
+			triggered = append(triggered, job.Name)
+		}
+	}
+	estimates := map[string]time.Duration{}
+	if len(triggered) > 0 {
+		estimates, err = durations.estimateDurations(ctx, triggered)
+		if err != nil {
+			fmt.Fprintf(stdout, "Unable to estimate job durations: %s\n\n", err)
+			estimates = map[string]time.Duration{}
+		}
+	}
+	return printExplanation(stdout, upstream, files, jobs, estimates)
+}
+
+// changedFiles returns the upstream branch of the local change, and the files
+// which the change modifies, including any uncommitted modifications.
+func changedFiles(ctx context.Context) (string, []string, error) {
+	upstream := defaultUpstream
+	if output, err := exec.RunCwd(ctx, ".", "git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}"); err == nil {
+		upstream = strings.TrimSpace(output)
+	}
+	output, err := exec.RunCwd(ctx, ".", "git", "merge-base", "HEAD", upstream)
+	if err != nil {
+		return "", nil, err
+	}
+	base := strings.TrimSpace(output)
+	output, err = exec.RunCwd(ctx, ".", "git", "diff", "--name-only", "--no-renames", base)
+	if err != nil {
+		return "", nil, err
+	}
+	files := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return upstream, files, nil
+}
+
+// matchLocationRegexes returns the first of the location regexes which matches
+// any of the files, or the empty string if none match. If fullMatch is true the
+// regexes must match the entire path of a file, otherwise they may match any
+// part of it.
+func matchLocationRegexes(locationRegexes, files []string, fullMatch bool) (string, error) {
+	for _, locationRegex := range locationRegexes {
+		expr := locationRegex
+		if fullMatch {
+			expr = "^(?:" + locationRegex + ")$"
+		}
+		r, err := regexp.Compile(expr)
+		if err != nil {
+			return "", skerr.Wrapf(err, "%s location regex does not compile", locationRegex)
+		}
+		for _, f := range files {
+			if r.MatchString(f) {
+				return locationRegex, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// evaluateCQ returns which of the jobs in the commit_queue section of tasks.json
+// the CQ and SkCQ would trigger on a change which modifies the given files and
+// has the given footers, sorted by name.
+//
+// Both the CQ and SkCQ only trigger a job with location regexes if they match a
+// modified file, but the CQ config generated from tasks.json requires the regex
+// to match the entire path of the file whereas SkCQ accepts a match of any part
+// of the path.
+func evaluateCQ(commitQueue map[string]*specs.CommitQueueJobConfig, files []string, footersMap map[string]string) ([]*cqJob, error) {
+	jobs := map[string]*specs.CommitQueueJobConfig{}
+	for name, cfg := range commitQueue {
+		jobs[name] = cfg
+	}
+	included := map[string]bool{}
+	if includeTryjobsFooter := git.GetStringFooterVal(footersMap, footers.IncludeTryjobsFooter); includeTryjobsFooter != "" {
+		includeTryJobsMap, err := footers.ParseIncludeTryjobsFooter(includeTryjobsFooter)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		for _, tryJobs := range includeTryJobsMap {
+			for _, name := range tryJobs {
+				if _, ok := jobs[name]; !ok {
+					jobs[name] = &specs.CommitQueueJobConfig{}
+					included[name] = true
+				}
+			}
+		}
+	}
+	noTry := git.GetBoolFooterVal(footersMap, footers.NoTryFooter, 0)
+
+	ret := make([]*cqJob, 0, len(jobs))
+	for name, cfg := range jobs {
+		job := &cqJob{
+			Name:         name,
+			Experimental: cfg.Experimental,
+		}
+		ret = append(ret, job)
+		if noTry {
+			job.Reason = fmt.Sprintf("skipped because \"%s: true\" is specified", footers.NoTryFooter)
+			continue
+		}
+		if included[name] {
+			job.CQ = true
+			job.SkCQ = true
+			job.Reason = fmt.Sprintf("listed in %s", footers.IncludeTryjobsFooter)
+			continue
+		}
+		if len(cfg.LocationRegexes) == 0 {
+			job.CQ = true
+			job.SkCQ = true
+			job.Reason = "runs on every change"
+			continue
+		}
+		cqMatch, err := matchLocationRegexes(cfg.LocationRegexes, files, true)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "invalid location regex for %s", name)
+		}
+		skcqMatch, err := matchLocationRegexes(cfg.LocationRegexes, files, false)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "invalid location regex for %s", name)
+		}
+		job.CQ = cqMatch != ""
+		job.SkCQ = skcqMatch != ""
+		if job.CQ {
+			job.Reason = fmt.Sprintf("matches location regex %q", cqMatch)
+		} else if job.SkCQ {
+			job.Reason = fmt.Sprintf("matches location regex %q, but only as part of a path", skcqMatch)
+		} else {
+			job.Reason = fmt.Sprintf("matches none of the location regexes: %s", strings.Join(cfg.LocationRegexes, ","))
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret, nil
+}
+
+// formatEstimate returns a human-readable estimated duration.
+func formatEstimate(d time.Duration, ok bool) string {
+	if !ok {
+		return "unknown"
+	}
+	return d.Round(time.Second).String()
+}
+
+// jobSummary sums up the estimated durations of the jobs triggered by the CQ or
+// SkCQ.
+type jobSummary struct {
+	count   int
+	total   time.Duration
+	longest time.Duration
+}
+
+// add a job with the given estimated duration to the summary.
+func (s *jobSummary) add(estimate time.Duration) {
+	s.count++
+	s.total += estimate
+	if estimate > s.longest {
+		s.longest = estimate
+	}
+}
+
+// printExplanation writes the evaluated jobs to w.
+func printExplanation(w io.Writer, upstream string, files []string, jobs []*cqJob, estimates map[string]time.Duration) error {
+	fmt.Fprintf(w, "Found %d modified files compared to %s.\n\n", len(files), upstream)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "JOB\tCQ\tSKCQ\tESTIMATE\tREASON")
+	var cq, skcq jobSummary
+	skipped := []*cqJob{}
+	for _, job := range jobs {
+		if !job.CQ && !job.SkCQ {
+			skipped = append(skipped, job)
+			continue
+		}
+		estimate, ok := estimates[job.Name]
+		if job.CQ {
+			cq.add(estimate)
+		}
+		if job.SkCQ {
+			skcq.add(estimate)
+		}
+		reason := job.Reason
+		if job.Experimental {
+			reason += " (experimental, does not block the change)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", job.Name, yesNo(job.CQ), yesNo(job.SkCQ), formatEstimate(estimate, ok), reason)
+	}
+	if err := tw.Flush(); err != nil {
+		return skerr.Wrap(err)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "CQ would trigger %d jobs, estimated at %s in total and %s for the longest job.\n", cq.count, formatEstimate(cq.total, true), formatEstimate(cq.longest, true))
+	fmt.Fprintf(w, "SkCQ would trigger %d jobs, estimated at %s in total and %s for the longest job.\n", skcq.count, formatEstimate(skcq.total, true), formatEstimate(skcq.longest, true))
+	if len(skipped) > 0 {
+		fmt.Fprintf(w, "\nSkipped %d jobs:\n", len(skipped))
+		for _, job := range skipped {
+			fmt.Fprintf(w, "  %s: %s\n", job.Name, job.Reason)
+		}
+	}
+	return nil
+}
+
+// yesNo returns "yes" or "no".
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// durationEstimator provides an abstraction for estimating how long jobs take
+// to facilitate testing.
+type durationEstimator interface {
+	// estimateDurations returns the estimated durations of the given jobs.
+	// Jobs without an estimate are not included in the returned map.
+	estimateDurations(ctx context.Context, jobs []string) (map[string]time.Duration, error)
+}
+
+// durationEstimatorImpl is the default durationEstimator implementation which
+// uses the median duration of the jobs which recently succeeded, according to
+// the task scheduler search API.
+type durationEstimatorImpl struct{}
+
+// estimateDurations implements durationEstimator.
+func (e *durationEstimatorImpl) estimateDurations(ctx context.Context, jobs []string) (map[string]time.Duration, error) {
+	client := rpc.NewTaskSchedulerServiceJSONClient(taskSchedulerURL, httputils.DefaultClientConfig().Client())
+	end := time.Now()
+	start := end.Add(-durationEstimatePeriod)
+	ret := make(map[string]time.Duration, len(jobs))
+	for _, job := range jobs {
+		resp, err := client.SearchJobs(ctx, &rpc.SearchJobsRequest{
+			Name:         job,
+			HasName:      true,
+			Status:       rpc.JobStatus_JOB_STATUS_SUCCESS,
+			HasStatus:    true,
+			TimeStart:    timestamppb.New(start),
+			HasTimeStart: true,
+			TimeEnd:      timestamppb.New(end),
+			HasTimeEnd:   true,
+		})
+		if err != nil {
+			return nil, skerr.Wrapf(err, "failed to search for %s", job)
+		}
+		if d, ok := medianDuration(resp.Jobs); ok {
+			ret[job] = d
+		}
+	}
+	return ret, nil
+}
+
+// medianDuration returns the median duration of the given finished jobs. The
+// returned bool is false if none of the jobs have finished.
+func medianDuration(jobs []*rpc.Job) (time.Duration, bool) {
+	durations := make([]time.Duration, 0, len(jobs))
+	for _, job := range jobs {
+		if job.CreatedAt == nil || job.FinishedAt == nil {
+			continue
+		}
+		if d := job.FinishedAt.AsTime().Sub(job.CreatedAt.AsTime()); d > 0 {
+			durations = append(durations, d)
+		}
+	}
+	if len(durations) == 0 {
+		return 0, false
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	return durations[len(durations)/2], true
+}
+
+// Assert that durationEstimatorImpl implements durationEstimator.
+var _ durationEstimator = (*durationEstimatorImpl)(nil)
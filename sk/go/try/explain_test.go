@@ -0,0 +1,141 @@
+package try
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/exec"
+	"go.skia.org/infra/task_scheduler/go/rpc"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testCommitQueue = map[string]*specs.CommitQueueJobConfig{
+	"Build-Always": {},
+	"Test-GPU": {
+		LocationRegexes: []string{"src/gpu/.*"},
+	},
+	"Test-GPU-Substring": {
+		LocationRegexes: []string{"gpu/"},
+	},
+	"Test-CanvasKit": {
+		LocationRegexes: []string{"modules/canvaskit/.*"},
+		Experimental:    true,
+	},
+}
+
+func TestEvaluateCQ_LocationRegexes_MatchedAsByEachCQ(t *testing.T) {
+	jobs, err := evaluateCQ(testCommitQueue, []string{"src/gpu/GrContext.cpp", "README.md"}, map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, []*cqJob{
+		{Name: "Build-Always", CQ: true, SkCQ: true, Reason: "runs on every change"},
+		{Name: "Test-CanvasKit", Experimental: true, Reason: "matches none of the location regexes: modules/canvaskit/.*"},
+		{Name: "Test-GPU", CQ: true, SkCQ: true, Reason: `matches location regex "src/gpu/.*"`},
+		{Name: "Test-GPU-Substring", SkCQ: true, Reason: `matches location regex "gpu/", but only as part of a path`},
+	}, jobs)
+}
+
+func TestEvaluateCQ_NoTryFooter_AllJobsSkipped(t *testing.T) {
+	jobs, err := evaluateCQ(testCommitQueue, []string{"src/gpu/GrContext.cpp"}, map[string]string{"No-Try": "true"})
+	require.NoError(t, err)
+	require.Len(t, jobs, 4)
+	for _, job := range jobs {
+		require.False(t, job.CQ, job.Name)
+		require.False(t, job.SkCQ, job.Name)
+	}
+}
+
+func TestEvaluateCQ_IncludeTryjobsFooter_JobsAdded(t *testing.T) {
+	jobs, err := evaluateCQ(map[string]*specs.CommitQueueJobConfig{}, []string{"README.md"}, map[string]string{
+		"Cq-Include-Trybots": "skia/skia.primary:Test-Extra,Test-More",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*cqJob{
+		{Name: "Test-Extra", CQ: true, SkCQ: true, Reason: "listed in Cq-Include-Trybots"},
+		{Name: "Test-More", CQ: true, SkCQ: true, Reason: "listed in Cq-Include-Trybots"},
+	}, jobs)
+}
+
+func TestEvaluateCQ_InvalidLocationRegex_ReturnsError(t *testing.T) {
+	_, err := evaluateCQ(map[string]*specs.CommitQueueJobConfig{
+		"Test-Bad": {LocationRegexes: []string{"("}},
+	}, []string{"README.md"}, map[string]string{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "location regex does not compile")
+}
+
+func TestChangedFiles_NoUpstream_ComparesToDefaultUpstream(t *testing.T) {
+	mockCmd := exec.CommandCollector{}
+	mockCmd.SetDelegateRun(func(ctx context.Context, cmd *exec.Command) error {
+		switch cmd.Args[0] {
+		case "rev-parse":
+			return errors.New("fatal: no upstream configured for branch")
+		case "merge-base":
+			require.Equal(t, []string{"merge-base", "HEAD", "origin/main"}, cmd.Args)
+			_, err := cmd.CombinedOutput.Write([]byte("abc123\n"))
+			return err
+		case "diff":
+			require.Equal(t, []string{"diff", "--name-only", "--no-renames", "abc123"}, cmd.Args)
+			_, err := cmd.CombinedOutput.Write([]byte("src/gpu/GrContext.cpp\nREADME.md\n"))
+			return err
+		}
+		return nil
+	})
+	ctx := exec.NewContext(context.Background(), mockCmd.Run)
+
+	upstream, files, err := changedFiles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "origin/main", upstream)
+	require.Equal(t, []string{"src/gpu/GrContext.cpp", "README.md"}, files)
+}
+
+func TestMedianDuration(t *testing.T) {
+	job := func(d time.Duration) *rpc.Job {
+		created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		return &rpc.Job{
+			CreatedAt:  timestamppb.New(created),
+			FinishedAt: timestamppb.New(created.Add(d)),
+		}
+	}
+	d, ok := medianDuration([]*rpc.Job{job(3 * time.Minute), job(time.Minute), job(2 * time.Minute), {}})
+	require.True(t, ok)
+	require.Equal(t, 2*time.Minute, d)
+
+	_, ok = medianDuration([]*rpc.Job{{}})
+	require.False(t, ok)
+}
+
+func TestPrintExplanation(t *testing.T) {
+	jobs, err := evaluateCQ(testCommitQueue, []string{"src/gpu/GrContext.cpp"}, map[string]string{})
+	require.NoError(t, err)
+	var b bytes.Buffer
+	require.NoError(t, printExplanation(&b, "origin/main", []string{"src/gpu/GrContext.cpp"}, jobs, map[string]time.Duration{
+		"Build-Always":       10 * time.Minute,
+		"Test-GPU":           20 * time.Minute,
+		"Test-GPU-Substring": 5 * time.Minute,
+	}))
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	require.Equal(t, []string{
+		"Found 1 modified files compared to origin/main.",
+		"",
+		"JOB                 CQ   SKCQ  ESTIMATE  REASON",
+		"Build-Always        yes  yes   10m0s     runs on every change",
+		`Test-GPU            yes  yes   20m0s     matches location regex "src/gpu/.*"`,
+		`Test-GPU-Substring  no   yes   5m0s      matches location regex "gpu/", but only as part of a path`,
+		"",
+		"CQ would trigger 2 jobs, estimated at 30m0s in total and 20m0s for the longest job.",
+		"SkCQ would trigger 3 jobs, estimated at 35m0s in total and 20m0s for the longest job.",
+		"",
+		"Skipped 1 jobs:",
+		"  Test-CanvasKit: matches none of the location regexes: modules/canvaskit/.*",
+		"",
+	}, lines)
+}
@@ -40,9 +40,10 @@ var (
 func Command() *cli.Command {
 	yFlag := "y"
 	bucketFlag := "bucket"
+	explainFlag := "explain"
 	return &cli.Command{
 		Name:        "try",
-		Usage:       "try [-y] [job name or regex]...",
+		Usage:       "try [-y] [--explain] [job name or regex]...",
 		Description: "Run try jobs against the active CL",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -55,8 +56,19 @@ func Command() *cli.Command {
 				Value: "",
 				Usage: "Override the Buildbucket bucket used to trigger try jobs.",
 			},
+			&cli.BoolFlag{
+				Name:  explainFlag,
+				Value: false,
+				Usage: "Print the jobs which the CQ and SkCQ would trigger on the active CL, with their estimated durations, without triggering anything.",
+			},
 		},
 		Action: func(ctx *cli.Context) error {
+			if ctx.Bool(explainFlag) {
+				if ctx.Args().Len() > 0 {
+					return skerr.Fmt("--%s does not accept job names", explainFlag)
+				}
+				return explain(ctx.Context)
+			}
 			if err := fixupIssue(ctx.Context); err != nil {
 				return err
 			}
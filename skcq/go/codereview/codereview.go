@@ -4,16 +4,30 @@ import (
 	"context"
 
 	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/github"
 )
 
 // Interface to abstract out SkCQ communications with gerrit client and
 // provide utility methods.
 type CodeReview interface {
 
+	// Abandon abandons the specified change with the specified message.
+	Abandon(ctx context.Context, ci *gerrit.ChangeInfo, message string) error
+
 	// AddComments adds a comment to the specified change using the
 	// AutogeneratedCommentTag.
 	AddComment(ctx context.Context, ci *gerrit.ChangeInfo, comment string, notify NotifyOption, notifyReason string) error
 
+	// CherryPick creates a new change on ci's branch that contains the latest
+	// patchset of ci applied on top of the latest patchset of base. If base is
+	// nil then ci is applied on top of the tip of its branch. The returned
+	// ChangeInfo is fully filled in.
+	CherryPick(ctx context.Context, ci, base *gerrit.ChangeInfo, commitMsg string) (*gerrit.ChangeInfo, error)
+
+	// GetBaseCommit returns the hash of the commit that the latest patchset of
+	// the change is based on.
+	GetBaseCommit(ctx context.Context, ci *gerrit.ChangeInfo) (string, error)
+
 	// GetChangeRef returns the change's ref string. A change ref has the format
 	// refs/changes/X/Y/Z where X is the last two digits of the change number,
 	// Y is the entire change number, and Z is the patch set.
@@ -87,6 +101,24 @@ type CodeReview interface {
 	GetCQVoters(ctx context.Context, ci *gerrit.ChangeInfo) []string
 }
 
+// CheckRunsCodeReview is implemented by code review systems whose try jobs
+// are reported as check runs on the change (eg: GitHub) instead of being
+// triggered by SkCQ via Buildbucket.
+type CheckRunsCodeReview interface {
+	// GetCheckRuns returns the check runs of the latest patchset of the
+	// change.
+	GetCheckRuns(ctx context.Context, ci *gerrit.ChangeInfo) ([]*github.Check, error)
+}
+
+// RepoFileReader is implemented by code review systems that can read files
+// from the repo they host (eg: GitHub). Code review systems that do not
+// implement it are read via Gitiles.
+type RepoFileReader interface {
+	// ReadFileAtRef returns the contents of the file at the specified ref. The
+	// returned error contains "NOT_FOUND" if the file does not exist.
+	ReadFileAtRef(ctx context.Context, path, ref string) ([]byte, error)
+}
+
 // NotifyOption are the different notification options supported by SkCQ.
 type NotifyOption string
 
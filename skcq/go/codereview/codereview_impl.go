@@ -42,6 +42,11 @@ func NewGerrit(httpClient *http.Client, cfg *gerrit.Config, gerritURL string) (C
 	}, nil
 }
 
+// Abandon implements the CodeReview interface.
+func (gc *gerritCodeReview) Abandon(ctx context.Context, ci *gerrit.ChangeInfo, message string) error {
+	return gc.gerritClient.Abandon(ctx, ci, message)
+}
+
 // AddComment implements the CodeReview interface.
 func (gc *gerritCodeReview) AddComment(ctx context.Context, ci *gerrit.ChangeInfo, comment string, notify NotifyOption, notifyReason string) error {
 	// Convert SkCQ's NotifyOption to Gerrit's NotifyOption.
@@ -109,6 +114,33 @@ func (gc *gerritCodeReview) AddComment(ctx context.Context, ci *gerrit.ChangeInf
 	return skerr.Wrap(backoff.Retry(addCommentFunc, exp))
 }
 
+// CherryPick implements the CodeReview interface.
+func (gc *gerritCodeReview) CherryPick(ctx context.Context, ci, base *gerrit.ChangeInfo, commitMsg string) (*gerrit.ChangeInfo, error) {
+	baseCommit := ""
+	if base != nil {
+		baseCommit = base.Patchsets[len(base.Patchsets)-1].ID
+	}
+	revision := ci.Patchsets[len(ci.Patchsets)-1].ID
+	newCI, err := gc.gerritClient.CreateCherryPickChangeOnBase(ctx, ci.Id, revision, commitMsg, ci.Branch, baseCommit)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not cherry-pick %d onto %q", ci.Issue, baseCommit)
+	}
+	// The cherry-pick endpoint does not return the revisions of the new change.
+	return gc.gerritClient.GetIssueProperties(ctx, newCI.Issue)
+}
+
+// GetBaseCommit implements the CodeReview interface.
+func (gc *gerritCodeReview) GetBaseCommit(ctx context.Context, ci *gerrit.ChangeInfo) (string, error) {
+	commitInfo, err := gc.gerritClient.GetCommit(ctx, ci.Issue, "current")
+	if err != nil {
+		return "", skerr.Wrapf(err, "Could not get the current commit of %d", ci.Issue)
+	}
+	if len(commitInfo.Parents) == 0 {
+		return "", skerr.Fmt("The current commit of %d has no parents", ci.Issue)
+	}
+	return commitInfo.Parents[0].Commit, nil
+}
+
 // GetChangeRef implements the CodeReview interface.
 func (gc *gerritCodeReview) GetChangeRef(ci *gerrit.ChangeInfo) string {
 	return fmt.Sprintf("%s%02d/%d/%d", gerrit.ChangeRefPrefix, ci.Issue%100, ci.Issue, gc.GetLatestPatchSetID(ci))
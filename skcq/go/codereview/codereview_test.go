@@ -339,3 +339,69 @@ func TestGetCQVoters(t *testing.T) {
 		require.True(t, deepequal.DeepEqual(test.expectedVoters, voters))
 	}
 }
+
+func TestCherryPick_WithBase_PicksLatestPatchsetOntoBase(t *testing.T) {
+	ci := &gerrit.ChangeInfo{
+		Id:        "skia~main~I123",
+		Issue:     123,
+		Branch:    "main",
+		Patchsets: []*gerrit.Revision{{ID: "abc", Number: 1}, {ID: "def", Number: 2}},
+	}
+	base := &gerrit.ChangeInfo{
+		Issue:     456,
+		Patchsets: []*gerrit.Revision{{ID: "789", Number: 1}},
+	}
+	newCI := &gerrit.ChangeInfo{Issue: 1000}
+	fullNewCI := &gerrit.ChangeInfo{Issue: 1000, Patchsets: []*gerrit.Revision{{ID: "fff", Number: 1}}}
+
+	g := &mocks.GerritInterface{}
+	g.On("CreateCherryPickChangeOnBase", testutils.AnyContext, ci.Id, "def", "msg", "main", "789").Return(newCI, nil).Once()
+	g.On("GetIssueProperties", testutils.AnyContext, newCI.Issue).Return(fullNewCI, nil).Once()
+
+	cr := gerritCodeReview{
+		gerritClient: g,
+		cfg:          gerrit.ConfigChromium,
+	}
+	ret, err := cr.CherryPick(context.Background(), ci, base, "msg")
+	require.NoError(t, err)
+	require.Equal(t, fullNewCI, ret)
+}
+
+func TestCherryPick_NoBase_PicksOntoBranchTip(t *testing.T) {
+	ci := &gerrit.ChangeInfo{
+		Id:        "skia~main~I123",
+		Issue:     123,
+		Branch:    "main",
+		Patchsets: []*gerrit.Revision{{ID: "abc", Number: 1}},
+	}
+	newCI := &gerrit.ChangeInfo{Issue: 1000}
+
+	g := &mocks.GerritInterface{}
+	g.On("CreateCherryPickChangeOnBase", testutils.AnyContext, ci.Id, "abc", "msg", "main", "").Return(newCI, nil).Once()
+	g.On("GetIssueProperties", testutils.AnyContext, newCI.Issue).Return(newCI, nil).Once()
+
+	cr := gerritCodeReview{
+		gerritClient: g,
+		cfg:          gerrit.ConfigChromium,
+	}
+	ret, err := cr.CherryPick(context.Background(), ci, nil, "msg")
+	require.NoError(t, err)
+	require.Equal(t, newCI, ret)
+}
+
+func TestGetBaseCommit_ReturnsParentOfCurrentCommit(t *testing.T) {
+	ci := &gerrit.ChangeInfo{Issue: 123}
+	g := &mocks.GerritInterface{}
+	g.On("GetCommit", testutils.AnyContext, ci.Issue, "current").Return(&gerrit.CommitInfo{
+		Commit:  "abc",
+		Parents: []*gerrit.CommitInfo{{Commit: "def"}},
+	}, nil).Once()
+
+	cr := gerritCodeReview{
+		gerritClient: g,
+		cfg:          gerrit.ConfigChromium,
+	}
+	base, err := cr.GetBaseCommit(context.Background(), ci)
+	require.NoError(t, err)
+	require.Equal(t, "def", base)
+}
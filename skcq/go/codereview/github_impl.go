@@ -0,0 +1,471 @@
+package codereview
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	gh "github.com/google/go-github/v29/github"
+
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/github"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+const (
+	// The pull request label that triggers a CQ run. It is the equivalent of
+	// a Commit-Queue+2 vote in Gerrit.
+	GitHubCommitQueueLabel = "commit-queue"
+
+	// The pull request label that triggers a dry run. It is the equivalent of
+	// a Commit-Queue+1 vote in Gerrit.
+	GitHubDryRunLabel = "commit-queue-dry-run"
+
+	// Review states of GitHub pull request reviews.
+	githubReviewApproved         = "APPROVED"
+	githubReviewChangesRequested = "CHANGES_REQUESTED"
+	githubReviewDismissed        = "DISMISSED"
+
+	// Issue event types that are used to find who applied the CQ labels.
+	githubEventLabeled = "labeled"
+)
+
+// githubCodeReview implements the CodeReview interface for GitHub pull
+// requests of a single GitHub repo.
+//
+// Pull requests are translated into gerrit.ChangeInfo objects so that the
+// verifiers and the poller work the same way for both code review systems:
+//   - ChangeInfo.Issue is the pull request number and ChangeInfo.Project is
+//     "<owner>/<repo>".
+//   - Every commit of the pull request is a patchset and none of them are
+//     considered trivial. Patchset IDs are derived from the commit SHAs (see
+//     patchsetIDFromSHA) since force pushes may rewrite the commits.
+//   - The GitHubCommitQueueLabel and GitHubDryRunLabel labels are translated
+//     into Commit-Queue+2 and Commit-Queue+1 votes by whoever applied them.
+//   - Approving reviews are translated into Code-Review+1 votes and reviews
+//     requesting changes are translated into Code-Review-1 votes.
+//   - Users are identified by their public email if they have one, else by
+//     their GitHub login.
+//
+// Try jobs on GitHub are not triggered by SkCQ, they are reported as check
+// runs on the pull request. See CheckRunsCodeReview.
+type githubCodeReview struct {
+	githubClient *github.GitHub
+}
+
+// NewGitHub returns a githubCodeReview instance for the specified GitHub repo.
+func NewGitHub(ctx context.Context, httpClient *http.Client, repoOwner, repoName string) (CodeReview, error) {
+	g, err := github.NewGitHub(ctx, repoOwner, repoName, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return &githubCodeReview{
+		githubClient: g,
+	}, nil
+}
+
+// Abandon implements the CodeReview interface.
+func (gc *githubCodeReview) Abandon(ctx context.Context, ci *gerrit.ChangeInfo, message string) error {
+	if message != "" {
+		if err := gc.githubClient.AddComment(int(ci.Issue), message); err != nil {
+			return skerr.Wrapf(err, "Could not comment on %d", ci.Issue)
+		}
+	}
+	if _, err := gc.githubClient.ClosePullRequest(int(ci.Issue)); err != nil {
+		return skerr.Wrapf(err, "Could not close %d", ci.Issue)
+	}
+	return nil
+}
+
+// AddComment implements the CodeReview interface. GitHub notifies everyone
+// participating in the pull request so the notify options are ignored.
+func (gc *githubCodeReview) AddComment(ctx context.Context, ci *gerrit.ChangeInfo, comment string, notify NotifyOption, notifyReason string) error {
+	return skerr.Wrap(gc.githubClient.AddComment(int(ci.Issue), comment))
+}
+
+// CherryPick implements the CodeReview interface. It is not supported for
+// GitHub pull requests, which means that merge queues are not supported for
+// GitHub repos.
+func (gc *githubCodeReview) CherryPick(ctx context.Context, ci, base *gerrit.ChangeInfo, commitMsg string) (*gerrit.ChangeInfo, error) {
+	return nil, skerr.Fmt("Cherry-picks are not supported for GitHub pull requests")
+}
+
+// GetBaseCommit implements the CodeReview interface.
+func (gc *githubCodeReview) GetBaseCommit(ctx context.Context, ci *gerrit.ChangeInfo) (string, error) {
+	pr, err := gc.githubClient.GetPullRequest(int(ci.Issue))
+	if err != nil {
+		return "", skerr.Wrapf(err, "Could not get pull request %d", ci.Issue)
+	}
+	return pr.GetBase().GetSHA(), nil
+}
+
+// GetChangeRef implements the CodeReview interface.
+func (gc *githubCodeReview) GetChangeRef(ci *gerrit.ChangeInfo) string {
+	return fmt.Sprintf("refs/pull/%d/head", ci.Issue)
+}
+
+// GetCommitAuthor implements the CodeReview interface.
+func (gc *githubCodeReview) GetCommitAuthor(ctx context.Context, issue int64, revision string) (string, error) {
+	commits, err := gc.githubClient.ListCommits(int(issue))
+	if err != nil {
+		return "", skerr.Wrapf(err, "Could not list commits of %d", issue)
+	}
+	for _, c := range commits {
+		if c.GetSHA() == revision {
+			return c.GetCommit().GetAuthor().GetEmail(), nil
+		}
+	}
+	return "", skerr.Fmt("Could not find commit %s in %d", revision, issue)
+}
+
+// GetCommitMessage implements the CodeReview interface. The title and the
+// description of the pull request are used as the commit message since that
+// is what is used when it is squash merged.
+func (gc *githubCodeReview) GetCommitMessage(ctx context.Context, issue int64) (string, error) {
+	pr, err := gc.githubClient.GetPullRequest(int(issue))
+	if err != nil {
+		return "", skerr.Wrapf(err, "Could not get pull request %d", issue)
+	}
+	return fmt.Sprintf("%s\n\n%s", pr.GetTitle(), pr.GetBody()), nil
+}
+
+// GetEarliestEquivalentPatchSetID implements the CodeReview interface. Every
+// commit of a pull request is considered a code change.
+func (gc *githubCodeReview) GetEarliestEquivalentPatchSetID(ci *gerrit.ChangeInfo) int64 {
+	return gc.GetLatestPatchSetID(ci)
+}
+
+// GetEquivalentPatchSetIDs implements the CodeReview interface. Every commit
+// of a pull request is considered a code change.
+func (gc *githubCodeReview) GetEquivalentPatchSetIDs(ci *gerrit.ChangeInfo, patchsetID int64) []int64 {
+	return []int64{patchsetID}
+}
+
+// GetFileNames implements the CodeReview interface.
+func (gc *githubCodeReview) GetFileNames(ctx context.Context, ci *gerrit.ChangeInfo) ([]string, error) {
+	return gc.githubClient.ListFiles(int(ci.Issue))
+}
+
+// GetIssueProperties implements the CodeReview interface.
+func (gc *githubCodeReview) GetIssueProperties(ctx context.Context, issue int64) (*gerrit.ChangeInfo, error) {
+	pr, err := gc.githubClient.GetPullRequest(int(issue))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not get pull request %d", issue)
+	}
+	ci := gc.pullRequestToChangeInfo(pr)
+
+	// Add the patchsets.
+	commits, err := gc.githubClient.ListCommits(int(issue))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not list commits of %d", issue)
+	}
+	ci.Revisions = map[string]*gerrit.Revision{}
+	for _, c := range commits {
+		number, err := patchsetIDFromSHA(c.GetSHA())
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Could not get patchset ID of %d", issue)
+		}
+		rev := &gerrit.Revision{
+			ID:      c.GetSHA(),
+			Number:  number,
+			Created: c.GetCommit().GetCommitter().GetDate(),
+			Kind:    gerrit.PatchSetKindRework,
+			Ref:     gc.GetChangeRef(ci),
+		}
+		ci.Revisions[rev.ID] = rev
+		ci.Patchsets = append(ci.Patchsets, rev)
+	}
+	if len(ci.Patchsets) == 0 {
+		return nil, skerr.Fmt("Pull request %d has no commits", issue)
+	}
+
+	// Add the Code-Review votes. Only the latest approving or change
+	// requesting review of each user counts.
+	reviews, err := gc.githubClient.ListReviews(int(issue))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not list reviews of %d", issue)
+	}
+	reviewVotes := map[string]*gerrit.LabelDetail{}
+	reviewers := []string{}
+	for _, r := range reviews {
+		var value int
+		switch r.GetState() {
+		case githubReviewApproved:
+			value = gerrit.LabelCodeReviewApprove
+		case githubReviewChangesRequested:
+			value = gerrit.LabelCodeReviewDisapprove
+		case githubReviewDismissed:
+			value = gerrit.LabelCodeReviewNone
+		default:
+			continue
+		}
+		user := getGitHubUser(r.GetUser())
+		if _, ok := reviewVotes[user]; !ok {
+			reviewers = append(reviewers, user)
+		}
+		reviewVotes[user] = &gerrit.LabelDetail{
+			Name:      r.GetUser().GetLogin(),
+			Email:     user,
+			Value:     value,
+			AccountID: int(r.GetUser().GetID()),
+		}
+	}
+	codeReviewEntry := &gerrit.LabelEntry{}
+	for _, user := range reviewers {
+		ci.Reviewers.Reviewer = append(ci.Reviewers.Reviewer, &gerrit.Person{Email: user, Name: reviewVotes[user].Name})
+		if reviewVotes[user].Value != gerrit.LabelCodeReviewNone {
+			codeReviewEntry.All = append(codeReviewEntry.All, reviewVotes[user])
+		}
+	}
+	ci.Labels[gerrit.LabelCodeReview] = codeReviewEntry
+
+	// Add the Commit-Queue votes by whoever last applied the CQ labels.
+	cqEntry := &gerrit.LabelEntry{}
+	for label, value := range map[string]int{
+		GitHubCommitQueueLabel: gerrit.LabelCommitQueueSubmit,
+		GitHubDryRunLabel:      gerrit.LabelCommitQueueDryRun,
+	} {
+		if !hasGitHubLabel(pr, label) {
+			continue
+		}
+		cqEntry.All = append(cqEntry.All, &gerrit.LabelDetail{Value: value})
+	}
+	if len(cqEntry.All) > 0 {
+		events, err := gc.githubClient.ListIssueEvents(int(issue))
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Could not list events of %d", issue)
+		}
+		for _, ld := range cqEntry.All {
+			label := GitHubDryRunLabel
+			if ld.Value == gerrit.LabelCommitQueueSubmit {
+				label = GitHubCommitQueueLabel
+			}
+			for _, e := range events {
+				if e.GetEvent() == githubEventLabeled && e.GetLabel().GetName() == label {
+					ld.Name = e.GetActor().GetLogin()
+					ld.Email = getGitHubUser(e.GetActor())
+					ld.AccountID = int(e.GetActor().GetID())
+					ld.Date = e.GetCreatedAt().Format(gerrit.TimeFormat)
+				}
+			}
+		}
+	}
+	ci.Labels[gerrit.LabelCommitQueue] = cqEntry
+
+	return ci, nil
+}
+
+// GetLatestPatchSetID implements the CodeReview interface.
+func (gc *githubCodeReview) GetLatestPatchSetID(ci *gerrit.ChangeInfo) int64 {
+	patchsetIDs := ci.GetPatchsetIDs()
+	return patchsetIDs[len(patchsetIDs)-1]
+}
+
+// GetSubmittedTogether implements the CodeReview interface. Pull requests are
+// always merged on their own.
+func (gc *githubCodeReview) GetSubmittedTogether(ctx context.Context, ci *gerrit.ChangeInfo) ([]*gerrit.ChangeInfo, error) {
+	return nil, nil
+}
+
+// IsCQ implements the CodeReview interface.
+func (gc *githubCodeReview) IsCQ(ctx context.Context, ci *gerrit.ChangeInfo) bool {
+	return !ci.IsClosed() && hasCQVote(ci, gerrit.LabelCommitQueueSubmit)
+}
+
+// IsDryRun implements the CodeReview interface.
+func (gc *githubCodeReview) IsDryRun(ctx context.Context, ci *gerrit.ChangeInfo) bool {
+	return !ci.IsClosed() && hasCQVote(ci, gerrit.LabelCommitQueueDryRun)
+}
+
+// RemoveFromCQ implements the CodeReview interface.
+func (gc *githubCodeReview) RemoveFromCQ(ctx context.Context, ci *gerrit.ChangeInfo, comment string, notifyReason string) {
+	// Remove the CQ labels.
+	for _, label := range []string{GitHubCommitQueueLabel, GitHubDryRunLabel} {
+		if err := gc.githubClient.RemoveLabel(int(ci.Issue), label); err != nil {
+			sklog.Errorf("[%d] Could not remove label %s: %s", ci.Issue, label, err)
+			return
+		}
+	}
+	// Update the pull request with a comment.
+	if err := gc.AddComment(ctx, ci, comment, NotifyOwnerTriggerers, notifyReason); err != nil {
+		sklog.Errorf("[%d] Could not add a comment \"%s\": %s", ci.Issue, comment, err)
+		return
+	}
+}
+
+// Search implements the CodeReview interface.
+func (gc *githubCodeReview) Search(ctx context.Context) ([]*gerrit.ChangeInfo, error) {
+	prs, err := gc.githubClient.ListOpenPullRequests()
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not list open pull requests")
+	}
+	matchingChanges := []*gerrit.ChangeInfo{}
+	for _, pr := range prs {
+		if hasGitHubLabel(pr, GitHubCommitQueueLabel) || hasGitHubLabel(pr, GitHubDryRunLabel) {
+			matchingChanges = append(matchingChanges, gc.pullRequestToChangeInfo(pr))
+		}
+	}
+	return matchingChanges, nil
+}
+
+// SetReadyForReview implements the CodeReview interface. The GitHub REST API
+// does not support converting draft pull requests so this always returns an
+// error.
+func (gc *githubCodeReview) SetReadyForReview(ctx context.Context, ci *gerrit.ChangeInfo) error {
+	return skerr.Fmt("Marking draft pull requests as ready for review is not supported")
+}
+
+// Submit implements the CodeReview interface. Pull requests are squash
+// merged using their title and description as the commit message. The merge
+// fails if commits were pushed to the pull request after it was verified.
+func (gc *githubCodeReview) Submit(ctx context.Context, ci *gerrit.ChangeInfo) error {
+	if len(ci.Patchsets) == 0 {
+		return skerr.Fmt("Pull request %d has no patchsets", ci.Issue)
+	}
+	headSHA := ci.Patchsets[len(ci.Patchsets)-1].ID
+	desc, err := gc.githubClient.GetDescription(int(ci.Issue))
+	if err != nil {
+		return skerr.Wrapf(err, "Could not get the description of %d", ci.Issue)
+	}
+	return skerr.Wrap(gc.githubClient.MergePullRequestAtSHA(int(ci.Issue), desc, github.MERGE_METHOD_SQUASH, headSHA))
+}
+
+// Url implements the CodeReview interface.
+func (gc *githubCodeReview) Url(issueID int64) string {
+	if issueID == 0 {
+		return gc.getRepoUrl()
+	}
+	return fmt.Sprintf("%s%d", gc.githubClient.GetPullRequestUrlBase(), issueID)
+}
+
+// GetRepoUrl implements the CodeReview interface.
+func (gc *githubCodeReview) GetRepoUrl(ci *gerrit.ChangeInfo) string {
+	return gc.getRepoUrl()
+}
+
+// GetCQVoters implements the CodeReview interface.
+func (gc *githubCodeReview) GetCQVoters(ctx context.Context, ci *gerrit.ChangeInfo) []string {
+	// Find which CQ label value we are looking for.
+	labelValue := gerrit.LabelCommitQueueDryRun
+	if gc.IsCQ(ctx, ci) {
+		labelValue = gerrit.LabelCommitQueueSubmit
+	}
+	voters := []string{}
+	if val, ok := ci.Labels[gerrit.LabelCommitQueue]; ok {
+		for _, ld := range val.All {
+			if ld.Value == labelValue && ld.Email != "" {
+				voters = append(voters, ld.Email)
+			}
+		}
+	}
+	return voters
+}
+
+// GetCheckRuns implements the CheckRunsCodeReview interface.
+func (gc *githubCodeReview) GetCheckRuns(ctx context.Context, ci *gerrit.ChangeInfo) ([]*github.Check, error) {
+	headSHA := ci.Patchsets[len(ci.Patchsets)-1].ID
+	checks, err := gc.githubClient.GetChecks(headSHA)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not get checks of %d at %s", ci.Issue, headSHA)
+	}
+	return checks, nil
+}
+
+// ReadFileAtRef implements the RepoFileReader interface.
+func (gc *githubCodeReview) ReadFileAtRef(ctx context.Context, path, ref string) ([]byte, error) {
+	return gc.githubClient.ReadFileAtRef(path, ref)
+}
+
+// getRepoUrl returns the URL of the GitHub repo.
+func (gc *githubCodeReview) getRepoUrl() string {
+	return fmt.Sprintf("https://github.com/%s/%s", gc.githubClient.RepoOwner, gc.githubClient.RepoName)
+}
+
+// pullRequestToChangeInfo returns a gerrit.ChangeInfo populated with the
+// fields that are available directly in the pull request. Patchsets and votes
+// are not populated.
+func (gc *githubCodeReview) pullRequestToChangeInfo(pr *gh.PullRequest) *gerrit.ChangeInfo {
+	status := gerrit.ChangeStatusNew
+	if pr.GetMerged() {
+		status = gerrit.ChangeStatusMerged
+	} else if pr.GetState() == github.CLOSED_STATE {
+		status = gerrit.ChangeStatusAbandoned
+	}
+	mergeableState := pr.GetMergeableState()
+	ci := &gerrit.ChangeInfo{
+		Id:             strconv.FormatInt(pr.GetID(), 10),
+		Issue:          int64(pr.GetNumber()),
+		Project:        fmt.Sprintf("%s/%s", gc.githubClient.RepoOwner, gc.githubClient.RepoName),
+		Branch:         pr.GetBase().GetRef(),
+		Subject:        pr.GetTitle(),
+		Created:        pr.GetCreatedAt(),
+		Updated:        pr.GetUpdatedAt(),
+		Status:         status,
+		WorkInProgress: pr.GetDraft(),
+		Owner: &gerrit.Person{
+			AccountID: int(pr.GetUser().GetID()),
+			Email:     getGitHubUser(pr.GetUser()),
+			Name:      pr.GetUser().GetLogin(),
+		},
+		// Failing or pending checks are verified separately, so only merge
+		// conflicts and unsatisfied branch protections block submission.
+		Submittable: !pr.GetDraft() && (mergeableState == github.MERGEABLE_STATE_CLEAN || mergeableState == github.MERGEABLE_STATE_UNSTABLE),
+		Labels:      map[string]*gerrit.LabelEntry{},
+	}
+	if pr.GetMerged() {
+		ci.Committed = true
+		ci.Submitted = pr.GetMergedAt()
+	}
+	return ci
+}
+
+// getGitHubUser returns the identifier that is used for the specified GitHub
+// user: their public email if they have one, else their login.
+func getGitHubUser(u *gh.User) string {
+	if u.GetEmail() != "" {
+		return u.GetEmail()
+	}
+	return u.GetLogin()
+}
+
+// patchsetIDFromSHA returns the patchset ID of the pull request commit with
+// the specified SHA. It is the number represented by the first 15 hex digits
+// of the SHA, which always fits in a positive int64.
+func patchsetIDFromSHA(sha string) (int64, error) {
+	prefix := sha
+	if len(prefix) > 15 {
+		prefix = prefix[:15]
+	}
+	id, err := strconv.ParseInt(prefix, 16, 64)
+	if err != nil {
+		return 0, skerr.Wrapf(err, "Invalid commit SHA %q", sha)
+	}
+	return id, nil
+}
+
+// hasGitHubLabel returns true if the pull request has the specified label.
+// GitHub label names are case-insensitive.
+func hasGitHubLabel(pr *gh.PullRequest, label string) bool {
+	for _, l := range pr.Labels {
+		if strings.EqualFold(l.GetName(), label) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCQVote returns true if the change has a Commit-Queue vote with the
+// specified value.
+func hasCQVote(ci *gerrit.ChangeInfo, value int) bool {
+	if val, ok := ci.Labels[gerrit.LabelCommitQueue]; ok {
+		for _, ld := range val.All {
+			if ld.Value == value {
+				return true
+			}
+		}
+	}
+	return false
+}
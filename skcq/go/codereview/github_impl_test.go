@@ -0,0 +1,138 @@
+package codereview
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	gh "github.com/google/go-github/v29/github"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/mockhttpclient"
+	"go.skia.org/infra/go/testutils"
+)
+
+const (
+	testGitHubOwner = "kryptonians"
+	testGitHubRepo  = "krypton"
+	testPRNum       = 1234
+)
+
+func setupGitHub(t *testing.T, r *chi.Mux) *githubCodeReview {
+	cr, err := NewGitHub(context.Background(), mockhttpclient.NewMuxClient(r), testGitHubOwner, testGitHubRepo)
+	require.NoError(t, err)
+	return cr.(*githubCodeReview)
+}
+
+func mockGitHubGet(t *testing.T, r *chi.Mux, path string, resp interface{}) {
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Get(path, mockhttpclient.MockGetDialogue([]byte(testutils.MarshalJSON(t, resp))).ServeHTTP)
+}
+
+func TestGitHubGetIssueProperties_TranslatesPullRequest(t *testing.T) {
+	labeledTime := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	r := chi.NewRouter()
+	mockGitHubGet(t, r, "/repos/kryptonians/krypton/pulls/1234", &gh.PullRequest{
+		ID:             gh.Int64(999),
+		Number:         gh.Int(testPRNum),
+		Title:          gh.String("Add kryptonite detector"),
+		State:          gh.String("open"),
+		MergeableState: gh.String("clean"),
+		User:           &gh.User{Login: gh.String("superman"), Email: gh.String("kal-el@krypton.com")},
+		Base:           &gh.PullRequestBranch{Ref: gh.String("main")},
+		Labels:         []*gh.Label{{Name: gh.String(GitHubCommitQueueLabel)}, {Name: gh.String("bug")}},
+	})
+	mockGitHubGet(t, r, "/repos/kryptonians/krypton/pulls/1234/commits", []*gh.RepositoryCommit{
+		{SHA: gh.String("abc")},
+		{SHA: gh.String("def")},
+	})
+	mockGitHubGet(t, r, "/repos/kryptonians/krypton/pulls/1234/reviews", []*gh.PullRequestReview{
+		{State: gh.String(githubReviewChangesRequested), User: &gh.User{Login: gh.String("batman")}},
+		{State: gh.String("COMMENTED"), User: &gh.User{Login: gh.String("robin")}},
+		{State: gh.String(githubReviewApproved), User: &gh.User{Login: gh.String("batman")}},
+	})
+	mockGitHubGet(t, r, "/repos/kryptonians/krypton/issues/1234/events", []*gh.IssueEvent{
+		{Event: gh.String(githubEventLabeled), Label: &gh.Label{Name: gh.String(GitHubCommitQueueLabel)}, Actor: &gh.User{Login: gh.String("wonderwoman")}, CreatedAt: &labeledTime},
+	})
+	cr := setupGitHub(t, r)
+
+	ci, err := cr.GetIssueProperties(context.Background(), testPRNum)
+	require.NoError(t, err)
+	require.Equal(t, int64(testPRNum), ci.Issue)
+	require.Equal(t, "kryptonians/krypton", ci.Project)
+	require.Equal(t, "main", ci.Branch)
+	require.Equal(t, "kal-el@krypton.com", ci.Owner.Email)
+	require.Equal(t, gerrit.ChangeStatusNew, ci.Status)
+	require.True(t, ci.Submittable)
+	require.Equal(t, int64(0xdef), cr.GetLatestPatchSetID(ci))
+	require.Equal(t, "def", ci.Patchsets[1].ID)
+	require.Equal(t, []*gerrit.LabelDetail{
+		{Name: "batman", Email: "batman", Value: gerrit.LabelCodeReviewApprove},
+	}, ci.Labels[gerrit.LabelCodeReview].All)
+	require.True(t, cr.IsCQ(context.Background(), ci))
+	require.False(t, cr.IsDryRun(context.Background(), ci))
+	require.Equal(t, []string{"wonderwoman"}, cr.GetCQVoters(context.Background(), ci))
+}
+
+func TestGitHubSearch_ReturnsOnlyPullRequestsWithCQLabels(t *testing.T) {
+	r := chi.NewRouter()
+	mockGitHubGet(t, r, "/repos/kryptonians/krypton/pulls", []*gh.PullRequest{
+		{Number: gh.Int(1), Labels: []*gh.Label{{Name: gh.String(GitHubDryRunLabel)}}},
+		{Number: gh.Int(2), Labels: []*gh.Label{{Name: gh.String("bug")}}},
+		{Number: gh.Int(3), Labels: []*gh.Label{{Name: gh.String(GitHubCommitQueueLabel)}}},
+	})
+	cr := setupGitHub(t, r)
+
+	cis, err := cr.Search(context.Background())
+	require.NoError(t, err)
+	require.Len(t, cis, 2)
+	require.Equal(t, int64(1), cis[0].Issue)
+	require.Equal(t, int64(3), cis[1].Issue)
+}
+
+func TestGitHubSubmit_SquashMerges(t *testing.T) {
+	r := chi.NewRouter()
+	mockGitHubGet(t, r, "/repos/kryptonians/krypton/issues/1234", &gh.Issue{Body: gh.String("Detects kryptonite.")})
+	md := mockhttpclient.MockPutDialogue("application/json", []byte(`{"commit_message":"Detects kryptonite.","merge_method":"squash","sha":"def"}
+`), []byte(`{"merged":true}`))
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Put("/repos/kryptonians/krypton/pulls/1234/merge", md.ServeHTTP)
+	cr := setupGitHub(t, r)
+
+	ci := &gerrit.ChangeInfo{
+		Issue:     testPRNum,
+		Patchsets: []*gerrit.Revision{{ID: "abc"}, {ID: "def"}},
+	}
+	require.NoError(t, cr.Submit(context.Background(), ci))
+}
+
+func TestPatchsetIDFromSHA_FullSHA_UsesFirst15HexDigits(t *testing.T) {
+	id, err := patchsetIDFromSHA("fedcba9876543210fedcba9876543210fedcba98")
+	require.NoError(t, err)
+	require.Equal(t, int64(0xfedcba987654321), id)
+}
+
+func TestPatchsetIDFromSHA_InvalidSHA_ReturnsError(t *testing.T) {
+	_, err := patchsetIDFromSHA("not-a-sha")
+	require.Error(t, err)
+}
+
+func TestGitHubReadFileAtRef_NotFound_ErrorContainsNotFound(t *testing.T) {
+	r := chi.NewRouter()
+	r.With(
+		mockhttpclient.SchemeMatcher("https"),
+		mockhttpclient.HostMatcher("api.github.com")).
+		Get("/repos/kryptonians/krypton/contents/infra/skcq.json", mockhttpclient.MockGetError("Not Found", http.StatusNotFound).ServeHTTP)
+	cr := setupGitHub(t, r)
+
+	_, err := cr.ReadFileAtRef(context.Background(), "infra/skcq.json", "main")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NOT_FOUND")
+}
@@ -19,6 +19,20 @@ type CodeReview struct {
 	mock.Mock
 }
 
+// Abandon provides a mock function with given fields: ctx, ci, message
+func (_m *CodeReview) Abandon(ctx context.Context, ci *gerrit.ChangeInfo, message string) error {
+	ret := _m.Called(ctx, ci, message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gerrit.ChangeInfo, string) error); ok {
+		r0 = rf(ctx, ci, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddComment provides a mock function with given fields: ctx, ci, comment, notify, notifyReason
 func (_m *CodeReview) AddComment(ctx context.Context, ci *gerrit.ChangeInfo, comment string, notify codereview.NotifyOption, notifyReason string) error {
 	ret := _m.Called(ctx, ci, comment, notify, notifyReason)
@@ -33,6 +47,50 @@ func (_m *CodeReview) AddComment(ctx context.Context, ci *gerrit.ChangeInfo, com
 	return r0
 }
 
+// CherryPick provides a mock function with given fields: ctx, ci, base, commitMsg
+func (_m *CodeReview) CherryPick(ctx context.Context, ci *gerrit.ChangeInfo, base *gerrit.ChangeInfo, commitMsg string) (*gerrit.ChangeInfo, error) {
+	ret := _m.Called(ctx, ci, base, commitMsg)
+
+	var r0 *gerrit.ChangeInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *gerrit.ChangeInfo, *gerrit.ChangeInfo, string) *gerrit.ChangeInfo); ok {
+		r0 = rf(ctx, ci, base, commitMsg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gerrit.ChangeInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gerrit.ChangeInfo, *gerrit.ChangeInfo, string) error); ok {
+		r1 = rf(ctx, ci, base, commitMsg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBaseCommit provides a mock function with given fields: ctx, ci
+func (_m *CodeReview) GetBaseCommit(ctx context.Context, ci *gerrit.ChangeInfo) (string, error) {
+	ret := _m.Called(ctx, ci)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *gerrit.ChangeInfo) string); ok {
+		r0 = rf(ctx, ci)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gerrit.ChangeInfo) error); ok {
+		r1 = rf(ctx, ci)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCQVoters provides a mock function with given fields: ctx, ci
 func (_m *CodeReview) GetCQVoters(ctx context.Context, ci *gerrit.ChangeInfo) []string {
 	ret := _m.Called(ctx, ci)
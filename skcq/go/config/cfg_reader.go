@@ -64,20 +64,35 @@ type ConfigReader interface {
 	// GetAuthorsFileContents reads the AUTHORS file from CL's ref if it was modified,
 	// else it reads it from HEAD.
 	GetAuthorsFileContents(ctx context.Context, authorsPath string) (string, error)
+
+	// GetOwnersFileContents reads the specified OWNERS file from the commit
+	// that the CL is based on. The version from the CL is never used, so that
+	// a CL cannot grant approval rights to its own owner. Returns a
+	// ConfigNotFoundError if the file does not exist.
+	GetOwnersFileContents(ctx context.Context, ownersPath string) (string, error)
 }
 
 // GitilesConfigReader is an implementation of ConfigReader interface.
 type GitilesConfigReader struct {
-	gitilesRepo           gitiles.GitilesRepo
+	gitilesRepo           codereview.RepoFileReader
 	ci                    *gerrit.ChangeInfo
 	cr                    codereview.CodeReview
 	changedFiles          []string
 	canModifyCfgsOnTheFly allowed.Allow
+	// The commit the CL is based on. Populated on first use.
+	baseCommit string
 }
 
 // NewGitilesConfigReader returns an instance of GitilesConfigReader.
 func NewGitilesConfigReader(ctx context.Context, httpClient *http.Client, ci *gerrit.ChangeInfo, cr codereview.CodeReview, canModifyCfgsOnTheFly allowed.Allow) (*GitilesConfigReader, error) {
-	gitilesRepo := gitiles.NewRepo(cr.GetRepoUrl(ci), httpClient)
+	// Code review systems that host their own repos (eg: GitHub) are read
+	// from directly. All others are read via Gitiles.
+	var gitilesRepo codereview.RepoFileReader
+	if fr, ok := cr.(codereview.RepoFileReader); ok {
+		gitilesRepo = fr
+	} else {
+		gitilesRepo = gitiles.NewRepo(cr.GetRepoUrl(ci), httpClient)
+	}
 	changedFiles, err := cr.GetFileNames(ctx, ci)
 	if err != nil {
 		return nil, skerr.Fmt("Not able to get changed files for %d: %s", ci.Issue, err)
@@ -111,6 +126,12 @@ func (gc *GitilesConfigReader) GetSkCQCfg(ctx context.Context) (*SkCQCfg, error)
 	if err := cfg.Validate(); err != nil {
 		return nil, skerr.Wrapf(err, "Error validating SkCQ cfg")
 	}
+	// Merge queues cherry-pick changes onto each other and run try jobs on
+	// the result. Code review systems whose try jobs are reported as check
+	// runs (eg: GitHub) support neither.
+	if _, ok := gc.cr.(codereview.CheckRunsCodeReview); ok && cfg.MergeQueueCfg != nil {
+		return nil, skerr.Fmt("Error validating SkCQ cfg: MergeQueueCfg is not supported for %s", gc.cr.GetRepoUrl(gc.ci))
+	}
 	return cfg, nil
 }
 
@@ -138,6 +159,29 @@ func (gc *GitilesConfigReader) GetAuthorsFileContents(ctx context.Context, autho
 	return contents, nil
 }
 
+// GetOwnersFileContents implements the ConfigReader interface.
+func (gc *GitilesConfigReader) GetOwnersFileContents(ctx context.Context, ownersPath string) (string, error) {
+	if gc.baseCommit == "" {
+		baseCommit, err := gc.cr.GetBaseCommit(ctx, gc.ci)
+		if err != nil {
+			return "", skerr.Wrapf(err, "Could not get the base commit of %d", gc.ci.Issue)
+		}
+		gc.baseCommit = baseCommit
+	}
+	contents, err := gc.gitilesRepo.ReadFileAtRef(ctx, ownersPath, gc.baseCommit)
+	if err != nil {
+		if strings.Contains(err.Error(), "NOT_FOUND") {
+			return "", &ConfigNotFoundError{
+				configPath: ownersPath,
+				repo:       gc.ci.Project,
+				branch:     gc.ci.Branch,
+			}
+		}
+		return "", skerr.Fmt("Failed to read %s at %s: %s", ownersPath, gc.baseCommit, err)
+	}
+	return string(contents), nil
+}
+
 // getFileContents checks to see if the CL has modified the file and returns those contents.
 // If the file has not been modified then it returns the file contents from HEAD.
 func (gc *GitilesConfigReader) getFileContents(ctx context.Context, cfgPath string) (string, bool, error) {
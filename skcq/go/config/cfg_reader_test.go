@@ -136,6 +136,34 @@ func TestGetSkCQCfg_ValidationFailed(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+// checkRunsCodeReview is a CodeReview whose try jobs are reported as check
+// runs, like GitHub's.
+type checkRunsCodeReview struct {
+	*cr_mocks.CodeReview
+	*cr_mocks.CheckRunsCodeReview
+}
+
+func TestGetSkCQCfg_MergeQueueWithCheckRunsCodeReview_ReturnsError(t *testing.T) {
+	skCfg := &SkCQCfg{
+		VisibilityType:   PublicVisibility,
+		CommitterList:    "test-list",
+		DryRunAccessList: "test-list",
+		TasksJSONPath:    "infra/bots/tasks.json",
+		MergeQueueCfg:    &MergeQueueCfg{},
+	}
+	skCfgContents, err := json.Marshal(skCfg)
+	require.NoError(t, err)
+
+	configReader := setupGetSkCQCfg(t, true, skCfgContents, nil, []string{"dir1/*"}, SkCQCfgPath, false)
+	cr := &cr_mocks.CodeReview{}
+	cr.On("GetRepoUrl", configReader.ci).Return("https://github.com/kryptonians/krypton")
+	configReader.cr = checkRunsCodeReview{CodeReview: cr}
+	cfg, err := configReader.GetSkCQCfg(context.Background())
+	require.Nil(t, cfg)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "MergeQueueCfg is not supported for https://github.com/kryptonians/krypton")
+}
+
 func TestGetTasksCfg_UpdatedInChange(t *testing.T) {
 
 	tasksJSONPath := "test/path/tasks.json"
@@ -161,3 +189,34 @@ func TestGetTasksCfg_NotUpdatedInChange(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, cfg)
 }
+
+func TestGetOwnersFileContents_ModifiedInChange_ReadsFromBaseCommit(t *testing.T) {
+	ownersPath := "dir1/OWNERS"
+	ci := &gerrit.ChangeInfo{
+		Issue:   int64(123),
+		Project: "test-repo",
+		Branch:  "test-branch",
+	}
+	cr := &cr_mocks.CodeReview{}
+	cr.On("GetBaseCommit", testutils.AnyContext, ci).Return("abc123", nil).Once()
+	gitilesRepo := &gitiles_mocks.GitilesRepo{}
+	gitilesRepo.On("ReadFileAtRef", testutils.AnyContext, ownersPath, "abc123").Return([]byte("batman@gotham.com"), nil).Once()
+	gitilesRepo.On("ReadFileAtRef", testutils.AnyContext, "OWNERS", "abc123").Return(nil, errors.New("NOT_FOUND")).Once()
+
+	configReader := &GitilesConfigReader{
+		gitilesRepo: gitilesRepo,
+		cr:          cr,
+		ci:          ci,
+		// The change modifies the OWNERS file but its version is not used.
+		changedFiles: []string{ownersPath},
+	}
+	contents, err := configReader.GetOwnersFileContents(context.Background(), ownersPath)
+	require.NoError(t, err)
+	require.Equal(t, "batman@gotham.com", contents)
+
+	// The base commit is only looked up once.
+	_, err = configReader.GetOwnersFileContents(context.Background(), "OWNERS")
+	require.True(t, IsNotFound(err))
+	cr.AssertExpectations(t)
+	gitilesRepo.AssertExpectations(t)
+}
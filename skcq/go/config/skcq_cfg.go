@@ -45,6 +45,32 @@ type SkCQCfg struct {
 	// run the authors_verifier on the change to validate that the author
 	// of the change is specified in the AUTHORS file.
 	AuthorsPath string `json:"authors_path,omitempty"`
+
+	// Names of the check runs that must succeed on GitHub pull requests. If
+	// not specified then all check runs reported on the pull request must
+	// succeed, and pull requests fail if no check runs are reported. Not used
+	// for Gerrit changes.
+	RequiredCheckRuns []string `json:"required_check_runs,omitempty"`
+
+	// If true then SkCQ will run the owners_verifier on CQ runs to validate
+	// that every file modified by the change has been approved by one of its
+	// owners, as specified by the OWNERS files of the repo.
+	EnforceOwners bool `json:"enforce_owners,omitempty"`
+
+	// The merge queue config of this repo+branch. If this is specified then
+	// CQ runs are not tested and submitted independently. Instead they are
+	// stacked into batches and the CQ try jobs are run on the combined state
+	// of each batch. See verifiers.MergeQueueVerifier for details. Not
+	// supported for GitHub repos.
+	MergeQueueCfg *MergeQueueCfg `json:"merge_queue_cfg,omitempty"`
+}
+
+// MergeQueueCfg is a struct which describes how changes to this repo+branch
+// are batched before being tested and submitted.
+type MergeQueueCfg struct {
+	// The maximum number of changes that will be tested together. Default
+	// used is verifiers.MergeQueueMaxBatchSizeDefault.
+	MaxBatchSize int `json:"max_batch_size"`
 }
 
 // ThrottlerCfg is a struct which describes how the rate of submissions to
@@ -69,6 +95,14 @@ func (c *SkCQCfg) Validate() error {
 	if c.VisibilityType == "" {
 		return skerr.Fmt("Must specify a VisiblityType")
 	}
+	if c.MergeQueueCfg != nil {
+		if c.TasksJSONPath == "" {
+			return skerr.Fmt("Must specify a TasksJSONPath when using a MergeQueueCfg")
+		}
+		if c.MergeQueueCfg.MaxBatchSize < 0 {
+			return skerr.Fmt("MergeQueueCfg.MaxBatchSize cannot be negative")
+		}
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"sync"
 	"time"
 
@@ -26,6 +27,7 @@ const (
 	// Names of Collections and Documents.
 	snapshotsCol              = "Snapshots"
 	currentChangesSnapshotDoc = "CurrentChangesSnapshot"
+	mergeQueuesCol            = "MergeQueues"
 
 	publicChangesCol   ChangesCol = "PublicChanges"
 	internalChangesCol ChangesCol = "InternalChanges"
@@ -50,6 +52,13 @@ type DB interface {
 
 	// PutChangeAttempts adds the specified change attempt to the DB.
 	PutChangeAttempt(ctx context.Context, newChangeAttempt *types.ChangeAttempt, changesCol ChangesCol) error
+
+	// GetMergeQueue returns the merge queue of the specified repo+branch. If
+	// none is found in DB then nil is returned.
+	GetMergeQueue(ctx context.Context, repo, branch string) (*types.MergeQueue, error)
+
+	// PutMergeQueue persists the specified merge queue.
+	PutMergeQueue(ctx context.Context, mq *types.MergeQueue) error
 }
 
 // FirestoreDB uses Cloud Firestore for storage and implements the DB
@@ -235,6 +244,37 @@ func (f *FirestoreDB) PutChangeAttempt(ctx context.Context, newChangeAttempt *ty
 	return nil
 }
 
+// GetMergeQueue implements the DB interface.
+func (f *FirestoreDB) GetMergeQueue(ctx context.Context, repo, branch string) (*types.MergeQueue, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	snapshot, err := f.client.Collection(mergeQueuesCol).Doc(getMergeQueueDocName(repo, branch)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	mq := types.MergeQueue{}
+	if err := snapshot.DataTo(&mq); err != nil {
+		return nil, err
+	}
+	return &mq, nil
+}
+
+// PutMergeQueue implements the DB interface.
+func (f *FirestoreDB) PutMergeQueue(ctx context.Context, mq *types.MergeQueue) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	col := f.client.Collection(mergeQueuesCol)
+	if _, err := f.client.Set(ctx, col.Doc(getMergeQueueDocName(mq.Repo, mq.Branch)), mq, defaultAttempts, putSingleTimeout); err != nil {
+		return skerr.Fmt("Could not set MergeQueue of %s/%s: %s", mq.Repo, mq.Branch, err)
+	}
+	return nil
+}
+
 // Utility function to return which changes col name to use based on if the
 // change is internal or not.
 func GetChangesCol(internal bool) ChangesCol {
@@ -249,3 +289,9 @@ func GetChangesCol(internal bool) ChangesCol {
 func getChangeAttemptsDocName(changeID, patchsetID int64) string {
 	return fmt.Sprintf("%d_%d", changeID, patchsetID)
 }
+
+// Utility function to return the name of the MergeQueue document. Repo names
+// may contain slashes, which are not allowed in document names.
+func getMergeQueueDocName(repo, branch string) string {
+	return fmt.Sprintf("%s_%s", url.PathEscape(repo), url.PathEscape(branch))
+}
@@ -64,6 +64,29 @@ func (_m *DB) GetCurrentChanges(ctx context.Context) (map[string]*types.Currentl
 	return r0, r1
 }
 
+// GetMergeQueue provides a mock function with given fields: ctx, repo, branch
+func (_m *DB) GetMergeQueue(ctx context.Context, repo string, branch string) (*types.MergeQueue, error) {
+	ret := _m.Called(ctx, repo, branch)
+
+	var r0 *types.MergeQueue
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *types.MergeQueue); ok {
+		r0 = rf(ctx, repo, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.MergeQueue)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, repo, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PutChangeAttempt provides a mock function with given fields: ctx, newChangeAttempt, changesCol
 func (_m *DB) PutChangeAttempt(ctx context.Context, newChangeAttempt *types.ChangeAttempt, changesCol db.ChangesCol) error {
 	ret := _m.Called(ctx, newChangeAttempt, changesCol)
@@ -92,6 +115,20 @@ func (_m *DB) PutCurrentChanges(ctx context.Context, currentChangesCache interfa
 	return r0
 }
 
+// PutMergeQueue provides a mock function with given fields: ctx, mq
+func (_m *DB) PutMergeQueue(ctx context.Context, mq *types.MergeQueue) error {
+	ret := _m.Called(ctx, mq)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.MergeQueue) error); ok {
+		r0 = rf(ctx, mq)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateChangeAttemptAsAbandoned provides a mock function with given fields: ctx, changeID, patchsetID, changesCol, patchStart
 func (_m *DB) UpdateChangeAttemptAsAbandoned(ctx context.Context, changeID int64, patchsetID int64, changesCol db.ChangesCol, patchStart int64) error {
 	ret := _m.Called(ctx, changeID, patchsetID, changesCol, patchStart)
@@ -9,6 +9,10 @@ import (
 	"flag"
 	"net/http"
 	"net/http/pprof"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/datastore"
@@ -21,7 +25,9 @@ import (
 	"go.skia.org/infra/go/baseapp"
 	"go.skia.org/infra/go/common"
 	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/github"
 	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/skcq/go/caches"
 	"go.skia.org/infra/skcq/go/codereview"
@@ -41,6 +47,8 @@ var (
 	publicFEInstanceURL = flag.String("public_fe_url", "localhost", "The public FE instance URL.")
 	corpFEInstanceURL   = flag.String("corp_fe_url", "localhost", "The corp FE instance URL.")
 
+	githubRepo = flag.String("github_repo", "", "If specified then SkCQ processes the pull requests of this GitHub repo (eg: google/skia-buildbot) instead of Gerrit changes.")
+
 	reposAllowList = common.NewMultiStringFlag("allowed_repo", nil, "Which repos should be processed by SkCQ. If not specified then all repos will be processed.")
 	reposBlockList = common.NewMultiStringFlag("blocked_repo", nil, "Which repos should not be processed by SkCQ. If not specified then no repos will be skipped.")
 
@@ -71,6 +79,32 @@ func maybeStartDebugServer() {
 	}
 }
 
+// newGitHubCodeReview returns a CodeReview for the specified GitHub repo of
+// the form "owner/name".
+func newGitHubCodeReview(ctx context.Context, repo string) (codereview.CodeReview, error) {
+	repoParts := strings.Split(repo, "/")
+	if len(repoParts) != 2 {
+		return nil, skerr.Fmt("Expected GitHub repo of the form owner/name. Got %q", repo)
+	}
+	pathToGithubToken := filepath.Join(github.GITHUB_TOKEN_SERVER_PATH, github.GITHUB_TOKEN_FILENAME)
+	if *baseapp.Local {
+		usr, err := user.Current()
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		pathToGithubToken = filepath.Join(usr.HomeDir, github.GITHUB_TOKEN_FILENAME)
+	}
+	gBody, err := os.ReadFile(pathToGithubToken)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not find githubToken in %s", pathToGithubToken)
+	}
+	gToken := strings.TrimSpace(string(gBody))
+	githubTS := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: gToken})
+	// Not using With2xxOnly because 404s are expected when reading files.
+	githubHttpClient := httputils.DefaultClientConfig().WithTokenSource(githubTS).Client()
+	return codereview.NewGitHub(ctx, githubHttpClient, repoParts[0], repoParts[1])
+}
+
 func main() {
 	common.InitWithMust(
 		"skcq-be",
@@ -97,9 +131,17 @@ func main() {
 	httpClient := httputils.DefaultClientConfig().WithTokenSource(ts).With2xxOnly().Client()
 
 	// Instantiate codereview.
-	g, err := codereview.NewGerrit(httpClient, gerrit.ConfigChromium, gerrit.GerritSkiaURL)
-	if err != nil {
-		sklog.Fatalf("Could not init gerrit client: %s", err)
+	var g codereview.CodeReview
+	if *githubRepo != "" {
+		g, err = newGitHubCodeReview(ctx, *githubRepo)
+		if err != nil {
+			sklog.Fatalf("Could not init github client: %s", err)
+		}
+	} else {
+		g, err = codereview.NewGerrit(httpClient, gerrit.ConfigChromium, gerrit.GerritSkiaURL)
+		if err != nil {
+			sklog.Fatalf("Could not init gerrit client: %s", err)
+		}
 	}
 
 	// Instantiate the cache.
@@ -170,3 +170,61 @@ type VerifierStatus struct {
 	// An explanation of why the verifier is in this state.
 	Reason string `json:"reason"`
 }
+
+// MergeQueue is the state of the merge queue of a repo+branch. Changes are
+// tested in batches: the changes of a batch are cherry-picked on top of each
+// other into speculative changes and the CQ try jobs are run on the last one,
+// which contains the combined state of the batch.
+type MergeQueue struct {
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"`
+
+	// The changes in the queue in the order they were enqueued.
+	Changes []*MergeQueueChange `json:"changes"`
+
+	// The speculative changes that were created for the batch that is
+	// currently being tested, in the order they were stacked. Empty if no
+	// batch is being tested.
+	SpeculativeChanges []int64 `json:"speculative_changes"`
+	// The files modified by the changes of the batch that is currently being
+	// tested.
+	BatchFiles []string `json:"batch_files"`
+	// When the batch that is currently being tested was created.
+	// Uses unix epoch time.
+	BatchStartTs int64 `json:"batch_start_ts"`
+
+	// The sizes of the next batches to test. This is populated when a failed
+	// batch is bisected. If empty then the max batch size is used.
+	BisectSizes []int `json:"bisect_sizes"`
+
+	// The last time the queue was advanced. Uses unix epoch time.
+	LastAdvancedTs int64 `json:"last_advanced_ts"`
+}
+
+// MergeQueueChange is a change in a MergeQueue.
+type MergeQueueChange struct {
+	ChangeID int64 `json:"change_id"`
+	// The earliest equivalent patchset of the change when it was enqueued.
+	PatchsetID int64 `json:"patchset_id"`
+	// When the change was enqueued. Uses unix epoch time.
+	EnqueuedTs int64           `json:"enqueued_ts"`
+	State      MergeQueueState `json:"state"`
+	// An explanation of why the change is in this state.
+	Reason string `json:"reason"`
+}
+
+// MergeQueueState describes the state of a change in a MergeQueue.
+type MergeQueueState string
+
+// The change is waiting to be added to a batch.
+const MergeQueueQueuedState MergeQueueState = "QUEUED"
+
+// The change is in the batch that is currently being tested.
+const MergeQueueTestingState MergeQueueState = "TESTING"
+
+// The change was in a batch that passed and is ready to be submitted.
+const MergeQueuePassedState MergeQueueState = "PASSED"
+
+// The change failed when tested on its own, or could not be applied on top of
+// its branch.
+const MergeQueueFailedState MergeQueueState = "FAILED"
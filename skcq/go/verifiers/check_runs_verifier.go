@@ -0,0 +1,119 @@
+package verifiers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/github"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/skcq/go/codereview"
+	"go.skia.org/infra/skcq/go/types"
+)
+
+const (
+	// How long to wait for check runs to be reported on a change that has
+	// none before failing.
+	CheckRunsReportTimeoutSecs = 5 * 60
+
+	// Conclusion of check runs that were skipped. Not defined in go/github.
+	checkStateSkipped = "skipped"
+)
+
+// NewCheckRunsVerifier returns an instance of CheckRunsVerifier.
+func NewCheckRunsVerifier(crc codereview.CheckRunsCodeReview, requiredCheckRuns []string) (types.Verifier, error) {
+	return &CheckRunsVerifier{
+		crc:               crc,
+		requiredCheckRuns: requiredCheckRuns,
+	}, nil
+}
+
+// CheckRunsVerifier implements the types.Verifier interface. It is used
+// instead of the TryJobsVerifier for code review systems whose try jobs are
+// reported as check runs on the change (eg: GitHub). SkCQ does not trigger
+// these, it only waits for them to succeed.
+type CheckRunsVerifier struct {
+	crc codereview.CheckRunsCodeReview
+	// If empty then all check runs reported on the change must succeed.
+	requiredCheckRuns []string
+}
+
+// Name implements the types.Verifier interface.
+func (cv *CheckRunsVerifier) Name() string {
+	return "CheckRunsVerifier"
+}
+
+// Verify implements the types.Verifier interface.
+func (cv *CheckRunsVerifier) Verify(ctx context.Context, ci *gerrit.ChangeInfo, startTime int64) (state types.VerifierState, reason string, err error) {
+	checks, err := cv.crc.GetCheckRuns(ctx, ci)
+	if err != nil {
+		return "", "", skerr.Wrapf(err, "Could not get check runs of %d", ci.Issue)
+	}
+	// Only the latest check run with each name is considered. This ignores
+	// the results of older attempts.
+	nameToCheck := map[string]*github.Check{}
+	for _, c := range checks {
+		if prev, ok := nameToCheck[c.Name]; !ok || isNewerCheck(c, prev) {
+			nameToCheck[c.Name] = c
+		}
+	}
+
+	requiredCheckRuns := cv.requiredCheckRuns
+	if len(requiredCheckRuns) == 0 {
+		if len(nameToCheck) == 0 {
+			if timeNowFunc().Unix()-startTime < CheckRunsReportTimeoutSecs {
+				return types.VerifierWaitingState, "Waiting for check runs to be reported", nil
+			}
+			// Do not let changes through without any verification. Repos
+			// whose check runs are slow to be reported should specify
+			// required_check_runs.
+			return types.VerifierFailureState, fmt.Sprintf("No check runs were reported within %d minutes", CheckRunsReportTimeoutSecs/60), nil
+		}
+		for name := range nameToCheck {
+			requiredCheckRuns = append(requiredCheckRuns, name)
+		}
+		sort.Strings(requiredCheckRuns)
+	}
+
+	failedChecks := []string{}
+	waitingChecks := []string{}
+	for _, name := range requiredCheckRuns {
+		c, ok := nameToCheck[name]
+		if !ok {
+			waitingChecks = append(waitingChecks, name)
+			continue
+		}
+		switch c.State {
+		case github.CHECK_STATE_SUCCESS, github.CHECK_STATE_NEUTRAL, checkStateSkipped:
+		case "", github.CHECK_STATE_PENDING:
+			waitingChecks = append(waitingChecks, name)
+		default:
+			failedChecks = append(failedChecks, fmt.Sprintf("%s (%s): %s", name, c.State, c.HTMLURL))
+		}
+	}
+
+	if len(failedChecks) > 0 {
+		return types.VerifierFailureState, fmt.Sprintf("These check runs failed:\n%s", strings.Join(failedChecks, "\n")), nil
+	}
+	if len(waitingChecks) > 0 {
+		return types.VerifierWaitingState, fmt.Sprintf("Waiting for these check runs to complete: %s", strings.Join(waitingChecks, ", ")), nil
+	}
+	return types.VerifierSuccessState, fmt.Sprintf("All %d check runs succeeded", len(requiredCheckRuns)), nil
+}
+
+// isNewerCheck returns true if check run a was started after check run b.
+// Check runs that were started at the same time are ordered by ID.
+func isNewerCheck(a, b *github.Check) bool {
+	if !a.StartedAt.Equal(b.StartedAt) {
+		return a.StartedAt.After(b.StartedAt)
+	}
+	return a.ID > b.ID
+}
+
+// Cleanup implements the types.Verifier interface. Check runs are not owned
+// by SkCQ so they are not cancelled.
+func (cv *CheckRunsVerifier) Cleanup(ctx context.Context, ci *gerrit.ChangeInfo, cleanupPatchsetID int64) {
+	return
+}
@@ -0,0 +1,85 @@
+package verifiers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/github"
+	"go.skia.org/infra/go/testutils"
+	cr_mocks "go.skia.org/infra/skcq/go/codereview/mocks"
+	"go.skia.org/infra/skcq/go/types"
+)
+
+func setupCheckRunsVerifier(t *testing.T, ci *gerrit.ChangeInfo, checks []*github.Check, requiredCheckRuns []string) types.Verifier {
+	timeNowFunc = func() time.Time {
+		return currentTime
+	}
+	crc := cr_mocks.NewCheckRunsCodeReview(t)
+	crc.On("GetCheckRuns", testutils.AnyContext, ci).Return(checks, nil).Once()
+
+	cv, err := NewCheckRunsVerifier(crc, requiredCheckRuns)
+	require.NoError(t, err)
+	return cv
+}
+
+func TestVerify_CheckRunsVerifier_AllSucceeded_Success(t *testing.T) {
+	ci := &gerrit.ChangeInfo{Issue: int64(123)}
+	cv := setupCheckRunsVerifier(t, ci, []*github.Check{
+		// An older failed attempt of "build" is ignored, regardless of the
+		// order in which the check runs are listed.
+		{ID: 1, Name: "build", State: github.CHECK_STATE_FAILURE, StartedAt: currentTime.Add(-time.Hour)},
+		{ID: 2, Name: "build", State: github.CHECK_STATE_SUCCESS, StartedAt: currentTime},
+		{ID: 3, Name: "lint", State: github.CHECK_STATE_NEUTRAL, StartedAt: currentTime},
+		{ID: 4, Name: "lint", State: github.CHECK_STATE_FAILURE, StartedAt: currentTime.Add(-time.Hour)},
+	}, nil)
+
+	state, reason, err := cv.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierSuccessState, state)
+	require.Equal(t, "All 2 check runs succeeded", reason)
+}
+
+func TestVerify_CheckRunsVerifier_OneFailed_Failure(t *testing.T) {
+	ci := &gerrit.ChangeInfo{Issue: int64(123)}
+	cv := setupCheckRunsVerifier(t, ci, []*github.Check{
+		{Name: "build", State: github.CHECK_STATE_SUCCESS},
+		{Name: "test", State: github.CHECK_STATE_FAILURE, HTMLURL: "https://github.com/checks/1"},
+		{Name: "lint", State: ""},
+	}, nil)
+
+	state, reason, err := cv.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierFailureState, state)
+	require.Equal(t, "These check runs failed:\ntest (failure): https://github.com/checks/1", reason)
+}
+
+func TestVerify_CheckRunsVerifier_RequiredCheckNotReported_Waiting(t *testing.T) {
+	ci := &gerrit.ChangeInfo{Issue: int64(123)}
+	cv := setupCheckRunsVerifier(t, ci, []*github.Check{
+		{Name: "build", State: github.CHECK_STATE_SUCCESS},
+		{Name: "lint", State: github.CHECK_STATE_FAILURE},
+	}, []string{"build", "test"})
+
+	state, reason, err := cv.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierWaitingState, state)
+	require.Equal(t, "Waiting for these check runs to complete: test", reason)
+}
+
+func TestVerify_CheckRunsVerifier_NoCheckRuns_WaitsThenFails(t *testing.T) {
+	ci := &gerrit.ChangeInfo{Issue: int64(123)}
+	cv := setupCheckRunsVerifier(t, ci, []*github.Check{}, nil)
+	state, _, err := cv.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierWaitingState, state)
+
+	cv = setupCheckRunsVerifier(t, ci, []*github.Check{}, nil)
+	state, reason, err := cv.Verify(context.Background(), ci, currentTime.Unix()-CheckRunsReportTimeoutSecs)
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierFailureState, state)
+	require.Equal(t, "No check runs were reported within 5 minutes", reason)
+}
@@ -0,0 +1,456 @@
+package verifiers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+
+	"go.skia.org/infra/go/buildbucket"
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/skcq/go/codereview"
+	"go.skia.org/infra/skcq/go/config"
+	"go.skia.org/infra/skcq/go/db"
+	"go.skia.org/infra/skcq/go/types"
+	"go.skia.org/infra/task_scheduler/go/specs"
+)
+
+const (
+	// The maximum number of changes that are tested together if the merge
+	// queue config does not specify one.
+	MergeQueueMaxBatchSizeDefault = 4
+
+	// The merge queue of a repo+branch is advanced at most once in this
+	// many seconds, no matter how many of its changes are verified during a
+	// poll iteration.
+	MergeQueueAdvanceIntervalSecs = 30
+
+	// Gerrit responds with 409 Conflict when a cherry-pick does not apply.
+	cherryPickConflictErr = "(409)"
+
+	AbandonSpeculativeChangeMsg = "SkCQ is done testing this speculative change"
+	CancelBatchBuildsMsg        = "SkCQ is cleaning up try jobs of a merge queue batch that is no longer tested"
+)
+
+// NewMergeQueueVerifier returns an instance of MergeQueueVerifier.
+func NewMergeQueueVerifier(httpClient *http.Client, cr codereview.CodeReview, dbClient db.DB, mqCfg *config.MergeQueueCfg, tasksCfg *specs.TasksCfg, visibilityType config.VisibilityType) (types.Verifier, error) {
+	// Find gerritURL (eg: skia-review.googlesource.com).
+	issueURL := cr.Url(0)
+	u, err := url.Parse(issueURL)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not url.Parse %s", issueURL)
+	}
+
+	maxBatchSize := mqCfg.MaxBatchSize
+	if maxBatchSize <= 0 {
+		maxBatchSize = MergeQueueMaxBatchSizeDefault
+	}
+
+	return &MergeQueueVerifier{
+		bb2:          buildbucket.NewClient(httpClient),
+		cr:           cr,
+		dbClient:     dbClient,
+		gerritURL:    u.Host,
+		maxBatchSize: maxBatchSize,
+		newBatchVerifier: func(batchFiles []string) (types.Verifier, error) {
+			// Footers of the individual changes (eg: No-Try) do not apply to
+			// batches, so an empty footers map is used.
+			return NewTryJobsVerifier(httpClient, &batchCodeReview{CodeReview: cr, files: batchFiles}, tasksCfg, map[string]string{}, visibilityType)
+		},
+	}, nil
+}
+
+// MergeQueueVerifier implements the types.Verifier interface. It is used
+// instead of the TryJobsVerifier for CQ runs of repo+branches that specify a
+// MergeQueueCfg.
+//
+// Changes are added to the merge queue of their repo+branch when they are
+// first verified. The first changes of the queue are then stacked into a
+// batch by cherry-picking them on top of each other into speculative changes,
+// and the CQ try jobs are run on the last speculative change, which contains
+// the combined state of the batch. If the try jobs pass then the verifier
+// succeeds for all changes in the batch and the poller submits them. If they
+// fail then the batch is bisected and its halves are tested separately until
+// the culprit is found and rejected. This catches changes that pass on their
+// own but break the branch in combination with each other.
+//
+// The state of all merge queues is stored in the DB because verifiers are
+// instantiated anew for every change in every poll iteration.
+type MergeQueueVerifier struct {
+	bb2          buildbucket.BuildBucketInterface
+	cr           codereview.CodeReview
+	dbClient     db.DB
+	gerritURL    string
+	maxBatchSize int
+	// newBatchVerifier returns the verifier that runs the CQ try jobs on the
+	// combined state of a batch. Overridden in tests.
+	newBatchVerifier func(batchFiles []string) (types.Verifier, error)
+}
+
+// batchCodeReview is used when running the try jobs of a batch. It reports
+// the files modified by all changes of the batch as the files modified by the
+// speculative change that contains their combined state, so that the location
+// regexes of CQ try jobs match the same way they do for individual changes.
+type batchCodeReview struct {
+	codereview.CodeReview
+	files []string
+}
+
+// GetFileNames implements the CodeReview interface.
+func (b *batchCodeReview) GetFileNames(ctx context.Context, ci *gerrit.ChangeInfo) ([]string, error) {
+	return b.files, nil
+}
+
+// Name implements the types.Verifier interface.
+func (mv *MergeQueueVerifier) Name() string {
+	return "MergeQueueVerifier"
+}
+
+// Verify implements the types.Verifier interface.
+func (mv *MergeQueueVerifier) Verify(ctx context.Context, ci *gerrit.ChangeInfo, startTime int64) (state types.VerifierState, reason string, err error) {
+	mq, err := mv.getMergeQueue(ctx, ci.Project, ci.Branch)
+	if err != nil {
+		return "", "", skerr.Wrap(err)
+	}
+
+	// Add the change to the queue if it is not already there.
+	patchsetID := mv.cr.GetEarliestEquivalentPatchSetID(ci)
+	idx := findMergeQueueChange(mq, ci.Issue)
+	if idx != -1 && mq.Changes[idx].PatchsetID != patchsetID {
+		// A new CODE_CHANGE patchset was uploaded. Start over with it.
+		sklog.Infof("[%d] Patchset %d replaced %d in the merge queue of %s/%s", ci.Issue, patchsetID, mq.Changes[idx].PatchsetID, mq.Repo, mq.Branch)
+		mv.removeChange(ctx, mq, idx)
+		idx = -1
+	}
+	if idx == -1 {
+		sklog.Infof("[%d] Adding to the merge queue of %s/%s", ci.Issue, mq.Repo, mq.Branch)
+		mq.Changes = append(mq.Changes, &types.MergeQueueChange{
+			ChangeID:   ci.Issue,
+			PatchsetID: patchsetID,
+			EnqueuedTs: timeNowFunc().Unix(),
+			State:      types.MergeQueueQueuedState,
+		})
+	}
+
+	if timeNowFunc().Unix()-mq.LastAdvancedTs >= MergeQueueAdvanceIntervalSecs {
+		if err := mv.advance(ctx, mq); err != nil {
+			// Persist whatever progress was made (eg: speculative changes that
+			// were already created) before returning the error.
+			if putErr := mv.dbClient.PutMergeQueue(ctx, mq); putErr != nil {
+				sklog.Errorf("[%d] Could not persist the merge queue of %s/%s: %s", ci.Issue, mq.Repo, mq.Branch, putErr)
+			}
+			return "", "", skerr.Wrapf(err, "Could not advance the merge queue of %s/%s", mq.Repo, mq.Branch)
+		}
+		mq.LastAdvancedTs = timeNowFunc().Unix()
+	}
+
+	idx = findMergeQueueChange(mq, ci.Issue)
+	if idx == -1 {
+		// This only happens if the change left the CQ after the poller looked
+		// at it. It will be added back if it is still in the CQ next time.
+		state = types.VerifierWaitingState
+		reason = "Change is no longer in the merge queue"
+	} else {
+		c := mq.Changes[idx]
+		switch c.State {
+		case types.MergeQueueQueuedState:
+			state = types.VerifierWaitingState
+			reason = fmt.Sprintf("Waiting in the merge queue of %s/%s. There are %d changes ahead of this one.", mq.Repo, mq.Branch, countChangesAhead(mq, idx))
+		case types.MergeQueueTestingState:
+			state = types.VerifierWaitingState
+			reason = fmt.Sprintf("Being tested together with %d other changes at %s", len(mq.SpeculativeChanges)-1, mv.cr.Url(mq.SpeculativeChanges[len(mq.SpeculativeChanges)-1]))
+		case types.MergeQueuePassedState:
+			state = types.VerifierSuccessState
+			reason = c.Reason
+		case types.MergeQueueFailedState:
+			// Remove the change so that it starts over if it is put back
+			// into the CQ.
+			mv.removeChange(ctx, mq, idx)
+			state = types.VerifierFailureState
+			reason = c.Reason
+		default:
+			return "", "", skerr.Fmt("Unknown merge queue state %s of %d", c.State, ci.Issue)
+		}
+	}
+
+	if err := mv.dbClient.PutMergeQueue(ctx, mq); err != nil {
+		return "", "", skerr.Wrapf(err, "Could not persist the merge queue of %s/%s", mq.Repo, mq.Branch)
+	}
+	return state, reason, nil
+}
+
+// Cleanup implements the types.Verifier interface.
+func (mv *MergeQueueVerifier) Cleanup(ctx context.Context, ci *gerrit.ChangeInfo, cleanupPatchsetID int64) {
+	mq, err := mv.getMergeQueue(ctx, ci.Project, ci.Branch)
+	if err != nil {
+		sklog.Errorf("[%d] Could not get the merge queue in cleanup of %s: %s", ci.Issue, mv.Name(), err)
+		return
+	}
+	idx := findMergeQueueChange(mq, ci.Issue)
+	if idx == -1 || mq.Changes[idx].PatchsetID != cleanupPatchsetID {
+		return
+	}
+	mv.removeChange(ctx, mq, idx)
+	if err := mv.dbClient.PutMergeQueue(ctx, mq); err != nil {
+		sklog.Errorf("[%d] Could not persist the merge queue in cleanup of %s: %s", ci.Issue, mv.Name(), err)
+	}
+}
+
+// getMergeQueue returns the merge queue of the specified repo+branch from the
+// DB, or a new empty one if it does not exist yet.
+func (mv *MergeQueueVerifier) getMergeQueue(ctx context.Context, repo, branch string) (*types.MergeQueue, error) {
+	mq, err := mv.dbClient.GetMergeQueue(ctx, repo, branch)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Could not get the merge queue of %s/%s", repo, branch)
+	}
+	if mq == nil {
+		mq = &types.MergeQueue{
+			Repo:   repo,
+			Branch: branch,
+		}
+	}
+	return mq, nil
+}
+
+// advance moves the merge queue forward. It drops changes that left the CQ,
+// checks on the batch that is being tested, and creates a new batch if none
+// is being tested.
+func (mv *MergeQueueVerifier) advance(ctx context.Context, mq *types.MergeQueue) error {
+	// Drop changes that were submitted, abandoned, removed from the CQ or that
+	// got new CODE_CHANGE patchsets.
+	for i := len(mq.Changes) - 1; i >= 0; i-- {
+		c := mq.Changes[i]
+		ci, err := mv.cr.GetIssueProperties(ctx, c.ChangeID)
+		if err != nil {
+			return skerr.Wrapf(err, "Could not get issue properties of %d", c.ChangeID)
+		}
+		if ci.IsClosed() || !mv.cr.IsCQ(ctx, ci) || mv.cr.GetEarliestEquivalentPatchSetID(ci) != c.PatchsetID {
+			sklog.Infof("[%d] Dropping from the merge queue of %s/%s because it is no longer in the CQ", c.ChangeID, mq.Repo, mq.Branch)
+			mv.removeChange(ctx, mq, i)
+		}
+	}
+
+	if len(mq.SpeculativeChanges) > 0 {
+		return mv.checkBatch(ctx, mq)
+	}
+
+	// Changes that passed have to be submitted before a new batch is created,
+	// or else the new batch would not be tested on top of them.
+	for _, c := range mq.Changes {
+		if c.State == types.MergeQueuePassedState {
+			return nil
+		}
+	}
+	if err := mv.createBatch(ctx, mq); err != nil {
+		return skerr.Wrap(err)
+	}
+	if len(mq.SpeculativeChanges) > 0 {
+		return mv.checkBatch(ctx, mq)
+	}
+	return nil
+}
+
+// createBatch stacks the first queued changes of the merge queue on top of
+// each other into speculative changes.
+func (mv *MergeQueueVerifier) createBatch(ctx context.Context, mq *types.MergeQueue) error {
+	batchSize := mv.maxBatchSize
+	if len(mq.BisectSizes) > 0 {
+		batchSize = mq.BisectSizes[0]
+		mq.BisectSizes = mq.BisectSizes[1:]
+	}
+
+	batch := []*types.MergeQueueChange{}
+	for _, c := range mq.Changes {
+		if len(batch) == batchSize {
+			break
+		}
+		if c.State == types.MergeQueueQueuedState {
+			batch = append(batch, c)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var base *gerrit.ChangeInfo
+	files := map[string]bool{}
+	for i, c := range batch {
+		ci, err := mv.cr.GetIssueProperties(ctx, c.ChangeID)
+		if err != nil {
+			return skerr.Wrapf(err, "Could not get issue properties of %d", c.ChangeID)
+		}
+		commitMsg := fmt.Sprintf("[SkCQ merge queue] %s\n\nSpeculative cherry-pick of %s that is used to test it together with the changes below it. It will be abandoned once the batch is tested.", ci.Subject, mv.cr.Url(ci.Issue))
+		speculativeCI, err := mv.cr.CherryPick(ctx, ci, base, commitMsg)
+		if err != nil {
+			if !strings.Contains(err.Error(), cherryPickConflictErr) {
+				return skerr.Wrapf(err, "Could not create a speculative change for %d", ci.Issue)
+			}
+			if i == 0 {
+				// The change does not apply on top of its branch.
+				sklog.Infof("[%d] Does not apply on top of %s/%s: %s", ci.Issue, mq.Repo, mq.Branch, err)
+				c.State = types.MergeQueueFailedState
+				c.Reason = fmt.Sprintf("Could not apply the change on top of %s. Please rebase it.", mq.Branch)
+				return nil
+			}
+			// The change does not apply on top of the changes ahead of it.
+			// Test the batch without it. It will be tested on its own
+			// once they are submitted.
+			sklog.Infof("[%d] Does not apply on top of the %d changes ahead of it in the merge queue of %s/%s", ci.Issue, i, mq.Repo, mq.Branch)
+			break
+		}
+		mq.SpeculativeChanges = append(mq.SpeculativeChanges, speculativeCI.Issue)
+		base = speculativeCI
+		c.State = types.MergeQueueTestingState
+		changedFiles, err := mv.cr.GetFileNames(ctx, ci)
+		if err != nil {
+			return skerr.Wrapf(err, "Could not get file names of %d", ci.Issue)
+		}
+		for _, f := range changedFiles {
+			files[f] = true
+		}
+	}
+
+	mq.BatchFiles = make([]string, 0, len(files))
+	for f := range files {
+		mq.BatchFiles = append(mq.BatchFiles, f)
+	}
+	sort.Strings(mq.BatchFiles)
+	mq.BatchStartTs = timeNowFunc().Unix()
+	sklog.Infof("Created a batch of %d changes in the merge queue of %s/%s. Speculative changes: %v", len(mq.SpeculativeChanges), mq.Repo, mq.Branch, mq.SpeculativeChanges)
+	return nil
+}
+
+// checkBatch runs the CQ try jobs on the combined state of the batch that is
+// being tested and updates the merge queue if they are done.
+func (mv *MergeQueueVerifier) checkBatch(ctx context.Context, mq *types.MergeQueue) error {
+	tipID := mq.SpeculativeChanges[len(mq.SpeculativeChanges)-1]
+	tip, err := mv.cr.GetIssueProperties(ctx, tipID)
+	if err != nil {
+		return skerr.Wrapf(err, "Could not get issue properties of speculative change %d", tipID)
+	}
+	batchVerifier, err := mv.newBatchVerifier(mq.BatchFiles)
+	if err != nil {
+		return skerr.Wrapf(err, "Could not create the batch verifier")
+	}
+	state, reason, err := batchVerifier.Verify(ctx, tip, mq.BatchStartTs)
+	if err != nil {
+		return skerr.Wrapf(err, "Could not verify speculative change %d", tipID)
+	}
+
+	batch := []*types.MergeQueueChange{}
+	for _, c := range mq.Changes {
+		if c.State == types.MergeQueueTestingState {
+			batch = append(batch, c)
+		}
+	}
+
+	switch state {
+	case types.VerifierWaitingState:
+		return nil
+	case types.VerifierSuccessState:
+		sklog.Infof("The batch %s of %d changes passed in the merge queue of %s/%s", mv.cr.Url(tipID), len(batch), mq.Repo, mq.Branch)
+		for _, c := range batch {
+			c.State = types.MergeQueuePassedState
+			c.Reason = fmt.Sprintf("Passed the merge queue when tested together with %d other changes at %s", len(batch)-1, mv.cr.Url(tipID))
+		}
+	case types.VerifierFailureState:
+		sklog.Infof("The batch %s of %d changes failed in the merge queue of %s/%s: %s", mv.cr.Url(tipID), len(batch), mq.Repo, mq.Branch, reason)
+		if len(batch) == 1 {
+			batch[0].State = types.MergeQueueFailedState
+			batch[0].Reason = fmt.Sprintf("Failed in the merge queue when tested on top of %s at %s:\n%s", mq.Branch, mv.cr.Url(tipID), reason)
+		} else {
+			// Bisect the batch. Both halves are tested before any other
+			// changes, so that the culprit is found quickly.
+			firstHalf := len(batch) / 2
+			mq.BisectSizes = append([]int{firstHalf, len(batch) - firstHalf}, mq.BisectSizes...)
+			for _, c := range batch {
+				c.State = types.MergeQueueQueuedState
+			}
+		}
+	default:
+		return skerr.Fmt("Unknown state %s from the batch verifier", state)
+	}
+	mv.finishBatch(ctx, mq)
+	return nil
+}
+
+// removeChange removes the change at the specified index from the merge
+// queue. If the change was being tested then its batch is aborted and the
+// other changes of the batch are queued again.
+func (mv *MergeQueueVerifier) removeChange(ctx context.Context, mq *types.MergeQueue, idx int) {
+	if mq.Changes[idx].State == types.MergeQueueTestingState {
+		sklog.Infof("[%d] Aborting the batch of the merge queue of %s/%s that the change was in", mq.Changes[idx].ChangeID, mq.Repo, mq.Branch)
+		for _, c := range mq.Changes {
+			if c.State == types.MergeQueueTestingState {
+				c.State = types.MergeQueueQueuedState
+			}
+		}
+		mv.finishBatch(ctx, mq)
+	}
+	mq.Changes = append(mq.Changes[:idx], mq.Changes[idx+1:]...)
+}
+
+// finishBatch cancels the try jobs of the batch that was being tested and
+// abandons its speculative changes. Errors are logged but otherwise ignored
+// since they do not affect the merge queue.
+func (mv *MergeQueueVerifier) finishBatch(ctx context.Context, mq *types.MergeQueue) {
+	for _, s := range mq.SpeculativeChanges {
+		ci, err := mv.cr.GetIssueProperties(ctx, s)
+		if err != nil {
+			sklog.Errorf("[%d] Could not get issue properties of speculative change: %s", s, err)
+			continue
+		}
+		// Speculative changes only ever have a single patchset.
+		builds, err := mv.bb2.GetTrybotsForCL(ctx, s, mv.cr.GetLatestPatchSetID(ci), "https://"+mv.gerritURL, map[string]string{"triggered_by": "skcq"})
+		if err != nil {
+			sklog.Errorf("[%d] Could not search for trybots of speculative change: %s", s, err)
+		} else {
+			buildIDsToCancel := []int64{}
+			for _, b := range builds {
+				if b.GetStatus() == buildbucketpb.Status_STARTED || b.GetStatus() == buildbucketpb.Status_SCHEDULED {
+					buildIDsToCancel = append(buildIDsToCancel, b.GetId())
+				}
+			}
+			if len(buildIDsToCancel) > 0 {
+				if _, err := mv.bb2.CancelBuilds(ctx, buildIDsToCancel, CancelBatchBuildsMsg); err != nil {
+					sklog.Errorf("[%d] Could not cancel buildbucket builds of IDs %+v: %s", s, buildIDsToCancel, err)
+				}
+			}
+		}
+		if err := mv.cr.Abandon(ctx, ci, AbandonSpeculativeChangeMsg); err != nil {
+			sklog.Errorf("[%d] Could not abandon speculative change: %s", s, err)
+		}
+	}
+	mq.SpeculativeChanges = nil
+	mq.BatchFiles = nil
+	mq.BatchStartTs = 0
+}
+
+// findMergeQueueChange returns the index of the specified change in the merge
+// queue, or -1 if it is not in it.
+func findMergeQueueChange(mq *types.MergeQueue, changeID int64) int {
+	for i, c := range mq.Changes {
+		if c.ChangeID == changeID {
+			return i
+		}
+	}
+	return -1
+}
+
+// countChangesAhead returns the number of changes in the merge queue that will
+// be tested or submitted before the change at the specified index.
+func countChangesAhead(mq *types.MergeQueue, idx int) int {
+	ahead := 0
+	for _, c := range mq.Changes[:idx] {
+		if c.State != types.MergeQueueFailedState {
+			ahead++
+		}
+	}
+	return ahead
+}
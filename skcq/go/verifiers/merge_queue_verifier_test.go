@@ -0,0 +1,309 @@
+package verifiers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+
+	bb_mocks "go.skia.org/infra/go/buildbucket/mocks"
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/testutils"
+	cr_mocks "go.skia.org/infra/skcq/go/codereview/mocks"
+	db_mocks "go.skia.org/infra/skcq/go/db/mocks"
+	"go.skia.org/infra/skcq/go/types"
+	"go.skia.org/infra/skcq/go/types/mocks"
+)
+
+const (
+	mqRepo   = "skia"
+	mqBranch = "main"
+)
+
+// setupMergeQueueTest returns a MergeQueueVerifier whose DB contains the
+// specified merge queue. The verifier updates the merge queue in place, so
+// tests can inspect it after calling Verify.
+func setupMergeQueueTest(t *testing.T, mq *types.MergeQueue) (*MergeQueueVerifier, *cr_mocks.CodeReview, *bb_mocks.BuildBucketInterface, *mocks.Verifier) {
+	timeNowFunc = func() time.Time {
+		return currentTime
+	}
+
+	cr := &cr_mocks.CodeReview{}
+	cr.On("Url", mock.AnythingOfType("int64")).Return("https://skia-review.googlesource.com/c/123").Maybe()
+	cr.On("GetEarliestEquivalentPatchSetID", mock.Anything).Return(int64(1)).Maybe()
+	cr.On("GetLatestPatchSetID", mock.Anything).Return(int64(1)).Maybe()
+	cr.On("IsCQ", testutils.AnyContext, mock.Anything).Return(true).Maybe()
+
+	dbClient := &db_mocks.DB{}
+	dbClient.On("GetMergeQueue", testutils.AnyContext, mqRepo, mqBranch).Return(mq, nil).Once()
+	dbClient.On("PutMergeQueue", testutils.AnyContext, mq).Return(nil).Once()
+
+	bb := &bb_mocks.BuildBucketInterface{}
+	batchVerifier := &mocks.Verifier{}
+	mv := &MergeQueueVerifier{
+		bb2:          bb,
+		cr:           cr,
+		dbClient:     dbClient,
+		gerritURL:    "skia-review.googlesource.com",
+		maxBatchSize: MergeQueueMaxBatchSizeDefault,
+		newBatchVerifier: func(batchFiles []string) (types.Verifier, error) {
+			return batchVerifier, nil
+		},
+	}
+	t.Cleanup(func() {
+		cr.AssertExpectations(t)
+		dbClient.AssertExpectations(t)
+		bb.AssertExpectations(t)
+		batchVerifier.AssertExpectations(t)
+	})
+	return mv, cr, bb, batchVerifier
+}
+
+func mqChange(issue int64) *gerrit.ChangeInfo {
+	return &gerrit.ChangeInfo{
+		Issue:   issue,
+		Project: mqRepo,
+		Branch:  mqBranch,
+		Status:  gerrit.ChangeStatusNew,
+		Subject: "Subject",
+	}
+}
+
+func expectFinishBatch(cr *cr_mocks.CodeReview, bb *bb_mocks.BuildBucketInterface, speculativeIssue int64) {
+	speculativeCI := mqChange(speculativeIssue)
+	cr.On("GetIssueProperties", testutils.AnyContext, speculativeIssue).Return(speculativeCI, nil)
+	bb.On("GetTrybotsForCL", testutils.AnyContext, speculativeIssue, int64(1), "https://skia-review.googlesource.com", map[string]string{"triggered_by": "skcq"}).Return([]*buildbucketpb.Build{
+		{Id: 1, Status: buildbucketpb.Status_STARTED},
+		{Id: 2, Status: buildbucketpb.Status_SUCCESS},
+	}, nil).Once()
+	bb.On("CancelBuilds", testutils.AnyContext, []int64{1}, CancelBatchBuildsMsg).Return(nil, nil).Once()
+	cr.On("Abandon", testutils.AnyContext, speculativeCI, AbandonSpeculativeChangeMsg).Return(nil).Once()
+}
+
+func TestMergeQueueVerify_NewChanges_BatchCreatedAndWaiting(t *testing.T) {
+	ci1 := mqChange(1)
+	ci2 := mqChange(2)
+	// ci1 is already queued, ci2 is being verified for the first time.
+	mq := &types.MergeQueue{
+		Repo:   mqRepo,
+		Branch: mqBranch,
+		Changes: []*types.MergeQueueChange{
+			{ChangeID: 1, PatchsetID: 1, State: types.MergeQueueQueuedState},
+		},
+	}
+	mv, cr, _, batchVerifier := setupMergeQueueTest(t, mq)
+
+	spec1 := mqChange(1001)
+	spec2 := mqChange(1002)
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(1)).Return(ci1, nil)
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(2)).Return(ci2, nil)
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(1002)).Return(spec2, nil).Once()
+	cr.On("CherryPick", testutils.AnyContext, ci1, (*gerrit.ChangeInfo)(nil), mock.AnythingOfType("string")).Return(spec1, nil).Once()
+	cr.On("CherryPick", testutils.AnyContext, ci2, spec1, mock.AnythingOfType("string")).Return(spec2, nil).Once()
+	cr.On("GetFileNames", testutils.AnyContext, ci1).Return([]string{"b.cpp", "a.cpp"}, nil).Once()
+	cr.On("GetFileNames", testutils.AnyContext, ci2).Return([]string{"a.cpp"}, nil).Once()
+	batchVerifier.On("Verify", testutils.AnyContext, spec2, currentTime.Unix()).Return(types.VerifierWaitingState, "Waiting for try jobs", nil).Once()
+
+	state, _, err := mv.Verify(context.Background(), ci2, 0)
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierWaitingState, state)
+
+	require.Equal(t, []int64{1001, 1002}, mq.SpeculativeChanges)
+	require.Equal(t, []string{"a.cpp", "b.cpp"}, mq.BatchFiles)
+	require.Equal(t, currentTime.Unix(), mq.BatchStartTs)
+	require.Equal(t, currentTime.Unix(), mq.LastAdvancedTs)
+	require.Len(t, mq.Changes, 2)
+	for _, c := range mq.Changes {
+		require.Equal(t, types.MergeQueueTestingState, c.State)
+	}
+}
+
+func TestMergeQueueVerify_BatchPasses_ChangesPassed(t *testing.T) {
+	ci1 := mqChange(1)
+	ci2 := mqChange(2)
+	mq := &types.MergeQueue{
+		Repo:   mqRepo,
+		Branch: mqBranch,
+		Changes: []*types.MergeQueueChange{
+			{ChangeID: 1, PatchsetID: 1, State: types.MergeQueueTestingState},
+			{ChangeID: 2, PatchsetID: 1, State: types.MergeQueueTestingState},
+		},
+		SpeculativeChanges: []int64{1001, 1002},
+		BatchStartTs:       currentTime.Unix() - 100,
+	}
+	mv, cr, bb, batchVerifier := setupMergeQueueTest(t, mq)
+
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(1)).Return(ci1, nil)
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(2)).Return(ci2, nil)
+	expectFinishBatch(cr, bb, 1001)
+	expectFinishBatch(cr, bb, 1002)
+	batchVerifier.On("Verify", testutils.AnyContext, mqChange(1002), currentTime.Unix()-100).Return(types.VerifierSuccessState, "", nil).Once()
+
+	state, _, err := mv.Verify(context.Background(), ci1, 0)
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierSuccessState, state)
+
+	require.Empty(t, mq.SpeculativeChanges)
+	for _, c := range mq.Changes {
+		require.Equal(t, types.MergeQueuePassedState, c.State)
+	}
+}
+
+func TestMergeQueueVerify_BatchFails_Bisected(t *testing.T) {
+	ci1 := mqChange(1)
+	ci2 := mqChange(2)
+	ci3 := mqChange(3)
+	mq := &types.MergeQueue{
+		Repo:   mqRepo,
+		Branch: mqBranch,
+		Changes: []*types.MergeQueueChange{
+			{ChangeID: 1, PatchsetID: 1, State: types.MergeQueueTestingState},
+			{ChangeID: 2, PatchsetID: 1, State: types.MergeQueueTestingState},
+			{ChangeID: 3, PatchsetID: 1, State: types.MergeQueueTestingState},
+		},
+		SpeculativeChanges: []int64{1001, 1002, 1003},
+	}
+	mv, cr, bb, batchVerifier := setupMergeQueueTest(t, mq)
+
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(1)).Return(ci1, nil)
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(2)).Return(ci2, nil)
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(3)).Return(ci3, nil)
+	expectFinishBatch(cr, bb, 1001)
+	expectFinishBatch(cr, bb, 1002)
+	expectFinishBatch(cr, bb, 1003)
+	batchVerifier.On("Verify", testutils.AnyContext, mqChange(1003), int64(0)).Return(types.VerifierFailureState, "try_job1 has failed", nil).Once()
+
+	state, _, err := mv.Verify(context.Background(), ci2, 0)
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierWaitingState, state)
+
+	require.Equal(t, []int{1, 2}, mq.BisectSizes)
+	require.Empty(t, mq.SpeculativeChanges)
+	for _, c := range mq.Changes {
+		require.Equal(t, types.MergeQueueQueuedState, c.State)
+	}
+}
+
+func TestMergeQueueVerify_SingleChangeBatchFails_ChangeFailedAndRemoved(t *testing.T) {
+	ci1 := mqChange(1)
+	mq := &types.MergeQueue{
+		Repo:   mqRepo,
+		Branch: mqBranch,
+		Changes: []*types.MergeQueueChange{
+			{ChangeID: 1, PatchsetID: 1, State: types.MergeQueueTestingState},
+		},
+		SpeculativeChanges: []int64{1001},
+		BisectSizes:        []int{1},
+	}
+	mv, cr, bb, batchVerifier := setupMergeQueueTest(t, mq)
+
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(1)).Return(ci1, nil)
+	expectFinishBatch(cr, bb, 1001)
+	batchVerifier.On("Verify", testutils.AnyContext, mqChange(1001), int64(0)).Return(types.VerifierFailureState, "try_job1 has failed", nil).Once()
+
+	state, reason, err := mv.Verify(context.Background(), ci1, 0)
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierFailureState, state)
+	require.Contains(t, reason, "try_job1 has failed")
+
+	require.Empty(t, mq.Changes)
+	// The bisect sizes of the rest of the failed batch are untouched.
+	require.Equal(t, []int{1}, mq.BisectSizes)
+}
+
+func TestMergeQueueVerify_CherryPickConflict_ChangeFailed(t *testing.T) {
+	ci1 := mqChange(1)
+	mq := &types.MergeQueue{
+		Repo:   mqRepo,
+		Branch: mqBranch,
+	}
+	mv, cr, _, _ := setupMergeQueueTest(t, mq)
+
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(1)).Return(ci1, nil)
+	cr.On("CherryPick", testutils.AnyContext, ci1, (*gerrit.ChangeInfo)(nil), mock.AnythingOfType("string")).Return(nil, skerr.Fmt("got status 409 Conflict (409): merge conflict")).Once()
+
+	state, reason, err := mv.Verify(context.Background(), ci1, 0)
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierFailureState, state)
+	require.Contains(t, reason, "Please rebase it")
+
+	require.Empty(t, mq.Changes)
+	require.Empty(t, mq.SpeculativeChanges)
+}
+
+func TestMergeQueueVerify_TestingChangeLeftCQ_BatchAbortedAndRecreated(t *testing.T) {
+	ci1 := mqChange(1)
+	ci2 := mqChange(2)
+	ci2.Status = gerrit.ChangeStatusAbandoned
+	mq := &types.MergeQueue{
+		Repo:   mqRepo,
+		Branch: mqBranch,
+		Changes: []*types.MergeQueueChange{
+			{ChangeID: 1, PatchsetID: 1, State: types.MergeQueueTestingState},
+			{ChangeID: 2, PatchsetID: 1, State: types.MergeQueueTestingState},
+		},
+		SpeculativeChanges: []int64{1001, 1002},
+	}
+	mv, cr, bb, batchVerifier := setupMergeQueueTest(t, mq)
+
+	spec3 := mqChange(1003)
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(1)).Return(ci1, nil)
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(2)).Return(ci2, nil)
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(1003)).Return(spec3, nil).Once()
+	expectFinishBatch(cr, bb, 1001)
+	expectFinishBatch(cr, bb, 1002)
+	// The remaining change is tested in a new batch.
+	cr.On("CherryPick", testutils.AnyContext, ci1, (*gerrit.ChangeInfo)(nil), mock.AnythingOfType("string")).Return(spec3, nil).Once()
+	cr.On("GetFileNames", testutils.AnyContext, ci1).Return([]string{"a.cpp"}, nil).Once()
+	batchVerifier.On("Verify", testutils.AnyContext, spec3, currentTime.Unix()).Return(types.VerifierWaitingState, "Waiting for try jobs", nil).Once()
+
+	state, _, err := mv.Verify(context.Background(), ci1, 0)
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierWaitingState, state)
+	require.Len(t, mq.Changes, 1)
+	require.Equal(t, types.MergeQueueTestingState, mq.Changes[0].State)
+	require.Equal(t, []int64{1003}, mq.SpeculativeChanges)
+}
+
+func TestMergeQueueVerify_PassedChangesNotSubmitted_NoNewBatch(t *testing.T) {
+	ci1 := mqChange(1)
+	ci2 := mqChange(2)
+	mq := &types.MergeQueue{
+		Repo:   mqRepo,
+		Branch: mqBranch,
+		Changes: []*types.MergeQueueChange{
+			{ChangeID: 1, PatchsetID: 1, State: types.MergeQueuePassedState},
+			{ChangeID: 2, PatchsetID: 1, State: types.MergeQueueQueuedState},
+		},
+	}
+	mv, cr, _, _ := setupMergeQueueTest(t, mq)
+
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(1)).Return(ci1, nil)
+	cr.On("GetIssueProperties", testutils.AnyContext, int64(2)).Return(ci2, nil)
+
+	state, reason, err := mv.Verify(context.Background(), ci2, 0)
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierWaitingState, state)
+	require.Contains(t, reason, "There are 1 changes ahead of this one")
+}
+
+func TestMergeQueueVerify_RecentlyAdvanced_QueueNotAdvanced(t *testing.T) {
+	ci1 := mqChange(1)
+	mq := &types.MergeQueue{
+		Repo:           mqRepo,
+		Branch:         mqBranch,
+		LastAdvancedTs: currentTime.Unix() - 1,
+	}
+	mv, _, _, _ := setupMergeQueueTest(t, mq)
+
+	state, _, err := mv.Verify(context.Background(), ci1, 0)
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierWaitingState, state)
+
+	require.Len(t, mq.Changes, 1)
+	require.Equal(t, types.MergeQueueQueuedState, mq.Changes[0].State)
+}
@@ -0,0 +1,313 @@
+package verifiers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/skcq/go/codereview"
+	"go.skia.org/infra/skcq/go/config"
+	"go.skia.org/infra/skcq/go/types"
+)
+
+const (
+	// Name of the files that specify the owners of a directory.
+	OwnersFileName = "OWNERS"
+
+	// Owner entry that allows anyone to approve.
+	ownersEveryone = "*"
+
+	ownersNoParentDirective = "set noparent"
+	ownersPerFilePrefix     = "per-file "
+)
+
+// NewOwnersVerifier returns an instance of OwnersVerifier.
+func NewOwnersVerifier(cr codereview.CodeReview, configReader config.ConfigReader) (types.Verifier, error) {
+	return &OwnersVerifier{
+		cr:           cr,
+		configReader: configReader,
+		ownersFiles:  map[string]*ownersFile{},
+	}, nil
+}
+
+// OwnersVerifier implements the types.Verifier interface. It verifies that
+// every file modified by a change has been approved (Code-Review+1 or higher)
+// by one of its owners.
+//
+// The owners of a file are found by walking up its directory tree and
+// reading the OWNERS file of every directory, until the root of the repo or
+// an OWNERS file with "set noparent" is reached. OWNERS files support the
+// following lines:
+//
+//	# A comment.
+//	batman@gotham.com          An owner of the directory.
+//	*                          Anyone can approve changes to the directory.
+//	set noparent               Do not consider the owners of parent directories.
+//	per-file *.gn=x@y.com      An owner of matching files in the directory.
+//	per-file *.gn=set noparent Only the per-file owners own matching files.
+//
+// If the change owner is an owner of a file then an approval by anyone is
+// enough for that file.
+type OwnersVerifier struct {
+	cr           codereview.CodeReview
+	configReader config.ConfigReader
+	// Parsed OWNERS files by directory. nil if the directory does not have an
+	// OWNERS file.
+	ownersFiles map[string]*ownersFile
+}
+
+// ownersFile is the parsed content of an OWNERS file.
+type ownersFile struct {
+	owners   []string
+	noParent bool
+	perFile  []*ownersPerFileRule
+}
+
+// ownersPerFileRule is a "per-file" line of an OWNERS file.
+type ownersPerFileRule struct {
+	globs    []string
+	owners   []string
+	noParent bool
+}
+
+// Name implements the types.Verifier interface.
+func (ov *OwnersVerifier) Name() string {
+	return "OwnersVerifier"
+}
+
+// Verify implements the types.Verifier interface.
+func (ov *OwnersVerifier) Verify(ctx context.Context, ci *gerrit.ChangeInfo, startTime int64) (state types.VerifierState, reason string, err error) {
+	changedFiles, err := ov.cr.GetFileNames(ctx, ci)
+	if err != nil {
+		return "", "", skerr.Wrapf(err, "Could not get file names of %d", ci.Issue)
+	}
+	approvers := getApprovers(ci)
+
+	// Directories with OWNERS files that still need approval, mapped to the
+	// files in them that need approval.
+	dirsToFiles := map[string][]string{}
+	dirsToOwners := map[string][]string{}
+	for _, f := range changedFiles {
+		// Gerrit includes magic files like "/COMMIT_MSG" in the list of files.
+		if strings.HasPrefix(f, "/") {
+			continue
+		}
+		owners, ownersDir, err := ov.getOwners(ctx, f)
+		if err != nil {
+			return "", "", skerr.Wrapf(err, "Could not get the owners of %s", f)
+		}
+		if isApproved(owners, approvers, ci.Owner.Email) {
+			continue
+		}
+		dirsToFiles[ownersDir] = append(dirsToFiles[ownersDir], f)
+		dirsToOwners[ownersDir] = owners
+	}
+
+	if len(dirsToFiles) == 0 {
+		return types.VerifierSuccessState, fmt.Sprintf("All %d modified files have been approved by their owners", len(changedFiles)), nil
+	}
+	dirs := make([]string, 0, len(dirsToFiles))
+	for d := range dirsToFiles {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+	msgs := []string{}
+	for _, d := range dirs {
+		owners := dirsToOwners[d]
+		if len(owners) == 0 {
+			msgs = append(msgs, fmt.Sprintf("%s has no owners. Files: %s", ownersFilePath(d), strings.Join(dirsToFiles[d], ", ")))
+		} else {
+			msgs = append(msgs, fmt.Sprintf("%s (owners: %s). Files: %s", ownersFilePath(d), strings.Join(owners, ", "), strings.Join(dirsToFiles[d], ", ")))
+		}
+	}
+	return types.VerifierFailureState, fmt.Sprintf("Missing approval from an owner of the following directories:\n%s", strings.Join(msgs, "\n")), nil
+}
+
+// Cleanup implements the types.Verifier interface.
+func (ov *OwnersVerifier) Cleanup(ctx context.Context, ci *gerrit.ChangeInfo, cleanupPatchsetID int64) {
+	return
+}
+
+// getOwners returns the owners of the specified file and the directory of the
+// closest OWNERS file that applies to it, which is where approval should be
+// requested from. If no OWNERS file applies to the file then the root
+// directory is returned.
+func (ov *OwnersVerifier) getOwners(ctx context.Context, filePath string) ([]string, string, error) {
+	owners := []string{}
+	ownersDir := ""
+	foundOwnersDir := false
+	for dir := parentDir(filePath); ; dir = parentDir(dir) {
+		of, err := ov.getOwnersFile(ctx, dir)
+		if err != nil {
+			return nil, "", skerr.Wrap(err)
+		}
+		if of != nil {
+			if !foundOwnersDir {
+				ownersDir = dir
+				foundOwnersDir = true
+			}
+			// Per-file globs are matched against the path of the file relative
+			// to the directory of the OWNERS file.
+			relPath := strings.TrimPrefix(filePath, dir+"/")
+			if dir == "" {
+				relPath = filePath
+			}
+			perFileNoParent := false
+			for _, rule := range of.perFile {
+				if !rule.matches(relPath) {
+					continue
+				}
+				owners = appendOwners(owners, rule.owners)
+				perFileNoParent = perFileNoParent || rule.noParent
+			}
+			if perFileNoParent {
+				break
+			}
+			owners = appendOwners(owners, of.owners)
+			if of.noParent {
+				break
+			}
+		}
+		if dir == "" {
+			break
+		}
+	}
+	return owners, ownersDir, nil
+}
+
+// getOwnersFile returns the parsed OWNERS file of the specified directory, or
+// nil if it does not have one.
+func (ov *OwnersVerifier) getOwnersFile(ctx context.Context, dir string) (*ownersFile, error) {
+	if of, ok := ov.ownersFiles[dir]; ok {
+		return of, nil
+	}
+	contents, err := ov.configReader.GetOwnersFileContents(ctx, ownersFilePath(dir))
+	if err != nil {
+		if !config.IsNotFound(err) {
+			return nil, skerr.Wrapf(err, "Could not read %s", ownersFilePath(dir))
+		}
+		ov.ownersFiles[dir] = nil
+		return nil, nil
+	}
+	of := parseOwnersFile(contents)
+	ov.ownersFiles[dir] = of
+	return of, nil
+}
+
+// parseOwnersFile parses the contents of an OWNERS file. Unsupported lines
+// (eg: "include" and "file:" directives) are logged and ignored.
+func parseOwnersFile(contents string) *ownersFile {
+	of := &ownersFile{}
+	for _, l := range strings.Split(contents, "\n") {
+		if i := strings.Index(l, "#"); i != -1 {
+			l = l[:i]
+		}
+		l = strings.TrimSpace(l)
+		switch {
+		case l == "":
+			continue
+		case l == ownersNoParentDirective:
+			of.noParent = true
+		case strings.HasPrefix(l, ownersPerFilePrefix):
+			globsAndOwners := strings.SplitN(strings.TrimPrefix(l, ownersPerFilePrefix), "=", 2)
+			if len(globsAndOwners) != 2 {
+				sklog.Warningf("Ignoring malformed line in %s file: %q", OwnersFileName, l)
+				continue
+			}
+			rule := &ownersPerFileRule{}
+			for _, g := range strings.Split(globsAndOwners[0], ",") {
+				rule.globs = append(rule.globs, strings.TrimSpace(g))
+			}
+			if strings.TrimSpace(globsAndOwners[1]) == ownersNoParentDirective {
+				rule.noParent = true
+			} else {
+				for _, o := range strings.Split(globsAndOwners[1], ",") {
+					rule.owners = append(rule.owners, strings.TrimSpace(o))
+				}
+			}
+			of.perFile = append(of.perFile, rule)
+		case l == ownersEveryone || strings.Contains(l, "@"):
+			of.owners = append(of.owners, l)
+		default:
+			sklog.Warningf("Ignoring unsupported line in %s file: %q", OwnersFileName, l)
+		}
+	}
+	return of
+}
+
+// matches returns true if any of the globs of the rule match the specified
+// path.
+func (r *ownersPerFileRule) matches(relPath string) bool {
+	for _, g := range r.globs {
+		if matched, err := path.Match(g, relPath); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// getApprovers returns the emails of everyone who voted Code-Review+1 or
+// higher on the change, other than the change owner.
+func getApprovers(ci *gerrit.ChangeInfo) []string {
+	approvers := []string{}
+	if val, ok := ci.Labels[gerrit.LabelCodeReview]; ok {
+		for _, ld := range val.All {
+			if ld.Value >= gerrit.LabelCodeReviewApprove && ld.Email != ci.Owner.Email {
+				approvers = append(approvers, ld.Email)
+			}
+		}
+	}
+	return approvers
+}
+
+// isApproved returns true if the approvers satisfy the specified owners.
+func isApproved(owners, approvers []string, changeOwner string) bool {
+	if len(approvers) == 0 {
+		return false
+	}
+	if util.In(ownersEveryone, owners) || util.In(changeOwner, owners) {
+		return true
+	}
+	for _, a := range approvers {
+		if util.In(a, owners) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendOwners appends the new owners to the owners while skipping
+// duplicates.
+func appendOwners(owners, newOwners []string) []string {
+	for _, o := range newOwners {
+		if !util.In(o, owners) {
+			owners = append(owners, o)
+		}
+	}
+	return owners
+}
+
+// parentDir returns the parent directory of the specified path, with "" being
+// the root of the repo.
+func parentDir(p string) string {
+	dir := path.Dir(p)
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	return dir
+}
+
+// ownersFilePath returns the path of the OWNERS file of the specified
+// directory.
+func ownersFilePath(dir string) string {
+	if dir == "" {
+		return OwnersFileName
+	}
+	return dir + "/" + OwnersFileName
+}
@@ -0,0 +1,144 @@
+package verifiers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/gerrit"
+	"go.skia.org/infra/go/testutils"
+	cr_mocks "go.skia.org/infra/skcq/go/codereview/mocks"
+	"go.skia.org/infra/skcq/go/config"
+	cfg_mocks "go.skia.org/infra/skcq/go/config/mocks"
+	"go.skia.org/infra/skcq/go/types"
+)
+
+const (
+	testChangeOwner = "author@google.com"
+)
+
+// setupOwnersVerifier returns an OwnersVerifier for a change that modifies the
+// specified files and a repo with the specified OWNERS files. OWNERS files
+// that are not specified are not found.
+func setupOwnersVerifier(t *testing.T, ci *gerrit.ChangeInfo, files []string, ownersFiles map[string]string) *OwnersVerifier {
+	cr := &cr_mocks.CodeReview{}
+	cr.On("GetFileNames", testutils.AnyContext, ci).Return(files, nil).Once()
+	cfgReader := &cfg_mocks.ConfigReader{}
+	for _, p := range []string{"OWNERS", "a/OWNERS", "a/b/OWNERS", "c/OWNERS"} {
+		if contents, ok := ownersFiles[p]; ok {
+			cfgReader.On("GetOwnersFileContents", testutils.AnyContext, p).Return(contents, nil).Maybe()
+		} else {
+			cfgReader.On("GetOwnersFileContents", testutils.AnyContext, p).Return("", &config.ConfigNotFoundError{}).Maybe()
+		}
+	}
+	t.Cleanup(func() {
+		cr.AssertExpectations(t)
+		cfgReader.AssertExpectations(t)
+	})
+
+	ov, err := NewOwnersVerifier(cr, cfgReader)
+	require.NoError(t, err)
+	return ov.(*OwnersVerifier)
+}
+
+func getOwnersTestChange(approvers ...string) *gerrit.ChangeInfo {
+	all := []*gerrit.LabelDetail{}
+	for _, a := range approvers {
+		all = append(all, &gerrit.LabelDetail{Email: a, Value: gerrit.LabelCodeReviewApprove})
+	}
+	return &gerrit.ChangeInfo{
+		Issue: int64(123),
+		Owner: &gerrit.Person{Email: testChangeOwner},
+		Labels: map[string]*gerrit.LabelEntry{
+			gerrit.LabelCodeReview: {All: all},
+		},
+	}
+}
+
+func TestVerify_OwnersVerifier_ApprovedByParentOwner_Success(t *testing.T) {
+	ci := getOwnersTestChange("root@google.com")
+	ov := setupOwnersVerifier(t, ci, []string{"/COMMIT_MSG", "a/b/file.cpp", "README.md"}, map[string]string{
+		"OWNERS":   "# Root owners.\nroot@google.com\n",
+		"a/OWNERS": "a-owner@google.com",
+	})
+
+	state, _, err := ov.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierSuccessState, state)
+}
+
+func TestVerify_OwnersVerifier_NoParent_ParentOwnerApprovalNotEnough(t *testing.T) {
+	ci := getOwnersTestChange("root@google.com")
+	ov := setupOwnersVerifier(t, ci, []string{"a/b/file.cpp", "README.md"}, map[string]string{
+		"OWNERS":     "root@google.com",
+		"a/b/OWNERS": "set noparent\nb-owner@google.com # The only owner.",
+	})
+
+	state, reason, err := ov.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierFailureState, state)
+	require.Equal(t, "Missing approval from an owner of the following directories:\na/b/OWNERS (owners: b-owner@google.com). Files: a/b/file.cpp", reason)
+}
+
+func TestVerify_OwnersVerifier_PerFile_MatchingFilesOwnedByPerFileOwners(t *testing.T) {
+	ci := getOwnersTestChange("gn-owner@google.com")
+	ov := setupOwnersVerifier(t, ci, []string{"a/BUILD.gn", "a/file.cpp"}, map[string]string{
+		"OWNERS":   "root@google.com",
+		"a/OWNERS": "a-owner@google.com\nper-file *.gn,*.gni=gn-owner@google.com",
+	})
+
+	state, reason, err := ov.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierFailureState, state)
+	require.Equal(t, "Missing approval from an owner of the following directories:\na/OWNERS (owners: a-owner@google.com, root@google.com). Files: a/file.cpp", reason)
+}
+
+func TestVerify_OwnersVerifier_PerFileNoParent_DirectoryOwnersExcluded(t *testing.T) {
+	ci := getOwnersTestChange("a-owner@google.com")
+	ov := setupOwnersVerifier(t, ci, []string{"a/secret.json"}, map[string]string{
+		"a/OWNERS": "a-owner@google.com\nper-file secret.json=set noparent\nper-file secret.json=security@google.com",
+	})
+
+	state, reason, err := ov.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierFailureState, state)
+	require.Equal(t, "Missing approval from an owner of the following directories:\na/OWNERS (owners: security@google.com). Files: a/secret.json", reason)
+}
+
+func TestVerify_OwnersVerifier_MissingApprovals_ListsAllDirectories(t *testing.T) {
+	ci := getOwnersTestChange()
+	ov := setupOwnersVerifier(t, ci, []string{"c/file.go", "a/b/file.cpp", "README.md"}, map[string]string{
+		"a/OWNERS": "a-owner@google.com",
+		"c/OWNERS": "*",
+	})
+
+	state, reason, err := ov.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierFailureState, state)
+	require.Equal(t, "Missing approval from an owner of the following directories:\nOWNERS has no owners. Files: README.md\na/OWNERS (owners: a-owner@google.com). Files: a/b/file.cpp\nc/OWNERS (owners: *). Files: c/file.go", reason)
+}
+
+func TestVerify_OwnersVerifier_ChangeOwnerIsOwner_AnyApprovalIsEnough(t *testing.T) {
+	ci := getOwnersTestChange("reviewer@google.com")
+	ci.Labels[gerrit.LabelCodeReview].All = append(ci.Labels[gerrit.LabelCodeReview].All, &gerrit.LabelDetail{Email: testChangeOwner, Value: gerrit.LabelCodeReviewSelfApprove})
+	ov := setupOwnersVerifier(t, ci, []string{"a/file.cpp"}, map[string]string{
+		"a/OWNERS": testChangeOwner,
+	})
+
+	state, _, err := ov.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierSuccessState, state)
+}
+
+func TestVerify_OwnersVerifier_ChangeOwnerSelfApproval_NotEnough(t *testing.T) {
+	ci := getOwnersTestChange()
+	ci.Labels[gerrit.LabelCodeReview].All = append(ci.Labels[gerrit.LabelCodeReview].All, &gerrit.LabelDetail{Email: testChangeOwner, Value: gerrit.LabelCodeReviewSelfApprove})
+	ov := setupOwnersVerifier(t, ci, []string{"a/file.cpp"}, map[string]string{
+		"a/OWNERS": testChangeOwner,
+	})
+
+	state, _, err := ov.Verify(context.Background(), ci, currentTime.Unix())
+	require.NoError(t, err)
+	require.Equal(t, types.VerifierFailureState, state)
+}
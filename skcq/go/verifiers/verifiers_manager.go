@@ -13,6 +13,7 @@ import (
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/skcq/go/codereview"
 	"go.skia.org/infra/skcq/go/config"
+	"go.skia.org/infra/skcq/go/db"
 	"go.skia.org/infra/skcq/go/types"
 )
 
@@ -27,6 +28,7 @@ type SkCQVerifiersManager struct {
 	httpClient            *http.Client
 	criaClient            *http.Client
 	cr                    codereview.CodeReview
+	dbClient              db.DB
 	canModifyCfgsOnTheFly allowed.Allow
 	// Allow lists will be cached here so that they are not continuously
 	// newly instantiated.
@@ -34,12 +36,13 @@ type SkCQVerifiersManager struct {
 }
 
 // NewSkCQVerifiersManager returns an instance of SkCQVerifiersManager.
-func NewSkCQVerifiersManager(throttlerManager types.ThrottlerManager, httpClient, criaClient *http.Client, cr codereview.CodeReview, canModifyCfgsOnTheFly allowed.Allow) *SkCQVerifiersManager {
+func NewSkCQVerifiersManager(throttlerManager types.ThrottlerManager, httpClient, criaClient *http.Client, cr codereview.CodeReview, dbClient db.DB, canModifyCfgsOnTheFly allowed.Allow) *SkCQVerifiersManager {
 	return &SkCQVerifiersManager{
 		throttlerManager: throttlerManager,
 		httpClient:       httpClient,
 		criaClient:       criaClient,
 		cr:               cr,
+		dbClient:         dbClient,
 		allowlistCache:   map[string]allowed.Allow{},
 	}
 }
@@ -113,6 +116,15 @@ func (vm *SkCQVerifiersManager) GetVerifiers(ctx context.Context, cfg *config.Sk
 		}
 		clVerifiers = append(clVerifiers, submittableVerifier)
 
+		if cfg.EnforceOwners {
+			// Verify that all modified files have been approved by their owners.
+			ownersVerifier, err := NewOwnersVerifier(vm.cr, configReader)
+			if err != nil {
+				return nil, nil, skerr.Wrapf(err, "Error when creating OwnersVerifier")
+			}
+			clVerifiers = append(clVerifiers, ownersVerifier)
+		}
+
 		if cfg.TreeStatusURL != "" {
 			// Verify that the tree is open.
 			treeStatusVerifier, err := NewTreeStatusVerifier(vm.httpClient, cfg.TreeStatusURL, footersMap)
@@ -133,17 +145,36 @@ func (vm *SkCQVerifiersManager) GetVerifiers(ctx context.Context, cfg *config.Sk
 
 	// Verifiers common to both dry runs and CQ.
 
-	if cfg.TasksJSONPath != "" {
+	if crc, ok := vm.cr.(codereview.CheckRunsCodeReview); ok {
+		// Try jobs of this code review system are reported as check runs on
+		// the change. Verify that they succeeded.
+		checkRunsVerifier, err := NewCheckRunsVerifier(crc, cfg.RequiredCheckRuns)
+		if err != nil {
+			return nil, nil, skerr.Wrapf(err, "Error when creating CheckRunsVerifier")
+		}
+		clVerifiers = append(clVerifiers, checkRunsVerifier)
+	} else if cfg.TasksJSONPath != "" {
 		// Verify that try jobs ran.
 		tasksCfg, err := configReader.GetTasksCfg(ctx, cfg.TasksJSONPath)
 		if err != nil {
 			return nil, nil, skerr.Wrapf(err, "Error getting tasks cfg")
 		}
-		tryJobsVerifier, err := NewTryJobsVerifier(vm.httpClient, vm.cr, tasksCfg, footersMap, cfg.VisibilityType)
-		if err != nil {
-			return nil, nil, skerr.Wrapf(err, "Error when creating TryJobsVerifier")
+		// CQ runs go through the merge queue if the repo+branch has one. Changes
+		// with submitted together changes cannot be cherry-picked on their own,
+		// so they continue to use the TryJobsVerifier.
+		if cfg.MergeQueueCfg != nil && !isSubmittedTogetherChange && vm.cr.IsCQ(ctx, ci) && len(togetherChanges) == 0 {
+			mergeQueueVerifier, err := NewMergeQueueVerifier(vm.httpClient, vm.cr, vm.dbClient, cfg.MergeQueueCfg, tasksCfg, cfg.VisibilityType)
+			if err != nil {
+				return nil, nil, skerr.Wrapf(err, "Error when creating MergeQueueVerifier")
+			}
+			clVerifiers = append(clVerifiers, mergeQueueVerifier)
+		} else {
+			tryJobsVerifier, err := NewTryJobsVerifier(vm.httpClient, vm.cr, tasksCfg, footersMap, cfg.VisibilityType)
+			if err != nil {
+				return nil, nil, skerr.Wrapf(err, "Error when creating TryJobsVerifier")
+			}
+			clVerifiers = append(clVerifiers, tryJobsVerifier)
 		}
-		clVerifiers = append(clVerifiers, tryJobsVerifier)
 	}
 
 	if cfg.AuthorsPath != "" {
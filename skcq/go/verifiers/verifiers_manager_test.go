@@ -137,6 +137,76 @@ func TestGetVerifier_CQAndDryRun(t *testing.T) {
 	testGetVerifier(t, true, true, nil, expectedVerifiers)
 }
 
+func TestGetVerifier_CQ_MergeQueue_TryJobsReplacedByMergeQueue(t *testing.T) {
+	allowListName := "test-cria-committers"
+	cfg := &config.SkCQCfg{
+		TasksJSONPath: "infra/bots/tasks.json",
+		CommitterList: allowListName,
+		MergeQueueCfg: &config.MergeQueueCfg{},
+	}
+	ci := &gerrit.ChangeInfo{Issue: int64(123)}
+
+	mockClient := mockhttpclient.NewURLMock()
+	mockClient.Mock(fmt.Sprintf(allowed.GROUP_URL_TEMPLATE, allowListName), mockhttpclient.MockGetDialogue([]byte("{}")))
+	cr := &cr_mocks.CodeReview{}
+	cfgReader := &cfg_mocks.ConfigReader{}
+	cr.On("GetCommitMessage", testutils.AnyContext, ci.Issue).Return("Test commit message", nil).Once()
+	cr.On("IsCQ", testutils.AnyContext, ci).Return(true).Twice()
+	cr.On("GetSubmittedTogether", testutils.AnyContext, ci).Return(nil, nil).Once()
+	cr.On("Url", int64(0)).Return("skia-review.googlesource.com").Once()
+	cfgReader.On("GetTasksCfg", testutils.AnyContext, cfg.TasksJSONPath).Return(nil, nil).Once()
+
+	vm := &SkCQVerifiersManager{
+		httpClient:     mockClient.Client(),
+		criaClient:     mockClient.Client(),
+		cr:             cr,
+		allowlistCache: map[string]allowed.Allow{},
+	}
+	verifiers, _, err := vm.GetVerifiers(context.Background(), cfg, ci, false, cfgReader)
+	require.NoError(t, err)
+	expectedVerifiers := []string{"CommitFooterVerifier", "WIPVerifier", "SubmittableVerifier", "ThrottlerVerifier", "MergeQueueVerifier"}
+	require.Len(t, verifiers, len(expectedVerifiers))
+	for i, name := range expectedVerifiers {
+		require.Equal(t, name, verifiers[i].Name())
+	}
+}
+
+// checkRunsCodeReview is a CodeReview which reports try jobs as check runs.
+type checkRunsCodeReview struct {
+	*cr_mocks.CodeReview
+	*cr_mocks.CheckRunsCodeReview
+}
+
+func TestGetVerifier_CQ_CheckRunsCodeReview_TryJobsReplacedByCheckRuns(t *testing.T) {
+	allowListName := "test-cria-committers"
+	cfg := &config.SkCQCfg{
+		TasksJSONPath: "infra/bots/tasks.json",
+		CommitterList: allowListName,
+	}
+	ci := &gerrit.ChangeInfo{Issue: int64(123)}
+
+	mockClient := mockhttpclient.NewURLMock()
+	mockClient.Mock(fmt.Sprintf(allowed.GROUP_URL_TEMPLATE, allowListName), mockhttpclient.MockGetDialogue([]byte("{}")))
+	cr := cr_mocks.NewCodeReview(t)
+	cr.On("GetCommitMessage", testutils.AnyContext, ci.Issue).Return("Test commit message", nil).Once()
+	cr.On("IsCQ", testutils.AnyContext, ci).Return(true).Once()
+	cr.On("GetSubmittedTogether", testutils.AnyContext, ci).Return(nil, nil).Once()
+
+	vm := &SkCQVerifiersManager{
+		httpClient:     mockClient.Client(),
+		criaClient:     mockClient.Client(),
+		cr:             checkRunsCodeReview{cr, cr_mocks.NewCheckRunsCodeReview(t)},
+		allowlistCache: map[string]allowed.Allow{},
+	}
+	verifiers, _, err := vm.GetVerifiers(context.Background(), cfg, ci, false, cfg_mocks.NewConfigReader(t))
+	require.NoError(t, err)
+	expectedVerifiers := []string{"CommitFooterVerifier", "WIPVerifier", "SubmittableVerifier", "ThrottlerVerifier", "CheckRunsVerifier"}
+	require.Len(t, verifiers, len(expectedVerifiers))
+	for i, name := range expectedVerifiers {
+		require.Equal(t, name, verifiers[i].Name())
+	}
+}
+
 func TestRunVerifiers(t *testing.T) {
 
 	ci := &gerrit.ChangeInfo{Issue: int64(123)}
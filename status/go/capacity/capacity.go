@@ -36,6 +36,10 @@ type CapacityClient interface {
 
 	// Returns the most recent capacity metrics. Keyed by stringified dimensions.
 	CapacityMetrics() map[string]BotConfig
+
+	// Forecast returns the forecasted utilization of each bot config, based
+	// on the most recent capacity metrics and commit rates.
+	Forecast(params ForecastParams) []*Forecast
 }
 
 type CapacityClientImpl struct {
@@ -44,6 +48,8 @@ type CapacityClientImpl struct {
 	repos repograph.Map
 	// The cached measurements
 	lastMeasurements map[string]BotConfig
+	lastCommitRates  map[string]CommitRate
+	lastCQRates      map[string]float64
 	mtx              sync.Mutex
 }
 
@@ -59,6 +65,7 @@ type taskData struct {
 
 type TaskDuration struct {
 	Name            string        `json:"task_name"`
+	Repo            string        `json:"repo"`
 	AverageDuration time.Duration `json:"task_duration_ns"`
 	OnCQ            bool          `json:"on_cq_also"`
 }
@@ -221,6 +228,7 @@ func (c *CapacityClientImpl) computeBotConfigs(ctx context.Context, durations ma
 			}
 			config.TaskAverageDurations = append(config.TaskAverageDurations, TaskDuration{
 				Name:            taskName,
+				Repo:            repo,
 				AverageDuration: avgDuration,
 				OnCQ:            util.In(taskName, cqTasks),
 			})
@@ -327,10 +335,53 @@ func (c *CapacityClientImpl) QueryAll(ctx context.Context) error {
 	// CPU type. I expect that to be a rare case.)
 	mergeBotConfigs(botConfigs)
 
+	// Failing to compute the forecast inputs shouldn't prevent us from
+	// reporting the current measurements; keep the previous inputs instead.
+	commitRates, err := c.getCommitRates()
+	if err != nil {
+		sklog.Errorf("Failed to compute commit rates: %s", err)
+	}
+	cqRates, err := c.getCQRates()
+	if err != nil {
+		sklog.Errorf("Failed to compute CQ rates: %s", err)
+	}
+
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
 	c.lastMeasurements = botConfigs
-	return err
+	if commitRates != nil {
+		c.lastCommitRates = commitRates
+	}
+	if cqRates != nil {
+		c.lastCQRates = cqRates
+	}
+	return nil
+}
+
+// getCommitRates computes the CommitRate of each repo over the last
+// commitHistoryDays.
+func (c *CapacityClientImpl) getCommitRates() (map[string]CommitRate, error) {
+	now := time.Now()
+	rv := make(map[string]CommitRate, len(c.repos))
+	for repo, graph := range c.repos {
+		commits, err := graph.GetCommitsNewerThan(now.Add(-commitHistoryDays * day))
+		if err != nil {
+			return nil, skerr.Wrapf(err, "failed to retrieve commits for %s", repo)
+		}
+		rv[repo] = computeCommitRate(commits, now, commitHistoryDays)
+	}
+	return rv, nil
+}
+
+// getCQRates computes the number of CQ runs per day in each repo over the last
+// cqHistoryDays.
+func (c *CapacityClientImpl) getCQRates() (map[string]float64, error) {
+	now := time.Now()
+	tasks, err := c.tasks.GetTasksFromDateRange(now.Add(-cqHistoryDays*day), now)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to retrieve tasks")
+	}
+	return computeCQRates(tasks, cqHistoryDays), nil
 }
 
 // StartLoading implements CapacityClient.
@@ -344,6 +395,13 @@ func (c *CapacityClientImpl) StartLoading(ctx context.Context, interval time.Dur
 	}()
 }
 
+// Forecast implements CapacityClient.
+func (c *CapacityClientImpl) Forecast(params ForecastParams) []*Forecast {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return computeForecasts(c.lastMeasurements, c.lastCommitRates, c.lastCQRates, params)
+}
+
 // CapacityMetrics implements CapacityClient.
 func (c *CapacityClientImpl) CapacityMetrics() map[string]BotConfig {
 	c.mtx.Lock()
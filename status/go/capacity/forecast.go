@@ -0,0 +1,220 @@
+package capacity
+
+// This file forecasts future bot utilization from the capacity metrics and the
+// history of commits landing in each repo, and recommends how many bots of each
+// config are needed to keep up.
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.skia.org/infra/go/vcsinfo"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// commitHistoryDays is the number of days of commit history used to
+	// forecast commit rates.
+	commitHistoryDays = 28
+
+	// cqHistoryDays is the number of days of try jobs used to measure the
+	// number of CQ runs per day. This matches the period over which task
+	// durations are measured.
+	cqHistoryDays = 3
+
+	// Defaults for ForecastParams.
+	defaultHorizonDays       = 30
+	defaultTargetTestedness  = 1.0
+	defaultTargetUtilization = 0.8
+
+	day = 24 * time.Hour
+)
+
+// CommitRate describes the rate at which commits land in a repo.
+type CommitRate struct {
+	// Daily is the number of commits which landed on each day, oldest first.
+	Daily []int `json:"daily"`
+	// PerDay is the average number of commits per day.
+	PerDay float64 `json:"per_day"`
+	// Slope is the daily change in commits per day, from a least-squares
+	// linear fit of Daily.
+	Slope float64 `json:"slope"`
+}
+
+// At returns the forecasted number of commits per day, the given number of
+// days after the end of the history. The forecast is never negative.
+func (r CommitRate) At(days float64) float64 {
+	// The fitted line passes through the mean at the center of the history.
+	center := float64(len(r.Daily)-1) / 2.0
+	end := float64(len(r.Daily) - 1)
+	return math.Max(0.0, r.PerDay+r.Slope*(end-center+days))
+}
+
+// computeCommitRate computes the CommitRate from the given commits over the
+// given number of days ending at now.
+func computeCommitRate(commits []*vcsinfo.LongCommit, now time.Time, days int) CommitRate {
+	daily := make([]int, days)
+	start := now.Add(-time.Duration(days) * day)
+	for _, c := range commits {
+		if c.Timestamp.Before(start) || !c.Timestamp.Before(now) {
+			continue
+		}
+		daily[int(c.Timestamp.Sub(start)/day)]++
+	}
+	rv := CommitRate{Daily: daily}
+	if days == 0 {
+		return rv
+	}
+	// Least-squares fit of commits per day against day index.
+	var sumY float64
+	for _, n := range daily {
+		sumY += float64(n)
+	}
+	meanX := float64(days-1) / 2.0
+	rv.PerDay = sumY / float64(days)
+	var num, den float64
+	for i, n := range daily {
+		dx := float64(i) - meanX
+		num += dx * (float64(n) - rv.PerDay)
+		den += dx * dx
+	}
+	if den > 0 {
+		rv.Slope = num / den
+	}
+	return rv
+}
+
+// computeCQRates computes the number of CQ runs per day in each repo from the
+// given tasks, which were created over the given number of days. Each distinct
+// patchset on which try jobs ran counts as one CQ run.
+func computeCQRates(tasks []*types.Task, days float64) map[string]float64 {
+	patchsets := map[string]map[types.RepoState]bool{}
+	for _, t := range tasks {
+		if !t.IsTryJob() {
+			continue
+		}
+		rs := t.RepoState
+		// Multiple revisions may be tested for the same patchset.
+		rs.Revision = ""
+		if patchsets[t.Repo] == nil {
+			patchsets[t.Repo] = map[types.RepoState]bool{}
+		}
+		patchsets[t.Repo][rs] = true
+	}
+	rv := make(map[string]float64, len(patchsets))
+	if days <= 0 {
+		return rv
+	}
+	for repo, ps := range patchsets {
+		rv[repo] = float64(len(ps)) / days
+	}
+	return rv
+}
+
+// ForecastParams are the parameters used to forecast capacity needs. Zero
+// values are replaced with defaults.
+type ForecastParams struct {
+	// HorizonDays is how far in the future to forecast.
+	HorizonDays int
+	// TargetTestedness is the fraction of commits at which each task should
+	// run.
+	TargetTestedness float64
+	// TargetUtilization is the maximum fraction of each bot's time which
+	// should be spent running tasks.
+	TargetUtilization float64
+}
+
+// withDefaults returns a copy of the ForecastParams with defaults filled in.
+func (p ForecastParams) withDefaults() ForecastParams {
+	if p.HorizonDays <= 0 {
+		p.HorizonDays = defaultHorizonDays
+	}
+	if p.TargetTestedness <= 0 {
+		p.TargetTestedness = defaultTargetTestedness
+	}
+	if p.TargetUtilization <= 0 {
+		p.TargetUtilization = defaultTargetUtilization
+	}
+	return p
+}
+
+// Forecast describes the current and forecasted utilization of one bot config
+// and the number of bots needed to keep up.
+type Forecast struct {
+	Dimensions []string `json:"dimensions"`
+	BotCount   int      `json:"bot_count"`
+	// CurrentDemand and ForecastDemand are the amounts of bot time needed
+	// per day at the current and forecasted commit rates.
+	CurrentDemand  time.Duration `json:"current_demand_ns"`
+	ForecastDemand time.Duration `json:"forecast_demand_ns"`
+	// CurrentUtilization and ForecastUtilization are the fractions of the
+	// existing bots' time needed to meet the demand. Values greater than
+	// 1.0 indicate that the bots cannot keep up.
+	CurrentUtilization  float64 `json:"current_utilization"`
+	ForecastUtilization float64 `json:"forecast_utilization"`
+	// BotsNeeded is the number of bots needed to meet the forecasted demand
+	// at the target utilization.
+	BotsNeeded int `json:"bots_needed"`
+	// BotsToBuy is the number of bots needed in addition to the existing
+	// bots.
+	BotsToBuy int `json:"bots_to_buy"`
+}
+
+// computeForecasts forecasts the utilization of each BotConfig using the
+// given commit rates and CQ runs per day. Tasks run at the target testedness on
+// every commit to their repo, and tasks which are on the CQ also run on every
+// CQ run. The number of CQ runs is assumed to grow with the commit rate.
+// Results are sorted by decreasing BotsToBuy, then by decreasing
+// ForecastUtilization.
+func computeForecasts(configs map[string]BotConfig, rates map[string]CommitRate, cqRates map[string]float64, params ForecastParams) []*Forecast {
+	params = params.withDefaults()
+	horizon := float64(params.HorizonDays)
+	rv := make([]*Forecast, 0, len(configs))
+	for _, config := range configs {
+		var current, forecast float64
+		for _, task := range config.TaskAverageDurations {
+			rate, ok := rates[task.Repo]
+			if ok {
+				dur := float64(task.AverageDuration) * params.TargetTestedness
+				current += dur * rate.PerDay
+				forecast += dur * rate.At(horizon)
+			}
+			if cq := cqRates[task.Repo]; task.OnCQ && cq > 0 {
+				forecastCQ := cq
+				if ok && rate.PerDay > 0 {
+					forecastCQ = cq * rate.At(horizon) / rate.PerDay
+				}
+				current += float64(task.AverageDuration) * cq
+				forecast += float64(task.AverageDuration) * forecastCQ
+			}
+		}
+		f := &Forecast{
+			Dimensions:     config.Dimensions,
+			BotCount:       len(config.Bots),
+			CurrentDemand:  time.Duration(current),
+			ForecastDemand: time.Duration(forecast),
+			BotsNeeded:     int(math.Ceil(forecast / (float64(day) * params.TargetUtilization))),
+		}
+		if f.BotCount > 0 {
+			available := float64(f.BotCount) * float64(day)
+			f.CurrentUtilization = current / available
+			f.ForecastUtilization = forecast / available
+		}
+		if f.BotsNeeded > f.BotCount {
+			f.BotsToBuy = f.BotsNeeded - f.BotCount
+		}
+		rv = append(rv, f)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		if rv[i].BotsToBuy != rv[j].BotsToBuy {
+			return rv[i].BotsToBuy > rv[j].BotsToBuy
+		}
+		if rv[i].ForecastUtilization != rv[j].ForecastUtilization {
+			return rv[i].ForecastUtilization > rv[j].ForecastUtilization
+		}
+		return strings.Join(rv[i].Dimensions, "|") < strings.Join(rv[j].Dimensions, "|")
+	})
+	return rv
+}
@@ -0,0 +1,165 @@
+package capacity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/vcsinfo"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+func makeCommits(now time.Time, perDay []int) []*vcsinfo.LongCommit {
+	var rv []*vcsinfo.LongCommit
+	start := now.Add(-time.Duration(len(perDay)) * day)
+	for i, n := range perDay {
+		for j := 0; j < n; j++ {
+			rv = append(rv, &vcsinfo.LongCommit{
+				ShortCommit: &vcsinfo.ShortCommit{},
+				Timestamp:   start.Add(time.Duration(i)*day + time.Duration(j)*time.Minute),
+			})
+		}
+	}
+	return rv
+}
+
+func TestComputeCommitRate_IncreasingRate_PositiveSlope(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	commits := makeCommits(now, []int{2, 4, 6, 8})
+	// Commits outside of the history are ignored.
+	commits = append(commits, &vcsinfo.LongCommit{
+		ShortCommit: &vcsinfo.ShortCommit{},
+		Timestamp:   now.Add(-10 * day),
+	})
+	rate := computeCommitRate(commits, now, 4)
+	require.Equal(t, []int{2, 4, 6, 8}, rate.Daily)
+	require.InDelta(t, 5.0, rate.PerDay, 0.0001)
+	require.InDelta(t, 2.0, rate.Slope, 0.0001)
+	require.InDelta(t, 8.0, rate.At(0), 0.0001)
+	require.InDelta(t, 28.0, rate.At(10), 0.0001)
+
+	// Forecasts are never negative.
+	rate = computeCommitRate(makeCommits(now, []int{8, 6, 4, 2}), now, 4)
+	require.Equal(t, 0.0, rate.At(10))
+}
+
+func TestComputeForecasts_OverloadedConfig_RecommendsBots(t *testing.T) {
+	configs := map[string]BotConfig{
+		"busy": {
+			Dimensions: []string{"os:Android"},
+			Bots:       map[string]bool{"bot1": true},
+			TaskAverageDurations: []TaskDuration{
+				{Name: "Test", Repo: "skia.git", AverageDuration: 2 * time.Hour},
+			},
+		},
+		"idle": {
+			Dimensions: []string{"os:Linux"},
+			Bots:       map[string]bool{"bot2": true, "bot3": true},
+			TaskAverageDurations: []TaskDuration{
+				{Name: "Build", Repo: "skia.git", AverageDuration: time.Hour},
+				// Repos without a commit rate do not contribute.
+				{Name: "Build", Repo: "other.git", AverageDuration: time.Hour},
+			},
+		},
+	}
+	rates := map[string]CommitRate{
+		// A constant 12 commits per day.
+		"skia.git": {Daily: []int{12, 12}, PerDay: 12},
+	}
+	forecasts := computeForecasts(configs, rates, nil, ForecastParams{
+		TargetTestedness:  0.5,
+		TargetUtilization: 0.5,
+	})
+	require.Equal(t, []*Forecast{
+		{
+			Dimensions:          []string{"os:Android"},
+			BotCount:            1,
+			CurrentDemand:       12 * time.Hour,
+			ForecastDemand:      12 * time.Hour,
+			CurrentUtilization:  0.5,
+			ForecastUtilization: 0.5,
+			BotsNeeded:          1,
+		},
+		{
+			Dimensions:          []string{"os:Linux"},
+			BotCount:            2,
+			CurrentDemand:       6 * time.Hour,
+			ForecastDemand:      6 * time.Hour,
+			CurrentUtilization:  0.125,
+			ForecastUtilization: 0.125,
+			BotsNeeded:          1,
+		},
+	}, forecasts)
+
+	// Requiring full testedness doubles the demand.
+	forecasts = computeForecasts(configs, rates, nil, ForecastParams{TargetUtilization: 0.5})
+	require.Equal(t, 2, forecasts[0].BotsNeeded)
+	require.Equal(t, 1, forecasts[0].BotsToBuy)
+	require.Equal(t, []string{"os:Android"}, forecasts[0].Dimensions)
+}
+
+func TestComputeCQRates_TryJobs_CountsDistinctPatchsets(t *testing.T) {
+	tryJob := func(repo, patchset, revision string) *types.Task {
+		return &types.Task{
+			TaskKey: types.TaskKey{
+				RepoState: types.RepoState{
+					Patch: types.Patch{
+						Issue:    "123",
+						Patchset: patchset,
+						Server:   "https://skia-review.googlesource.com",
+					},
+					Repo:     repo,
+					Revision: revision,
+				},
+			},
+		}
+	}
+	tasks := []*types.Task{
+		tryJob("skia.git", "1", "abc"),
+		// Multiple tasks and revisions for the same patchset count once.
+		tryJob("skia.git", "1", "abc"),
+		tryJob("skia.git", "1", "def"),
+		tryJob("skia.git", "2", "abc"),
+		tryJob("other.git", "1", "abc"),
+		// Tasks at commits are not CQ runs.
+		{TaskKey: types.TaskKey{RepoState: types.RepoState{Repo: "skia.git", Revision: "abc"}}},
+	}
+	require.Equal(t, map[string]float64{
+		"skia.git":  1.0,
+		"other.git": 0.5,
+	}, computeCQRates(tasks, 2))
+}
+
+func TestComputeForecasts_TasksOnCQ_IncludesCQLoad(t *testing.T) {
+	configs := map[string]BotConfig{
+		"linux": {
+			Dimensions: []string{"os:Linux"},
+			Bots:       map[string]bool{"bot1": true},
+			TaskAverageDurations: []TaskDuration{
+				{Name: "Build", Repo: "skia.git", AverageDuration: time.Hour, OnCQ: true},
+				{Name: "Perf", Repo: "skia.git", AverageDuration: time.Hour},
+				// CQ load is included even without a commit rate.
+				{Name: "Build", Repo: "other.git", AverageDuration: time.Hour, OnCQ: true},
+			},
+		},
+	}
+	rates := map[string]CommitRate{
+		// 4 commits per day, doubling over the next 10 days.
+		"skia.git": {Daily: []int{4}, PerDay: 4, Slope: 0.4},
+	}
+	cqRates := map[string]float64{
+		"skia.git":  6,
+		"other.git": 2,
+	}
+	forecasts := computeForecasts(configs, rates, cqRates, ForecastParams{
+		HorizonDays:       10,
+		TargetUtilization: 0.5,
+	})
+	require.Len(t, forecasts, 1)
+	// Commits: 2 tasks * 4 commits; CQ: 6 runs + 2 runs.
+	require.Equal(t, 16*time.Hour, forecasts[0].CurrentDemand)
+	// Commits: 2 tasks * 8 commits; CQ: 12 runs + 2 runs.
+	require.Equal(t, 30*time.Hour, forecasts[0].ForecastDemand)
+	require.Equal(t, 3, forecasts[0].BotsNeeded)
+	require.Equal(t, 2, forecasts[0].BotsToBuy)
+}
@@ -0,0 +1,392 @@
+package lkgr
+
+// This file provides a configurable LKGR engine which computes the last known
+// good revision of any repo from Task Scheduler results.
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/go/git"
+	"go.skia.org/infra/go/git/repograph"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/db"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+// Policy determines which revisions are considered good.
+type Policy string
+
+const (
+	// PolicyAllGreen requires every job in the Config to have succeeded.
+	PolicyAllGreen Policy = "all_green"
+	// PolicyNOfM requires at least MinSuccesses of the jobs in the Config
+	// to have succeeded.
+	PolicyNOfM Policy = "n_of_m"
+
+	// DefaultMaxCommits is the number of commits searched for a good
+	// revision if the Config does not specify a number.
+	DefaultMaxCommits = 100
+)
+
+// Config describes how to compute the LKGR for one repo.
+type Config struct {
+	// Name identifies the LKGR.
+	Name string `json:"name"`
+	// Repo is the URL of the repo.
+	Repo string `json:"repo"`
+	// Branch is the branch whose first-parent history is searched. Defaults
+	// to git.MainBranch.
+	Branch string `json:"branch,omitempty"`
+	// Jobs are the names of the jobs which determine whether a revision is
+	// good.
+	Jobs []string `json:"jobs"`
+	// Policy determines which revisions are considered good.
+	Policy Policy `json:"policy"`
+	// MinSuccesses is the number of jobs which must succeed under
+	// PolicyNOfM.
+	MinSuccesses int `json:"min_successes,omitempty"`
+	// IgnoreFlakes indicates that failed jobs should count as successful if
+	// all of their failed tasks are marked as flaky on the status page.
+	IgnoreFlakes bool `json:"ignore_flakes,omitempty"`
+	// MaxCommits is the number of commits searched for a good revision.
+	MaxCommits int `json:"max_commits,omitempty"`
+	// Ref, if set, is the git ref which is updated to point to the LKGR,
+	// eg. "refs/heads/lkgr".
+	Ref string `json:"ref,omitempty"`
+}
+
+// Validate returns an error if the Config is not valid.
+func (c *Config) Validate() error {
+	if c.Name == "" {
+		return skerr.Fmt("name is required")
+	}
+	if c.Repo == "" {
+		return skerr.Fmt("repo is required for %q", c.Name)
+	}
+	if len(c.Jobs) == 0 {
+		return skerr.Fmt("at least one job is required for %q", c.Name)
+	}
+	switch c.Policy {
+	case PolicyAllGreen:
+	case PolicyNOfM:
+		if c.MinSuccesses < 1 || c.MinSuccesses > len(c.Jobs) {
+			return skerr.Fmt("invalid min_successes %d for %q; must be between 1 and %d", c.MinSuccesses, c.Name, len(c.Jobs))
+		}
+	default:
+		return skerr.Fmt("unknown policy %q for %q", c.Policy, c.Name)
+	}
+	if c.MaxCommits < 0 {
+		return skerr.Fmt("invalid max_commits %d for %q", c.MaxCommits, c.Name)
+	}
+	return nil
+}
+
+// ParseConfigs parses and validates a list of Configs from the given JSON
+// contents.
+func ParseConfigs(contents []byte) ([]*Config, error) {
+	var rv []*Config
+	if err := json.Unmarshal(contents, &rv); err != nil {
+		return nil, skerr.Wrapf(err, "failed to parse LKGR configs")
+	}
+	names := util.StringSet{}
+	for _, c := range rv {
+		if err := c.Validate(); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		if names[c.Name] {
+			return nil, skerr.Fmt("duplicate LKGR name %q", c.Name)
+		}
+		names[c.Name] = true
+	}
+	return rv, nil
+}
+
+// ReadConfigs reads, parses, and validates a list of Configs from the given
+// file.
+func ReadConfigs(path string) ([]*Config, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to read LKGR configs")
+	}
+	return ParseConfigs(contents)
+}
+
+// Result is a computed LKGR.
+type Result struct {
+	Name     string    `json:"name"`
+	Repo     string    `json:"repo"`
+	Revision string    `json:"revision"`
+	Updated  time.Time `json:"updated"`
+}
+
+// RefPusher updates a git ref to point to a revision.
+type RefPusher interface {
+	PushRef(ctx context.Context, repo, revision, ref string) error
+}
+
+// gitRefPusher is a RefPusher which uses local bare clones of each repo.
+type gitRefPusher struct {
+	workdir string
+
+	// checkoutMtxs serializes pushes to each checkout, since multiple
+	// Engines may push to the same repo concurrently.
+	mtx          sync.Mutex
+	checkoutMtxs map[string]*sync.Mutex
+}
+
+// NewGitRefPusher returns a RefPusher which pushes from local clones of each
+// repo kept in the given working directory.
+func NewGitRefPusher(workdir string) RefPusher {
+	return &gitRefPusher{
+		workdir:      workdir,
+		checkoutMtxs: map[string]*sync.Mutex{},
+	}
+}
+
+// checkoutDir returns the directory of the local clone of the given repo.
+// Repos on different hosts may share a base name, so the directory is keyed
+// on a hash of the full URL.
+func (p *gitRefPusher) checkoutDir(repo string) string {
+	hash := sha256.Sum256([]byte(repo))
+	return filepath.Join(p.workdir, filepath.Base(repo)+"-"+hex.EncodeToString(hash[:]))
+}
+
+// lockCheckout locks the given checkout directory and returns a func which
+// unlocks it.
+func (p *gitRefPusher) lockCheckout(dir string) func() {
+	p.mtx.Lock()
+	m, ok := p.checkoutMtxs[dir]
+	if !ok {
+		m = &sync.Mutex{}
+		p.checkoutMtxs[dir] = m
+	}
+	p.mtx.Unlock()
+	m.Lock()
+	return m.Unlock
+}
+
+// PushRef implements RefPusher.
+func (p *gitRefPusher) PushRef(ctx context.Context, repo, revision, ref string) error {
+	dir := p.checkoutDir(repo)
+	defer p.lockCheckout(dir)()
+	r, err := git.NewRepo(ctx, repo, dir)
+	if err != nil {
+		return skerr.Wrapf(err, "failed to create repo %s", repo)
+	}
+	if err := r.Update(ctx); err != nil {
+		return skerr.Wrap(err)
+	}
+	// The LKGR may move backward if the Config changes, so force the push.
+	if _, err := r.Git(ctx, "push", "--force", "origin", revision+":"+ref); err != nil {
+		return skerr.Wrapf(err, "failed to push %s to %s in %s", revision, ref, repo)
+	}
+	return nil
+}
+
+// Engine computes the LKGR for a Config.
+type Engine struct {
+	cfg    *Config
+	db     db.RemoteDB
+	repos  repograph.Map
+	pusher RefPusher
+
+	mtx    sync.RWMutex
+	result *Result
+}
+
+// NewEngine returns an Engine for the given Config. If pusher is nil, the
+// Config's Ref is not updated.
+func NewEngine(cfg *Config, d db.RemoteDB, repos repograph.Map, pusher RefPusher) *Engine {
+	return &Engine{
+		cfg:    cfg,
+		db:     d,
+		repos:  repos,
+		pusher: pusher,
+	}
+}
+
+// Get returns the most recently computed Result, or nil if no good revision
+// has been found.
+func (e *Engine) Get() *Result {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+	return e.result
+}
+
+// Update recomputes the LKGR and pushes the Config's Ref if it has changed.
+// If no good revision is found, the previous Result is retained.
+func (e *Engine) Update(ctx context.Context, now time.Time) error {
+	revision, err := e.compute(ctx, now)
+	if err != nil {
+		return skerr.Wrapf(err, "failed to compute LKGR %q", e.cfg.Name)
+	}
+	if revision == "" {
+		sklog.Warningf("No good revision found for LKGR %q within %d commits", e.cfg.Name, e.maxCommits())
+		return nil
+	}
+	prev := e.Get()
+	if e.pusher != nil && e.cfg.Ref != "" && (prev == nil || prev.Revision != revision) {
+		if err := e.pusher.PushRef(ctx, e.cfg.Repo, revision, e.cfg.Ref); err != nil {
+			return skerr.Wrapf(err, "failed to push LKGR %q", e.cfg.Name)
+		}
+	}
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.result = &Result{
+		Name:     e.cfg.Name,
+		Repo:     e.cfg.Repo,
+		Revision: revision,
+		Updated:  now,
+	}
+	return nil
+}
+
+// UpdateLoop periodically updates the Engine until the given context is
+// canceled.
+func (e *Engine) UpdateLoop(ctx context.Context, freq time.Duration) {
+	lv := metrics2.NewLiveness("last_successful_lkgr_engine_update", map[string]string{"lkgr": e.cfg.Name})
+	go util.RepeatCtx(ctx, freq, func(ctx context.Context) {
+		if err := e.Update(ctx, time.Now()); err != nil {
+			sklog.Errorf("Failed to update LKGR %q: %s", e.cfg.Name, err)
+		} else {
+			lv.Reset()
+		}
+	})
+}
+
+// maxCommits returns the number of commits searched for a good revision.
+func (e *Engine) maxCommits() int {
+	if e.cfg.MaxCommits == 0 {
+		return DefaultMaxCommits
+	}
+	return e.cfg.MaxCommits
+}
+
+// compute returns the most recent good revision on the Config's branch, or
+// the empty string if there is none within the most recent commits.
+func (e *Engine) compute(ctx context.Context, now time.Time) (string, error) {
+	repo, ok := e.repos[e.cfg.Repo]
+	if !ok {
+		return "", skerr.Fmt("unknown repo %s", e.cfg.Repo)
+	}
+	branch := e.cfg.Branch
+	if branch == "" {
+		branch = git.MainBranch
+	}
+	head := repo.Get(branch)
+	if head == nil {
+		return "", skerr.Fmt("unknown branch %s in %s", branch, e.cfg.Repo)
+	}
+	commits := make([]*repograph.Commit, 0, e.maxCommits())
+	if err := head.RecurseFirstParent(func(c *repograph.Commit) error {
+		commits = append(commits, c)
+		if len(commits) >= e.maxCommits() {
+			return repograph.ErrStopRecursing
+		}
+		return nil
+	}); err != nil {
+		return "", skerr.Wrap(err)
+	}
+
+	// Jobs are created no earlier than the commits they run at.
+	start := commits[len(commits)-1].Timestamp
+	jobs, err := e.db.GetJobsFromDateRange(ctx, start, now, e.cfg.Repo)
+	if err != nil {
+		return "", skerr.Wrapf(err, "failed to retrieve jobs")
+	}
+	required := util.NewStringSet(e.cfg.Jobs)
+	// Maps revision to job name to the most recently created job. Jobs are
+	// sorted by creation time, so later jobs replace earlier ones.
+	byRevision := map[string]map[string]*types.Job{}
+	for _, job := range jobs {
+		if job.IsTryJob() || !required[job.Name] {
+			continue
+		}
+		m, ok := byRevision[job.Revision]
+		if !ok {
+			m = map[string]*types.Job{}
+			byRevision[job.Revision] = m
+		}
+		m[job.Name] = job
+	}
+
+	var flaky util.StringSet
+	if e.cfg.IgnoreFlakes {
+		flaky, err = e.flakyTaskSpecs(ctx)
+		if err != nil {
+			return "", skerr.Wrap(err)
+		}
+	}
+	for _, c := range commits {
+		if e.isGood(byRevision[c.Hash], flaky) {
+			return c.Hash, nil
+		}
+	}
+	return "", nil
+}
+
+// flakyTaskSpecs returns the names of the TaskSpecs in the Config's repo which
+// are currently marked as flaky.
+func (e *Engine) flakyTaskSpecs(ctx context.Context) (util.StringSet, error) {
+	comments, err := e.db.GetCommentsForRepos(ctx, []string{e.cfg.Repo}, time.Time{})
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to retrieve comments")
+	}
+	rv := util.StringSet{}
+	for _, rc := range comments {
+		for name, specComments := range rc.TaskSpecComments {
+			for _, c := range specComments {
+				if c.Flaky && (c.Deleted == nil || !*c.Deleted) {
+					rv[name] = true
+				}
+			}
+		}
+	}
+	return rv, nil
+}
+
+// isGood returns true iff the given jobs, keyed by name, satisfy the Config's
+// Policy.
+func (e *Engine) isGood(jobs map[string]*types.Job, flaky util.StringSet) bool {
+	successes := 0
+	for _, name := range e.cfg.Jobs {
+		if job, ok := jobs[name]; ok && jobSucceeded(job, flaky) {
+			successes++
+		}
+	}
+	if e.cfg.Policy == PolicyNOfM {
+		return successes >= e.cfg.MinSuccesses
+	}
+	return successes == len(e.cfg.Jobs)
+}
+
+// jobSucceeded returns true iff the Job succeeded, or if it failed and every
+// failed task belongs to one of the given flaky TaskSpecs.
+func jobSucceeded(job *types.Job, flaky util.StringSet) bool {
+	if job.Status == types.JOB_STATUS_SUCCESS {
+		return true
+	}
+	if job.Status != types.JOB_STATUS_FAILURE || len(flaky) == 0 {
+		return false
+	}
+	for name, tasks := range job.Tasks {
+		if len(tasks) == 0 {
+			continue
+		}
+		latest := tasks[len(tasks)-1]
+		if latest.Status != types.TASK_STATUS_SUCCESS && !flaky[name] {
+			return false
+		}
+	}
+	return true
+}
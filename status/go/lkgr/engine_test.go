@@ -0,0 +1,190 @@
+package lkgr
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/git/repograph"
+	"go.skia.org/infra/go/git/testutils/mem_git"
+	"go.skia.org/infra/go/gitstore"
+	"go.skia.org/infra/go/gitstore/mem_gitstore"
+	"go.skia.org/infra/task_scheduler/go/db/memory"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const fakeRepo = "fake.git"
+
+var commitTime = time.Unix(1600000000, 0)
+
+type fakePusher struct {
+	pushed []string
+}
+
+// PushRef implements RefPusher.
+func (p *fakePusher) PushRef(_ context.Context, repo, revision, ref string) error {
+	p.pushed = append(p.pushed, repo+" "+revision+":"+ref)
+	return nil
+}
+
+// setup returns a DB and a repo containing three commits, oldest first.
+func setup(t *testing.T) (context.Context, *memory.InMemoryDB, repograph.Map, []string) {
+	ctx := context.Background()
+	gs := mem_gitstore.New()
+	mg := mem_git.New(t, gs)
+	ri, err := gitstore.NewGitStoreRepoImpl(ctx, gs)
+	require.NoError(t, err)
+	repo, err := repograph.NewWithRepoImpl(ctx, ri)
+	require.NoError(t, err)
+	mg.AddUpdater(repo)
+	commits := []string{}
+	for i := 0; i < 3; i++ {
+		commits = append(commits, mg.CommitAt("commit", commitTime.Add(time.Duration(i)*time.Hour)))
+	}
+	return ctx, memory.NewInMemoryDB(), repograph.Map{fakeRepo: repo}, commits
+}
+
+func addJob(t *testing.T, ctx context.Context, d *memory.InMemoryDB, revision, name string, status types.JobStatus, tasks map[string][]*types.TaskSummary) {
+	require.NoError(t, d.PutJob(ctx, &types.Job{
+		Created: commitTime.Add(3 * time.Hour),
+		Name:    name,
+		RepoState: types.RepoState{
+			Repo:     fakeRepo,
+			Revision: revision,
+		},
+		Status: status,
+		Tasks:  tasks,
+	}))
+}
+
+func TestParseConfigs_Invalid_ReturnsError(t *testing.T) {
+	test := func(name, contents, expectErr string) {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfigs([]byte(contents))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), expectErr)
+		})
+	}
+	test("no jobs", `[{"name": "a", "repo": "r", "policy": "all_green"}]`, "at least one job")
+	test("bad policy", `[{"name": "a", "repo": "r", "jobs": ["j"], "policy": "most"}]`, "unknown policy")
+	test("bad min", `[{"name": "a", "repo": "r", "jobs": ["j"], "policy": "n_of_m", "min_successes": 2}]`, "invalid min_successes")
+	test("duplicate", `[{"name": "a", "repo": "r", "jobs": ["j"], "policy": "all_green"}, {"name": "a", "repo": "r", "jobs": ["j"], "policy": "all_green"}]`, "duplicate")
+}
+
+func TestEngineUpdate_AllGreen_FindsNewestGoodRevisionAndPushesRef(t *testing.T) {
+	ctx, d, repos, commits := setup(t)
+	addJob(t, ctx, d, commits[0], "Build", types.JOB_STATUS_SUCCESS, nil)
+	addJob(t, ctx, d, commits[0], "Test", types.JOB_STATUS_SUCCESS, nil)
+	addJob(t, ctx, d, commits[1], "Build", types.JOB_STATUS_SUCCESS, nil)
+	addJob(t, ctx, d, commits[1], "Test", types.JOB_STATUS_FAILURE, nil)
+	// The newest commit has not finished testing.
+	addJob(t, ctx, d, commits[2], "Build", types.JOB_STATUS_SUCCESS, nil)
+
+	pusher := &fakePusher{}
+	e := NewEngine(&Config{
+		Name:   "skia",
+		Repo:   fakeRepo,
+		Jobs:   []string{"Build", "Test"},
+		Policy: PolicyAllGreen,
+		Ref:    "refs/heads/lkgr",
+	}, d, repos, pusher)
+	now := commitTime.Add(4 * time.Hour)
+	require.NoError(t, e.Update(ctx, now))
+	require.Equal(t, &Result{Name: "skia", Repo: fakeRepo, Revision: commits[0], Updated: now}, e.Get())
+	require.Equal(t, []string{fakeRepo + " " + commits[0] + ":refs/heads/lkgr"}, pusher.pushed)
+
+	// The ref is only pushed when the LKGR changes.
+	require.NoError(t, e.Update(ctx, now))
+	require.Len(t, pusher.pushed, 1)
+
+	// A later retry of the failed job succeeds.
+	addJob(t, ctx, d, commits[1], "Test", types.JOB_STATUS_SUCCESS, nil)
+	require.NoError(t, e.Update(ctx, now))
+	require.Equal(t, commits[1], e.Get().Revision)
+	require.Len(t, pusher.pushed, 2)
+}
+
+func TestEngineUpdate_NOfM_RequiresMinSuccesses(t *testing.T) {
+	ctx, d, repos, commits := setup(t)
+	addJob(t, ctx, d, commits[1], "A", types.JOB_STATUS_SUCCESS, nil)
+	addJob(t, ctx, d, commits[1], "B", types.JOB_STATUS_SUCCESS, nil)
+	addJob(t, ctx, d, commits[2], "A", types.JOB_STATUS_SUCCESS, nil)
+	addJob(t, ctx, d, commits[2], "B", types.JOB_STATUS_FAILURE, nil)
+	addJob(t, ctx, d, commits[2], "C", types.JOB_STATUS_FAILURE, nil)
+
+	e := NewEngine(&Config{
+		Name:         "skia",
+		Repo:         fakeRepo,
+		Jobs:         []string{"A", "B", "C"},
+		Policy:       PolicyNOfM,
+		MinSuccesses: 2,
+	}, d, repos, nil)
+	require.NoError(t, e.Update(ctx, commitTime.Add(4*time.Hour)))
+	require.Equal(t, commits[1], e.Get().Revision)
+}
+
+func TestEngineUpdate_IgnoreFlakes_FailuresInFlakyTasksAreIgnored(t *testing.T) {
+	ctx, d, repos, commits := setup(t)
+	failedIn := func(name string) map[string][]*types.TaskSummary {
+		return map[string][]*types.TaskSummary{
+			"Compile": {{Status: types.TASK_STATUS_SUCCESS}},
+			name:      {{Status: types.TASK_STATUS_FAILURE}},
+		}
+	}
+	addJob(t, ctx, d, commits[1], "Test", types.JOB_STATUS_FAILURE, failedIn("FlakyTest"))
+	addJob(t, ctx, d, commits[2], "Test", types.JOB_STATUS_FAILURE, failedIn("StableTest"))
+	require.NoError(t, d.PutTaskSpecComment(ctx, &types.TaskSpecComment{
+		Repo:      fakeRepo,
+		Name:      "FlakyTest",
+		Timestamp: commitTime,
+		Flaky:     true,
+	}))
+
+	cfg := &Config{
+		Name:   "skia",
+		Repo:   fakeRepo,
+		Jobs:   []string{"Test"},
+		Policy: PolicyAllGreen,
+	}
+	e := NewEngine(cfg, d, repos, nil)
+	require.NoError(t, e.Update(ctx, commitTime.Add(4*time.Hour)))
+	require.Nil(t, e.Get())
+
+	cfg.IgnoreFlakes = true
+	require.NoError(t, e.Update(ctx, commitTime.Add(4*time.Hour)))
+	require.Equal(t, commits[1], e.Get().Revision)
+}
+
+func TestGitRefPusherCheckoutDir_SameBaseNameDifferentHosts_DistinctDirs(t *testing.T) {
+	p := NewGitRefPusher("/workdir").(*gitRefPusher)
+	a := p.checkoutDir("https://skia.googlesource.com/buildbot.git")
+	b := p.checkoutDir("https://chromium.googlesource.com/buildbot.git")
+	require.NotEqual(t, a, b)
+	require.Equal(t, a, p.checkoutDir("https://skia.googlesource.com/buildbot.git"))
+	require.Equal(t, "/workdir", filepath.Dir(a))
+	require.True(t, strings.HasPrefix(filepath.Base(a), "buildbot.git-"))
+}
+
+func TestGitRefPusherLockCheckout_SameDir_Serialized(t *testing.T) {
+	p := NewGitRefPusher("/workdir").(*gitRefPusher)
+	unlock := p.lockCheckout("a")
+
+	// A different checkout is not blocked.
+	p.lockCheckout("b")()
+
+	locked := make(chan struct{})
+	go func() {
+		p.lockCheckout("a")()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		require.FailNow(t, "checkout was locked twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	<-locked
+}
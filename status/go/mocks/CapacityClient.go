@@ -35,6 +35,22 @@ func (_m *CapacityClient) CapacityMetrics() map[string]capacity.BotConfig {
 	return r0
 }
 
+// Forecast provides a mock function with given fields: params
+func (_m *CapacityClient) Forecast(params capacity.ForecastParams) []*capacity.Forecast {
+	ret := _m.Called(params)
+
+	var r0 []*capacity.Forecast
+	if rf, ok := ret.Get(0).(func(capacity.ForecastParams) []*capacity.Forecast); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*capacity.Forecast)
+		}
+	}
+
+	return r0
+}
+
 // QueryAll provides a mock function with given fields: ctx
 func (_m *CapacityClient) QueryAll(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -7,11 +7,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/twitchtv/twirp"
 	"go.skia.org/infra/go/alogin"
 	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/roles"
 	"go.skia.org/infra/status/go/capacity"
 	"go.skia.org/infra/status/go/incremental"
+	"go.skia.org/infra/status/go/lkgr"
 	"go.skia.org/infra/task_scheduler/go/db"
 	"go.skia.org/infra/task_scheduler/go/types"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -31,6 +33,7 @@ type statusServerImpl struct {
 	capacityClient        capacity.CapacityClient
 	getAutorollerStatuses func() *GetAutorollerStatusesResponse
 	getRepo               func(string) (string, string, error)
+	getLKGR               func(string) *lkgr.Result
 	maxCommitsToLoad      int
 	defaultCommitsToLoad  int
 	podID                 string
@@ -211,14 +214,7 @@ func (s *statusServerImpl) GetAutorollerStatuses(ctx context.Context, req *GetAu
 func (s *statusServerImpl) GetBotUsage(ctx context.Context, req *GetBotUsageRequest) (*GetBotUsageResponse, error) {
 	rv := GetBotUsageResponse{}
 	for _, botconfig := range s.capacityClient.CapacityMetrics() {
-		dims := make(map[string]string, len(botconfig.Dimensions))
-		for _, dim := range botconfig.Dimensions {
-			split := strings.SplitN(dim, ":", 2)
-			if len(split) > 0 {
-				// Handles empty dimensions.
-				dims[split[0]] = dim[len(split[0])+1:]
-			}
-		}
+		dims := dimensionsMap(botconfig.Dimensions)
 		var totalTasks, cqTasks int32
 		var taskTimeMs, cqTimeMs int64
 		for _, task := range botconfig.TaskAverageDurations {
@@ -243,14 +239,69 @@ func (s *statusServerImpl) GetBotUsage(ctx context.Context, req *GetBotUsageRequ
 	return &rv, nil
 }
 
+func (s *statusServerImpl) GetCapacityForecast(ctx context.Context, req *GetCapacityForecastRequest) (*GetCapacityForecastResponse, error) {
+	if req.HorizonDays < 0 || req.TargetTestedness < 0 || req.TargetTestedness > 1 || req.TargetUtilization < 0 || req.TargetUtilization > 1 {
+		return nil, twirp.InvalidArgumentError("req", "horizon must be non-negative and targets must be between zero and one")
+	}
+	forecasts := s.capacityClient.Forecast(capacity.ForecastParams{
+		HorizonDays:       int(req.HorizonDays),
+		TargetTestedness:  req.TargetTestedness,
+		TargetUtilization: req.TargetUtilization,
+	})
+	rv := GetCapacityForecastResponse{}
+	for _, f := range forecasts {
+		rv.Forecasts = append(rv.Forecasts, &CapacityForecast{
+			Dimensions:          dimensionsMap(f.Dimensions),
+			BotCount:            int32(f.BotCount),
+			MsPerDayCurrent:     f.CurrentDemand.Milliseconds(),
+			MsPerDayForecast:    f.ForecastDemand.Milliseconds(),
+			UtilizationCurrent:  f.CurrentUtilization,
+			UtilizationForecast: f.ForecastUtilization,
+			BotsNeeded:          int32(f.BotsNeeded),
+			BotsToBuy:           int32(f.BotsToBuy),
+		})
+	}
+	return &rv, nil
+}
+
+func (s *statusServerImpl) GetLKGR(ctx context.Context, req *GetLKGRRequest) (*GetLKGRResponse, error) {
+	if req.Name == "" {
+		return nil, twirp.RequiredArgumentError("name")
+	}
+	r := s.getLKGR(req.Name)
+	if r == nil {
+		return nil, twirp.NotFoundError(fmt.Sprintf("no LKGR found for %q", req.Name))
+	}
+	return &GetLKGRResponse{
+		Name:     r.Name,
+		Repo:     r.Repo,
+		Revision: r.Revision,
+		Updated:  timestamppb.New(r.Updated),
+	}, nil
+}
+
+// dimensionsMap converts a list of "key:value" dimensions to a map.
+func dimensionsMap(dimensions []string) map[string]string {
+	dims := make(map[string]string, len(dimensions))
+	for _, dim := range dimensions {
+		split := strings.SplitN(dim, ":", 2)
+		if len(split) > 0 {
+			// Handles empty dimensions.
+			dims[split[0]] = dim[len(split[0])+1:]
+		}
+	}
+	return dims
+}
+
 // newStatusServerImpl creates and returns a statusServerImpl instance.
-func newStatusServerImpl(iCache incremental.IncrementalCache, taskDb db.RemoteDB, capacityClient capacity.CapacityClient, getAutorollerStatuses func() *GetAutorollerStatusesResponse, getRepo func(string) (string, string, error), maxCommitsToLoad, defaultCommitsToLoad int, podID string) *statusServerImpl {
+func newStatusServerImpl(iCache incremental.IncrementalCache, taskDb db.RemoteDB, capacityClient capacity.CapacityClient, getAutorollerStatuses func() *GetAutorollerStatusesResponse, getRepo func(string) (string, string, error), getLKGR func(string) *lkgr.Result, maxCommitsToLoad, defaultCommitsToLoad int, podID string) *statusServerImpl {
 	return &statusServerImpl{
 		iCache:                iCache,
 		taskDb:                taskDb,
 		capacityClient:        capacityClient,
 		getAutorollerStatuses: getAutorollerStatuses,
 		getRepo:               getRepo,
+		getLKGR:               getLKGR,
 		maxCommitsToLoad:      maxCommitsToLoad,
 		defaultCommitsToLoad:  defaultCommitsToLoad,
 		podID:                 podID}
@@ -263,6 +314,7 @@ func NewStatusServer(
 	capacityClient capacity.CapacityClient,
 	getAutorollerStatuses func() *GetAutorollerStatusesResponse,
 	getRepo func(string) (string, string, error),
+	getLKGR func(string) *lkgr.Result,
 	maxCommitsToLoad int,
 	defaultCommitsToLoad int,
 	podID string) http.Handler {
@@ -272,6 +324,7 @@ func NewStatusServer(
 		capacityClient,
 		getAutorollerStatuses,
 		getRepo,
+		getLKGR,
 		maxCommitsToLoad,
 		defaultCommitsToLoad,
 		podID), nil)
@@ -17,6 +17,7 @@ import (
 	"go.skia.org/infra/go/vcsinfo"
 	"go.skia.org/infra/status/go/capacity"
 	"go.skia.org/infra/status/go/incremental"
+	"go.skia.org/infra/status/go/lkgr"
 	status_mocks "go.skia.org/infra/status/go/mocks"
 	ts_mocks "go.skia.org/infra/task_scheduler/go/mocks"
 	"go.skia.org/infra/task_scheduler/go/types"
@@ -71,6 +72,17 @@ func setupServerWithMockCapacityClient() (context.Context, mocks, *statusServerI
 			}
 		},
 		func(string) (string, string, error) { return "", "skia", nil },
+		func(name string) *lkgr.Result {
+			if name != "skia" {
+				return nil
+			}
+			return &lkgr.Result{
+				Name:     "skia",
+				Repo:     "https://skia.googlesource.com/skia.git",
+				Revision: "abc123",
+				Updated:  time.Unix(1600000000, 0).UTC(),
+			}
+		},
 		100,
 		35,
 		"mypod",
@@ -693,3 +705,73 @@ func TestGetBotUsage_MultipleDimensionSets_ValidResponse(t *testing.T) {
 		},
 	}, resp.BotSets)
 }
+
+func TestGetCapacityForecast_ValidRequest_ConvertsForecasts(t *testing.T) {
+	ctx, mocks, server := setupServerWithMockCapacityClient()
+	defer mocks.AssertExpectations(t)
+	mocks.capacityClient.On("Forecast", capacity.ForecastParams{
+		HorizonDays:       60,
+		TargetTestedness:  0.5,
+		TargetUtilization: 0.75,
+	}).Return([]*capacity.Forecast{
+		{
+			Dimensions:          []string{"os:Android", "pool:Skia"},
+			BotCount:            2,
+			CurrentDemand:       36 * time.Hour,
+			ForecastDemand:      48 * time.Hour,
+			CurrentUtilization:  0.75,
+			ForecastUtilization: 1.0,
+			BotsNeeded:          3,
+			BotsToBuy:           1,
+		},
+	}).Once()
+	resp, err := server.GetCapacityForecast(ctx, &GetCapacityForecastRequest{
+		HorizonDays:       60,
+		TargetTestedness:  0.5,
+		TargetUtilization: 0.75,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*CapacityForecast{
+		{
+			Dimensions: map[string]string{
+				"os":   "Android",
+				"pool": "Skia",
+			},
+			BotCount:            2,
+			MsPerDayCurrent:     (36 * time.Hour).Milliseconds(),
+			MsPerDayForecast:    (48 * time.Hour).Milliseconds(),
+			UtilizationCurrent:  0.75,
+			UtilizationForecast: 1.0,
+			BotsNeeded:          3,
+			BotsToBuy:           1,
+		},
+	}, resp.Forecasts)
+}
+
+func TestGetCapacityForecast_InvalidTarget_ReturnsError(t *testing.T) {
+	ctx, mocks, server := setupServerWithMockCapacityClient()
+	defer mocks.AssertExpectations(t)
+	_, err := server.GetCapacityForecast(ctx, &GetCapacityForecastRequest{
+		TargetUtilization: 1.5,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "targets must be between zero and one")
+}
+
+func TestGetLKGR_KnownName_ReturnsResult(t *testing.T) {
+	ctx, mocks, server := setupServerWithMockCapacityClient()
+	defer mocks.AssertExpectations(t)
+	resp, err := server.GetLKGR(ctx, &GetLKGRRequest{Name: "skia"})
+	require.NoError(t, err)
+	require.Equal(t, "https://skia.googlesource.com/skia.git", resp.Repo)
+	require.Equal(t, "abc123", resp.Revision)
+	require.Equal(t, timestamppb.New(time.Unix(1600000000, 0)), resp.Updated)
+}
+
+func TestGetLKGR_UnknownName_ReturnsNotFound(t *testing.T) {
+	ctx, mocks, server := setupServerWithMockCapacityClient()
+	defer mocks.AssertExpectations(t)
+	_, err := server.GetLKGR(ctx, &GetLKGRRequest{Name: "bogus"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no LKGR found")
+}
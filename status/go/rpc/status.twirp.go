@@ -70,6 +70,12 @@ type StatusService interface {
 
 	// Method to get latest bot usage / capacity metrics.
 	GetBotUsage(context.Context, *GetBotUsageRequest) (*GetBotUsageResponse, error)
+
+	// Method to get forecasted bot utilization and bot purchase recommendations.
+	GetCapacityForecast(context.Context, *GetCapacityForecastRequest) (*GetCapacityForecastResponse, error)
+
+	// Method to get the last known good revision computed by a configured LKGR.
+	GetLKGR(context.Context, *GetLKGRRequest) (*GetLKGRResponse, error)
 }
 
 // =============================
@@ -78,7 +84,7 @@ type StatusService interface {
 
 type statusServiceProtobufClient struct {
 	client      HTTPClient
-	urls        [5]string
+	urls        [7]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -98,12 +104,14 @@ func NewStatusServiceProtobufClient(baseURL string, client HTTPClient, opts ...t
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(clientOpts.PathPrefix(), "status", "StatusService")
-	urls := [5]string{
+	urls := [7]string{
 		serviceURL + "GetIncrementalCommits",
 		serviceURL + "AddComment",
 		serviceURL + "DeleteComment",
 		serviceURL + "GetAutorollerStatuses",
 		serviceURL + "GetBotUsage",
+		serviceURL + "GetCapacityForecast",
+		serviceURL + "GetLKGR",
 	}
 
 	return &statusServiceProtobufClient{
@@ -344,13 +352,105 @@ func (c *statusServiceProtobufClient) callGetBotUsage(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *statusServiceProtobufClient) GetCapacityForecast(ctx context.Context, in *GetCapacityForecastRequest) (*GetCapacityForecastResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "status")
+	ctx = ctxsetters.WithServiceName(ctx, "StatusService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetCapacityForecast")
+	caller := c.callGetCapacityForecast
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetCapacityForecastRequest) (*GetCapacityForecastResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetCapacityForecastRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetCapacityForecastRequest) when calling interceptor")
+					}
+					return c.callGetCapacityForecast(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetCapacityForecastResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetCapacityForecastResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *statusServiceProtobufClient) callGetCapacityForecast(ctx context.Context, in *GetCapacityForecastRequest) (*GetCapacityForecastResponse, error) {
+	out := new(GetCapacityForecastResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *statusServiceProtobufClient) GetLKGR(ctx context.Context, in *GetLKGRRequest) (*GetLKGRResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "status")
+	ctx = ctxsetters.WithServiceName(ctx, "StatusService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetLKGR")
+	caller := c.callGetLKGR
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetLKGRRequest) (*GetLKGRResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetLKGRRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetLKGRRequest) when calling interceptor")
+					}
+					return c.callGetLKGR(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetLKGRResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetLKGRResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *statusServiceProtobufClient) callGetLKGR(ctx context.Context, in *GetLKGRRequest) (*GetLKGRResponse, error) {
+	out := new(GetLKGRResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // =========================
 // StatusService JSON Client
 // =========================
 
 type statusServiceJSONClient struct {
 	client      HTTPClient
-	urls        [5]string
+	urls        [7]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -370,12 +470,14 @@ func NewStatusServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(clientOpts.PathPrefix(), "status", "StatusService")
-	urls := [5]string{
+	urls := [7]string{
 		serviceURL + "GetIncrementalCommits",
 		serviceURL + "AddComment",
 		serviceURL + "DeleteComment",
 		serviceURL + "GetAutorollerStatuses",
 		serviceURL + "GetBotUsage",
+		serviceURL + "GetCapacityForecast",
+		serviceURL + "GetLKGR",
 	}
 
 	return &statusServiceJSONClient{
@@ -616,6 +718,98 @@ func (c *statusServiceJSONClient) callGetBotUsage(ctx context.Context, in *GetBo
 	return out, nil
 }
 
+func (c *statusServiceJSONClient) GetCapacityForecast(ctx context.Context, in *GetCapacityForecastRequest) (*GetCapacityForecastResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "status")
+	ctx = ctxsetters.WithServiceName(ctx, "StatusService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetCapacityForecast")
+	caller := c.callGetCapacityForecast
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetCapacityForecastRequest) (*GetCapacityForecastResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetCapacityForecastRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetCapacityForecastRequest) when calling interceptor")
+					}
+					return c.callGetCapacityForecast(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetCapacityForecastResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetCapacityForecastResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *statusServiceJSONClient) callGetCapacityForecast(ctx context.Context, in *GetCapacityForecastRequest) (*GetCapacityForecastResponse, error) {
+	out := new(GetCapacityForecastResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *statusServiceJSONClient) GetLKGR(ctx context.Context, in *GetLKGRRequest) (*GetLKGRResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "status")
+	ctx = ctxsetters.WithServiceName(ctx, "StatusService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetLKGR")
+	caller := c.callGetLKGR
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetLKGRRequest) (*GetLKGRResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetLKGRRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetLKGRRequest) when calling interceptor")
+					}
+					return c.callGetLKGR(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetLKGRResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetLKGRResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *statusServiceJSONClient) callGetLKGR(ctx context.Context, in *GetLKGRRequest) (*GetLKGRResponse, error) {
+	out := new(GetLKGRResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ============================
 // StatusService Server Handler
 // ============================
@@ -715,6 +909,12 @@ func (s *statusServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Requ
 	case "GetBotUsage":
 		s.serveGetBotUsage(ctx, resp, req)
 		return
+	case "GetCapacityForecast":
+		s.serveGetCapacityForecast(ctx, resp, req)
+		return
+	case "GetLKGR":
+		s.serveGetLKGR(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
@@ -1597,6 +1797,356 @@ func (s *statusServiceServer) serveGetBotUsageProtobuf(ctx context.Context, resp
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *statusServiceServer) serveGetCapacityForecast(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetCapacityForecastJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetCapacityForecastProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *statusServiceServer) serveGetCapacityForecastJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetCapacityForecast")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GetCapacityForecastRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	handler := s.StatusService.GetCapacityForecast
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetCapacityForecastRequest) (*GetCapacityForecastResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetCapacityForecastRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetCapacityForecastRequest) when calling interceptor")
+					}
+					return s.StatusService.GetCapacityForecast(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetCapacityForecastResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetCapacityForecastResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetCapacityForecastResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetCapacityForecastResponse and nil error while calling GetCapacityForecast. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true, EmitDefaults: !s.jsonSkipDefaults}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *statusServiceServer) serveGetCapacityForecastProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetCapacityForecast")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(GetCapacityForecastRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.StatusService.GetCapacityForecast
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetCapacityForecastRequest) (*GetCapacityForecastResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetCapacityForecastRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetCapacityForecastRequest) when calling interceptor")
+					}
+					return s.StatusService.GetCapacityForecast(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetCapacityForecastResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetCapacityForecastResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetCapacityForecastResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetCapacityForecastResponse and nil error while calling GetCapacityForecast. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *statusServiceServer) serveGetLKGR(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetLKGRJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetLKGRProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *statusServiceServer) serveGetLKGRJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetLKGR")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GetLKGRRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	handler := s.StatusService.GetLKGR
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetLKGRRequest) (*GetLKGRResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetLKGRRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetLKGRRequest) when calling interceptor")
+					}
+					return s.StatusService.GetLKGR(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetLKGRResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetLKGRResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetLKGRResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetLKGRResponse and nil error while calling GetLKGR. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true, EmitDefaults: !s.jsonSkipDefaults}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *statusServiceServer) serveGetLKGRProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetLKGR")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(GetLKGRRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.StatusService.GetLKGR
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetLKGRRequest) (*GetLKGRResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetLKGRRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetLKGRRequest) when calling interceptor")
+					}
+					return s.StatusService.GetLKGR(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetLKGRResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetLKGRResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetLKGRResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetLKGRResponse and nil error while calling GetLKGR. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *statusServiceServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -2162,81 +2712,100 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 1210 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xcb, 0x6e, 0x1c, 0x45,
-	0x17, 0x56, 0xf7, 0x8c, 0x67, 0xa6, 0xcf, 0xd8, 0x8e, 0x53, 0x7f, 0xf2, 0xa7, 0x33, 0xb9, 0x99,
-	0x56, 0x22, 0x99, 0x8b, 0x66, 0x88, 0x61, 0x11, 0x21, 0x81, 0x88, 0x1d, 0x12, 0x22, 0x85, 0x10,
-	0x95, 0x93, 0x0d, 0x9b, 0x56, 0x4d, 0x77, 0x79, 0xa6, 0x71, 0x77, 0x57, 0xbb, 0xaa, 0x7a, 0x90,
-	0x17, 0xec, 0x79, 0x0c, 0xc4, 0x23, 0xb0, 0x00, 0x89, 0x2d, 0x62, 0xc7, 0x03, 0xf0, 0x38, 0xa8,
-	0x6e, 0x3d, 0x97, 0x8c, 0x13, 0xcc, 0x82, 0x55, 0x57, 0x9d, 0xf3, 0xd5, 0xa9, 0x73, 0xbe, 0x73,
-	0xa9, 0x86, 0x4d, 0x21, 0x89, 0xac, 0xc5, 0xb0, 0xe2, 0x4c, 0x32, 0xd4, 0x31, 0xbb, 0xc1, 0x9d,
-	0x09, 0x63, 0x93, 0x9c, 0x8e, 0xb4, 0x74, 0x5c, 0x1f, 0x8f, 0x64, 0x56, 0x50, 0x21, 0x49, 0x51,
-	0x19, 0x60, 0xf4, 0xab, 0x07, 0x37, 0x9f, 0x50, 0xf9, 0xb4, 0x4c, 0x38, 0x2d, 0x68, 0x29, 0x49,
-	0x7e, 0xc8, 0x8a, 0x22, 0x93, 0x02, 0xd3, 0xd3, 0x9a, 0x0a, 0x89, 0x86, 0xd0, 0x3e, 0xe6, 0xac,
-	0x08, 0xbd, 0x5d, 0x6f, 0xaf, 0xbf, 0x3f, 0x18, 0x1a, 0x83, 0x43, 0x67, 0x70, 0xf8, 0xd2, 0x19,
-	0xc4, 0x1a, 0x87, 0xde, 0x03, 0x5f, 0xb2, 0xd0, 0x7f, 0x2b, 0xda, 0x97, 0x0c, 0x6d, 0x82, 0x57,
-	0x86, 0xad, 0x5d, 0x6f, 0xaf, 0x85, 0xbd, 0x12, 0xed, 0x40, 0xab, 0x62, 0x69, 0xd8, 0xde, 0xf5,
-	0xf6, 0x02, 0xac, 0x96, 0xe8, 0x06, 0x04, 0x9c, 0x56, 0x2c, 0xae, 0x88, 0x9c, 0x86, 0x1b, 0x5a,
-	0xde, 0x53, 0x82, 0x17, 0x44, 0x4e, 0xa3, 0x1f, 0x3c, 0xb8, 0x75, 0x8e, 0xe7, 0xa2, 0x62, 0xa5,
-	0xa0, 0xe8, 0x63, 0xe8, 0x15, 0x54, 0x92, 0x94, 0x48, 0x62, 0xdd, 0x0f, 0x87, 0x96, 0x25, 0x87,
-	0xf9, 0xca, 0xea, 0x71, 0x83, 0x44, 0xf7, 0xa1, 0x53, 0x57, 0x29, 0x91, 0xd4, 0x06, 0x71, 0xdd,
-	0x9d, 0x59, 0xb8, 0xe9, 0x95, 0x06, 0x60, 0x0b, 0x8c, 0xfe, 0xf0, 0xe0, 0xf2, 0x6b, 0x5a, 0xf4,
-	0x01, 0x74, 0x13, 0xe3, 0x51, 0xe8, 0xed, 0xb6, 0xf6, 0xfa, 0xfb, 0xc8, 0x59, 0x7a, 0xc6, 0xca,
-	0x89, 0x71, 0x16, 0x3b, 0x08, 0xba, 0x0f, 0x9b, 0x63, 0x4e, 0xca, 0x64, 0x1a, 0x4f, 0x29, 0x49,
-	0x45, 0xe8, 0xeb, 0x23, 0xdb, 0xee, 0xc8, 0x81, 0xd6, 0xe1, 0xbe, 0xc1, 0x7c, 0xa9, 0x20, 0x28,
-	0x82, 0x0d, 0x49, 0xc4, 0x89, 0x08, 0x5b, 0x1a, 0xbb, 0xe9, 0xb0, 0x2f, 0x89, 0x38, 0xc1, 0x46,
-	0x85, 0xde, 0x87, 0x9e, 0xba, 0x81, 0x96, 0x52, 0x84, 0x6d, 0x0d, 0xbb, 0xe4, 0x60, 0x87, 0x46,
-	0x8e, 0x1b, 0x40, 0xf4, 0x21, 0x74, 0xcc, 0x3d, 0x08, 0x41, 0xbb, 0x24, 0x05, 0xd5, 0xb4, 0x05,
-	0x58, 0xaf, 0x95, 0x4c, 0xb9, 0xa6, 0x69, 0x09, 0xb0, 0x5e, 0x47, 0x3f, 0x79, 0xd0, 0x56, 0xd7,
-	0xa1, 0x70, 0x39, 0xd8, 0x60, 0x1e, 0x98, 0x33, 0xe5, 0x2f, 0x98, 0xda, 0x06, 0x3f, 0x4b, 0x75,
-	0xe6, 0x03, 0xec, 0x67, 0x29, 0x1a, 0x40, 0x8f, 0xd3, 0x59, 0x26, 0x32, 0x56, 0xda, 0xfc, 0x37,
-	0x7b, 0xf4, 0x7f, 0xb0, 0xc5, 0x6c, 0x2b, 0xc0, 0xee, 0xd0, 0x1e, 0xec, 0x88, 0xef, 0x08, 0x2f,
-	0xb2, 0x72, 0x12, 0xab, 0x58, 0xe3, 0x2c, 0x0d, 0x3b, 0x1a, 0xb1, 0xed, 0xe4, 0xca, 0xb3, 0xa7,
-	0x69, 0xf4, 0x9b, 0x07, 0x30, 0xa7, 0x5c, 0xc7, 0x41, 0xc4, 0xd4, 0xc5, 0xa6, 0xd6, 0xea, 0x12,
-	0x52, 0xcb, 0x29, 0xe3, 0xd6, 0x4d, 0xbb, 0x53, 0x61, 0x89, 0x7a, 0xfc, 0x2d, 0x4d, 0xa4, 0xf5,
-	0xd6, 0x6d, 0x95, 0xa6, 0x22, 0xbc, 0xe1, 0x35, 0xc0, 0x6e, 0xab, 0xec, 0x8f, 0x59, 0x7a, 0x66,
-	0xdd, 0xd5, 0x6b, 0xf4, 0x00, 0x82, 0xa6, 0xf3, 0xb4, 0x97, 0x6f, 0x6e, 0x8e, 0x39, 0x38, 0xfa,
-	0xdd, 0x87, 0xae, 0xcd, 0x94, 0xa5, 0xcd, 0x6b, 0x68, 0x43, 0xd0, 0x56, 0xed, 0xe0, 0xa8, 0x55,
-	0xeb, 0xe5, 0x9b, 0x5a, 0x17, 0xb8, 0x49, 0x59, 0xab, 0x05, 0xe5, 0x36, 0x01, 0x7a, 0xad, 0xa2,
-	0x2c, 0xa8, 0x10, 0x64, 0x42, 0x6d, 0x38, 0x6e, 0xab, 0x34, 0x29, 0xcd, 0xa9, 0xa4, 0x86, 0xf5,
-	0x1e, 0x76, 0x5b, 0x74, 0x0f, 0xb6, 0xb3, 0x49, 0xc9, 0x38, 0x8d, 0x8f, 0x49, 0x96, 0xd7, 0x9c,
-	0x86, 0x5d, 0x0d, 0xd8, 0x32, 0xd2, 0xc7, 0x46, 0x88, 0xae, 0xc0, 0xc6, 0x71, 0x4e, 0x4e, 0xce,
-	0xc2, 0x9e, 0xd6, 0x9a, 0x0d, 0xba, 0x0b, 0xdb, 0x3a, 0x99, 0xa2, 0xa2, 0x49, 0xac, 0xeb, 0x26,
-	0xd0, 0xf7, 0x6e, 0x2a, 0xe9, 0x51, 0x45, 0x93, 0xe7, 0xaa, 0x7e, 0xae, 0x41, 0xd7, 0xa5, 0x1c,
-	0x4c, 0xbe, 0xa4, 0x4e, 0xb5, 0xca, 0xa3, 0xa9, 0xbb, 0xb0, 0x6f, 0xe4, 0x66, 0x17, 0x7d, 0x0f,
-	0x3b, 0xab, 0x2d, 0x8f, 0x6e, 0x01, 0x08, 0x49, 0xb8, 0x8c, 0xd9, 0x8c, 0x72, 0xcd, 0x6a, 0x0f,
-	0x07, 0x5a, 0xf2, 0xf5, 0x8c, 0x72, 0x37, 0x8e, 0xfc, 0xf9, 0x38, 0xfa, 0xd7, 0xd4, 0x46, 0x7f,
-	0x7a, 0x70, 0xf9, 0x61, 0x9a, 0xba, 0x8e, 0xb3, 0xa3, 0xd5, 0xa5, 0xcf, 0x5b, 0x48, 0xdf, 0x3c,
-	0x00, 0x7f, 0x31, 0x00, 0x35, 0x0a, 0x1b, 0x5e, 0x6c, 0x29, 0xf6, 0x1c, 0x25, 0x8b, 0x74, 0xb4,
-	0x97, 0xe8, 0x38, 0x3f, 0x7d, 0x0d, 0xfb, 0x9d, 0x45, 0xf6, 0xff, 0x59, 0xea, 0xa2, 0xe7, 0x80,
-	0x16, 0xa3, 0xb1, 0xe3, 0x76, 0x89, 0x1e, 0xef, 0x22, 0xf4, 0xfc, 0xec, 0xc1, 0x95, 0x47, 0xba,
-	0x7a, 0xfe, 0x73, 0x86, 0x96, 0x9c, 0xde, 0xb8, 0x88, 0xd3, 0xd7, 0xe0, 0xea, 0x8a, 0xcf, 0x86,
-	0x87, 0xe8, 0xb6, 0x7e, 0x51, 0x1f, 0xd6, 0x92, 0x71, 0x96, 0xe7, 0x94, 0x1f, 0xe9, 0x79, 0x45,
-	0xdd, 0x8b, 0x1a, 0x1d, 0xe9, 0x77, 0x6b, 0x9d, 0xde, 0x12, 0xb9, 0x0f, 0x5d, 0xa3, 0x71, 0x0f,
-	0x47, 0xf3, 0x6c, 0xad, 0x1e, 0xc2, 0x0e, 0x18, 0xfd, 0xe5, 0xc1, 0xce, 0xaa, 0x76, 0xed, 0x14,
-	0xdf, 0x83, 0x9d, 0xa4, 0xe6, 0x6a, 0x52, 0xc5, 0x0a, 0x1b, 0x73, 0x3a, 0xb3, 0x44, 0x6e, 0x5b,
-	0x39, 0x66, 0x79, 0x8e, 0xe9, 0x0c, 0x45, 0xb0, 0x95, 0x13, 0xb1, 0x00, 0x33, 0xa4, 0xf6, 0x95,
-	0xd0, 0x61, 0x10, 0xb4, 0x0b, 0x96, 0x52, 0x37, 0x33, 0xd4, 0x5a, 0xf5, 0x55, 0x59, 0x17, 0xba,
-	0x82, 0x68, 0xaa, 0x39, 0xdd, 0xc0, 0x41, 0x59, 0x17, 0x8f, 0xb5, 0xc0, 0xa9, 0xc7, 0x74, 0x9a,
-	0x95, 0x66, 0x76, 0x18, 0xf5, 0x81, 0x16, 0xa8, 0xb6, 0xab, 0x79, 0xae, 0xeb, 0x2e, 0xc0, 0x6a,
-	0x19, 0x5d, 0x01, 0xf4, 0x84, 0xca, 0x03, 0x26, 0x5f, 0xa9, 0xca, 0x75, 0x2c, 0x7e, 0x0e, 0xff,
-	0x5b, 0x92, 0x5a, 0xee, 0xde, 0x85, 0xde, 0x98, 0xc9, 0x58, 0xd0, 0xe6, 0xd5, 0x9d, 0x3f, 0xa1,
-	0x4c, 0x1e, 0x51, 0x89, 0xbb, 0x63, 0xfd, 0x15, 0xd1, 0x8f, 0x3e, 0x74, 0x8c, 0x0c, 0x7d, 0x06,
-	0x90, 0x66, 0x05, 0x2d, 0xd5, 0x83, 0xe3, 0xce, 0xdd, 0x5e, 0x3e, 0x37, 0x7c, 0xd4, 0x00, 0xbe,
-	0x28, 0x25, 0x3f, 0xc3, 0x0b, 0x27, 0x54, 0xed, 0xa9, 0x5b, 0x13, 0x56, 0x97, 0xa6, 0x2c, 0x37,
-	0xb0, 0x72, 0xe3, 0x50, 0xed, 0xd1, 0x75, 0xe8, 0x25, 0xa7, 0xb1, 0x7b, 0xa9, 0x95, 0xae, 0x9b,
-	0x9c, 0xbe, 0xd4, 0xaf, 0xf3, 0x00, 0x82, 0x42, 0xc4, 0x15, 0xe5, 0x71, 0x72, 0xaa, 0x39, 0x6c,
-	0xe1, 0x6e, 0x21, 0x5e, 0x50, 0x7e, 0x78, 0x8a, 0xee, 0x40, 0x5f, 0x32, 0x49, 0x72, 0x7b, 0xd2,
-	0xf0, 0x08, 0x5a, 0x64, 0x0e, 0x47, 0xb0, 0xe5, 0x0e, 0x9b, 0x7e, 0xe8, 0x68, 0x03, 0x7d, 0x63,
-	0x40, 0x8b, 0x06, 0x9f, 0xc2, 0xa5, 0x15, 0xbf, 0x15, 0xc1, 0x27, 0xf4, 0xcc, 0xd6, 0x84, 0x5a,
-	0xaa, 0x59, 0x30, 0x23, 0x79, 0xed, 0x9e, 0x68, 0xb3, 0xf9, 0xc4, 0x7f, 0xe0, 0xed, 0xff, 0xd2,
-	0x82, 0x2d, 0x53, 0x4b, 0x47, 0x94, 0xcf, 0xb2, 0x84, 0xa2, 0x14, 0xae, 0xae, 0xfd, 0xe9, 0x42,
-	0x77, 0x1d, 0x5d, 0x6f, 0xfa, 0x9b, 0x1c, 0xdc, 0x7b, 0x0b, 0xca, 0x66, 0xf1, 0x10, 0x60, 0x3e,
-	0x60, 0x50, 0xf3, 0x07, 0xf6, 0xda, 0x08, 0x1d, 0x0c, 0xd6, 0xa9, 0xac, 0x91, 0x67, 0xb0, 0xb5,
-	0xd4, 0xa0, 0xe8, 0xa6, 0x03, 0xaf, 0x9b, 0x35, 0x83, 0x5b, 0xe7, 0x68, 0xad, 0x35, 0x13, 0xf8,
-	0xeb, 0x5d, 0xbb, 0x14, 0xf8, 0xb9, 0x4d, 0xbf, 0x14, 0xf8, 0x1b, 0x5a, 0xff, 0x31, 0xf4, 0x17,
-	0xaa, 0x1a, 0x0d, 0x16, 0x4e, 0xad, 0x34, 0xc0, 0xe0, 0xc6, 0x5a, 0x9d, 0xb1, 0x73, 0xf0, 0xce,
-	0x37, 0x77, 0x26, 0x6c, 0x28, 0x4e, 0x32, 0x32, 0x64, 0x7c, 0x32, 0xca, 0xca, 0x63, 0x4e, 0x46,
-	0x06, 0x3f, 0x9a, 0xb0, 0x11, 0xaf, 0x92, 0x71, 0x47, 0x8f, 0xb7, 0x8f, 0xfe, 0x0e, 0x00, 0x00,
-	0xff, 0xff, 0x76, 0x57, 0x61, 0x3d, 0x39, 0x0c, 0x00, 0x00,
+	// 1518 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xcf, 0x6f, 0x1b, 0xc5,
+	0x17, 0xd7, 0xfa, 0xb7, 0x9f, 0xf3, 0xc3, 0x9d, 0xa6, 0xed, 0xd6, 0x6d, 0x9a, 0x74, 0xbf, 0xad,
+	0x94, 0x2f, 0xa5, 0x0e, 0x0d, 0x15, 0xaa, 0x2a, 0x81, 0x68, 0x12, 0x92, 0x56, 0x94, 0x52, 0x6d,
+	0x92, 0x0b, 0x07, 0x56, 0x63, 0xef, 0xc4, 0x5e, 0xe2, 0xdd, 0x71, 0x66, 0x66, 0x83, 0x5c, 0x89,
+	0x3b, 0x12, 0x7f, 0x02, 0x17, 0xc4, 0x9f, 0xc0, 0x05, 0xc1, 0x15, 0x71, 0xe3, 0xc4, 0x89, 0x3f,
+	0x07, 0xcd, 0xaf, 0xf5, 0xda, 0x8e, 0x1b, 0x5a, 0x24, 0x4e, 0x9e, 0x79, 0xef, 0xf3, 0xde, 0xcc,
+	0xfb, 0xbc, 0x37, 0xef, 0xad, 0x61, 0x81, 0x0b, 0x2c, 0x52, 0xde, 0x1e, 0x32, 0x2a, 0x28, 0xaa,
+	0xe8, 0x5d, 0x6b, 0xad, 0x47, 0x69, 0x6f, 0x40, 0x36, 0x95, 0xb4, 0x93, 0x1e, 0x6f, 0x8a, 0x28,
+	0x26, 0x5c, 0xe0, 0x78, 0xa8, 0x81, 0xde, 0xcf, 0x0e, 0xdc, 0xdc, 0x27, 0xe2, 0x59, 0xd2, 0x65,
+	0x24, 0x26, 0x89, 0xc0, 0x83, 0x1d, 0x1a, 0xc7, 0x91, 0xe0, 0x3e, 0x39, 0x4d, 0x09, 0x17, 0xa8,
+	0x0d, 0xa5, 0x63, 0x46, 0x63, 0xd7, 0x59, 0x77, 0x36, 0x1a, 0x5b, 0xad, 0xb6, 0x76, 0xd8, 0xb6,
+	0x0e, 0xdb, 0x87, 0xd6, 0xa1, 0xaf, 0x70, 0xe8, 0x1d, 0x28, 0x08, 0xea, 0x16, 0x2e, 0x44, 0x17,
+	0x04, 0x45, 0x0b, 0xe0, 0x24, 0x6e, 0x71, 0xdd, 0xd9, 0x28, 0xfa, 0x4e, 0x82, 0x9a, 0x50, 0x1c,
+	0xd2, 0xd0, 0x2d, 0xad, 0x3b, 0x1b, 0x75, 0x5f, 0x2e, 0xd1, 0x0d, 0xa8, 0x33, 0x32, 0xa4, 0xc1,
+	0x10, 0x8b, 0xbe, 0x5b, 0x56, 0xf2, 0x9a, 0x14, 0xbc, 0xc4, 0xa2, 0xef, 0x7d, 0xeb, 0xc0, 0xea,
+	0x9c, 0x9b, 0xf3, 0x21, 0x4d, 0x38, 0x41, 0x0f, 0xa1, 0x16, 0x13, 0x81, 0x43, 0x2c, 0xb0, 0xb9,
+	0xbe, 0xdb, 0x36, 0x2c, 0x59, 0xcc, 0x67, 0x46, 0xef, 0x67, 0x48, 0xf4, 0x00, 0x2a, 0xe9, 0x30,
+	0xc4, 0x82, 0x98, 0x20, 0xae, 0x5b, 0x9b, 0xdc, 0x49, 0x47, 0x0a, 0xe0, 0x1b, 0xa0, 0xf7, 0xbb,
+	0x03, 0x97, 0x66, 0xb4, 0xe8, 0x5d, 0xa8, 0x76, 0xf5, 0x8d, 0x5c, 0x67, 0xbd, 0xb8, 0xd1, 0xd8,
+	0x42, 0xd6, 0xd3, 0x73, 0x9a, 0xf4, 0xf4, 0x65, 0x7d, 0x0b, 0x41, 0x0f, 0x60, 0xa1, 0xc3, 0x70,
+	0xd2, 0xed, 0x07, 0x7d, 0x82, 0x43, 0xee, 0x16, 0x94, 0xc9, 0x92, 0x35, 0xd9, 0x56, 0x3a, 0xbf,
+	0xa1, 0x31, 0x4f, 0x25, 0x04, 0x79, 0x50, 0x16, 0x98, 0x9f, 0x70, 0xb7, 0xa8, 0xb0, 0x0b, 0x16,
+	0x7b, 0x88, 0xf9, 0x89, 0xaf, 0x55, 0xe8, 0x1e, 0xd4, 0xe4, 0x09, 0x24, 0x11, 0xdc, 0x2d, 0x29,
+	0xd8, 0xb2, 0x85, 0xed, 0x68, 0xb9, 0x9f, 0x01, 0xbc, 0xf7, 0xa0, 0xa2, 0xcf, 0x41, 0x08, 0x4a,
+	0x09, 0x8e, 0x89, 0xa2, 0xad, 0xee, 0xab, 0xb5, 0x94, 0xc9, 0xab, 0x29, 0x5a, 0xea, 0xbe, 0x5a,
+	0x7b, 0x3f, 0x3a, 0x50, 0x92, 0xc7, 0x21, 0x77, 0x32, 0xd8, 0xfa, 0x38, 0x30, 0xeb, 0xaa, 0x90,
+	0x73, 0xb5, 0x04, 0x85, 0x28, 0x54, 0x99, 0xaf, 0xfb, 0x85, 0x28, 0x44, 0x2d, 0xa8, 0x31, 0x72,
+	0x16, 0xf1, 0x88, 0x26, 0x26, 0xff, 0xd9, 0x1e, 0x5d, 0x05, 0x53, 0xcc, 0xa6, 0x02, 0xcc, 0x0e,
+	0x6d, 0x40, 0x93, 0x7f, 0x8d, 0x59, 0x1c, 0x25, 0xbd, 0x40, 0xc6, 0x1a, 0x44, 0xa1, 0x5b, 0x51,
+	0x88, 0x25, 0x2b, 0x97, 0x37, 0x7b, 0x16, 0x7a, 0xbf, 0x3a, 0x00, 0x63, 0xca, 0x55, 0x1c, 0x98,
+	0xf7, 0x6d, 0x6c, 0x72, 0x2d, 0x0f, 0xc1, 0xa9, 0xe8, 0x53, 0x66, 0xae, 0x69, 0x76, 0x32, 0x2c,
+	0x9e, 0x76, 0xbe, 0x22, 0x5d, 0x61, 0x6e, 0x6b, 0xb7, 0x52, 0x33, 0xc4, 0x2c, 0xe3, 0xb5, 0xee,
+	0xdb, 0xad, 0xf4, 0xdf, 0xa1, 0xe1, 0xc8, 0x5c, 0x57, 0xad, 0xd1, 0x23, 0xa8, 0x67, 0x2f, 0xcf,
+	0xad, 0x5c, 0xf8, 0x38, 0xc6, 0x60, 0xef, 0xb7, 0x02, 0x54, 0x4d, 0xa6, 0x0c, 0x6d, 0x4e, 0x46,
+	0x1b, 0x82, 0x92, 0x7c, 0x0e, 0x96, 0x5a, 0xb9, 0x9e, 0x3c, 0xa9, 0xf8, 0x06, 0x27, 0x49, 0x6f,
+	0x29, 0x27, 0xcc, 0x24, 0x40, 0xad, 0x65, 0x94, 0x31, 0xe1, 0x1c, 0xf7, 0x88, 0x09, 0xc7, 0x6e,
+	0xa5, 0x26, 0x24, 0x03, 0x22, 0x88, 0x66, 0xbd, 0xe6, 0xdb, 0x2d, 0xba, 0x0b, 0x4b, 0x51, 0x2f,
+	0xa1, 0x8c, 0x04, 0xc7, 0x38, 0x1a, 0xa4, 0x8c, 0xb8, 0x55, 0x05, 0x58, 0xd4, 0xd2, 0x3d, 0x2d,
+	0x44, 0x2b, 0x50, 0x3e, 0x1e, 0xe0, 0x93, 0x91, 0x5b, 0x53, 0x5a, 0xbd, 0x41, 0x77, 0x60, 0x49,
+	0x25, 0x93, 0x0f, 0x49, 0x37, 0x50, 0x75, 0x53, 0x57, 0xe7, 0x2e, 0x48, 0xe9, 0xc1, 0x90, 0x74,
+	0x5f, 0xc8, 0xfa, 0xb9, 0x06, 0x55, 0x9b, 0x72, 0xd0, 0xf9, 0x12, 0x2a, 0xd5, 0x32, 0x8f, 0xba,
+	0xee, 0xdc, 0x86, 0x96, 0xeb, 0x9d, 0xf7, 0x0d, 0x34, 0xa7, 0x9f, 0x3c, 0x5a, 0x05, 0xe0, 0x02,
+	0x33, 0x11, 0xd0, 0x33, 0xc2, 0x14, 0xab, 0x35, 0xbf, 0xae, 0x24, 0x9f, 0x9f, 0x11, 0x66, 0xdb,
+	0x51, 0x61, 0xdc, 0x8e, 0xde, 0x9a, 0x5a, 0xef, 0x0f, 0x07, 0x2e, 0x3d, 0x09, 0x43, 0xfb, 0xe2,
+	0x4c, 0x6b, 0xb5, 0xe9, 0x73, 0x72, 0xe9, 0x1b, 0x07, 0x50, 0xc8, 0x07, 0x20, 0x5b, 0x61, 0xc6,
+	0x8b, 0x29, 0xc5, 0x9a, 0xa5, 0x24, 0x4f, 0x47, 0x69, 0x82, 0x8e, 0xf9, 0xe9, 0xcb, 0xd8, 0xaf,
+	0xe4, 0xd9, 0xff, 0x67, 0xa9, 0xf3, 0x5e, 0x00, 0xca, 0x47, 0x63, 0xda, 0xed, 0x04, 0x3d, 0xce,
+	0x9b, 0xd0, 0xf3, 0x93, 0x03, 0x2b, 0xbb, 0xaa, 0x7a, 0xfe, 0x73, 0x86, 0x26, 0x2e, 0x5d, 0x7e,
+	0x93, 0x4b, 0x5f, 0x83, 0x2b, 0x53, 0x77, 0xd6, 0x3c, 0x78, 0xb7, 0xd4, 0x44, 0x7d, 0x92, 0x0a,
+	0xca, 0xe8, 0x60, 0x40, 0xd8, 0x81, 0xea, 0x57, 0xc4, 0x4e, 0x54, 0xef, 0x00, 0x56, 0xe7, 0xe8,
+	0x0d, 0x91, 0x5b, 0x50, 0xd5, 0x1a, 0x3b, 0x38, 0xb2, 0xb1, 0x35, 0x6d, 0xe4, 0x5b, 0xa0, 0xf7,
+	0x97, 0x03, 0xcd, 0x69, 0xed, 0xb9, 0x5d, 0x7c, 0x03, 0x9a, 0xdd, 0x94, 0xc9, 0x4e, 0x15, 0x48,
+	0x6c, 0xc0, 0xc8, 0x99, 0x21, 0x72, 0xc9, 0xc8, 0x7d, 0x3a, 0x18, 0xf8, 0xe4, 0x0c, 0x79, 0xb0,
+	0x38, 0xc0, 0x3c, 0x07, 0xd3, 0xa4, 0x36, 0xa4, 0xd0, 0x62, 0x10, 0x94, 0x62, 0x1a, 0x12, 0xdb,
+	0x33, 0xe4, 0x5a, 0xbe, 0xab, 0x24, 0x8d, 0x55, 0x05, 0x91, 0x50, 0x71, 0x5a, 0xf6, 0xeb, 0x49,
+	0x1a, 0xef, 0x29, 0x81, 0x55, 0x77, 0x48, 0x3f, 0x4a, 0x74, 0xef, 0xd0, 0xea, 0x6d, 0x25, 0x90,
+	0xcf, 0x2e, 0x65, 0x03, 0x55, 0x77, 0x75, 0x5f, 0x2e, 0xbd, 0x15, 0x40, 0xfb, 0x44, 0x6c, 0x53,
+	0x71, 0x24, 0x2b, 0xd7, 0xb2, 0xf8, 0x31, 0x5c, 0x9e, 0x90, 0x1a, 0xee, 0xfe, 0x0f, 0xb5, 0x0e,
+	0x15, 0x01, 0x27, 0xd9, 0xd4, 0x1d, 0x8f, 0x50, 0x2a, 0x0e, 0x88, 0xf0, 0xab, 0x1d, 0xf5, 0xcb,
+	0xbd, 0x1f, 0x0a, 0x50, 0xd1, 0x32, 0xf4, 0x11, 0x40, 0x18, 0xc5, 0x24, 0x91, 0x03, 0xc7, 0xda,
+	0xdd, 0x9a, 0xb4, 0x6b, 0xef, 0x66, 0x80, 0x4f, 0x12, 0xc1, 0x46, 0x7e, 0xce, 0x42, 0xd6, 0x9e,
+	0x3c, 0xb5, 0x4b, 0xd3, 0x44, 0x97, 0x65, 0xd9, 0x97, 0xd7, 0xd8, 0x91, 0x7b, 0x74, 0x1d, 0x6a,
+	0xdd, 0xd3, 0xc0, 0x4e, 0x6a, 0xa9, 0xab, 0x76, 0x4f, 0xe5, 0x68, 0xe2, 0xa8, 0x05, 0xf5, 0x98,
+	0x07, 0x43, 0xc2, 0x82, 0xee, 0xa9, 0xe2, 0xb0, 0xe8, 0x57, 0x63, 0xfe, 0x92, 0xb0, 0x9d, 0x53,
+	0xb4, 0x06, 0x0d, 0x41, 0x05, 0x1e, 0x18, 0x4b, 0xcd, 0x23, 0x28, 0x91, 0x36, 0xf6, 0x60, 0xd1,
+	0x1a, 0xeb, 0xf7, 0x50, 0x51, 0x0e, 0x1a, 0xda, 0x81, 0x12, 0xb5, 0x3e, 0x84, 0xe5, 0xa9, 0x7b,
+	0x4b, 0x82, 0x4f, 0xc8, 0xc8, 0xd4, 0x84, 0x5c, 0xca, 0x5e, 0x70, 0x86, 0x07, 0xa9, 0x1d, 0xd1,
+	0x7a, 0xf3, 0xb8, 0xf0, 0xc8, 0xf1, 0xbe, 0x77, 0xa0, 0xb5, 0x4f, 0xc4, 0x0e, 0x1e, 0xe2, 0x6e,
+	0x24, 0x46, 0x7b, 0x94, 0x91, 0xae, 0xcc, 0xbe, 0x79, 0x9e, 0xb7, 0x61, 0xa1, 0x4f, 0x59, 0xf4,
+	0x8a, 0x26, 0x41, 0x88, 0x47, 0x5c, 0xf9, 0x2c, 0xfb, 0x0d, 0x23, 0xdb, 0xc5, 0x23, 0xf9, 0xfd,
+	0x71, 0x49, 0x60, 0xd6, 0x23, 0x22, 0x10, 0x84, 0x0b, 0x12, 0x26, 0x84, 0x73, 0x75, 0x8e, 0xe3,
+	0x37, 0xb5, 0xe2, 0x30, 0x93, 0xa3, 0xfb, 0x80, 0x0c, 0x38, 0x15, 0xd1, 0x20, 0x7a, 0x85, 0x85,
+	0xfc, 0x20, 0x28, 0x2a, 0xb4, 0x71, 0x73, 0x34, 0x56, 0x78, 0x47, 0x70, 0xe3, 0xdc, 0xcb, 0x99,
+	0x52, 0xf8, 0x00, 0xea, 0xc7, 0x46, 0x36, 0xf3, 0x90, 0x66, 0x8c, 0xc6, 0x50, 0xef, 0x97, 0x22,
+	0x34, 0xa7, 0xf5, 0xe8, 0xe9, 0x39, 0x15, 0xb2, 0x31, 0xcf, 0xdb, 0xdb, 0xd7, 0xca, 0x3d, 0x40,
+	0x26, 0xa7, 0x21, 0x1e, 0x05, 0xe6, 0x41, 0x9a, 0x4f, 0xe4, 0x65, 0x95, 0xd8, 0x5d, 0x3c, 0xda,
+	0xd1, 0x62, 0x74, 0x1f, 0x2e, 0xe7, 0xc0, 0x36, 0x00, 0x53, 0x47, 0x4d, 0x8b, 0xce, 0x42, 0xd8,
+	0x84, 0xcb, 0x39, 0x5a, 0x33, 0xe7, 0x65, 0x45, 0x2f, 0xca, 0xa9, 0xac, 0xff, 0x07, 0xb0, 0x92,
+	0x37, 0xc8, 0x0e, 0xa8, 0x28, 0x8b, 0xbc, 0xb3, 0xec, 0x8c, 0x35, 0x68, 0x74, 0xa8, 0xe0, 0x41,
+	0x42, 0x48, 0x48, 0x42, 0xf5, 0x8a, 0xcb, 0x3e, 0x48, 0xd1, 0x0b, 0x25, 0x41, 0xb7, 0x0c, 0x40,
+	0xd0, 0xa0, 0x93, 0xea, 0xd9, 0x5f, 0xf6, 0x25, 0x21, 0xfc, 0x90, 0x6e, 0xa7, 0xa3, 0x7f, 0x5b,
+	0xb0, 0x77, 0x60, 0x69, 0x9f, 0x88, 0xe7, 0x9f, 0xee, 0xfb, 0xb9, 0x11, 0x32, 0xdd, 0x03, 0xbd,
+	0xef, 0x1c, 0x58, 0xce, 0x60, 0xa6, 0x5a, 0xe6, 0x7c, 0xf1, 0xce, 0x7c, 0x5f, 0xe5, 0x3f, 0x55,
+	0x8b, 0x53, 0x9f, 0xaa, 0x0f, 0xa1, 0xaa, 0xff, 0x11, 0xe8, 0x29, 0xf3, 0xfa, 0x51, 0x62, 0xa1,
+	0x5b, 0x7f, 0x96, 0x60, 0x51, 0x37, 0xec, 0x03, 0xc2, 0xce, 0xa2, 0x2e, 0x41, 0x21, 0x5c, 0x39,
+	0xf7, 0x9f, 0x0d, 0xba, 0x63, 0x2b, 0xee, 0x75, 0x7f, 0xd9, 0x5a, 0x77, 0x2f, 0x40, 0x99, 0x88,
+	0x77, 0x00, 0xc6, 0x53, 0x1c, 0x65, 0x7f, 0x73, 0x66, 0xbe, 0x53, 0x5a, 0xad, 0xf3, 0x54, 0xc6,
+	0xc9, 0x73, 0x58, 0x9c, 0x98, 0x82, 0xe8, 0xa6, 0x05, 0x9f, 0x37, 0xd0, 0x5b, 0xab, 0x73, 0xb4,
+	0xc6, 0x9b, 0x0e, 0x7c, 0x76, 0x34, 0x4e, 0x04, 0x3e, 0x77, 0xb2, 0xb6, 0xee, 0x5e, 0x80, 0x32,
+	0xa7, 0xec, 0x41, 0x23, 0x37, 0x3a, 0x50, 0x2b, 0x67, 0x35, 0x35, 0x65, 0x5a, 0x37, 0xce, 0xd5,
+	0x19, 0x3f, 0x5f, 0xaa, 0x11, 0x34, 0xd3, 0x2a, 0xbc, 0x9c, 0xcd, 0x9c, 0xce, 0xd9, 0xfa, 0xdf,
+	0x6b, 0x31, 0xc6, 0xff, 0x63, 0xa8, 0x9a, 0x2a, 0x45, 0x57, 0x73, 0xf8, 0x5c, 0x75, 0xb7, 0xae,
+	0xcd, 0xc8, 0xb5, 0xed, 0xf6, 0xed, 0x2f, 0xd6, 0x7a, 0xb4, 0xcd, 0x4f, 0x22, 0xdc, 0xa6, 0xac,
+	0xb7, 0x19, 0x25, 0xc7, 0x0c, 0x6f, 0x6a, 0xec, 0x66, 0x8f, 0x6e, 0xb2, 0x61, 0xb7, 0x53, 0x51,
+	0x45, 0xf9, 0xfe, 0xdf, 0x03, 0x00, 0x1f, 0xcf, 0x6b, 0x67, 0x3a, 0x10, 0x00, 0x00,
 }
@@ -71,6 +71,7 @@ var (
 	commitsTemplate     *template.Template                 = nil
 	iCache              *incremental.IncrementalCacheImpl  = nil
 	lkgrObj             *lkgr.LKGR                         = nil
+	lkgrEngines         map[string]*lkgr.Engine            = nil
 	taskDb              db.RemoteDB                        = nil
 	taskDriverDb        task_driver_db.DB                  = nil
 	taskDriverLogs      *logs.LogsManager                  = nil
@@ -107,6 +108,8 @@ var (
 	firestoreInstance           = flag.String("firestore_instance", "", "Firestore instance to use, eg. \"production\"")
 	gitstoreTable               = flag.String("gitstore_bt_table", "git-repos2", "BigTable table used for GitStore.")
 	host                        = flag.String("host", "localhost", "HTTP service host")
+	lkgrConfig                  = flag.String("lkgr_config", "", "Optional JSON file containing a list of LKGR configs to compute from task results.")
+	lkgrWorkdir                 = flag.String("lkgr_workdir", "", "Working directory used to push LKGR refs. If blank, refs are not pushed.")
 	port                        = flag.String("port", ":8002", "HTTP service port (e.g., ':8002')")
 	promPort                    = flag.String("prom_port", ":20000", "Metrics service address (e.g., ':10110')")
 	repoUrls                    = common.NewMultiStringFlag("repo", nil, "Repositories to query for status.")
@@ -257,6 +260,16 @@ func capacityHandler(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
+// getLKGRTwirp returns the most recent result of the named LKGR engine, if
+// any.
+func getLKGRTwirp(name string) *lkgr.Result {
+	e, ok := lkgrEngines[name]
+	if !ok {
+		return nil
+	}
+	return e.Get()
+}
+
 func lkgrHandler(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "text/html")
 	if _, err := w.Write([]byte(lkgrObj.Get())); err != nil {
@@ -396,6 +409,27 @@ func main() {
 	capacityClient = capacity.New(tasksPerCommit.tcc, tCache, repos)
 	capacityClient.StartLoading(ctx, *capacityRecalculateInterval)
 
+	// Configured LKGRs.
+	lkgrEngines = map[string]*lkgr.Engine{}
+	if *lkgrConfig != "" {
+		configs, err := lkgr.ReadConfigs(*lkgrConfig)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+		var pusher lkgr.RefPusher
+		if *lkgrWorkdir != "" {
+			pusher = lkgr.NewGitRefPusher(*lkgrWorkdir)
+		}
+		for _, c := range configs {
+			if _, ok := repos[c.Repo]; !ok {
+				sklog.Fatalf("LKGR %q uses repo %s which is not one of --repo", c.Name, c.Repo)
+			}
+			e := lkgr.NewEngine(c, taskDb, repos, pusher)
+			e.UpdateLoop(ctx, 10*time.Minute)
+			lkgrEngines[c.Name] = e
+		}
+	}
+
 	// Periodically obtain the autoroller statuses.
 	if err := ds.InitWithOpt(common.PROJECT_ID, ds.AUTOROLL_NS, option.WithTokenSource(ts)); err != nil {
 		sklog.Fatalf("Failed to initialize datastore: %s", err)
@@ -461,7 +495,7 @@ func main() {
 	}
 
 	// Create Twirp Server.
-	twirpServer := rpc.NewStatusServer(iCache, taskDb, capacityClient, getAutorollerStatusesTwirp, getRepoTwirp, maxCommitsToLoad, defaultCommitsToLoad, podId)
+	twirpServer := rpc.NewStatusServer(iCache, taskDb, capacityClient, getAutorollerStatusesTwirp, getRepoTwirp, getLKGRTwirp, maxCommitsToLoad, defaultCommitsToLoad, podId)
 
 	// Run the server.
 	runServer(serverURL, twirpServer)
@@ -48,6 +48,16 @@ export class MockStatusService implements StatusService {
     | ((req: status.GetBotUsageRequest) => status.GetBotUsageResponse)
     | null = null;
 
+  private processGetCapacityForecast:
+    | ((
+        req: status.GetCapacityForecastRequest
+      ) => status.GetCapacityForecastResponse)
+    | null = null;
+
+  private processGetLKGR:
+    | ((req: status.GetLKGRRequest) => status.GetLKGRResponse)
+    | null = null;
+
   constructor() {}
 
   exhausted(): boolean {
@@ -56,7 +66,9 @@ export class MockStatusService implements StatusService {
       this.processDeleteComment ||
       this.processGetIncrementalCommits ||
       this.processGetAutorollerStatuses ||
-      this.processGetBotUsage
+      this.processGetBotUsage ||
+      this.processGetCapacityForecast ||
+      this.processGetLKGR
     );
   }
 
@@ -120,6 +132,30 @@ export class MockStatusService implements StatusService {
     return this;
   }
 
+  // Set the GetCapacityForecast response.
+  expectGetCapacityForecast(
+    resp: status.GetCapacityForecastResponse,
+    check: (req: status.GetCapacityForecastRequest) => void = (req) => {}
+  ): MockStatusService {
+    this.processGetCapacityForecast = (req) => {
+      check(req);
+      return resp;
+    };
+    return this;
+  }
+
+  // Set the GetLKGR response.
+  expectGetLKGR(
+    resp: status.GetLKGRResponse,
+    check: (req: status.GetLKGRRequest) => void = (req) => {}
+  ): MockStatusService {
+    this.processGetLKGR = (req) => {
+      check(req);
+      return resp;
+    };
+    return this;
+  }
+
   getIncrementalCommits(
     req: status.GetIncrementalCommitsRequest
   ): Promise<status.GetIncrementalCommitsResponse> {
@@ -169,4 +205,22 @@ export class MockStatusService implements StatusService {
       ? Promise.resolve(process(req))
       : Promise.reject('No mock response set');
   }
+
+  getCapacityForecast(
+    req: status.GetCapacityForecastRequest
+  ): Promise<status.GetCapacityForecastResponse> {
+    const process = this.processGetCapacityForecast;
+    this.processGetCapacityForecast = null;
+    return process
+      ? Promise.resolve(process(req))
+      : Promise.reject('No mock response set');
+  }
+
+  getLKGR(req: status.GetLKGRRequest): Promise<status.GetLKGRResponse> {
+    const process = this.processGetLKGR;
+    this.processGetLKGR = null;
+    return process
+      ? Promise.resolve(process(req))
+      : Promise.reject('No mock response set');
+  }
 }
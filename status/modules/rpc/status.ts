@@ -407,12 +407,128 @@ const JSONToBotSet = (m: BotSetJSON): BotSet => {
   };
 };
 
+export interface GetCapacityForecastRequest {
+  horizonDays: number;
+  targetTestedness: number;
+  targetUtilization: number;
+}
+
+interface GetCapacityForecastRequestJSON {
+  horizon_days?: number;
+  target_testedness?: number;
+  target_utilization?: number;
+}
+
+const GetCapacityForecastRequestToJSON = (m: GetCapacityForecastRequest): GetCapacityForecastRequestJSON => {
+  return {
+    horizon_days: m.horizonDays,
+    target_testedness: m.targetTestedness,
+    target_utilization: m.targetUtilization,
+  };
+};
+
+export interface GetCapacityForecastResponse {
+  forecasts?: CapacityForecast[];
+}
+
+interface GetCapacityForecastResponseJSON {
+  forecasts?: CapacityForecastJSON[];
+}
+
+const JSONToGetCapacityForecastResponse = (m: GetCapacityForecastResponseJSON): GetCapacityForecastResponse => {
+  return {
+    forecasts: m.forecasts && m.forecasts.map(JSONToCapacityForecast),
+  };
+};
+
+export interface CapacityForecast_DimensionsEntry {
+  [key: string]: string;
+}
+
+interface CapacityForecast_DimensionsEntryJSON {
+  [key: string]: string;
+}
+
+export interface CapacityForecast {
+  dimensions?: CapacityForecast_DimensionsEntry;
+  botCount: number;
+  msPerDayCurrent: number;
+  msPerDayForecast: number;
+  utilizationCurrent: number;
+  utilizationForecast: number;
+  botsNeeded: number;
+  botsToBuy: number;
+}
+
+interface CapacityForecastJSON {
+  dimensions?: CapacityForecast_DimensionsEntryJSON;
+  bot_count?: number;
+  ms_per_day_current?: number;
+  ms_per_day_forecast?: number;
+  utilization_current?: number;
+  utilization_forecast?: number;
+  bots_needed?: number;
+  bots_to_buy?: number;
+}
+
+const JSONToCapacityForecast = (m: CapacityForecastJSON): CapacityForecast => {
+  return {
+    dimensions: m.dimensions,
+    botCount: m.bot_count || 0,
+    msPerDayCurrent: m.ms_per_day_current || 0,
+    msPerDayForecast: m.ms_per_day_forecast || 0,
+    utilizationCurrent: m.utilization_current || 0,
+    utilizationForecast: m.utilization_forecast || 0,
+    botsNeeded: m.bots_needed || 0,
+    botsToBuy: m.bots_to_buy || 0,
+  };
+};
+
+export interface GetLKGRRequest {
+  name: string;
+}
+
+interface GetLKGRRequestJSON {
+  name?: string;
+}
+
+const GetLKGRRequestToJSON = (m: GetLKGRRequest): GetLKGRRequestJSON => {
+  return {
+    name: m.name,
+  };
+};
+
+export interface GetLKGRResponse {
+  name: string;
+  repo: string;
+  revision: string;
+  updated?: string;
+}
+
+interface GetLKGRResponseJSON {
+  name?: string;
+  repo?: string;
+  revision?: string;
+  updated?: string;
+}
+
+const JSONToGetLKGRResponse = (m: GetLKGRResponseJSON): GetLKGRResponse => {
+  return {
+    name: m.name || "",
+    repo: m.repo || "",
+    revision: m.revision || "",
+    updated: m.updated,
+  };
+};
+
 export interface StatusService {
   getIncrementalCommits: (getIncrementalCommitsRequest: GetIncrementalCommitsRequest) => Promise<GetIncrementalCommitsResponse>;
   addComment: (addCommentRequest: AddCommentRequest) => Promise<AddCommentResponse>;
   deleteComment: (deleteCommentRequest: DeleteCommentRequest) => Promise<DeleteCommentResponse>;
   getAutorollerStatuses: (getAutorollerStatusesRequest: GetAutorollerStatusesRequest) => Promise<GetAutorollerStatusesResponse>;
   getBotUsage: (getBotUsageRequest: GetBotUsageRequest) => Promise<GetBotUsageResponse>;
+  getCapacityForecast: (getCapacityForecastRequest: GetCapacityForecastRequest) => Promise<GetCapacityForecastResponse>;
+  getLKGR: (getLKGRRequest: GetLKGRRequest) => Promise<GetLKGRResponse>;
 }
 
 export class StatusServiceClient implements StatusService {
@@ -503,4 +619,34 @@ export class StatusServiceClient implements StatusService {
       return resp.json().then(JSONToGetBotUsageResponse);
     });
   }
+
+  getCapacityForecast(getCapacityForecastRequest: GetCapacityForecastRequest): Promise<GetCapacityForecastResponse> {
+    const url = this.hostname + this.pathPrefix + "GetCapacityForecast";
+    let body: GetCapacityForecastRequest | GetCapacityForecastRequestJSON = getCapacityForecastRequest;
+    if (!this.writeCamelCase) {
+      body = GetCapacityForecastRequestToJSON(getCapacityForecastRequest);
+    }
+    return this.fetch(createTwirpRequest(url, body, this.optionsOverride)).then((resp) => {
+      if (!resp.ok) {
+        return throwTwirpError(resp);
+      }
+
+      return resp.json().then(JSONToGetCapacityForecastResponse);
+    });
+  }
+
+  getLKGR(getLKGRRequest: GetLKGRRequest): Promise<GetLKGRResponse> {
+    const url = this.hostname + this.pathPrefix + "GetLKGR";
+    let body: GetLKGRRequest | GetLKGRRequestJSON = getLKGRRequest;
+    if (!this.writeCamelCase) {
+      body = GetLKGRRequestToJSON(getLKGRRequest);
+    }
+    return this.fetch(createTwirpRequest(url, body, this.optionsOverride)).then((resp) => {
+      if (!resp.ok) {
+        return throwTwirpError(resp);
+      }
+
+      return resp.json().then(JSONToGetLKGRResponse);
+    });
+  }
 }
@@ -0,0 +1,371 @@
+// Package cdb contains an implementation of ../db.DB that uses CockroachDB.
+//
+// Every Message is stored as-is, and TaskDriverRuns are rebuilt from their
+// Messages, as in the BigTable implementation. In addition, each Message is
+// applied to a denormalized Steps table which supports cross-run queries.
+//
+// The package also contains an implementation of ../logs.Logs which stores log
+// entries in the same database, so that a Task Driver server which uses
+// CockroachDB does not depend on BigTable.
+package cdb
+
+//go:generate bazelisk run --config=mayberemote //:go -- run ./tosql
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sql/sqlutil"
+	"go.skia.org/infra/task_driver/go/db"
+	"go.skia.org/infra/task_driver/go/td"
+)
+
+const (
+	// DatabaseName is the name of the database.
+	DatabaseName = "taskdriver"
+
+	// defaultSearchLimit is the maximum number of results returned by
+	// queries which do not specify a limit.
+	defaultSearchLimit = 100
+)
+
+// MessageRow is a single td.Message for a Task Driver run.
+type MessageRow struct {
+	TaskID string `sql:"task_id STRING NOT NULL"`
+	// MessageID is the ID of the Message, or its zero-padded index for
+	// older Messages which have no ID.
+	MessageID string    `sql:"message_id STRING NOT NULL"`
+	Timestamp time.Time `sql:"ts TIMESTAMPTZ NOT NULL"`
+	// Message is the gob-encoded td.Message.
+	Message []byte `sql:"message BYTES NOT NULL"`
+
+	primaryKey struct{} `sql:"PRIMARY KEY (task_id, ts, message_id)"`
+}
+
+// StepRow is the current state of a single step, derived from its Messages.
+type StepRow struct {
+	TaskID   string     `sql:"task_id STRING NOT NULL"`
+	StepID   string     `sql:"step_id STRING NOT NULL"`
+	Name     string     `sql:"name STRING NOT NULL DEFAULT ''"`
+	Parent   string     `sql:"parent STRING NOT NULL DEFAULT ''"`
+	IsInfra  bool       `sql:"is_infra BOOL NOT NULL DEFAULT FALSE"`
+	Started  *time.Time `sql:"started TIMESTAMPTZ"`
+	Finished *time.Time `sql:"finished TIMESTAMPTZ"`
+	Result   string     `sql:"result STRING NOT NULL DEFAULT ''"`
+
+	primaryKey   struct{} `sql:"PRIMARY KEY (task_id, step_id)"`
+	byNameIndex  struct{} `sql:"INDEX by_name (name, started)"`
+	byStartIndex struct{} `sql:"INDEX by_started (started)"`
+}
+
+// LogRow is a single log entry for a Task Driver run.
+type LogRow struct {
+	TaskID    string    `sql:"task_id STRING NOT NULL"`
+	StepID    string    `sql:"step_id STRING NOT NULL"`
+	LogID     string    `sql:"log_id STRING NOT NULL"`
+	Timestamp time.Time `sql:"ts TIMESTAMPTZ NOT NULL"`
+	InsertID  string    `sql:"insert_id STRING NOT NULL"`
+	// Entry is the gob-encoded logs.Entry.
+	Entry []byte `sql:"entry BYTES NOT NULL"`
+
+	primaryKey struct{} `sql:"PRIMARY KEY (task_id, step_id, log_id, ts, insert_id)"`
+}
+
+// Tables represents all SQL tables used by the Task Driver DB.
+type Tables struct {
+	Messages []MessageRow
+	Steps    []StepRow
+	Logs     []LogRow
+}
+
+// statement is an SQL statement or fragment of an SQL statement.
+type statement int
+
+// All the different statements we need. Each statement will appear in Statements.
+const (
+	InsertMessage statement = iota
+	GetMessages
+	StepStarted
+	StepFinished
+	StepResult
+	SearchSteps
+	SlowestSteps
+	InsertLog
+	SearchLogs
+)
+
+// Statements are all the SQL statements used by DB.
+var Statements = map[statement]string{
+	InsertMessage: fmt.Sprintf(`
+UPSERT INTO
+	Messages (%s)
+VALUES
+	%s
+`, strings.Join(Messages, ","), sqlutil.ValuesPlaceholders(len(Messages), 1)),
+	GetMessages: `
+SELECT
+	message
+FROM
+	Messages
+WHERE
+	task_id = $1
+ORDER BY
+	ts, message_id
+`,
+	StepStarted: `
+INSERT INTO
+	Steps (task_id, step_id, name, parent, is_infra, started)
+VALUES
+	($1, $2, $3, $4, $5, $6)
+ON CONFLICT (task_id, step_id) DO UPDATE SET
+	name = excluded.name,
+	parent = excluded.parent,
+	is_infra = excluded.is_infra,
+	started = excluded.started
+`,
+	// The STEP_FINISHED message may arrive before STEP_FAILED, in which
+	// case the result is corrected when STEP_FAILED arrives.
+	StepFinished: `
+INSERT INTO
+	Steps (task_id, step_id, finished, result)
+VALUES
+	($1, $2, $3, 'SUCCESS')
+ON CONFLICT (task_id, step_id) DO UPDATE SET
+	finished = excluded.finished,
+	result = IF(Steps.result = '', excluded.result, Steps.result)
+`,
+	StepResult: `
+INSERT INTO
+	Steps (task_id, step_id, result)
+VALUES
+	($1, $2, $3)
+ON CONFLICT (task_id, step_id) DO UPDATE SET
+	result = excluded.result
+`,
+	// The name of the task is the name of its root step.
+	SearchSteps: fmt.Sprintf(`
+SELECT
+	s.task_id, s.step_id, s.name, COALESCE(r.name, ''), s.result, s.started, s.finished
+FROM
+	Steps AS s
+	LEFT JOIN Steps AS r ON r.task_id = s.task_id AND r.step_id = '%s'
+WHERE
+	s.started >= $1
+	AND s.started < $2
+	AND ($3 = '' OR s.name = $3)
+	AND ($4 = '' OR s.result = $4)
+	AND ($5 = 0 OR (s.finished IS NOT NULL AND s.finished - s.started >= $5 * INTERVAL '1 microsecond'))
+	AND ($6 = 0 OR (s.finished IS NOT NULL AND s.finished - s.started < $6 * INTERVAL '1 microsecond'))
+ORDER BY
+	s.started DESC
+LIMIT
+	$7
+`, td.StepIDRoot),
+	SlowestSteps: fmt.Sprintf(`
+SELECT
+	s.name,
+	COUNT(*),
+	AVG(EXTRACT(EPOCH FROM (s.finished - s.started))),
+	MAX(EXTRACT(EPOCH FROM (s.finished - s.started)))
+FROM
+	Steps AS r
+	JOIN Steps AS s ON s.task_id = r.task_id
+WHERE
+	r.step_id = '%s'
+	AND r.name = $1
+	AND r.started >= $2
+	AND r.started < $3
+	AND s.step_id != '%s'
+	AND s.started IS NOT NULL
+	AND s.finished IS NOT NULL
+GROUP BY
+	s.name
+ORDER BY
+	3 DESC, s.name
+LIMIT
+	$4
+`, td.StepIDRoot, td.StepIDRoot),
+	InsertLog: fmt.Sprintf(`
+UPSERT INTO
+	Logs (%s)
+VALUES
+	%s
+`, strings.Join(Logs, ","), sqlutil.ValuesPlaceholders(len(Logs), 1)),
+	SearchLogs: `
+SELECT
+	entry
+FROM
+	Logs
+WHERE
+	task_id = $1
+	AND ($2 = '' OR step_id = $2)
+	AND ($3 = '' OR log_id = $3)
+ORDER BY
+	step_id, log_id, ts, insert_id
+`,
+}
+
+// DB implements db.DB and db.SearchDB using CockroachDB.
+type DB struct {
+	db *pgxpool.Pool
+}
+
+// New returns a DB which uses the given Pool.
+func New(pool *pgxpool.Pool) *DB {
+	return &DB{
+		db: pool,
+	}
+}
+
+// wrappedError unwraps and re-wraps a pgconn.PgError to give more details on
+// the failure.
+func wrappedError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return skerr.Wrapf(err, "Mgs: %s, Code: %s, Detail: %s, Hint: %s", pgErr.Message, pgErr.Code, pgErr.Detail, pgErr.Hint)
+	}
+	return skerr.Wrap(err)
+}
+
+// Close implements db.DB.
+func (d *DB) Close() error {
+	d.db.Close()
+	return nil
+}
+
+// GetTaskDriver implements db.DB.
+func (d *DB) GetTaskDriver(ctx context.Context, id string) (*db.TaskDriverRun, error) {
+	ctx, span := trace.StartSpan(ctx, "cdb_GetTaskDriver")
+	defer span.End()
+	rows, err := d.db.Query(ctx, Statements[GetMessages], id)
+	if err != nil {
+		return nil, wrappedError(err)
+	}
+	defer rows.Close()
+	var rv *db.TaskDriverRun
+	for rows.Next() {
+		var b []byte
+		if err := rows.Scan(&b); err != nil {
+			return nil, wrappedError(err)
+		}
+		var msg td.Message
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&msg); err != nil {
+			return nil, skerr.Wrapf(err, "failed to gob-decode message")
+		}
+		if rv == nil {
+			rv = &db.TaskDriverRun{
+				TaskId: id,
+			}
+		}
+		if err := rv.UpdateFromMessage(&msg); err != nil {
+			return nil, skerr.Wrapf(err, "failed to apply update to TaskDriverRun")
+		}
+	}
+	return rv, wrappedError(rows.Err())
+}
+
+// UpdateTaskDriver implements db.DB.
+func (d *DB) UpdateTaskDriver(ctx context.Context, id string, msg *td.Message) error {
+	ctx, span := trace.StartSpan(ctx, "cdb_UpdateTaskDriver")
+	defer span.End()
+	buf := bytes.Buffer{}
+	if err := gob.NewEncoder(&buf).Encode(msg); err != nil {
+		return skerr.Wrapf(err, "failed to gob-encode Message")
+	}
+	msgID := msg.ID
+	if msgID == "" {
+		msgID = fmt.Sprintf("%010d", msg.Index)
+	}
+	return wrappedError(d.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, Statements[InsertMessage], id, msgID, msg.Timestamp, buf.Bytes()); err != nil {
+			return err
+		}
+		var err error
+		switch msg.Type {
+		case td.MsgType_StepStarted:
+			if msg.Step == nil {
+				return skerr.Fmt("Step properties are required.")
+			}
+			_, err = tx.Exec(ctx, Statements[StepStarted], id, msg.StepId, msg.Step.Name, msg.Step.Parent, msg.Step.IsInfra, msg.Timestamp)
+		case td.MsgType_StepFinished:
+			_, err = tx.Exec(ctx, Statements[StepFinished], id, msg.StepId, msg.Timestamp)
+		case td.MsgType_StepFailed:
+			_, err = tx.Exec(ctx, Statements[StepResult], id, msg.StepId, string(td.StepResultFailure))
+		case td.MsgType_StepException:
+			_, err = tx.Exec(ctx, Statements[StepResult], id, msg.StepId, string(td.StepResultException))
+		}
+		return err
+	}))
+}
+
+// SearchSteps implements db.SearchDB.
+func (d *DB) SearchSteps(ctx context.Context, params *db.StepSearchParams) ([]*db.StepSummary, error) {
+	ctx, span := trace.StartSpan(ctx, "cdb_SearchSteps")
+	defer span.End()
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	rows, err := d.db.Query(ctx, Statements[SearchSteps], params.Start, params.End, params.StepName, string(params.Result), params.MinDuration.Microseconds(), params.MaxDuration.Microseconds(), limit)
+	if err != nil {
+		return nil, wrappedError(err)
+	}
+	defer rows.Close()
+	rv := []*db.StepSummary{}
+	for rows.Next() {
+		var s db.StepSummary
+		var result string
+		var finished *time.Time
+		if err := rows.Scan(&s.TaskId, &s.StepId, &s.Name, &s.TaskName, &result, &s.Started, &finished); err != nil {
+			return nil, wrappedError(err)
+		}
+		s.Result = td.StepResult(result)
+		if finished != nil {
+			s.Finished = *finished
+		}
+		rv = append(rv, &s)
+	}
+	return rv, wrappedError(rows.Err())
+}
+
+// SlowestSteps implements db.SearchDB.
+func (d *DB) SlowestSteps(ctx context.Context, taskName string, start, end time.Time, limit int) ([]*db.StepDurationStats, error) {
+	ctx, span := trace.StartSpan(ctx, "cdb_SlowestSteps")
+	defer span.End()
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	rows, err := d.db.Query(ctx, Statements[SlowestSteps], taskName, start, end, limit)
+	if err != nil {
+		return nil, wrappedError(err)
+	}
+	defer rows.Close()
+	rv := []*db.StepDurationStats{}
+	for rows.Next() {
+		var s db.StepDurationStats
+		var avg, max float64
+		if err := rows.Scan(&s.Name, &s.Count, &avg, &max); err != nil {
+			return nil, wrappedError(err)
+		}
+		s.Mean = time.Duration(avg * float64(time.Second))
+		s.Max = time.Duration(max * float64(time.Second))
+		rv = append(rv, &s)
+	}
+	return rv, wrappedError(rows.Err())
+}
+
+var _ db.DB = &DB{}
+var _ db.SearchDB = &DB{}
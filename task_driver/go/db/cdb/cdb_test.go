@@ -0,0 +1,165 @@
+package cdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_driver/go/db"
+	"go.skia.org/infra/task_driver/go/db/cdb"
+	"go.skia.org/infra/task_driver/go/db/cdb/cdbtest"
+	"go.skia.org/infra/task_driver/go/db/shared_tests"
+	"go.skia.org/infra/task_driver/go/logs"
+	"go.skia.org/infra/task_driver/go/td"
+)
+
+func setup(t *testing.T) *cdb.DB {
+	return cdb.New(cdbtest.NewCockroachDBForTests(t, cdb.DatabaseName))
+}
+
+func TestCDB(t *testing.T) {
+	shared_tests.TestDB(t, setup(t))
+}
+
+func TestCDBMessageOrdering(t *testing.T) {
+	shared_tests.TestMessageOrdering(t, setup(t))
+}
+
+var startTime = time.Unix(1600000000, 0).UTC()
+
+// addStep inserts the messages for a finished step which started at the given
+// offset from startTime.
+func addStep(t *testing.T, d *cdb.DB, taskID, stepID, parent, name string, offset, dur time.Duration, result td.StepResult) {
+	ctx := context.Background()
+	msgs := []*td.Message{
+		{
+			Type:      td.MsgType_StepStarted,
+			Timestamp: startTime.Add(offset),
+			Step: &td.StepProperties{
+				Id:     stepID,
+				Name:   name,
+				Parent: parent,
+			},
+		},
+	}
+	if result != td.StepResultSuccess {
+		msgs = append(msgs, &td.Message{
+			Type:      td.MsgType_StepFailed,
+			Timestamp: startTime.Add(offset + dur),
+			Error:     "failed",
+		})
+	}
+	// Insert the STEP_FINISHED message last to verify that it does not
+	// overwrite the failure.
+	msgs = append(msgs, &td.Message{
+		Type:      td.MsgType_StepFinished,
+		Timestamp: startTime.Add(offset + dur),
+	})
+	for _, msg := range msgs {
+		msg.ID = uuid.New().String()
+		msg.TaskId = taskID
+		msg.StepId = stepID
+		require.NoError(t, d.UpdateTaskDriver(ctx, taskID, msg))
+	}
+}
+
+func TestSearchSteps_FiltersByNameResultAndDuration(t *testing.T) {
+	d := setup(t)
+	ctx := context.Background()
+	addStep(t, d, "task1", td.StepIDRoot, "", "Build", 0, 10*time.Minute, td.StepResultFailure)
+	addStep(t, d, "task1", "compile", td.StepIDRoot, "compile", time.Minute, 5*time.Minute, td.StepResultFailure)
+	addStep(t, d, "task2", td.StepIDRoot, "", "Build", time.Hour, 10*time.Minute, td.StepResultSuccess)
+	addStep(t, d, "task2", "compile", td.StepIDRoot, "compile", time.Hour+time.Minute, 2*time.Minute, td.StepResultSuccess)
+
+	steps, err := d.SearchSteps(ctx, &db.StepSearchParams{
+		Start:    startTime,
+		End:      startTime.Add(24 * time.Hour),
+		StepName: "compile",
+	})
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	require.Equal(t, &db.StepSummary{
+		TaskId:   "task2",
+		TaskName: "Build",
+		StepId:   "compile",
+		Name:     "compile",
+		Result:   td.StepResultSuccess,
+		Started:  startTime.Add(time.Hour + time.Minute),
+		Finished: startTime.Add(time.Hour + 3*time.Minute),
+	}, steps[0])
+	require.Equal(t, td.StepResultFailure, steps[1].Result)
+
+	steps, err = d.SearchSteps(ctx, &db.StepSearchParams{
+		Start:       startTime,
+		End:         startTime.Add(24 * time.Hour),
+		Result:      td.StepResultFailure,
+		MinDuration: 4 * time.Minute,
+		MaxDuration: 6 * time.Minute,
+	})
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	require.Equal(t, "task1", steps[0].TaskId)
+	require.Equal(t, "compile", steps[0].StepId)
+}
+
+func TestSlowestSteps_ReturnsStepsOfNamedTaskSlowestFirst(t *testing.T) {
+	d := setup(t)
+	addStep(t, d, "task1", td.StepIDRoot, "", "Build", 0, 10*time.Minute, td.StepResultSuccess)
+	addStep(t, d, "task1", "compile", td.StepIDRoot, "compile", 0, 6*time.Minute, td.StepResultSuccess)
+	addStep(t, d, "task1", "checkout", td.StepIDRoot, "checkout", 0, 3*time.Minute, td.StepResultSuccess)
+	addStep(t, d, "task2", td.StepIDRoot, "", "Build", time.Hour, 10*time.Minute, td.StepResultSuccess)
+	addStep(t, d, "task2", "compile", td.StepIDRoot, "compile", time.Hour, 2*time.Minute, td.StepResultSuccess)
+	// Steps of other tasks are ignored.
+	addStep(t, d, "task3", td.StepIDRoot, "", "Test", 0, time.Hour, td.StepResultSuccess)
+	addStep(t, d, "task3", "compile", td.StepIDRoot, "compile", 0, time.Hour, td.StepResultSuccess)
+
+	stats, err := d.SlowestSteps(context.Background(), "Build", startTime, startTime.Add(24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Equal(t, []*db.StepDurationStats{
+		{Name: "compile", Count: 2, Mean: 4 * time.Minute, Max: 6 * time.Minute},
+		{Name: "checkout", Count: 1, Mean: 3 * time.Minute, Max: 3 * time.Minute},
+	}, stats)
+}
+
+func TestLogsDB_InsertAndSearch(t *testing.T) {
+	ctx := context.Background()
+	l := cdb.NewLogsDB(cdbtest.NewCockroachDBForTests(t, cdb.DatabaseName))
+	entry := func(stepID, logID string, offset time.Duration, text string) *logs.Entry {
+		return &logs.Entry{
+			InsertID: uuid.New().String(),
+			Labels: map[string]string{
+				"taskId": "task1",
+				"stepId": stepID,
+				"logId":  logID,
+			},
+			TextPayload: text,
+			Timestamp:   startTime.Add(offset),
+		}
+	}
+	compileStdout2 := entry("compile", "stdout", 2*time.Second, "compiled b.cpp")
+	compileStdout1 := entry("compile", "stdout", time.Second, "compiled a.cpp")
+	compileStderr := entry("compile", "stderr", time.Second, "warning")
+	testStdout := entry("test", "stdout", 3*time.Second, "PASS")
+	for _, e := range []*logs.Entry{compileStdout2, compileStdout1, compileStderr, testStdout} {
+		require.NoError(t, l.Insert(ctx, e))
+	}
+	require.Error(t, l.Insert(ctx, &logs.Entry{Timestamp: startTime}))
+
+	entries, err := l.Search(ctx, "task1", "compile", "stdout")
+	require.NoError(t, err)
+	require.Equal(t, []*logs.Entry{compileStdout1, compileStdout2}, entries)
+
+	entries, err = l.Search(ctx, "task1", "compile", "")
+	require.NoError(t, err)
+	require.Equal(t, []*logs.Entry{compileStderr, compileStdout1, compileStdout2}, entries)
+
+	entries, err = l.Search(ctx, "task1", "", "")
+	require.NoError(t, err)
+	require.Equal(t, []*logs.Entry{compileStderr, compileStdout1, compileStdout2, testStdout}, entries)
+
+	entries, err = l.Search(ctx, "task2", "", "")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
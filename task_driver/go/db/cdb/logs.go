@@ -0,0 +1,92 @@
+package cdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/task_driver/go/logs"
+)
+
+const (
+	// Log entries without a step or log ID are stored under these IDs, as in
+	// the BigTable implementation.
+	noStepID = "no_step_id"
+	noLogID  = "no_log_id"
+)
+
+// LogsDB implements logs.Logs using CockroachDB.
+type LogsDB struct {
+	db *pgxpool.Pool
+}
+
+// NewLogsDB returns a LogsDB which uses the given Pool.
+func NewLogsDB(pool *pgxpool.Pool) *LogsDB {
+	return &LogsDB{
+		db: pool,
+	}
+}
+
+// Close implements logs.Logs.
+func (l *LogsDB) Close() error {
+	l.db.Close()
+	return nil
+}
+
+// Insert implements logs.Logs.
+func (l *LogsDB) Insert(ctx context.Context, e *logs.Entry) error {
+	ctx, span := trace.StartSpan(ctx, "cdb_LogsDB_Insert")
+	defer span.End()
+	taskID, ok := e.Labels["taskId"]
+	if !ok {
+		return skerr.Fmt("Log entry is missing a task ID! %+v", e)
+	}
+	stepID, ok := e.Labels["stepId"]
+	if !ok {
+		stepID = noStepID
+	}
+	logID, ok := e.Labels["logId"]
+	if !ok {
+		logID = noLogID
+	}
+	buf := bytes.Buffer{}
+	if err := gob.NewEncoder(&buf).Encode(e); err != nil {
+		return skerr.Wrapf(err, "failed to gob-encode log entry")
+	}
+	_, err := l.db.Exec(ctx, Statements[InsertLog], taskID, stepID, logID, e.Timestamp, e.InsertID, buf.Bytes())
+	return wrappedError(err)
+}
+
+// Search implements logs.Logs.
+func (l *LogsDB) Search(ctx context.Context, taskID, stepID, logID string) ([]*logs.Entry, error) {
+	ctx, span := trace.StartSpan(ctx, "cdb_LogsDB_Search")
+	defer span.End()
+	if stepID == "" {
+		// As in the BigTable implementation, a log ID is only used
+		// together with a step ID.
+		logID = ""
+	}
+	rows, err := l.db.Query(ctx, Statements[SearchLogs], taskID, stepID, logID)
+	if err != nil {
+		return nil, wrappedError(err)
+	}
+	defer rows.Close()
+	rv := []*logs.Entry{}
+	for rows.Next() {
+		var b []byte
+		if err := rows.Scan(&b); err != nil {
+			return nil, wrappedError(err)
+		}
+		var e logs.Entry
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&e); err != nil {
+			return nil, skerr.Wrapf(err, "failed to gob-decode log entry")
+		}
+		rv = append(rv, &e)
+	}
+	return rv, wrappedError(rows.Err())
+}
+
+var _ logs.Logs = &LogsDB{}
@@ -0,0 +1,62 @@
+package cdb
+
+// Generated by //go/sql/exporter/
+// DO NOT EDIT
+
+const Schema = `CREATE TABLE IF NOT EXISTS Messages (
+  task_id STRING NOT NULL,
+  message_id STRING NOT NULL,
+  ts TIMESTAMPTZ NOT NULL,
+  message BYTES NOT NULL,
+  PRIMARY KEY (task_id, ts, message_id)
+);
+CREATE TABLE IF NOT EXISTS Steps (
+  task_id STRING NOT NULL,
+  step_id STRING NOT NULL,
+  name STRING NOT NULL DEFAULT '',
+  parent STRING NOT NULL DEFAULT '',
+  is_infra BOOL NOT NULL DEFAULT FALSE,
+  started TIMESTAMPTZ,
+  finished TIMESTAMPTZ,
+  result STRING NOT NULL DEFAULT '',
+  PRIMARY KEY (task_id, step_id),
+  INDEX by_name (name, started),
+  INDEX by_started (started)
+);
+CREATE TABLE IF NOT EXISTS Logs (
+  task_id STRING NOT NULL,
+  step_id STRING NOT NULL,
+  log_id STRING NOT NULL,
+  ts TIMESTAMPTZ NOT NULL,
+  insert_id STRING NOT NULL,
+  entry BYTES NOT NULL,
+  PRIMARY KEY (task_id, step_id, log_id, ts, insert_id)
+);
+`
+
+var Messages = []string{
+	"task_id",
+	"message_id",
+	"ts",
+	"message",
+}
+
+var Steps = []string{
+	"task_id",
+	"step_id",
+	"name",
+	"parent",
+	"is_infra",
+	"started",
+	"finished",
+	"result",
+}
+
+var Logs = []string{
+	"task_id",
+	"step_id",
+	"log_id",
+	"ts",
+	"insert_id",
+	"entry",
+}
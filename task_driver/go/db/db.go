@@ -38,6 +38,51 @@ type DB interface {
 	Close() error
 }
 
+// SearchDB is implemented by DBs which support queries across Task Driver
+// runs.
+type SearchDB interface {
+	// SearchSteps returns the steps matching the given parameters, most
+	// recently started first.
+	SearchSteps(context.Context, *StepSearchParams) ([]*StepSummary, error)
+
+	// SlowestSteps returns duration statistics for the finished steps of
+	// the Task Driver runs with the given name which started within the
+	// given time range, slowest mean first.
+	SlowestSteps(ctx context.Context, taskName string, start, end time.Time, limit int) ([]*StepDurationStats, error)
+}
+
+// StepSearchParams are the parameters for SearchSteps. Zero values match any
+// step, except that Start and End are required.
+type StepSearchParams struct {
+	Start       time.Time
+	End         time.Time
+	StepName    string
+	Result      td.StepResult
+	MinDuration time.Duration
+	MaxDuration time.Duration
+	Limit       int
+}
+
+// StepSummary describes one step of a Task Driver run, without its data.
+type StepSummary struct {
+	TaskId string `json:"taskId"`
+	// TaskName is the name of the root step of the run.
+	TaskName string        `json:"taskName"`
+	StepId   string        `json:"stepId"`
+	Name     string        `json:"name"`
+	Result   td.StepResult `json:"result"`
+	Started  time.Time     `json:"started"`
+	Finished time.Time     `json:"finished"`
+}
+
+// StepDurationStats describes the durations of all steps with a given name.
+type StepDurationStats struct {
+	Name  string        `json:"name"`
+	Count int           `json:"count"`
+	Mean  time.Duration `json:"mean"`
+	Max   time.Duration `json:"max"`
+}
+
 // Step represents one step in a single run of a Task Driver.
 type Step struct {
 	Properties *td.StepProperties `json:"properties"`
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.opencensus.io/trace"
 
@@ -19,7 +20,7 @@ import (
 )
 
 // logsHandler reads log entries from BigTable and writes them to the ResponseWriter.
-func logsHandler(w http.ResponseWriter, r *http.Request, lm *logs.LogsManager, taskId, stepId, logId string) {
+func logsHandler(w http.ResponseWriter, r *http.Request, lm logs.Logs, taskId, stepId, logId string) {
 	// TODO(borenet): If we had access to the Task Driver DB, we could first
 	// retrieve the run and then limit our search to its duration. That
 	// might speed up the search quite a bit.
@@ -70,7 +71,7 @@ func getVar(w http.ResponseWriter, r *http.Request, key string) string {
 }
 
 // taskLogsHandler returns a handler which serves logs for a given task.
-func taskLogsHandler(lm *logs.LogsManager) http.HandlerFunc {
+func taskLogsHandler(lm logs.Logs) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		taskId := getVar(w, r, "taskId")
 		if taskId == "" {
@@ -81,7 +82,7 @@ func taskLogsHandler(lm *logs.LogsManager) http.HandlerFunc {
 }
 
 // stepLogsHandler returns a handler which serves logs for a given step.
-func stepLogsHandler(lm *logs.LogsManager) http.HandlerFunc {
+func stepLogsHandler(lm logs.Logs) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		taskId := getVar(w, r, "taskId")
 		stepId := getVar(w, r, "stepId")
@@ -93,7 +94,7 @@ func stepLogsHandler(lm *logs.LogsManager) http.HandlerFunc {
 }
 
 // singleLogHandler returns a handler which serves logs for a single log ID.
-func singleLogHandler(lm *logs.LogsManager) http.HandlerFunc {
+func singleLogHandler(lm logs.Logs) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		taskId := getVar(w, r, "taskId")
 		stepId := getVar(w, r, "stepId")
@@ -205,12 +206,136 @@ func fullErrorHandler(d db.DB) http.HandlerFunc {
 	}
 }
 
-// AddTaskDriverHandlers adds handlers for Task Drivers to the given Router.
-func AddTaskDriverHandlers(r chi.Router, d db.DB, lm *logs.LogsManager) {
+// defaultSearchPeriod is the time range searched if a request does not specify
+// a start time.
+const defaultSearchPeriod = 7 * 24 * time.Hour
+
+// parseTimeRange parses the optional "start" and "end" RFC3339 query
+// parameters. The range ends at the given time and covers defaultSearchPeriod
+// by default.
+func parseTimeRange(r *http.Request, now time.Time) (time.Time, time.Time, error) {
+	start := now.Add(-defaultSearchPeriod)
+	end := now
+	for key, dest := range map[string]*time.Time{"start": &start, "end": &end} {
+		if v := r.FormValue(key); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("Invalid %s: %s", key, err)
+			}
+			*dest = t
+		}
+	}
+	return start, end, nil
+}
+
+// parseStepSearchParams parses db.StepSearchParams from the query parameters of
+// the given request.
+func parseStepSearchParams(r *http.Request, now time.Time) (*db.StepSearchParams, error) {
+	start, end, err := parseTimeRange(r, now)
+	if err != nil {
+		return nil, err
+	}
+	rv := &db.StepSearchParams{
+		Start:    start,
+		End:      end,
+		StepName: r.FormValue("step"),
+		Result:   td.StepResult(r.FormValue("result")),
+	}
+	switch rv.Result {
+	case "", td.StepResultSuccess, td.StepResultFailure, td.StepResultException:
+	default:
+		return nil, fmt.Errorf("Invalid result %q", rv.Result)
+	}
+	for key, dest := range map[string]*time.Duration{"min_duration": &rv.MinDuration, "max_duration": &rv.MaxDuration} {
+		if v := r.FormValue(key); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid %s: %s", key, err)
+			}
+			*dest = d
+		}
+	}
+	if v := r.FormValue("limit"); v != "" {
+		rv.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid limit: %s", err)
+		}
+	}
+	return rv, nil
+}
+
+// searchStepsHandler returns the steps matching the query parameters "step",
+// "result", "min_duration", "max_duration", "start", "end" and "limit".
+func searchStepsHandler(d db.SearchDB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := trace.StartSpan(r.Context(), "searchStepsHandler")
+		defer span.End()
+		params, err := parseStepSearchParams(r, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		steps, err := d.SearchSteps(ctx, params)
+		if err != nil {
+			httputils.ReportError(w, err, "Failed to search steps.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(steps); err != nil {
+			httputils.ReportError(w, err, "Failed to encode response.", http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// slowestStepsHandler returns the slowest steps of the task named by the "task"
+// query parameter, within the time range given by "start" and "end".
+func slowestStepsHandler(d db.SearchDB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := trace.StartSpan(r.Context(), "slowestStepsHandler")
+		defer span.End()
+		taskName := r.FormValue("task")
+		if taskName == "" {
+			http.Error(w, "Parameter \"task\" is required.", http.StatusBadRequest)
+			return
+		}
+		start, end, err := parseTimeRange(r, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		limit := 0
+		if v := r.FormValue("limit"); v != "" {
+			limit, err = strconv.Atoi(v)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid limit: %s", err), http.StatusBadRequest)
+				return
+			}
+		}
+		stats, err := d.SlowestSteps(ctx, taskName, start, end, limit)
+		if err != nil {
+			httputils.ReportError(w, err, "Failed to retrieve step durations.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(stats); err != nil {
+			httputils.ReportError(w, err, "Failed to encode response.", http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// AddTaskDriverHandlers adds handlers for Task Drivers to the given Router. If
+// the DB supports searching, search handlers are also added.
+func AddTaskDriverHandlers(r chi.Router, d db.DB, lm logs.Logs) {
 	r.HandleFunc("/json/td/{taskId}", httputils.CorsHandler(jsonTaskDriverHandler(d)))
 	r.HandleFunc("/errors/{taskId}/{errId}", fullErrorHandler(d))
 	r.HandleFunc("/errors/{taskId}/{stepId}/{errId}", fullErrorHandler(d))
 	r.HandleFunc("/logs/{taskId}", taskLogsHandler(lm))
 	r.HandleFunc("/logs/{taskId}/{stepId}", stepLogsHandler(lm))
 	r.HandleFunc("/logs/{taskId}/{stepId}/{logId}", singleLogHandler(lm))
+	if sd, ok := d.(db.SearchDB); ok {
+		r.HandleFunc("/json/search/steps", httputils.CorsHandler(searchStepsHandler(sd)))
+		r.HandleFunc("/json/search/slowest", httputils.CorsHandler(slowestStepsHandler(sd)))
+	}
 }
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_driver/go/db"
+	"go.skia.org/infra/task_driver/go/td"
+)
+
+func TestParseStepSearchParams_AllParams_Success(t *testing.T) {
+	now := time.Unix(1600000000, 0).UTC()
+	r := httptest.NewRequest("GET", "/json/search/steps?step=compile&result=FAILURE&min_duration=5m&max_duration=1h&start=2020-09-01T00:00:00Z&limit=10", nil)
+	params, err := parseStepSearchParams(r, now)
+	require.NoError(t, err)
+	require.Equal(t, &db.StepSearchParams{
+		Start:       time.Date(2020, time.September, 1, 0, 0, 0, 0, time.UTC),
+		End:         now,
+		StepName:    "compile",
+		Result:      td.StepResultFailure,
+		MinDuration: 5 * time.Minute,
+		MaxDuration: time.Hour,
+		Limit:       10,
+	}, params)
+}
+
+func TestParseStepSearchParams_NoParams_DefaultsToLastWeek(t *testing.T) {
+	now := time.Unix(1600000000, 0).UTC()
+	params, err := parseStepSearchParams(httptest.NewRequest("GET", "/json/search/steps", nil), now)
+	require.NoError(t, err)
+	require.Equal(t, &db.StepSearchParams{
+		Start: now.Add(-defaultSearchPeriod),
+		End:   now,
+	}, params)
+}
+
+func TestParseStepSearchParams_InvalidParams_ReturnsError(t *testing.T) {
+	test := func(query, expectErr string) {
+		_, err := parseStepSearchParams(httptest.NewRequest("GET", "/json/search/steps?"+query, nil), time.Now())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), expectErr)
+	}
+	test("result=BOGUS", "Invalid result")
+	test("min_duration=soon", "Invalid min_duration")
+	test("end=yesterday", "Invalid end")
+	test("limit=many", "Invalid limit")
+}
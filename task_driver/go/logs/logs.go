@@ -2,7 +2,7 @@ package logs
 
 /*
 	The logs package provides an interface for inserting and retrieving
-	Task Driver logs in Cloud BigTable.
+	Task Driver logs, and an implementation of it using Cloud BigTable.
 */
 
 import (
@@ -96,6 +96,20 @@ type Entry struct {
 	Timestamp   time.Time   `json:"timestamp"`
 }
 
+// Logs provides an interface for inserting and retrieving Task Driver logs.
+type Logs interface {
+	// Insert the given log entry.
+	Insert(ctx context.Context, e *Entry) error
+
+	// Search returns Entries matching the given search terms. stepId and
+	// logId may be empty, in which case all entries for the task or step
+	// are returned, ordered by step ID, log ID and timestamp.
+	Search(ctx context.Context, taskId, stepId, logId string) ([]*Entry, error)
+
+	// Close the Logs.
+	Close() error
+}
+
 // LogsManager is a struct which provides an interface for inserting and
 // retrieving Task Driver logs in Cloud BigTable.
 type LogsManager struct {
@@ -184,3 +198,5 @@ func (m *LogsManager) Search(ctx context.Context, taskId, stepId, logId string)
 	}
 	return entries, nil
 }
+
+var _ Logs = &LogsManager{}
@@ -20,6 +20,7 @@ import (
 	"cloud.google.com/go/pubsub"
 	"contrib.go.opencensus.io/exporter/stackdriver"
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v4/pgxpool"
 	"go.opencensus.io/trace"
 	"golang.org/x/oauth2/google"
 
@@ -31,6 +32,7 @@ import (
 	"go.skia.org/infra/go/tracing"
 	"go.skia.org/infra/task_driver/go/db"
 	bigtable_db "go.skia.org/infra/task_driver/go/db/bigtable"
+	"go.skia.org/infra/task_driver/go/db/cdb"
 	"go.skia.org/infra/task_driver/go/display"
 	"go.skia.org/infra/task_driver/go/handlers"
 	"go.skia.org/infra/task_driver/go/logs"
@@ -45,8 +47,9 @@ const (
 
 var (
 	// Flags.
-	btInstance   = flag.String("bigtable_instance", "", "BigTable instance to use.")
-	btProject    = flag.String("bigtable_project", "", "GCE project to use for BigTable.")
+	btInstance   = flag.String("bigtable_instance", "", "BigTable instance to use. Not used if --cockroachdb_connection_string is set.")
+	btProject    = flag.String("bigtable_project", "", "GCE project to use for BigTable. Not used if --cockroachdb_connection_string is set.")
+	cdbConnStr   = flag.String("cockroachdb_connection_string", "", "If set, store Task Drivers and their logs in the CockroachDB database with this connection string instead of BigTable, and enable search.")
 	host         = flag.String("host", "localhost", "HTTP service host")
 	local        = flag.Bool("local", false, "Running locally if true. As opposed to in production.")
 	port         = flag.String("port", ":8000", "HTTP service port (e.g., ':8000')")
@@ -58,14 +61,14 @@ var (
 	// Database used for storing and retrieving Task Drivers.
 	d db.DB
 
-	// BigTable connector used for storing and retrieving logs.
-	lm *logs.LogsManager
+	// Used for storing and retrieving logs.
+	lm logs.Logs
 
 	// HTML templates.
 	tdTemplate *template.Template = nil
 )
 
-// logsHandler reads log entries from BigTable and writes them to the ResponseWriter.
+// logsHandler reads log entries from the logs store and writes them to the ResponseWriter.
 func logsHandler(w http.ResponseWriter, r *http.Request, taskId, stepId, logId string) {
 	// TODO(borenet): If we had access to the Task Driver DB, we could first
 	// retrieve the run and then limit our search to its duration. That
@@ -322,18 +325,30 @@ func main() {
 	}
 	sub.ReceiveSettings.MaxOutstandingMessages = subscriptionMaxOutstandingMessages
 
-	// Create the TaskDriver DB.
-	ts, err := google.DefaultTokenSource(ctx, bigtable.Scope)
-	if err != nil {
-		sklog.Fatal(err)
-	}
-	d, err = bigtable_db.NewBigTableDB(ctx, *btProject, *btInstance, ts)
-	if err != nil {
-		sklog.Fatal(err)
-	}
-	lm, err = logs.NewLogsManager(ctx, *btProject, *btInstance, ts)
-	if err != nil {
-		sklog.Fatal(err)
+	// Create the TaskDriver DB and logs store.
+	if *cdbConnStr != "" {
+		pool, err := pgxpool.Connect(ctx, *cdbConnStr)
+		if err != nil {
+			sklog.Fatalf("Failed to connect to CockroachDB: %s", err)
+		}
+		if _, err := pool.Exec(ctx, cdb.Schema); err != nil {
+			sklog.Fatalf("Failed to create tables: %s", err)
+		}
+		d = cdb.New(pool)
+		lm = cdb.NewLogsDB(pool)
+	} else {
+		ts, err := google.DefaultTokenSource(ctx, bigtable.Scope)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+		d, err = bigtable_db.NewBigTableDB(ctx, *btProject, *btInstance, ts)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+		lm, err = logs.NewLogsManager(ctx, *btProject, *btInstance, ts)
+		if err != nil {
+			sklog.Fatal(err)
+		}
 	}
 
 	// Launch a goroutine to listen for pubsub messages.
@@ -5,6 +5,8 @@ package flakes
 */
 
 import (
+	"sort"
+
 	"go.skia.org/infra/task_scheduler/go/types"
 )
 
@@ -42,3 +44,69 @@ func FindFlakes(tasks []*types.Task) []*types.Task {
 	}
 	return flaky
 }
+
+// Stats describes the flakiness of a single TaskSpec in a given repo.
+type Stats struct {
+	Repo string `json:"repo"`
+	Name string `json:"name"`
+	// Runs is the number of finished tasks.
+	Runs int `json:"runs"`
+	// Failures is the number of tasks which failed or mishapped.
+	Failures int `json:"failures"`
+	// Flakes is the number of failures which were flakes, as determined by
+	// FindFlakes.
+	Flakes int `json:"flakes"`
+}
+
+// FlakeRate returns the fraction of runs which were flakes.
+func (s *Stats) FlakeRate() float64 {
+	if s.Runs == 0 {
+		return 0.0
+	}
+	return float64(s.Flakes) / float64(s.Runs)
+}
+
+// statsKey identifies a TaskSpec within a repo.
+type statsKey struct {
+	repo string
+	name string
+}
+
+// ComputeStats returns per-TaskSpec flake statistics for the given tasks,
+// sorted by repo and TaskSpec name. Only finished tasks are counted.
+func ComputeStats(tasks []*types.Task) []*Stats {
+	byKey := map[statsKey]*Stats{}
+	get := func(t *types.Task) *Stats {
+		k := statsKey{repo: t.Repo, name: t.Name}
+		s, ok := byKey[k]
+		if !ok {
+			s = &Stats{Repo: t.Repo, Name: t.Name}
+			byKey[k] = s
+		}
+		return s
+	}
+	for _, t := range tasks {
+		if !t.Done() {
+			continue
+		}
+		s := get(t)
+		s.Runs++
+		if t.Status == types.TASK_STATUS_FAILURE || t.Status == types.TASK_STATUS_MISHAP {
+			s.Failures++
+		}
+	}
+	for _, t := range FindFlakes(tasks) {
+		get(t).Flakes++
+	}
+	rv := make([]*Stats, 0, len(byKey))
+	for _, s := range byKey {
+		rv = append(rv, s)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		if rv[i].Repo != rv[j].Repo {
+			return rv[i].Repo < rv[j].Repo
+		}
+		return rv[i].Name < rv[j].Name
+	})
+	return rv
+}
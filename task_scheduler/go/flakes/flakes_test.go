@@ -0,0 +1,159 @@
+package flakes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/config"
+	"go.skia.org/infra/task_scheduler/go/db/memory"
+	"go.skia.org/infra/task_scheduler/go/skip_tasks"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+func makeTask(repo, name, revision string, status types.TaskStatus, created time.Time) *types.Task {
+	return &types.Task{
+		Created: created,
+		Status:  status,
+		TaskKey: types.TaskKey{
+			RepoState: types.RepoState{
+				Repo:     repo,
+				Revision: revision,
+			},
+			Name: name,
+		},
+	}
+}
+
+func TestComputeStats_MixedResults_CountsFlakes(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	tasks := []*types.Task{
+		// Failed, then succeeded on retry: one flake.
+		makeTask("a.git", "Test", "abc", types.TASK_STATUS_FAILURE, now),
+		makeTask("a.git", "Test", "abc", types.TASK_STATUS_SUCCESS, now),
+		// Consistent failure: not a flake.
+		makeTask("a.git", "Test", "def", types.TASK_STATUS_FAILURE, now),
+		// Mishaps are always flakes.
+		makeTask("a.git", "Build", "abc", types.TASK_STATUS_MISHAP, now),
+		// Unfinished tasks are not counted.
+		makeTask("a.git", "Build", "def", types.TASK_STATUS_RUNNING, now),
+	}
+	stats := ComputeStats(tasks)
+	require.Equal(t, []*Stats{
+		{Repo: "a.git", Name: "Build", Runs: 1, Failures: 1, Flakes: 1},
+		{Repo: "a.git", Name: "Test", Runs: 3, Failures: 2, Flakes: 1},
+	}, stats)
+	require.Equal(t, 1.0, stats[0].FlakeRate())
+	require.InDelta(t, 1.0/3.0, stats[1].FlakeRate(), 0.0001)
+}
+
+func TestParsePolicy_Valid_Success(t *testing.T) {
+	p, err := ParsePolicy([]byte(`{
+		"window": "24h",
+		"min_runs": 10,
+		"extra_attempts": 2,
+		"retry_threshold": 0.1,
+		"retry_on_different_bot": true,
+		"quarantine_threshold": 0.5,
+		"mark_passed_with_flakes": true
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour, p.GetWindow())
+	require.Equal(t, DefaultQuarantineDuration, p.GetQuarantineDuration())
+	require.Equal(t, 2, p.ExtraAttempts)
+	require.True(t, p.RetryOnDifferentBot)
+	require.True(t, p.MarkPassedWithFlakes)
+}
+
+func TestParsePolicy_Invalid_ReturnsError(t *testing.T) {
+	test := func(name, contents, expectErr string) {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(contents))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), expectErr)
+		})
+	}
+	test("bad duration", `{"window": "forever"}`, "invalid duration")
+	test("negative attempts", `{"extra_attempts": -1}`, "invalid extra_attempts")
+	test("retry threshold", `{"retry_threshold": 2}`, "invalid retry_threshold")
+	test("quarantine threshold", `{"quarantine_threshold": -0.5}`, "invalid quarantine_threshold")
+}
+
+func TestTracker_Update_AppliesPolicyToFlakyTaskSpecs(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1600000000, 0)
+	d := memory.NewInMemoryTaskDB()
+	require.NoError(t, d.PutTasks(ctx, []*types.Task{
+		makeTask("a.git", "Flaky", "abc", types.TASK_STATUS_FAILURE, now.Add(-2*time.Hour)),
+		makeTask("a.git", "Flaky", "abc", types.TASK_STATUS_SUCCESS, now.Add(-time.Hour)),
+		makeTask("a.git", "Stable", "abc", types.TASK_STATUS_SUCCESS, now.Add(-time.Hour)),
+		makeTask("a.git", "Stable", "def", types.TASK_STATUS_SUCCESS, now.Add(-time.Hour)),
+		// Outside of the window.
+		makeTask("a.git", "Stable", "012", types.TASK_STATUS_MISHAP, now.Add(-48*time.Hour)),
+	}))
+	tracker := NewTracker(d, nil, nil, &Policy{
+		Window:              config.Duration{Duration: 24 * time.Hour},
+		MinRuns:             2,
+		ExtraAttempts:       2,
+		RetryThreshold:      0.25,
+		RetryOnDifferentBot: true,
+	})
+	require.NoError(t, tracker.Update(ctx, now))
+
+	require.Equal(t, &Stats{Repo: "a.git", Name: "Flaky", Runs: 2, Failures: 1, Flakes: 1}, tracker.Get("a.git", "Flaky"))
+	require.Equal(t, &Stats{Repo: "a.git", Name: "Stable", Runs: 2}, tracker.Get("a.git", "Stable"))
+	require.Nil(t, tracker.Get("b.git", "Flaky"))
+	require.Equal(t, 2, tracker.ExtraAttempts("a.git", "Flaky"))
+	require.Equal(t, 0, tracker.ExtraAttempts("a.git", "Stable"))
+	require.True(t, tracker.RetryOnDifferentBot())
+	require.False(t, tracker.MarkPassedWithFlakes())
+
+	// A nil Tracker applies no policy.
+	var nilTracker *Tracker
+	require.Equal(t, 0, nilTracker.ExtraAttempts("a.git", "Flaky"))
+	require.False(t, nilTracker.RetryOnDifferentBot())
+}
+
+func TestQuarantineStats_PreviouslyQuarantined_OnlyCountsNewerRuns(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	tasks := []*types.Task{
+		makeTask("a.git", "Flaky", "abc", types.TASK_STATUS_FAILURE, now.Add(-3*time.Hour)),
+		makeTask("a.git", "Flaky", "abc", types.TASK_STATUS_SUCCESS, now.Add(-3*time.Hour)),
+		makeTask("a.git", "Flaky", "def", types.TASK_STATUS_SUCCESS, now.Add(-time.Hour)),
+		// The same TaskSpec in another repo was never quarantined.
+		makeTask("b.git", "Flaky", "abc", types.TASK_STATUS_FAILURE, now.Add(-3*time.Hour)),
+		makeTask("b.git", "Flaky", "abc", types.TASK_STATUS_SUCCESS, now.Add(-3*time.Hour)),
+	}
+	quarantineEnds := map[statsKey]time.Time{
+		{repo: "a.git", name: "Flaky"}: now.Add(-2 * time.Hour),
+	}
+
+	require.Equal(t, []*Stats{
+		{Repo: "a.git", Name: "Flaky", Runs: 1},
+		{Repo: "b.git", Name: "Flaky", Runs: 2, Failures: 1, Flakes: 1},
+	}, quarantineStats(tasks, quarantineEnds))
+}
+
+func TestQuarantineEnds_OnlyUsesRulesAddedByTracker(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	stats := []*Stats{
+		{Repo: "a.git", Name: "Expired"},
+		{Repo: "a.git", Name: "Active"},
+		{Repo: "a.git", Name: "NeverQuarantined"},
+		{Repo: "a.git", Name: "UserRule"},
+	}
+	rules := map[string]*skip_tasks.Rule{}
+	addRule := func(name, addedBy string, expires time.Time) {
+		ruleName := QuarantineRuleName("a.git", name)
+		rules[ruleName] = &skip_tasks.Rule{AddedBy: addedBy, Name: ruleName, Expires: expires}
+	}
+	addRule("Expired", QuarantineRuleAddedBy, now.Add(-time.Hour))
+	addRule("Active", QuarantineRuleAddedBy, now.Add(time.Hour))
+	addRule("UserRule", "test@google.com", now.Add(-time.Hour))
+
+	require.Equal(t, map[statsKey]time.Time{
+		{repo: "a.git", name: "Expired"}: now.Add(-time.Hour),
+		{repo: "a.git", name: "Active"}:  now.Add(time.Hour),
+	}, quarantineEnds(stats, rules))
+}
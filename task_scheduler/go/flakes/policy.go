@@ -0,0 +1,313 @@
+package flakes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/go/config"
+	"go.skia.org/infra/go/git/repograph"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/db"
+	"go.skia.org/infra/task_scheduler/go/skip_tasks"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// DefaultWindow is the period over which flake rates are computed if
+	// the Policy does not specify one.
+	DefaultWindow = 72 * time.Hour
+
+	// DefaultQuarantineDuration is how long a flaky TaskSpec is quarantined
+	// if the Policy does not specify a duration.
+	DefaultQuarantineDuration = 24 * time.Hour
+
+	// QuarantineRuleAddedBy is the AddedBy user for skip_tasks rules which
+	// quarantine flaky TaskSpecs.
+	QuarantineRuleAddedBy = "task-scheduler"
+
+	// updateInterval is how often the Tracker recomputes flake rates.
+	updateInterval = 10 * time.Minute
+)
+
+// Policy describes how the Task Scheduler reacts to flaky TaskSpecs. Flake
+// rates are computed per TaskSpec over Window; TaskSpecs with fewer than
+// MinRuns finished tasks in the window are never considered flaky.
+type Policy struct {
+	// Window is the period over which flake rates are computed.
+	Window config.Duration `json:"window"`
+	// MinRuns is the minimum number of finished tasks in the window before
+	// a TaskSpec's flake rate is acted upon.
+	MinRuns int `json:"min_runs"`
+
+	// ExtraAttempts is the number of attempts added to MaxAttempts for
+	// TaskSpecs whose flake rate exceeds RetryThreshold.
+	ExtraAttempts int `json:"extra_attempts"`
+	// RetryThreshold is the flake rate above which ExtraAttempts apply.
+	RetryThreshold float64 `json:"retry_threshold"`
+	// RetryOnDifferentBot indicates that retries of failed tasks should
+	// not run on any bot on which a previous attempt failed. If no other
+	// matching bot is free, the retry waits until one is.
+	RetryOnDifferentBot bool `json:"retry_on_different_bot"`
+
+	// QuarantineThreshold is the flake rate above which a TaskSpec is
+	// quarantined by adding a skip_tasks rule for it. Zero disables
+	// quarantine.
+	QuarantineThreshold float64 `json:"quarantine_threshold"`
+	// QuarantineDuration is how long the skip_tasks rule remains in effect.
+	QuarantineDuration config.Duration `json:"quarantine_duration"`
+
+	// MarkPassedWithFlakes indicates that Jobs which succeeded after
+	// retrying failed tasks should be marked as passed-with-flakes.
+	MarkPassedWithFlakes bool `json:"mark_passed_with_flakes"`
+}
+
+// Validate returns an error if the Policy is not valid.
+func (p *Policy) Validate() error {
+	if p.Window.Duration < 0 {
+		return skerr.Fmt("invalid window %s", p.Window.Duration)
+	}
+	if p.MinRuns < 0 {
+		return skerr.Fmt("invalid min_runs %d", p.MinRuns)
+	}
+	if p.ExtraAttempts < 0 {
+		return skerr.Fmt("invalid extra_attempts %d", p.ExtraAttempts)
+	}
+	if p.RetryThreshold < 0.0 || p.RetryThreshold > 1.0 {
+		return skerr.Fmt("invalid retry_threshold %f", p.RetryThreshold)
+	}
+	if p.QuarantineThreshold < 0.0 || p.QuarantineThreshold > 1.0 {
+		return skerr.Fmt("invalid quarantine_threshold %f", p.QuarantineThreshold)
+	}
+	if p.QuarantineDuration.Duration < 0 {
+		return skerr.Fmt("invalid quarantine_duration %s", p.QuarantineDuration.Duration)
+	}
+	return nil
+}
+
+// GetWindow returns the period over which flake rates are computed.
+func (p *Policy) GetWindow() time.Duration {
+	if p.Window.Duration == 0 {
+		return DefaultWindow
+	}
+	return p.Window.Duration
+}
+
+// GetQuarantineDuration returns how long flaky TaskSpecs are quarantined.
+func (p *Policy) GetQuarantineDuration() time.Duration {
+	if p.QuarantineDuration.Duration == 0 {
+		return DefaultQuarantineDuration
+	}
+	return p.QuarantineDuration.Duration
+}
+
+// ShouldRetry returns true iff the extra attempts apply to the given Stats.
+func (p *Policy) ShouldRetry(s *Stats) bool {
+	return p.ExtraAttempts > 0 && s.Runs >= p.MinRuns && s.FlakeRate() > p.RetryThreshold
+}
+
+// ShouldQuarantine returns true iff the given Stats warrant quarantine.
+func (p *Policy) ShouldQuarantine(s *Stats) bool {
+	return p.QuarantineThreshold > 0.0 && s.Runs >= p.MinRuns && s.FlakeRate() > p.QuarantineThreshold
+}
+
+// ParsePolicy parses and validates a Policy from the given JSON contents.
+func ParsePolicy(contents []byte) (*Policy, error) {
+	var rv Policy
+	if err := json.Unmarshal(contents, &rv); err != nil {
+		return nil, skerr.Wrapf(err, "failed to parse flake policy")
+	}
+	if err := rv.Validate(); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return &rv, nil
+}
+
+// ReadPolicy reads, parses, and validates a Policy from the given file.
+func ReadPolicy(path string) (*Policy, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to read flake policy")
+	}
+	return ParsePolicy(contents)
+}
+
+// QuarantineRuleName returns the name of the skip_tasks rule which
+// quarantines the given TaskSpec. Rule names are limited in length, so the
+// repo and TaskSpec name are hashed.
+func QuarantineRuleName(repo, name string) string {
+	return fmt.Sprintf("flaky-%x", sha256.Sum256([]byte(repo+"|"+name)))[:22]
+}
+
+// Tracker periodically computes flake rates for all TaskSpecs and applies a
+// Policy. A nil Tracker applies no policy.
+type Tracker struct {
+	db        db.TaskReader
+	policy    *Policy
+	repos     repograph.Map
+	skipTasks *skip_tasks.DB
+
+	mtx   sync.RWMutex
+	stats map[statsKey]*Stats
+}
+
+// NewTracker returns a Tracker which applies the given Policy. If skipTasks
+// is nil, flaky TaskSpecs are not quarantined.
+func NewTracker(d db.TaskReader, skipTasks *skip_tasks.DB, repos repograph.Map, policy *Policy) *Tracker {
+	return &Tracker{
+		db:        d,
+		policy:    policy,
+		repos:     repos,
+		skipTasks: skipTasks,
+		stats:     map[statsKey]*Stats{},
+	}
+}
+
+// Update recomputes the flake rates over the Policy's window ending at the
+// given time, quarantines flaky TaskSpecs and removes expired quarantines.
+func (t *Tracker) Update(ctx context.Context, now time.Time) error {
+	tasks, err := t.db.GetTasksFromDateRange(ctx, now.Add(-t.policy.GetWindow()), now, "")
+	if err != nil {
+		return skerr.Wrapf(err, "failed to retrieve tasks")
+	}
+	stats := ComputeStats(tasks)
+	byKey := make(map[statsKey]*Stats, len(stats))
+	for _, s := range stats {
+		byKey[statsKey{repo: s.Repo, name: s.Name}] = s
+	}
+	t.mtx.Lock()
+	t.stats = byKey
+	t.mtx.Unlock()
+
+	if t.skipTasks == nil {
+		return nil
+	}
+	// Expired quarantine rules no longer match any tasks, but they record
+	// when each quarantine ended. Keep them until that is outside of the
+	// window, so that the end survives restarts.
+	removed, err := t.skipTasks.RemoveExpiredRules(ctx, QuarantineRuleAddedBy, now.Add(-t.policy.GetWindow()))
+	if err != nil {
+		return skerr.Wrapf(err, "failed to remove expired quarantine rules")
+	}
+	for _, name := range removed {
+		sklog.Infof("Removed expired quarantine rule %q", name)
+	}
+	existing := map[string]*skip_tasks.Rule{}
+	for _, r := range t.skipTasks.GetRules() {
+		existing[r.Name] = r
+	}
+	for _, s := range quarantineStats(tasks, quarantineEnds(stats, existing)) {
+		if !t.policy.ShouldQuarantine(s) {
+			continue
+		}
+		ruleName := QuarantineRuleName(s.Repo, s.Name)
+		if r, ok := existing[ruleName]; ok {
+			if r.AddedBy != QuarantineRuleAddedBy || !r.Expired(now) {
+				continue
+			}
+			// Replace the expired rule from the previous quarantine.
+			if err := t.skipTasks.RemoveRule(ctx, ruleName); err != nil {
+				return skerr.Wrapf(err, "failed to remove expired quarantine rule %q", ruleName)
+			}
+		}
+		rule := &skip_tasks.Rule{
+			AddedBy:          QuarantineRuleAddedBy,
+			TaskSpecPatterns: []string{"^" + regexp.QuoteMeta(s.Name) + "$"},
+			Description:      fmt.Sprintf("Quarantined %s in %s: flake rate %.2f over %d runs exceeds %.2f.", s.Name, s.Repo, s.FlakeRate(), s.Runs, t.policy.QuarantineThreshold),
+			Expires:          now.Add(t.policy.GetQuarantineDuration()),
+			Name:             ruleName,
+			Repos:            []string{s.Repo},
+		}
+		if err := t.skipTasks.AddRule(ctx, rule, t.repos); err != nil {
+			return skerr.Wrapf(err, "failed to quarantine %s", s.Name)
+		}
+		sklog.Warningf("Quarantined flaky task %s in %s until %s", s.Name, s.Repo, rule.Expires)
+	}
+	return nil
+}
+
+// quarantineEnds returns when the most recent quarantine of each of the given
+// TaskSpecs ended (or will end), according to the Expires time of the
+// skip_tasks rules added by the Tracker, keyed by rule name.
+func quarantineEnds(stats []*Stats, rules map[string]*skip_tasks.Rule) map[statsKey]time.Time {
+	rv := map[statsKey]time.Time{}
+	for _, s := range stats {
+		if r, ok := rules[QuarantineRuleName(s.Repo, s.Name)]; ok && r.AddedBy == QuarantineRuleAddedBy {
+			rv[statsKey{repo: s.Repo, name: s.Name}] = r.Expires
+		}
+	}
+	return rv
+}
+
+// quarantineStats returns the Stats used to decide whether to quarantine
+// TaskSpecs. Quarantined TaskSpecs don't run, so their flake rate does not
+// change during the quarantine. To avoid quarantining them again as soon as
+// the quarantine ends, only their tasks which were created after the end of
+// their most recent quarantine are counted.
+func quarantineStats(tasks []*types.Task, quarantineEnds map[statsKey]time.Time) []*Stats {
+	filtered := make([]*types.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if end, ok := quarantineEnds[statsKey{repo: task.Repo, name: task.Name}]; ok && task.Created.Before(end) {
+			continue
+		}
+		filtered = append(filtered, task)
+	}
+	return ComputeStats(filtered)
+}
+
+// Start periodically updates the Tracker until the given context is
+// canceled.
+func (t *Tracker) Start(ctx context.Context) {
+	lv := metrics2.NewLiveness("last_successful_flake_stats_update")
+	go util.RepeatCtx(ctx, updateInterval, func(ctx context.Context) {
+		if err := t.Update(ctx, time.Now()); err != nil {
+			sklog.Errorf("Failed to update flake stats: %s", err)
+		} else {
+			lv.Reset()
+		}
+	})
+}
+
+// Get returns the most recently computed Stats for the given TaskSpec, or nil
+// if there are none.
+func (t *Tracker) Get(repo, name string) *Stats {
+	if t == nil {
+		return nil
+	}
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.stats[statsKey{repo: repo, name: name}]
+}
+
+// ExtraAttempts returns the number of attempts which should be added to the
+// MaxAttempts of the given TaskSpec due to its flake rate.
+func (t *Tracker) ExtraAttempts(repo, name string) int {
+	if t == nil {
+		return 0
+	}
+	s := t.Get(repo, name)
+	if s == nil || !t.policy.ShouldRetry(s) {
+		return 0
+	}
+	return t.policy.ExtraAttempts
+}
+
+// RetryOnDifferentBot returns true iff retries should avoid bots on which
+// previous attempts failed.
+func (t *Tracker) RetryOnDifferentBot() bool {
+	return t != nil && t.policy.RetryOnDifferentBot
+}
+
+// MarkPassedWithFlakes returns true iff Jobs which succeeded after retrying
+// failed tasks should be marked as passed-with-flakes.
+func (t *Tracker) MarkPassedWithFlakes() bool {
+	return t != nil && t.policy.MarkPassedWithFlakes
+}
@@ -202,6 +202,12 @@ func (jc *JobCreator) gatherNewJobs(ctx context.Context, repoUrl string, repo *r
 			return skerr.Wrap(err)
 		}
 		alreadyScheduledAllJobs := true
+		// Jobs which are skipped because of their path filters say nothing
+		// about whether we've visited this commit before, so we only use
+		// the jobs which should run at this commit to decide whether to
+		// stop recursing.
+		skippedByPathFilter := false
+		shouldRunAnyJob := false
 		for name, spec := range cfg.Jobs {
 			shouldRun := false
 			if !util.In(spec.Trigger, specs.PERIODIC_TRIGGERS) {
@@ -227,7 +233,18 @@ func (jc *JobCreator) gatherNewJobs(ctx context.Context, repoUrl string, repo *r
 					shouldRun = true
 				}
 			}
+			if shouldRun && spec.HasPathFilters() {
+				relevant, err := commitMatchesPaths(ctx, r, c, spec.MatchesChangedFiles)
+				if err != nil {
+					return skerr.Wrap(err)
+				}
+				if !relevant {
+					skippedByPathFilter = true
+					shouldRun = false
+				}
+			}
 			if shouldRun {
+				shouldRunAnyJob = true
 				prevJobs, err := jc.jCache.GetJobsByRepoState(name, rs)
 				if err != nil {
 					return skerr.Wrap(err)
@@ -268,8 +285,10 @@ func (jc *JobCreator) gatherNewJobs(ctx context.Context, repoUrl string, repo *r
 		}
 		// If we'd already scheduled all of the jobs for this commit,
 		// stop recursing, under the assumption that we've already
-		// scheduled all of the jobs for the ones before it.
-		if alreadyScheduledAllJobs {
+		// scheduled all of the jobs for the ones before it. If the only
+		// jobs at this commit were skipped because of their path filters,
+		// we can't tell, so keep going.
+		if alreadyScheduledAllJobs && (shouldRunAnyJob || !skippedByPathFilter) {
 			return repograph.ErrStopRecursing
 		}
 		if c.Hash == "50537e46e4f0999df0a4707b227000cfa8c800ff" {
@@ -292,6 +311,19 @@ func (jc *JobCreator) gatherNewJobs(ctx context.Context, repoUrl string, repo *r
 	return newJobs, nil
 }
 
+// commitMatchesPaths returns true iff the files changed by the given commit
+// satisfy the given path filter. If the repo cannot provide the changed files,
+// every commit is assumed to match.
+func commitMatchesPaths(ctx context.Context, r *repograph.Graph, c *repograph.Commit, matches func([]string) (bool, error)) (bool, error) {
+	files, err := r.ChangedFiles(ctx, c.Hash)
+	if err == repograph.ErrChangedFilesNotSupported {
+		return true, nil
+	} else if err != nil {
+		return false, skerr.Wrap(err)
+	}
+	return matches(files)
+}
+
 // HandleRepoUpdate is a pubsub.AutoUpdateMapCallback which is called when any
 // of the repos is updated.
 func (jc *JobCreator) HandleRepoUpdate(ctx context.Context, repoUrl string, g *repograph.Graph, ack, nack func()) error {
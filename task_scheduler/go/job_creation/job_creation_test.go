@@ -171,6 +171,82 @@ func TestGatherNewJobs(t *testing.T) {
 	testGatherNewJobs(72)
 }
 
+func TestGatherNewJobs_PathRegexes_IrrelevantCommitsSkipped(t *testing.T) {
+	ctx, gb, _, jc, _, _, cleanup := setup(t)
+	defer cleanup()
+
+	testGatherNewJobs := func(expectedJobs int) {
+		updateRepos(t, ctx, jc)
+		jobs, err := jc.jCache.UnfinishedJobs()
+		require.NoError(t, err)
+		require.Equal(t, expectedJobs, len(jobs))
+	}
+	testGatherNewJobs(5) // c1 has 2 jobs, c2 has 3 jobs.
+
+	// Restrict all jobs to changes in the src directory. The commit which
+	// changes the config doesn't touch src, so no jobs are added.
+	cfg, err := specs.ReadTasksCfg(gb.Dir())
+	require.NoError(t, err)
+	for _, jobSpec := range cfg.Jobs {
+		jobSpec.IncludePathRegexes = []string{"^src/"}
+	}
+	cfgBytes, err := specs.EncodeTasksCfg(cfg)
+	require.NoError(t, err)
+	gb.Add(ctx, "infra/bots/tasks.json", string(cfgBytes))
+	gb.CommitMsg(ctx, "Add path regexes")
+	testGatherNewJobs(5)
+
+	// Irrelevant commits don't get jobs either.
+	makeDummyCommits(ctx, gb, 2)
+	testGatherNewJobs(5)
+
+	// A commit which touches src gets all 3 jobs.
+	gb.Add(ctx, "src/file.cpp", "int main() {}")
+	gb.CommitMsg(ctx, "Relevant commit")
+	testGatherNewJobs(8)
+}
+
+// countingChangedFilesGetter is a repograph.ChangedFilesGetter which reports
+// the same file for every commit and counts how often it is called.
+type countingChangedFilesGetter struct {
+	calls int
+}
+
+func (g *countingChangedFilesGetter) ChangedFiles(_ context.Context, _ string) ([]string, error) {
+	g.calls++
+	return []string{"dummyfile.txt"}, nil
+}
+
+func TestGatherNewJobs_PathRegexes_StopsAtPreviouslyScheduledCommit(t *testing.T) {
+	ctx, gb, _, jc, _, _, cleanup := setup(t)
+	defer cleanup()
+
+	// Restrict all but the Build job to changes in the src directory.
+	cfg, err := specs.ReadTasksCfg(gb.Dir())
+	require.NoError(t, err)
+	for name, jobSpec := range cfg.Jobs {
+		if name != tcc_testutils.BuildTaskName {
+			jobSpec.IncludePathRegexes = []string{"^src/"}
+		}
+	}
+	cfgBytes, err := specs.EncodeTasksCfg(cfg)
+	require.NoError(t, err)
+	gb.Add(ctx, "infra/bots/tasks.json", string(cfgBytes))
+	gb.CommitMsg(ctx, "Add path regexes")
+	makeDummyCommits(ctx, gb, 5)
+	updateRepos(t, ctx, jc)
+
+	// Setting the getter also clears the cache of changed files.
+	getter := &countingChangedFilesGetter{}
+	jc.repos[gb.RepoUrl()].SetChangedFilesGetter(getter)
+	makeDummyCommits(ctx, gb, 1)
+	updateRepos(t, ctx, jc)
+	// The Build job already ran at the previous commit, so we should stop
+	// there rather than checking the changed files of every commit in the
+	// scheduling window.
+	require.Equal(t, 2, getter.calls)
+}
+
 func TestPeriodicJobs(t *testing.T) {
 	ctx, gb, _, jc, _, _, cleanup := setup(t)
 	defer cleanup()
@@ -287,7 +363,7 @@ func TestTaskSchedulerIntegration(t *testing.T) {
 		types.TaskExecutor_UseDefault: swarmingTaskExec,
 		types.TaskExecutor_Swarming:   swarmingTaskExec,
 	}
-	ts, err := scheduling.NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, jc.repos, cas, "fake-rbe-instance", taskExecs, urlMock.Client(), 1.0, swarming.POOLS_PUBLIC, "", "", jc.taskCfgCache, nil, mem_gcsclient.New("fake"), "testing", scheduling.BusyBotsDebugLoggingOff)
+	ts, err := scheduling.NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, jc.repos, cas, "fake-rbe-instance", taskExecs, urlMock.Client(), 1.0, swarming.POOLS_PUBLIC, "", "", jc.taskCfgCache, nil, mem_gcsclient.New("fake"), "testing", scheduling.BusyBotsDebugLoggingOff, nil, nil)
 	require.NoError(t, err)
 
 	jc.Start(ctx, false)
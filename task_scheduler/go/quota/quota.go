@@ -0,0 +1,385 @@
+package quota
+
+/*
+	Fair-share quotas for the Task Scheduler.
+
+	A Quota applies to the tasks of a given repo, job class and Swarming pool
+	(any of which may be left empty to match everything) and specifies the
+	guaranteed minimum and the maximum share of the bots in the pool which may
+	be used by those tasks. Each task is charged to the first Quota in the
+	Config which matches it; tasks which match no Quota are unconstrained.
+
+	Shares are converted to bot counts by rounding up, so that a non-zero share
+	of a small pool always amounts to at least one bot.
+*/
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+// JobClass categorizes Jobs for the purpose of fair-share quotas.
+type JobClass string
+
+const (
+	// JobClassCommit is a Job triggered by a commit landing.
+	JobClassCommit JobClass = "commit"
+	// JobClassCQ is a try job requested by the commit queue.
+	JobClassCQ JobClass = "cq"
+	// JobClassTry is a try job which was not requested by the commit queue.
+	JobClassTry JobClass = "try"
+	// JobClassPeriodic is a Job triggered on a schedule, eg. nightly.
+	JobClassPeriodic JobClass = "periodic"
+	// JobClassCD is a continuous deployment Job.
+	JobClassCD JobClass = "cd"
+	// JobClassForced is a manually-triggered Job.
+	JobClassForced JobClass = "forced"
+)
+
+var (
+	// JobClasses lists all valid JobClasses in order of precedence. A task
+	// which is shared by Jobs of different classes is charged to the class
+	// which appears first.
+	JobClasses = []JobClass{JobClassCD, JobClassCQ, JobClassTry, JobClassForced, JobClassPeriodic, JobClassCommit}
+)
+
+// precedence returns the index of the given JobClass in JobClasses, or
+// len(JobClasses) if it is not valid.
+func precedence(c JobClass) int {
+	for idx, class := range JobClasses {
+		if class == c {
+			return idx
+		}
+	}
+	return len(JobClasses)
+}
+
+// ClassifyJob returns the JobClass of the given Job. The JobSpec is optional;
+// without it, CD and periodic Jobs cannot be identified.
+func ClassifyJob(j *types.Job, spec *specs.JobSpec) JobClass {
+	if spec != nil && spec.IsCD {
+		return JobClassCD
+	}
+	if j.IsTryJob() {
+		if j.IsCQ {
+			return JobClassCQ
+		}
+		return JobClassTry
+	}
+	if j.IsForce {
+		return JobClassForced
+	}
+	if spec != nil && util.In(spec.Trigger, specs.PERIODIC_TRIGGERS) {
+		return JobClassPeriodic
+	}
+	return JobClassCommit
+}
+
+// Classify returns the JobClass and pool of a task with the given name which
+// is shared by the given Jobs, using the given TasksCfg. The TasksCfg may be
+// nil, in which case the pool is unknown.
+func Classify(jobs []*types.Job, cfg *specs.TasksCfg, taskName string) (JobClass, string) {
+	var class JobClass
+	for _, j := range jobs {
+		var spec *specs.JobSpec
+		if cfg != nil {
+			spec = cfg.Jobs[j.Name]
+		}
+		jobClass := ClassifyJob(j, spec)
+		if class == "" || precedence(jobClass) < precedence(class) {
+			class = jobClass
+		}
+	}
+	pool := ""
+	if cfg != nil {
+		if taskSpec, ok := cfg.Tasks[taskName]; ok {
+			pool = PoolFromDimensions(taskSpec.Dimensions)
+		}
+	}
+	return class, pool
+}
+
+// PoolFromDimensions returns the Swarming pool from the given dimensions, or
+// the empty string if there is no pool dimension.
+func PoolFromDimensions(dims []string) string {
+	for _, dim := range dims {
+		if strings.HasPrefix(dim, "pool:") {
+			return strings.TrimPrefix(dim, "pool:")
+		}
+	}
+	return ""
+}
+
+// Quota describes the share of a pool which may be used by matching tasks.
+type Quota struct {
+	// Name identifies the Quota in metrics and the UI.
+	Name string `json:"name"`
+	// Repo, JobClass, and Pool restrict the tasks which are charged to this
+	// Quota. Empty values match everything.
+	Repo     string   `json:"repo,omitempty"`
+	JobClass JobClass `json:"job_class,omitempty"`
+	Pool     string   `json:"pool,omitempty"`
+	// MinShare is the fraction of the pool which is guaranteed to matching
+	// tasks, if they have candidates waiting.
+	MinShare float64 `json:"min_share,omitempty"`
+	// MaxShare is the maximum fraction of the pool which may be used by
+	// matching tasks. Zero means no limit.
+	MaxShare float64 `json:"max_share,omitempty"`
+}
+
+// Matches returns true iff tasks with the given repo, JobClass, and pool are
+// charged to this Quota.
+func (q *Quota) Matches(repo string, class JobClass, pool string) bool {
+	return (q.Repo == "" || q.Repo == repo) &&
+		(q.JobClass == "" || q.JobClass == class) &&
+		(q.Pool == "" || q.Pool == pool)
+}
+
+// Validate returns an error if the Quota is not valid.
+func (q *Quota) Validate() error {
+	if q.Name == "" {
+		return skerr.Fmt("quota name is required")
+	}
+	if q.JobClass != "" && precedence(q.JobClass) == len(JobClasses) {
+		return skerr.Fmt("quota %q has unknown job class %q", q.Name, q.JobClass)
+	}
+	if q.MinShare < 0 || q.MinShare > 1 {
+		return skerr.Fmt("quota %q has invalid min_share %f", q.Name, q.MinShare)
+	}
+	if q.MaxShare < 0 || q.MaxShare > 1 {
+		return skerr.Fmt("quota %q has invalid max_share %f", q.Name, q.MaxShare)
+	}
+	if q.MaxShare > 0 && q.MinShare > q.MaxShare {
+		return skerr.Fmt("quota %q has min_share greater than max_share", q.Name)
+	}
+	return nil
+}
+
+// Config is a set of Quotas.
+type Config struct {
+	Quotas []*Quota `json:"quotas"`
+}
+
+// Validate returns an error if the Config is not valid.
+func (c *Config) Validate() error {
+	names := util.StringSet{}
+	pools := util.StringSet{"": true}
+	for _, q := range c.Quotas {
+		if err := q.Validate(); err != nil {
+			return skerr.Wrap(err)
+		}
+		if names[q.Name] {
+			return skerr.Fmt("duplicate quota name %q", q.Name)
+		}
+		names[q.Name] = true
+		pools[q.Pool] = true
+	}
+	// The guaranteed minimums within each pool may not exceed the pool.
+	for pool := range pools {
+		sum := 0.0
+		for _, q := range c.Quotas {
+			if q.Pool == "" || q.Pool == pool {
+				sum += q.MinShare
+			}
+		}
+		if sum > 1.0 {
+			return skerr.Fmt("sum of min_share for pool %q is %f, which exceeds 1.0", pool, sum)
+		}
+	}
+	return nil
+}
+
+// Match returns the first Quota which matches tasks with the given repo,
+// JobClass, and pool, or nil if there is none.
+func (c *Config) Match(repo string, class JobClass, pool string) *Quota {
+	for _, q := range c.Quotas {
+		if q.Matches(repo, class, pool) {
+			return q
+		}
+	}
+	return nil
+}
+
+// ParseConfig parses and validates a Config from the given JSON contents.
+func ParseConfig(contents []byte) (*Config, error) {
+	var rv Config
+	if err := json.Unmarshal(contents, &rv); err != nil {
+		return nil, skerr.Wrapf(err, "failed to parse quota config")
+	}
+	if err := rv.Validate(); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return &rv, nil
+}
+
+// ReadConfig reads, parses, and validates a Config from the given file.
+func ReadConfig(path string) (*Config, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to read quota config")
+	}
+	return ParseConfig(contents)
+}
+
+// usageKey identifies the usage of a Quota within a pool.
+type usageKey struct {
+	quota string
+	pool  string
+}
+
+// Usage describes the usage of a Quota within a pool.
+type Usage struct {
+	Quota    string   `json:"quota"`
+	Repo     string   `json:"repo,omitempty"`
+	JobClass JobClass `json:"job_class,omitempty"`
+	Pool     string   `json:"pool"`
+	// Running is the number of tasks charged to the Quota which are pending
+	// or running.
+	Running int `json:"running"`
+	// Capacity is the number of bots in the pool.
+	Capacity int `json:"capacity"`
+	// Min and Max are the guaranteed minimum and maximum number of tasks for
+	// this Quota in this pool.
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// Tracker performs deficit accounting for a Config within a single scheduling
+// cycle. It is not safe for concurrent use.
+type Tracker struct {
+	cfg      *Config
+	capacity map[string]int
+	running  map[string]int
+	used     map[usageKey]int
+}
+
+// NewTracker returns a Tracker for the given Config.
+func NewTracker(cfg *Config) *Tracker {
+	return &Tracker{
+		cfg:      cfg,
+		capacity: map[string]int{},
+		running:  map[string]int{},
+		used:     map[usageKey]int{},
+	}
+}
+
+// SetCapacity sets the number of bots in the given pool.
+func (t *Tracker) SetCapacity(pool string, capacity int) {
+	t.capacity[pool] = capacity
+}
+
+// AddRunning records a pending or running task with the given repo, JobClass,
+// and pool.
+func (t *Tracker) AddRunning(repo string, class JobClass, pool string) {
+	t.running[pool]++
+	if q := t.cfg.Match(repo, class, pool); q != nil {
+		t.used[usageKey{quota: q.Name, pool: pool}]++
+	}
+}
+
+// Running returns the number of pending or running tasks in the given pool.
+func (t *Tracker) Running(pool string) int {
+	return t.running[pool]
+}
+
+// limits returns the guaranteed minimum and maximum number of tasks for the
+// given Quota in the given pool.
+func (t *Tracker) limits(q *Quota, pool string) (int, int) {
+	capacity := t.capacity[pool]
+	min := int(math.Ceil(q.MinShare * float64(capacity)))
+	max := capacity
+	if q.MaxShare > 0 {
+		max = int(math.Ceil(q.MaxShare * float64(capacity)))
+	}
+	return min, max
+}
+
+// Deficit returns the number of additional tasks with the given repo,
+// JobClass, and pool which are needed to reach the guaranteed minimum of the
+// matching Quota, and the name of that Quota.
+func (t *Tracker) Deficit(repo string, class JobClass, pool string) (int, string) {
+	q := t.cfg.Match(repo, class, pool)
+	if q == nil {
+		return 0, ""
+	}
+	min, _ := t.limits(q, pool)
+	deficit := min - t.used[usageKey{quota: q.Name, pool: pool}]
+	if deficit < 0 {
+		deficit = 0
+	}
+	return deficit, q.Name
+}
+
+// Admit returns true iff another task with the given repo, JobClass, and pool
+// would not exceed the maximum share of the matching Quota.
+func (t *Tracker) Admit(repo string, class JobClass, pool string) bool {
+	q := t.cfg.Match(repo, class, pool)
+	if q == nil || q.MaxShare == 0 {
+		return true
+	}
+	_, max := t.limits(q, pool)
+	return t.used[usageKey{quota: q.Name, pool: pool}] < max
+}
+
+// Headroom returns the number of additional tasks with the given repo,
+// JobClass, and pool which may be started without exceeding the maximum share
+// of the matching Quota, and the name of that Quota. Returns -1 if the number
+// is unlimited.
+func (t *Tracker) Headroom(repo string, class JobClass, pool string) (int, string) {
+	q := t.cfg.Match(repo, class, pool)
+	if q == nil {
+		return -1, ""
+	} else if q.MaxShare == 0 {
+		return -1, q.Name
+	}
+	_, max := t.limits(q, pool)
+	headroom := max - t.used[usageKey{quota: q.Name, pool: pool}]
+	if headroom < 0 {
+		headroom = 0
+	}
+	return headroom, q.Name
+}
+
+// Usage returns the usage of each Quota in each pool with known capacity,
+// sorted by quota name and pool.
+func (t *Tracker) Usage() []*Usage {
+	pools := make([]string, 0, len(t.capacity))
+	for pool := range t.capacity {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+	rv := []*Usage{}
+	for _, q := range t.cfg.Quotas {
+		for _, pool := range pools {
+			if q.Pool != "" && q.Pool != pool {
+				continue
+			}
+			min, max := t.limits(q, pool)
+			rv = append(rv, &Usage{
+				Quota:    q.Name,
+				Repo:     q.Repo,
+				JobClass: q.JobClass,
+				Pool:     pool,
+				Running:  t.used[usageKey{quota: q.Name, pool: pool}],
+				Capacity: t.capacity[pool],
+				Min:      min,
+				Max:      max,
+			})
+		}
+	}
+	sort.SliceStable(rv, func(i, j int) bool {
+		if rv[i].Quota == rv[j].Quota {
+			return rv[i].Pool < rv[j].Pool
+		}
+		return rv[i].Quota < rv[j].Quota
+	})
+	return rv
+}
@@ -0,0 +1,153 @@
+package quota
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+var (
+	tryPatch = types.Patch{
+		Server:   "https://skia-review.googlesource.com",
+		Issue:    "123",
+		Patchset: "1",
+	}
+)
+
+func TestClassifyJob_AllClasses(t *testing.T) {
+	require.Equal(t, JobClassCD, ClassifyJob(&types.Job{}, &specs.JobSpec{IsCD: true}))
+	require.Equal(t, JobClassCQ, ClassifyJob(&types.Job{
+		RepoState: types.RepoState{Patch: tryPatch},
+		IsCQ:      true,
+	}, nil))
+	require.Equal(t, JobClassTry, ClassifyJob(&types.Job{
+		RepoState: types.RepoState{Patch: tryPatch},
+	}, nil))
+	require.Equal(t, JobClassForced, ClassifyJob(&types.Job{IsForce: true}, nil))
+	require.Equal(t, JobClassPeriodic, ClassifyJob(&types.Job{}, &specs.JobSpec{Trigger: specs.TRIGGER_NIGHTLY}))
+	require.Equal(t, JobClassCommit, ClassifyJob(&types.Job{}, &specs.JobSpec{}))
+	require.Equal(t, JobClassCommit, ClassifyJob(&types.Job{}, nil))
+}
+
+func TestClassify_SharedTask_UsesHighestPrecedenceClass(t *testing.T) {
+	cfg := &specs.TasksCfg{
+		Tasks: map[string]*specs.TaskSpec{
+			"Build": {Dimensions: []string{"os:Linux", "pool:Skia"}},
+		},
+		Jobs: map[string]*specs.JobSpec{
+			"Nightly": {Trigger: specs.TRIGGER_NIGHTLY},
+			"Commit":  {},
+		},
+	}
+	jobs := []*types.Job{
+		{Name: "Commit"},
+		{Name: "Nightly"},
+	}
+	class, pool := Classify(jobs, cfg, "Build")
+	require.Equal(t, JobClassPeriodic, class)
+	require.Equal(t, "Skia", pool)
+
+	// Without a TasksCfg, neither the periodic trigger nor the pool are known.
+	class, pool = Classify(jobs, nil, "Build")
+	require.Equal(t, JobClassCommit, class)
+	require.Equal(t, "", pool)
+}
+
+func TestParseConfig_Valid_Success(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`{
+		"quotas": [
+			{"name": "cq", "job_class": "cq", "min_share": 0.5},
+			{"name": "big-repo", "repo": "big.git", "pool": "Skia", "max_share": 0.6}
+		]
+	}`))
+	require.NoError(t, err)
+	require.Len(t, cfg.Quotas, 2)
+	require.Equal(t, JobClassCQ, cfg.Quotas[0].JobClass)
+	require.Equal(t, 0.6, cfg.Quotas[1].MaxShare)
+}
+
+func TestConfigValidate_Invalid_ReturnsError(t *testing.T) {
+	test := func(name, expectErr string, quotas ...*Quota) {
+		t.Run(name, func(t *testing.T) {
+			err := (&Config{Quotas: quotas}).Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), expectErr)
+		})
+	}
+	test("no name", "quota name is required", &Quota{})
+	test("unknown class", "unknown job class", &Quota{Name: "q", JobClass: "bogus"})
+	test("min share", "invalid min_share", &Quota{Name: "q", MinShare: 1.5})
+	test("max share", "invalid max_share", &Quota{Name: "q", MaxShare: -1})
+	test("min over max", "min_share greater than max_share", &Quota{Name: "q", MinShare: 0.5, MaxShare: 0.25})
+	test("duplicate", "duplicate quota name", &Quota{Name: "q"}, &Quota{Name: "q"})
+	test("oversubscribed", "exceeds 1.0",
+		&Quota{Name: "a", MinShare: 0.5},
+		&Quota{Name: "b", Pool: "Skia", MinShare: 0.75},
+	)
+}
+
+func TestConfigMatch_FirstMatchWins(t *testing.T) {
+	cfg := &Config{
+		Quotas: []*Quota{
+			{Name: "skia-try", Repo: "skia.git", JobClass: JobClassTry},
+			{Name: "try", JobClass: JobClassTry},
+		},
+	}
+	require.Equal(t, "skia-try", cfg.Match("skia.git", JobClassTry, "Skia").Name)
+	require.Equal(t, "try", cfg.Match("other.git", JobClassTry, "Skia").Name)
+	require.Nil(t, cfg.Match("skia.git", JobClassCommit, "Skia"))
+}
+
+func TestTracker_DeficitAndAdmit(t *testing.T) {
+	tracker := NewTracker(&Config{
+		Quotas: []*Quota{
+			{Name: "cq", JobClass: JobClassCQ, MinShare: 0.3},
+			{Name: "try", JobClass: JobClassTry, MaxShare: 0.25},
+		},
+	})
+	tracker.SetCapacity("Skia", 10)
+
+	// The minimum share is rounded up.
+	deficit, name := tracker.Deficit("skia.git", JobClassCQ, "Skia")
+	require.Equal(t, 3, deficit)
+	require.Equal(t, "cq", name)
+	tracker.AddRunning("skia.git", JobClassCQ, "Skia")
+	deficit, _ = tracker.Deficit("skia.git", JobClassCQ, "Skia")
+	require.Equal(t, 2, deficit)
+
+	// Quotas without a maximum share admit everything.
+	require.True(t, tracker.Admit("skia.git", JobClassCQ, "Skia"))
+	// Tasks which match no quota are unconstrained.
+	deficit, name = tracker.Deficit("skia.git", JobClassCommit, "Skia")
+	require.Equal(t, 0, deficit)
+	require.Equal(t, "", name)
+	require.True(t, tracker.Admit("skia.git", JobClassCommit, "Skia"))
+
+	// Quotas without a maximum share have unlimited headroom.
+	headroom, name := tracker.Headroom("skia.git", JobClassCQ, "Skia")
+	require.Equal(t, -1, headroom)
+	require.Equal(t, "cq", name)
+	headroom, name = tracker.Headroom("skia.git", JobClassCommit, "Skia")
+	require.Equal(t, -1, headroom)
+	require.Equal(t, "", name)
+
+	// The maximum share is rounded up, to 3 bots.
+	headroom, name = tracker.Headroom("skia.git", JobClassTry, "Skia")
+	require.Equal(t, 3, headroom)
+	require.Equal(t, "try", name)
+	for i := 0; i < 3; i++ {
+		require.True(t, tracker.Admit("skia.git", JobClassTry, "Skia"))
+		tracker.AddRunning("skia.git", JobClassTry, "Skia")
+	}
+	require.False(t, tracker.Admit("skia.git", JobClassTry, "Skia"))
+	headroom, _ = tracker.Headroom("skia.git", JobClassTry, "Skia")
+	require.Equal(t, 0, headroom)
+	require.Equal(t, 4, tracker.Running("Skia"))
+
+	require.Equal(t, []*Usage{
+		{Quota: "cq", JobClass: JobClassCQ, Pool: "Skia", Running: 1, Capacity: 10, Min: 3, Max: 10},
+		{Quota: "try", JobClass: JobClassTry, Pool: "Skia", Running: 3, Capacity: 10, Min: 0, Max: 3},
+	}, tracker.Usage())
+}
@@ -15,7 +15,10 @@ import (
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/swarming"
 	"go.skia.org/infra/go/twirp_auth2"
+	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/task_scheduler/go/db"
+	"go.skia.org/infra/task_scheduler/go/flakes"
+	"go.skia.org/infra/task_scheduler/go/quota"
 	"go.skia.org/infra/task_scheduler/go/skip_tasks"
 	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
 	"go.skia.org/infra/task_scheduler/go/types"
@@ -31,8 +34,8 @@ import (
 //go:generate bazelisk run --config=mayberemote //:protoc -- --twirp_typescript_out=../../modules/rpc ./rpc.proto
 
 // NewTaskSchedulerServer creates and returns a Twirp HTTP server.
-func NewTaskSchedulerServer(ctx context.Context, db db.DB, repos repograph.Map, skipTasks *skip_tasks.DB, taskCfgCache task_cfg_cache.TaskCfgCache, swarm swarming.ApiClient, plogin alogin.Login) http.Handler {
-	impl := newTaskSchedulerServiceImpl(ctx, db, repos, skipTasks, taskCfgCache, swarm)
+func NewTaskSchedulerServer(ctx context.Context, db db.DB, repos repograph.Map, skipTasks *skip_tasks.DB, taskCfgCache task_cfg_cache.TaskCfgCache, swarm swarming.ApiClient, quotas *quota.Config, flakePolicy *flakes.Policy, plogin alogin.Login) http.Handler {
+	impl := newTaskSchedulerServiceImpl(ctx, db, repos, skipTasks, taskCfgCache, swarm, quotas, flakePolicy)
 	srv := NewTaskSchedulerServiceServer(impl, nil)
 	return alogin.StatusMiddleware(plogin)(srv)
 }
@@ -45,10 +48,15 @@ type taskSchedulerServiceImpl struct {
 	skipTasks    *skip_tasks.DB
 	taskCfgCache task_cfg_cache.TaskCfgCache
 	swarming     swarming.ApiClient
+	quotas       *quota.Config
+	flakePolicy  *flakes.Policy
 }
 
 // newTaskSchedulerServiceImpl returns a taskSchedulerServiceImpl instance.
-func newTaskSchedulerServiceImpl(ctx context.Context, db db.DB, repos repograph.Map, skipTasks *skip_tasks.DB, taskCfgCache task_cfg_cache.TaskCfgCache, swarm swarming.ApiClient) *taskSchedulerServiceImpl {
+func newTaskSchedulerServiceImpl(ctx context.Context, db db.DB, repos repograph.Map, skipTasks *skip_tasks.DB, taskCfgCache task_cfg_cache.TaskCfgCache, swarm swarming.ApiClient, quotas *quota.Config, flakePolicy *flakes.Policy) *taskSchedulerServiceImpl {
+	if flakePolicy == nil {
+		flakePolicy = &flakes.Policy{}
+	}
 	return &taskSchedulerServiceImpl{
 		AuthHelper:   twirp_auth2.New(),
 		db:           db,
@@ -56,6 +64,8 @@ func newTaskSchedulerServiceImpl(ctx context.Context, db db.DB, repos repograph.
 		skipTasks:    skipTasks,
 		taskCfgCache: taskCfgCache,
 		swarming:     swarm,
+		quotas:       quotas,
+		flakePolicy:  flakePolicy,
 	}
 }
 
@@ -409,6 +419,135 @@ func (s *taskSchedulerServiceImpl) DeleteSkipTaskRule(ctx context.Context, req *
 	}, nil
 }
 
+// GetQuotaUsage returns the current usage of the fair-share quotas.
+func (s *taskSchedulerServiceImpl) GetQuotaUsage(ctx context.Context, req *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	if s.quotas == nil {
+		return &GetQuotaUsageResponse{}, nil
+	}
+	var tasks []*types.Task
+	for _, status := range []types.TaskStatus{types.TASK_STATUS_PENDING, types.TASK_STATUS_RUNNING} {
+		results, err := db.SearchTasks(ctx, s.db, &db.TaskSearchParams{
+			Status: &status,
+		})
+		if err != nil {
+			sklog.Error(err)
+			return nil, twirp.InternalError("Failed to search tasks")
+		}
+		tasks = append(tasks, results...)
+	}
+
+	// Charge each unfinished task to its quota.
+	tracker := quota.NewTracker(s.quotas)
+	jobs := map[string]*types.Job{}
+	pools := util.StringSet{}
+	for _, q := range s.quotas.Quotas {
+		if q.Pool != "" {
+			pools[q.Pool] = true
+		}
+	}
+	for _, task := range tasks {
+		taskJobs := make([]*types.Job, 0, len(task.Jobs))
+		for _, id := range task.Jobs {
+			job, ok := jobs[id]
+			if !ok {
+				var err error
+				job, err = s.db.GetJobById(ctx, id)
+				if err != nil {
+					sklog.Error(err)
+					return nil, twirp.InternalError("Failed to retrieve job")
+				}
+				jobs[id] = job
+			}
+			if job != nil {
+				taskJobs = append(taskJobs, job)
+			}
+		}
+		cfg, cachedErr, err := s.taskCfgCache.Get(ctx, task.RepoState)
+		if err != nil {
+			sklog.Error(err)
+			return nil, twirp.InternalError("Failed to retrieve task config")
+		} else if cachedErr != nil {
+			cfg = nil
+		}
+		class, pool := quota.Classify(taskJobs, cfg, task.Name)
+		tracker.AddRunning(task.Repo, class, pool)
+		if pool != "" {
+			pools[pool] = true
+		}
+	}
+
+	// The capacity of each pool is the number of bots which are available to
+	// run tasks.
+	for pool := range pools {
+		bots, err := s.swarming.ListBotsForPool(ctx, pool)
+		if err != nil {
+			sklog.Error(err)
+			return nil, twirp.InternalError("Failed to retrieve bots")
+		}
+		capacity := 0
+		for _, bot := range bots {
+			if !bot.IsDead && !bot.Quarantined {
+				capacity++
+			}
+		}
+		tracker.SetCapacity(pool, capacity)
+	}
+
+	usage := tracker.Usage()
+	rv := make([]*QuotaUsage, 0, len(usage))
+	for _, u := range usage {
+		rv = append(rv, &QuotaUsage{
+			Quota:    u.Quota,
+			Repo:     u.Repo,
+			JobClass: string(u.JobClass),
+			Pool:     u.Pool,
+			Running:  int32(u.Running),
+			Capacity: int32(u.Capacity),
+			Min:      int32(u.Min),
+			Max:      int32(u.Max),
+		})
+	}
+	return &GetQuotaUsageResponse{
+		Usage: rv,
+	}, nil
+}
+
+// GetFlakeStats returns per-TaskSpec flake rates over the flake policy's
+// window.
+func (s *taskSchedulerServiceImpl) GetFlakeStats(ctx context.Context, req *GetFlakeStatsRequest) (*GetFlakeStatsResponse, error) {
+	end := now.Now(ctx)
+	tasks, err := s.db.GetTasksFromDateRange(ctx, end.Add(-s.flakePolicy.GetWindow()), end, req.Repo)
+	if err != nil {
+		sklog.Error(err)
+		return nil, twirp.InternalError("Failed to retrieve tasks")
+	}
+	quarantined := util.StringSet{}
+	for _, rule := range s.skipTasks.GetRules() {
+		if rule.AddedBy == flakes.QuarantineRuleAddedBy && !rule.Expired(end) {
+			quarantined[rule.Name] = true
+		}
+	}
+	stats := flakes.ComputeStats(tasks)
+	rv := make([]*FlakeStats, 0, len(stats))
+	for _, st := range stats {
+		rv = append(rv, &FlakeStats{
+			Repo:        st.Repo,
+			TaskName:    st.Name,
+			Runs:        int32(st.Runs),
+			Failures:    int32(st.Failures),
+			Flakes:      int32(st.Flakes),
+			FlakeRate:   float32(st.FlakeRate()),
+			Quarantined: quarantined[flakes.QuarantineRuleName(st.Repo, st.Name)],
+		})
+	}
+	sort.SliceStable(rv, func(i, j int) bool {
+		return rv[i].FlakeRate > rv[j].FlakeRate
+	})
+	return &GetFlakeStatsResponse{
+		Stats: rv,
+	}, nil
+}
+
 // convertRepoState converts a types.RepoState to rpc.RepoState.
 func convertRepoState(rs types.RepoState) *RepoState {
 	return &RepoState{
@@ -565,6 +704,7 @@ func convertJob(job *types.Job) (*Job, error) {
 		Id:                  job.Id,
 		IsForce:             job.IsForce,
 		Name:                job.Name,
+		PassedWithFlakes:    job.PassedWithFlakes,
 		Priority:            float32(job.Priority),
 		RepoState:           convertRepoState(job.RepoState),
 		RequestedAt:         timestamppb.New(job.Requested),
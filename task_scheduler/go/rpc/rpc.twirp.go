@@ -83,6 +83,12 @@ type TaskSchedulerService interface {
 
 	// DeleteSkipTaskRule deletes the given rule for skipping tasks.
 	DeleteSkipTaskRule(context.Context, *DeleteSkipTaskRuleRequest) (*DeleteSkipTaskRuleResponse, error)
+
+	// GetQuotaUsage returns the current usage of the fair-share quotas.
+	GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error)
+
+	// GetFlakeStats returns per-TaskSpec flake rates.
+	GetFlakeStats(context.Context, *GetFlakeStatsRequest) (*GetFlakeStatsResponse, error)
 }
 
 // ====================================
@@ -91,7 +97,7 @@ type TaskSchedulerService interface {
 
 type taskSchedulerServiceProtobufClient struct {
 	client      HTTPClient
-	urls        [9]string
+	urls        [11]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -111,7 +117,7 @@ func NewTaskSchedulerServiceProtobufClient(baseURL string, client HTTPClient, op
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(clientOpts.PathPrefix(), "task_scheduler.rpc", "TaskSchedulerService")
-	urls := [9]string{
+	urls := [11]string{
 		serviceURL + "TriggerJobs",
 		serviceURL + "GetJob",
 		serviceURL + "CancelJob",
@@ -121,6 +127,8 @@ func NewTaskSchedulerServiceProtobufClient(baseURL string, client HTTPClient, op
 		serviceURL + "GetSkipTaskRules",
 		serviceURL + "AddSkipTaskRule",
 		serviceURL + "DeleteSkipTaskRule",
+		serviceURL + "GetQuotaUsage",
+		serviceURL + "GetFlakeStats",
 	}
 
 	return &taskSchedulerServiceProtobufClient{
@@ -545,13 +553,105 @@ func (c *taskSchedulerServiceProtobufClient) callDeleteSkipTaskRule(ctx context.
 	return out, nil
 }
 
+func (c *taskSchedulerServiceProtobufClient) GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "task_scheduler.rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "TaskSchedulerService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetQuotaUsage")
+	caller := c.callGetQuotaUsage
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetQuotaUsageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetQuotaUsageRequest) when calling interceptor")
+					}
+					return c.callGetQuotaUsage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetQuotaUsageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetQuotaUsageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *taskSchedulerServiceProtobufClient) callGetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	out := new(GetQuotaUsageResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *taskSchedulerServiceProtobufClient) GetFlakeStats(ctx context.Context, in *GetFlakeStatsRequest) (*GetFlakeStatsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "task_scheduler.rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "TaskSchedulerService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetFlakeStats")
+	caller := c.callGetFlakeStats
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetFlakeStatsRequest) (*GetFlakeStatsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetFlakeStatsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetFlakeStatsRequest) when calling interceptor")
+					}
+					return c.callGetFlakeStats(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetFlakeStatsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetFlakeStatsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *taskSchedulerServiceProtobufClient) callGetFlakeStats(ctx context.Context, in *GetFlakeStatsRequest) (*GetFlakeStatsResponse, error) {
+	out := new(GetFlakeStatsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[10], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ================================
 // TaskSchedulerService JSON Client
 // ================================
 
 type taskSchedulerServiceJSONClient struct {
 	client      HTTPClient
-	urls        [9]string
+	urls        [11]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -571,7 +671,7 @@ func NewTaskSchedulerServiceJSONClient(baseURL string, client HTTPClient, opts .
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(clientOpts.PathPrefix(), "task_scheduler.rpc", "TaskSchedulerService")
-	urls := [9]string{
+	urls := [11]string{
 		serviceURL + "TriggerJobs",
 		serviceURL + "GetJob",
 		serviceURL + "CancelJob",
@@ -581,6 +681,8 @@ func NewTaskSchedulerServiceJSONClient(baseURL string, client HTTPClient, opts .
 		serviceURL + "GetSkipTaskRules",
 		serviceURL + "AddSkipTaskRule",
 		serviceURL + "DeleteSkipTaskRule",
+		serviceURL + "GetQuotaUsage",
+		serviceURL + "GetFlakeStats",
 	}
 
 	return &taskSchedulerServiceJSONClient{
@@ -1005,6 +1107,98 @@ func (c *taskSchedulerServiceJSONClient) callDeleteSkipTaskRule(ctx context.Cont
 	return out, nil
 }
 
+func (c *taskSchedulerServiceJSONClient) GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "task_scheduler.rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "TaskSchedulerService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetQuotaUsage")
+	caller := c.callGetQuotaUsage
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetQuotaUsageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetQuotaUsageRequest) when calling interceptor")
+					}
+					return c.callGetQuotaUsage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetQuotaUsageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetQuotaUsageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *taskSchedulerServiceJSONClient) callGetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	out := new(GetQuotaUsageResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *taskSchedulerServiceJSONClient) GetFlakeStats(ctx context.Context, in *GetFlakeStatsRequest) (*GetFlakeStatsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "task_scheduler.rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "TaskSchedulerService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetFlakeStats")
+	caller := c.callGetFlakeStats
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetFlakeStatsRequest) (*GetFlakeStatsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetFlakeStatsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetFlakeStatsRequest) when calling interceptor")
+					}
+					return c.callGetFlakeStats(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetFlakeStatsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetFlakeStatsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *taskSchedulerServiceJSONClient) callGetFlakeStats(ctx context.Context, in *GetFlakeStatsRequest) (*GetFlakeStatsResponse, error) {
+	out := new(GetFlakeStatsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[10], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ===================================
 // TaskSchedulerService Server Handler
 // ===================================
@@ -1116,6 +1310,12 @@ func (s *taskSchedulerServiceServer) ServeHTTP(resp http.ResponseWriter, req *ht
 	case "DeleteSkipTaskRule":
 		s.serveDeleteSkipTaskRule(ctx, resp, req)
 		return
+	case "GetQuotaUsage":
+		s.serveGetQuotaUsage(ctx, resp, req)
+		return
+	case "GetFlakeStats":
+		s.serveGetFlakeStats(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
@@ -2698,6 +2898,356 @@ func (s *taskSchedulerServiceServer) serveDeleteSkipTaskRuleProtobuf(ctx context
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *taskSchedulerServiceServer) serveGetQuotaUsage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetQuotaUsageJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetQuotaUsageProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *taskSchedulerServiceServer) serveGetQuotaUsageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetQuotaUsage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GetQuotaUsageRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	handler := s.TaskSchedulerService.GetQuotaUsage
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetQuotaUsageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetQuotaUsageRequest) when calling interceptor")
+					}
+					return s.TaskSchedulerService.GetQuotaUsage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetQuotaUsageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetQuotaUsageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetQuotaUsageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetQuotaUsageResponse and nil error while calling GetQuotaUsage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true, EmitDefaults: !s.jsonSkipDefaults}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *taskSchedulerServiceServer) serveGetQuotaUsageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetQuotaUsage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(GetQuotaUsageRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.TaskSchedulerService.GetQuotaUsage
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetQuotaUsageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetQuotaUsageRequest) when calling interceptor")
+					}
+					return s.TaskSchedulerService.GetQuotaUsage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetQuotaUsageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetQuotaUsageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetQuotaUsageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetQuotaUsageResponse and nil error while calling GetQuotaUsage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *taskSchedulerServiceServer) serveGetFlakeStats(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetFlakeStatsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetFlakeStatsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *taskSchedulerServiceServer) serveGetFlakeStatsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetFlakeStats")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GetFlakeStatsRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	handler := s.TaskSchedulerService.GetFlakeStats
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetFlakeStatsRequest) (*GetFlakeStatsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetFlakeStatsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetFlakeStatsRequest) when calling interceptor")
+					}
+					return s.TaskSchedulerService.GetFlakeStats(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetFlakeStatsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetFlakeStatsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetFlakeStatsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetFlakeStatsResponse and nil error while calling GetFlakeStats. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true, EmitDefaults: !s.jsonSkipDefaults}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *taskSchedulerServiceServer) serveGetFlakeStatsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetFlakeStats")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(GetFlakeStatsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.TaskSchedulerService.GetFlakeStats
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetFlakeStatsRequest) (*GetFlakeStatsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetFlakeStatsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetFlakeStatsRequest) when calling interceptor")
+					}
+					return s.TaskSchedulerService.GetFlakeStats(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetFlakeStatsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetFlakeStatsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetFlakeStatsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetFlakeStatsResponse and nil error while calling GetFlakeStats. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *taskSchedulerServiceServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -3263,133 +3813,152 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 2043 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcd, 0x72, 0x1b, 0xc7,
-	0x11, 0x36, 0xfe, 0x81, 0x06, 0xf1, 0xc3, 0xa1, 0x24, 0x42, 0x70, 0x49, 0xa2, 0x57, 0x8e, 0x44,
-	0x4b, 0x0a, 0x98, 0xa2, 0x4b, 0x72, 0x14, 0xc7, 0x49, 0x40, 0x0a, 0x16, 0x41, 0x49, 0x24, 0xb3,
-	0x80, 0xaa, 0x52, 0x4e, 0x95, 0xb7, 0x16, 0xd8, 0x21, 0xb1, 0x24, 0x80, 0xdd, 0xec, 0x0c, 0x68,
-	0xe3, 0x94, 0xaa, 0x54, 0xaa, 0x92, 0x6b, 0x2e, 0x79, 0x85, 0xbc, 0x44, 0x9e, 0x24, 0xc7, 0x9c,
-	0x72, 0xcb, 0x2b, 0xb8, 0xe6, 0x6f, 0x39, 0x00, 0x76, 0x01, 0x51, 0x3a, 0xe8, 0xb6, 0xd3, 0xfd,
-	0x75, 0xcf, 0x4c, 0x4f, 0x7f, 0x3d, 0xbd, 0x03, 0x85, 0xc0, 0xef, 0x37, 0xfc, 0xc0, 0xa3, 0x1e,
-	0x42, 0xd4, 0x26, 0x17, 0x16, 0xe9, 0x0f, 0xb0, 0x33, 0x19, 0xe2, 0xa0, 0x11, 0xf8, 0xfd, 0xfa,
-	0xbd, 0x33, 0xcf, 0x3b, 0x1b, 0xe2, 0x1d, 0x8e, 0xe8, 0x4d, 0x4e, 0x77, 0xa8, 0x3b, 0xc2, 0x84,
-	0xda, 0x23, 0x5f, 0x18, 0x19, 0x07, 0x00, 0xdd, 0xc0, 0x3d, 0x3b, 0xc3, 0xc1, 0xa1, 0xd7, 0x43,
-	0xb7, 0x21, 0x7f, 0xee, 0xf5, 0xac, 0xb1, 0x3d, 0xc2, 0xb5, 0xc4, 0x56, 0x62, 0xbb, 0x60, 0xe6,
-	0xce, 0xbd, 0xde, 0x91, 0x3d, 0xc2, 0xe8, 0x1e, 0x14, 0xfb, 0xde, 0x68, 0xe4, 0x52, 0x6b, 0x60,
-	0x93, 0x41, 0x2d, 0xc9, 0xb5, 0x20, 0x44, 0x07, 0x36, 0x19, 0x18, 0x07, 0x80, 0xae, 0x3c, 0x11,
-	0x13, 0xff, 0x69, 0x82, 0x09, 0x45, 0xbb, 0x90, 0x3e, 0xf7, 0x7a, 0xa4, 0x96, 0xd8, 0x4a, 0x6d,
-	0x17, 0x77, 0xef, 0x36, 0x16, 0xd7, 0xd8, 0xb8, 0xb2, 0x32, 0x39, 0xd6, 0x68, 0xc0, 0xc6, 0x8c,
-	0x27, 0xe2, 0x7b, 0x63, 0x82, 0xd1, 0x26, 0xb0, 0xc5, 0x58, 0xae, 0x23, 0xbc, 0x15, 0xcc, 0xec,
-	0xb9, 0xd7, 0x6b, 0x3b, 0xc4, 0xb8, 0x07, 0xa5, 0x97, 0x98, 0x32, 0x7b, 0x39, 0x69, 0x19, 0x92,
-	0xae, 0x23, 0x37, 0x90, 0x74, 0x1d, 0xe3, 0x6b, 0x28, 0x2b, 0x80, 0xf4, 0xf5, 0x05, 0xa4, 0xce,
-	0xbd, 0x1e, 0x87, 0x14, 0x77, 0x37, 0xa3, 0x56, 0xc5, 0xd0, 0x0c, 0x63, 0x18, 0x50, 0xdd, 0xb7,
-	0xc7, 0x7d, 0x3c, 0x5c, 0x32, 0xc1, 0x6f, 0x60, 0x5d, 0xc3, 0x5c, 0x7f, 0x8e, 0xff, 0x64, 0x60,
-	0xbd, 0x83, 0xed, 0xa0, 0x3f, 0xd0, 0x63, 0xf7, 0x0b, 0xb8, 0xd1, 0x9b, 0xb8, 0x43, 0xa7, 0x37,
-	0xe9, 0x5f, 0x60, 0x6a, 0xf1, 0x6f, 0x2b, 0x9c, 0x17, 0x69, 0xba, 0x3d, 0xf6, 0xd9, 0x76, 0xd0,
-	0x57, 0x50, 0x1b, 0xd8, 0xc4, 0x8a, 0xb4, 0x62, 0x27, 0x96, 0x37, 0x6f, 0x0e, 0x6c, 0xb2, 0xb7,
-	0x68, 0x78, 0x1b, 0xf2, 0x2e, 0xb1, 0x4e, 0xbd, 0xa0, 0x8f, 0x6b, 0x29, 0x0e, 0xcc, 0xb9, 0xe4,
-	0x5b, 0x36, 0x44, 0x5b, 0xb0, 0xc6, 0x7c, 0x86, 0xea, 0x34, 0x57, 0xc3, 0xc0, 0x26, 0x6d, 0x89,
-	0xb8, 0x01, 0x19, 0x97, 0x90, 0x09, 0xae, 0x65, 0xf8, 0xc2, 0xc4, 0x00, 0x7d, 0x0a, 0x05, 0x61,
-	0xc7, 0x34, 0x59, 0x6e, 0x94, 0xe7, 0x46, 0x4c, 0x89, 0x20, 0xcd, 0x93, 0x2c, 0xc7, 0x2d, 0xf8,
-	0x37, 0x5b, 0x03, 0x33, 0xe0, 0xf2, 0xbc, 0x58, 0xc3, 0xc0, 0x26, 0x3c, 0xf9, 0xea, 0x90, 0xf7,
-	0x6d, 0xda, 0x1f, 0x10, 0x4c, 0x6b, 0x05, 0x6e, 0x12, 0x8e, 0xd1, 0x67, 0x62, 0x7d, 0xa1, 0x1e,
-	0xb8, 0x69, 0x71, 0x60, 0x93, 0x13, 0x05, 0x41, 0x90, 0x0e, 0xb0, 0xef, 0xd5, 0x8a, 0x62, 0x36,
-	0xf6, 0xad, 0x66, 0xe3, 0xf2, 0xb5, 0x70, 0x36, 0x93, 0xa9, 0xea, 0x90, 0x0f, 0xf0, 0xa5, 0x4b,
-	0x5c, 0x6f, 0x5c, 0x2b, 0x89, 0xd9, 0xd4, 0x58, 0xcd, 0x16, 0xea, 0xcb, 0xe1, 0x6c, 0xa6, 0x82,
-	0x3c, 0x85, 0x2c, 0xa1, 0x36, 0x9d, 0x90, 0x5a, 0x65, 0x2b, 0xb1, 0x5d, 0xde, 0xbd, 0x13, 0x73,
-	0xf4, 0x1d, 0x0e, 0x32, 0x25, 0x18, 0xdd, 0x01, 0x16, 0x53, 0x4b, 0x9a, 0x56, 0xb9, 0x5f, 0x16,
-	0x41, 0x01, 0x43, 0xcf, 0x01, 0x18, 0x77, 0x99, 0x3e, 0xa0, 0xb5, 0x75, 0x9e, 0x54, 0xf5, 0x86,
-	0xa0, 0x77, 0x43, 0xd1, 0xbb, 0xd1, 0x55, 0xf4, 0x36, 0x0b, 0x0c, 0xdd, 0x61, 0x60, 0xf4, 0x39,
-	0x94, 0x99, 0x67, 0xcd, 0x1c, 0x71, 0xef, 0x6c, 0x27, 0xdd, 0x10, 0xf5, 0x14, 0xf2, 0x1c, 0x81,
-	0xc7, 0x4e, 0x6d, 0x63, 0xa5, 0xfb, 0x1c, 0xc3, 0xb6, 0xc6, 0x8e, 0x4a, 0x8f, 0xd0, 0xf4, 0x46,
-	0x98, 0x1e, 0x5d, 0x81, 0x30, 0x9a, 0x80, 0xf4, 0xdc, 0x96, 0xec, 0x78, 0x3c, 0x53, 0x18, 0x62,
-	0xe9, 0x21, 0x2a, 0x42, 0x8b, 0x13, 0xb8, 0x6b, 0x93, 0x8b, 0x18, 0x06, 0xa2, 0xfb, 0x50, 0x72,
-	0xc7, 0xfd, 0xe1, 0xc4, 0xe1, 0x5b, 0xa4, 0x44, 0xa6, 0xfb, 0x9a, 0x14, 0xb2, 0x20, 0x12, 0xe3,
-	0xb7, 0x50, 0x09, 0xdd, 0xc8, 0x65, 0x3c, 0x81, 0x34, 0x9b, 0x59, 0xb2, 0xb4, 0x16, 0x59, 0x9f,
-	0x18, 0x9e, 0xa3, 0x8c, 0xff, 0xa7, 0xd5, 0x5e, 0x98, 0x30, 0x24, 0x6a, 0x0d, 0x72, 0x36, 0xa5,
-	0x78, 0xe4, 0x53, 0xee, 0x27, 0x63, 0xaa, 0x21, 0xab, 0x9a, 0x2c, 0x3a, 0x4a, 0x9b, 0x0c, 0x83,
-	0xd3, 0x94, 0x80, 0x90, 0x3b, 0xa9, 0x58, 0xee, 0xa4, 0x63, 0xb8, 0x93, 0x89, 0xe1, 0x4e, 0x36,
-	0x9e, 0x3b, 0xb9, 0x15, 0xdc, 0xc9, 0xc7, 0x73, 0xa7, 0x10, 0xc3, 0x1d, 0x88, 0xe7, 0x4e, 0x71,
-	0x05, 0x77, 0xd6, 0x16, 0xb9, 0xf3, 0x2c, 0xe4, 0x4e, 0x89, 0x73, 0xe7, 0x6e, 0xdc, 0x81, 0x2c,
-	0x25, 0x4f, 0x79, 0x39, 0x79, 0x2a, 0x1f, 0x46, 0x9e, 0xea, 0x0a, 0xf2, 0xac, 0xbf, 0x3f, 0x79,
-	0xd0, 0x02, 0x79, 0x5a, 0xb0, 0x31, 0x93, 0x70, 0x32, 0x6d, 0x1b, 0x90, 0x61, 0x81, 0x51, 0xf4,
-	0x89, 0xcf, 0x5b, 0x01, 0x33, 0x6e, 0xc3, 0xe6, 0x4b, 0x4c, 0x3b, 0x17, 0xae, 0xcf, 0xa5, 0x93,
-	0x21, 0x56, 0xc9, 0x6b, 0xfc, 0x2b, 0x01, 0x6b, 0xba, 0x82, 0x9d, 0xae, 0xed, 0x38, 0xd8, 0xb1,
-	0x7a, 0x53, 0xd5, 0x04, 0xf0, 0xf1, 0xde, 0x14, 0x3d, 0x01, 0xd9, 0x64, 0xf8, 0xb8, 0xcf, 0xb2,
-	0x86, 0xe2, 0x60, 0xcc, 0xa8, 0xc6, 0x6e, 0xe3, 0x2a, 0xd3, 0x74, 0x7c, 0xdc, 0x3f, 0x91, 0x72,
-	0x46, 0x0b, 0xd1, 0x1f, 0x90, 0x5a, 0x8a, 0x43, 0xd4, 0x10, 0x6d, 0x41, 0xd1, 0xc1, 0xa4, 0x1f,
-	0xb8, 0x3e, 0x65, 0x89, 0x90, 0xe6, 0xb3, 0xe8, 0xa2, 0xa8, 0x24, 0x37, 0x4c, 0xa8, 0x2d, 0x6e,
-	0x42, 0x06, 0xe4, 0x19, 0x64, 0x02, 0x26, 0x90, 0x01, 0xd9, 0x8a, 0x0a, 0x88, 0x6e, 0x69, 0x0a,
-	0xb8, 0xf1, 0xcf, 0x04, 0xdc, 0x6a, 0x3a, 0xce, 0x8c, 0x4a, 0xb2, 0xfa, 0xe3, 0x6e, 0xf6, 0xf7,
-	0xb0, 0xb9, 0xb0, 0xae, 0x0f, 0xdc, 0xeb, 0x63, 0xb8, 0xfd, 0x02, 0x0f, 0x31, 0xc5, 0x51, 0xbb,
-	0x9d, 0x6f, 0x69, 0xba, 0x50, 0x8f, 0x02, 0x7f, 0xe0, 0x12, 0xfe, 0x97, 0x80, 0x02, 0xab, 0x13,
-	0x8c, 0x97, 0x18, 0x3d, 0x87, 0x0c, 0x2f, 0x3d, 0xb2, 0xfa, 0xde, 0x8f, 0xf2, 0x12, 0xa2, 0x1b,
-	0xbc, 0x24, 0x99, 0xc2, 0x22, 0x2c, 0x4b, 0x49, 0xad, 0x2c, 0xe9, 0xb5, 0x27, 0x35, 0x5b, 0x7b,
-	0xea, 0x3e, 0x64, 0xb8, 0xfd, 0x55, 0xc1, 0x4d, 0xe8, 0x05, 0xf7, 0x0e, 0x00, 0xf7, 0x6b, 0x69,
-	0x4e, 0x0b, 0x5c, 0xa2, 0xaa, 0x5a, 0x58, 0x23, 0x53, 0x73, 0x35, 0xf4, 0x16, 0x64, 0x09, 0x0e,
-	0x2e, 0x71, 0x20, 0x4f, 0x56, 0x8e, 0x8c, 0x3f, 0x43, 0x8e, 0xed, 0xfe, 0x15, 0x9e, 0xa2, 0x5f,
-	0x03, 0x30, 0xbf, 0xbc, 0x3c, 0x61, 0xb9, 0xd9, 0x3b, 0x4b, 0x37, 0x6b, 0x16, 0x82, 0x30, 0x4a,
-	0x2a, 0x3b, 0x92, 0x5a, 0xbd, 0x37, 0xa0, 0xc4, 0xbb, 0x31, 0xc7, 0x12, 0x2d, 0xb1, 0x5c, 0x55,
-	0x51, 0x08, 0x0f, 0x59, 0x5f, 0x6c, 0xfc, 0x37, 0x0b, 0x69, 0xb6, 0x82, 0x25, 0xd7, 0x93, 0x96,
-	0xb4, 0xc9, 0xd9, 0xa4, 0x7d, 0x0e, 0xd0, 0x0f, 0xb0, 0x4d, 0xb1, 0x63, 0xd9, 0x62, 0xcf, 0x2b,
-	0x2a, 0xa6, 0x44, 0x37, 0x29, 0xfa, 0x1d, 0x94, 0x9d, 0x9e, 0x35, 0xf2, 0x1c, 0xf7, 0xd4, 0x15,
-	0xe6, 0xe9, 0x95, 0xe6, 0x6b, 0x4e, 0xef, 0x8d, 0x34, 0x68, 0x52, 0xf4, 0x35, 0x14, 0x4f, 0xdd,
-	0xb1, 0x4b, 0x06, 0xc2, 0x3c, 0xb3, 0xd2, 0x1c, 0x14, 0xbc, 0xa9, 0x12, 0x39, 0x1b, 0x76, 0x06,
-	0x0f, 0xa1, 0xe2, 0x12, 0x6f, 0xc8, 0xb7, 0xe2, 0x4d, 0xa8, 0x3f, 0x51, 0xd7, 0x60, 0x59, 0x89,
-	0x8f, 0xb9, 0x94, 0xc5, 0x99, 0x77, 0x24, 0x79, 0x1e, 0x09, 0xfe, 0xcd, 0xae, 0xac, 0x91, 0xfd,
-	0xa3, 0xba, 0xbf, 0x09, 0xbf, 0x05, 0x33, 0x66, 0x71, 0x64, 0xff, 0x28, 0x2f, 0x70, 0x82, 0x1e,
-	0x40, 0xc5, 0xb7, 0x03, 0x3c, 0xa6, 0x16, 0x3f, 0x50, 0xf6, 0x7b, 0x02, 0xdc, 0x43, 0x49, 0x88,
-	0xd9, 0x11, 0xb4, 0x1d, 0x82, 0x0e, 0x00, 0xfc, 0xc0, 0xf3, 0x71, 0x40, 0x5d, 0x4c, 0x6a, 0x45,
-	0xce, 0x9b, 0xed, 0xb8, 0xba, 0xdd, 0x38, 0x09, 0xa1, 0xad, 0x31, 0x0d, 0xa6, 0xa6, 0x66, 0xcb,
-	0x0a, 0x74, 0x80, 0x69, 0x30, 0xb5, 0xbc, 0x53, 0x7e, 0x87, 0x16, 0xcc, 0x1c, 0x1f, 0x1f, 0x9f,
-	0xb2, 0x63, 0xe3, 0x97, 0x94, 0x08, 0x5c, 0x69, 0xf5, 0xb1, 0x49, 0x74, 0x93, 0x6a, 0x57, 0x6f,
-	0xf9, 0x5a, 0x57, 0xef, 0x03, 0xa8, 0x90, 0x1f, 0xec, 0x60, 0xe4, 0x8e, 0xcf, 0xac, 0x9e, 0x47,
-	0x59, 0x32, 0x56, 0xf8, 0xa2, 0x4a, 0x4a, 0xbc, 0xe7, 0xd1, 0xb6, 0x83, 0xb6, 0xa1, 0x1a, 0xe2,
-	0x64, 0xa4, 0xf8, 0x55, 0x5a, 0x30, 0xcb, 0x4a, 0x2e, 0x42, 0x85, 0x9e, 0x41, 0x9e, 0x03, 0x2e,
-	0xf0, 0x54, 0x5e, 0xa6, 0x9f, 0xc6, 0xad, 0xe5, 0x15, 0x9e, 0x9a, 0x39, 0x2a, 0x69, 0xf6, 0x25,
-	0x64, 0x44, 0xef, 0x87, 0xe2, 0x19, 0xa6, 0x36, 0x40, 0x4c, 0x81, 0xad, 0x7f, 0x03, 0x95, 0xb9,
-	0x58, 0xa3, 0x2a, 0xa4, 0xd8, 0xd4, 0xa2, 0x40, 0xb0, 0x4f, 0x56, 0x34, 0x2e, 0xed, 0xe1, 0x44,
-	0x71, 0x50, 0x0c, 0x7e, 0x95, 0xfc, 0x65, 0xc2, 0x38, 0x84, 0x2a, 0x73, 0xf9, 0x02, 0xfb, 0x78,
-	0xec, 0xe0, 0x71, 0x9f, 0x9d, 0x0f, 0xd2, 0x7a, 0xca, 0x82, 0xe8, 0x1c, 0x91, 0x01, 0x6b, 0x8e,
-	0x86, 0x91, 0x74, 0x9b, 0x91, 0x19, 0xff, 0x4e, 0x40, 0x91, 0xaf, 0x6f, 0x32, 0x1a, 0xd9, 0xc1,
-	0x74, 0xa1, 0xc7, 0xd5, 0x78, 0x9c, 0x9c, 0xe5, 0xf1, 0x7c, 0x9a, 0xa6, 0x16, 0xd3, 0xf4, 0xea,
-	0x78, 0xd3, 0xd7, 0x3a, 0xde, 0xa8, 0x63, 0xcb, 0x44, 0x1d, 0x9b, 0xf1, 0x1d, 0x94, 0xae, 0x56,
-	0x2f, 0xe3, 0xa0, 0xbd, 0x24, 0x88, 0xc2, 0xf5, 0x54, 0x35, 0x2e, 0x49, 0x4e, 0x80, 0x7b, 0xb1,
-	0xab, 0x10, 0x31, 0x50, 0xfd, 0xcb, 0x1b, 0x28, 0xf3, 0x30, 0xbb, 0x23, 0x3c, 0x66, 0xf5, 0x9c,
-	0xb0, 0x16, 0x99, 0x9b, 0x6a, 0x33, 0xf0, 0xac, 0xe1, 0x3d, 0xef, 0x5d, 0x00, 0x27, 0x84, 0xca,
-	0x58, 0x6b, 0x12, 0xe3, 0x1f, 0x09, 0x28, 0x84, 0x99, 0xc0, 0xb6, 0x48, 0x3d, 0x6a, 0x0f, 0x2d,
-	0xef, 0x12, 0x07, 0x03, 0x6c, 0x3b, 0x16, 0xe1, 0x1e, 0x93, 0x66, 0x99, 0xcb, 0x8f, 0xa5, 0xb8,
-	0x83, 0x1a, 0xb0, 0xe1, 0x78, 0x3f, 0x8c, 0x87, 0x9e, 0xed, 0xe8, 0xe0, 0x24, 0x07, 0xaf, 0x2b,
-	0xd5, 0x15, 0xfe, 0x11, 0xac, 0x4f, 0xfc, 0x79, 0x74, 0x8a, 0xa3, 0x2b, 0x42, 0x11, 0x62, 0x8d,
-	0xbf, 0x66, 0x21, 0x75, 0xe8, 0xf5, 0xde, 0xe3, 0xaf, 0x7f, 0x17, 0x6e, 0xea, 0x16, 0x43, 0x6c,
-	0x13, 0xcc, 0xc9, 0x23, 0xb2, 0x75, 0x43, 0x53, 0xbe, 0x66, 0x3a, 0xc6, 0x95, 0x8f, 0x5a, 0xdf,
-	0x0f, 0xe6, 0xc8, 0x90, 0xe1, 0xb9, 0xf0, 0x79, 0x5c, 0x2e, 0xe8, 0xe4, 0x9a, 0xa5, 0xcc, 0xfc,
-	0x4d, 0x91, 0x7d, 0x8f, 0x9b, 0x22, 0x17, 0xf2, 0x4b, 0x7f, 0x04, 0xc9, 0xcf, 0x3e, 0x82, 0xa8,
-	0x54, 0x2e, 0x68, 0xa9, 0xcc, 0x9a, 0x82, 0xc0, 0xf5, 0x02, 0x97, 0x4e, 0xf9, 0x5f, 0x50, 0xd2,
-	0x0c, 0xc7, 0x73, 0x37, 0x7e, 0xf1, 0x9a, 0x37, 0xfe, 0x37, 0xb0, 0x16, 0x88, 0xb6, 0x4c, 0x6c,
-	0x6b, 0x6d, 0xe5, 0xb6, 0x8a, 0x21, 0xbe, 0x49, 0xb5, 0x07, 0x88, 0xd2, 0x75, 0x1e, 0x20, 0xbe,
-	0x52, 0xd4, 0x2c, 0xf3, 0xe3, 0xf8, 0x6c, 0x39, 0x35, 0xd9, 0x59, 0x08, 0x3c, 0x7a, 0x05, 0x15,
-	0x0e, 0xd5, 0x28, 0x57, 0xe1, 0x2e, 0x8c, 0xd8, 0x13, 0x0d, 0x91, 0x66, 0x99, 0xce, 0x8c, 0x1f,
-	0xfd, 0x3d, 0x01, 0x70, 0x55, 0x86, 0xd0, 0x26, 0x6c, 0x74, 0x9b, 0x9d, 0x57, 0x56, 0xa7, 0xdb,
-	0xec, 0xbe, 0xed, 0x58, 0x27, 0xad, 0xa3, 0x17, 0xed, 0xa3, 0x97, 0xd5, 0x4f, 0xe6, 0x15, 0xe6,
-	0xdb, 0xa3, 0x23, 0xa6, 0x48, 0xcc, 0x2b, 0x3a, 0x6f, 0xf7, 0xf7, 0x5b, 0x9d, 0x4e, 0x35, 0x39,
-	0xaf, 0xf8, 0xb6, 0xd9, 0x7e, 0xfd, 0xd6, 0x6c, 0x55, 0x53, 0xe8, 0x16, 0x20, 0x5d, 0xf1, 0xa6,
-	0xdd, 0x39, 0x68, 0x9e, 0x54, 0xd3, 0x8f, 0xfe, 0x96, 0x80, 0x42, 0x18, 0x26, 0x54, 0x87, 0x5b,
-	0x87, 0xc7, 0x7b, 0x0a, 0xd4, 0x3e, 0xb2, 0x4e, 0xcc, 0xe3, 0x97, 0x26, 0x73, 0xfd, 0x09, 0xf3,
-	0xa0, 0xe9, 0xd4, 0x94, 0x89, 0x39, 0xb9, 0x9a, 0x31, 0x89, 0x6e, 0xc2, 0xba, 0x26, 0x97, 0x13,
-	0xa6, 0xd8, 0x0a, 0x35, 0xf1, 0x7e, 0xf3, 0x68, 0xbf, 0xf5, 0xba, 0xf5, 0xa2, 0x9a, 0xde, 0xfd,
-	0x4b, 0x0e, 0x6e, 0xf0, 0xa0, 0xa8, 0x48, 0x76, 0x70, 0x70, 0xe9, 0xf6, 0x31, 0xfa, 0x1e, 0x8a,
-	0xda, 0x53, 0x29, 0x7a, 0xb0, 0xfc, 0x7d, 0x55, 0xfd, 0xf3, 0xd5, 0x1f, 0xae, 0xc4, 0xc9, 0x3e,
-	0xff, 0x18, 0xb2, 0xe2, 0xe5, 0x14, 0x45, 0xa6, 0xc3, 0xcc, 0xb3, 0x6b, 0xdd, 0x58, 0x06, 0x91,
-	0x0e, 0xff, 0x00, 0x85, 0xf0, 0xa5, 0x14, 0x45, 0x32, 0x7e, 0xfe, 0xb1, 0xb5, 0xfe, 0xb3, 0x15,
-	0x28, 0xe9, 0xf9, 0x8f, 0x00, 0x57, 0xcf, 0x4c, 0x28, 0xd2, 0x68, 0xe1, 0x89, 0xb5, 0xfe, 0x60,
-	0x15, 0x4c, 0x3a, 0x37, 0x21, 0x27, 0x5f, 0x8e, 0x50, 0xdc, 0x2e, 0xb5, 0xd7, 0xa9, 0xfa, 0xfd,
-	0xa5, 0x18, 0xe9, 0xf3, 0x7b, 0x28, 0x6a, 0xbf, 0xf6, 0x68, 0xc9, 0x52, 0xf4, 0xc7, 0xa6, 0xe8,
-	0xb3, 0x8b, 0x7a, 0x23, 0x18, 0x41, 0x75, 0xfe, 0x77, 0x19, 0x3d, 0x8e, 0x59, 0x58, 0xd4, 0xcb,
-	0x40, 0xfd, 0xc9, 0xbb, 0x81, 0xe5, 0x74, 0xe7, 0x50, 0x99, 0xfb, 0x61, 0x45, 0x8f, 0xa2, 0x1c,
-	0x44, 0xff, 0x6d, 0xd7, 0x1f, 0xbf, 0x13, 0x56, 0xce, 0x45, 0x00, 0x2d, 0xfe, 0x9c, 0xa2, 0x9f,
-	0x47, 0xb9, 0x88, 0xfd, 0xe3, 0xad, 0x37, 0xde, 0x15, 0x2e, 0x26, 0xdd, 0xfb, 0xe2, 0xbb, 0x87,
-	0x67, 0x5e, 0x83, 0x5c, 0xb8, 0x76, 0xc3, 0x0b, 0xce, 0x76, 0xdc, 0xf1, 0x69, 0x60, 0xef, 0xcc,
-	0xba, 0xd8, 0x39, 0xf3, 0x76, 0x02, 0xbf, 0xdf, 0xcb, 0xf2, 0x12, 0xfd, 0xe5, 0x4f, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0xea, 0x45, 0x87, 0x19, 0x9e, 0x19, 0x00, 0x00,
+	// 2337 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0x4b, 0x73, 0xdb, 0xc8,
+	0xf1, 0x5f, 0xbe, 0xc9, 0xa6, 0x44, 0x52, 0x23, 0x5b, 0xa6, 0xb9, 0xe5, 0xb5, 0x16, 0xde, 0xbf,
+	0x2d, 0x3f, 0xfe, 0x54, 0x4a, 0x1b, 0x7b, 0xe3, 0x6c, 0x36, 0x09, 0x25, 0xcb, 0x96, 0xfc, 0x90,
+	0xb4, 0xa0, 0x5c, 0x49, 0x6d, 0xaa, 0x16, 0x35, 0x24, 0x46, 0x22, 0x24, 0x12, 0x80, 0x31, 0x43,
+	0xdb, 0x3a, 0xe5, 0x98, 0x5c, 0x73, 0xc9, 0x57, 0xc8, 0x3d, 0xe7, 0x5c, 0x72, 0xc9, 0x31, 0x1f,
+	0x20, 0xc7, 0x9c, 0x72, 0xcb, 0x57, 0x48, 0xf5, 0xcc, 0x00, 0x04, 0x1f, 0x20, 0xad, 0xf5, 0x61,
+	0x6f, 0x33, 0x3d, 0xbf, 0xee, 0x99, 0xe9, 0xe9, 0x17, 0x1a, 0x50, 0x0a, 0xfc, 0x6e, 0xd3, 0x0f,
+	0x3c, 0xe1, 0x11, 0x22, 0x28, 0x3f, 0xb7, 0x78, 0xb7, 0xc7, 0xec, 0x61, 0x9f, 0x05, 0xcd, 0xc0,
+	0xef, 0x36, 0x6e, 0x9e, 0x7a, 0xde, 0x69, 0x9f, 0x6d, 0x4a, 0x44, 0x67, 0x78, 0xb2, 0x29, 0x9c,
+	0x01, 0xe3, 0x82, 0x0e, 0x7c, 0xc5, 0x64, 0xec, 0x01, 0x1c, 0x07, 0xce, 0xe9, 0x29, 0x0b, 0x9e,
+	0x7b, 0x1d, 0x72, 0x1d, 0x8a, 0x67, 0x5e, 0xc7, 0x72, 0xe9, 0x80, 0xd5, 0x53, 0xeb, 0xa9, 0x8d,
+	0x92, 0x59, 0x38, 0xf3, 0x3a, 0x07, 0x74, 0xc0, 0xc8, 0x4d, 0x28, 0x77, 0xbd, 0xc1, 0xc0, 0x11,
+	0x56, 0x8f, 0xf2, 0x5e, 0x3d, 0x2d, 0x57, 0x41, 0x91, 0xf6, 0x28, 0xef, 0x19, 0x7b, 0x40, 0x46,
+	0x92, 0xb8, 0xc9, 0xde, 0x0c, 0x19, 0x17, 0x64, 0x0b, 0xb2, 0x67, 0x5e, 0x87, 0xd7, 0x53, 0xeb,
+	0x99, 0x8d, 0xf2, 0xd6, 0x67, 0xcd, 0xe9, 0x33, 0x36, 0x47, 0x5c, 0xa6, 0xc4, 0x1a, 0x4d, 0x58,
+	0x1d, 0x93, 0xc4, 0x7d, 0xcf, 0xe5, 0x8c, 0x5c, 0x03, 0x3c, 0x8c, 0xe5, 0xd8, 0x4a, 0x5a, 0xc9,
+	0xcc, 0x9f, 0x79, 0x9d, 0x7d, 0x9b, 0x1b, 0x37, 0x61, 0xf9, 0x19, 0x13, 0xc8, 0xaf, 0x37, 0xad,
+	0x40, 0xda, 0xb1, 0xf5, 0x05, 0xd2, 0x8e, 0x6d, 0x7c, 0x0d, 0x95, 0x10, 0xa0, 0x65, 0xdd, 0x85,
+	0xcc, 0x99, 0xd7, 0x91, 0x90, 0xf2, 0xd6, 0xb5, 0x59, 0xa7, 0x42, 0x34, 0x62, 0x0c, 0x03, 0x6a,
+	0x3b, 0xd4, 0xed, 0xb2, 0xfe, 0x9c, 0x0d, 0x7e, 0x09, 0x2b, 0x31, 0xcc, 0xe5, 0xf7, 0xf8, 0x57,
+	0x0e, 0x56, 0xda, 0x8c, 0x06, 0xdd, 0x5e, 0x5c, 0x77, 0x3f, 0x81, 0x2b, 0x9d, 0xa1, 0xd3, 0xb7,
+	0x3b, 0xc3, 0xee, 0x39, 0x13, 0x96, 0x1c, 0x5b, 0xd1, 0xbe, 0x24, 0xb6, 0xb6, 0x8d, 0xc3, 0x7d,
+	0x9b, 0x7c, 0x05, 0xf5, 0x1e, 0xe5, 0xd6, 0x4c, 0x2e, 0x7c, 0xb1, 0xa2, 0x79, 0xb5, 0x47, 0xf9,
+	0xf6, 0x34, 0xe3, 0x75, 0x28, 0x3a, 0xdc, 0x3a, 0xf1, 0x82, 0x2e, 0xab, 0x67, 0x24, 0xb0, 0xe0,
+	0xf0, 0xa7, 0x38, 0x25, 0xeb, 0xb0, 0x84, 0x32, 0xa3, 0xe5, 0xac, 0x5c, 0x86, 0x1e, 0xe5, 0xfb,
+	0x1a, 0x71, 0x05, 0x72, 0x0e, 0xe7, 0x43, 0x56, 0xcf, 0xc9, 0x83, 0xa9, 0x09, 0xf9, 0x14, 0x4a,
+	0x8a, 0x0f, 0x57, 0xf2, 0x92, 0xa9, 0x28, 0x99, 0x70, 0x91, 0x40, 0x56, 0x1a, 0x59, 0x41, 0x72,
+	0xc8, 0x31, 0x9e, 0x01, 0x19, 0x24, 0xbd, 0xa8, 0xce, 0xd0, 0xa3, 0x5c, 0x1a, 0x5f, 0x03, 0x8a,
+	0x3e, 0x15, 0xdd, 0x1e, 0x67, 0xa2, 0x5e, 0x92, 0x2c, 0xd1, 0x9c, 0x7c, 0xae, 0xce, 0x17, 0xad,
+	0x83, 0x64, 0x2d, 0xf7, 0x28, 0x3f, 0x0a, 0x21, 0x04, 0xb2, 0x01, 0xf3, 0xbd, 0x7a, 0x59, 0xed,
+	0x86, 0xe3, 0x70, 0x37, 0x49, 0x5f, 0x8a, 0x76, 0x33, 0x71, 0xa9, 0x01, 0xc5, 0x80, 0xbd, 0x75,
+	0xb8, 0xe3, 0xb9, 0xf5, 0x65, 0xb5, 0x5b, 0x38, 0x0f, 0x77, 0x8b, 0xd6, 0x2b, 0xd1, 0x6e, 0x66,
+	0x08, 0x79, 0x08, 0x79, 0x2e, 0xa8, 0x18, 0xf2, 0x7a, 0x75, 0x3d, 0xb5, 0x51, 0xd9, 0xba, 0x91,
+	0xf0, 0xf4, 0x6d, 0x09, 0x32, 0x35, 0x98, 0xdc, 0x00, 0xd4, 0xa9, 0xa5, 0x59, 0x6b, 0x52, 0x2e,
+	0x6a, 0x50, 0xc1, 0xc8, 0x63, 0x00, 0xf4, 0x5d, 0x5c, 0x0f, 0x44, 0x7d, 0x45, 0x1a, 0x55, 0xa3,
+	0xa9, 0xdc, 0xbb, 0x19, 0xba, 0x77, 0xf3, 0x38, 0x74, 0x6f, 0xb3, 0x84, 0xe8, 0x36, 0x82, 0xc9,
+	0x17, 0x50, 0x41, 0xc9, 0x31, 0x76, 0x22, 0xa5, 0xe3, 0x4d, 0x8e, 0x23, 0xd4, 0x43, 0x28, 0x4a,
+	0x04, 0x73, 0xed, 0xfa, 0xea, 0x42, 0xf1, 0x05, 0xc4, 0xee, 0xba, 0x76, 0x68, 0x1e, 0x11, 0xeb,
+	0x95, 0xc8, 0x3c, 0x8e, 0x15, 0xc2, 0x68, 0x01, 0x89, 0xdb, 0xb6, 0xf6, 0x8e, 0xfb, 0x63, 0x81,
+	0x21, 0xd1, 0x3d, 0x54, 0x44, 0xd8, 0x95, 0x0e, 0x7c, 0x4c, 0xf9, 0x79, 0x82, 0x07, 0x92, 0x5b,
+	0xb0, 0xec, 0xb8, 0xdd, 0xfe, 0xd0, 0x96, 0x57, 0x14, 0x5c, 0x9b, 0xfb, 0x92, 0x26, 0xa2, 0x12,
+	0xb9, 0xf1, 0x2b, 0xa8, 0x46, 0x62, 0xf4, 0x31, 0x1e, 0x40, 0x16, 0x77, 0xd6, 0x5e, 0x5a, 0x9f,
+	0x19, 0x9f, 0x10, 0x2f, 0x51, 0xc6, 0x7f, 0xb3, 0xe1, 0x5d, 0x90, 0x18, 0x39, 0x6a, 0x1d, 0x0a,
+	0x54, 0x08, 0x36, 0xf0, 0x85, 0x94, 0x93, 0x33, 0xc3, 0x29, 0x46, 0x4d, 0xd4, 0x4e, 0xb8, 0x9a,
+	0x8e, 0x94, 0xd3, 0xd2, 0x80, 0xc8, 0x77, 0x32, 0x89, 0xbe, 0x93, 0x4d, 0xf0, 0x9d, 0x5c, 0x82,
+	0xef, 0xe4, 0x93, 0x7d, 0xa7, 0xb0, 0xc0, 0x77, 0x8a, 0xc9, 0xbe, 0x53, 0x4a, 0xf0, 0x1d, 0x48,
+	0xf6, 0x9d, 0xf2, 0x02, 0xdf, 0x59, 0x9a, 0xf6, 0x9d, 0x47, 0x91, 0xef, 0x2c, 0x4b, 0xdf, 0xf9,
+	0x2c, 0xe9, 0x41, 0xe6, 0x3a, 0x4f, 0x65, 0xbe, 0xf3, 0x54, 0x3f, 0xce, 0x79, 0x6a, 0x0b, 0x9c,
+	0x67, 0xe5, 0x87, 0x3b, 0x0f, 0x99, 0x72, 0x9e, 0x5d, 0x58, 0x1d, 0x33, 0x38, 0x6d, 0xb6, 0x4d,
+	0xc8, 0xa1, 0x62, 0x42, 0xf7, 0x49, 0xb6, 0x5b, 0x05, 0x33, 0xae, 0xc3, 0xb5, 0x67, 0x4c, 0xb4,
+	0xcf, 0x1d, 0x5f, 0x52, 0x87, 0x7d, 0x16, 0x1a, 0xaf, 0xf1, 0x97, 0x14, 0x2c, 0xc5, 0x17, 0xf0,
+	0x75, 0xa9, 0x6d, 0x33, 0xdb, 0xea, 0x5c, 0x84, 0x45, 0x80, 0x9c, 0x6f, 0x5f, 0x90, 0x07, 0xa0,
+	0x8b, 0x0c, 0x9f, 0x75, 0xd1, 0x6a, 0x04, 0x0b, 0x5c, 0x74, 0x35, 0xcc, 0xc6, 0x35, 0x5c, 0x69,
+	0xfb, 0xac, 0x7b, 0xa4, 0xe9, 0xe8, 0x16, 0xaa, 0x3e, 0xe0, 0xf5, 0x8c, 0x84, 0x84, 0x53, 0xb2,
+	0x0e, 0x65, 0x9b, 0xf1, 0x6e, 0xe0, 0xf8, 0x02, 0x0d, 0x21, 0x2b, 0x77, 0x89, 0x93, 0x66, 0x19,
+	0xb9, 0x61, 0x42, 0x7d, 0xfa, 0x12, 0x5a, 0x21, 0x8f, 0x20, 0x17, 0x20, 0x41, 0x2b, 0x64, 0x7d,
+	0x96, 0x42, 0xe2, 0x9c, 0xa6, 0x82, 0x1b, 0x7f, 0x4e, 0xc1, 0x5a, 0xcb, 0xb6, 0xc7, 0x96, 0xb4,
+	0x57, 0xff, 0xb8, 0x97, 0xfd, 0x16, 0xae, 0x4d, 0x9d, 0xeb, 0x23, 0xef, 0x7a, 0x1f, 0xae, 0x3f,
+	0x61, 0x7d, 0x26, 0xd8, 0xac, 0xdb, 0x4e, 0x96, 0x34, 0xc7, 0xd0, 0x98, 0x05, 0xfe, 0xc8, 0x23,
+	0xac, 0xc1, 0x95, 0x67, 0x4c, 0x7c, 0x3b, 0xf4, 0x04, 0x7d, 0xcd, 0xe9, 0x69, 0xb8, 0xbb, 0xf1,
+	0xf7, 0x14, 0xc0, 0x88, 0x8a, 0x51, 0xf1, 0x0d, 0xce, 0xf4, 0x79, 0xd4, 0x24, 0x0a, 0x45, 0xe9,
+	0x58, 0x28, 0xfa, 0x14, 0x4a, 0x58, 0x14, 0x76, 0xfb, 0x94, 0x73, 0x1d, 0x43, 0xb1, 0x84, 0xdd,
+	0xc1, 0x39, 0x32, 0xf8, 0x9e, 0xd7, 0xd7, 0x2a, 0x97, 0x63, 0x7c, 0xa7, 0x60, 0xe8, 0xba, 0x8e,
+	0x7b, 0x2a, 0xd5, 0x9d, 0x33, 0xc3, 0x29, 0x86, 0xae, 0x2e, 0xf5, 0x69, 0xd7, 0x11, 0x17, 0x32,
+	0x86, 0xe6, 0xcc, 0x68, 0x4e, 0x6a, 0x90, 0x19, 0x38, 0xae, 0x8c, 0x9f, 0x39, 0x13, 0x87, 0x92,
+	0x42, 0xdf, 0xd7, 0x8b, 0x9a, 0x42, 0xdf, 0x1b, 0xaf, 0xe0, 0xea, 0xc4, 0xdd, 0xb4, 0xb2, 0x7e,
+	0x0a, 0xb9, 0x21, 0x12, 0xe6, 0x15, 0xc1, 0x31, 0x36, 0x05, 0x36, 0xee, 0x49, 0x55, 0x3d, 0xed,
+	0xd3, 0x73, 0x95, 0xbd, 0xc2, 0x87, 0x0a, 0xb5, 0x90, 0x1a, 0x69, 0xc1, 0xf8, 0x47, 0x0a, 0x60,
+	0x84, 0x9c, 0x05, 0x41, 0x45, 0xc9, 0x6d, 0xa5, 0xa1, 0x29, 0x0d, 0x16, 0x91, 0x20, 0x73, 0x04,
+	0x32, 0x0c, 0x5d, 0xa5, 0xc0, 0x9c, 0x29, 0xc7, 0xa8, 0x8e, 0x13, 0xea, 0xf4, 0x87, 0x01, 0xe3,
+	0x52, 0x81, 0x39, 0x33, 0x9a, 0x93, 0x35, 0xc8, 0x9f, 0xe0, 0x76, 0x5c, 0xeb, 0x50, 0xcf, 0x30,
+	0x0c, 0xcb, 0x91, 0x15, 0x50, 0xa1, 0x12, 0x51, 0xda, 0x2c, 0x49, 0x8a, 0x49, 0x05, 0x96, 0x92,
+	0xe5, 0x37, 0x43, 0x1a, 0x50, 0x57, 0x38, 0x2e, 0xb3, 0xa5, 0x36, 0x8b, 0x66, 0x9c, 0xa4, 0x75,
+	0x18, 0xbf, 0xf4, 0x48, 0x87, 0x2a, 0xaf, 0xcf, 0xd1, 0x61, 0x8c, 0x4d, 0x81, 0x8d, 0xff, 0xa4,
+	0xa0, 0x84, 0x69, 0x09, 0x89, 0x8c, 0x3c, 0x86, 0x9c, 0xcc, 0x74, 0x3a, 0xd9, 0xdf, 0x9a, 0x25,
+	0x23, 0x42, 0x37, 0x65, 0x06, 0x34, 0x15, 0xc7, 0x4c, 0xd3, 0x8b, 0xa7, 0xba, 0xcc, 0x78, 0xaa,
+	0x6b, 0xf8, 0x90, 0x93, 0xfc, 0xa3, 0xfc, 0x9e, 0x8a, 0xe7, 0xf7, 0x1b, 0x00, 0x52, 0xae, 0x15,
+	0x13, 0x5a, 0x92, 0x94, 0x30, 0x89, 0x46, 0x29, 0x39, 0x33, 0x91, 0xb2, 0xd7, 0x20, 0xcf, 0x59,
+	0xf0, 0x96, 0x05, 0xda, 0xaa, 0xf5, 0xcc, 0xf8, 0x3d, 0x14, 0xd0, 0xd9, 0x5e, 0xb0, 0x0b, 0xf2,
+	0x0b, 0x00, 0x94, 0x2b, 0xb3, 0x21, 0xd3, 0x97, 0xbd, 0x31, 0xf7, 0xb2, 0x66, 0x29, 0x08, 0x87,
+	0x51, 0x30, 0x4a, 0xc7, 0xca, 0x0b, 0x03, 0x96, 0x65, 0xf1, 0x6f, 0x5b, 0xea, 0x0b, 0x4c, 0x9f,
+	0xaa, 0xac, 0x88, 0xcf, 0xf1, 0x33, 0xcc, 0xf8, 0x77, 0x1e, 0xb2, 0x78, 0x82, 0x39, 0xd5, 0x50,
+	0x2c, 0x46, 0xa6, 0xc7, 0x63, 0xe4, 0x63, 0x80, 0x6e, 0xc0, 0xa8, 0x60, 0xb6, 0x45, 0xd5, 0x9d,
+	0x17, 0x24, 0x68, 0x8d, 0x6e, 0x09, 0xf2, 0x6b, 0xa8, 0xd8, 0x1d, 0x6b, 0xe0, 0xd9, 0xce, 0x89,
+	0xa3, 0xd8, 0xb3, 0x0b, 0xd9, 0x97, 0xec, 0xce, 0x2b, 0xcd, 0xd0, 0x12, 0xe4, 0x6b, 0x28, 0x9f,
+	0x38, 0xae, 0xc3, 0x7b, 0x8a, 0x3d, 0xb7, 0x90, 0x1d, 0x42, 0x78, 0x2b, 0x8c, 0x9b, 0xf9, 0xa8,
+	0x10, 0xbd, 0x03, 0x55, 0x87, 0x7b, 0x7d, 0x79, 0x15, 0x6f, 0x28, 0xfc, 0x61, 0x58, 0x75, 0x55,
+	0x42, 0xf2, 0xa1, 0xa4, 0xa2, 0x9e, 0x65, 0x01, 0x5c, 0x94, 0x9a, 0x90, 0x63, 0xac, 0x90, 0x06,
+	0xf4, 0x7d, 0x58, 0x2e, 0x72, 0x59, 0x74, 0xe5, 0xcc, 0xf2, 0x80, 0xbe, 0xd7, 0xf5, 0x22, 0x27,
+	0xb7, 0xa1, 0xea, 0xd3, 0x80, 0xb9, 0xc2, 0x92, 0x0f, 0x8a, 0x5f, 0xc3, 0x20, 0x25, 0x2c, 0x2b,
+	0x32, 0x3e, 0xc1, 0xbe, 0xcd, 0xc9, 0x1e, 0x80, 0x1f, 0x78, 0x3e, 0x0b, 0x84, 0xc3, 0x78, 0xbd,
+	0x2c, 0xbd, 0x66, 0x23, 0xa9, 0x4c, 0x68, 0x1e, 0x45, 0xd0, 0x5d, 0x57, 0x04, 0x17, 0x66, 0x8c,
+	0x17, 0xeb, 0x81, 0x80, 0x89, 0xe0, 0xc2, 0xf2, 0x4e, 0x64, 0xc9, 0x56, 0x32, 0x0b, 0x72, 0x7e,
+	0x78, 0x82, 0xcf, 0x26, 0x6b, 0x22, 0xa5, 0xb8, 0xe5, 0xc5, 0xcf, 0xa6, 0xd1, 0x2d, 0x11, 0xab,
+	0xf4, 0x2a, 0x97, 0xaa, 0xf4, 0x6e, 0x43, 0x95, 0xbf, 0xa3, 0xc1, 0xc0, 0x71, 0x4f, 0xad, 0x8e,
+	0x27, 0xd0, 0x18, 0xab, 0xf2, 0x50, 0xcb, 0x21, 0x79, 0xdb, 0x13, 0xfb, 0x36, 0xd9, 0x80, 0x5a,
+	0x84, 0xd3, 0x9a, 0x92, 0x95, 0x5b, 0xc9, 0xac, 0x84, 0x74, 0xa5, 0x2a, 0xf2, 0x08, 0x64, 0x20,
+	0xb4, 0xce, 0xd9, 0x85, 0xae, 0xdd, 0x3e, 0x4d, 0x3a, 0xcb, 0x0b, 0x76, 0x61, 0x16, 0x84, 0x1a,
+	0x90, 0x2f, 0xc3, 0x90, 0x44, 0x92, 0x3d, 0x2c, 0xbc, 0x40, 0x18, 0x91, 0x1a, 0xdf, 0x40, 0x75,
+	0x42, 0xd7, 0x98, 0x49, 0x70, 0x6b, 0x15, 0x20, 0x70, 0x88, 0x41, 0xe3, 0x2d, 0xed, 0x0f, 0x43,
+	0x1f, 0x54, 0x93, 0x9f, 0xa7, 0x7f, 0x96, 0x32, 0x9e, 0x43, 0x0d, 0x45, 0x3e, 0x61, 0x3e, 0x73,
+	0x6d, 0xe6, 0x76, 0xf1, 0x7d, 0x48, 0xec, 0x13, 0xa6, 0xa4, 0x3e, 0x54, 0x88, 0x01, 0x4b, 0x76,
+	0x0c, 0xa3, 0xdd, 0x6d, 0x8c, 0x66, 0xfc, 0x2d, 0x05, 0x65, 0x79, 0xbe, 0xe1, 0x60, 0x40, 0x83,
+	0x8b, 0xa9, 0x4f, 0xaa, 0x98, 0x1f, 0xa7, 0xc7, 0xfd, 0x78, 0xd2, 0x4c, 0x33, 0xd3, 0x66, 0x3a,
+	0x7a, 0xde, 0xec, 0xa5, 0x9e, 0x77, 0xd6, 0xb3, 0xe5, 0x66, 0x3d, 0x9b, 0xf1, 0x1d, 0x2c, 0x8f,
+	0x4e, 0xaf, 0xf5, 0x10, 0x6b, 0x5c, 0xc9, 0x31, 0x79, 0x18, 0xd6, 0xc9, 0x69, 0xe9, 0x00, 0x37,
+	0x13, 0x4f, 0xa1, 0x74, 0x10, 0x96, 0xcb, 0xaf, 0xa0, 0x22, 0xd5, 0xec, 0x0c, 0x98, 0x8b, 0xf1,
+	0x9c, 0x8f, 0xa7, 0xcf, 0xd4, 0x44, 0xfa, 0xfc, 0x0c, 0xc0, 0x8e, 0xa0, 0x5a, 0xd7, 0x31, 0x8a,
+	0xf1, 0xa7, 0x14, 0x94, 0x22, 0x4b, 0xc0, 0x2b, 0x0a, 0x4f, 0xd0, 0xbe, 0xe5, 0xbd, 0x65, 0x41,
+	0x8f, 0x51, 0xdb, 0xe2, 0x52, 0x62, 0xda, 0xac, 0x48, 0xfa, 0xa1, 0x26, 0xb7, 0x49, 0x13, 0x56,
+	0x6d, 0xef, 0x9d, 0xdb, 0xf7, 0xa8, 0x1d, 0x07, 0xa7, 0x25, 0x78, 0x25, 0x5c, 0x1a, 0xe1, 0xef,
+	0xc1, 0xca, 0xd0, 0x9f, 0x44, 0x67, 0x24, 0xba, 0x3a, 0xf4, 0xc7, 0xb0, 0xc6, 0x5f, 0xf3, 0x90,
+	0xc1, 0x96, 0xdf, 0xe5, 0x9b, 0x4c, 0x5b, 0x70, 0x35, 0xce, 0xd1, 0x67, 0x94, 0x33, 0xe9, 0x3c,
+	0xca, 0x5a, 0x57, 0x63, 0x8b, 0x2f, 0x71, 0x0d, 0x7d, 0xe5, 0x47, 0x8d, 0xef, 0x7b, 0x13, 0xce,
+	0x90, 0x93, 0xb6, 0xf0, 0x45, 0x92, 0x2d, 0xc4, 0x9d, 0x6b, 0xdc, 0x65, 0x26, 0x33, 0x45, 0xfe,
+	0x07, 0x64, 0x8a, 0x42, 0xe4, 0x5f, 0xf1, 0x9e, 0x5b, 0x71, 0xbc, 0xe7, 0x16, 0x9a, 0x72, 0x29,
+	0x66, 0xca, 0x58, 0x14, 0x04, 0x8e, 0x17, 0x60, 0x79, 0x0a, 0xf2, 0x4d, 0xa3, 0xf9, 0x44, 0xc6,
+	0x2f, 0x5f, 0x32, 0xe3, 0x7f, 0x03, 0x4b, 0x81, 0x2a, 0x2e, 0xd5, 0xb5, 0x96, 0x16, 0x5e, 0xab,
+	0x1c, 0xe1, 0x5b, 0x22, 0xd6, 0xef, 0x5a, 0xbe, 0x4c, 0xbf, 0xeb, 0xab, 0xd0, 0x35, 0x2b, 0xf2,
+	0x39, 0x3e, 0x9f, 0xef, 0x9a, 0xf8, 0x16, 0x0a, 0x4f, 0x5e, 0x40, 0x55, 0x42, 0x63, 0x2e, 0x57,
+	0x95, 0x22, 0x8c, 0xc4, 0x17, 0x8d, 0x90, 0x66, 0x45, 0x8c, 0xcd, 0xf1, 0x23, 0xcf, 0xa7, 0x9c,
+	0x33, 0xdb, 0x7a, 0xe7, 0x88, 0x9e, 0xa5, 0xab, 0x5a, 0xf5, 0x89, 0x5f, 0x53, 0x2b, 0xbf, 0x71,
+	0x44, 0x4f, 0x96, 0x96, 0xfc, 0xde, 0x1f, 0x53, 0x00, 0xa3, 0xa0, 0x45, 0xae, 0xc1, 0xea, 0x71,
+	0xab, 0xfd, 0xc2, 0x6a, 0x1f, 0xb7, 0x8e, 0x5f, 0xb7, 0xad, 0xa3, 0xdd, 0x83, 0x27, 0xfb, 0x07,
+	0xcf, 0x6a, 0x9f, 0x4c, 0x2e, 0x98, 0xaf, 0x0f, 0x0e, 0x70, 0x21, 0x35, 0xb9, 0xd0, 0x7e, 0xbd,
+	0xb3, 0xb3, 0xdb, 0x6e, 0xd7, 0xd2, 0x93, 0x0b, 0x4f, 0x5b, 0xfb, 0x2f, 0x5f, 0x9b, 0xbb, 0xb5,
+	0x0c, 0x59, 0x03, 0x12, 0x5f, 0x78, 0xb5, 0xdf, 0xde, 0x6b, 0x1d, 0xd5, 0xb2, 0xf7, 0xfe, 0x90,
+	0x82, 0x52, 0xa4, 0x54, 0xd2, 0x80, 0xb5, 0xe7, 0x87, 0xdb, 0x21, 0x68, 0xff, 0xc0, 0x3a, 0x32,
+	0x0f, 0x9f, 0x99, 0x28, 0xfa, 0x13, 0x94, 0x10, 0x5b, 0x0b, 0xb7, 0x4c, 0x4d, 0xd0, 0xc3, 0x1d,
+	0xd3, 0xe4, 0x2a, 0xac, 0xc4, 0xe8, 0x7a, 0xc3, 0x0c, 0x9e, 0x30, 0x46, 0xde, 0x69, 0x1d, 0xec,
+	0xec, 0xbe, 0xdc, 0x7d, 0x52, 0xcb, 0x6e, 0xfd, 0xb3, 0x08, 0x57, 0xa4, 0x52, 0x42, 0xbd, 0xb7,
+	0x59, 0xf0, 0xd6, 0xe9, 0x32, 0xf2, 0x3d, 0x94, 0x63, 0x7d, 0x7c, 0x72, 0x7b, 0x7e, 0xf3, 0x3f,
+	0xfc, 0xc0, 0x69, 0xdc, 0x59, 0x88, 0xd3, 0xdf, 0x04, 0x87, 0x90, 0x57, 0x6d, 0x7d, 0x32, 0xd3,
+	0x78, 0xc6, 0xfe, 0x09, 0x34, 0x8c, 0x79, 0x10, 0x2d, 0xf0, 0xb7, 0x50, 0x8a, 0xda, 0xf8, 0x64,
+	0x66, 0x7c, 0x98, 0xfc, 0x13, 0xd0, 0xf8, 0xbf, 0x05, 0x28, 0x2d, 0xf9, 0x77, 0x00, 0xa3, 0x1e,
+	0x28, 0x99, 0xc9, 0x34, 0xd5, 0xff, 0x6f, 0xdc, 0x5e, 0x04, 0xd3, 0xc2, 0x4d, 0x28, 0xe8, 0xb6,
+	0x26, 0x49, 0xba, 0x65, 0xac, 0x75, 0xda, 0xb8, 0x35, 0x17, 0xa3, 0x65, 0x7e, 0x0f, 0xe5, 0x58,
+	0xdf, 0x89, 0xcc, 0x39, 0x4a, 0xbc, 0x13, 0xda, 0xb8, 0xb3, 0x10, 0xa7, 0xe5, 0x0f, 0xa0, 0x36,
+	0xd9, 0xcb, 0x21, 0xf7, 0x13, 0x0e, 0x36, 0xab, 0x6d, 0xd5, 0x78, 0xf0, 0x61, 0x60, 0xbd, 0xdd,
+	0x19, 0x54, 0x27, 0xba, 0x29, 0xe4, 0xde, 0x2c, 0x01, 0xb3, 0x5b, 0x41, 0x8d, 0xfb, 0x1f, 0x84,
+	0xd5, 0x7b, 0x71, 0x20, 0xd3, 0x9d, 0x13, 0xf2, 0xff, 0xb3, 0x44, 0x24, 0xb6, 0x63, 0x1a, 0xcd,
+	0x0f, 0x85, 0xeb, 0x4d, 0x6d, 0xf9, 0x0f, 0x2c, 0xd6, 0x42, 0xd9, 0x48, 0xd0, 0xcf, 0x54, 0xef,
+	0xa5, 0x71, 0xf7, 0x03, 0x90, 0x63, 0xbb, 0xc4, 0x3a, 0x0d, 0x49, 0xbb, 0x4c, 0xb5, 0x2d, 0x1a,
+	0x77, 0x3f, 0x00, 0xa9, 0x76, 0xd9, 0xbe, 0xfb, 0xdd, 0x9d, 0x53, 0xaf, 0xc9, 0xcf, 0x1d, 0xda,
+	0xf4, 0x82, 0xd3, 0x4d, 0xc7, 0x3d, 0x09, 0xe8, 0xe6, 0x38, 0xf7, 0xe6, 0xa9, 0xb7, 0x19, 0xf8,
+	0xdd, 0x4e, 0x5e, 0x26, 0xa7, 0x2f, 0xff, 0x37, 0x00, 0x30, 0xa5, 0xe4, 0x7f, 0x07, 0x1d, 0x00,
+	0x00,
 }
@@ -19,6 +19,7 @@ import (
 	"go.skia.org/infra/go/swarming/mocks"
 	"go.skia.org/infra/go/testutils"
 	"go.skia.org/infra/task_scheduler/go/db/memory"
+	"go.skia.org/infra/task_scheduler/go/quota"
 	"go.skia.org/infra/task_scheduler/go/skip_tasks"
 	"go.skia.org/infra/task_scheduler/go/specs"
 	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
@@ -137,7 +138,7 @@ func setup(t *testing.T) (context.Context, *taskSchedulerServiceImpl, *types.Tas
 	swarm := &mocks.ApiClient{}
 
 	// Create the service.
-	srv := newTaskSchedulerServiceImpl(ctx, d, repos, skipDB, tcc, swarm)
+	srv := newTaskSchedulerServiceImpl(ctx, d, repos, skipDB, tcc, swarm, nil, nil)
 	return ctx, srv, task, job, skipRule, swarm, func() {
 		btCleanup()
 		cleanupFS()
@@ -399,6 +400,93 @@ func TestDeleteSkipTaskRule(t *testing.T) {
 	require.Equal(t, 0, len(res.Rules))
 }
 
+func TestGetQuotaUsage_NoQuotas_ReturnsEmpty(t *testing.T) {
+	ctx, srv, _, _, _, _, cleanup := setup(t)
+	defer cleanup()
+
+	res, err := srv.GetQuotaUsage(ctx, &GetQuotaUsageRequest{})
+	require.NoError(t, err)
+	require.Empty(t, res.Usage)
+}
+
+func TestGetQuotaUsage_PendingTask_ChargedToQuota(t *testing.T) {
+	ctx, srv, task, job, _, swarm, cleanup := setup(t)
+	defer cleanup()
+
+	srv.quotas = &quota.Config{
+		Quotas: []*quota.Quota{
+			{Name: "commits", JobClass: quota.JobClassCommit, Pool: "Skia", MinShare: 0.5},
+		},
+	}
+	require.NoError(t, srv.taskCfgCache.Set(ctx, task.RepoState, &specs.TasksCfg{
+		Jobs: map[string]*specs.JobSpec{
+			job.Name: {
+				TaskSpecs: []string{task.Name},
+			},
+		},
+		Tasks: map[string]*specs.TaskSpec{
+			task.Name: {
+				Dimensions: []string{"os:linux", "pool:Skia"},
+			},
+		},
+	}, nil))
+	task.Status = types.TASK_STATUS_PENDING
+	task.Jobs = []string{job.Id}
+	require.NoError(t, srv.db.PutTask(ctx, task))
+	swarm.On("ListBotsForPool", testutils.AnyContext, "Skia").Return([]*swarming_api.SwarmingRpcsBotInfo{
+		{BotId: "bot1"},
+		{BotId: "bot2"},
+		{BotId: "bot3", IsDead: true},
+		{BotId: "bot4", Quarantined: true},
+	}, nil)
+
+	res, err := srv.GetQuotaUsage(ctx, &GetQuotaUsageRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []*QuotaUsage{
+		{
+			Quota:    "commits",
+			JobClass: "commit",
+			Pool:     "Skia",
+			Running:  1,
+			Capacity: 2,
+			Min:      1,
+			Max:      2,
+		},
+	}, res.Usage)
+}
+
+func TestGetFlakeStats_FailureThenSuccess_CountsFlake(t *testing.T) {
+	ctx, srv, task, _, _, _, cleanup := setup(t)
+	defer cleanup()
+
+	task.Status = types.TASK_STATUS_FAILURE
+	require.NoError(t, srv.db.PutTask(ctx, task))
+	retry := task.Copy()
+	retry.Id = ""
+	retry.Attempt = 1
+	retry.RetryOf = task.Id
+	retry.Status = types.TASK_STATUS_SUCCESS
+	require.NoError(t, srv.db.PutTask(ctx, retry))
+
+	res, err := srv.GetFlakeStats(ctx, &GetFlakeStatsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []*FlakeStats{
+		{
+			Repo:      fakeRepo,
+			TaskName:  task.Name,
+			Runs:      2,
+			Failures:  1,
+			Flakes:    1,
+			FlakeRate: 0.5,
+		},
+	}, res.Stats)
+
+	// Filter by a different repo.
+	res, err = srv.GetFlakeStats(ctx, &GetFlakeStatsRequest{Repo: "other.git"})
+	require.NoError(t, err)
+	require.Empty(t, res.Stats)
+}
+
 func TestConvertRepoState(t *testing.T) {
 
 	actual := convertRepoState(types.RepoState{
@@ -560,11 +648,12 @@ func TestConvertJob(t *testing.T) {
 			"taskC": {"taskB", "taskA"},
 			"taskD": {"taskC"},
 		},
-		Finished: time.Unix(1600183000, 0),
-		Id:       "fake-job-id",
-		IsForce:  true,
-		Name:     "My Job",
-		Priority: 0.8,
+		Finished:         time.Unix(1600183000, 0),
+		Id:               "fake-job-id",
+		IsForce:          true,
+		Name:             "My Job",
+		PassedWithFlakes: true,
+		Priority:         0.8,
 		RepoState: types.RepoState{
 			Repo:     fakeRepo,
 			Revision: "abc123",
@@ -629,11 +718,12 @@ func TestConvertJob(t *testing.T) {
 				Dependencies: []string{"taskC"},
 			},
 		},
-		FinishedAt: timestamppb.New(time.Unix(1600183000, 0)),
-		Id:         "fake-job-id",
-		IsForce:    true,
-		Name:       "My Job",
-		Priority:   0.8,
+		FinishedAt:       timestamppb.New(time.Unix(1600183000, 0)),
+		Id:               "fake-job-id",
+		IsForce:          true,
+		Name:             "My Job",
+		PassedWithFlakes: true,
+		Priority:         0.8,
 		RepoState: &RepoState{
 			Repo:     fakeRepo,
 			Revision: "abc123",
@@ -335,7 +335,7 @@ func main() {
 		types.TaskExecutor_UseDefault: swarmingTaskExec,
 		types.TaskExecutor_Swarming:   swarmingTaskExec,
 	}
-	s, err := scheduling.NewTaskScheduler(ctx, d, nil, windowPeriod, 0, repos, cas, rbeInstance, taskExecs, http.DefaultClient, 0.99999, swarming.POOLS_PUBLIC, "", "", taskCfgCache, nil, nil, "", scheduling.BusyBotsDebugLoggingOff)
+	s, err := scheduling.NewTaskScheduler(ctx, d, nil, windowPeriod, 0, repos, cas, rbeInstance, taskExecs, http.DefaultClient, 0.99999, swarming.POOLS_PUBLIC, "", "", taskCfgCache, nil, nil, "", scheduling.BusyBotsDebugLoggingOff, nil, nil)
 	assertNoError(err)
 
 	client := httputils.DefaultClientConfig().WithTokenSource(ts).Client()
@@ -0,0 +1,175 @@
+package scheduling
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/task_scheduler/go/quota"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	MEASUREMENT_QUOTA_RUNNING = "task_scheduler_quota_running"
+	MEASUREMENT_QUOTA_MIN     = "task_scheduler_quota_min"
+	MEASUREMENT_QUOTA_MAX     = "task_scheduler_quota_max"
+)
+
+// quotaClass is the JobClass and pool to which a task is charged.
+type quotaClass struct {
+	class quota.JobClass
+	pool  string
+}
+
+// classify returns the JobClass and pool of the task with the given name at
+// the given RepoState, which is shared by the given Jobs.
+func (s *TaskScheduler) classify(ctx context.Context, rs types.RepoState, jobs []*types.Job, taskName string) (quotaClass, error) {
+	cfg, cachedErr, err := s.taskCfgCache.Get(ctx, rs)
+	if err != nil {
+		return quotaClass{}, skerr.Wrapf(err, "failed to retrieve TasksCfg for %+v", rs)
+	} else if cachedErr != nil {
+		// The task can't have come from this config, so we don't know its
+		// pool, but we can still determine its JobClass.
+		cfg = nil
+	}
+	class, pool := quota.Classify(jobs, cfg, taskName)
+	return quotaClass{class: class, pool: pool}, nil
+}
+
+// newQuotaTracker returns a quota.Tracker which accounts for all unfinished
+// tasks. The capacity of each pool is the number of given free bots plus the
+// number of bots which are running tasks.
+func (s *TaskScheduler) newQuotaTracker(ctx context.Context, bots []*types.Machine) (*quota.Tracker, error) {
+	tracker := quota.NewTracker(s.quotas)
+	unfinished, err := s.tCache.UnfinishedTasks()
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to retrieve unfinished tasks")
+	}
+	busy := map[string]int{}
+	for _, t := range unfinished {
+		jobs := make([]*types.Job, 0, len(t.Jobs))
+		for _, id := range t.Jobs {
+			// Jobs may have fallen out of the cache window; classify the
+			// task using the Jobs we have.
+			if j, err := s.jCache.GetJob(id); err == nil {
+				jobs = append(jobs, j)
+			}
+		}
+		qc, err := s.classify(ctx, t.RepoState, jobs, t.Name)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		tracker.AddRunning(t.Repo, qc.class, qc.pool)
+		// Pending tasks count toward their quotas, but they are not
+		// occupying a bot, so they don't count toward the capacity.
+		if t.Status == types.TASK_STATUS_RUNNING {
+			busy[qc.pool]++
+		}
+	}
+	free := map[string]int{}
+	for _, b := range bots {
+		free[quota.PoolFromDimensions(b.Dimensions)]++
+	}
+	for _, pool := range s.pools {
+		tracker.SetCapacity(pool, free[pool]+busy[pool])
+	}
+	return tracker, nil
+}
+
+// getCandidatesToScheduleWithQuotas is like getCandidatesToSchedule, but
+// applies the fair-share quotas of the TaskScheduler. Candidates are first
+// admitted by deficit accounting, then ranked: admitted candidates whose quota
+// is below its guaranteed minimum are considered first, in order of score, up
+// to the size of the deficit; the remaining admitted candidates are considered
+// in order of score.
+func (s *TaskScheduler) getCandidatesToScheduleWithQuotas(ctx context.Context, bots []*types.Machine, queue []*TaskCandidate) []*TaskCandidate {
+	ctx, span := trace.StartSpan(ctx, "getCandidatesToScheduleWithQuotas")
+	defer span.End()
+
+	tracker, err := s.newQuotaTracker(ctx, bots)
+	if err != nil {
+		sklog.Errorf("Failed to compute quota usage; scheduling without quotas: %s", err)
+		return getCandidatesToSchedule(ctx, bots, queue)
+	}
+	classes := make(map[*TaskCandidate]quotaClass, len(queue))
+	for _, c := range queue {
+		qc, err := s.classify(ctx, c.RepoState, c.Jobs, c.Name)
+		if err != nil {
+			sklog.Errorf("Failed to classify candidates; scheduling without quotas: %s", err)
+			return getCandidatesToSchedule(ctx, bots, queue)
+		}
+		classes[c] = qc
+	}
+
+	candidates, quotas, deficit := admitByQuota(tracker, queue, classes)
+	rv := getCandidatesToSchedule(ctx, bots, candidates)
+	for _, c := range candidates {
+		if diag := c.GetDiagnostics().Scheduling; diag != nil {
+			diag.Quota = quotas[c]
+			diag.QuotaDeficit = deficit[c]
+		}
+	}
+	for _, c := range rv {
+		qc := classes[c]
+		tracker.AddRunning(c.Repo, qc.class, qc.pool)
+	}
+	s.updateQuotaMetrics(tracker.Usage())
+	return rv
+}
+
+// admitByQuota performs deficit accounting for the given candidates, which
+// must be sorted by score. Candidates which would exceed the maximum share of
+// their quota are rejected. The admitted candidates are returned ranked so
+// that the candidates needed to bring each quota up to its guaranteed minimum
+// come first. Also returns the name of the quota for each admitted candidate
+// and whether the candidate was ranked ahead because of a deficit.
+func admitByQuota(tracker *quota.Tracker, queue []*TaskCandidate, classes map[*TaskCandidate]quotaClass) ([]*TaskCandidate, map[*TaskCandidate]string, map[*TaskCandidate]bool) {
+	remainingDeficit := map[string]int{}
+	remainingHeadroom := map[string]int{}
+	quotas := make(map[*TaskCandidate]string, len(queue))
+	deficit := map[*TaskCandidate]bool{}
+	guaranteed := make([]*TaskCandidate, 0, len(queue))
+	rest := make([]*TaskCandidate, 0, len(queue))
+	for _, c := range queue {
+		qc := classes[c]
+		n, name := tracker.Deficit(c.Repo, qc.class, qc.pool)
+		key := name + "@" + qc.pool
+		if _, ok := remainingDeficit[key]; !ok {
+			remainingDeficit[key] = n
+			remainingHeadroom[key], _ = tracker.Headroom(c.Repo, qc.class, qc.pool)
+		}
+		if remainingHeadroom[key] == 0 {
+			c.GetDiagnostics().Scheduling = &taskCandidateSchedulingDiagnostics{
+				Quota:     name,
+				OverQuota: true,
+			}
+			continue
+		} else if remainingHeadroom[key] > 0 {
+			remainingHeadroom[key]--
+		}
+		quotas[c] = name
+		if name != "" && remainingDeficit[key] > 0 {
+			remainingDeficit[key]--
+			deficit[c] = true
+			guaranteed = append(guaranteed, c)
+		} else {
+			rest = append(rest, c)
+		}
+	}
+	return append(guaranteed, rest...), quotas, deficit
+}
+
+// updateQuotaMetrics reports the given quota usage.
+func (s *TaskScheduler) updateQuotaMetrics(usage []*quota.Usage) {
+	for _, u := range usage {
+		tags := map[string]string{
+			"quota": u.Quota,
+			"pool":  u.Pool,
+		}
+		metrics2.GetInt64Metric(MEASUREMENT_QUOTA_RUNNING, tags).Update(int64(u.Running))
+		metrics2.GetInt64Metric(MEASUREMENT_QUOTA_MIN, tags).Update(int64(u.Min))
+		metrics2.GetInt64Metric(MEASUREMENT_QUOTA_MAX, tags).Update(int64(u.Max))
+	}
+}
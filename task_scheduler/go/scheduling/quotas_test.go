@@ -0,0 +1,84 @@
+package scheduling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_scheduler/go/quota"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+func makeQuotaCandidate(name, repo string, score float64) *TaskCandidate {
+	c := makeTaskCandidate(name, []string{"pool:Skia"})
+	c.Repo = repo
+	c.Score = score
+	return c
+}
+
+func TestAdmitByQuota_QuotaBelowMinimum_CandidatesRankedAhead(t *testing.T) {
+	tracker := quota.NewTracker(&quota.Config{
+		Quotas: []*quota.Quota{
+			{Name: "small-repo", Repo: "small.git", MinShare: 0.5},
+		},
+	})
+	tracker.SetCapacity("Skia", 4)
+	big1 := makeQuotaCandidate("big1", "big.git", 3.0)
+	big2 := makeQuotaCandidate("big2", "big.git", 2.0)
+	small1 := makeQuotaCandidate("small1", "small.git", 1.0)
+	small2 := makeQuotaCandidate("small2", "small.git", 0.9)
+	small3 := makeQuotaCandidate("small3", "small.git", 0.8)
+	queue := []*TaskCandidate{big1, big2, small1, small2, small3}
+	classes := map[*TaskCandidate]quotaClass{}
+	for _, c := range queue {
+		classes[c] = quotaClass{class: quota.JobClassCommit, pool: "Skia"}
+	}
+
+	ordered, quotas, deficit := admitByQuota(tracker, queue, classes)
+	// The guaranteed minimum is two bots, so only two of the small repo's
+	// candidates are moved ahead.
+	require.Equal(t, []*TaskCandidate{small1, small2, big1, big2, small3}, ordered)
+	require.Equal(t, "small-repo", quotas[small3])
+	require.Equal(t, "", quotas[big1])
+	require.True(t, deficit[small1])
+	require.True(t, deficit[small2])
+	require.False(t, deficit[small3])
+	require.False(t, deficit[big1])
+}
+
+func TestAdmitByQuota_QuotaAtMaximum_CandidatesRejectedBeforeRanking(t *testing.T) {
+	tracker := quota.NewTracker(&quota.Config{
+		Quotas: []*quota.Quota{
+			{Name: "try", JobClass: quota.JobClassTry, MaxShare: 0.5},
+		},
+	})
+	tracker.SetCapacity("Skia", 4)
+	// One try job task is already running.
+	tracker.AddRunning("skia.git", quota.JobClassTry, "Skia")
+	try1 := makeQuotaCandidate("try1", "skia.git", 3.0)
+	try2 := makeQuotaCandidate("try2", "skia.git", 2.0)
+	commit := makeQuotaCandidate("commit", "skia.git", 1.0)
+	classes := map[*TaskCandidate]quotaClass{
+		try1:   {class: quota.JobClassTry, pool: "Skia"},
+		try2:   {class: quota.JobClassTry, pool: "Skia"},
+		commit: {class: quota.JobClassCommit, pool: "Skia"},
+	}
+
+	admitted, quotas, _ := admitByQuota(tracker, []*TaskCandidate{try1, try2, commit}, classes)
+	require.Equal(t, []*TaskCandidate{try1, commit}, admitted)
+	require.Equal(t, "try", quotas[try1])
+	require.True(t, try2.Diagnostics.Scheduling.OverQuota)
+	require.Equal(t, "try", try2.Diagnostics.Scheduling.Quota)
+
+	// The rejected candidate does not prevent lower-score candidates from
+	// using the remaining bots.
+	bots := []*types.Machine{
+		makeSwarmingBot("bot1", []string{"pool:Skia"}),
+		makeSwarmingBot("bot2", []string{"pool:Skia"}),
+		makeSwarmingBot("bot3", []string{"pool:Skia"}),
+	}
+	rv := getCandidatesToSchedule(context.Background(), bots, admitted)
+	require.Equal(t, []*TaskCandidate{try1, commit}, rv)
+	require.True(t, commit.Diagnostics.Scheduling.Selected)
+	require.False(t, try2.Diagnostics.Scheduling.Selected)
+}
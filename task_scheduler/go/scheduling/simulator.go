@@ -0,0 +1,751 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/db"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+/*
+	What-if simulator for the TaskScheduler.
+
+	Simulate replays a recorded window of jobs through the real candidate
+	scoring and getCandidatesToSchedule, using a simulated TaskExecutor backed
+	by a hypothetical pool of bots. Tasks run for their recorded median
+	duration and always succeed. Blamelists are computed from the order in
+	which jobs were created for each commit; bisection and retries are not
+	simulated.
+*/
+
+const (
+	// DefaultSimulatorCycleInterval is the default amount of simulated time
+	// between scheduling cycles.
+	DefaultSimulatorCycleInterval = 30 * time.Second
+
+	// DefaultSimulatedTaskDuration is used for tasks which have no recorded
+	// duration.
+	DefaultSimulatedTaskDuration = 10 * time.Minute
+)
+
+// SimulatedBotGroup describes a set of identical bots in a hypothetical bot
+// pool.
+type SimulatedBotGroup struct {
+	Dimensions []string `json:"dimensions"`
+	Count      int      `json:"count"`
+}
+
+// SimulationHistory is a recorded window of jobs and task durations which is
+// replayed by Simulate.
+type SimulationHistory struct {
+	// Jobs are sorted by Created timestamp.
+	Jobs []*types.Job
+	// Durations maps task names to their median recorded run time.
+	Durations map[string]time.Duration
+}
+
+// LoadSimulationHistory reads the jobs and tasks created in the given time
+// range from the DB. If repo is empty, all repos are included.
+func LoadSimulationHistory(ctx context.Context, d db.DB, repo string, start, end time.Time) (*SimulationHistory, error) {
+	jobs, err := d.GetJobsFromDateRange(ctx, start, end, repo)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to load jobs")
+	}
+	tasks, err := d.GetTasksFromDateRange(ctx, start, end, repo)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to load tasks")
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Created.Before(jobs[j].Created)
+	})
+	byName := map[string][]time.Duration{}
+	for _, t := range tasks {
+		if util.TimeIsZero(t.Started) || util.TimeIsZero(t.Finished) || !t.Finished.After(t.Started) {
+			continue
+		}
+		byName[t.Name] = append(byName[t.Name], t.Finished.Sub(t.Started))
+	}
+	durations := make(map[string]time.Duration, len(byName))
+	for name, d := range byName {
+		durations[name] = percentile(d, 0.5)
+	}
+	return &SimulationHistory{
+		Jobs:      jobs,
+		Durations: durations,
+	}, nil
+}
+
+// SimulatorConfig describes a hypothetical scheduler configuration to run
+// against a SimulationHistory.
+type SimulatorConfig struct {
+	// Start and End bound the simulated time period.
+	Start time.Time
+	End   time.Time
+	// CycleInterval is the simulated time between scheduling cycles.
+	CycleInterval time.Duration
+	// TimeDecayAmt24Hr is passed through to the TaskScheduler scoring.
+	TimeDecayAmt24Hr float64
+	// Bots is the hypothetical bot pool.
+	Bots []*SimulatedBotGroup
+	// TasksCfg provides the TaskSpecs, in particular the dimensions, for the
+	// tasks in the history.
+	TasksCfg *specs.TasksCfg
+	// DefaultTaskDuration is used for tasks with no recorded duration.
+	DefaultTaskDuration time.Duration
+}
+
+// Validate returns an error if the SimulatorConfig is not valid.
+func (c *SimulatorConfig) Validate() error {
+	if !c.End.After(c.Start) {
+		return skerr.Fmt("End must be after Start")
+	}
+	if c.CycleInterval <= 0 {
+		return skerr.Fmt("CycleInterval must be positive")
+	}
+	if c.TasksCfg == nil {
+		return skerr.Fmt("TasksCfg is required")
+	}
+	if len(c.Bots) == 0 {
+		return skerr.Fmt("at least one bot group is required")
+	}
+	for _, b := range c.Bots {
+		if b.Count <= 0 {
+			return skerr.Fmt("bot group %v has non-positive count %d", b.Dimensions, b.Count)
+		}
+		hasPool := false
+		for _, dim := range b.Dimensions {
+			if strings.HasPrefix(dim, "pool:") {
+				hasPool = true
+			}
+		}
+		if !hasPool {
+			return skerr.Fmt("bot group %v has no pool dimension", b.Dimensions)
+		}
+	}
+	return nil
+}
+
+// LatencyStats summarizes a distribution of latencies.
+type LatencyStats struct {
+	Count int           `json:"count"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P90   time.Duration `json:"p90"`
+	P99   time.Duration `json:"p99"`
+	Max   time.Duration `json:"max"`
+}
+
+// BotGroupUtilization is the fraction of the simulated time that the bots in
+// a SimulatedBotGroup spent running tasks.
+type BotGroupUtilization struct {
+	Dimensions  []string `json:"dimensions"`
+	Count       int      `json:"count"`
+	Utilization float64  `json:"utilization"`
+}
+
+// SimulationReport contains the results of a simulation.
+type SimulationReport struct {
+	Cycles         int `json:"cycles"`
+	TasksTriggered int `json:"tasks_triggered"`
+	JobsFinished   int `json:"jobs_finished"`
+	JobsUnfinished int `json:"jobs_unfinished"`
+	// QueueLatency is the time between a task candidate first appearing in
+	// the queue and a task covering its commit being triggered.
+	QueueLatency LatencyStats `json:"queue_latency"`
+	// JobLatency is the time between a job being created and all of its
+	// tasks finishing.
+	JobLatency LatencyStats `json:"job_latency"`
+	// Testedness is the mean testedness (see testedness()) over every
+	// commit and task spec required by a non-try job, at the end of the
+	// simulation. It ranges from -1.0 (nothing tested) to 1.0 (every task
+	// ran at every commit).
+	Testedness float64 `json:"testedness"`
+	// UntestedCommits is the number of commit and task spec pairs which were
+	// not covered by any finished task.
+	UntestedCommits int `json:"untested_commits"`
+	// QueueLength is the number of candidates left in the queue after the
+	// last cycle.
+	QueueLength    int                    `json:"queue_length"`
+	BotUtilization float64                `json:"bot_utilization"`
+	BotGroups      []*BotGroupUtilization `json:"bot_groups"`
+	// UnknownTaskSpecs lists tasks required by jobs which were not found in
+	// the TasksCfg and therefore could not be scheduled.
+	UnknownTaskSpecs []string `json:"unknown_task_specs"`
+}
+
+// simulatedBot is a bot in the simulated pool.
+type simulatedBot struct {
+	group     int
+	machine   *types.Machine
+	busyUntil time.Time
+	busyTime  time.Duration
+}
+
+// simulatedTaskExecutor is a types.TaskExecutor which runs tasks on a
+// simulated pool of bots, using the clock from the context.
+type simulatedTaskExecutor struct {
+	bots            []*simulatedBot
+	defaultDuration time.Duration
+	durations       map[string]time.Duration
+	end             time.Time
+	results         map[string]*types.TaskResult
+}
+
+// newSimulatedTaskExecutor returns a simulatedTaskExecutor with the given
+// bots. Tasks running past end do not count towards bot busy time.
+func newSimulatedTaskExecutor(groups []*SimulatedBotGroup, durations map[string]time.Duration, defaultDuration time.Duration, end time.Time) *simulatedTaskExecutor {
+	var bots []*simulatedBot
+	for groupIdx, g := range groups {
+		for i := 0; i < g.Count; i++ {
+			bots = append(bots, &simulatedBot{
+				group: groupIdx,
+				machine: &types.Machine{
+					ID:         fmt.Sprintf("sim-bot-%03d-%05d", groupIdx, i),
+					Dimensions: util.CopyStringSlice(g.Dimensions),
+				},
+			})
+		}
+	}
+	return &simulatedTaskExecutor{
+		bots:            bots,
+		defaultDuration: defaultDuration,
+		durations:       durations,
+		end:             end,
+		results:         map[string]*types.TaskResult{},
+	}
+}
+
+// GetFreeMachines implements types.TaskExecutor.
+func (e *simulatedTaskExecutor) GetFreeMachines(ctx context.Context, pool string) ([]*types.Machine, error) {
+	currentTime := now.Now(ctx)
+	var rv []*types.Machine
+	for _, b := range e.bots {
+		if b.busyUntil.After(currentTime) || !util.In("pool:"+pool, b.machine.Dimensions) {
+			continue
+		}
+		rv = append(rv, b.machine)
+	}
+	return rv, nil
+}
+
+// GetPendingTasks implements types.TaskExecutor. Simulated tasks start as soon
+// as they are triggered, so there are never any pending tasks.
+func (e *simulatedTaskExecutor) GetPendingTasks(_ context.Context, _ string) ([]*types.TaskResult, error) {
+	return nil, nil
+}
+
+// GetTaskResult implements types.TaskExecutor.
+func (e *simulatedTaskExecutor) GetTaskResult(ctx context.Context, taskID string) (*types.TaskResult, error) {
+	res, ok := e.results[taskID]
+	if !ok {
+		return nil, skerr.Fmt("unknown task %q", taskID)
+	}
+	rv := *res
+	if rv.Finished.After(now.Now(ctx)) {
+		rv.Status = types.TASK_STATUS_RUNNING
+		rv.Finished = time.Time{}
+	}
+	return &rv, nil
+}
+
+// GetTaskCompletionStatuses implements types.TaskExecutor.
+func (e *simulatedTaskExecutor) GetTaskCompletionStatuses(ctx context.Context, taskIDs []string) ([]bool, error) {
+	rv := make([]bool, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		res, err := e.GetTaskResult(ctx, id)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		rv = append(rv, res.Status != types.TASK_STATUS_RUNNING)
+	}
+	return rv, nil
+}
+
+// TriggerTask implements types.TaskExecutor. The task runs on the free bot
+// with the lowest ID which matches the requested dimensions, which is the same
+// bot chosen by getCandidatesToSchedule.
+func (e *simulatedTaskExecutor) TriggerTask(ctx context.Context, req *types.TaskRequest) (*types.TaskResult, error) {
+	currentTime := now.Now(ctx)
+	for _, b := range e.bots {
+		if b.busyUntil.After(currentTime) || !hasAllDimensions(b.machine, req.Dimensions) {
+			continue
+		}
+		duration, ok := e.durations[req.Name]
+		if !ok {
+			duration = e.defaultDuration
+		}
+		finished := currentTime.Add(duration)
+		b.busyUntil = finished
+		if finished.After(e.end) {
+			b.busyTime += e.end.Sub(currentTime)
+		} else {
+			b.busyTime += duration
+		}
+		res := &types.TaskResult{
+			Created:   currentTime,
+			Finished:  finished,
+			ID:        fmt.Sprintf("sim-task-%d", len(e.results)),
+			MachineID: b.machine.ID,
+			Started:   currentTime,
+			Status:    types.TASK_STATUS_SUCCESS,
+		}
+		e.results[res.ID] = res
+		return res, nil
+	}
+	return nil, skerr.Fmt("no free bot matches dimensions %v", req.Dimensions)
+}
+
+var _ types.TaskExecutor = &simulatedTaskExecutor{}
+
+// hasAllDimensions returns true iff the machine has all of the given
+// dimensions.
+func hasAllDimensions(m *types.Machine, dims []string) bool {
+	for _, dim := range dims {
+		if !util.In(dim, m.Dimensions) {
+			return false
+		}
+	}
+	return true
+}
+
+// simulatedTask is a task which was triggered during the simulation.
+type simulatedTask struct {
+	key      types.TaskKey
+	commits  []string
+	finished time.Time
+}
+
+// simulation holds the state of a single run of Simulate.
+type simulation struct {
+	cfg     *SimulatorConfig
+	exec    *simulatedTaskExecutor
+	history *SimulationHistory
+	// scheduler is used only for scoring candidates.
+	scheduler *TaskScheduler
+
+	// commits and commitIdx record the order in which commits landed in
+	// each repo, derived from the creation time of non-try jobs.
+	commits    map[string][]string
+	commitIdx  map[string]map[string]int
+	commitTime map[string]map[string]time.Time
+
+	// covered maps repo, task name and commit to the task whose blamelist
+	// includes that commit. Try jobs and forced jobs are tracked in keyed
+	// instead.
+	covered map[string]map[string]map[string]*simulatedTask
+	keyed   map[types.TaskKey]*simulatedTask
+
+	queuedSince    map[types.TaskKey]time.Time
+	queueLatencies []time.Duration
+	jobLatencies   []time.Duration
+	unknown        util.StringSet
+}
+
+// Simulate replays the given history using the given configuration and
+// returns a report of the results.
+func Simulate(ctx context.Context, history *SimulationHistory, cfg *SimulatorConfig) (*SimulationReport, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	defaultDuration := cfg.DefaultTaskDuration
+	if defaultDuration == 0 {
+		defaultDuration = DefaultSimulatedTaskDuration
+	}
+	sim := &simulation{
+		cfg:     cfg,
+		exec:    newSimulatedTaskExecutor(cfg.Bots, history.Durations, defaultDuration, cfg.End),
+		history: history,
+		scheduler: &TaskScheduler{
+			timeDecayAmt24Hr: cfg.TimeDecayAmt24Hr,
+		},
+		commits:     map[string][]string{},
+		commitIdx:   map[string]map[string]int{},
+		commitTime:  map[string]map[string]time.Time{},
+		covered:     map[string]map[string]map[string]*simulatedTask{},
+		keyed:       map[types.TaskKey]*simulatedTask{},
+		queuedSince: map[types.TaskKey]time.Time{},
+		unknown:     util.StringSet{},
+	}
+	return sim.run(ctx)
+}
+
+// run performs the simulation.
+func (s *simulation) run(ctx context.Context) (*SimulationReport, error) {
+	pools := util.StringSet{}
+	for _, b := range s.cfg.Bots {
+		for _, dim := range b.Dimensions {
+			if strings.HasPrefix(dim, "pool:") {
+				pools[strings.TrimPrefix(dim, "pool:")] = true
+			}
+		}
+	}
+	poolList := pools.Keys()
+	sort.Strings(poolList)
+	busy := newBusyBots(BusyBotsDebugLoggingOff)
+
+	simCtx := now.TimeTravelingContext(s.cfg.Start).WithContext(ctx)
+	rv := &SimulationReport{}
+	var active []*types.Job
+	nextJob := 0
+	queueLen := 0
+	for currentTime := s.cfg.Start; currentTime.Before(s.cfg.End); currentTime = currentTime.Add(s.cfg.CycleInterval) {
+		simCtx.SetTime(currentTime)
+		rv.Cycles++
+
+		// Add newly-created jobs.
+		for ; nextJob < len(s.history.Jobs) && !s.history.Jobs[nextJob].Created.After(currentTime); nextJob++ {
+			j := s.history.Jobs[nextJob]
+			if j.Created.Before(s.cfg.Start) {
+				continue
+			}
+			s.addCommit(j)
+			active = append(active, j)
+		}
+
+		// Find candidates for the unfinished jobs.
+		var candidates map[types.TaskKey]*TaskCandidate
+		candidates, active = s.findCandidates(currentTime, active)
+
+		// Score the candidates and schedule them onto the free bots.
+		queue := s.buildQueue(simCtx, currentTime, candidates)
+		queueLen = len(queue)
+		for _, c := range queue {
+			if _, ok := s.queuedSince[c.TaskKey]; !ok {
+				s.queuedSince[c.TaskKey] = currentTime
+			}
+		}
+		freeMachines, err := getFreeMachines(simCtx, s.exec, busy, poolList)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		for _, c := range getCandidatesToSchedule(simCtx, freeMachines, queue) {
+			res, err := s.exec.TriggerTask(simCtx, &types.TaskRequest{
+				Dimensions:          c.TaskSpec.Dimensions,
+				Name:                c.Name,
+				TaskSchedulerTaskID: c.MakeId(),
+			})
+			if err != nil {
+				return nil, skerr.Wrapf(err, "failed to trigger %s", c.MakeId())
+			}
+			s.addTask(&simulatedTask{
+				key:      c.TaskKey,
+				commits:  c.Commits,
+				finished: res.Finished,
+			})
+			s.queueLatencies = append(s.queueLatencies, currentTime.Sub(s.dequeue(c)))
+			rv.TasksTriggered++
+		}
+	}
+
+	rv.JobsFinished = len(s.jobLatencies)
+	rv.JobsUnfinished = len(active)
+	rv.QueueLatency = latencyStats(s.queueLatencies)
+	rv.JobLatency = latencyStats(s.jobLatencies)
+	rv.QueueLength = queueLen
+	rv.Testedness, rv.UntestedCommits = s.testedness()
+	rv.BotUtilization, rv.BotGroups = s.utilization()
+	rv.UnknownTaskSpecs = s.unknown.Keys()
+	sort.Strings(rv.UnknownTaskSpecs)
+	return rv, nil
+}
+
+// dequeue removes the given candidate from queuedSince, along with any other
+// candidates whose commits are in its blamelist, and returns the earliest time
+// at which any of them was queued.
+func (s *simulation) dequeue(c *TaskCandidate) time.Time {
+	rv := s.queuedSince[c.TaskKey]
+	delete(s.queuedSince, c.TaskKey)
+	if c.IsTryJob() || c.IsForceRun() {
+		return rv
+	}
+	for _, commit := range c.Commits {
+		key := c.TaskKey.Copy()
+		key.Revision = commit
+		if ts, ok := s.queuedSince[key]; ok {
+			if ts.Before(rv) {
+				rv = ts
+			}
+			delete(s.queuedSince, key)
+		}
+	}
+	return rv
+}
+
+// addCommit records the commit for the given job, if it is not a try job or a
+// forced job and the commit has not been seen before.
+func (s *simulation) addCommit(j *types.Job) {
+	if j.IsTryJob() || j.IsForce {
+		return
+	}
+	if _, ok := s.commitIdx[j.Repo]; !ok {
+		s.commitIdx[j.Repo] = map[string]int{}
+		s.commitTime[j.Repo] = map[string]time.Time{}
+	}
+	if _, ok := s.commitIdx[j.Repo][j.Revision]; ok {
+		return
+	}
+	s.commitIdx[j.Repo][j.Revision] = len(s.commits[j.Repo])
+	s.commitTime[j.Repo][j.Revision] = j.Created
+	s.commits[j.Repo] = append(s.commits[j.Repo], j.Revision)
+}
+
+// taskKey returns the TaskKey for the given task within the given job.
+func taskKey(j *types.Job, name string) types.TaskKey {
+	key := types.TaskKey{
+		RepoState: j.RepoState,
+		Name:      name,
+	}
+	if j.IsForce {
+		key.ForcedJobId = j.Id
+	}
+	return key
+}
+
+// getTask returns the simulated task which satisfies the given TaskKey, if
+// any.
+func (s *simulation) getTask(key types.TaskKey) *simulatedTask {
+	if key.IsTryJob() || key.IsForceRun() {
+		return s.keyed[key]
+	}
+	return s.covered[key.Repo][key.Name][key.Revision]
+}
+
+// addTask records a triggered task.
+func (s *simulation) addTask(t *simulatedTask) {
+	if t.key.IsTryJob() || t.key.IsForceRun() {
+		s.keyed[t.key] = t
+		return
+	}
+	if _, ok := s.covered[t.key.Repo]; !ok {
+		s.covered[t.key.Repo] = map[string]map[string]*simulatedTask{}
+	}
+	if _, ok := s.covered[t.key.Repo][t.key.Name]; !ok {
+		s.covered[t.key.Repo][t.key.Name] = map[string]*simulatedTask{}
+	}
+	for _, c := range t.commits {
+		s.covered[t.key.Repo][t.key.Name][c] = t
+	}
+}
+
+// findCandidates returns the task candidates for the given jobs, along with the
+// jobs which are still unfinished.
+func (s *simulation) findCandidates(currentTime time.Time, jobs []*types.Job) (map[types.TaskKey]*TaskCandidate, []*types.Job) {
+	finished := func(key types.TaskKey) bool {
+		t := s.getTask(key)
+		return t != nil && !t.finished.After(currentTime)
+	}
+	candidates := map[types.TaskKey]*TaskCandidate{}
+	unfinished := make([]*types.Job, 0, len(jobs))
+	for _, j := range jobs {
+		done := true
+		var jobFinished time.Time
+		for name, deps := range j.Dependencies {
+			key := taskKey(j, name)
+			if t := s.getTask(key); t != nil {
+				if t.finished.After(currentTime) {
+					done = false
+				} else if t.finished.After(jobFinished) {
+					jobFinished = t.finished
+				}
+				continue
+			}
+			done = false
+			depsMet := true
+			for _, dep := range deps {
+				if !finished(taskKey(j, dep)) {
+					depsMet = false
+					break
+				}
+			}
+			if !depsMet {
+				continue
+			}
+			spec, ok := s.cfg.TasksCfg.Tasks[name]
+			if !ok {
+				s.unknown[name] = true
+				continue
+			}
+			c, ok := candidates[key]
+			if !ok {
+				c = &TaskCandidate{
+					TaskKey:  key,
+					TaskSpec: spec,
+				}
+				candidates[key] = c
+			}
+			c.AddJob(j)
+		}
+		if done {
+			s.jobLatencies = append(s.jobLatencies, jobFinished.Sub(j.Created))
+		} else {
+			unfinished = append(unfinished, j)
+		}
+	}
+	return candidates, unfinished
+}
+
+// blamelist returns the commits which would be included in a task for the
+// given candidate, excluding any commits which have already been claimed by
+// other candidates.
+func (s *simulation) blamelist(c *TaskCandidate, claimed util.StringSet) []string {
+	commits := s.commits[c.Repo]
+	covered := s.covered[c.Repo][c.Name]
+	idx := s.commitIdx[c.Repo][c.Revision]
+	rv := []string{c.Revision}
+	for i := idx - 1; i >= 0 && len(rv) < MAX_BLAMELIST_COMMITS; i-- {
+		if _, ok := covered[commits[i]]; ok || claimed[commits[i]] {
+			break
+		}
+		rv = append(rv, commits[i])
+	}
+	return rv
+}
+
+// buildQueue scores the candidates and returns them in decreasing order by
+// score. As in processTaskCandidatesSingleTaskSpec, the highest-scoring
+// candidate for each task spec claims its blamelist before the remaining
+// candidates are rescored; candidates whose commit has been claimed are
+// dropped since their jobs will be satisfied by the claiming task.
+func (s *simulation) buildQueue(ctx context.Context, currentTime time.Time, candidates map[types.TaskKey]*TaskCandidate) []*TaskCandidate {
+	queue := make([]*TaskCandidate, 0, len(candidates))
+	bySpec := map[string][]*TaskCandidate{}
+	for _, c := range candidates {
+		if c.IsTryJob() {
+			s.scheduler.scoreCandidate(ctx, c, currentTime, time.Time{}, nil)
+			queue = append(queue, c)
+		} else if c.IsForceRun() {
+			c.Commits = []string{c.Revision}
+			s.scheduler.scoreCandidate(ctx, c, currentTime, s.commitTime[c.Repo][c.Revision], nil)
+			queue = append(queue, c)
+		} else {
+			specKey := c.Repo + "#" + c.Name
+			bySpec[specKey] = append(bySpec[specKey], c)
+		}
+	}
+	for _, remaining := range bySpec {
+		claimed := util.StringSet{}
+		for len(remaining) > 0 {
+			var best *TaskCandidate
+			for _, c := range remaining {
+				c.Commits = s.blamelist(c, claimed)
+				s.scheduler.scoreCandidate(ctx, c, currentTime, s.commitTime[c.Repo][c.Revision], nil)
+				if best == nil || c.Score > best.Score || (c.Score == best.Score && c.Revision < best.Revision) {
+					best = c
+				}
+			}
+			queue = append(queue, best)
+			for _, commit := range best.Commits {
+				claimed[commit] = true
+			}
+			next := make([]*TaskCandidate, 0, len(remaining))
+			for _, c := range remaining {
+				if !claimed[c.Revision] {
+					next = append(next, c)
+				}
+			}
+			remaining = next
+		}
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		if queue[i].Score == queue[j].Score {
+			return queue[i].MakeId() < queue[j].MakeId()
+		}
+		return queue[i].Score > queue[j].Score
+	})
+	return queue
+}
+
+// testedness returns the mean testedness over every commit and task spec
+// required by a non-try job, along with the number of those which were not
+// covered by a finished task.
+func (s *simulation) testedness() (float64, int) {
+	type pair struct {
+		repo, name, commit string
+	}
+	required := map[pair]bool{}
+	for _, j := range s.history.Jobs {
+		if j.Created.Before(s.cfg.Start) || !j.Created.Before(s.cfg.End) || j.IsTryJob() || j.IsForce {
+			continue
+		}
+		for name := range j.Dependencies {
+			required[pair{j.Repo, name, j.Revision}] = true
+		}
+	}
+	if len(required) == 0 {
+		return 0.0, 0
+	}
+	total := 0.0
+	untested := 0
+	for p := range required {
+		t := s.covered[p.repo][p.name][p.commit]
+		if t == nil || t.finished.After(s.cfg.End) {
+			total -= 1.0
+			untested++
+		} else if t.key.Revision == p.commit {
+			total += 1.0
+		} else {
+			total += 1.0 / float64(len(t.commits))
+		}
+	}
+	return total / float64(len(required)), untested
+}
+
+// utilization returns the overall bot utilization and the utilization of
+// each bot group.
+func (s *simulation) utilization() (float64, []*BotGroupUtilization) {
+	period := s.cfg.End.Sub(s.cfg.Start)
+	busyByGroup := make([]time.Duration, len(s.cfg.Bots))
+	var busyTotal time.Duration
+	for _, b := range s.exec.bots {
+		busyByGroup[b.group] += b.busyTime
+		busyTotal += b.busyTime
+	}
+	groups := make([]*BotGroupUtilization, 0, len(s.cfg.Bots))
+	for idx, g := range s.cfg.Bots {
+		groups = append(groups, &BotGroupUtilization{
+			Dimensions:  g.Dimensions,
+			Count:       g.Count,
+			Utilization: float64(busyByGroup[idx]) / (float64(period) * float64(g.Count)),
+		})
+	}
+	return float64(busyTotal) / (float64(period) * float64(len(s.exec.bots))), groups
+}
+
+// percentile returns the given percentile of the durations, which are sorted
+// in place.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	return durations[int(float64(len(durations)-1)*p)]
+}
+
+// latencyStats summarizes the given latencies.
+func latencyStats(latencies []time.Duration) LatencyStats {
+	if len(latencies) == 0 {
+		return LatencyStats{}
+	}
+	var sum time.Duration
+	for _, l := range latencies {
+		sum += l
+	}
+	return LatencyStats{
+		Count: len(latencies),
+		Mean:  sum / time.Duration(len(latencies)),
+		P50:   percentile(latencies, 0.5),
+		P90:   percentile(latencies, 0.9),
+		P99:   percentile(latencies, 0.99),
+		Max:   percentile(latencies, 1.0),
+	}
+}
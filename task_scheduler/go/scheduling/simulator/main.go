@@ -0,0 +1,128 @@
+package main
+
+/*
+	What-if simulator for the Task Scheduler.
+
+	Replays the jobs recorded in the Task Scheduler DB over the given time
+	range against a hypothetical bot pool and reports queue latency,
+	testedness and bot utilization. The bot pool is read from a JSON file
+	containing a list of objects with "dimensions" and "count" keys, eg:
+
+	[
+	  {"dimensions": ["pool:Skia", "os:Debian10"], "count": 50},
+	  {"dimensions": ["pool:Skia", "os:Android", "device_type:sargo"], "count": 4}
+	]
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"time"
+
+	"go.skia.org/infra/go/common"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/db/firestore"
+	"go.skia.org/infra/task_scheduler/go/scheduling"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"golang.org/x/oauth2/google"
+)
+
+var (
+	fsInstance          = flag.String("firestore_instance", "", "Firestore instance from which to read recorded jobs and tasks, eg. \"production\".")
+	repo                = flag.String("repo", "", "Only replay jobs for this repo. If not set, all repos are included.")
+	start               = flag.String("start", "", "Start of the time range to replay, in RFC3339 format.")
+	end                 = flag.String("end", "", "End of the time range to replay, in RFC3339 format. Defaults to the current time.")
+	botsFile            = flag.String("bots", "", "JSON file describing the hypothetical bot pool.")
+	tasksCfgFile        = flag.String("tasks_cfg", "", "Path to the tasks.json file providing the task dimensions.")
+	scoreDecay24Hr      = flag.Float64("scoreDecay24Hr", 0.9, "Task candidate scores are penalized using linear time decay. This is the desired value after 24 hours.")
+	cycleInterval       = flag.Duration("cycle_interval", scheduling.DefaultSimulatorCycleInterval, "Simulated time between scheduling cycles.")
+	defaultTaskDuration = flag.Duration("default_task_duration", scheduling.DefaultSimulatedTaskDuration, "Duration of tasks which have no recorded duration.")
+	out                 = flag.String("out", "", "If set, write the JSON report to this file instead of stdout.")
+)
+
+func main() {
+	common.Init()
+
+	if *fsInstance == "" {
+		sklog.Fatal("--firestore_instance is required.")
+	}
+	if *start == "" || *botsFile == "" || *tasksCfgFile == "" {
+		sklog.Fatal("--start, --bots, and --tasks_cfg are required.")
+	}
+	startTime, err := time.Parse(time.RFC3339, *start)
+	if err != nil {
+		sklog.Fatalf("Invalid --start: %s", err)
+	}
+	endTime := time.Now()
+	if *end != "" {
+		endTime, err = time.Parse(time.RFC3339, *end)
+		if err != nil {
+			sklog.Fatalf("Invalid --end: %s", err)
+		}
+	}
+
+	var bots []*scheduling.SimulatedBotGroup
+	if err := util.WithReadFile(*botsFile, func(f io.Reader) error {
+		return json.NewDecoder(f).Decode(&bots)
+	}); err != nil {
+		sklog.Fatalf("Failed to read --bots: %s", err)
+	}
+	contents, err := os.ReadFile(*tasksCfgFile)
+	if err != nil {
+		sklog.Fatalf("Failed to read --tasks_cfg: %s", err)
+	}
+	tasksCfg, err := specs.ParseTasksCfg(string(contents))
+	if err != nil {
+		sklog.Fatalf("Failed to parse --tasks_cfg: %s", err)
+	}
+
+	ctx := context.Background()
+	ts, err := google.DefaultTokenSource(ctx)
+	if err != nil {
+		sklog.Fatal(err)
+	}
+	d, err := firestore.NewDBWithParams(ctx, firestore.FIRESTORE_PROJECT, *fsInstance, ts)
+	if err != nil {
+		sklog.Fatal(err)
+	}
+	defer util.Close(d)
+
+	sklog.Infof("Loading jobs and tasks from %s to %s...", startTime, endTime)
+	history, err := scheduling.LoadSimulationHistory(ctx, d, *repo, startTime, endTime)
+	if err != nil {
+		sklog.Fatal(err)
+	}
+	sklog.Infof("Replaying %d jobs...", len(history.Jobs))
+	report, err := scheduling.Simulate(ctx, history, &scheduling.SimulatorConfig{
+		Start:               startTime,
+		End:                 endTime,
+		CycleInterval:       *cycleInterval,
+		TimeDecayAmt24Hr:    *scoreDecay24Hr,
+		Bots:                bots,
+		TasksCfg:            tasksCfg,
+		DefaultTaskDuration: *defaultTaskDuration,
+	})
+	if err != nil {
+		sklog.Fatal(err)
+	}
+	sklog.Infof("Queue latency p50 %s, p90 %s; testedness %.3f; bot utilization %.3f", report.QueueLatency.P50, report.QueueLatency.P90, report.Testedness, report.BotUtilization)
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+		defer util.Close(f)
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		sklog.Fatal(err)
+	}
+}
@@ -0,0 +1,225 @@
+package scheduling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_scheduler/go/db/memory"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	simRepo      = "skia.git"
+	simBuildTask = "Build"
+	simTestTask  = "Test"
+)
+
+var (
+	simStart = time.Date(2021, time.October, 15, 0, 0, 0, 0, time.UTC)
+
+	simTasksCfg = &specs.TasksCfg{
+		Tasks: map[string]*specs.TaskSpec{
+			simBuildTask: {
+				Dimensions: []string{"pool:Skia", "os:Linux"},
+			},
+			simTestTask: {
+				Dependencies: []string{simBuildTask},
+				Dimensions:   []string{"pool:Skia", "os:Android"},
+			},
+		},
+	}
+)
+
+// simJob returns a job at the given revision which requires the Build and Test
+// tasks.
+func simJob(revision string, created time.Time) *types.Job {
+	return &types.Job{
+		Id:      "job-" + revision,
+		Created: created,
+		Name:    "Test-Android",
+		RepoState: types.RepoState{
+			Repo:     simRepo,
+			Revision: revision,
+		},
+		Dependencies: map[string][]string{
+			simBuildTask: {},
+			simTestTask:  {simBuildTask},
+		},
+		Priority: 0.5,
+	}
+}
+
+func simConfig(linuxBots, androidBots int) *SimulatorConfig {
+	return &SimulatorConfig{
+		Start:            simStart,
+		End:              simStart.Add(2 * time.Hour),
+		CycleInterval:    time.Minute,
+		TimeDecayAmt24Hr: 0.9,
+		Bots: []*SimulatedBotGroup{
+			{Dimensions: []string{"pool:Skia", "os:Linux"}, Count: linuxBots},
+			{Dimensions: []string{"pool:Skia", "os:Android"}, Count: androidBots},
+		},
+		TasksCfg: simTasksCfg,
+	}
+}
+
+func TestSimulate_EnoughBots_EveryCommitTested(t *testing.T) {
+	history := &SimulationHistory{
+		Jobs: []*types.Job{
+			simJob("a", simStart),
+			simJob("b", simStart.Add(10*time.Minute)),
+			simJob("c", simStart.Add(20*time.Minute)),
+		},
+		Durations: map[string]time.Duration{
+			simBuildTask: 5 * time.Minute,
+			simTestTask:  5 * time.Minute,
+		},
+	}
+	report, err := Simulate(context.Background(), history, simConfig(1, 1))
+	require.NoError(t, err)
+	require.Equal(t, 120, report.Cycles)
+	require.Equal(t, 6, report.TasksTriggered)
+	require.Equal(t, 3, report.JobsFinished)
+	require.Equal(t, 0, report.JobsUnfinished)
+	require.Equal(t, 1.0, report.Testedness)
+	require.Equal(t, 0, report.UntestedCommits)
+	require.Equal(t, 0, report.QueueLength)
+	require.Equal(t, time.Duration(0), report.QueueLatency.Max)
+	require.Equal(t, 3, report.JobLatency.Count)
+	require.Equal(t, 10*time.Minute, report.JobLatency.Max)
+	require.Len(t, report.BotGroups, 2)
+	require.InDelta(t, 0.125, report.BotGroups[0].Utilization, 0.0001)
+	require.InDelta(t, 0.125, report.BotGroups[1].Utilization, 0.0001)
+	require.InDelta(t, 0.125, report.BotUtilization, 0.0001)
+	require.Empty(t, report.UnknownTaskSpecs)
+}
+
+func TestSimulate_SlowTasks_BatchesCommitsIntoBlamelists(t *testing.T) {
+	history := &SimulationHistory{
+		Jobs: []*types.Job{
+			simJob("a", simStart),
+			simJob("b", simStart.Add(10*time.Minute)),
+			simJob("c", simStart.Add(20*time.Minute)),
+		},
+		Durations: map[string]time.Duration{
+			simBuildTask: 25 * time.Minute,
+			simTestTask:  5 * time.Minute,
+		},
+	}
+	report, err := Simulate(context.Background(), history, simConfig(1, 1))
+	require.NoError(t, err)
+	// The Build task at "a" is still running when "b" and "c" land, so the
+	// next Build task covers both of them.
+	require.Equal(t, 4, report.TasksTriggered)
+	require.Equal(t, 3, report.JobsFinished)
+	// Both tasks ran at "a" and "c"; "b" is covered by blamelists of two.
+	require.InDelta(t, (1.0+1.0+0.5+0.5+1.0+1.0)/6.0, report.Testedness, 0.0001)
+	require.Equal(t, 15*time.Minute, report.QueueLatency.Max)
+}
+
+func TestSimulate_TryJob_ScheduledBeforeRegularJob(t *testing.T) {
+	tryJob := simJob("a", simStart)
+	tryJob.Id = "tryjob"
+	tryJob.Patch = types.Patch{
+		Server:   "https://skia-review.googlesource.com",
+		Issue:    "123",
+		Patchset: "1",
+	}
+	tryJob.Dependencies = map[string][]string{simBuildTask: {}}
+	regularJob := simJob("a", simStart)
+	regularJob.Dependencies = map[string][]string{simBuildTask: {}}
+	history := &SimulationHistory{
+		Jobs: []*types.Job{regularJob, tryJob},
+		Durations: map[string]time.Duration{
+			simBuildTask: 30 * time.Minute,
+		},
+	}
+	cfg := simConfig(1, 1)
+	cfg.End = simStart.Add(90 * time.Minute)
+	report, err := Simulate(context.Background(), history, cfg)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.TasksTriggered)
+	require.Equal(t, 2, report.JobsFinished)
+	// The regular job waited for the try job to finish.
+	require.Equal(t, 30*time.Minute, report.QueueLatency.Max)
+	require.Equal(t, 60*time.Minute, report.JobLatency.Max)
+}
+
+func TestSimulate_NoMatchingBots_JobsUnfinished(t *testing.T) {
+	history := &SimulationHistory{
+		Jobs: []*types.Job{simJob("a", simStart)},
+	}
+	cfg := simConfig(1, 1)
+	cfg.Bots[1].Dimensions = []string{"pool:Skia", "os:iOS"}
+	report, err := Simulate(context.Background(), history, cfg)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.TasksTriggered)
+	require.Equal(t, 0, report.JobsFinished)
+	require.Equal(t, 1, report.JobsUnfinished)
+	require.Equal(t, 1, report.QueueLength)
+	require.Equal(t, 1, report.UntestedCommits)
+	require.Equal(t, 0.0, report.Testedness)
+}
+
+func TestSimulate_UnknownTaskSpec_Reported(t *testing.T) {
+	j := simJob("a", simStart)
+	j.Dependencies["Perf"] = []string{simBuildTask}
+	history := &SimulationHistory{
+		Jobs: []*types.Job{j},
+	}
+	report, err := Simulate(context.Background(), history, simConfig(1, 1))
+	require.NoError(t, err)
+	require.Equal(t, []string{"Perf"}, report.UnknownTaskSpecs)
+	require.Equal(t, 1, report.JobsUnfinished)
+}
+
+func TestSimulatorConfigValidate_NoPoolDimension_ReturnsError(t *testing.T) {
+	cfg := simConfig(1, 1)
+	cfg.Bots[0].Dimensions = []string{"os:Linux"}
+	err := cfg.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "has no pool dimension")
+}
+
+func TestLoadSimulationHistory_ComputesMedianDurations(t *testing.T) {
+	ctx := context.Background()
+	d := memory.NewInMemoryDB()
+	j1 := simJob("b", simStart.Add(time.Minute))
+	j2 := simJob("a", simStart)
+	require.NoError(t, d.PutJobs(ctx, []*types.Job{j1, j2}))
+	var tasks []*types.Task
+	for _, minutes := range []int{3, 10, 5} {
+		started := simStart.Add(time.Minute)
+		tasks = append(tasks, &types.Task{
+			Created:  simStart,
+			Started:  started,
+			Finished: started.Add(time.Duration(minutes) * time.Minute),
+			TaskKey: types.TaskKey{
+				RepoState: j1.RepoState,
+				Name:      simBuildTask,
+			},
+		})
+	}
+	// Tasks which have not finished are ignored.
+	tasks = append(tasks, &types.Task{
+		Created: simStart,
+		Started: simStart,
+		TaskKey: types.TaskKey{
+			RepoState: j1.RepoState,
+			Name:      simTestTask,
+		},
+	})
+	require.NoError(t, d.PutTasks(ctx, tasks))
+
+	history, err := LoadSimulationHistory(ctx, d, simRepo, simStart, simStart.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, history.Jobs, 2)
+	require.Equal(t, "a", history.Jobs[0].Revision)
+	require.Equal(t, "b", history.Jobs[1].Revision)
+	require.Equal(t, map[string]time.Duration{
+		simBuildTask: 5 * time.Minute,
+	}, history.Durations)
+}
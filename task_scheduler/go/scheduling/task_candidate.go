@@ -19,6 +19,9 @@ import (
 // TaskCandidate is a struct used for determining which tasks to schedule.
 type TaskCandidate struct {
 	Attempt int `json:"attempt"`
+	// AvoidBots are bots which may not run this candidate, eg. because a
+	// previous attempt failed on them.
+	AvoidBots []string `json:"avoidBots,omitempty"`
 	// NB: Because multiple Jobs may share a Task, the BuildbucketBuildId
 	// could be inherited from any matching Job. Therefore, this should be
 	// used for non-critical, informational purposes only.
@@ -45,6 +48,7 @@ func (c *TaskCandidate) CopyNoDiagnostics() *TaskCandidate {
 	copy(jobs, c.Jobs)
 	return &TaskCandidate{
 		Attempt:            c.Attempt,
+		AvoidBots:          util.CopyStringSlice(c.AvoidBots),
 		BuildbucketBuildId: c.BuildbucketBuildId,
 		Commits:            util.CopyStringSlice(c.Commits),
 		CasInput:           c.CasInput,
@@ -407,6 +411,14 @@ type taskCandidateSchedulingDiagnostics struct {
 	// candidate's TaskKey. In many cases, it is possible to identify all candidates included in
 	// NumHigherScoreSimilarCandidates by following the chain of LastSimilarCandidate.
 	LastSimilarCandidate *types.TaskKey `json:"lastSimilarCandidate,omitempty"`
+	// Name of the fair-share quota to which this candidate is charged, if any.
+	Quota string `json:"quota,omitempty"`
+	// True if this candidate was considered ahead of higher-score candidates
+	// because its quota is below its guaranteed minimum.
+	QuotaDeficit bool `json:"quotaDeficit,omitempty"`
+	// True if this candidate was not selected because its quota has reached
+	// its maximum share of the pool.
+	OverQuota bool `json:"overQuota,omitempty"`
 	// True if this candidate has been selected to run.
 	Selected bool `json:"selected,omitempty"`
 }
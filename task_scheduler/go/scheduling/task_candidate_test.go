@@ -17,6 +17,7 @@ import (
 func fullTaskCandidate() *TaskCandidate {
 	return &TaskCandidate{
 		Attempt:            3,
+		AvoidBots:          []string{"bot1"},
 		BuildbucketBuildId: 8888,
 		Commits:            []string{"a", "b"},
 		Diagnostics:        &taskCandidateDiagnostics{},
@@ -32,6 +32,8 @@ import (
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/task_scheduler/go/db"
 	"go.skia.org/infra/task_scheduler/go/db/cache"
+	"go.skia.org/infra/task_scheduler/go/flakes"
+	"go.skia.org/infra/task_scheduler/go/quota"
 	"go.skia.org/infra/task_scheduler/go/skip_tasks"
 	"go.skia.org/infra/task_scheduler/go/specs"
 	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
@@ -94,6 +96,7 @@ type TaskScheduler struct {
 	db                  db.DB
 	diagClient          gcs.GCSClient
 	diagInstance        string
+	flakes              *flakes.Tracker
 	rbeCas              cas.CAS
 	rbeCasInstance      string
 	jCache              cache.JobCache
@@ -105,6 +108,7 @@ type TaskScheduler struct {
 	pools         []string
 	pubsubCount   metrics2.Counter
 	pubsubTopic   string
+	quotas        *quota.Config
 	queue         []*TaskCandidate // protected by queueMtx.
 	queueMtx      sync.RWMutex
 	repos         repograph.Map
@@ -121,7 +125,7 @@ type TaskScheduler struct {
 	window                window.Window
 }
 
-func NewTaskScheduler(ctx context.Context, d db.DB, bl *skip_tasks.DB, period time.Duration, numCommits int, repos repograph.Map, rbeCas cas.CAS, rbeCasInstance string, taskExecutors map[string]types.TaskExecutor, c *http.Client, timeDecayAmt24Hr float64, pools []string, cdPool, pubsubTopic string, taskCfgCache task_cfg_cache.TaskCfgCache, ts oauth2.TokenSource, diagClient gcs.GCSClient, diagInstance string, debugBusyBots BusyBotsDebugLog) (*TaskScheduler, error) {
+func NewTaskScheduler(ctx context.Context, d db.DB, bl *skip_tasks.DB, period time.Duration, numCommits int, repos repograph.Map, rbeCas cas.CAS, rbeCasInstance string, taskExecutors map[string]types.TaskExecutor, c *http.Client, timeDecayAmt24Hr float64, pools []string, cdPool, pubsubTopic string, taskCfgCache task_cfg_cache.TaskCfgCache, ts oauth2.TokenSource, diagClient gcs.GCSClient, diagInstance string, debugBusyBots BusyBotsDebugLog, quotas *quota.Config, flakePolicy *flakes.Policy) (*TaskScheduler, error) {
 	// Repos must be updated before window is initialized; otherwise the repos may be uninitialized,
 	// resulting in the window being too short, causing the caches to be loaded with incomplete data.
 	for _, r := range repos {
@@ -151,6 +155,12 @@ func NewTaskScheduler(ctx context.Context, d db.DB, bl *skip_tasks.DB, period ti
 		pools = append(pools, cdPool)
 	}
 
+	// Track flaky tasks, if a policy was provided.
+	var flakeTracker *flakes.Tracker
+	if flakePolicy != nil {
+		flakeTracker = flakes.NewTracker(d, bl, repos, flakePolicy)
+	}
+
 	s := &TaskScheduler{
 		skipTasks:             bl,
 		busyBots:              newBusyBots(debugBusyBots),
@@ -159,12 +169,14 @@ func NewTaskScheduler(ctx context.Context, d db.DB, bl *skip_tasks.DB, period ti
 		db:                    d,
 		diagClient:            diagClient,
 		diagInstance:          diagInstance,
+		flakes:                flakeTracker,
 		jCache:                jCache,
 		pendingInsert:         map[string]bool{},
 		pools:                 pools,
 		pubsubCount:           metrics2.GetCounter("task_scheduler_pubsub_handler"),
 		pubsubTopic:           pubsubTopic,
 		queue:                 []*TaskCandidate{},
+		quotas:                quotas,
 		queueMtx:              sync.RWMutex{},
 		rbeCas:                rbeCas,
 		rbeCasInstance:        rbeCasInstance,
@@ -220,6 +232,9 @@ func (s *TaskScheduler) Start(ctx context.Context) {
 			lvUpdateUnfinishedTasks.Reset()
 		}
 	})
+	if s.flakes != nil {
+		s.flakes.Start(ctx)
+	}
 }
 
 // putTask is a wrapper around DB.PutTask which adds the task to the cache.
@@ -356,6 +371,7 @@ type tasksCfgGetter interface {
 
 type commitGetter interface {
 	Get(ref string) *repograph.Commit
+	ChangedFiles(ctx context.Context, hash string) ([]string, error)
 }
 
 // ComputeBlamelist computes the blamelist for a new task, specified by name,
@@ -375,6 +391,9 @@ type commitGetter interface {
 //     history, "stealing" commits from the previous task until we find a commit
 //     which was covered by a *different* previous task.
 //
+// In all cases, ancestors of the revision which are not relevant to the task
+// according to its path regexes are left out of the blamelist.
+//
 // Args:
 //   - cache:      TaskCache instance.
 //   - repo:       repograph.Graph instance corresponding to the repository of the task.
@@ -415,11 +434,29 @@ func ComputeBlamelist(ctx context.Context, cache cache.TaskCache, repo commitGet
 			}
 			return skerr.Wrap(err)
 		}
-		if _, ok := cfg.Tasks[taskName]; !ok {
+		taskSpec, ok := cfg.Tasks[taskName]
+		if !ok {
 			sklog.Infof("Computing blamelist for %s in %s @ %s, Task Spec not defined in %s (have %d tasks); stopping blamelist calculation.", taskName, repoName, revision.Hash, commit.Hash, len(cfg.Tasks))
 			return repograph.ErrStopRecursing
 		}
 
+		// Leave out commits which aren't relevant to the task according to
+		// its path regexes. The revision itself is always included.
+		if commit.Hash != revision.Hash && taskSpec.HasPathFilters() {
+			files, err := repo.ChangedFiles(ctx, commit.Hash)
+			if err != nil && err != repograph.ErrChangedFilesNotSupported {
+				return skerr.Wrap(err)
+			} else if err == nil {
+				relevant, err := taskSpec.MatchesChangedFiles(files)
+				if err != nil {
+					return skerr.Wrap(err)
+				}
+				if !relevant {
+					return nil
+				}
+			}
+		}
+
 		// Determine whether any task already includes this commit.
 		prev, err := cache.GetTaskForCommit(repoName, commit.Hash, taskName)
 		if err != nil {
@@ -528,6 +565,19 @@ func writeMainLoopDiagnosticsToGCS(ctx context.Context, start time.Time, end tim
 	})
 }
 
+// setMaxAttempts sets the maximum number of attempts for the given candidate,
+// copying its TaskSpec if the maximum differs from that of the TaskSpec.
+func setMaxAttempts(c *TaskCandidate, maxAttempts int) {
+	specMaxAttempts := c.TaskSpec.MaxAttempts
+	if specMaxAttempts == 0 {
+		specMaxAttempts = specs.DEFAULT_TASK_SPEC_MAX_ATTEMPTS
+	}
+	if maxAttempts != specMaxAttempts {
+		c.TaskSpec = c.TaskSpec.Copy()
+		c.TaskSpec.MaxAttempts = maxAttempts
+	}
+}
+
 // findTaskCandidatesForJobs returns the set of all taskCandidates needed by all
 // currently-unfinished jobs.
 func (s *TaskScheduler) findTaskCandidatesForJobs(ctx context.Context, unfinishedJobs []*types.Job) (map[types.TaskKey]*TaskCandidate, error) {
@@ -610,7 +660,7 @@ func (s *TaskScheduler) filterTaskCandidates(ctx context.Context, preFilterCandi
 	skipped := map[string]int{}
 	for _, c := range preFilterCandidates {
 		// Reject skipped tasks.
-		if rule := s.skipTasks.MatchRule(c.Name, c.Revision); rule != "" {
+		if rule := s.skipTasks.MatchRule(c.Repo, c.Name, c.Revision); rule != "" {
 			skipped[rule]++
 			c.GetDiagnostics().Filtering = &taskCandidateFilteringDiagnostics{SkippedByRule: rule}
 			continue
@@ -655,6 +705,13 @@ func (s *TaskScheduler) filterTaskCandidates(ctx context.Context, preFilterCandi
 			if maxAttempts == 0 {
 				maxAttempts = specs.DEFAULT_TASK_SPEC_MAX_ATTEMPTS
 			}
+			// Flaky TaskSpecs may be granted extra attempts. The
+			// previous attempt may also have been granted more
+			// attempts, eg. if the TaskSpec was flakier at the time.
+			maxAttempts += s.flakes.ExtraAttempts(c.Repo, c.Name)
+			if previous.MaxAttempts > maxAttempts {
+				maxAttempts = previous.MaxAttempts
+			}
 			// Special case for tasks created before arbitrary
 			// numbers of attempts were possible.
 			previousAttempt := previous.Attempt
@@ -671,6 +728,20 @@ func (s *TaskScheduler) filterTaskCandidates(ctx context.Context, preFilterCandi
 			}
 			c.Attempt = previousAttempt + 1
 			c.RetryOf = previous.Id
+			setMaxAttempts(c, maxAttempts)
+			if s.flakes.RetryOnDifferentBot() {
+				for _, t := range prevTasks {
+					if !t.Success() && t.SwarmingBotId != "" && !util.In(t.SwarmingBotId, c.AvoidBots) {
+						c.AvoidBots = append(c.AvoidBots, t.SwarmingBotId)
+					}
+				}
+			}
+		} else if extra := s.flakes.ExtraAttempts(c.Repo, c.Name); extra > 0 {
+			maxAttempts := c.TaskSpec.MaxAttempts
+			if maxAttempts == 0 {
+				maxAttempts = specs.DEFAULT_TASK_SPEC_MAX_ATTEMPTS
+			}
+			setMaxAttempts(c, maxAttempts+extra)
 		}
 
 		// Don't consider candidates whose dependencies are not met.
@@ -1167,6 +1238,12 @@ func getCandidatesToSchedule(ctx context.Context, bots []*types.Machine, tasks [
 			}
 		}
 
+		// Retries may be required to run on a different bot from the
+		// previous attempts.
+		for _, botId := range c.AvoidBots {
+			delete(matches, botId)
+		}
+
 		// Set of candidates that could have used the same bots.
 		similarCandidates := map[*TaskCandidate]struct{}{}
 		var lowestScoreSimilarCandidate *TaskCandidate
@@ -1213,6 +1290,13 @@ func getCandidatesToSchedule(ctx context.Context, bots []*types.Machine, tasks [
 			diag.Selected = true
 			usedBots[chosenBot] = true
 
+			// Swarming chooses the bot which runs the task, so pin the
+			// task to the chosen bot if it must avoid others.
+			if len(c.AvoidBots) > 0 {
+				c.TaskSpec = c.TaskSpec.Copy()
+				c.TaskSpec.Dimensions = append(c.TaskSpec.Dimensions, "id:"+chosenBot)
+			}
+
 			// Add the task to the scheduling list.
 			rv = append(rv, c)
 			countByTaskSpec[c.Name]++
@@ -1349,8 +1433,13 @@ func (s *TaskScheduler) scheduleTasks(ctx context.Context, bots []*types.Machine
 	ctx, span := trace.StartSpan(ctx, "scheduleTasks")
 	defer span.End()
 
-	// Match free bots with tasks.
-	candidates := getCandidatesToSchedule(ctx, bots, queue)
+	// Match free bots with tasks, applying fair-share quotas if configured.
+	var candidates []*TaskCandidate
+	if s.quotas != nil {
+		candidates = s.getCandidatesToScheduleWithQuotas(ctx, bots, queue)
+	} else {
+		candidates = getCandidatesToSchedule(ctx, bots, queue)
+	}
 
 	// Merge CAS inputs for the tasks.
 	merged, mergeErr := s.mergeCASInputs(ctx, candidates)
@@ -1825,6 +1914,9 @@ func (s *TaskScheduler) updateUnfinishedJobs(ctx context.Context) error {
 		if !reflect.DeepEqual(summaries, j.Tasks) {
 			j.Tasks = summaries
 			j.Status = j.DeriveStatus()
+			if s.flakes.MarkPassedWithFlakes() {
+				j.PassedWithFlakes = j.DeriveFlaky()
+			}
 			if j.Done() {
 				j.Finished = now.Now(ctx)
 			}
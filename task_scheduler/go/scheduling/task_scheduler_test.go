@@ -297,7 +297,7 @@ func setup(t *testing.T) (context.Context, *mem_git.MemGit, *memory.InMemoryDB,
 		types.TaskExecutor_Swarming:   taskExec,
 		types.TaskExecutor_UseDefault: taskExec,
 	}
-	s, err := NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, repos, cas, "fake-cas-instance", taskExecs, urlMock.Client(), 1.0, swarming.POOLS_PUBLIC, cdPoolName, "", taskCfgCache, nil, mem_gcsclient.New("diag_unit_tests"), btInstance, false)
+	s, err := NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, repos, cas, "fake-cas-instance", taskExecs, urlMock.Client(), 1.0, swarming.POOLS_PUBLIC, cdPoolName, "", taskCfgCache, nil, mem_gcsclient.New("diag_unit_tests"), btInstance, false, nil, nil)
 	require.NoError(t, err)
 
 	// Insert jobs. This is normally done by the JobCreator.
@@ -1939,6 +1939,27 @@ func TestGetCandidatesToSchedule(t *testing.T) {
 	checkDiags([]*types.Machine{b1, b2}, []*TaskCandidate{t1, t2, t3})
 }
 
+func TestGetCandidatesToSchedule_AvoidBots_PinnedToDifferentBot(t *testing.T) {
+	ctx := context.Background()
+	retry := makeTaskCandidate("retry", []string{"pool:Skia"})
+	retry.AvoidBots = []string{"bot1"}
+	bots := []*types.Machine{
+		makeSwarmingBot("bot1", []string{"pool:Skia"}),
+		makeSwarmingBot("bot2", []string{"pool:Skia"}),
+	}
+	rv := getCandidatesToSchedule(ctx, bots, []*TaskCandidate{retry})
+	require.Equal(t, []*TaskCandidate{retry}, rv)
+	require.Equal(t, []string{"bot2"}, retry.Diagnostics.Scheduling.MatchingBots)
+	require.Equal(t, []string{"pool:Skia", "id:bot2"}, retry.TaskSpec.Dimensions)
+
+	// If only the avoided bot is free, the retry waits.
+	retry = makeTaskCandidate("retry", []string{"pool:Skia"})
+	retry.AvoidBots = []string{"bot1"}
+	rv = getCandidatesToSchedule(ctx, bots[:1], []*TaskCandidate{retry})
+	require.Empty(t, rv)
+	require.True(t, retry.Diagnostics.Scheduling.NoBotsAvailable)
+}
+
 func makeBot(id string, dims map[string]string) *types.Machine {
 	dimensions := make([]string, 0, len(dims))
 	for k, v := range dims {
@@ -2362,7 +2383,7 @@ func testMultipleCandidatesBackfillingEachOtherSetup(t *testing.T) (context.Cont
 		types.TaskExecutor_Swarming:   taskExec,
 		types.TaskExecutor_UseDefault: taskExec,
 	}
-	s, err := NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, repos, cas, "fake-cas-instance", taskExecs, mockhttpclient.NewURLMock().Client(), 1.0, swarming.POOLS_PUBLIC, cdPoolName, "", taskCfgCache, nil, mem_gcsclient.New("diag_unit_tests"), btInstance, BusyBotsDebugLoggingOff)
+	s, err := NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, repos, cas, "fake-cas-instance", taskExecs, mockhttpclient.NewURLMock().Client(), 1.0, swarming.POOLS_PUBLIC, cdPoolName, "", taskCfgCache, nil, mem_gcsclient.New("diag_unit_tests"), btInstance, BusyBotsDebugLoggingOff, nil, nil)
 	require.NoError(t, err)
 
 	for _, h := range hashes {
@@ -4102,6 +4123,55 @@ func TestComputeBlamelist_FirstCommitTested(t *testing.T) {
 	})
 }
 
+// changedFilesMap implements repograph.ChangedFilesGetter.
+type changedFilesMap map[string][]string
+
+func (m changedFilesMap) ChangedFiles(_ context.Context, hash string) ([]string, error) {
+	return m[hash], nil
+}
+
+func TestComputeBlamelist_PathRegexes_IrrelevantCommitsOmitted(t *testing.T) {
+	ctx := context.Background()
+
+	const repoName = "some_repo"
+	const taskName = "Test-Foo-Bar"
+	tcg := tcc_mocks.FixedTasksCfg(&specs.TasksCfg{
+		Tasks: map[string]*specs.TaskSpec{
+			taskName: {
+				ExcludePathRegexes: []string{"^docs/"},
+			},
+		},
+	})
+
+	mg, repo := newMemRepo(t)
+	firstCommit := mg.Commit("a")
+	secondCommit := mg.Commit("b", firstCommit)
+	thirdCommit := mg.Commit("c", secondCommit)
+	fourthCommit := mg.Commit("d", thirdCommit)
+	repo.SetChangedFilesGetter(changedFilesMap{
+		firstCommit:  {"src/a.cpp"},
+		secondCommit: {"docs/a.md"},
+		thirdCommit:  {"docs/b.md"},
+		fourthCommit: {"docs/c.md", "src/b.cpp"},
+	})
+
+	mtc := &cache_mocks.TaskCache{}
+	mtc.On("GetTaskForCommit", repoName, mock.Anything, taskName).Return(nil, nil)
+
+	test := func(name string, revision string, expectedBlamelist []string) {
+		t.Run(name, func(t *testing.T) {
+			blamedCommits, stealFromTask, err := ComputeBlamelist(ctx, mtc, repo, taskName, repoName, repo.Get(revision), commitsBuffer, tcg, window_mocks.AllInclusiveWindow())
+			require.NoError(t, err)
+			assert.Equal(t, expectedBlamelist, blamedCommits)
+			assert.Nil(t, stealFromTask)
+		})
+	}
+	// The docs-only commits are left out of the blamelist.
+	test("relevant revision", fourthCommit, []string{fourthCommit, firstCommit})
+	// The revision itself is always included.
+	test("irrelevant revision", thirdCommit, []string{thirdCommit, firstCommit})
+}
+
 func TestComputeBlamelist_LastCommitTested_FollowingCommitsBlamed(t *testing.T) {
 	ctx := context.Background()
 
@@ -131,23 +131,23 @@ func (b *DB) AutoUpdate(ctx context.Context) {
 	}()
 }
 
-// Match determines whether the given taskSpec/commit pair matches one of the
-// Rules in the DB.
-func (b *DB) Match(taskSpec, commit string) bool {
-	return b.MatchRule(taskSpec, commit) != ""
+// Match determines whether the given repo/taskSpec/commit combination matches
+// one of the Rules in the DB.
+func (b *DB) Match(repo, taskSpec, commit string) bool {
+	return b.MatchRule(repo, taskSpec, commit) != ""
 }
 
-// MatchRule determines whether the given taskSpec/commit pair matches one of
-// the Rules in the DB. Returns the name of the matched Rule or the empty string
-// if no Rules match.
-func (b *DB) MatchRule(taskSpec, commit string) string {
+// MatchRule determines whether the given repo/taskSpec/commit combination
+// matches one of the Rules in the DB. Returns the name of the matched Rule or
+// the empty string if no Rules match.
+func (b *DB) MatchRule(repo, taskSpec, commit string) string {
 	if b == nil {
 		return ""
 	}
 	b.mtx.RLock()
 	defer b.mtx.RUnlock()
 	for _, rule := range b.rules {
-		if rule.Match(taskSpec, commit) {
+		if rule.Match(repo, taskSpec, commit) {
 			return rule.Name
 		}
 	}
@@ -244,6 +244,25 @@ func (b *DB) RemoveRule(ctx context.Context, id string) error {
 	return nil
 }
 
+// RemoveExpiredRules removes the Rules added by the given user which had
+// expired as of the given time from the DB. Rules added by anyone else are
+// left for their owners to remove. Returns the names of the removed Rules.
+func (b *DB) RemoveExpiredRules(ctx context.Context, addedBy string, expiredAt time.Time) ([]string, error) {
+	if b == nil {
+		return nil, nil
+	}
+	expired := []string{}
+	for _, r := range b.GetRules() {
+		if r.AddedBy == addedBy && r.Expired(expiredAt) {
+			if err := b.RemoveRule(ctx, r.Name); err != nil {
+				return expired, err
+			}
+			expired = append(expired, r.Name)
+		}
+	}
+	return expired, nil
+}
+
 // GetRules returns a slice containing all of the Rules in the DB.
 func (b *DB) GetRules() []*Rule {
 	if b == nil {
@@ -270,13 +289,20 @@ func (b *DB) GetRules() []*Rule {
 //
 // A Rule should specify TaskSpecPatterns or Commits or both.
 //
+// Repos are the URLs of the repos to which the Rule applies. If the list is
+// empty, the Rule applies to all repos.
+//
+// If Expires is set, the Rule no longer applies after that time.
+//
 // TODO(borenet): Add an explicit ID field and a timestamp.
 type Rule struct {
-	AddedBy          string   `json:"added_by"`
-	TaskSpecPatterns []string `json:"task_spec_patterns"`
-	Commits          []string `json:"commits"`
-	Description      string   `json:"description"`
-	Name             string   `json:"name"`
+	AddedBy          string    `json:"added_by"`
+	TaskSpecPatterns []string  `json:"task_spec_patterns"`
+	Commits          []string  `json:"commits"`
+	Description      string    `json:"description"`
+	Expires          time.Time `json:"expires,omitempty"`
+	Name             string    `json:"name"`
+	Repos            []string  `json:"repos,omitempty"`
 }
 
 type rules []*Rule
@@ -333,6 +359,15 @@ func (r *Rule) matchTaskSpec(taskSpec string) bool {
 	return false
 }
 
+// matchRepo determines whether the repo portion of the Rule matches.
+func (r *Rule) matchRepo(repo string) bool {
+	// If no repos are specified, then the rule applies for ALL repos.
+	if len(r.Repos) == 0 {
+		return true
+	}
+	return util.In(repo, r.Repos)
+}
+
 // matchCommit determines whether the commit portion of the Rule matches.
 func (r *Rule) matchCommit(commit string) bool {
 	// If no commit is specified, then the rule applies for ALL commits.
@@ -349,9 +384,16 @@ func (r *Rule) matchCommit(commit string) bool {
 	return false
 }
 
-// Match returns true iff the Rule matches the given taskSpec and commit.
-func (r *Rule) Match(taskSpec, commit string) bool {
-	return r.matchTaskSpec(taskSpec) && r.matchCommit(commit)
+// Expired returns true iff the Rule has an expiration time which is not after
+// the given time.
+func (r *Rule) Expired(now time.Time) bool {
+	return !r.Expires.IsZero() && !now.Before(r.Expires)
+}
+
+// Match returns true iff the Rule matches the given repo, taskSpec and commit
+// and has not expired.
+func (r *Rule) Match(repo, taskSpec, commit string) bool {
+	return !r.Expired(time.Now()) && r.matchRepo(repo) && r.matchTaskSpec(taskSpec) && r.matchCommit(commit)
 }
 
 // Copy returns a deep copy of the Rule.
@@ -361,6 +403,8 @@ func (r *Rule) Copy() *Rule {
 		TaskSpecPatterns: util.CopyStringSlice(r.TaskSpecPatterns),
 		Commits:          util.CopyStringSlice(r.Commits),
 		Description:      r.Description,
+		Expires:          r.Expires,
 		Name:             r.Name,
+		Repos:            util.CopyStringSlice(r.Repos),
 	}
 }
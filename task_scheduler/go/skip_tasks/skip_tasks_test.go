@@ -55,19 +55,51 @@ func TestAddRemove(t *testing.T) {
 	assertRulesAreEqual()
 }
 
+func TestRemoveExpiredRules_OnlyRemovesRulesAddedByGivenUser(t *testing.T) {
+	b, cleanup := setup(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Unix(1600000000, 0)
+	add := func(name, addedBy string, expires time.Time) {
+		require.NoError(t, b.addRule(ctx, &Rule{
+			AddedBy:          addedBy,
+			TaskSpecPatterns: []string{".*"},
+			Name:             name,
+			Expires:          expires,
+		}))
+	}
+	add("mine-expired", "task-scheduler", now.Add(-time.Hour))
+	add("mine-unexpired", "task-scheduler", now.Add(time.Hour))
+	add("mine-no-expiry", "task-scheduler", time.Time{})
+	add("theirs-expired", "test@google.com", now.Add(-time.Hour))
+
+	removed, err := b.RemoveExpiredRules(ctx, "task-scheduler", now)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mine-expired"}, removed)
+	var names []string
+	for _, r := range b.GetRules() {
+		names = append(names, r.Name)
+	}
+	require.ElementsMatch(t, []string{"mine-unexpired", "mine-no-expiry", "theirs-expired"}, names)
+}
+
 func TestRuleCopy(t *testing.T) {
 	r := &Rule{
 		AddedBy:          "me@google.com",
 		TaskSpecPatterns: []string{"a", "b"},
 		Commits:          []string{"abc123", "def456"},
 		Description:      "this is a rule",
+		Expires:          time.Unix(1600000000, 0),
 		Name:             "example",
+		Repos:            []string{"a.git"},
 	}
 	assertdeep.Copy(t, r, r.Copy())
 }
 
 func TestRules(t *testing.T) {
 	type testCase struct {
+		repo        string
 		taskSpec    string
 		commit      string
 		expectMatch bool
@@ -174,10 +206,66 @@ func TestRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			rule: Rule{
+				AddedBy:          "test@google.com",
+				Name:             "Expired",
+				TaskSpecPatterns: []string{".*"},
+				Expires:          time.Now().Add(-time.Hour),
+			},
+			cases: []testCase{
+				{
+					taskSpec:    "My-TaskSpec",
+					commit:      "abc123",
+					expectMatch: false,
+					msg:         "Expired rules should not match",
+				},
+			},
+		},
+		{
+			rule: Rule{
+				AddedBy:          "test@google.com",
+				Name:             "Not yet expired",
+				TaskSpecPatterns: []string{".*"},
+				Expires:          time.Now().Add(time.Hour),
+			},
+			cases: []testCase{
+				{
+					taskSpec:    "My-TaskSpec",
+					commit:      "abc123",
+					expectMatch: true,
+					msg:         "Unexpired rules should match",
+				},
+			},
+		},
+		{
+			rule: Rule{
+				AddedBy:          "test@google.com",
+				Name:             "Match some repos",
+				TaskSpecPatterns: []string{".*"},
+				Repos:            []string{"a.git"},
+			},
+			cases: []testCase{
+				{
+					repo:        "a.git",
+					taskSpec:    "My-TaskSpec",
+					commit:      "abc123",
+					expectMatch: true,
+					msg:         "Should match in a listed repo",
+				},
+				{
+					repo:        "b.git",
+					taskSpec:    "My-TaskSpec",
+					commit:      "abc123",
+					expectMatch: false,
+					msg:         "Should not match in other repos",
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		for _, c := range test.cases {
-			require.Equal(t, c.expectMatch, test.rule.Match(c.taskSpec, c.commit), c.msg)
+			require.Equal(t, c.expectMatch, test.rule.Match(c.repo, c.taskSpec, c.commit), c.msg)
 		}
 	}
 }
@@ -432,6 +520,6 @@ func TestCommitRange(t *testing.T) {
 		},
 	}
 	for _, c := range tc {
-		require.Equal(t, c.expect, b.Match("", c.commit))
+		require.Equal(t, c.expect, b.Match("", "", c.commit))
 	}
 }
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"go.skia.org/infra/go/cas/rbe"
@@ -369,11 +371,22 @@ type TaskSpec struct {
 	// ExtraTags are extra tags to add to the Swarming task.
 	ExtraTags map[string]string `json:"extra_tags,omitempty"`
 
+	// ExcludePathRegexes are regular expressions for paths in the repo which
+	// are not relevant to this task. See IncludePathRegexes.
+	ExcludePathRegexes []string `json:"exclude_path_regexes,omitempty"`
+
 	// Idempotent indicates that triggering this task with the same
 	// parameters as previously triggered has no side effect and thus the
 	// task may be de-duplicated.
 	Idempotent bool `json:"idempotent,omitempty"`
 
+	// IncludePathRegexes are regular expressions for paths in the repo which
+	// are relevant to this task. If set, commits on which no changed file
+	// matches any of these regexes (or on which every matching file also
+	// matches one of the ExcludePathRegexes) are left out of the blamelists
+	// of this task, so that they don't cause the task to run.
+	IncludePathRegexes []string `json:"include_path_regexes,omitempty"`
+
 	// IoTimeout is the maximum amount of time which the task may take to
 	// communicate with the server.
 	IoTimeout time.Duration `json:"io_timeout_ns,omitempty"`
@@ -423,9 +436,25 @@ func (t *TaskSpec) Validate(cfg *TasksCfg) error {
 		return fmt.Errorf("Invalid task executor %q; must be one of: %v", t.TaskExecutor, types.ValidTaskExecutors)
 	}
 
+	if err := validatePathRegexes(t.IncludePathRegexes, t.ExcludePathRegexes); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// HasPathFilters returns true iff the TaskSpec has any include or exclude path
+// regexes.
+func (t *TaskSpec) HasPathFilters() bool {
+	return len(t.IncludePathRegexes) > 0 || len(t.ExcludePathRegexes) > 0
+}
+
+// MatchesChangedFiles returns true iff a commit which changed the given files
+// is relevant to this TaskSpec, according to its path regexes.
+func (t *TaskSpec) MatchesChangedFiles(files []string) (bool, error) {
+	return matchesChangedFiles(t.IncludePathRegexes, t.ExcludePathRegexes, files)
+}
+
 // Copy returns a copy of the TaskSpec.
 func (t *TaskSpec) Copy() *TaskSpec {
 	var caches []*Cache
@@ -459,27 +488,31 @@ func (t *TaskSpec) Copy() *TaskSpec {
 	}
 	extraArgs := util.CopyStringSlice(t.ExtraArgs)
 	extraTags := util.CopyStringMap(t.ExtraTags)
+	excludePathRegexes := util.CopyStringSlice(t.ExcludePathRegexes)
+	includePathRegexes := util.CopyStringSlice(t.IncludePathRegexes)
 	outputs := util.CopyStringSlice(t.Outputs)
 	return &TaskSpec{
-		Caches:           caches,
-		CasSpec:          t.CasSpec,
-		CipdPackages:     cipdPackages,
-		Command:          cmd,
-		Dependencies:     deps,
-		Dimensions:       dims,
-		Environment:      environment,
-		EnvPrefixes:      envPrefixes,
-		ExecutionTimeout: t.ExecutionTimeout,
-		Expiration:       t.Expiration,
-		ExtraArgs:        extraArgs,
-		ExtraTags:        extraTags,
-		Idempotent:       t.Idempotent,
-		IoTimeout:        t.IoTimeout,
-		MaxAttempts:      t.MaxAttempts,
-		Outputs:          outputs,
-		Priority:         t.Priority,
-		ServiceAccount:   t.ServiceAccount,
-		TaskExecutor:     t.TaskExecutor,
+		Caches:             caches,
+		CasSpec:            t.CasSpec,
+		CipdPackages:       cipdPackages,
+		Command:            cmd,
+		Dependencies:       deps,
+		Dimensions:         dims,
+		Environment:        environment,
+		EnvPrefixes:        envPrefixes,
+		ExcludePathRegexes: excludePathRegexes,
+		ExecutionTimeout:   t.ExecutionTimeout,
+		Expiration:         t.Expiration,
+		ExtraArgs:          extraArgs,
+		ExtraTags:          extraTags,
+		Idempotent:         t.Idempotent,
+		IncludePathRegexes: includePathRegexes,
+		IoTimeout:          t.IoTimeout,
+		MaxAttempts:        t.MaxAttempts,
+		Outputs:            outputs,
+		Priority:           t.Priority,
+		ServiceAccount:     t.ServiceAccount,
+		TaskExecutor:       t.TaskExecutor,
 	}
 }
 
@@ -498,6 +531,15 @@ type CipdPackage = cipd.Package
 // JobSpec is a struct which describes a set of TaskSpecs to run as part of a
 // larger effort.
 type JobSpec struct {
+	// ExcludePathRegexes are regular expressions for paths in the repo which
+	// are not relevant to this job. See IncludePathRegexes.
+	ExcludePathRegexes []string `json:"exclude_path_regexes,omitempty"`
+	// IncludePathRegexes are regular expressions for paths in the repo which
+	// are relevant to this job. If set, the job is not triggered for commits
+	// on which no changed file matches any of these regexes (or on which
+	// every matching file also matches one of the ExcludePathRegexes). Only
+	// applies to jobs which are triggered by commits.
+	IncludePathRegexes []string `json:"include_path_regexes,omitempty"`
 	// IsCD indicates whether this job is a Continuous Deployment pipeline. If
 	// true, this job is not allowed to be triggered as a try job, no backfills
 	// of tasks are run (ie. only the newest Task Candidate runs), and its tasks
@@ -534,7 +576,19 @@ func (j *JobSpec) Validate() error {
 	default:
 		return fmt.Errorf("Invalid job trigger %q", j.Trigger)
 	}
-	return nil
+	return validatePathRegexes(j.IncludePathRegexes, j.ExcludePathRegexes)
+}
+
+// HasPathFilters returns true iff the JobSpec has any include or exclude path
+// regexes.
+func (j *JobSpec) HasPathFilters() bool {
+	return len(j.IncludePathRegexes) > 0 || len(j.ExcludePathRegexes) > 0
+}
+
+// MatchesChangedFiles returns true iff a commit which changed the given files
+// is relevant to this JobSpec, according to its path regexes.
+func (j *JobSpec) MatchesChangedFiles(files []string) (bool, error) {
+	return matchesChangedFiles(j.IncludePathRegexes, j.ExcludePathRegexes, files)
 }
 
 // Copy returns a copy of the JobSpec.
@@ -545,11 +599,77 @@ func (j *JobSpec) Copy() *JobSpec {
 		copy(taskSpecs, j.TaskSpecs)
 	}
 	return &JobSpec{
-		IsCD:      j.IsCD,
-		Priority:  j.Priority,
-		TaskSpecs: taskSpecs,
-		Trigger:   j.Trigger,
+		ExcludePathRegexes: util.CopyStringSlice(j.ExcludePathRegexes),
+		IncludePathRegexes: util.CopyStringSlice(j.IncludePathRegexes),
+		IsCD:               j.IsCD,
+		Priority:           j.Priority,
+		TaskSpecs:          taskSpecs,
+		Trigger:            j.Trigger,
+	}
+}
+
+// validatePathRegexes returns an error if any of the given path regexes do not
+// compile.
+func validatePathRegexes(include, exclude []string) error {
+	for _, regexes := range [][]string{include, exclude} {
+		for _, r := range regexes {
+			if _, err := regexp.Compile(r); err != nil {
+				return fmt.Errorf("Invalid path regex %q: %s", r, err)
+			}
+		}
+	}
+	return nil
+}
+
+// pathRegexes caches compiled path regexes, keyed by their source. Path regexes
+// are matched against the changed files of every commit in the scheduling
+// window, so we only want to compile them once.
+var pathRegexes sync.Map
+
+// compilePathRegexes returns the compiled versions of the given path regexes.
+func compilePathRegexes(regexes []string) ([]*regexp.Regexp, error) {
+	rv := make([]*regexp.Regexp, 0, len(regexes))
+	for _, r := range regexes {
+		if re, ok := pathRegexes.Load(r); ok {
+			rv = append(rv, re.(*regexp.Regexp))
+			continue
+		}
+		re, err := regexp.Compile(r)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "invalid path regex %q", r)
+		}
+		pathRegexes.Store(r, re)
+		rv = append(rv, re)
+	}
+	return rv, nil
+}
+
+// matchesChangedFiles returns true iff at least one of the given files matches
+// at least one of the include regexes (or there are no include regexes) and
+// none of the exclude regexes.
+func matchesChangedFiles(include, exclude []string, files []string) (bool, error) {
+	matchesAny := func(regexes []*regexp.Regexp, file string) bool {
+		for _, re := range regexes {
+			if re.MatchString(file) {
+				return true
+			}
+		}
+		return false
+	}
+	includeRegexes, err := compilePathRegexes(include)
+	if err != nil {
+		return false, err
+	}
+	excludeRegexes, err := compilePathRegexes(exclude)
+	if err != nil {
+		return false, err
+	}
+	for _, file := range files {
+		if (len(includeRegexes) == 0 || matchesAny(includeRegexes, file)) && !matchesAny(excludeRegexes, file) {
+			return true, nil
+		}
 	}
+	return false, nil
 }
 
 // GetTaskSpecDAG returns a map describing all of the dependencies of the
@@ -34,28 +34,32 @@ func fakeTaskSpec() *TaskSpec {
 		EnvPrefixes: map[string][]string{
 			"PATH": {"curdir"},
 		},
-		ExecutionTimeout: 60 * time.Minute,
-		Expiration:       90 * time.Minute,
-		ExtraArgs:        []string{"--do-really-awesome-stuff"},
+		ExcludePathRegexes: []string{"docs/.*"},
+		ExecutionTimeout:   60 * time.Minute,
+		Expiration:         90 * time.Minute,
+		ExtraArgs:          []string{"--do-really-awesome-stuff"},
 		ExtraTags: map[string]string{
 			"dummy_tag": "dummy_val",
 		},
-		Idempotent:     true,
-		IoTimeout:      10 * time.Minute,
-		MaxAttempts:    5,
-		Outputs:        []string{"out"},
-		Priority:       19.0,
-		ServiceAccount: "fake-account@gmail.com",
-		TaskExecutor:   types.TaskExecutor_Swarming,
+		Idempotent:         true,
+		IncludePathRegexes: []string{"src/.*"},
+		IoTimeout:          10 * time.Minute,
+		MaxAttempts:        5,
+		Outputs:            []string{"out"},
+		Priority:           19.0,
+		ServiceAccount:     "fake-account@gmail.com",
+		TaskExecutor:       types.TaskExecutor_Swarming,
 	}
 }
 
 func fakeJobSpec() *JobSpec {
 	return &JobSpec{
-		IsCD:      true,
-		TaskSpecs: []string{"Build", "Test"},
-		Trigger:   "trigger-name",
-		Priority:  753,
+		ExcludePathRegexes: []string{"infra/.*"},
+		IncludePathRegexes: []string{"src/.*"},
+		IsCD:               true,
+		TaskSpecs:          []string{"Build", "Test"},
+		Trigger:            "trigger-name",
+		Priority:           753,
 	}
 }
 
@@ -103,6 +107,44 @@ func TestCopyJobSpec(t *testing.T) {
 	assertdeep.Copy(t, v, v.Copy())
 }
 
+func TestMatchesChangedFiles(t *testing.T) {
+	test := func(name string, include, exclude, files []string, expect bool) {
+		t.Run(name, func(t *testing.T) {
+			job := &JobSpec{
+				IncludePathRegexes: include,
+				ExcludePathRegexes: exclude,
+			}
+			match, err := job.MatchesChangedFiles(files)
+			require.NoError(t, err)
+			require.Equal(t, expect, match)
+			task := &TaskSpec{
+				IncludePathRegexes: include,
+				ExcludePathRegexes: exclude,
+			}
+			match, err = task.MatchesChangedFiles(files)
+			require.NoError(t, err)
+			require.Equal(t, expect, match)
+		})
+	}
+	test("no filters", nil, nil, []string{"README.md"}, true)
+	test("no files", nil, []string{"docs/.*"}, []string{}, false)
+	test("include matches", []string{"^src/"}, nil, []string{"docs/a.md", "src/a.cpp"}, true)
+	test("include does not match", []string{"^src/"}, nil, []string{"docs/a.md"}, false)
+	test("all excluded", nil, []string{"^docs/", "^infra/"}, []string{"docs/a.md", "infra/bots/a.py"}, false)
+	test("some not excluded", nil, []string{"^docs/"}, []string{"docs/a.md", "BUILD.gn"}, true)
+	test("included but excluded", []string{"^src/"}, []string{"_test\\.cpp$"}, []string{"src/a_test.cpp"}, false)
+}
+
+func TestJobSpecValidate_InvalidPathRegex_ReturnsError(t *testing.T) {
+	j := &JobSpec{
+		Trigger:            TRIGGER_ANY_BRANCH,
+		IncludePathRegexes: []string{"src/("},
+	}
+	err := j.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid path regex")
+}
+
 func TestCopyCasSpec(t *testing.T) {
 	v := fakeCasSpec()
 	assertdeep.Copy(t, v, v.Copy())
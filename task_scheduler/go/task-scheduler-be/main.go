@@ -20,6 +20,7 @@ import (
 	"go.skia.org/infra/go/gcs/gcsclient"
 	"go.skia.org/infra/go/git/repograph"
 	"go.skia.org/infra/go/gitauth"
+	"go.skia.org/infra/go/gitiles"
 	"go.skia.org/infra/go/gitstore/bt_gitstore"
 	gs_pubsub "go.skia.org/infra/go/gitstore/pubsub"
 	"go.skia.org/infra/go/httputils"
@@ -29,6 +30,8 @@ import (
 	"go.skia.org/infra/go/tracing"
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/task_scheduler/go/db/firestore"
+	"go.skia.org/infra/task_scheduler/go/flakes"
+	"go.skia.org/infra/task_scheduler/go/quota"
 	"go.skia.org/infra/task_scheduler/go/scheduling"
 	"go.skia.org/infra/task_scheduler/go/skip_tasks"
 	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
@@ -66,6 +69,8 @@ var (
 	commitWindow      = flag.Int("commitWindow", 10, "Minimum number of recent commits to keep in the timeWindow.")
 	diagnosticsBucket = flag.String("diagnostics_bucket", "skia-task-scheduler-diagnostics", "Name of Google Cloud Storage bucket to use for diagnostics data.")
 	promPort          = flag.String("prom_port", ":20000", "Metrics service address (e.g., ':10110')")
+	quotaConfig       = flag.String("quota_config", "", "Optional JSON file containing fair-share quotas for tasks.")
+	flakePolicyFile   = flag.String("flake_policy", "", "Optional JSON file containing the policy for flaky tasks.")
 
 	pubsubTopicName      = flag.String("pubsub_topic", swarming.PUBSUB_TOPIC_SWARMING_TASKS, "Pub/Sub topic to use for Swarming tasks.")
 	pubsubSubscriberName = flag.String("pubsub_subscriber", PUBSUB_SUBSCRIBER_TASK_SCHEDULER, "Pub/Sub subscriber name.")
@@ -133,6 +138,12 @@ func main() {
 	}
 	repos := autoUpdateRepos.Map
 
+	// GitStore doesn't record which files were changed by each commit, so use
+	// Gitiles to evaluate path regexes in JobSpecs and TaskSpecs.
+	for repoUrl, repo := range repos {
+		repo.SetChangedFilesGetter(gitiles.NewRepo(repoUrl, httpClient))
+	}
+
 	// Initialize Swarming client.
 	cfg := httputils.DefaultClientConfig().WithTokenSource(tokenSource).WithDialTimeout(time.Minute).With2xxOnly()
 	cfg.RequestTimeout = time.Minute
@@ -160,6 +171,24 @@ func main() {
 		sklog.Fatalf("Failed to create TaskCfgCache: %s", err)
 	}
 
+	// Read fair-share quotas, if any.
+	var quotas *quota.Config
+	if *quotaConfig != "" {
+		quotas, err = quota.ReadConfig(*quotaConfig)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+	}
+
+	// Read the flaky task policy, if any.
+	var flakePolicy *flakes.Policy
+	if *flakePolicyFile != "" {
+		flakePolicy, err = flakes.ReadPolicy(*flakePolicyFile)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+	}
+
 	// Create and start the task scheduler.
 	sklog.Infof("Creating task scheduler.")
 	swarmingTaskExec := swarming_task_execution.NewSwarmingTaskExecutor(swarm, *rbeInstance, *pubsubTopicName)
@@ -167,7 +196,7 @@ func main() {
 		types.TaskExecutor_UseDefault: swarmingTaskExec,
 		types.TaskExecutor_Swarming:   swarmingTaskExec,
 	}
-	ts, err := scheduling.NewTaskScheduler(ctx, tsDb, skipTasks, period, *commitWindow, repos, cas, *rbeInstance, taskExecs, httpClient, *scoreDecay24Hr, *swarmingPools, *cdPool, *pubsubTopicName, taskCfgCache, tokenSource, diagClient, diagInstance, scheduling.BusyBotsDebugLog(*debugBusyBots))
+	ts, err := scheduling.NewTaskScheduler(ctx, tsDb, skipTasks, period, *commitWindow, repos, cas, *rbeInstance, taskExecs, httpClient, *scoreDecay24Hr, *swarmingPools, *cdPool, *pubsubTopicName, taskCfgCache, tokenSource, diagClient, diagInstance, scheduling.BusyBotsDebugLog(*debugBusyBots), quotas, flakePolicy)
 	if err != nil {
 		sklog.Fatal(err)
 	}
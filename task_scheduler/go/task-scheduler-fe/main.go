@@ -35,6 +35,8 @@ import (
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/task_scheduler/go/db"
 	"go.skia.org/infra/task_scheduler/go/db/firestore"
+	"go.skia.org/infra/task_scheduler/go/flakes"
+	"go.skia.org/infra/task_scheduler/go/quota"
 	"go.skia.org/infra/task_scheduler/go/rpc"
 	"go.skia.org/infra/task_scheduler/go/skip_tasks"
 	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
@@ -86,6 +88,8 @@ var (
 	resourcesDir      = flag.String("resources_dir", "", "The directory to find templates, JS, and CSS files. If blank, assumes you're running inside a checkout and will attempt to find the resources relative to this source file.")
 	swarmingServer    = flag.String("swarming_server", swarming.SWARMING_SERVER, "Which Swarming server to use.")
 	promPort          = flag.String("prom_port", ":20000", "Metrics service address (e.g., ':10110')")
+	quotaConfig       = flag.String("quota_config", "", "Optional JSON file containing fair-share quotas for tasks.")
+	flakePolicyFile   = flag.String("flake_policy", "", "Optional JSON file containing the policy for flaky tasks.")
 )
 
 func reloadTemplates() {
@@ -414,7 +418,25 @@ func main() {
 	}
 	plogin := proxylogin.NewWithDefaults()
 
-	srv := rpc.NewTaskSchedulerServer(ctx, tsDb, repos, skipTasks, taskCfgCache, swarm, plogin)
+	// Read fair-share quotas, if any.
+	var quotas *quota.Config
+	if *quotaConfig != "" {
+		quotas, err = quota.ReadConfig(*quotaConfig)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+	}
+
+	// Read the flaky task policy, if any.
+	var flakePolicy *flakes.Policy
+	if *flakePolicyFile != "" {
+		flakePolicy, err = flakes.ReadPolicy(*flakePolicyFile)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+	}
+
+	srv := rpc.NewTaskSchedulerServer(ctx, tsDb, repos, skipTasks, taskCfgCache, swarm, quotas, flakePolicy, plogin)
 	if err != nil {
 		sklog.Fatal(err)
 	}
@@ -23,6 +23,7 @@ import (
 	"go.skia.org/infra/go/depot_tools"
 	"go.skia.org/infra/go/gerrit"
 	"go.skia.org/infra/go/gitauth"
+	"go.skia.org/infra/go/gitiles"
 	"go.skia.org/infra/go/gitstore/bt_gitstore"
 	gs_pubsub "go.skia.org/infra/go/gitstore/pubsub"
 	"go.skia.org/infra/go/httputils"
@@ -148,6 +149,12 @@ func main() {
 	}
 	repos := autoUpdateRepos.Map
 
+	// GitStore doesn't record which files were changed by each commit, so use
+	// Gitiles to evaluate path regexes in JobSpecs and TaskSpecs.
+	for repoUrl, repo := range repos {
+		repo.SetChangedFilesGetter(gitiles.NewRepo(repoUrl, httpClient))
+	}
+
 	// Initialize Swarming client.
 	cfg := httputils.DefaultClientConfig().WithTokenSource(tokenSource).WithDialTimeout(time.Minute).With2xxOnly()
 	cfg.RequestTimeout = time.Minute
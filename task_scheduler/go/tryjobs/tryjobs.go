@@ -77,6 +77,10 @@ const (
 	// truncated cancel reason to Buildbucket to avoid exceeding limits in
 	// Buildbucket's DB.
 	maxCancelReasonLen = 1024
+
+	// Builds requested by the commit queue carry this tag.
+	cqTagKey   = "user_agent"
+	cqTagValue = "cq"
 )
 
 // TryJobIntegrator is responsible for communicating with Buildbucket to
@@ -467,6 +471,8 @@ func (t *TryJobIntegrator) insertNewJob(ctx context.Context, buildId int64) erro
 		j.IsForce = true
 	}
 
+	j.IsCQ = isCQBuild(build)
+
 	// Attempt to lease the build.
 	leaseKey, err := t.tryLeaseBuild(ctx, buildId)
 	if err != nil {
@@ -499,6 +505,17 @@ func (t *TryJobIntegrator) insertNewJob(ctx context.Context, buildId int64) erro
 	return nil
 }
 
+// isCQBuild returns true iff the given build was requested by the commit
+// queue.
+func isCQBuild(build *buildbucketpb.Build) bool {
+	for _, tag := range build.Tags {
+		if tag.Key == cqTagKey && tag.Value == cqTagValue {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *TryJobIntegrator) Poll(ctx context.Context) error {
 	if err := t.jCache.Update(ctx); err != nil {
 		return err
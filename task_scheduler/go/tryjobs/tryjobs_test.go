@@ -412,12 +412,15 @@ func TestRetry(t *testing.T) {
 	require.NotEqual(t, "", j1.BuildbucketLeaseKey)
 	require.True(t, j1.Valid())
 	require.False(t, j1.IsForce)
+	require.False(t, j1.IsCQ)
 	require.NoError(t, trybots.db.PutJobs(ctx, []*types.Job{j1}))
 	trybots.jCache.AddJobs([]*types.Job{j1})
 	require.NoError(t, trybots.jCache.Update(ctx))
 
-	// Obtain a second try job, ensure that it gets IsForce = true.
+	// Obtain a second try job, ensure that it gets IsForce = true. This one
+	// was requested by the commit queue.
 	b2 := Build(t, now)
+	b2.Tags = []*buildbucketpb.StringPair{{Key: cqTagKey, Value: cqTagValue}}
 	MockTryLeaseBuild(mock, b2.Id, nil)
 	MockJobStarted(mock, b2.Id, nil)
 	mockBB.On("GetBuild", ctx, b2.Id).Return(b2, nil)
@@ -429,6 +432,7 @@ func TestRetry(t *testing.T) {
 	require.NotEqual(t, "", j2.BuildbucketLeaseKey)
 	require.True(t, j2.Valid())
 	require.True(t, j2.IsForce)
+	require.True(t, j2.IsCQ)
 }
 
 func TestPoll(t *testing.T) {
@@ -130,11 +130,19 @@ type Job struct {
 	// opposed to a normally scheduled one, or a try job.
 	IsForce bool `json:"isForce"`
 
+	// IsCQ indicates whether this is a try job which was requested by the
+	// commit queue.
+	IsCQ bool `json:"isCq"`
+
 	// Name is a human-friendly descriptive name for the Job. All Jobs
 	// generated from the same JobSpec have the same name. This property
 	// should never change for a given Job instance.
 	Name string `json:"name"`
 
+	// PassedWithFlakes indicates that the Job succeeded, but only after
+	// retrying one or more failed tasks.
+	PassedWithFlakes bool `json:"passedWithFlakes"`
+
 	// Priority is an indicator of the relative priority of this Job.
 	Priority float64 `json:"priority"`
 
@@ -186,7 +194,9 @@ func (j *Job) Copy() *Job {
 		Finished:            j.Finished,
 		Id:                  j.Id,
 		IsForce:             j.IsForce,
+		IsCQ:                j.IsCQ,
 		Name:                j.Name,
+		PassedWithFlakes:    j.PassedWithFlakes,
 		Priority:            j.Priority,
 		RepoState:           j.RepoState.Copy(),
 		Requested:           j.Requested,
@@ -262,8 +272,14 @@ func (j *Job) DeriveStatus() JobStatus {
 		// We may have more than one Task for this spec, due to
 		// retrying of failed Tasks. We should not return a "failed"
 		// result if we still have retry attempts remaining or if we've
-		// already retried and succeeded.
-		maxAttempts := tasks[0].MaxAttempts
+		// already retried and succeeded. Later attempts may have been
+		// granted more attempts than the first, eg. due to flakiness.
+		maxAttempts := 0
+		for _, t := range tasks {
+			if t.MaxAttempts > maxAttempts {
+				maxAttempts = t.MaxAttempts
+			}
+		}
 		if maxAttempts == 0 {
 			maxAttempts = DEFAULT_MAX_TASK_ATTEMPTS
 		}
@@ -293,6 +309,22 @@ func (j *Job) DeriveStatus() JobStatus {
 	return worstStatus
 }
 
+// DeriveFlaky returns true iff the Job succeeded but one or more of its tasks
+// succeeded only after failed attempts.
+func (j *Job) DeriveFlaky() bool {
+	if j.Status != JOB_STATUS_SUCCESS {
+		return false
+	}
+	for _, tasks := range j.Tasks {
+		for _, t := range tasks {
+			if t.Status == TASK_STATUS_FAILURE || t.Status == TASK_STATUS_MISHAP {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // JobSlice implements sort.Interface. To sort jobs []*Job, use
 // sort.Sort(JobSlice(jobs)).
 type JobSlice []*Job
@@ -102,3 +102,32 @@ func TestJobDeriveStatus(t *testing.T) {
 	t3.Status = TASK_STATUS_SUCCESS
 	require.Equal(t, j1.DeriveStatus(), JOB_STATUS_SUCCESS)
 }
+
+func TestJobDeriveStatus_RetryGrantedMoreAttempts_StillInProgress(t *testing.T) {
+	t1 := &TaskSummary{Status: TASK_STATUS_FAILURE, MaxAttempts: 2}
+	t2 := &TaskSummary{Status: TASK_STATUS_FAILURE, MaxAttempts: 3}
+	j := &Job{
+		Dependencies: map[string][]string{"build": {}},
+		Tasks:        map[string][]*TaskSummary{"build": {t1, t2}},
+	}
+	require.Equal(t, JOB_STATUS_IN_PROGRESS, j.DeriveStatus())
+}
+
+func TestJobDeriveFlaky(t *testing.T) {
+	j := &Job{
+		Status: JOB_STATUS_SUCCESS,
+		Tasks: map[string][]*TaskSummary{
+			"build": {{Status: TASK_STATUS_SUCCESS}},
+			"test":  {{Status: TASK_STATUS_SUCCESS}},
+		},
+	}
+	require.False(t, j.DeriveFlaky())
+
+	// The test task succeeded on retry.
+	j.Tasks["test"] = []*TaskSummary{{Status: TASK_STATUS_MISHAP}, {Status: TASK_STATUS_SUCCESS}}
+	require.True(t, j.DeriveFlaky())
+
+	// Failed Jobs are not flaky.
+	j.Status = JOB_STATUS_FAILURE
+	require.False(t, j.DeriveFlaky())
+}
@@ -48,7 +48,9 @@ func MakeFullJob(now time.Time) *Job {
 		Finished:            now.Add(time.Second),
 		Id:                  "abc123",
 		IsForce:             true,
+		IsCQ:                true,
 		Name:                "C",
+		PassedWithFlakes:    true,
 		Priority:            1.2,
 		RepoState: RepoState{
 			Repo: DEFAULT_TEST_REPO,
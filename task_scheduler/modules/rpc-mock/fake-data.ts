@@ -191,6 +191,7 @@ export const job1: Job = {
   finishedAt: new Date('2019-02-19T13:32:46.274182Z').toString(),
   id: task2.jobs![0],
   isForce: false,
+  passedWithFlakes: false,
   name: task2.taskKey!.name,
   priority: '0.8', // TODO: Why is this a string??
   repoState: repoState,
@@ -308,6 +309,7 @@ export const job2: Job = {
   finishedAt: '0001-01-01T00:00:00Z',
   id: job2ID,
   isForce: false,
+  passedWithFlakes: false,
   name: 'ABCDEF',
   priority: '0.8',
   repoState: repoState,
@@ -7,6 +7,10 @@ import {
   DeleteSkipTaskRuleResponse,
   GetJobRequest,
   GetJobResponse,
+  GetQuotaUsageRequest,
+  GetQuotaUsageResponse,
+  GetFlakeStatsRequest,
+  GetFlakeStatsResponse,
   GetTaskRequest,
   GetTaskResponse,
   GetSkipTaskRulesRequest,
@@ -173,4 +177,12 @@ export class FakeTaskSchedulerService implements TaskSchedulerService {
     );
     return Promise.resolve({ rules: this.skipRules.slice() });
   }
+
+  getQuotaUsage(_: GetQuotaUsageRequest): Promise<GetQuotaUsageResponse> {
+    return Promise.resolve({ usage: [] });
+  }
+
+  getFlakeStats(_: GetFlakeStatsRequest): Promise<GetFlakeStatsResponse> {
+    return Promise.resolve({ stats: [] });
+  }
 }
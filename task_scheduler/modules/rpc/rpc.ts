@@ -428,6 +428,126 @@ const JSONToDeleteSkipTaskRuleResponse = (m: DeleteSkipTaskRuleResponseJSON): De
   };
 };
 
+export interface GetQuotaUsageRequest {
+}
+
+interface GetQuotaUsageRequestJSON {
+}
+
+const GetQuotaUsageRequestToJSON = (m: GetQuotaUsageRequest): GetQuotaUsageRequestJSON => {
+  return {
+  };
+};
+
+export interface QuotaUsage {
+  quota: string;
+  repo: string;
+  jobClass: string;
+  pool: string;
+  running: number;
+  capacity: number;
+  min: number;
+  max: number;
+}
+
+interface QuotaUsageJSON {
+  quota?: string;
+  repo?: string;
+  job_class?: string;
+  pool?: string;
+  running?: number;
+  capacity?: number;
+  min?: number;
+  max?: number;
+}
+
+const JSONToQuotaUsage = (m: QuotaUsageJSON): QuotaUsage => {
+  return {
+    quota: m.quota || "",
+    repo: m.repo || "",
+    jobClass: m.job_class || "",
+    pool: m.pool || "",
+    running: m.running || 0,
+    capacity: m.capacity || 0,
+    min: m.min || 0,
+    max: m.max || 0,
+  };
+};
+
+export interface GetQuotaUsageResponse {
+  usage?: QuotaUsage[];
+}
+
+interface GetQuotaUsageResponseJSON {
+  usage?: QuotaUsageJSON[];
+}
+
+const JSONToGetQuotaUsageResponse = (m: GetQuotaUsageResponseJSON): GetQuotaUsageResponse => {
+  return {
+    usage: m.usage && m.usage.map(JSONToQuotaUsage),
+  };
+};
+
+export interface GetFlakeStatsRequest {
+  repo: string;
+}
+
+interface GetFlakeStatsRequestJSON {
+  repo?: string;
+}
+
+const GetFlakeStatsRequestToJSON = (m: GetFlakeStatsRequest): GetFlakeStatsRequestJSON => {
+  return {
+    repo: m.repo,
+  };
+};
+
+export interface FlakeStats {
+  repo: string;
+  taskName: string;
+  runs: number;
+  failures: number;
+  flakes: number;
+  flakeRate: string;
+  quarantined: boolean;
+}
+
+interface FlakeStatsJSON {
+  repo?: string;
+  task_name?: string;
+  runs?: number;
+  failures?: number;
+  flakes?: number;
+  flake_rate?: string;
+  quarantined?: boolean;
+}
+
+const JSONToFlakeStats = (m: FlakeStatsJSON): FlakeStats => {
+  return {
+    repo: m.repo || "",
+    taskName: m.task_name || "",
+    runs: m.runs || 0,
+    failures: m.failures || 0,
+    flakes: m.flakes || 0,
+    flakeRate: m.flake_rate || "",
+    quarantined: m.quarantined || false,
+  };
+};
+
+export interface GetFlakeStatsResponse {
+  stats?: FlakeStats[];
+}
+
+interface GetFlakeStatsResponseJSON {
+  stats?: FlakeStatsJSON[];
+}
+
+const JSONToGetFlakeStatsResponse = (m: GetFlakeStatsResponseJSON): GetFlakeStatsResponse => {
+  return {
+    stats: m.stats && m.stats.map(JSONToFlakeStats),
+  };
+};
+
 export interface RepoState_Patch {
   issue: string;
   patchRepo: string;
@@ -677,6 +797,7 @@ export interface Job {
   status: JobStatus;
   tasks?: TaskSummaries[];
   taskDimensions?: TaskDimensions[];
+  passedWithFlakes: boolean;
 }
 
 interface JobJSON {
@@ -695,6 +816,7 @@ interface JobJSON {
   status?: string;
   tasks?: TaskSummariesJSON[];
   task_dimensions?: TaskDimensionsJSON[];
+  passed_with_flakes?: boolean;
 }
 
 const JSONToJob = (m: JobJSON): Job => {
@@ -714,6 +836,7 @@ const JSONToJob = (m: JobJSON): Job => {
     status: (m.status || Object.keys(JobStatus)[0]) as JobStatus,
     tasks: m.tasks && m.tasks.map(JSONToTaskSummaries),
     taskDimensions: m.task_dimensions && m.task_dimensions.map(JSONToTaskDimensions),
+    passedWithFlakes: m.passed_with_flakes || false,
   };
 };
 
@@ -727,6 +850,8 @@ export interface TaskSchedulerService {
   getSkipTaskRules: (getSkipTaskRulesRequest: GetSkipTaskRulesRequest) => Promise<GetSkipTaskRulesResponse>;
   addSkipTaskRule: (addSkipTaskRuleRequest: AddSkipTaskRuleRequest) => Promise<AddSkipTaskRuleResponse>;
   deleteSkipTaskRule: (deleteSkipTaskRuleRequest: DeleteSkipTaskRuleRequest) => Promise<DeleteSkipTaskRuleResponse>;
+  getQuotaUsage: (getQuotaUsageRequest: GetQuotaUsageRequest) => Promise<GetQuotaUsageResponse>;
+  getFlakeStats: (getFlakeStatsRequest: GetFlakeStatsRequest) => Promise<GetFlakeStatsResponse>;
 }
 
 export class TaskSchedulerServiceClient implements TaskSchedulerService {
@@ -877,4 +1002,34 @@ export class TaskSchedulerServiceClient implements TaskSchedulerService {
       return resp.json().then(JSONToDeleteSkipTaskRuleResponse);
     });
   }
+
+  getQuotaUsage(getQuotaUsageRequest: GetQuotaUsageRequest): Promise<GetQuotaUsageResponse> {
+    const url = this.hostname + this.pathPrefix + "GetQuotaUsage";
+    let body: GetQuotaUsageRequest | GetQuotaUsageRequestJSON = getQuotaUsageRequest;
+    if (!this.writeCamelCase) {
+      body = GetQuotaUsageRequestToJSON(getQuotaUsageRequest);
+    }
+    return this.fetch(createTwirpRequest(url, body, this.optionsOverride)).then((resp) => {
+      if (!resp.ok) {
+        return throwTwirpError(resp);
+      }
+
+      return resp.json().then(JSONToGetQuotaUsageResponse);
+    });
+  }
+
+  getFlakeStats(getFlakeStatsRequest: GetFlakeStatsRequest): Promise<GetFlakeStatsResponse> {
+    const url = this.hostname + this.pathPrefix + "GetFlakeStats";
+    let body: GetFlakeStatsRequest | GetFlakeStatsRequestJSON = getFlakeStatsRequest;
+    if (!this.writeCamelCase) {
+      body = GetFlakeStatsRequestToJSON(getFlakeStatsRequest);
+    }
+    return this.fetch(createTwirpRequest(url, body, this.optionsOverride)).then((resp) => {
+      if (!resp.ok) {
+        return throwTwirpError(resp);
+      }
+
+      return resp.json().then(JSONToGetFlakeStatsResponse);
+    });
+  }
 }